@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/middleware"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/services"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+)
+
+// apiKeyTouchInterval limits last_used_at writes to one per key per interval.
+const apiKeyTouchInterval = time.Minute
+
+// apiKeyAuthenticator authenticates requests carrying a personal API key as the key's owner.
+type apiKeyAuthenticator struct {
+	keys    store.APIKeyStore
+	hasher  *services.APIKeyService
+	limiter *middleware.RateLimiter
+	// userActive reports whether the key's owner may still sign in; nil skips the check
+	userActive func(ctx context.Context, userID uuid.UUID) (bool, error)
+	now        func() time.Time
+}
+
+// bearerAPIKey returns the personal API key from an `Authorization: Bearer` header. Bearer tokens
+// without the key prefix are not ours and are left to the other authentication paths.
+func bearerAPIKey(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(r.Header.Get("Authorization")), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, strings.HasPrefix(token, services.APIKeyPrefix)
+}
+
+// apiKeyScopeFor maps a request method to the scope it needs.
+func apiKeyScopeFor(method string) string {
+	if method == http.MethodGet || method == http.MethodHead {
+		return models.APIKeyScopeRead
+	}
+	return models.APIKeyScopeWrite
+}
+
+// apiKeySessionOnlyPrefixes are the API areas a personal API key may not use: account security and
+// user administration need a signed-in session, so a leaked key cannot change the password, manage MFA,
+// mint further keys or change roles. Endpoints added under these paths are refused until listed in
+// apiKeyAllowedOperations.
+var apiKeySessionOnlyPrefixes = []string{"/api/v2/auth/", "/api/v2/users/"}
+
+// apiKeySessionOnlyPatterns are further session-only paths, matched with path.Match: granting campaign
+// access from a key would let its holder share the owner's campaigns with an account they control.
+var apiKeySessionOnlyPatterns = []string{"/api/v2/campaigns/*/access", "/api/v2/campaigns/*/access/*"}
+
+// apiKeyAllowedOperations are the operations under apiKeySessionOnlyPrefixes that API keys may call.
+var apiKeyAllowedOperations = map[string]bool{
+	"AuthMe": true,
+}
+
+// apiKeyMayCall reports whether a personal API key may be used for operationID at r's path.
+func apiKeyMayCall(r *http.Request, operationID string) bool {
+	if apiKeyAllowedOperations[operationID] {
+		return true
+	}
+	p := path.Clean("/" + r.URL.Path)
+	for _, prefix := range apiKeySessionOnlyPrefixes {
+		if strings.HasPrefix(p+"/", prefix) {
+			return false
+		}
+	}
+	for _, pattern := range apiKeySessionOnlyPatterns {
+		if ok, _ := path.Match(pattern, p); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// authenticate validates key for operationID and returns ctx carrying the owner as user_id. When the
+// key is rejected (unknown, revoked, expired, out of scope, used for a session-only operation or rate
+// limited) the error response has already been written to w and ok is false.
+func (a *apiKeyAuthenticator) authenticate(ctx context.Context, w http.ResponseWriter, r *http.Request, key, operationID string) (context.Context, bool) {
+	ip := clientIP(r)
+	k, err := a.keys.GetAPIKeyByHash(ctx, nil, a.hasher.HashAPIKey(key))
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			log.Printf("api key lookup failed: %v", err)
+			writeAPIKeyError(w, r, http.StatusInternalServerError, gen.INTERNALSERVERERROR, "failed to verify API key")
+			return ctx, false
+		}
+		logAuthEvent("API_KEY_AUTH_FAILURE", nil, ip, "unknown_key", nil)
+		writeAPIKeyError(w, r, http.StatusUnauthorized, gen.UNAUTHORIZED, "invalid API key")
+		return ctx, false
+	}
+	if !k.Usable(a.now()) {
+		reason := "expired"
+		if k.RevokedAt.Valid {
+			reason = "revoked"
+		}
+		logAuthEvent("API_KEY_AUTH_FAILURE", &k.UserID, ip, reason, map[string]interface{}{"api_key_id": k.ID})
+		writeAPIKeyError(w, r, http.StatusUnauthorized, gen.UNAUTHORIZED, "API key "+reason)
+		return ctx, false
+	}
+	if a.userActive != nil {
+		active, err := a.userActive(ctx, k.UserID)
+		if err != nil {
+			writeAPIKeyError(w, r, http.StatusInternalServerError, gen.INTERNALSERVERERROR, "failed to verify API key")
+			return ctx, false
+		}
+		if !active {
+			logAuthEvent("API_KEY_AUTH_FAILURE", &k.UserID, ip, "account_disabled", map[string]interface{}{"api_key_id": k.ID})
+			writeAPIKeyError(w, r, http.StatusUnauthorized, gen.UNAUTHORIZED, "account disabled")
+			return ctx, false
+		}
+	}
+	if !apiKeyMayCall(r, operationID) {
+		logAuthEvent("API_KEY_AUTH_FAILURE", &k.UserID, ip, "session_only_operation", map[string]interface{}{"api_key_id": k.ID, "operation": operationID})
+		writeAPIKeyError(w, r, http.StatusForbidden, gen.FORBIDDEN, "this operation requires a signed-in session")
+		return ctx, false
+	}
+	if scope := apiKeyScopeFor(r.Method); !k.HasScope(scope) {
+		writeAPIKeyError(w, r, http.StatusForbidden, gen.FORBIDDEN, "API key lacks the "+scope+" scope")
+		return ctx, false
+	}
+	if a.limiter != nil && !a.limiter.Allow(k.ID.String(), "api_key") {
+		a.limiter.RespondRateLimited(w, r)
+		return ctx, false
+	}
+
+	go func(id uuid.UUID) {
+		tctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := a.keys.TouchAPIKey(tctx, nil, id, ip, apiKeyTouchInterval); err != nil {
+			log.Printf("api key last-used update failed: %v", err)
+		}
+	}(k.ID)
+
+	ctx = context.WithValue(ctx, "user_id", k.UserID.String())
+	ctx = context.WithValue(ctx, "api_key_id", k.ID.String())
+	ctx = context.WithValue(ctx, "auth_method", "api_key")
+	return ctx, true
+}
+
+func writeAPIKeyError(w http.ResponseWriter, r *http.Request, status int, code gen.ErrorCode, message string) {
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	}
+	writeErrorEnvelope(w, r, status, code, message)
+}
+
+// writeErrorEnvelope writes the standard error envelope from middleware that answers before the handler runs.
+func writeErrorEnvelope(w http.ResponseWriter, r *http.Request, status int, code gen.ErrorCode, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(gen.ErrorEnvelope{
+		Error:     gen.ApiError{Message: message, Code: code, Timestamp: time.Now()},
+		RequestId: requestID(r),
+		Success:   boolPtr(false),
+	})
+}
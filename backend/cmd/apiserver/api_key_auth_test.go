@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/middleware"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/services"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type fakeAPIKeyStore struct {
+	store.APIKeyStore
+	mu      sync.Mutex
+	byHash  map[string]*models.APIKey
+	touched int
+}
+
+func (f *fakeAPIKeyStore) GetAPIKeyByHash(_ context.Context, _ store.Querier, keyHash string) (*models.APIKey, error) {
+	if k, ok := f.byHash[keyHash]; ok {
+		return k, nil
+	}
+	return nil, store.ErrNotFound
+}
+
+func (f *fakeAPIKeyStore) TouchAPIKey(context.Context, store.Querier, uuid.UUID, string, time.Duration) error {
+	f.mu.Lock()
+	f.touched++
+	f.mu.Unlock()
+	return nil
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	svc := services.NewAPIKeyService(nil)
+	now := time.Now()
+	userID := uuid.New()
+	fake := &fakeAPIKeyStore{byHash: map[string]*models.APIKey{}}
+	issue := func(scopes []string, mutate func(*models.APIKey)) string {
+		issued, err := svc.CreateAPIKey(userID.String(), "ci", nil)
+		if err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+		k := &models.APIKey{ID: issued.ID, UserID: userID, KeyHash: issued.KeyHash, Scopes: pq.StringArray(scopes)}
+		if mutate != nil {
+			mutate(k)
+		}
+		fake.byHash[issued.KeyHash] = k
+		return issued.Key
+	}
+	readWrite := issue([]string{models.APIKeyScopeRead, models.APIKeyScopeWrite}, nil)
+	readOnly := issue([]string{models.APIKeyScopeRead}, nil)
+	revoked := issue([]string{models.APIKeyScopeWrite}, func(k *models.APIKey) { k.RevokedAt = sql.NullTime{Time: now, Valid: true} })
+	expired := issue([]string{models.APIKeyScopeWrite}, func(k *models.APIKey) { k.ExpiresAt = sql.NullTime{Time: now.Add(-time.Hour), Valid: true} })
+
+	auth := &apiKeyAuthenticator{
+		keys:    fake,
+		hasher:  svc,
+		limiter: middleware.NewRateLimiter(nil, &middleware.RateLimitConfig{APIKeyMaxRequests: 3, APIKeyWindow: time.Minute}),
+		now:     func() time.Time { return now },
+	}
+
+	cases := []struct {
+		name, method, path, key, op string
+		want                        int
+	}{
+		{"read-write key may write", http.MethodPost, "/api/v2/campaigns", readWrite, "CampaignsCreate", http.StatusOK},
+		{"read-only key may read", http.MethodGet, "/api/v2/campaigns", readOnly, "CampaignsList", http.StatusOK},
+		{"read-only key may not write", http.MethodDelete, "/api/v2/campaigns/x", readOnly, "CampaignsDelete", http.StatusForbidden},
+		{"key may read its owner", http.MethodGet, "/api/v2/auth/me", readOnly, "AuthMe", http.StatusOK},
+		{"key may not change the password", http.MethodPost, "/api/v2/auth/change-password", readWrite, "AuthChangePassword", http.StatusForbidden},
+		{"key may not disable MFA", http.MethodPost, "/api/v2/auth/mfa/disable", readWrite, "AuthMfaDisable", http.StatusForbidden},
+		{"key may not list keys", http.MethodGet, "/api/v2/auth/api-keys", readOnly, "AuthApiKeysList", http.StatusForbidden},
+		{"unlisted auth endpoints are refused", http.MethodPost, "/api/v2/auth/something-new", readWrite, "AuthSomethingNew", http.StatusForbidden},
+		{"key may not change roles", http.MethodPut, "/api/v2/users/" + userID.String() + "/role", readWrite, "UsersRoleUpdate", http.StatusForbidden},
+		{"key may not share campaigns", http.MethodPut, "/api/v2/campaigns/c1/access/" + userID.String(), readWrite, "CampaignsAccessGrant", http.StatusForbidden},
+		{"key may not list campaign access", http.MethodGet, "/api/v2/campaigns/c1/access", readOnly, "CampaignsAccessList", http.StatusForbidden},
+		{"revoked key", http.MethodGet, "/api/v2/campaigns", revoked, "CampaignsList", http.StatusUnauthorized},
+		{"expired key", http.MethodGet, "/api/v2/campaigns", expired, "CampaignsList", http.StatusUnauthorized},
+		{"unknown key", http.MethodGet, "/api/v2/campaigns", services.APIKeyPrefix + "deadbeef", "CampaignsList", http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		req.Header.Set("Authorization", "Bearer "+tc.key)
+		key, ok := bearerAPIKey(req)
+		if !ok {
+			t.Fatalf("%s: expected a personal API key in the header", tc.name)
+		}
+		rec := httptest.NewRecorder()
+		ctx, ok := auth.authenticate(context.Background(), rec, req, key, tc.op)
+		got := rec.Code
+		if ok {
+			if uid, _ := sessionUserID(ctx); uid != userID || ctx.Value("auth_method") != "api_key" {
+				t.Errorf("%s: expected the key owner in context", tc.name)
+			}
+		}
+		if (ok && tc.want != http.StatusOK) || (!ok && got != tc.want) {
+			t.Errorf("%s: ok=%v status=%d, want %d", tc.name, ok, got, tc.want)
+		}
+	}
+
+	// The limit is per key and rejected requests are not counted: readWrite has two requests left.
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/api/v2/campaigns", nil)
+		if _, ok := auth.authenticate(context.Background(), httptest.NewRecorder(), req, readWrite, "CampaignsList"); !ok {
+			t.Errorf("request %d: expected to be within the limit", i)
+		}
+	}
+	rec := httptest.NewRecorder()
+	if _, ok := auth.authenticate(context.Background(), rec, httptest.NewRequest(http.MethodGet, "/", nil), readWrite, "CampaignsList"); ok || rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429 once the key is over its limit, got %d", rec.Code)
+	}
+	if _, ok := auth.authenticate(context.Background(), httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), readOnly, "CampaignsList"); !ok {
+		t.Error("expected another key to be unaffected by the limit")
+	}
+}
+
+func TestBearerAPIKeyIgnoresOtherTokens(t *testing.T) {
+	for header, want := range map[string]bool{
+		"Bearer " + services.APIKeyPrefix + "abc": true,
+		"bearer " + services.APIKeyPrefix + "abc": true,
+		"Bearer eyJhbGciOiJIUzI1NiJ9.e30.x":       false,
+		"Basic dXNlcjpwYXNz":                      false,
+		"":                                        false,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", header)
+		if _, ok := bearerAPIKey(req); ok != want {
+			t.Errorf("bearerAPIKey(%q) = %v, want %v", header, ok, want)
+		}
+	}
+}
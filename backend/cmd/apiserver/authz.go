@@ -0,0 +1,329 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// authzDecisionBuffer bounds decisions waiting to be written; beyond it decisions are dropped
+// rather than slowing requests down.
+const authzDecisionBuffer = 1024
+
+// authzRule is what one request needs: a minimum role and, for campaign-scoped requests, an access
+// level on each campaign it names.
+type authzRule struct {
+	resource    string // authorization_resource_type_enum value
+	minRole     string
+	campaignIDs []uuid.UUID
+	level       string
+	// bulkOperationID names a bulk operation whose task campaigns the authorizer adds to campaignIDs
+	bulkOperationID uuid.UUID
+}
+
+// policy names the rule for the decision log, e.g. "role>=operator;campaign>=control".
+func (r authzRule) policy() string {
+	p := "role>=" + r.minRole
+	if len(r.campaignIDs) > 0 {
+		p += ";campaign>=" + r.level
+	}
+	return p
+}
+
+func (r authzRule) permissions() pq.StringArray {
+	perms := pq.StringArray{"role:" + r.minRole}
+	if len(r.campaignIDs) > 0 {
+		perms = append(perms, "campaign:"+r.level)
+	}
+	return perms
+}
+
+// authzRuleFor maps a strict-handler request to its rule; ok is false for operations that are not
+// subject to role or campaign checks. Reads need the viewer role and read access; writes need the
+// operator role (admin under /config) and control access. /users is admin only. Deleting a campaign
+// and managing its shares need admin access on it. A bulk operation is checked against the campaigns
+// of its tasks.
+func authzRuleFor(operationID, method, path string, req interface{}) (authzRule, bool) {
+	path = strings.TrimPrefix(path, "/api/v2")
+	read := method == http.MethodGet || method == http.MethodHead
+	rule := authzRule{minRole: models.RoleViewer, level: models.CampaignAccessRead, campaignIDs: campaignIDsOf(req)}
+	writeRole := models.RoleOperator
+	switch {
+	case hasPathPrefix(path, "/users"):
+		return authzRule{resource: "user_management", minRole: models.RoleAdmin}, true
+	case hasPathPrefix(path, "/config"):
+		rule.resource, writeRole = "system_settings", models.RoleAdmin
+	case hasPathPrefix(path, "/personas"):
+		rule.resource = "persona"
+	case hasPathPrefix(path, "/proxy-pools"):
+		rule.resource = "proxy_pool"
+	case hasPathPrefix(path, "/proxies"):
+		rule.resource = "proxy"
+	case hasPathPrefix(path, "/campaigns"), len(rule.campaignIDs) > 0:
+		rule.resource = "campaign"
+	default:
+		return authzRule{}, false
+	}
+	if rule.resource != "campaign" {
+		rule.campaignIDs = nil
+		if !read {
+			rule.minRole = writeRole
+		}
+		return rule, true
+	}
+
+	switch r := req.(type) {
+	case gen.GetBulkOperationStatusRequestObject:
+		rule.bulkOperationID = r.OperationId
+	case gen.CancelBulkOperationRequestObject:
+		rule.bulkOperationID = r.OperationId
+	}
+	switch operationID {
+	case "CampaignsDelete", "CampaignsAccessList", "CampaignsAccessGrant", "CampaignsAccessRevoke":
+		rule.level = models.CampaignAccessAdmin
+	case "CampaignsDuplicatePost":
+		// Copying needs only read on the source, but creates a campaign
+		rule.minRole = models.RoleOperator
+	default:
+		if !read {
+			rule.level = models.CampaignAccessControl
+			if len(rule.campaignIDs) == 0 {
+				rule.minRole = models.RoleOperator
+			}
+		}
+	}
+	return rule, true
+}
+
+func hasPathPrefix(path, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// campaignIDsOf returns the campaigns a request names: its campaignId path parameter plus any
+// campaignId / campaignIds fields in its JSON body (bulk operations).
+func campaignIDsOf(req interface{}) []uuid.UUID {
+	v := reflect.ValueOf(req)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	var ids []uuid.UUID
+	seen := map[uuid.UUID]bool{}
+	add := func(id uuid.UUID) {
+		if id != uuid.Nil && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if f := v.FieldByName("CampaignId"); f.IsValid() {
+		if id, ok := f.Interface().(uuid.UUID); ok {
+			add(id)
+		}
+	}
+	if f := v.FieldByName("Body"); f.IsValid() && !(f.Kind() == reflect.Ptr && f.IsNil()) {
+		if raw, err := json.Marshal(f.Interface()); err == nil {
+			var body interface{}
+			if json.Unmarshal(raw, &body) == nil {
+				walkCampaignIDs(body, add)
+			}
+		}
+	}
+	return ids
+}
+
+func walkCampaignIDs(node interface{}, add func(uuid.UUID)) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, val := range n {
+			switch k {
+			case "campaignId":
+				if s, ok := val.(string); ok {
+					if id, err := uuid.Parse(s); err == nil {
+						add(id)
+					}
+				}
+			case "campaignIds":
+				if list, ok := val.([]interface{}); ok {
+					for _, item := range list {
+						if s, ok := item.(string); ok {
+							if id, err := uuid.Parse(s); err == nil {
+								add(id)
+							}
+						}
+					}
+				}
+			default:
+				walkCampaignIDs(val, add)
+			}
+		}
+	case []interface{}:
+		for _, item := range n {
+			walkCampaignIDs(item, add)
+		}
+	}
+}
+
+// requestAuthorizer enforces user roles and campaign grants in front of the strict handlers and
+// records every decision in authorization_decisions.
+type requestAuthorizer struct {
+	store     store.AuthorizationStore
+	bulkOps   store.BulkOperationStore
+	decisions chan *models.AuthorizationDecision
+}
+
+func newRequestAuthorizer(s store.AuthorizationStore, bulkOps store.BulkOperationStore) *requestAuthorizer {
+	a := &requestAuthorizer{store: s, bulkOps: bulkOps, decisions: make(chan *models.AuthorizationDecision, authzDecisionBuffer)}
+	go a.recordDecisions()
+	return a
+}
+
+// evaluate decides rule for userID holding role. reason explains a denial.
+func (a *requestAuthorizer) evaluate(ctx context.Context, userID uuid.UUID, role string, rule authzRule) (allowed bool, reason string, err error) {
+	if !models.RoleAtLeast(role, rule.minRole) {
+		return false, "requires the " + rule.minRole + " role", nil
+	}
+	if role == models.RoleAdmin {
+		return true, "admin role", nil
+	}
+	for _, id := range rule.campaignIDs {
+		access, err := a.store.GetCampaignAccess(ctx, nil, id, userID)
+		if errors.Is(err, store.ErrNotFound) {
+			continue // the handler answers 404
+		}
+		if err != nil {
+			return false, "", err
+		}
+		if !models.CampaignAccessAtLeast(access.Level(userID, role), rule.level) {
+			return false, "requires " + rule.level + " access to campaign " + id.String(), nil
+		}
+	}
+	return true, "role " + role, nil
+}
+
+// middleware is a strict middleware; it must run inside authCtx so user_id is already resolved.
+// Allowed requests carry the caller's role as user_role.
+func (a *requestAuthorizer) middleware(next gen.StrictHandlerFunc, operationID string) gen.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, req interface{}) (interface{}, error) {
+		rule, ok := authzRuleFor(operationID, r.Method, r.URL.Path, req)
+		if !ok {
+			return next(ctx, w, r, req)
+		}
+		start := time.Now()
+		d := &models.AuthorizationDecision{
+			ResourceType:       rule.resource,
+			Action:             operationID,
+			Decision:           models.AuthorizationDeny,
+			RequestMethod:      r.Method,
+			RequestPath:        r.URL.Path,
+			PolicyEvaluated:    rule.policy(),
+			PermissionsChecked: rule.permissions(),
+		}
+		if len(rule.campaignIDs) > 0 {
+			d.ResourceID = uuid.NullUUID{UUID: rule.campaignIDs[0], Valid: true}
+		}
+		if ip := net.ParseIP(clientIP(r)); ip != nil {
+			d.IPAddress.String, d.IPAddress.Valid = ip.String(), true
+		}
+		if ua := r.UserAgent(); ua != "" {
+			d.UserAgent.String, d.UserAgent.Valid = ua, true
+		}
+		finish := func(reason string) {
+			d.Reason = reason
+			d.EvaluationTimeMs = int(time.Since(start).Milliseconds())
+			a.record(d)
+		}
+
+		userID, ok := sessionUserID(ctx)
+		if !ok {
+			finish("unauthenticated")
+			writeErrorEnvelope(w, r, http.StatusUnauthorized, gen.UNAUTHORIZED, "authentication required")
+			return nil, nil
+		}
+		d.UserID = uuid.NullUUID{UUID: userID, Valid: true}
+		role, err := a.store.GetUserRole(ctx, nil, userID)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			log.Printf("authz: role lookup failed for %s: %v", userID, err)
+			writeErrorEnvelope(w, r, http.StatusInternalServerError, gen.INTERNALSERVERERROR, "failed to check permissions")
+			return nil, nil
+		}
+		if rule.bulkOperationID != uuid.Nil && role != models.RoleAdmin {
+			if err := a.addBulkOperationCampaigns(ctx, &rule); err != nil {
+				log.Printf("authz: %s task lookup failed for %s: %v", operationID, userID, err)
+				writeErrorEnvelope(w, r, http.StatusInternalServerError, gen.INTERNALSERVERERROR, "failed to check permissions")
+				return nil, nil
+			}
+			d.PolicyEvaluated, d.PermissionsChecked = rule.policy(), rule.permissions()
+		}
+		allowed, reason, err := a.evaluate(ctx, userID, role, rule)
+		if err != nil {
+			log.Printf("authz: %s evaluation failed for %s: %v", operationID, userID, err)
+			writeErrorEnvelope(w, r, http.StatusInternalServerError, gen.INTERNALSERVERERROR, "failed to check permissions")
+			return nil, nil
+		}
+		if !allowed {
+			finish(reason)
+			writeErrorEnvelope(w, r, http.StatusForbidden, gen.FORBIDDEN, "forbidden: "+reason)
+			return nil, nil
+		}
+		d.Decision = models.AuthorizationAllow
+		finish(reason)
+		return next(context.WithValue(ctx, "user_role", role), w, r, req)
+	}
+}
+
+// addBulkOperationCampaigns adds the campaigns of the bulk operation's tasks to rule. An unknown
+// operation adds none and is left to the handler.
+func (a *requestAuthorizer) addBulkOperationCampaigns(ctx context.Context, rule *authzRule) error {
+	if a.bulkOps == nil {
+		return errors.New("bulk operation store not initialized")
+	}
+	tasks, err := a.bulkOps.ListBulkOperationTasks(ctx, nil, rule.bulkOperationID)
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		rule.campaignIDs = append(rule.campaignIDs, t.CampaignID)
+	}
+	return nil
+}
+
+func (a *requestAuthorizer) record(d *models.AuthorizationDecision) {
+	if len(d.Action) > 50 {
+		d.Action = d.Action[:50]
+	}
+	select {
+	case a.decisions <- d:
+	default:
+		log.Printf("authz: decision log full, dropping %s decision for %s", d.Decision, d.Action)
+	}
+}
+
+func (a *requestAuthorizer) recordDecisions() {
+	for d := range a.decisions {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := a.store.RecordAuthorizationDecision(ctx, nil, d); err != nil {
+			log.Printf("authz: failed to record decision: %v", err)
+		}
+		cancel()
+	}
+}
+
+// requestRole returns the caller's role as resolved by the authorization middleware.
+func requestRole(ctx context.Context) string {
+	role, _ := ctx.Value("user_role").(string)
+	return role
+}
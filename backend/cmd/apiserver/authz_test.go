@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type fakeAuthorizationStore struct {
+	store.AuthorizationStore
+	roles  map[uuid.UUID]string
+	access map[uuid.UUID]*models.CampaignAccess // keyed by campaign; grants apply to grantee
+	grants map[[2]uuid.UUID][]string            // {campaign, user} -> access types
+}
+
+func (f *fakeAuthorizationStore) GetUserRole(_ context.Context, _ store.Querier, userID uuid.UUID) (string, error) {
+	if role, ok := f.roles[userID]; ok {
+		return role, nil
+	}
+	return "", store.ErrNotFound
+}
+
+func (f *fakeAuthorizationStore) GetCampaignAccess(_ context.Context, _ store.Querier, campaignID, userID uuid.UUID) (*models.CampaignAccess, error) {
+	a, ok := f.access[campaignID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return &models.CampaignAccess{OwnerID: a.OwnerID, GrantTypes: pq.StringArray(f.grants[[2]uuid.UUID{campaignID, userID}])}, nil
+}
+
+type fakeBulkOperationStore struct {
+	store.BulkOperationStore
+	tasks map[uuid.UUID][]uuid.UUID // operation -> task campaigns
+}
+
+func (f *fakeBulkOperationStore) ListBulkOperationTasks(_ context.Context, _ store.Querier, operationID uuid.UUID) ([]*models.BulkOperationTask, error) {
+	tasks := []*models.BulkOperationTask{}
+	for _, id := range f.tasks[operationID] {
+		tasks = append(tasks, &models.BulkOperationTask{OperationID: operationID, CampaignID: id})
+	}
+	return tasks, nil
+}
+
+func TestRequestAuthorizer(t *testing.T) {
+	admin, owner, operator, viewer := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	campaign, other := uuid.New(), uuid.New()
+	fake := &fakeAuthorizationStore{
+		roles: map[uuid.UUID]string{admin: models.RoleAdmin, owner: models.RoleOperator, operator: models.RoleOperator, viewer: models.RoleViewer},
+		access: map[uuid.UUID]*models.CampaignAccess{
+			campaign: {OwnerID: uuid.NullUUID{UUID: owner, Valid: true}},
+			other:    {OwnerID: uuid.NullUUID{UUID: admin, Valid: true}},
+		},
+		grants: map[[2]uuid.UUID][]string{
+			{campaign, operator}: {"write"},
+			{campaign, viewer}:   {"write"},
+		},
+	}
+	sharedOp, foreignOp := uuid.New(), uuid.New()
+	bulk := &fakeBulkOperationStore{tasks: map[uuid.UUID][]uuid.UUID{sharedOp: {campaign}, foreignOp: {campaign, other}}}
+	a := &requestAuthorizer{store: fake, bulkOps: bulk, decisions: make(chan *models.AuthorizationDecision, 64)}
+
+	cases := []struct {
+		name        string
+		user        uuid.UUID
+		operationID string
+		method      string
+		path        string
+		req         interface{}
+		wantStatus  int
+	}{
+		{"owner deletes own campaign", owner, "CampaignsDelete", http.MethodDelete, "/api/v2/campaigns/" + campaign.String(), gen.CampaignsDeleteRequestObject{CampaignId: campaign}, http.StatusOK},
+		{"control grantee starts a phase", operator, "CampaignsPhaseStart", http.MethodPost, "/api/v2/campaigns/" + campaign.String() + "/phases/dns_validation/start", gen.CampaignsPhaseStartRequestObject{CampaignId: campaign}, http.StatusOK},
+		{"control grantee cannot delete", operator, "CampaignsDelete", http.MethodDelete, "/api/v2/campaigns/" + campaign.String(), gen.CampaignsDeleteRequestObject{CampaignId: campaign}, http.StatusForbidden},
+		{"viewer grant is capped at read", viewer, "CampaignsPhaseStart", http.MethodPost, "/api/v2/campaigns/" + campaign.String() + "/phases/dns_validation/start", gen.CampaignsPhaseStartRequestObject{CampaignId: campaign}, http.StatusForbidden},
+		{"viewer reads shared campaign", viewer, "CampaignsGet", http.MethodGet, "/api/v2/campaigns/" + campaign.String(), gen.CampaignsGetRequestObject{CampaignId: campaign}, http.StatusOK},
+		{"operator cannot see unshared campaign", operator, "CampaignsGet", http.MethodGet, "/api/v2/campaigns/" + other.String(), gen.CampaignsGetRequestObject{CampaignId: other}, http.StatusForbidden},
+		{"admin sees every campaign", admin, "CampaignsGet", http.MethodGet, "/api/v2/campaigns/" + campaign.String(), gen.CampaignsGetRequestObject{CampaignId: campaign}, http.StatusOK},
+		{"unknown campaign is left to the handler", operator, "CampaignsGet", http.MethodGet, "/api/v2/campaigns/x", gen.CampaignsGetRequestObject{CampaignId: uuid.New()}, http.StatusOK},
+		{"bulk request needs control on every campaign", operator, "BulkAnalyzeDomains", http.MethodPost, "/api/v2/campaigns/bulk/domains/analyze",
+			gen.BulkAnalyzeDomainsRequestObject{Body: &gen.BulkAnalyzeDomainsJSONRequestBody{CampaignIds: []uuid.UUID{campaign, other}}}, http.StatusForbidden},
+		{"viewer reads a bulk operation over shared campaigns", viewer, "GetBulkOperationStatus", http.MethodGet, "/api/v2/campaigns/bulk/operations/" + sharedOp.String() + "/status",
+			gen.GetBulkOperationStatusRequestObject{OperationId: sharedOp}, http.StatusOK},
+		{"viewer cannot read another user's bulk operation", viewer, "GetBulkOperationStatus", http.MethodGet, "/api/v2/campaigns/bulk/operations/" + foreignOp.String() + "/status",
+			gen.GetBulkOperationStatusRequestObject{OperationId: foreignOp}, http.StatusForbidden},
+		{"viewer cannot cancel a bulk operation", viewer, "CancelBulkOperation", http.MethodPost, "/api/v2/campaigns/bulk/operations/" + sharedOp.String() + "/cancel",
+			gen.CancelBulkOperationRequestObject{OperationId: sharedOp}, http.StatusForbidden},
+		{"control grantee cancels a bulk operation over its campaigns", operator, "CancelBulkOperation", http.MethodPost, "/api/v2/campaigns/bulk/operations/" + sharedOp.String() + "/cancel",
+			gen.CancelBulkOperationRequestObject{OperationId: sharedOp}, http.StatusOK},
+		{"admin reads any bulk operation", admin, "GetBulkOperationStatus", http.MethodGet, "/api/v2/campaigns/bulk/operations/" + foreignOp.String() + "/status",
+			gen.GetBulkOperationStatusRequestObject{OperationId: foreignOp}, http.StatusOK},
+		{"viewer cannot create campaigns", viewer, "CampaignsCreate", http.MethodPost, "/api/v2/campaigns", gen.CampaignsCreateRequestObject{}, http.StatusForbidden},
+		{"operator manages personas", operator, "PersonasCreate", http.MethodPost, "/api/v2/personas", gen.PersonasCreateRequestObject{}, http.StatusOK},
+		{"viewer lists proxies", viewer, "ProxiesList", http.MethodGet, "/api/v2/proxies", gen.ProxiesListRequestObject{}, http.StatusOK},
+		{"operator cannot change config", operator, "ConfigUpdateServer", http.MethodPut, "/api/v2/config/server", gen.ConfigUpdateServerRequestObject{}, http.StatusForbidden},
+		{"only admins set roles", operator, "UsersRoleUpdate", http.MethodPut, "/api/v2/users/" + viewer.String() + "/role", gen.UsersRoleUpdateRequestObject{UserId: viewer}, http.StatusForbidden},
+		{"anonymous request is rejected", uuid.Nil, "CampaignsList", http.MethodGet, "/api/v2/campaigns", gen.CampaignsListRequestObject{}, http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			next := func(ctx context.Context, w http.ResponseWriter, r *http.Request, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			}
+			ctx := context.Background()
+			if tc.user != uuid.Nil {
+				ctx = context.WithValue(ctx, "user_id", tc.user.String())
+			}
+			rec := httptest.NewRecorder()
+			_, _ = a.middleware(next, tc.operationID)(ctx, rec, httptest.NewRequest(tc.method, tc.path, nil), tc.req)
+			if got := rec.Code; called != (tc.wantStatus == http.StatusOK) || (!called && got != tc.wantStatus) {
+				t.Fatalf("called=%v status=%d, want status %d", called, got, tc.wantStatus)
+			}
+			select {
+			case d := <-a.decisions:
+				want := models.AuthorizationDeny
+				if called {
+					want = models.AuthorizationAllow
+				}
+				if d.Decision != want || d.Action != tc.operationID || d.PolicyEvaluated == "" {
+					t.Fatalf("unexpected decision %+v", d)
+				}
+			default:
+				t.Fatal("no decision recorded")
+			}
+		})
+	}
+
+	// Operations outside the protected areas pass straight through without a decision
+	called := false
+	next := func(context.Context, http.ResponseWriter, *http.Request, interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	}
+	_, _ = a.middleware(next, "HealthCheck")(context.Background(), httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v2/health", nil), gen.HealthCheckRequestObject{})
+	if !called || len(a.decisions) != 0 {
+		t.Fatalf("unprotected operation: called=%v decisions=%d", called, len(a.decisions))
+	}
+}
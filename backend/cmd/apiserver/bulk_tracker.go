@@ -2,189 +2,357 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"sync"
 	"time"
 
-	"github.com/fntelecomllc/studio/backend/internal/application"
+	domainservices "github.com/fntelecomllc/studio/backend/internal/domain/services"
 	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
 	"github.com/google/uuid"
-	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-type BulkOperationType string
-
 const (
-	BulkOpDomainGeneration BulkOperationType = "domain_generation"
-	BulkOpDNSValidation    BulkOperationType = "dns_validation"
-	BulkOpHTTPValidation   BulkOperationType = "http_validation"
-	BulkOpAnalysis         BulkOperationType = "analysis"
+	// bulkTaskClaimLease bounds how long a task stays claimed while its phase is being launched; a task
+	// whose launcher died becomes claimable again once it expires.
+	bulkTaskClaimLease = time.Minute
+	bulkResumeBatch    = 50
+	// bulkResumeDelay gives in-flight phase restoration a head start before remaining tasks are launched.
+	bulkResumeDelay    = 10 * time.Second
+	bulkResumeInterval = time.Minute
 )
 
-type BulkOperationStatus string
-
-const (
-	BulkStatusPending   BulkOperationStatus = "pending"
-	BulkStatusRunning   BulkOperationStatus = "running"
-	BulkStatusCompleted BulkOperationStatus = "completed"
-	BulkStatusFailed    BulkOperationStatus = "failed"
-	BulkStatusCancelled BulkOperationStatus = "cancelled"
-)
+// bulkPhaseRunner is the part of the campaign orchestrator the bulk tracker drives.
+type bulkPhaseRunner interface {
+	ConfigurePhase(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum, config interface{}) error
+	StartPhaseInternal(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum) error
+	GetPhaseStatus(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum) (*domainservices.PhaseStatus, error)
+	CancelPhase(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum) error
+}
 
-type BulkOperation struct {
-	ID              openapi_types.UUID
-	Type            BulkOperationType
-	CampaignIDs     []uuid.UUID
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	Status          BulkOperationStatus
-	CancelRequested bool
+// BulkTaskSpec is one campaign's share of a new bulk operation. Config is the phase configuration to
+// apply before starting the phase; operations that only observe a phase leave it nil.
+type BulkTaskSpec struct {
+	CampaignID uuid.UUID
+	Config     map[string]interface{}
 }
 
+// BulkOpsTracker persists bulk operations and launches their per-campaign phases. Operations and the
+// phase configuration of every task are stored, so status survives restarts and tasks that were not
+// launched yet are picked up by whichever instance resumes first.
 type BulkOpsTracker struct {
-	mu  sync.RWMutex
-	ops map[openapi_types.UUID]*BulkOperation
+	store     store.BulkOperationStore
+	runner    bulkPhaseRunner
+	startOnce sync.Once
 }
 
-func NewBulkOpsTracker() *BulkOpsTracker {
-	return &BulkOpsTracker{ops: make(map[openapi_types.UUID]*BulkOperation)}
+func NewBulkOpsTracker(st store.BulkOperationStore, runner bulkPhaseRunner) *BulkOpsTracker {
+	return &BulkOpsTracker{store: st, runner: runner}
 }
 
-func (t *BulkOpsTracker) NewOperation(opType BulkOperationType, campaignIDs []uuid.UUID) openapi_types.UUID {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-	id := openapi_types.UUID(uuid.New())
-	now := time.Now()
-	t.ops[id] = &BulkOperation{
-		ID:          id,
-		Type:        opType,
-		CampaignIDs: campaignIDs,
-		CreatedAt:   now,
-		UpdatedAt:   now,
-		Status:      BulkStatusPending,
-	}
-	return id
+func bulkPhaseFor(opType models.BulkOperationTypeEnum) models.PhaseTypeEnum {
+	switch opType {
+	case models.BulkOperationTypeDNSValidation:
+		return models.PhaseTypeDNSValidation
+	case models.BulkOperationTypeHTTPValidation:
+		return models.PhaseTypeHTTPKeywordValidation
+	case models.BulkOperationTypeAnalysis:
+		return models.PhaseTypeAnalysis
+	default:
+		return models.PhaseTypeDomainGeneration
+	}
 }
 
-func (t *BulkOpsTracker) List() []*BulkOperation {
-	t.mu.RLock()
-	defer t.mu.RUnlock()
-	out := make([]*BulkOperation, 0, len(t.ops))
-	for _, op := range t.ops {
-		out = append(out, op)
+// NewOperation persists an operation with one task per campaign; a campaign listed twice keeps its
+// first spec. Tasks of launchable types start out pending until Launch or Resume starts them, while
+// analysis tasks only observe the phase and are tracked as running right away.
+func (t *BulkOpsTracker) NewOperation(ctx context.Context, opType models.BulkOperationTypeEnum, specs []BulkTaskSpec) (uuid.UUID, error) {
+	now := time.Now().UTC()
+	op := &models.BulkOperation{
+		ID:        uuid.New(),
+		Type:      opType,
+		Status:    models.BulkOperationStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	taskStatus := models.BulkOperationStatusPending
+	if opType == models.BulkOperationTypeAnalysis {
+		op.Status = models.BulkOperationStatusRunning
+		taskStatus = models.BulkOperationStatusRunning
+	}
+
+	seen := make(map[uuid.UUID]bool, len(specs))
+	tasks := make([]*models.BulkOperationTask, 0, len(specs))
+	for _, spec := range specs {
+		if seen[spec.CampaignID] {
+			continue
+		}
+		seen[spec.CampaignID] = true
+		task := &models.BulkOperationTask{
+			OperationID: op.ID,
+			CampaignID:  spec.CampaignID,
+			Position:    len(tasks),
+			Status:      taskStatus,
+			UpdatedAt:   now,
+		}
+		if spec.Config != nil {
+			raw, err := json.Marshal(spec.Config)
+			if err != nil {
+				return uuid.Nil, fmt.Errorf("encode config for campaign %s: %w", spec.CampaignID, err)
+			}
+			cfg := json.RawMessage(raw)
+			task.Config = &cfg
+		}
+		tasks = append(tasks, task)
 	}
-	return out
+
+	tx, err := t.store.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := t.store.CreateBulkOperation(ctx, tx, op, tasks); err != nil {
+		return uuid.Nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, err
+	}
+	return op.ID, nil
 }
 
-func (t *BulkOpsTracker) Get(id openapi_types.UUID) (*BulkOperation, bool) {
-	t.mu.RLock()
-	defer t.mu.RUnlock()
-	op, ok := t.ops[id]
-	return op, ok
+// Launch claims the operation's pending tasks and configures and starts their phases. The result maps
+// each launched campaign to its launch error; campaigns missing from it were not claimed (e.g. another
+// instance is launching them) and stay pending.
+func (t *BulkOpsTracker) Launch(ctx context.Context, id uuid.UUID) (map[uuid.UUID]error, error) {
+	op, err := t.store.GetBulkOperation(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	claimed, err := t.store.ClaimPendingBulkOperationTasks(ctx, nil, &id, int(op.CampaignCount), bulkTaskClaimLease)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[uuid.UUID]error, len(claimed))
+	for _, task := range claimed {
+		results[task.CampaignID] = t.launchTask(ctx, bulkPhaseFor(op.Type), task)
+	}
+	return results, nil
 }
 
-func (t *BulkOpsTracker) phaseFor(opType BulkOperationType) models.PhaseTypeEnum {
-	switch opType {
-	case BulkOpDomainGeneration:
-		return models.PhaseTypeDomainGeneration
-	case BulkOpDNSValidation:
-		return models.PhaseTypeDNSValidation
-	case BulkOpHTTPValidation:
-		return models.PhaseTypeHTTPKeywordValidation
-	case BulkOpAnalysis:
-		return models.PhaseTypeAnalysis
-	default:
-		return models.PhaseTypeDomainGeneration
+func (t *BulkOpsTracker) launchTask(ctx context.Context, phase models.PhaseTypeEnum, task *models.BulkOperationTask) error {
+	var cfg map[string]interface{}
+	var err error
+	if task.Config != nil {
+		err = json.Unmarshal(*task.Config, &cfg)
+	}
+	if err == nil {
+		err = t.runner.ConfigurePhase(ctx, task.CampaignID, phase, cfg)
+	}
+	if err == nil {
+		err = t.runner.StartPhaseInternal(ctx, task.CampaignID, phase)
+	}
+	if err != nil {
+		task.Status = models.BulkOperationStatusFailed
+		task.LastError.String, task.LastError.Valid = err.Error(), true
+	} else {
+		task.Status = models.BulkOperationStatusRunning
+		task.StartedAt.Time, task.StartedAt.Valid = time.Now().UTC(), true
+	}
+	if uerr := t.store.UpdateBulkOperationTask(ctx, nil, task); uerr != nil {
+		log.Printf("[bulk-ops] record task launch failed operation=%s campaign=%s err=%v", task.OperationID, task.CampaignID, uerr)
+	}
+	return err
+}
+
+// Start launches the resume loop: after a short delay, and then periodically, pending tasks of
+// operations that were not cancelled are claimed and launched. It stops when ctx is cancelled.
+func (t *BulkOpsTracker) Start(ctx context.Context) {
+	t.startOnce.Do(func() {
+		go t.loop(ctx)
+	})
+}
+
+func (t *BulkOpsTracker) loop(ctx context.Context) {
+	timer := time.NewTimer(bulkResumeDelay)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return
+	case <-timer.C:
+	}
+	ticker := time.NewTicker(bulkResumeInterval)
+	defer ticker.Stop()
+	for {
+		if n, err := t.Resume(ctx); err != nil {
+			log.Printf("[bulk-ops] resume failed: %v", err)
+		} else if n > 0 {
+			log.Printf("[bulk-ops] resumed %d pending bulk tasks", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
 }
 
-// Aggregate computes overall progress and status from orchestrator per-campaign phase status.
-func (t *BulkOpsTracker) Aggregate(ctx context.Context, orch *application.CampaignOrchestrator, id openapi_types.UUID) (progressPct float32, status BulkOperationStatus, typ string, found bool) {
-	t.mu.RLock()
-	op, ok := t.ops[id]
-	t.mu.RUnlock()
-	if !ok {
-		return 0, BulkStatusPending, "", false
+// Resume launches every remaining pending task across operations and returns how many were launched.
+func (t *BulkOpsTracker) Resume(ctx context.Context) (int, error) {
+	ops := map[uuid.UUID]*models.BulkOperation{}
+	launched := 0
+	for {
+		claimed, err := t.store.ClaimPendingBulkOperationTasks(ctx, nil, nil, bulkResumeBatch, bulkTaskClaimLease)
+		if err != nil {
+			return launched, err
+		}
+		if len(claimed) == 0 {
+			return launched, nil
+		}
+		for _, task := range claimed {
+			op, ok := ops[task.OperationID]
+			if !ok {
+				if op, err = t.store.GetBulkOperation(ctx, nil, task.OperationID); err != nil {
+					return launched, err
+				}
+				ops[task.OperationID] = op
+			}
+			_ = t.launchTask(ctx, bulkPhaseFor(op.Type), task)
+			launched++
+		}
 	}
+}
 
-	phase := t.phaseFor(op.Type)
-	var totalProcessed int64
-	var totalItems int64
-	anyRunning := false
-	anyFailed := false
-	allCompleted := true
+// Aggregate refreshes the operation's running tasks from the orchestrator's phase status and persists
+// the resulting progress (0..100) and status. It returns store.ErrNotFound for unknown operations.
+func (t *BulkOpsTracker) Aggregate(ctx context.Context, id uuid.UUID) (*models.BulkOperation, []*models.BulkOperationTask, error) {
+	op, err := t.store.GetBulkOperation(ctx, nil, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	tasks, err := t.store.ListBulkOperationTasks(ctx, nil, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if op.CancelRequested {
+		return op, tasks, nil
+	}
 
-	for _, cid := range op.CampaignIDs {
-		st, err := orch.GetPhaseStatus(ctx, cid, phase)
+	phase := bulkPhaseFor(op.Type)
+	for _, task := range tasks {
+		if task.Status != models.BulkOperationStatusRunning {
+			continue
+		}
+		st, err := t.runner.GetPhaseStatus(ctx, task.CampaignID, phase)
 		if err != nil || st == nil {
-			anyRunning = true
-			allCompleted = false
 			continue
 		}
-		totalProcessed += st.ItemsProcessed
-		totalItems += st.ItemsTotal
+		next := task.Status
 		switch st.Status {
 		case models.PhaseStatusCompleted:
-			// keep allCompleted
+			next = models.BulkOperationStatusCompleted
 		case models.PhaseStatusFailed:
-			anyFailed = true
-			allCompleted = false
-		case models.PhaseStatusInProgress:
-			anyRunning = true
-			allCompleted = false
+			next = models.BulkOperationStatusFailed
+		}
+		if next == task.Status && st.ItemsProcessed == task.ItemsProcessed && st.ItemsTotal == task.ItemsTotal {
+			continue
+		}
+		task.Status, task.ItemsProcessed, task.ItemsTotal = next, st.ItemsProcessed, st.ItemsTotal
+		if next == models.BulkOperationStatusFailed && st.LastError != "" {
+			task.LastError.String, task.LastError.Valid = st.LastError, true
+		}
+		if err := t.store.UpdateBulkOperationTask(ctx, nil, task); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	status, progress := aggregateBulkTasks(tasks)
+	if status != op.Status || progress != op.Progress {
+		if err := t.store.UpdateBulkOperationProgress(ctx, nil, id, status, progress); err != nil {
+			return nil, nil, err
+		}
+		op.Status, op.Progress = status, progress
+	}
+	return op, tasks, nil
+}
+
+// aggregateBulkTasks derives an operation's status and progress percentage from its tasks. The
+// operation stays running until every task has finished, then reports failed if any task failed.
+func aggregateBulkTasks(tasks []*models.BulkOperationTask) (models.BulkOperationStatusEnum, float64) {
+	var pending, running, failed, finished int
+	var processed, total int64
+	for _, task := range tasks {
+		processed += task.ItemsProcessed
+		total += task.ItemsTotal
+		switch task.Status {
+		case models.BulkOperationStatusPending:
+			pending++
+		case models.BulkOperationStatusRunning:
+			running++
+		case models.BulkOperationStatusFailed:
+			failed++
+			finished++
 		default:
-			allCompleted = false
+			finished++
 		}
 	}
 
-	// Compute status
+	var status models.BulkOperationStatusEnum
 	switch {
-	case op.CancelRequested:
-		status = BulkStatusCancelled
-	case anyFailed:
-		status = BulkStatusFailed
-	case allCompleted && len(op.CampaignIDs) > 0:
-		status = BulkStatusCompleted
-	case anyRunning:
-		status = BulkStatusRunning
+	case running > 0 || (pending > 0 && finished > 0):
+		status = models.BulkOperationStatusRunning
+	case pending > 0 || len(tasks) == 0:
+		status = models.BulkOperationStatusPending
+	case failed > 0:
+		status = models.BulkOperationStatusFailed
 	default:
-		status = BulkStatusPending
+		status = models.BulkOperationStatusCompleted
 	}
 
-	// Compute progress percentage (0..100)
 	var pct float64
-	if totalItems > 0 {
-		pct = (float64(totalProcessed) / float64(totalItems)) * 100.0
-	} else if status == BulkStatusCompleted {
+	if total > 0 {
+		pct = float64(processed) / float64(total) * 100.0
+		if pct > 100 {
+			pct = 100
+		}
+	} else if status == models.BulkOperationStatusCompleted {
 		pct = 100
-	} else {
-		pct = 0
 	}
-
-	// Update operation record
-	t.mu.Lock()
-	op.Status = status
-	op.UpdatedAt = time.Now()
-	t.mu.Unlock()
-
-	return float32(pct), status, string(op.Type), true
+	return status, pct
 }
 
-// Cancel requests cancellation and forwards to orchestrator for the phase across campaigns.
-func (t *BulkOpsTracker) Cancel(ctx context.Context, orch *application.CampaignOrchestrator, id openapi_types.UUID) (BulkOperationStatus, bool) {
-	t.mu.Lock()
-	op, ok := t.ops[id]
-	if !ok {
-		t.mu.Unlock()
-		return BulkStatusPending, false
+// Cancel flags the operation cancelled, drops its tasks that have not started and cancels the phase of
+// tasks still running. It returns store.ErrNotFound for unknown operations.
+func (t *BulkOpsTracker) Cancel(ctx context.Context, id uuid.UUID) error {
+	if err := t.store.RequestBulkOperationCancel(ctx, nil, id); err != nil {
+		return err
 	}
-	op.CancelRequested = true
-	op.Status = BulkStatusCancelled
-	phase := t.phaseFor(op.Type)
-	cids := append([]uuid.UUID(nil), op.CampaignIDs...)
-	t.mu.Unlock()
-
-	for _, cid := range cids {
-		_ = orch.CancelPhase(ctx, cid, phase)
+	op, err := t.store.GetBulkOperation(ctx, nil, id)
+	if err != nil {
+		return err
+	}
+	tasks, err := t.store.ListBulkOperationTasks(ctx, nil, id)
+	if err != nil {
+		return err
 	}
-	return BulkStatusCancelled, true
+	phase := bulkPhaseFor(op.Type)
+	var errs []error
+	for _, task := range tasks {
+		if task.Status != models.BulkOperationStatusRunning {
+			continue
+		}
+		_ = t.runner.CancelPhase(ctx, task.CampaignID, phase)
+		task.Status = models.BulkOperationStatusCancelled
+		if err := t.store.UpdateBulkOperationTask(ctx, nil, task); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// List returns a page of the operation history and the total matching the filter.
+func (t *BulkOpsTracker) List(ctx context.Context, filter store.ListBulkOperationsFilter) ([]*models.BulkOperation, int64, error) {
+	return t.store.ListBulkOperations(ctx, nil, filter)
 }
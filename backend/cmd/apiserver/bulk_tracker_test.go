@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	domainservices "github.com/fntelecomllc/studio/backend/internal/domain/services"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type fakeBulkStore struct {
+	ops   map[uuid.UUID]*models.BulkOperation
+	tasks map[uuid.UUID][]*models.BulkOperationTask
+}
+
+func newFakeBulkStore() *fakeBulkStore {
+	return &fakeBulkStore{ops: map[uuid.UUID]*models.BulkOperation{}, tasks: map[uuid.UUID][]*models.BulkOperationTask{}}
+}
+
+func (f *fakeBulkStore) add(opType models.BulkOperationTypeEnum, statuses ...models.BulkOperationStatusEnum) *models.BulkOperation {
+	op := &models.BulkOperation{ID: uuid.New(), Type: opType, Status: models.BulkOperationStatusPending, CampaignCount: len(statuses)}
+	f.ops[op.ID] = op
+	for i, st := range statuses {
+		cfg := json.RawMessage(`{"batch_size":100}`)
+		task := &models.BulkOperationTask{OperationID: op.ID, CampaignID: uuid.New(), Position: i, Status: st, Config: &cfg}
+		f.tasks[op.ID] = append(f.tasks[op.ID], task)
+	}
+	return op
+}
+
+func (f *fakeBulkStore) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return nil, errors.New("not supported")
+}
+func (f *fakeBulkStore) CreateBulkOperation(ctx context.Context, exec store.Querier, op *models.BulkOperation, tasks []*models.BulkOperationTask) error {
+	return nil
+}
+func (f *fakeBulkStore) GetBulkOperation(ctx context.Context, exec store.Querier, id uuid.UUID) (*models.BulkOperation, error) {
+	op, ok := f.ops[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	cp := *op
+	return &cp, nil
+}
+func (f *fakeBulkStore) ListBulkOperations(ctx context.Context, exec store.Querier, filter store.ListBulkOperationsFilter) ([]*models.BulkOperation, int64, error) {
+	return nil, 0, nil
+}
+func (f *fakeBulkStore) ListBulkOperationTasks(ctx context.Context, exec store.Querier, operationID uuid.UUID) ([]*models.BulkOperationTask, error) {
+	out := []*models.BulkOperationTask{}
+	for _, t := range f.tasks[operationID] {
+		cp := *t
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+func (f *fakeBulkStore) UpdateBulkOperationProgress(ctx context.Context, exec store.Querier, id uuid.UUID, status models.BulkOperationStatusEnum, progress float64) error {
+	f.ops[id].Status, f.ops[id].Progress = status, progress
+	return nil
+}
+func (f *fakeBulkStore) UpdateBulkOperationTask(ctx context.Context, exec store.Querier, task *models.BulkOperationTask) error {
+	for _, t := range f.tasks[task.OperationID] {
+		if t.CampaignID == task.CampaignID {
+			*t = *task
+		}
+	}
+	return nil
+}
+func (f *fakeBulkStore) RequestBulkOperationCancel(ctx context.Context, exec store.Querier, id uuid.UUID) error {
+	op, ok := f.ops[id]
+	if !ok {
+		return store.ErrNotFound
+	}
+	op.CancelRequested, op.Status = true, models.BulkOperationStatusCancelled
+	for _, t := range f.tasks[id] {
+		if t.Status == models.BulkOperationStatusPending {
+			t.Status = models.BulkOperationStatusCancelled
+		}
+	}
+	return nil
+}
+func (f *fakeBulkStore) ClaimPendingBulkOperationTasks(ctx context.Context, exec store.Querier, operationID *uuid.UUID, limit int, lease time.Duration) ([]*models.BulkOperationTask, error) {
+	out := []*models.BulkOperationTask{}
+	for id, tasks := range f.tasks {
+		if (operationID != nil && id != *operationID) || f.ops[id].CancelRequested {
+			continue
+		}
+		for _, t := range tasks {
+			if t.Status == models.BulkOperationStatusPending && !t.ClaimedUntil.Valid && len(out) < limit {
+				t.ClaimedUntil = sql.NullTime{Time: time.Now().Add(lease), Valid: true}
+				cp := *t
+				out = append(out, &cp)
+			}
+		}
+	}
+	return out, nil
+}
+
+type fakePhaseRunner struct {
+	started   []uuid.UUID
+	cancelled []uuid.UUID
+	failStart map[uuid.UUID]error
+	status    map[uuid.UUID]*domainservices.PhaseStatus
+}
+
+func (r *fakePhaseRunner) ConfigurePhase(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum, config interface{}) error {
+	if cfg, ok := config.(map[string]interface{}); !ok || cfg["batch_size"] != float64(100) {
+		return errors.New("stored config was not passed through")
+	}
+	return nil
+}
+func (r *fakePhaseRunner) StartPhaseInternal(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum) error {
+	if err := r.failStart[campaignID]; err != nil {
+		return err
+	}
+	r.started = append(r.started, campaignID)
+	return nil
+}
+func (r *fakePhaseRunner) GetPhaseStatus(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum) (*domainservices.PhaseStatus, error) {
+	if st, ok := r.status[campaignID]; ok {
+		return st, nil
+	}
+	return nil, errors.New("no status")
+}
+func (r *fakePhaseRunner) CancelPhase(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum) error {
+	r.cancelled = append(r.cancelled, campaignID)
+	return nil
+}
+
+func TestBulkOpsTrackerResumeLaunchesPendingTasks(t *testing.T) {
+	fs := newFakeBulkStore()
+	op := fs.add(models.BulkOperationTypeDNSValidation, models.BulkOperationStatusRunning, models.BulkOperationStatusPending, models.BulkOperationStatusPending)
+	cancelled := fs.add(models.BulkOperationTypeDNSValidation, models.BulkOperationStatusPending)
+	fs.ops[cancelled.ID].CancelRequested = true
+	failing := fs.tasks[op.ID][2].CampaignID
+	runner := &fakePhaseRunner{failStart: map[uuid.UUID]error{failing: errors.New("campaign busy")}}
+	tracker := NewBulkOpsTracker(fs, runner)
+
+	n, err := tracker.Resume(context.Background())
+	if err != nil || n != 2 {
+		t.Fatalf("Resume: launched %d, err %v", n, err)
+	}
+	if len(runner.started) != 1 || runner.started[0] != fs.tasks[op.ID][1].CampaignID {
+		t.Fatalf("expected only the remaining pending task to start, got %v", runner.started)
+	}
+	if got := fs.tasks[op.ID][2]; got.Status != models.BulkOperationStatusFailed || got.LastError.String != "campaign busy" {
+		t.Fatalf("expected launch failure to be recorded, got %+v", got)
+	}
+	if fs.tasks[cancelled.ID][0].Status != models.BulkOperationStatusPending {
+		t.Fatal("tasks of cancelled operations must not be launched")
+	}
+	if n, _ := tracker.Resume(context.Background()); n != 0 {
+		t.Fatalf("expected nothing left to resume, got %d", n)
+	}
+}
+
+func TestBulkOpsTrackerAggregateAndCancel(t *testing.T) {
+	fs := newFakeBulkStore()
+	op := fs.add(models.BulkOperationTypeHTTPValidation, models.BulkOperationStatusRunning, models.BulkOperationStatusRunning)
+	first, second := fs.tasks[op.ID][0].CampaignID, fs.tasks[op.ID][1].CampaignID
+	runner := &fakePhaseRunner{status: map[uuid.UUID]*domainservices.PhaseStatus{
+		first:  {Status: models.PhaseStatusCompleted, ItemsProcessed: 50, ItemsTotal: 50},
+		second: {Status: models.PhaseStatusInProgress, ItemsProcessed: 25, ItemsTotal: 50},
+	}}
+	tracker := NewBulkOpsTracker(fs, runner)
+
+	got, tasks, err := tracker.Aggregate(context.Background(), op.ID)
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if got.Status != models.BulkOperationStatusRunning || got.Progress != 75 || fs.ops[op.ID].Progress != 75 {
+		t.Fatalf("expected running at 75%%, got %s %.1f", got.Status, got.Progress)
+	}
+	if tasks[0].Status != models.BulkOperationStatusCompleted || fs.tasks[op.ID][0].Status != models.BulkOperationStatusCompleted {
+		t.Fatal("expected finished task to be persisted as completed")
+	}
+
+	if err := tracker.Cancel(context.Background(), op.ID); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if len(runner.cancelled) != 1 || runner.cancelled[0] != second {
+		t.Fatalf("expected only the running task's phase to be cancelled, got %v", runner.cancelled)
+	}
+	got, _, _ = tracker.Aggregate(context.Background(), op.ID)
+	if got.Status != models.BulkOperationStatusCancelled {
+		t.Fatalf("expected cancellation to stick, got %s", got.Status)
+	}
+	if _, _, err := tracker.Aggregate(context.Background(), uuid.New()); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown operation, got %v", err)
+	}
+}
+
+func TestAggregateBulkTasks(t *testing.T) {
+	task := func(st models.BulkOperationStatusEnum) *models.BulkOperationTask {
+		return &models.BulkOperationTask{Status: st}
+	}
+	cases := []struct {
+		name  string
+		tasks []*models.BulkOperationTask
+		want  models.BulkOperationStatusEnum
+		pct   float64
+	}{
+		{"all pending", []*models.BulkOperationTask{task("pending"), task("pending")}, models.BulkOperationStatusPending, 0},
+		{"partially launched", []*models.BulkOperationTask{task("completed"), task("pending")}, models.BulkOperationStatusRunning, 0},
+		{"failure waits for the rest", []*models.BulkOperationTask{task("failed"), task("running")}, models.BulkOperationStatusRunning, 0},
+		{"finished with failure", []*models.BulkOperationTask{task("failed"), task("completed")}, models.BulkOperationStatusFailed, 0},
+		{"all completed", []*models.BulkOperationTask{task("completed"), task("completed")}, models.BulkOperationStatusCompleted, 100},
+	}
+	for _, tc := range cases {
+		status, pct := aggregateBulkTasks(tc.tasks)
+		if status != tc.want || pct != tc.pct {
+			t.Errorf("%s: got %s %.0f, want %s %.0f", tc.name, status, pct, tc.want, tc.pct)
+		}
+	}
+}
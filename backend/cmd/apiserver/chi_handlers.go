@@ -51,6 +51,14 @@ func startChiServer() {
 				"X-Idempotency-Key": r.Header.Get("X-Idempotency-Key"),
 			}
 			ctx = context.WithValue(ctx, "request_headers", headers)
+			// Personal API keys take precedence over a session cookie sent alongside them
+			if key, ok := bearerAPIKey(r); ok && deps.APIKeyAuth != nil {
+				authed, ok := deps.APIKeyAuth.authenticate(ctx, w, r, key, operationID)
+				if !ok {
+					return nil, nil // rejection already written
+				}
+				return next(authed, w, r, req)
+			}
 			// Only attempt if session service is available
 			if deps.Session != nil {
 				if c, err := r.Cookie(config.SessionCookieName); err == nil {
@@ -114,26 +122,30 @@ func startChiServer() {
 		},
 	}
 
-	// Post-response middleware: set session cookie on successful AuthLogin
+	// Post-response middleware: set session cookie on successful AuthLogin (or its MFA step)
 	authLoginCookie := func(next gen.StrictHandlerFunc, operationID string) gen.StrictHandlerFunc {
 		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, req interface{}) (interface{}, error) {
 			resp, err := next(ctx, w, r, req)
-			if operationID == "AuthLogin" {
-				if v, ok := resp.(gen.AuthLogin200JSONResponse); ok {
-					token := v.Token
-					if token != "" {
-						// Sign the session cookie for security
-						signedCookie := services.SignSessionCookie(token)
-						http.SetCookie(w, &http.Cookie{
-							Name:     config.SessionCookieName,
-							Value:    signedCookie,
-							Path:     config.CookiePath,
-							HttpOnly: config.CookieHttpOnly,
-							Secure:   config.CookieSecure,
-							SameSite: sameSiteFromString(config.CookieSameSite),
-							MaxAge:   config.CookieMaxAge,
-						})
-					}
+			if operationID == "AuthLogin" || operationID == "AuthLoginMfa" {
+				var token string
+				switch v := resp.(type) {
+				case gen.AuthLogin200JSONResponse:
+					token = v.Token
+				case gen.AuthLoginMfa200JSONResponse:
+					token = v.Token
+				}
+				if token != "" {
+					// Sign the session cookie for security
+					signedCookie := services.SignSessionCookie(token)
+					http.SetCookie(w, &http.Cookie{
+						Name:     config.SessionCookieName,
+						Value:    signedCookie,
+						Path:     config.CookiePath,
+						HttpOnly: config.CookieHttpOnly,
+						Secure:   config.CookieSecure,
+						SameSite: sameSiteFromString(config.CookieSameSite),
+						MaxAge:   config.CookieMaxAge,
+					})
 				}
 			}
 			return resp, err
@@ -183,7 +195,12 @@ func startChiServer() {
 
 	networkLogger := newNetworkLogHandler(deps)
 	strict := &strictHandlers{deps: deps, networkLogger: networkLogger}
-	handler := gen.NewStrictHandlerWithOptions(strict, []gen.StrictMiddlewareFunc{authCtx, authLoginCookie, authLogoutCookie}, opts)
+	// Middlewares listed first run innermost, so authorization sees the user resolved by authCtx
+	middlewares := []gen.StrictMiddlewareFunc{authCtx, authLoginCookie, authLogoutCookie}
+	if deps.Authorizer != nil {
+		middlewares = append([]gen.StrictMiddlewareFunc{deps.Authorizer.middleware}, middlewares...)
+	}
+	handler := gen.NewStrictHandlerWithOptions(strict, middlewares, opts)
 	baseHandler := gen.HandlerWithOptions(handler, gen.ChiServerOptions{BaseURL: "/api/v2"})
 
 	// CORS middleware to allow frontend at localhost:3000 to call API with credentials
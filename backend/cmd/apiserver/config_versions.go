@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sync"
+
+	"github.com/fntelecomllc/studio/backend/internal/config"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// configVersioner stores runtime config changes in config_versions and installs each one in the
+// live AppConfig only after it has been saved, so the running config is always a stored version.
+// Changes in this process are serialised; the version check in the store catches other processes.
+// Without a store it falls back to editing the live config and the config file.
+type configVersioner struct {
+	mu    sync.Mutex
+	db    *sqlx.DB
+	store store.ConfigVersionStore
+	cfg   *config.AppConfig
+}
+
+func newConfigVersioner(db *sqlx.DB, s store.ConfigVersionStore, cfg *config.AppConfig) *configVersioner {
+	return &configVersioner{db: db, store: s, cfg: cfg}
+}
+
+func (v *configVersioner) persistent() bool {
+	return v.store != nil && v.db != nil
+}
+
+// configChange describes one commit to a config section.
+type configChange struct {
+	section string
+	author  uuid.UUID
+	// expected is the version the change was based on; nil skips the check
+	expected   *int
+	changeType string
+	reason     string
+	// restored is the version whose value a rollback reapplies
+	restored *int
+}
+
+// loadCurrent applies the current stored version of every runtime section to the live config.
+func (v *configVersioner) loadCurrent(ctx context.Context) error {
+	if !v.persistent() {
+		return nil
+	}
+	versions, err := v.store.GetCurrentConfigVersions(ctx, nil)
+	if err != nil {
+		return err
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, ver := range versions {
+		if !config.ValidRuntimeSection(ver.ConfigKey) {
+			continue
+		}
+		if err := config.RestoreSection(v.cfg, ver.ConfigKey, ver.ConfigValue); err != nil {
+			log.Printf("Warning: config section %s version %d not applied: %v", ver.ConfigKey, ver.VersionNumber, err)
+			continue
+		}
+		log.Printf("Config: applied stored %s settings (version %d)", ver.ConfigKey, ver.VersionNumber)
+	}
+	return nil
+}
+
+// current returns the section's current version, or nil when it has never been changed.
+func (v *configVersioner) current(ctx context.Context, section string) (*models.ConfigVersion, error) {
+	// Rollbacks add versions too, so the newest version is always the current one
+	versions, err := v.store.ListConfigVersions(ctx, nil, section, 1, 0)
+	if err != nil || len(versions) == 0 {
+		return nil, err
+	}
+	return versions[0], nil
+}
+
+// commit applies mutate to a copy of the section and stores the result as a new version before
+// installing it. A change that leaves the section as it is stores nothing, reports created false and
+// returns the current version (nil if there is none). It returns store.ErrOptimisticLock when
+// ch.expected is stale.
+func (v *configVersioner) commit(ctx context.Context, ch configChange, mutate func(*config.AppConfig)) (*models.ConfigVersion, bool, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.persistent() {
+		mutate(v.cfg)
+		return nil, false, config.SaveAppConfig(v.cfg)
+	}
+
+	cur, err := v.current(ctx, ch.section)
+	if err != nil {
+		return nil, false, err
+	}
+	curNum := 0
+	next := v.cfg.RuntimeCopy()
+	if cur != nil {
+		curNum = cur.VersionNumber
+		// Start from what is stored, which another instance may have changed since this one loaded it
+		if err := config.RestoreSection(next, ch.section, cur.ConfigValue); err != nil {
+			return nil, false, err
+		}
+	}
+	if ch.expected != nil && *ch.expected != curNum {
+		return nil, false, store.ErrOptimisticLock
+	}
+	before, err := config.SnapshotSection(next, ch.section)
+	if err != nil {
+		return nil, false, err
+	}
+	mutate(next)
+	after, err := config.SnapshotSection(next, ch.section)
+	if err != nil {
+		return nil, false, err
+	}
+	changes, err := config.DiffSection(before, after)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(changes) == 0 {
+		return cur, false, nil
+	}
+	b, err := json.Marshal(changes)
+	if err != nil {
+		return nil, false, err
+	}
+	diff := json.RawMessage(b)
+
+	tx, err := v.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+	if cur == nil {
+		// The first change also records the file and environment settings it replaces, so they can be restored
+		baseline := &models.ConfigVersion{
+			ConfigKey:    ch.section,
+			ConfigValue:  before,
+			ConfigHash:   configHash(before),
+			ChangeType:   models.ConfigChangeCreate,
+			ChangeReason: sql.NullString{String: "settings from config file and environment", Valid: true},
+			CreatedBy:    ch.author,
+		}
+		if err := v.store.CreateConfigVersion(ctx, tx, baseline, 0); err != nil {
+			return nil, false, err
+		}
+		curNum = baseline.VersionNumber
+	}
+	ver := &models.ConfigVersion{
+		ConfigKey:    ch.section,
+		ConfigValue:  after,
+		ConfigHash:   configHash(after),
+		ChangeType:   ch.changeType,
+		ChangeReason: sql.NullString{String: ch.reason, Valid: ch.reason != ""},
+		ChangeDiff:   &diff,
+		CreatedBy:    ch.author,
+	}
+	if ch.restored != nil {
+		ver.RollbackVersion = sql.NullInt32{Int32: int32(*ch.restored), Valid: true}
+	}
+	if err := v.store.CreateConfigVersion(ctx, tx, ver, curNum); err != nil {
+		return nil, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+
+	if err := config.RestoreSection(v.cfg, ch.section, after); err != nil {
+		return nil, false, err
+	}
+	// The database is authoritative; the file copy only helps deployments that start without it
+	if v.cfg.GetLoadedFromPath() != "" {
+		if err := config.SaveAppConfig(v.cfg); err != nil {
+			log.Printf("Warning: config version %s/%d saved but config file not updated: %v", ch.section, ver.VersionNumber, err)
+		}
+	}
+	return ver, true, nil
+}
+
+// rollback reapplies the value of an earlier version as a new version of its section, with the same
+// results as commit. It returns store.ErrNotFound for unknown ids.
+func (v *configVersioner) rollback(ctx context.Context, id, author uuid.UUID, reason string) (*models.ConfigVersion, bool, error) {
+	target, err := v.store.GetConfigVersion(ctx, nil, id)
+	if err != nil {
+		return nil, false, err
+	}
+	if !config.ValidRuntimeSection(target.ConfigKey) {
+		return nil, false, store.ErrNotFound
+	}
+	restored := target.VersionNumber
+	var restoreErr error
+	ver, created, err := v.commit(ctx, configChange{section: target.ConfigKey, author: author, changeType: models.ConfigChangeRollback, reason: reason, restored: &restored},
+		func(c *config.AppConfig) { restoreErr = config.RestoreSection(c, target.ConfigKey, target.ConfigValue) })
+	if restoreErr != nil {
+		return nil, false, restoreErr
+	}
+	return ver, created, err
+}
+
+func configHash(value json.RawMessage) string {
+	sum := sha256.Sum256(value)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/config"
+	pg_store "github.com/fntelecomllc/studio/backend/internal/store/postgres"
+	"github.com/google/uuid"
+)
+
+var configVersionRowColumns = []string{"id", "config_key", "version_number", "config_value", "config_hash", "change_type",
+	"change_reason", "change_diff", "rollback_version", "is_current", "created_by", "created_at"}
+
+func TestConfigUpdateStealth_StoresBaselineAndNewVersion(t *testing.T) {
+	db, mock := createTestDBWithMock(t)
+	cfg := &config.AppConfig{}
+	deps := &AppDeps{DB: db, Config: cfg, ConfigVersions: newConfigVersioner(db, pg_store.NewConfigVersionStorePostgres(db), cfg)}
+	h := &strictHandlers{deps: deps}
+	admin := uuid.New()
+	ctx := context.WithValue(context.Background(), "user_id", admin)
+
+	mock.ExpectQuery(`SELECT .+ FROM config_versions WHERE \$1 = '' OR config_key = \$1`).
+		WithArgs(config.SectionStealth, 1, 0).
+		WillReturnRows(sqlmock.NewRows(configVersionRowColumns))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`WITH demoted AS`).
+		WithArgs(config.SectionStealth, 0, `{"enabled":false}`, sqlmock.AnyArg(), "create", sqlmock.AnyArg(), nil, sqlmock.AnyArg(), admin).
+		WillReturnRows(sqlmock.NewRows(configVersionRowColumns).
+			AddRow(uuid.New(), config.SectionStealth, 1, []byte(`{"enabled":false}`), "h1", "create", nil, nil, nil, true, admin, time.Now()))
+	mock.ExpectQuery(`WITH demoted AS`).
+		WithArgs(config.SectionStealth, 1, `{"enabled":true}`, sqlmock.AnyArg(), "update", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), admin).
+		WillReturnRows(sqlmock.NewRows(configVersionRowColumns).
+			AddRow(uuid.New(), config.SectionStealth, 2, []byte(`{"enabled":true}`), "h2", "update", nil,
+				[]byte(`[{"path":"enabled","before":false,"after":true}]`), nil, true, admin, time.Now()))
+	mock.ExpectCommit()
+
+	ifMatch := gen.ConfigIfMatch("0")
+	resp, err := h.ConfigUpdateStealth(ctx, gen.ConfigUpdateStealthRequestObject{
+		Params: gen.ConfigUpdateStealthParams{IfMatch: &ifMatch},
+		Body:   &gen.ConfigUpdateStealthJSONRequestBody{Enabled: true},
+	})
+	if err != nil {
+		t.Fatalf("ConfigUpdateStealth: %v", err)
+	}
+	if _, ok := resp.(gen.ConfigUpdateStealth200JSONResponse); !ok {
+		t.Fatalf("expected 200, got %T", resp)
+	}
+	if !cfg.Features.EnableStealth {
+		t.Fatal("stored change was not applied to the live config")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestConfigUpdateStealth_StaleIfMatchConflicts(t *testing.T) {
+	db, mock := createTestDBWithMock(t)
+	cfg := &config.AppConfig{}
+	deps := &AppDeps{DB: db, Config: cfg, ConfigVersions: newConfigVersioner(db, pg_store.NewConfigVersionStorePostgres(db), cfg)}
+	h := &strictHandlers{deps: deps}
+	ctx := context.WithValue(context.Background(), "user_id", uuid.New())
+
+	mock.ExpectQuery(`SELECT .+ FROM config_versions`).
+		WithArgs(config.SectionStealth, 1, 0).
+		WillReturnRows(sqlmock.NewRows(configVersionRowColumns).
+			AddRow(uuid.New(), config.SectionStealth, 3, []byte(`{"enabled":false}`), "h3", "update", nil, nil, nil, true, uuid.New(), time.Now()))
+
+	ifMatch := gen.ConfigIfMatch(`"2"`)
+	resp, _ := h.ConfigUpdateStealth(ctx, gen.ConfigUpdateStealthRequestObject{
+		Params: gen.ConfigUpdateStealthParams{IfMatch: &ifMatch},
+		Body:   &gen.ConfigUpdateStealthJSONRequestBody{Enabled: true},
+	})
+	fail, ok := resp.(*configUpdateFailure)
+	if !ok || fail.status != 409 {
+		t.Fatalf("expected 409, got %#v", resp)
+	}
+	if cfg.Features.EnableStealth {
+		t.Fatal("rejected change was applied")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/fntelecomllc/studio/backend/internal/application"
@@ -18,11 +19,18 @@ import (
 	domaininfra "github.com/fntelecomllc/studio/backend/internal/domain/services/infra"
 	"github.com/fntelecomllc/studio/backend/internal/extraction"
 	"github.com/fntelecomllc/studio/backend/internal/httpvalidator"
+	"github.com/fntelecomllc/studio/backend/internal/mailer"
+	"github.com/fntelecomllc/studio/backend/internal/middleware"
+	"github.com/fntelecomllc/studio/backend/internal/models"
 	"github.com/fntelecomllc/studio/backend/internal/monitoring"
 	"github.com/fntelecomllc/studio/backend/internal/proxymanager"
+	"github.com/fntelecomllc/studio/backend/internal/rdap"
+	"github.com/fntelecomllc/studio/backend/internal/robots"
+	"github.com/fntelecomllc/studio/backend/internal/scheduler"
 	"github.com/fntelecomllc/studio/backend/internal/services"
 	"github.com/fntelecomllc/studio/backend/internal/store"
 	pg_store "github.com/fntelecomllc/studio/backend/internal/store/postgres"
+	"github.com/fntelecomllc/studio/backend/internal/webhooks"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
@@ -43,9 +51,24 @@ type AppDeps struct {
 		Keyword     store.KeywordStore
 		AuditLog    store.AuditLogStore
 		CampaignJob store.CampaignJobStore
+		WordLists   store.WordListStore
+		Imports     store.DomainImportStore
+		Exports     store.DomainExportStore
+		Webhooks    store.WebhookStore
+		SSEJournal  store.SSEEventJournalStore
+		Schedules   store.CampaignScheduleStore
+		Snapshots   store.DomainRunSnapshotStore
+		BulkOps     store.BulkOperationStore
+		APIKeys     store.APIKeyStore
+		// Roles, campaign shares and the authorization decision log
+		Authorization store.AuthorizationStore
+		// Saved versions of the runtime config sections
+		ConfigVersions store.ConfigVersionStore
 	}
 	ProxyMgr          *proxymanager.ProxyManager
 	SSE               *services.SSEService
+	Webhooks          *webhooks.Dispatcher
+	Scheduler         *scheduler.Scheduler
 	Orchestrator      *application.CampaignOrchestrator
 	RehydrationWorker *application.RehydrationWorker
 	BulkOps           *BulkOpsTracker
@@ -56,6 +79,18 @@ type AppDeps struct {
 	Cleanup    *monitoring.CleanupService
 	// Auth/session
 	Session *services.SessionService
+	// TOTP multi-factor authentication; FieldCrypto encrypts MFA secrets and backup codes at rest
+	MFA         *services.MFAService
+	FieldCrypto *services.EncryptionService
+	// Personal API keys accepted as `Authorization: Bearer` alongside session cookies
+	APIKeys    *services.APIKeyService
+	APIKeyAuth *apiKeyAuthenticator
+	// Role and campaign-grant checks applied to strict handlers; nil disables enforcement
+	Authorizer *requestAuthorizer
+	// Delivers password reset and email verification messages
+	Mailer mailer.Mailer
+	// Versioned, database-backed changes to the runtime config sections
+	ConfigVersions *configVersioner
 	// Logger available to handlers (simple structured logger)
 	Logger HandlerLogger
 	// Aggregations cache (funnel & metrics)
@@ -189,6 +224,37 @@ func (a *eventBusAdapter) enrichSSEIdentifiers(ctx context.Context, evt *service
 	}
 }
 
+// scheduleRunnerAdapter lets the campaign scheduler drive the orchestrator
+type scheduleRunnerAdapter struct {
+	o *application.CampaignOrchestrator
+}
+
+func (a *scheduleRunnerAdapter) RestartCampaign(ctx context.Context, campaignID uuid.UUID) error {
+	_, err := a.o.RestartCampaign(ctx, campaignID)
+	return err
+}
+
+func (a *scheduleRunnerAdapter) StartPhase(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum) error {
+	return a.o.StartPhaseInternal(ctx, campaignID, phase)
+}
+
+func (a *scheduleRunnerAdapter) ActivePhase(ctx context.Context, campaignID uuid.UUID) (models.PhaseTypeEnum, bool, error) {
+	phases := append([]models.PhaseTypeEnum{models.PhaseTypeDomainGeneration}, restartablePhaseSequence...)
+	for _, phase := range phases {
+		st, err := a.o.GetPhaseStatus(ctx, campaignID, phase)
+		if err != nil {
+			return "", false, fmt.Errorf("phase %s status: %w", phase, err)
+		}
+		if st == nil {
+			continue
+		}
+		if st.Status == models.PhaseStatusInProgress || st.Status == models.PhaseStatusPaused {
+			return phase, true, nil
+		}
+	}
+	return "", false, nil
+}
+
 func extractCampaignIDFromPayload(payload map[string]interface{}) (uuid.UUID, bool) {
 	if payload == nil {
 		return uuid.Nil, false
@@ -296,6 +362,17 @@ func initAppDependencies() (*AppDeps, error) {
 		deps.Stores.Keyword = pg_store.NewKeywordStorePostgres(db)
 		deps.Stores.AuditLog = pg_store.NewAuditLogStorePostgres(db)
 		deps.Stores.CampaignJob = pg_store.NewCampaignJobStorePostgres(db)
+		deps.Stores.WordLists = pg_store.NewWordListStorePostgres(db)
+		deps.Stores.Imports = pg_store.NewDomainImportStorePostgres(db)
+		deps.Stores.Exports = pg_store.NewDomainExportStorePostgres(db)
+		deps.Stores.Webhooks = pg_store.NewWebhookStorePostgres(db)
+		deps.Stores.SSEJournal = pg_store.NewSSEEventJournalStorePostgres(db)
+		deps.Stores.Schedules = pg_store.NewCampaignScheduleStorePostgres(db)
+		deps.Stores.Snapshots = pg_store.NewDomainRunSnapshotStorePostgres(db)
+		deps.Stores.BulkOps = pg_store.NewBulkOperationStorePostgres(db)
+		deps.Stores.APIKeys = pg_store.NewAPIKeyStorePostgres(db)
+		deps.Stores.Authorization = pg_store.NewAuthorizationStorePostgres(db)
+		deps.Stores.ConfigVersions = pg_store.NewConfigVersionStorePostgres(db)
 
 		// Extraction metrics initialization (idempotent)
 		func() {
@@ -319,6 +396,43 @@ func initAppDependencies() (*AppDeps, error) {
 		deps.Session = sessSvc
 	}
 
+	// MFA stays unavailable (and MFA-enabled accounts cannot log in) when the key is misconfigured
+	deps.MFA = services.NewMFAService("DomainFlow")
+	if key, kerr := services.MFAEncryptionKey(); kerr != nil {
+		log.Printf("Warning: MFA disabled: %v; enrollment and logins to MFA-enabled accounts fail until MFA_ENCRYPTION_KEY is set", kerr)
+	} else if enc, eerr := services.NewEncryptionService(key); eerr == nil {
+		deps.FieldCrypto = enc
+	}
+
+	deps.APIKeys = services.NewAPIKeyService(deps.FieldCrypto)
+	if deps.Stores.APIKeys != nil {
+		db := deps.DB
+		deps.APIKeyAuth = &apiKeyAuthenticator{
+			keys:    deps.Stores.APIKeys,
+			hasher:  deps.APIKeys,
+			limiter: middleware.NewRateLimiter(db, nil),
+			userActive: func(ctx context.Context, userID uuid.UUID) (bool, error) {
+				var active bool
+				err := db.QueryRowxContext(ctx, `SELECT is_active FROM auth.users WHERE id = $1`, userID).Scan(&active)
+				if err == sql.ErrNoRows {
+					return false, nil
+				}
+				return active, err
+			},
+			now: time.Now,
+		}
+	}
+	if deps.Stores.Authorization != nil {
+		deps.Authorizer = newRequestAuthorizer(deps.Stores.Authorization, deps.Stores.BulkOps)
+	}
+	deps.Mailer = newMailer(appConfig.Server.AuthConfig)
+
+	// Settings saved through the API take precedence over the config file
+	deps.ConfigVersions = newConfigVersioner(deps.DB, deps.Stores.ConfigVersions, appConfig)
+	if err := deps.ConfigVersions.loadCurrent(context.Background()); err != nil {
+		log.Printf("Warning: stored config versions not loaded: %v", err)
+	}
+
 	// Initialize ProxyManager if DB and store available
 	if deps.DB != nil && deps.Stores.Proxy != nil {
 		pmCfg := appConfig.ProxyManager
@@ -330,8 +444,16 @@ func initAppDependencies() (*AppDeps, error) {
 			}
 		}
 		deps.ProxyMgr = proxymanager.NewProxyManager(appConfig.Proxies, pmCfg, deps.Stores.Proxy, deps.DB)
+		if deps.Stores.ProxyPools != nil {
+			deps.ProxyMgr.SetPoolStore(deps.Stores.ProxyPools)
+		}
 	}
 
+	// robots.txt cache and default policy shared by the validator, content fetcher and micro-crawlers
+	robotsCfg := appConfig.HTTPValidator.Robots
+	robots.Shared().Configure(robots.Options{TTL: robotsCfg.CacheTTL, MaxCrawlDelay: robotsCfg.MaxCrawlDelay})
+	robots.Shared().SetDefaultPolicy(robots.Policy{Respect: robotsCfg.Respect, EnforceRoot: robotsCfg.EnforceOnRootFetch})
+
 	// Initialize engines/services required by orchestrator
 	httpValSvc := httpvalidator.NewHTTPValidator(appConfig)
 	dnsValSvc := dnsvalidator.New(appConfig.DNSValidator)
@@ -366,17 +488,31 @@ func initAppDependencies() (*AppDeps, error) {
 		Cache:           cacheAdapter,
 		// EventBus, SSE, StealthIntegration provided where needed separately
 	}
+	if deps.ProxyMgr != nil {
+		domainDeps.ProxyPools = deps.ProxyMgr
+	}
 
 	// Provide EventBus adapter early so services can emit events
 	deps.SSE = services.NewSSEService()
 	deps.SSE.Start(context.Background())
+	// Journaling campaign events lets Last-Event-ID replay survive restarts; off by default
+	if deps.Stores.SSEJournal != nil && (strings.EqualFold(os.Getenv("SSE_REPLAY_PERSIST"), "true") || os.Getenv("SSE_REPLAY_PERSIST") == "1") {
+		deps.SSE.SetReplayJournal(context.Background(), deps.Stores.SSEJournal)
+	}
 	domainDeps.EventBus = &eventBusAdapter{sse: deps.SSE}
 
+	// Outbound webhooks observe the same lifecycle events the SSE service broadcasts
+	if deps.Stores.Webhooks != nil {
+		deps.Webhooks = webhooks.NewDispatcher(deps.Stores.Webhooks, webhooks.DefaultDispatcherConfig())
+		deps.SSE.AddObserver(deps.Webhooks.Observe)
+		deps.Webhooks.Start(context.Background())
+	}
+
 	// Use store-backed config manager and stealth adapter where applicable
 	domainDeps.ConfigManager = domaininfra.NewStoreBackedConfigManager(deps.Stores.Campaign)
 
-	domainGenSvc := domainservices.NewDomainGenerationService(deps.Stores.Campaign, domainDeps)
-	dnsValidationSvc := domainservices.NewDNSValidationService(dnsValSvc, deps.Stores.Campaign, domainDeps)
+	domainGenSvc := domainservices.NewDomainGenerationService(deps.Stores.Campaign, deps.Stores.WordLists, deps.Stores.Imports, domainDeps)
+	dnsValidationSvc := domainservices.NewDNSValidationService(dnsValSvc, deps.Stores.Campaign, deps.Stores.Persona, domainDeps)
 	httpValidationSvc := domainservices.NewHTTPValidationService(deps.Stores.Campaign, domainDeps, httpValSvc, deps.Stores.Persona, deps.Stores.Proxy, deps.Stores.Keyword)
 	enrichmentSvc := domainservices.NewEnrichmentService(deps.Stores.Campaign, domainDeps)
 	analysisSvc := domainservices.NewAnalysisService(deps.Stores.Campaign, domainDeps, contentFetcherSvc, deps.Stores.Persona, deps.Stores.Proxy)
@@ -441,12 +577,45 @@ func initAppDependencies() (*AppDeps, error) {
 			deps.Metrics,
 		)
 
+		// Optional RDAP registration lookup phase
+		rdapCfg := deps.Config.RDAP
+		rdapClient := rdap.NewClient(rdap.Config{
+			BootstrapSource:   rdapCfg.BootstrapSource,
+			BootstrapTTL:      time.Duration(rdapCfg.BootstrapTTLHours) * time.Hour,
+			Timeout:           time.Duration(rdapCfg.TimeoutSeconds) * time.Second,
+			RequestsPerSecond: rdapCfg.RequestsPerSecond,
+			Burst:             rdapCfg.Burst,
+			UserAgent:         deps.Config.HTTPValidator.DefaultUserAgent,
+		})
+		deps.Orchestrator.SetRDAPLookupService(domainservices.NewRDAPLookupService(deps.Stores.Campaign, domainDeps, rdapClient))
+
 		// Register post-completion hooks
 		deps.Orchestrator.RegisterPostCompletionHook(&application_hooks.SummaryReportHook{Store: deps.Stores.Campaign, Deps: domainDeps})
+		// Snapshot domain outcomes after each phase run for change detection between runs
+		if deps.Stores.Snapshots != nil {
+			deps.Orchestrator.RegisterPhaseCompletionHook(&application_hooks.RunSnapshotHook{
+				Snapshots: deps.Stores.Snapshots,
+				Campaigns: deps.Stores.Campaign,
+				Deps:      domainDeps,
+				SSE:       deps.SSE,
+			})
+		}
 
 		cfg := application.DefaultRehydrationWorkerConfig()
 		deps.RehydrationWorker = application.NewRehydrationWorker(deps.Orchestrator, domainDeps.Logger, cfg)
 		deps.RehydrationWorker.Start(context.Background())
+
+		// Cron-style campaign schedules; the first poll waits for in-flight phases to be restored
+		if deps.Stores.Schedules != nil {
+			deps.Scheduler = scheduler.New(deps.Stores.Schedules, &scheduleRunnerAdapter{o: deps.Orchestrator}, scheduler.DefaultConfig())
+			deps.Scheduler.Start(context.Background())
+		}
+
+		// Durable bulk operations; pending tasks left behind by a restart are launched once phases are restored
+		if deps.Stores.BulkOps != nil {
+			deps.BulkOps = NewBulkOpsTracker(deps.Stores.BulkOps, deps.Orchestrator)
+			deps.BulkOps.Start(context.Background())
+		}
 	}
 
 	// Monitoring and cleanup services
@@ -457,9 +626,6 @@ func initAppDependencies() (*AppDeps, error) {
 	// Global integration for convenience
 	monitoring.SetGlobalMonitoringIntegration(monitoring.NewCampaignMonitoringIntegration(deps.Monitoring))
 
-	// In-memory bulk operations tracker
-	deps.BulkOps = NewBulkOpsTracker()
-
 	// Start domain counters reconciliation job if enabled
 	if deps.DB != nil && deps.Config.Reconciliation.Enabled {
 		interval := time.Duration(deps.Config.Reconciliation.IntervalMinutes) * time.Minute
@@ -474,3 +640,34 @@ func initAppDependencies() (*AppDeps, error) {
 
 	return deps, nil
 }
+
+// newMailer returns an SMTP mailer when a relay is configured, and the development mailer otherwise.
+func newMailer(cfg *config.AuthConfig) mailer.Mailer {
+	if cfg == nil {
+		defaults := config.GetDefaultAuthConfig()
+		cfg = &defaults
+	}
+	from := mailer.Sender{Email: cfg.FromEmail, Name: cfg.FromName}
+	if from.Email == "" {
+		from.Email = "noreply@localhost"
+	}
+	if cfg.SMTPHost == "" {
+		if cfg.MailOutboxDir != "" {
+			log.Printf("SMTP_HOST not set; account emails will be written to %s", cfg.MailOutboxDir)
+		} else {
+			log.Printf("Warning: SMTP_HOST and MAIL_OUTBOX_DIR not set; account emails will be dropped")
+		}
+		return &mailer.LogMailer{From: from, Dir: cfg.MailOutboxDir}
+	}
+	if cfg.SMTPAllowInsecure {
+		log.Printf("Warning: SMTP_ALLOW_INSECURE set; account emails may be sent to %s without TLS", cfg.SMTPHost)
+	}
+	return mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host:          cfg.SMTPHost,
+		Port:          cfg.SMTPPort,
+		Username:      cfg.SMTPUsername,
+		Password:      cfg.SMTPPassword,
+		From:          from,
+		AllowInsecure: cfg.SMTPAllowInsecure,
+	})
+}
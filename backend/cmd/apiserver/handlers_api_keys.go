@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// maxAPIKeysPerUser bounds active keys per account.
+const maxAPIKeysPerUser = 25
+
+func toAPIKey(k *models.APIKey, secret string, now time.Time) gen.ApiKey {
+	out := gen.ApiKey{
+		Id:        openapi_types.UUID(k.ID),
+		Name:      k.Name,
+		KeyHint:   k.KeyHint,
+		Scopes:    make([]gen.ApiKeyScope, 0, len(k.Scopes)),
+		Status:    gen.Active,
+		CreatedAt: k.CreatedAt,
+	}
+	for _, s := range k.Scopes {
+		out.Scopes = append(out.Scopes, gen.ApiKeyScope(s))
+	}
+	switch {
+	case k.RevokedAt.Valid:
+		out.Status = gen.Revoked
+	case !k.Usable(now):
+		out.Status = gen.Expired
+	}
+	if secret != "" {
+		out.Key = &secret
+	}
+	if k.ExpiresAt.Valid {
+		out.ExpiresAt = &k.ExpiresAt.Time
+	}
+	if k.LastUsedAt.Valid {
+		out.LastUsedAt = &k.LastUsedAt.Time
+	}
+	if k.LastUsedIP.Valid {
+		out.LastUsedIp = &k.LastUsedIP.String
+	}
+	if k.RevokedAt.Valid {
+		out.RevokedAt = &k.RevokedAt.Time
+	}
+	if k.RotatedAt.Valid {
+		out.RotatedAt = &k.RotatedAt.Time
+	}
+	return out
+}
+
+func (h *strictHandlers) AuthApiKeysList(ctx context.Context, r gen.AuthApiKeysListRequestObject) (gen.AuthApiKeysListResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.APIKeys == nil || h.deps.DB == nil {
+		return gen.AuthApiKeysList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "API key store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.AuthApiKeysList401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	keys, err := h.deps.Stores.APIKeys.ListAPIKeys(ctx, h.deps.DB, userID)
+	if err != nil {
+		return gen.AuthApiKeysList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to list API keys", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	now := time.Now()
+	out := make([]gen.ApiKey, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, toAPIKey(k, "", now))
+	}
+	return gen.AuthApiKeysList200JSONResponse(out), nil
+}
+
+func (h *strictHandlers) AuthApiKeysCreate(ctx context.Context, r gen.AuthApiKeysCreateRequestObject) (gen.AuthApiKeysCreateResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.APIKeys == nil || h.deps.APIKeys == nil || h.deps.DB == nil {
+		return gen.AuthApiKeysCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "API key store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.AuthApiKeysCreate401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil || strings.TrimSpace(r.Body.Name) == "" || len(strings.TrimSpace(r.Body.Name)) > 100 {
+		return gen.AuthApiKeysCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "name is required (at most 100 characters)", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	scopes := pq.StringArray{models.APIKeyScopeRead, models.APIKeyScopeWrite}
+	if r.Body.Scopes != nil {
+		scopes = pq.StringArray{}
+		seen := map[string]bool{}
+		for _, s := range *r.Body.Scopes {
+			if s != gen.ApiKeyScopeRead && s != gen.ApiKeyScopeWrite {
+				return gen.AuthApiKeysCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "unknown scope " + string(s), Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+			}
+			if !seen[string(s)] {
+				seen[string(s)] = true
+				scopes = append(scopes, string(s))
+			}
+		}
+		if len(scopes) == 0 {
+			return gen.AuthApiKeysCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "at least one scope is required", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+	}
+	var expiresIn *time.Duration
+	if r.Body.ExpiresAt != nil {
+		d := time.Until(*r.Body.ExpiresAt)
+		if d <= 0 {
+			return gen.AuthApiKeysCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "expiresAt must be in the future", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		expiresIn = &d
+	}
+
+	existing, err := h.deps.Stores.APIKeys.ListAPIKeys(ctx, h.deps.DB, userID)
+	if err != nil {
+		return gen.AuthApiKeysCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to list API keys", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	now := time.Now().UTC()
+	active := 0
+	for _, k := range existing {
+		if k.Usable(now) {
+			active++
+		}
+	}
+	if active >= maxAPIKeysPerUser {
+		return gen.AuthApiKeysCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "too many active API keys; revoke one first", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+
+	issued, err := h.deps.APIKeys.CreateAPIKey(userID.String(), strings.TrimSpace(r.Body.Name), expiresIn)
+	if err != nil {
+		return gen.AuthApiKeysCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to generate API key", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	key := &models.APIKey{
+		ID:        issued.ID,
+		UserID:    userID,
+		Name:      issued.KeyName,
+		KeyHash:   issued.KeyHash,
+		KeyHint:   issued.KeyHint,
+		Scopes:    scopes,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if issued.ExpiresAt != nil {
+		key.ExpiresAt.Time, key.ExpiresAt.Valid = issued.ExpiresAt.UTC(), true
+	}
+	if err := h.deps.Stores.APIKeys.CreateAPIKey(ctx, h.deps.DB, key); err != nil {
+		return gen.AuthApiKeysCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to create API key", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	h.auditAuthEvent(ctx, "API_KEY_CREATED", &userID, map[string]string{"api_key_id": key.ID.String(), "name": key.Name, "scopes": strings.Join(scopes, ",")})
+	return gen.AuthApiKeysCreate201JSONResponse(toAPIKey(key, issued.Key, now)), nil
+}
+
+func (h *strictHandlers) AuthApiKeysRevoke(ctx context.Context, r gen.AuthApiKeysRevokeRequestObject) (gen.AuthApiKeysRevokeResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.APIKeys == nil || h.deps.DB == nil {
+		return gen.AuthApiKeysRevoke500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "API key store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.AuthApiKeysRevoke401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	keyID := uuid.UUID(r.KeyId)
+	if err := h.deps.Stores.APIKeys.RevokeAPIKey(ctx, h.deps.DB, userID, keyID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.AuthApiKeysRevoke404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "API key not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.AuthApiKeysRevoke500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to revoke API key", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	h.auditAuthEvent(ctx, "API_KEY_REVOKED", &userID, map[string]string{"api_key_id": keyID.String()})
+	return gen.AuthApiKeysRevoke204Response{}, nil
+}
+
+func (h *strictHandlers) AuthApiKeysRotate(ctx context.Context, r gen.AuthApiKeysRotateRequestObject) (gen.AuthApiKeysRotateResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.APIKeys == nil || h.deps.APIKeys == nil || h.deps.DB == nil {
+		return gen.AuthApiKeysRotate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "API key store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.AuthApiKeysRotate401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	keyID := uuid.UUID(r.KeyId)
+	current, err := h.deps.Stores.APIKeys.GetAPIKey(ctx, h.deps.DB, userID, keyID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.AuthApiKeysRotate404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "API key not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.AuthApiKeysRotate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch API key", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	issued, err := h.deps.APIKeys.CreateAPIKey(userID.String(), current.Name, nil)
+	if err != nil {
+		return gen.AuthApiKeysRotate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to generate API key", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	// Revoked keys are not rotated; the store reports them as not found
+	rotated, err := h.deps.Stores.APIKeys.RotateAPIKey(ctx, h.deps.DB, userID, keyID, issued.KeyHash, issued.KeyHint)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.AuthApiKeysRotate404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "API key not found or revoked", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.AuthApiKeysRotate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to rotate API key", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	h.auditAuthEvent(ctx, "API_KEY_ROTATED", &userID, map[string]string{"api_key_id": keyID.String()})
+	return gen.AuthApiKeysRotate200JSONResponse(toAPIKey(rotated, issued.Key, time.Now())), nil
+}
@@ -9,6 +9,7 @@ import (
 
 	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
 	"github.com/fntelecomllc/studio/backend/internal/logging"
+	"github.com/fntelecomllc/studio/backend/internal/utils"
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 	"golang.org/x/crypto/bcrypt"
@@ -38,9 +39,10 @@ func (h *strictHandlers) AuthLogin(ctx context.Context, r gen.AuthLoginRequestOb
 		passwordHash string
 		firstName    string
 		lastName     string
+		mfaEnabled   bool
 	)
-	q := `SELECT id, email, is_active, is_locked, locked_until, password_hash, first_name, last_name FROM auth.users WHERE LOWER(email) = LOWER($1) LIMIT 1`
-	if err := h.deps.DB.QueryRowxContext(ctx, q, attemptedEmail).Scan(&id, &email, &isActive, &isLocked, &lockedUntil, &passwordHash, &firstName, &lastName); err != nil {
+	q := `SELECT id, email, is_active, is_locked, locked_until, password_hash, first_name, last_name, mfa_enabled FROM auth.users WHERE LOWER(email) = LOWER($1) LIMIT 1`
+	if err := h.deps.DB.QueryRowxContext(ctx, q, attemptedEmail).Scan(&id, &email, &isActive, &isLocked, &lockedUntil, &passwordHash, &firstName, &lastName, &mfaEnabled); err != nil {
 		if err == sql.ErrNoRows {
 			// Log failed login - user not found
 			logAuthEvent("LOGIN_FAILURE", nil, clientIP, "user_not_found", map[string]interface{}{
@@ -67,9 +69,15 @@ func (h *strictHandlers) AuthLogin(ctx context.Context, r gen.AuthLoginRequestOb
 
 	if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(r.Body.Password)); err != nil {
 		logAuthEvent("LOGIN_FAILURE", &id, clientIP, "invalid_password", nil)
+		h.recordLoginFailure(ctx, id, clientIP)
 		return gen.AuthLogin401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "invalid credentials", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
 
+	// Accounts with MFA get a challenge instead of a session; AuthLoginMfa finishes the login
+	if mfaEnabled {
+		return h.issueMFAChallenge(ctx, id, clientIP)
+	}
+
 	// Create session (client IP/UA may not be available here; use empty strings)
 	sd, err := h.deps.Session.CreateSession(id, clientIP, "")
 	if err != nil {
@@ -79,6 +87,8 @@ func (h *strictHandlers) AuthLogin(ctx context.Context, r gen.AuthLoginRequestOb
 		return gen.AuthLogin500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to create session", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
 
+	h.clearLoginFailures(ctx, id)
+
 	// Log successful login
 	logAuthEvent("LOGIN_SUCCESS", &id, clientIP, "", map[string]interface{}{
 		"session_id":  sd.ID,
@@ -92,6 +102,56 @@ func (h *strictHandlers) AuthLogin(ctx context.Context, r gen.AuthLoginRequestOb
 	return gen.AuthLogin200JSONResponse(data), nil
 }
 
+// recordLoginFailure counts a wrong password or MFA code against the account and, once
+// MaxFailedAttempts is reached, locks it for AccountLockDuration and starts the count over.
+func (h *strictHandlers) recordLoginFailure(ctx context.Context, userID uuid.UUID, clientIP string) {
+	settings := h.authSettings()
+	if settings.MaxFailedAttempts <= 0 || settings.AccountLockDuration <= 0 {
+		return
+	}
+	var attempts int
+	var lockedUntil sql.NullTime
+	err := h.deps.DB.QueryRowxContext(ctx, `UPDATE auth.users SET
+		is_locked = CASE WHEN failed_login_attempts + 1 >= $2 THEN TRUE ELSE is_locked END,
+		locked_until = CASE WHEN failed_login_attempts + 1 >= $2 THEN NOW() + make_interval(secs => $3) ELSE locked_until END,
+		failed_login_attempts = CASE WHEN failed_login_attempts + 1 >= $2 THEN 0 ELSE failed_login_attempts + 1 END,
+		updated_at = NOW()
+		WHERE id = $1
+		RETURNING failed_login_attempts, locked_until`, userID, settings.MaxFailedAttempts, settings.AccountLockDuration.Seconds()).Scan(&attempts, &lockedUntil)
+	if err != nil {
+		logAuthEvent("LOGIN_FAILURE", &userID, clientIP, "failed_attempt_not_recorded", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	if attempts == 0 && lockedUntil.Valid {
+		h.auditAuthEvent(ctx, "ACCOUNT_LOCKED", &userID, map[string]string{"locked_until": lockedUntil.Time.UTC().Format(time.RFC3339)})
+	}
+}
+
+// clearLoginFailures resets the failure count and any expired lockout after a completed login.
+// A lock without an expiry was set by hand and is left alone.
+func (h *strictHandlers) clearLoginFailures(ctx context.Context, userID uuid.UUID) {
+	_, _ = h.deps.DB.ExecContext(ctx, `UPDATE auth.users SET failed_login_attempts = 0, is_locked = is_locked AND locked_until IS NULL, locked_until = NULL
+		WHERE id = $1 AND (failed_login_attempts > 0 OR locked_until IS NOT NULL)`, userID)
+}
+
+// auditAuthEvent records an account security event (MFA, API keys) in both the auth event log and the audit trail.
+func (h *strictHandlers) auditAuthEvent(ctx context.Context, event string, userID *uuid.UUID, details map[string]string) {
+	clientIP, _ := ctx.Value("client_ip").(string)
+	logDetails := make(map[string]interface{}, len(details))
+	for k, v := range details {
+		logDetails[k] = v
+	}
+	logAuthEvent(event, userID, clientIP, "", logDetails)
+	if h.deps == nil || h.deps.Stores.AuditLog == nil || h.deps.DB == nil {
+		return
+	}
+	if details == nil {
+		details = map[string]string{}
+	}
+	details["client_ip"] = clientIP
+	utils.NewAuditLogger(h.deps.Stores.AuditLog).LogGenericEvent(ctx, h.deps.DB, userID, strings.ToLower(event), "user", userID, details)
+}
+
 // logAuthEvent logs an authentication event to the audit log
 func logAuthEvent(eventType string, userID *uuid.UUID, ipAddress, reason string, details map[string]interface{}) {
 	status := "SUCCESS"
@@ -0,0 +1,426 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// mfaChallengeTTL is how long a password-verified login waits for its second factor.
+	mfaChallengeTTL         = 5 * time.Minute
+	mfaChallengeMaxAttempts = 5
+)
+
+// mfaState is a user's decrypted MFA enrollment.
+type mfaState struct {
+	enabled      bool
+	secret       string // empty when not enrolled
+	backupHashes []string
+	lastUsedAt   sql.NullTime
+	lastTOTPStep int64 // last accepted TOTP time step; codes for it or earlier steps are replays
+}
+
+func (h *strictHandlers) mfaAvailable() bool {
+	return h.deps != nil && h.deps.DB != nil && h.deps.MFA != nil && h.deps.FieldCrypto != nil
+}
+
+// loadMFAState reads and decrypts a user's MFA columns; forUpdate locks the row inside a transaction.
+func (h *strictHandlers) loadMFAState(ctx context.Context, q sqlx.QueryerContext, userID uuid.UUID, forUpdate bool) (*mfaState, error) {
+	query := `SELECT mfa_enabled, mfa_secret_encrypted, mfa_backup_codes_encrypted, mfa_last_used_at, mfa_last_totp_step FROM auth.users WHERE id = $1`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+	var (
+		st               mfaState
+		secretEnc, bcEnc []byte
+		lastStep         sql.NullInt64
+	)
+	if err := q.QueryRowxContext(ctx, query, userID).Scan(&st.enabled, &secretEnc, &bcEnc, &st.lastUsedAt, &lastStep); err != nil {
+		return nil, err
+	}
+	st.lastTOTPStep = lastStep.Int64
+	if len(secretEnc) > 0 {
+		secret, err := h.deps.FieldCrypto.DecryptBytes(secretEnc)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt MFA secret: %w", err)
+		}
+		st.secret = string(secret)
+	}
+	if len(bcEnc) > 0 {
+		raw, err := h.deps.FieldCrypto.DecryptBytes(bcEnc)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt backup codes: %w", err)
+		}
+		if err := json.Unmarshal(raw, &st.backupHashes); err != nil {
+			return nil, fmt.Errorf("decode backup codes: %w", err)
+		}
+	}
+	return &st, nil
+}
+
+// sealBackupHashes encrypts the remaining backup code hashes for mfa_backup_codes_encrypted.
+func (h *strictHandlers) sealBackupHashes(hashes []string) ([]byte, error) {
+	raw, err := json.Marshal(hashes)
+	if err != nil {
+		return nil, err
+	}
+	return h.deps.FieldCrypto.EncryptBytes(raw)
+}
+
+func mfaChallengeHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// issueMFAChallenge answers a password-verified login for an MFA-enabled account.
+func (h *strictHandlers) issueMFAChallenge(ctx context.Context, userID uuid.UUID, clientIP string) (gen.AuthLoginResponseObject, error) {
+	if !h.mfaAvailable() {
+		logAuthEvent("LOGIN_FAILURE", &userID, clientIP, "mfa_unavailable", nil)
+		return gen.AuthLogin500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "multi-factor authentication unavailable", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return gen.AuthLogin500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to create MFA challenge", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	expiresAt := time.Now().Add(mfaChallengeTTL)
+	_, _ = h.deps.DB.ExecContext(ctx, `DELETE FROM public.mfa_challenges WHERE expires_at < NOW()`)
+	if _, err := h.deps.DB.ExecContext(ctx, `INSERT INTO public.mfa_challenges (token_hash, user_id, client_ip, max_attempts, expires_at) VALUES ($1, $2, $3, $4, $5)`,
+		mfaChallengeHash(token), userID, clientIP, mfaChallengeMaxAttempts, expiresAt); err != nil {
+		return gen.AuthLogin500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to create MFA challenge", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	h.auditAuthEvent(ctx, "MFA_CHALLENGE_ISSUED", &userID, map[string]string{"expires_at": expiresAt.UTC().Format(time.RFC3339)})
+	return gen.AuthLogin202JSONResponse{
+		MfaRequired:    true,
+		ChallengeToken: token,
+		ExpiresAt:      expiresAt,
+		Methods:        []gen.MfaChallengeResponseMethods{gen.Totp, gen.Backup},
+	}, nil
+}
+
+// AuthLoginMfa completes a two-step login with a TOTP code or a single-use backup code.
+func (h *strictHandlers) AuthLoginMfa(ctx context.Context, r gen.AuthLoginMfaRequestObject) (gen.AuthLoginMfaResponseObject, error) {
+	clientIP, _ := ctx.Value("client_ip").(string)
+	if !h.mfaAvailable() || h.deps.Session == nil {
+		return gen.AuthLoginMfa500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "multi-factor authentication unavailable", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil || strings.TrimSpace(r.Body.ChallengeToken) == "" {
+		return gen.AuthLoginMfa400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "challengeToken required", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	var code, backupCode string
+	if r.Body.Code != nil {
+		code = strings.TrimSpace(*r.Body.Code)
+	}
+	if r.Body.BackupCode != nil {
+		backupCode = strings.TrimSpace(*r.Body.BackupCode)
+	}
+	if (code == "") == (backupCode == "") {
+		return gen.AuthLoginMfa400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "exactly one of code or backupCode required", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	tokenHash := mfaChallengeHash(strings.TrimSpace(r.Body.ChallengeToken))
+
+	// Consume an attempt before verifying so parallel guesses cannot exceed the limit
+	var (
+		userID                uuid.UUID
+		attempts, maxAttempts int
+	)
+	err := h.deps.DB.QueryRowxContext(ctx, `UPDATE public.mfa_challenges SET attempts = attempts + 1
+		WHERE token_hash = $1 AND expires_at > NOW() AND attempts < max_attempts
+		RETURNING user_id, attempts, max_attempts`, tokenHash).Scan(&userID, &attempts, &maxAttempts)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			h.auditAuthEvent(ctx, "MFA_CHALLENGE_FAILURE", nil, map[string]string{"reason": "challenge_invalid_or_expired"})
+			return gen.AuthLoginMfa401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "MFA challenge invalid or expired; log in again", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.AuthLoginMfa500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load MFA challenge", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+
+	var (
+		email, firstName, lastName string
+		isActive, isLocked         bool
+		lockedUntil                sql.NullTime
+	)
+	if err := h.deps.DB.QueryRowxContext(ctx, `SELECT email, is_active, is_locked, locked_until, first_name, last_name FROM auth.users WHERE id = $1`, userID).Scan(&email, &isActive, &isLocked, &lockedUntil, &firstName, &lastName); err != nil {
+		return gen.AuthLoginMfa500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load user", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	// A lockout reached during the challenge (here or via password guesses) ends it
+	if isLocked && lockedUntil.Valid && lockedUntil.Time.After(time.Now()) {
+		_, _ = h.deps.DB.ExecContext(ctx, `DELETE FROM public.mfa_challenges WHERE token_hash = $1`, tokenHash)
+		logAuthEvent("LOGIN_FAILURE", &userID, clientIP, "account_locked", map[string]interface{}{
+			"locked_until": lockedUntil.Time.Format(time.RFC3339),
+		})
+		return gen.AuthLoginMfa401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "account locked", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+
+	method, remaining, ok, err := h.verifySecondFactor(ctx, userID, code, backupCode, tokenHash)
+	if err != nil {
+		return gen.AuthLoginMfa500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to verify MFA code", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if !ok {
+		if attempts >= maxAttempts {
+			_, _ = h.deps.DB.ExecContext(ctx, `DELETE FROM public.mfa_challenges WHERE token_hash = $1`, tokenHash)
+		}
+		h.auditAuthEvent(ctx, "MFA_CHALLENGE_FAILURE", &userID, map[string]string{"method": method, "reason": "invalid_code", "attempt": fmt.Sprint(attempts)})
+		// Each challenge allows a few guesses, but a fresh password login issues a new one, so the
+		// account-wide counter is what bounds guessing across challenges
+		h.recordLoginFailure(ctx, userID, clientIP)
+		return gen.AuthLoginMfa401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "invalid MFA code", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+
+	if !isActive {
+		logAuthEvent("LOGIN_FAILURE", &userID, clientIP, "account_disabled", nil)
+		return gen.AuthLoginMfa401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "account disabled", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	details := map[string]string{"method": method}
+	if method == "backup" {
+		details["backup_codes_remaining"] = fmt.Sprint(remaining)
+	}
+	h.auditAuthEvent(ctx, "MFA_CHALLENGE_SUCCESS", &userID, details)
+	h.clearLoginFailures(ctx, userID)
+
+	sd, err := h.deps.Session.CreateSession(userID, clientIP, "")
+	if err != nil {
+		logAuthEvent("LOGIN_FAILURE", &userID, clientIP, "session_creation_failed", map[string]interface{}{"error": err.Error()})
+		return gen.AuthLoginMfa500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to create session", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	logAuthEvent("LOGIN_SUCCESS", &userID, clientIP, "", map[string]interface{}{"session_id": sd.ID, "mfa_method": method})
+
+	u := gen.UserPublicResponse{Email: openapi_types.Email(email), Id: openapi_types.UUID(userID), IsActive: isActive, Username: deriveUsername(email, firstName, lastName)}
+	return gen.AuthLoginMfa200JSONResponse(gen.SessionResponse{Token: sd.ID, ExpiresAt: sd.ExpiresAt, User: u}), nil
+}
+
+// verifySecondFactor checks a TOTP or backup code for a login challenge. A redeemed backup code is
+// removed, the accepted TOTP step recorded and the challenge deleted in the same transaction, so none
+// of them can be used twice.
+func (h *strictHandlers) verifySecondFactor(ctx context.Context, userID uuid.UUID, code, backupCode, tokenHash string) (method string, backupRemaining int, ok bool, err error) {
+	method = "totp"
+	if backupCode != "" {
+		method = "backup"
+	}
+	tx, err := h.deps.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return method, 0, false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	st, err := h.loadMFAState(ctx, tx, userID, true)
+	if err != nil {
+		return method, 0, false, err
+	}
+	if !st.enabled || st.secret == "" {
+		return method, 0, false, nil
+	}
+	if backupCode != "" {
+		remaining, redeemed := h.deps.MFA.RedeemBackupCode(st.backupHashes, backupCode)
+		if !redeemed {
+			return method, 0, false, nil
+		}
+		sealed, err := h.sealBackupHashes(remaining)
+		if err != nil {
+			return method, 0, false, err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE auth.users SET mfa_backup_codes_encrypted = $1 WHERE id = $2`, sealed, userID); err != nil {
+			return method, 0, false, err
+		}
+		backupRemaining = len(remaining)
+	} else {
+		step, valid := h.deps.MFA.VerifyTOTPStep(st.secret, code, st.lastTOTPStep)
+		if !valid {
+			return method, 0, false, nil
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE auth.users SET mfa_last_totp_step = $1 WHERE id = $2`, step, userID); err != nil {
+			return method, 0, false, err
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM public.mfa_challenges WHERE token_hash = $1`, tokenHash)
+	if err != nil {
+		return method, 0, false, err
+	}
+	if n, _ := res.RowsAffected(); n != 1 {
+		return method, 0, false, nil // answered concurrently
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE auth.users SET mfa_last_used_at = NOW() WHERE id = $1`, userID); err != nil {
+		return method, 0, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return method, 0, false, err
+	}
+	return method, backupRemaining, true, nil
+}
+
+func mfaStatusResponse(st *mfaState) gen.MfaStatusResponse {
+	resp := gen.MfaStatusResponse{Enabled: st.enabled, PendingEnrollment: !st.enabled && st.secret != ""}
+	if st.enabled {
+		resp.BackupCodesRemaining = len(st.backupHashes)
+	}
+	if st.lastUsedAt.Valid {
+		resp.LastUsedAt = &st.lastUsedAt.Time
+	}
+	return resp
+}
+
+// AuthMfaStatus reports whether the current user has MFA enabled.
+func (h *strictHandlers) AuthMfaStatus(ctx context.Context, r gen.AuthMfaStatusRequestObject) (gen.AuthMfaStatusResponseObject, error) {
+	if !h.mfaAvailable() {
+		return gen.AuthMfaStatus500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "multi-factor authentication unavailable", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.AuthMfaStatus401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	st, err := h.loadMFAState(ctx, h.deps.DB, userID, false)
+	if err != nil {
+		return gen.AuthMfaStatus500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load MFA status", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.AuthMfaStatus200JSONResponse(mfaStatusResponse(st)), nil
+}
+
+// AuthMfaEnroll generates a pending TOTP secret and backup codes; AuthMfaVerify turns MFA on.
+func (h *strictHandlers) AuthMfaEnroll(ctx context.Context, r gen.AuthMfaEnrollRequestObject) (gen.AuthMfaEnrollResponseObject, error) {
+	if !h.mfaAvailable() {
+		return gen.AuthMfaEnroll500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "multi-factor authentication unavailable", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.AuthMfaEnroll401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	var (
+		email   string
+		enabled bool
+	)
+	if err := h.deps.DB.QueryRowxContext(ctx, `SELECT email, mfa_enabled FROM auth.users WHERE id = $1`, userID).Scan(&email, &enabled); err != nil {
+		return gen.AuthMfaEnroll500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load user", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if enabled {
+		return gen.AuthMfaEnroll409JSONResponse{ConflictJSONResponse: gen.ConflictJSONResponse{Error: gen.ApiError{Message: "MFA is already enabled; disable it before enrolling again", Code: gen.CONFLICT, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	secret, err := h.deps.MFA.GenerateTOTPSecret(userID.String(), email)
+	if err != nil {
+		return gen.AuthMfaEnroll500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to generate MFA secret", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	hashes := make([]string, len(secret.BackupCodes))
+	for i, c := range secret.BackupCodes {
+		hashes[i] = h.deps.MFA.HashBackupCode(c)
+	}
+	secretEnc, err := h.deps.FieldCrypto.EncryptBytes([]byte(secret.Secret))
+	var codesEnc []byte
+	if err == nil {
+		codesEnc, err = h.sealBackupHashes(hashes)
+	}
+	if err != nil {
+		return gen.AuthMfaEnroll500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to encrypt MFA secret", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	res, err := h.deps.DB.ExecContext(ctx, `UPDATE auth.users SET mfa_secret_encrypted = $1, mfa_backup_codes_encrypted = $2, mfa_last_totp_step = NULL, updated_at = NOW() WHERE id = $3 AND mfa_enabled = FALSE`, secretEnc, codesEnc, userID)
+	if err != nil {
+		return gen.AuthMfaEnroll500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to save MFA enrollment", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return gen.AuthMfaEnroll409JSONResponse{ConflictJSONResponse: gen.ConflictJSONResponse{Error: gen.ApiError{Message: "MFA is already enabled; disable it before enrolling again", Code: gen.CONFLICT, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	h.auditAuthEvent(ctx, "MFA_ENROLLMENT_STARTED", &userID, nil)
+	return gen.AuthMfaEnroll200JSONResponse{Secret: secret.Secret, OtpauthUrl: secret.QRCode, BackupCodes: secret.BackupCodes}, nil
+}
+
+// AuthMfaVerify confirms a pending enrollment with a code from the authenticator and enables MFA.
+func (h *strictHandlers) AuthMfaVerify(ctx context.Context, r gen.AuthMfaVerifyRequestObject) (gen.AuthMfaVerifyResponseObject, error) {
+	if !h.mfaAvailable() {
+		return gen.AuthMfaVerify500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "multi-factor authentication unavailable", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.AuthMfaVerify401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil || strings.TrimSpace(r.Body.Code) == "" {
+		return gen.AuthMfaVerify400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "code required", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	st, err := h.loadMFAState(ctx, h.deps.DB, userID, false)
+	if err != nil {
+		return gen.AuthMfaVerify500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load MFA status", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if st.enabled {
+		return gen.AuthMfaVerify200JSONResponse(mfaStatusResponse(st)), nil
+	}
+	if st.secret == "" {
+		return gen.AuthMfaVerify400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "no pending MFA enrollment; call /auth/mfa/enroll first", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	step, valid := h.deps.MFA.VerifyTOTPStep(st.secret, r.Body.Code, st.lastTOTPStep)
+	if !valid {
+		h.auditAuthEvent(ctx, "MFA_ENROLLMENT_FAILURE", &userID, map[string]string{"reason": "invalid_code"})
+		return gen.AuthMfaVerify400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "invalid code", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	// Recording the step keeps the enrollment code from also completing a login
+	if _, err := h.deps.DB.ExecContext(ctx, `UPDATE auth.users SET mfa_enabled = TRUE, mfa_last_used_at = NOW(), mfa_last_totp_step = $1, updated_at = NOW() WHERE id = $2`, step, userID); err != nil {
+		return gen.AuthMfaVerify500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to enable MFA", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	h.auditAuthEvent(ctx, "MFA_ENABLED", &userID, nil)
+	now := time.Now()
+	st.enabled, st.lastUsedAt = true, sql.NullTime{Time: now, Valid: true}
+	return gen.AuthMfaVerify200JSONResponse(mfaStatusResponse(st)), nil
+}
+
+// AuthMfaDisable turns MFA off after re-checking the password and an unused TOTP or backup code.
+func (h *strictHandlers) AuthMfaDisable(ctx context.Context, r gen.AuthMfaDisableRequestObject) (gen.AuthMfaDisableResponseObject, error) {
+	if !h.mfaAvailable() {
+		return gen.AuthMfaDisable500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "multi-factor authentication unavailable", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.AuthMfaDisable401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil || r.Body.Password == "" || strings.TrimSpace(r.Body.Code) == "" {
+		return gen.AuthMfaDisable400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "password and code required", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	var passwordHash string
+	if err := h.deps.DB.QueryRowxContext(ctx, `SELECT password_hash FROM auth.users WHERE id = $1`, userID).Scan(&passwordHash); err != nil {
+		return gen.AuthMfaDisable500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load user", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(r.Body.Password)) != nil {
+		h.auditAuthEvent(ctx, "MFA_DISABLE_FAILURE", &userID, map[string]string{"reason": "invalid_password"})
+		return gen.AuthMfaDisable401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "invalid credentials", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	// The row stays locked from the code check to the update so a code cannot be spent twice concurrently
+	tx, err := h.deps.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return gen.AuthMfaDisable500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load MFA status", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	defer func() { _ = tx.Rollback() }()
+	st, err := h.loadMFAState(ctx, tx, userID, true)
+	if err != nil {
+		return gen.AuthMfaDisable500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load MFA status", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if !st.enabled {
+		return gen.AuthMfaDisable400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "MFA is not enabled", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	_, valid := h.deps.MFA.VerifyTOTPStep(st.secret, r.Body.Code, st.lastTOTPStep)
+	if !valid {
+		_, valid = h.deps.MFA.RedeemBackupCode(st.backupHashes, r.Body.Code)
+	}
+	if !valid {
+		h.auditAuthEvent(ctx, "MFA_DISABLE_FAILURE", &userID, map[string]string{"reason": "invalid_code"})
+		return gen.AuthMfaDisable401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "invalid MFA code", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE auth.users SET mfa_enabled = FALSE, mfa_secret_encrypted = NULL, mfa_backup_codes_encrypted = NULL, mfa_last_totp_step = NULL, updated_at = NOW() WHERE id = $1`, userID); err != nil {
+		return gen.AuthMfaDisable500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to disable MFA", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return gen.AuthMfaDisable500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to disable MFA", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	_, _ = h.deps.DB.ExecContext(ctx, `DELETE FROM public.mfa_challenges WHERE user_id = $1`, userID)
+	h.auditAuthEvent(ctx, "MFA_DISABLED", &userID, nil)
+	return gen.AuthMfaDisable200JSONResponse(mfaStatusResponse(&mfaState{lastUsedAt: st.lastUsedAt})), nil
+}
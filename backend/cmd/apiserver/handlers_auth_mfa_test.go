@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/services"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+func newMFATestHandlers(t *testing.T) (*strictHandlers, sqlmock.Sqlmock, string, []byte) {
+	t.Helper()
+	db, mock := createTestDBWithMock(t)
+	crypto, err := services.NewEncryptionService(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatalf("encryption service: %v", err)
+	}
+	mfa := services.NewMFAService("DomainFlow")
+	secret, err := mfa.GenerateTOTPSecret("user", "user@example.com")
+	if err != nil {
+		t.Fatalf("generate secret: %v", err)
+	}
+	secretEnc, err := crypto.EncryptBytes([]byte(secret.Secret))
+	if err != nil {
+		t.Fatalf("encrypt secret: %v", err)
+	}
+	return &strictHandlers{deps: &AppDeps{DB: db, MFA: mfa, FieldCrypto: crypto}}, mock, secret.Secret, secretEnc
+}
+
+func currentTOTP(t *testing.T, secret string) (string, int64) {
+	t.Helper()
+	now := time.Now()
+	code, err := totp.GenerateCodeCustom(secret, now, totp.ValidateOpts{Period: 30, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1})
+	if err != nil {
+		t.Fatalf("generate code: %v", err)
+	}
+	return code, now.Unix() / 30
+}
+
+func TestVerifySecondFactorRecordsAcceptedTOTPStep(t *testing.T) {
+	h, mock, secret, secretEnc := newMFATestHandlers(t)
+	userID := uuid.New()
+	code, step := currentTOTP(t, secret)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT mfa_enabled, .+ FROM auth.users WHERE id = \$1 FOR UPDATE`).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"mfa_enabled", "mfa_secret_encrypted", "mfa_backup_codes_encrypted", "mfa_last_used_at", "mfa_last_totp_step"}).
+			AddRow(true, secretEnc, nil, nil, step-2))
+	mock.ExpectExec(`UPDATE auth.users SET mfa_last_totp_step = \$1 WHERE id = \$2`).WithArgs(step, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM public.mfa_challenges WHERE token_hash = \$1`).WithArgs("challenge").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE auth.users SET mfa_last_used_at = NOW\(\) WHERE id = \$1`).WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	method, _, ok, err := h.verifySecondFactor(context.Background(), userID, code, "", "challenge")
+	if err != nil || !ok || method != "totp" {
+		t.Fatalf("expected the current code to verify, got %s %v %v", method, ok, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestVerifySecondFactorRejectsReplayedTOTP(t *testing.T) {
+	h, mock, secret, secretEnc := newMFATestHandlers(t)
+	userID := uuid.New()
+	code, step := currentTOTP(t, secret)
+
+	// The code's step has already been accepted once
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT mfa_enabled, .+ FROM auth.users WHERE id = \$1 FOR UPDATE`).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"mfa_enabled", "mfa_secret_encrypted", "mfa_backup_codes_encrypted", "mfa_last_used_at", "mfa_last_totp_step"}).
+			AddRow(true, secretEnc, nil, time.Now(), step))
+	mock.ExpectRollback()
+
+	_, _, ok, err := h.verifySecondFactor(context.Background(), userID, code, "", "challenge")
+	if err != nil || ok {
+		t.Fatalf("expected a replayed code to be rejected, got %v %v", ok, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestAuthLoginMfaFailedCodeCountsTowardLockout(t *testing.T) {
+	h, mock, _, secretEnc := newMFATestHandlers(t)
+	h.deps.Session = &services.SessionService{}
+	userID := uuid.New()
+	wrong := "000000"
+	lockedUntil := time.Now().Add(15 * time.Minute)
+
+	mock.ExpectQuery(`UPDATE public.mfa_challenges SET attempts = attempts \+ 1`).WithArgs(mfaChallengeHash("challenge")).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "attempts", "max_attempts"}).AddRow(userID, 1, 5))
+	mock.ExpectQuery(`SELECT email, is_active, is_locked, locked_until, first_name, last_name FROM auth.users WHERE id = \$1`).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"email", "is_active", "is_locked", "locked_until", "first_name", "last_name"}).
+			AddRow("user@example.com", true, false, nil, "", ""))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT mfa_enabled, .+ FROM auth.users WHERE id = \$1 FOR UPDATE`).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"mfa_enabled", "mfa_secret_encrypted", "mfa_backup_codes_encrypted", "mfa_last_used_at", "mfa_last_totp_step"}).
+			AddRow(true, secretEnc, nil, nil, 0))
+	mock.ExpectRollback()
+	// The fifth account-wide failure locks the account and starts the count over
+	mock.ExpectQuery(`UPDATE auth.users SET\s+is_locked = .+ failed_login_attempts = CASE .+ RETURNING failed_login_attempts, locked_until`).
+		WithArgs(userID, 5, (15 * time.Minute).Seconds()).
+		WillReturnRows(sqlmock.NewRows([]string{"failed_login_attempts", "locked_until"}).AddRow(0, lockedUntil))
+
+	resp, err := h.AuthLoginMfa(context.Background(), gen.AuthLoginMfaRequestObject{Body: &gen.AuthLoginMfaJSONRequestBody{ChallengeToken: "challenge", Code: &wrong}})
+	if err != nil {
+		t.Fatalf("AuthLoginMfa: %v", err)
+	}
+	if _, ok := resp.(gen.AuthLoginMfa401JSONResponse); !ok {
+		t.Fatalf("expected 401 for a wrong code, got %T", resp)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestAuthLoginMfaLockedAccountEndsChallenge(t *testing.T) {
+	h, mock, secret, _ := newMFATestHandlers(t)
+	h.deps.Session = &services.SessionService{}
+	userID := uuid.New()
+	code, _ := currentTOTP(t, secret)
+
+	mock.ExpectQuery(`UPDATE public.mfa_challenges SET attempts = attempts \+ 1`).WithArgs(mfaChallengeHash("challenge")).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "attempts", "max_attempts"}).AddRow(userID, 1, 5))
+	mock.ExpectQuery(`SELECT email, is_active, is_locked, locked_until, first_name, last_name FROM auth.users WHERE id = \$1`).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"email", "is_active", "is_locked", "locked_until", "first_name", "last_name"}).
+			AddRow("user@example.com", true, true, time.Now().Add(10*time.Minute), "", ""))
+	mock.ExpectExec(`DELETE FROM public.mfa_challenges WHERE token_hash = \$1`).WithArgs(mfaChallengeHash("challenge")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Even a valid code is refused while the account is locked
+	resp, err := h.AuthLoginMfa(context.Background(), gen.AuthLoginMfaRequestObject{Body: &gen.AuthLoginMfaJSONRequestBody{ChallengeToken: "challenge", Code: &code}})
+	if err != nil {
+		t.Fatalf("AuthLoginMfa: %v", err)
+	}
+	if _, ok := resp.(gen.AuthLoginMfa401JSONResponse); !ok {
+		t.Fatalf("expected 401 for a locked account, got %T", resp)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
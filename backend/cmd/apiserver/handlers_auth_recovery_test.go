@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"database/sql/driver"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/mailer"
+	"github.com/fntelecomllc/studio/backend/internal/services"
+	"github.com/google/uuid"
+)
+
+type captureMailer chan mailer.Message
+
+func (c captureMailer) Send(_ context.Context, msg mailer.Message) error {
+	c <- msg
+	return nil
+}
+
+// captureArg matches any string argument and records it.
+type captureArg struct{ dst *string }
+
+func (m captureArg) Match(v driver.Value) bool {
+	*m.dst, _ = v.(string)
+	return true
+}
+
+func TestAuthPasswordResetRequest_StoresHashAndEmailsToken(t *testing.T) {
+	db, mock := createTestDBWithMock(t)
+	sent := make(captureMailer, 1)
+	h := &strictHandlers{deps: &AppDeps{DB: db, Mailer: sent}}
+	userID := uuid.New()
+
+	mock.ExpectQuery(`SELECT id, email, first_name FROM auth.users WHERE LOWER\(email\) = LOWER\(\$1\)`).
+		WithArgs("Ops@Example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "first_name"}).AddRow(userID, "ops@example.com", "Ops"))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM password_reset_tokens`).
+		WithArgs(userID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE password_reset_tokens SET used_at = NOW\(\) WHERE user_id = \$1 AND used_at IS NULL`).
+		WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	var storedHash string
+	mock.ExpectExec(`INSERT INTO password_reset_tokens`).
+		WithArgs(sqlmock.AnyArg(), userID, captureArg{&storedHash}, sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	resp, err := h.AuthPasswordResetRequest(context.Background(), gen.AuthPasswordResetRequestRequestObject{Body: &gen.PasswordResetRequest{Email: "Ops@Example.com"}})
+	if err != nil {
+		t.Fatalf("AuthPasswordResetRequest: %v", err)
+	}
+	if _, ok := resp.(gen.AuthPasswordResetRequest202Response); !ok {
+		t.Fatalf("expected 202, got %T", resp)
+	}
+
+	// The token is issued in the background; the email goes out once it is stored
+	var msg mailer.Message
+	select {
+	case msg = <-sent:
+	case <-time.After(2 * time.Second):
+		t.Fatal("reset email was not sent")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+	i := strings.Index(msg.Body, "/reset-password?")
+	if msg.To != "ops@example.com" || i < 0 {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+	q, _ := url.ParseQuery(strings.Fields(msg.Body[i+len("/reset-password?"):])[0])
+	token := q.Get("token")
+	if token == "" || storedHash == token || storedHash != services.HashAccountToken(token) {
+		t.Fatalf("stored %q does not hash emailed token %q", storedHash, token)
+	}
+}
+
+func TestAuthPasswordResetRequest_UnknownEmailIsAccepted(t *testing.T) {
+	db, mock := createTestDBWithMock(t)
+	h := &strictHandlers{deps: &AppDeps{DB: db, Mailer: make(captureMailer)}}
+	mock.ExpectQuery(`SELECT id, email, first_name FROM auth.users`).
+		WithArgs("nobody@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "first_name"}))
+
+	resp, _ := h.AuthPasswordResetRequest(context.Background(), gen.AuthPasswordResetRequestRequestObject{Body: &gen.PasswordResetRequest{Email: "nobody@example.com"}})
+	if _, ok := resp.(gen.AuthPasswordResetRequest202Response); !ok {
+		t.Fatalf("expected 202 for an unknown address, got %T", resp)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestAuthPasswordResetConfirm_TokenIsSingleUse(t *testing.T) {
+	db, mock := createTestDBWithMock(t)
+	h := &strictHandlers{deps: &AppDeps{DB: db}}
+	userID := uuid.New()
+	hash := services.HashAccountToken("reset-token")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE password_reset_tokens SET used_at = NOW\(\)\s+WHERE token_hash = \$1 AND used_at IS NULL AND expires_at > NOW\(\)`).
+		WithArgs(hash).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(userID))
+	mock.ExpectExec(`UPDATE auth.users SET password_hash = \$1`).
+		WithArgs(sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE password_reset_tokens SET used_at = NOW\(\)`).
+		WithArgs(hash).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+	mock.ExpectRollback()
+
+	req := gen.AuthPasswordResetConfirmRequestObject{Body: &gen.PasswordResetConfirmRequest{Token: "reset-token", NewPassword: "a-long-enough-password"}}
+	resp, _ := h.AuthPasswordResetConfirm(context.Background(), req)
+	if _, ok := resp.(gen.AuthPasswordResetConfirm204Response); !ok {
+		t.Fatalf("first use: expected 204, got %T", resp)
+	}
+	resp, _ = h.AuthPasswordResetConfirm(context.Background(), req)
+	if _, ok := resp.(gen.AuthPasswordResetConfirm400JSONResponse); !ok {
+		t.Fatalf("second use: expected 400, got %T", resp)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
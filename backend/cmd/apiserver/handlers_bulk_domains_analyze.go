@@ -18,8 +18,27 @@ func (h *strictHandlers) BulkAnalyzeDomains(ctx context.Context, r gen.BulkAnaly
 		}}, nil
 	}
 
-	// Track this analytics request as a bulk operation (analysis phase)
-	opID := h.deps.BulkOps.NewOperation(BulkOpAnalysis, r.Body.CampaignIds)
+	if h.deps == nil || h.deps.BulkOps == nil {
+		return gen.BulkAnalyzeDomains500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{
+			Error:     gen.ApiError{Code: gen.INTERNALSERVERERROR, Message: "bulk operations store not initialized", Timestamp: time.Now()},
+			RequestId: reqID(),
+			Success:   boolPtr(false),
+		}}, nil
+	}
+
+	// Track this analytics request as a bulk operation observing the analysis phase
+	specs := make([]BulkTaskSpec, 0, len(r.Body.CampaignIds))
+	for _, cid := range r.Body.CampaignIds {
+		specs = append(specs, BulkTaskSpec{CampaignID: cid})
+	}
+	opID, err := h.deps.BulkOps.NewOperation(ctx, models.BulkOperationTypeAnalysis, specs)
+	if err != nil {
+		return gen.BulkAnalyzeDomains500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{
+			Error:     gen.ApiError{Code: gen.INTERNALSERVERERROR, Message: "failed to record bulk operation", Timestamp: time.Now()},
+			RequestId: reqID(),
+			Success:   boolPtr(false),
+		}}, nil
+	}
 
 	// Aggregate simple, real metrics from the store and orchestrator
 	var totalDomains int64
@@ -51,14 +70,17 @@ func (h *strictHandlers) BulkAnalyzeDomains(ctx context.Context, r gen.BulkAnaly
 	}
 
 	// Map tracker status to response enum
-	_, aggStatus, _, _ := h.deps.BulkOps.Aggregate(ctx, h.deps.Orchestrator, opID)
+	aggStatus := models.BulkOperationStatusPending
+	if op, _, err := h.deps.BulkOps.Aggregate(ctx, opID); err == nil {
+		aggStatus = op.Status
+	}
 	var respStatus gen.BulkAnalyticsResponseStatus
 	switch aggStatus {
-	case BulkStatusCompleted:
+	case models.BulkOperationStatusCompleted:
 		respStatus = gen.BulkAnalyticsResponseStatusCompleted
-	case BulkStatusRunning:
+	case models.BulkOperationStatusRunning:
 		respStatus = gen.BulkAnalyticsResponseStatusRunning
-	case BulkStatusPending:
+	case models.BulkOperationStatusPending:
 		respStatus = gen.BulkAnalyticsResponseStatusPending
 	default:
 		// No explicit failed/cancelled in schema; treat as pending
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/models"
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
@@ -18,13 +19,53 @@ func (h *strictHandlers) BulkGenerateDomains(ctx context.Context, r gen.BulkGene
 			Success:   boolPtr(false),
 		}}, nil
 	}
-	// Prepare response skeleton with tracked operation id
-	opCampaigns := make([]uuid.UUID, 0, len(r.Body.Operations))
+	if h.deps == nil || h.deps.BulkOps == nil {
+		return gen.BulkGenerateDomains500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{
+			Error:     gen.ApiError{Code: gen.INTERNALSERVERERROR, Message: "bulk operations store not initialized", Timestamp: time.Now()},
+			RequestId: reqID(),
+			Success:   boolPtr(false),
+		}}, nil
+	}
+
+	// Build each campaign's phase config from the request; the tracker persists it with the task
+	specs := make([]BulkTaskSpec, 0, len(r.Body.Operations))
 	for _, op := range r.Body.Operations {
-		opCampaigns = append(opCampaigns, op.CampaignId)
+		cfg := map[string]interface{}{
+			"max_domains": op.MaxDomains,
+			"batch_size":  1000,
+		}
+		patternCfg := map[string]interface{}{
+			"pattern_type":         op.Config.PatternType,
+			"characterSet":         op.Config.CharacterSet,
+			"constantString":       op.Config.ConstantString,
+			"numDomainsToGenerate": op.Config.NumDomainsToGenerate,
+			"tlds":                 op.Config.Tlds,
+		}
+		if op.Config.BatchSize != nil {
+			patternCfg["batchSize"] = *op.Config.BatchSize
+		}
+		if op.Config.VariableLength != nil {
+			patternCfg["variableLength"] = *op.Config.VariableLength
+		}
+		if op.Config.PrefixVariableLength != nil {
+			patternCfg["prefixVariableLength"] = *op.Config.PrefixVariableLength
+		}
+		if op.Config.SuffixVariableLength != nil {
+			patternCfg["suffixVariableLength"] = *op.Config.SuffixVariableLength
+		}
+		if len(op.Config.Tlds) > 0 || op.Config.ConstantString != "" || op.Config.VariableLength != nil || op.Config.PrefixVariableLength != nil || op.Config.SuffixVariableLength != nil {
+			cfg["operation_config"] = patternCfg
+		}
+		specs = append(specs, BulkTaskSpec{CampaignID: op.CampaignId, Config: cfg})
+	}
+	opID, err := h.deps.BulkOps.NewOperation(ctx, models.BulkOperationTypeDomainGeneration, specs)
+	if err != nil {
+		return gen.BulkGenerateDomains500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{
+			Error:     gen.ApiError{Code: gen.INTERNALSERVERERROR, Message: "failed to record bulk operation", Timestamp: time.Now()},
+			RequestId: reqID(),
+			Success:   boolPtr(false),
+		}}, nil
 	}
-	opID := h.deps.BulkOps.NewOperation(BulkOpDomainGeneration, opCampaigns)
-	_ = time.Now()
 	resp := gen.BulkGenerationResponse{
 		EstimatedDuration: ptrString("PT5M"),
 		OperationId:       opID,
@@ -41,64 +82,40 @@ func (h *strictHandlers) BulkGenerateDomains(ctx context.Context, r gen.BulkGene
 		TotalOperations: len(r.Body.Operations),
 	}
 
-	if h.deps != nil && h.deps.Orchestrator != nil {
-		// Iterate operations: configure and start async; fill optimistic progress
-		for _, op := range r.Body.Operations {
-			key := uuid.NewString()
-			// Build config map from request
-			cfg := map[string]interface{}{
-				"max_domains": op.MaxDomains,
-				"batch_size":  1000,
-			}
-			patternCfg := map[string]interface{}{
-				"pattern_type":         op.Config.PatternType,
-				"characterSet":         op.Config.CharacterSet,
-				"constantString":       op.Config.ConstantString,
-				"numDomainsToGenerate": op.Config.NumDomainsToGenerate,
-				"tlds":                 op.Config.Tlds,
-			}
-			if op.Config.BatchSize != nil {
-				patternCfg["batchSize"] = *op.Config.BatchSize
-			}
-			if op.Config.VariableLength != nil {
-				patternCfg["variableLength"] = *op.Config.VariableLength
-			}
-			if op.Config.PrefixVariableLength != nil {
-				patternCfg["prefixVariableLength"] = *op.Config.PrefixVariableLength
-			}
-			if op.Config.SuffixVariableLength != nil {
-				patternCfg["suffixVariableLength"] = *op.Config.SuffixVariableLength
-			}
-			if len(op.Config.Tlds) > 0 || op.Config.ConstantString != "" || op.Config.VariableLength != nil || op.Config.PrefixVariableLength != nil || op.Config.SuffixVariableLength != nil {
-				cfg["operation_config"] = patternCfg
-			}
-			// Configure and start. Errors are tolerated per-op and reflected in status
-			status := gen.BulkGenerationResponseOperationsStatusPending
-			if err := h.deps.Orchestrator.ConfigurePhase(ctx, op.CampaignId, "domain_generation", cfg); err == nil {
-				_ = h.deps.Orchestrator.StartPhase(ctx, op.CampaignId, "domain_generation")
-				status = gen.BulkGenerationResponseOperationsStatusRunning
+	// Launch now; tasks that could not be claimed stay pending and are picked up by the resume loop
+	launched, err := h.deps.BulkOps.Launch(ctx, opID)
+	if err != nil && h.deps.Logger != nil {
+		h.deps.Logger.Warn(ctx, "bulk.operation.launch_deferred", map[string]interface{}{"operation_id": opID, "error": err.Error()})
+	}
+	for _, op := range r.Body.Operations {
+		status := gen.BulkGenerationResponseOperationsStatusPending
+		if launchErr, ok := launched[op.CampaignId]; ok {
+			status = gen.BulkGenerationResponseOperationsStatusRunning
+			if launchErr != nil {
+				status = gen.BulkGenerationResponseOperationsStatusFailed
 			}
-			target := op.MaxDomains
-			processed := 0
-			resp.Operations[key] = struct {
-				CampaignId       *openapi_types.UUID `json:"campaignId,omitempty"`
-				DomainsGenerated *int                `json:"domainsGenerated,omitempty"`
-				Progress         *struct {
-					Processed *int `json:"processed,omitempty"`
-					Total     *int `json:"total,omitempty"`
-				} `json:"progress,omitempty"`
-				Status *gen.BulkGenerationResponseOperationsStatus `json:"status,omitempty"`
+		}
+		campaignID := op.CampaignId
+		target := op.MaxDomains
+		processed := 0
+		resp.Operations[uuid.NewString()] = struct {
+			CampaignId       *openapi_types.UUID `json:"campaignId,omitempty"`
+			DomainsGenerated *int                `json:"domainsGenerated,omitempty"`
+			Progress         *struct {
+				Processed *int `json:"processed,omitempty"`
+				Total     *int `json:"total,omitempty"`
+			} `json:"progress,omitempty"`
+			Status *gen.BulkGenerationResponseOperationsStatus `json:"status,omitempty"`
+		}{
+			CampaignId: &campaignID,
+			Progress: &struct {
+				Processed *int `json:"processed,omitempty"`
+				Total     *int `json:"total,omitempty"`
 			}{
-				CampaignId: &op.CampaignId,
-				Progress: &struct {
-					Processed *int `json:"processed,omitempty"`
-					Total     *int `json:"total,omitempty"`
-				}{
-					Processed: &processed,
-					Total:     &target,
-				},
-				Status: &status,
-			}
+				Processed: &processed,
+				Total:     &target,
+			},
+			Status: &status,
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/models"
 	"github.com/google/uuid"
 )
 
@@ -18,11 +19,29 @@ func (h *strictHandlers) BulkValidateDNS(ctx context.Context, r gen.BulkValidate
 		}}, nil
 	}
 
-	opCampaigns := make([]uuid.UUID, 0, len(r.Body.Operations))
+	if h.deps == nil || h.deps.BulkOps == nil {
+		return gen.BulkValidateDNS500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{
+			Error:     gen.ApiError{Code: gen.INTERNALSERVERERROR, Message: "bulk operations store not initialized", Timestamp: time.Now()},
+			RequestId: reqID(),
+			Success:   boolPtr(false),
+		}}, nil
+	}
+
+	specs := make([]BulkTaskSpec, 0, len(r.Body.Operations))
 	for _, op := range r.Body.Operations {
-		opCampaigns = append(opCampaigns, op.CampaignId)
+		specs = append(specs, BulkTaskSpec{CampaignID: op.CampaignId, Config: map[string]interface{}{
+			"stealth_enabled": r.Body.Stealth != nil && (r.Body.Stealth.Enabled != nil && *r.Body.Stealth.Enabled),
+			"batch_size":      100,
+		}})
+	}
+	opID, err := h.deps.BulkOps.NewOperation(ctx, models.BulkOperationTypeDNSValidation, specs)
+	if err != nil {
+		return gen.BulkValidateDNS500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{
+			Error:     gen.ApiError{Code: gen.INTERNALSERVERERROR, Message: "failed to record bulk operation", Timestamp: time.Now()},
+			RequestId: reqID(),
+			Success:   boolPtr(false),
+		}}, nil
 	}
-	opID := h.deps.BulkOps.NewOperation(BulkOpDNSValidation, opCampaigns)
 	resp := gen.BulkValidationResponse{
 		EstimatedDuration: ptrString("PT5M"),
 		OperationId:       opID,
@@ -31,37 +50,38 @@ func (h *strictHandlers) BulkValidateDNS(ctx context.Context, r gen.BulkValidate
 		TotalOperations:   len(r.Body.Operations),
 	}
 
-	if h.deps != nil && h.deps.Orchestrator != nil {
-		for _, op := range r.Body.Operations {
-			key := uuid.NewString()
-			cfg := map[string]interface{}{
-				"stealth_enabled": r.Body.Stealth != nil && (r.Body.Stealth.Enabled != nil && *r.Body.Stealth.Enabled),
-				"batch_size":      100,
-			}
-			success := true
-			errorMsg := ""
-			if err := h.deps.Orchestrator.ConfigurePhase(ctx, op.CampaignId, "dns_validation", cfg); err == nil {
-				_ = h.deps.Orchestrator.StartPhase(ctx, op.CampaignId, "dns_validation")
-			} else {
+	// Tasks that could not be launched now stay pending and are picked up by the resume loop
+	launched, err := h.deps.BulkOps.Launch(ctx, opID)
+	if err != nil && h.deps.Logger != nil {
+		h.deps.Logger.Warn(ctx, "bulk.operation.launch_deferred", map[string]interface{}{"operation_id": opID, "error": err.Error()})
+	}
+	for _, op := range r.Body.Operations {
+		status := "pending"
+		success := true
+		errorMsg := ""
+		if launchErr, ok := launched[op.CampaignId]; ok {
+			status = "running"
+			if launchErr != nil {
+				status = "failed"
 				success = false
-				errorMsg = err.Error()
+				errorMsg = launchErr.Error()
 			}
+		}
 
-			metadata := make(map[string]*gen.FlexibleValue)
-			metadata["campaign_id"] = flexibleValueFromString(op.CampaignId.String())
-			metadata["status"] = flexibleValueFromString("pending")
-
-			result := gen.ProxyOperationResult{
-				ProxyId:  op.CampaignId,
-				Success:  success,
-				Metadata: &metadata,
-			}
-			if !success {
-				result.Error = &errorMsg
-			}
+		metadata := make(map[string]*gen.FlexibleValue)
+		metadata["campaign_id"] = flexibleValueFromString(op.CampaignId.String())
+		metadata["status"] = flexibleValueFromString(status)
 
-			resp.Operations[key] = result
+		result := gen.ProxyOperationResult{
+			ProxyId:  op.CampaignId,
+			Success:  success,
+			Metadata: &metadata,
 		}
+		if !success {
+			result.Error = &errorMsg
+		}
+
+		resp.Operations[uuid.NewString()] = result
 	}
 
 	return gen.BulkValidateDNS200JSONResponse(resp), nil
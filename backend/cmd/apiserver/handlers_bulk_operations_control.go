@@ -2,32 +2,76 @@ package main
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
 )
 
 // CancelBulkOperation implements POST /campaigns/bulk/operations/{operationId}/cancel
 func (h *strictHandlers) CancelBulkOperation(ctx context.Context, r gen.CancelBulkOperationRequestObject) (gen.CancelBulkOperationResponseObject, error) {
-	st, _ := h.deps.BulkOps.Cancel(ctx, h.deps.Orchestrator, r.OperationId)
-	var mapped gen.BulkOperationCancelStatus
-	switch st {
-	case BulkStatusCancelled:
-		mapped = gen.BulkOperationCancelStatusCancelled
-	default:
-		mapped = gen.BulkOperationCancelStatusCancelling
-	}
-	return gen.CancelBulkOperation200JSONResponse{OperationId: &r.OperationId, Status: &mapped}, nil
+	if h.deps == nil || h.deps.BulkOps == nil {
+		return gen.CancelBulkOperation500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "bulk operations store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if err := h.deps.BulkOps.Cancel(ctx, r.OperationId); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.CancelBulkOperation404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "bulk operation not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		// The operation is flagged cancelled; only stopping some running phases failed
+		status := gen.BulkOperationCancelStatusCancelling
+		return gen.CancelBulkOperation200JSONResponse{OperationId: &r.OperationId, Status: &status}, nil
+	}
+	status := gen.BulkOperationCancelStatusCancelled
+	return gen.CancelBulkOperation200JSONResponse{OperationId: &r.OperationId, Status: &status}, nil
 }
 
 // GetBulkOperationStatus implements GET /campaigns/bulk/operations/{operationId}/status
 func (h *strictHandlers) GetBulkOperationStatus(ctx context.Context, r gen.GetBulkOperationStatusRequestObject) (gen.GetBulkOperationStatusResponseObject, error) {
-	processed := float32(0)
-	typ := ""
-	status := "pending"
-	if pct, st, t, ok := h.deps.BulkOps.Aggregate(ctx, h.deps.Orchestrator, r.OperationId); ok {
-		processed = pct
-		typ = t
-		status = string(st)
-	}
-	return gen.GetBulkOperationStatus200JSONResponse{OperationId: r.OperationId, Progress: processed, Status: status, Type: typ}, nil
+	if h.deps == nil || h.deps.BulkOps == nil {
+		return gen.GetBulkOperationStatus500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "bulk operations store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	op, tasks, err := h.deps.BulkOps.Aggregate(ctx, r.OperationId)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.GetBulkOperationStatus404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "bulk operation not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.GetBulkOperationStatus500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load bulk operation", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	items := make([]gen.BulkOperationTask, 0, len(tasks))
+	for _, task := range tasks {
+		items = append(items, mapBulkOperationTask(task))
+	}
+	resp := gen.GetBulkOperationStatus200JSONResponse{
+		OperationId:     op.ID,
+		Progress:        float32(op.Progress),
+		Status:          string(op.Status),
+		Type:            string(op.Type),
+		CancelRequested: &op.CancelRequested,
+		CreatedAt:       &op.CreatedAt,
+		UpdatedAt:       &op.UpdatedAt,
+		Tasks:           &items,
+	}
+	if op.CompletedAt.Valid {
+		resp.CompletedAt = &op.CompletedAt.Time
+	}
+	return resp, nil
+}
+
+func mapBulkOperationTask(task *models.BulkOperationTask) gen.BulkOperationTask {
+	out := gen.BulkOperationTask{
+		CampaignId:     task.CampaignID,
+		Status:         gen.BulkOperationState(task.Status),
+		ItemsProcessed: task.ItemsProcessed,
+		ItemsTotal:     task.ItemsTotal,
+		UpdatedAt:      task.UpdatedAt,
+	}
+	if task.LastError.Valid {
+		out.Error = &task.LastError.String
+	}
+	if task.StartedAt.Valid {
+		out.StartedAt = &task.StartedAt.Time
+	}
+	return out
 }
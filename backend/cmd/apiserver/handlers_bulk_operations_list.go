@@ -2,30 +2,127 @@ package main
 
 import (
 	"context"
+	"time"
 
 	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// bulkOperationsListLimit caps the unpaged listing; older operations are reachable through the history endpoint.
+const bulkOperationsListLimit = 500
+
 // CampaignsBulkOperationsList implements GET /campaigns/bulk/operations
 func (h *strictHandlers) CampaignsBulkOperationsList(ctx context.Context, r gen.CampaignsBulkOperationsListRequestObject) (gen.CampaignsBulkOperationsListResponseObject, error) {
-	ops := []struct {
-		OperationId *openapi_types.UUID `json:"operationId,omitempty"`
-		Status      *string             `json:"status,omitempty"`
-		Type        *string             `json:"type,omitempty"`
-	}{}
-	if h.deps != nil && h.deps.BulkOps != nil {
-		for _, op := range h.deps.BulkOps.List() {
-			id := op.ID
-			st := string(op.Status)
-			typ := string(op.Type)
-			ops = append(ops, struct {
-				OperationId *openapi_types.UUID `json:"operationId,omitempty"`
-				Status      *string             `json:"status,omitempty"`
-				Type        *string             `json:"type,omitempty"`
-			}{OperationId: &id, Status: &st, Type: &typ})
+	if h.deps == nil || h.deps.BulkOps == nil {
+		return gen.CampaignsBulkOperationsList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "bulk operations store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	ops, _, err := h.deps.BulkOps.List(ctx, bulkOperationsVisibleTo(ctx, store.ListBulkOperationsFilter{Limit: bulkOperationsListLimit}))
+	if err != nil {
+		return gen.CampaignsBulkOperationsList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to list bulk operations", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	out := make(gen.CampaignsBulkOperationsList200JSONResponse, 0, len(ops))
+	for _, op := range ops {
+		id := op.ID
+		st := string(op.Status)
+		typ := string(op.Type)
+		out = append(out, struct {
+			OperationId *openapi_types.UUID `json:"operationId,omitempty"`
+			Status      *string             `json:"status,omitempty"`
+			Type        *string             `json:"type,omitempty"`
+		}{OperationId: &id, Status: &st, Type: &typ})
+	}
+	return out, nil
+}
+
+// CampaignsBulkOperationsHistory implements GET /campaigns/bulk/operations/history
+func (h *strictHandlers) CampaignsBulkOperationsHistory(ctx context.Context, r gen.CampaignsBulkOperationsHistoryRequestObject) (gen.CampaignsBulkOperationsHistoryResponseObject, error) {
+	if h.deps == nil || h.deps.BulkOps == nil {
+		return gen.CampaignsBulkOperationsHistory500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "bulk operations store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	filter := store.ListBulkOperationsFilter{Limit: 50, CreatedAfter: r.Params.CreatedAfter, CreatedBefore: r.Params.CreatedBefore}
+	if r.Params.Limit != nil {
+		filter.Limit = int(*r.Params.Limit)
+	}
+	if r.Params.Offset != nil {
+		filter.Offset = int(*r.Params.Offset)
+	}
+	if filter.Limit < 1 || filter.Limit > 500 || filter.Offset < 0 {
+		return gen.CampaignsBulkOperationsHistory400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "limit must be between 1 and 500 and offset must not be negative", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Params.Type != nil {
+		if !validBulkOperationType(*r.Params.Type) {
+			return gen.CampaignsBulkOperationsHistory400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "unknown bulk operation type", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		typ := models.BulkOperationTypeEnum(*r.Params.Type)
+		filter.Type = &typ
+	}
+	if r.Params.Status != nil {
+		if !validBulkOperationState(*r.Params.Status) {
+			return gen.CampaignsBulkOperationsHistory400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "unknown bulk operation status", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		status := models.BulkOperationStatusEnum(*r.Params.Status)
+		filter.Status = &status
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return gen.CampaignsBulkOperationsHistory400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "createdAfter must be before createdBefore", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+
+	ops, total, err := h.deps.BulkOps.List(ctx, bulkOperationsVisibleTo(ctx, filter))
+	if err != nil {
+		return gen.CampaignsBulkOperationsHistory500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to list bulk operations", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	items := make([]gen.BulkOperationSummary, 0, len(ops))
+	for _, op := range ops {
+		item := gen.BulkOperationSummary{
+			OperationId:     op.ID,
+			Type:            gen.BulkOperationType(op.Type),
+			Status:          gen.BulkOperationState(op.Status),
+			Progress:        float32(op.Progress),
+			CampaignCount:   op.CampaignCount,
+			CancelRequested: op.CancelRequested,
+			CreatedAt:       op.CreatedAt,
+			UpdatedAt:       op.UpdatedAt,
+		}
+		if op.CompletedAt.Valid {
+			t := op.CompletedAt.Time
+			item.CompletedAt = &t
 		}
+		items = append(items, item)
+	}
+	resp := gen.BulkOperationListResponse{Items: items}
+	resp.Meta.Limit = filter.Limit
+	resp.Meta.Offset = filter.Offset
+	resp.Meta.Total = int(total)
+	return gen.CampaignsBulkOperationsHistory200JSONResponse(resp), nil
+}
+
+// bulkOperationsVisibleTo limits filter to the operations the caller may read: admins see them all,
+// everyone else only those whose campaigns they own or were granted.
+func bulkOperationsVisibleTo(ctx context.Context, filter store.ListBulkOperationsFilter) store.ListBulkOperationsFilter {
+	if requestRole(ctx) == models.RoleAdmin {
+		return filter
 	}
-	return gen.CampaignsBulkOperationsList200JSONResponse(ops), nil
+	userID, _ := sessionUserID(ctx)
+	filter.AccessibleTo = &userID
+	return filter
 }
+
+func validBulkOperationType(t gen.BulkOperationType) bool {
+	switch t {
+	case gen.BulkOperationTypeDomainGeneration, gen.BulkOperationTypeDnsValidation, gen.BulkOperationTypeHttpValidation, gen.BulkOperationTypeAnalysis:
+		return true
+	}
+	return false
+}
+
+func validBulkOperationState(s gen.BulkOperationState) bool {
+	switch s {
+	case gen.BulkOperationStatePending, gen.BulkOperationStateRunning, gen.BulkOperationStateCompleted, gen.BulkOperationStateFailed, gen.BulkOperationStateCancelled:
+		return true
+	}
+	return false
+}
+
 func strPtr(s string) *string { return &s }
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"github.com/google/uuid"
+)
+
+// Access checks on these endpoints happen in the authorization middleware (campaign admin access,
+// or the admin role for UsersRoleUpdate); the handlers only validate and persist.
+
+func toCampaignAccessGrant(g *models.CampaignAccessGrant) gen.CampaignAccessGrant {
+	out := gen.CampaignAccessGrant{
+		Id:         openapi_types.UUID(g.ID),
+		CampaignId: openapi_types.UUID(g.CampaignID),
+		UserId:     openapi_types.UUID(g.UserID),
+		UserEmail:  g.UserEmail,
+		Level:      gen.CampaignAccessLevel(g.Level()),
+		GrantedBy:  openapi_types.UUID(g.GrantedBy),
+		GrantedAt:  g.GrantedAt,
+	}
+	if g.ExpiresAt.Valid {
+		out.ExpiresAt = &g.ExpiresAt.Time
+	}
+	return out
+}
+
+// CampaignsAccessList implements GET /campaigns/{campaignId}/access
+func (h *strictHandlers) CampaignsAccessList(ctx context.Context, r gen.CampaignsAccessListRequestObject) (gen.CampaignsAccessListResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Authorization == nil || h.deps.DB == nil {
+		return gen.CampaignsAccessList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "authorization store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	campaignID := uuid.UUID(r.CampaignId)
+	if _, err := h.deps.Stores.Authorization.GetCampaignAccess(ctx, h.deps.DB, campaignID, uuid.Nil); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.CampaignsAccessList404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "campaign not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsAccessList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load campaign", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	grants, err := h.deps.Stores.Authorization.ListCampaignAccessGrants(ctx, h.deps.DB, campaignID)
+	if err != nil {
+		return gen.CampaignsAccessList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to list access grants", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	out := make(gen.CampaignsAccessList200JSONResponse, 0, len(grants))
+	for _, g := range grants {
+		out = append(out, toCampaignAccessGrant(g))
+	}
+	return out, nil
+}
+
+// CampaignsAccessGrant implements PUT /campaigns/{campaignId}/access/{userId}
+func (h *strictHandlers) CampaignsAccessGrant(ctx context.Context, r gen.CampaignsAccessGrantRequestObject) (gen.CampaignsAccessGrantResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Authorization == nil || h.deps.DB == nil {
+		return gen.CampaignsAccessGrant500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "authorization store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	callerID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.CampaignsAccessGrant401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil || !models.ValidCampaignAccessLevel(string(r.Body.Level)) {
+		return gen.CampaignsAccessGrant400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "level must be one of read, control, admin", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body.ExpiresAt != nil && !r.Body.ExpiresAt.After(time.Now()) {
+		return gen.CampaignsAccessGrant400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "expiresAt must be in the future", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	campaignID, userID := uuid.UUID(r.CampaignId), uuid.UUID(r.UserId)
+	access, err := h.deps.Stores.Authorization.GetCampaignAccess(ctx, h.deps.DB, campaignID, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.CampaignsAccessGrant404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "campaign not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsAccessGrant500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load campaign", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if access.OwnerID.Valid && access.OwnerID.UUID == userID {
+		return gen.CampaignsAccessGrant400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "the campaign owner already has admin access", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if _, err := h.deps.Stores.Authorization.GetUserRole(ctx, h.deps.DB, userID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.CampaignsAccessGrant404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "user not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsAccessGrant500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load user", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	grant, err := h.deps.Stores.Authorization.GrantCampaignAccess(ctx, h.deps.DB, campaignID, userID, callerID, string(r.Body.Level), r.Body.ExpiresAt)
+	if err != nil {
+		return gen.CampaignsAccessGrant500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to grant access", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	h.auditAuthEvent(ctx, "CAMPAIGN_ACCESS_GRANTED", &callerID, map[string]string{"campaign_id": campaignID.String(), "grantee_id": userID.String(), "level": grant.Level()})
+	return gen.CampaignsAccessGrant200JSONResponse(toCampaignAccessGrant(grant)), nil
+}
+
+// CampaignsAccessRevoke implements DELETE /campaigns/{campaignId}/access/{userId}
+func (h *strictHandlers) CampaignsAccessRevoke(ctx context.Context, r gen.CampaignsAccessRevokeRequestObject) (gen.CampaignsAccessRevokeResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Authorization == nil || h.deps.DB == nil {
+		return gen.CampaignsAccessRevoke500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "authorization store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	callerID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.CampaignsAccessRevoke401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	campaignID, userID := uuid.UUID(r.CampaignId), uuid.UUID(r.UserId)
+	if err := h.deps.Stores.Authorization.RevokeCampaignAccess(ctx, h.deps.DB, campaignID, userID, callerID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.CampaignsAccessRevoke404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "no access grant for this user", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsAccessRevoke500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to revoke access", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	h.auditAuthEvent(ctx, "CAMPAIGN_ACCESS_REVOKED", &callerID, map[string]string{"campaign_id": campaignID.String(), "grantee_id": userID.String()})
+	return gen.CampaignsAccessRevoke204Response{}, nil
+}
+
+// UsersRoleUpdate implements PUT /users/{userId}/role
+func (h *strictHandlers) UsersRoleUpdate(ctx context.Context, r gen.UsersRoleUpdateRequestObject) (gen.UsersRoleUpdateResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Authorization == nil || h.deps.DB == nil {
+		return gen.UsersRoleUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "authorization store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	callerID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.UsersRoleUpdate401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil || !models.ValidRole(string(r.Body.Role)) {
+		return gen.UsersRoleUpdate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "role must be one of admin, operator, viewer", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID := uuid.UUID(r.UserId)
+	if userID == callerID {
+		return gen.UsersRoleUpdate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "admins cannot change their own role", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if err := h.deps.Stores.Authorization.SetUserRole(ctx, h.deps.DB, userID, string(r.Body.Role)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.UsersRoleUpdate404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "user not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.UsersRoleUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to update role", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	h.auditAuthEvent(ctx, "USER_ROLE_CHANGED", &callerID, map[string]string{"target_user_id": userID.String(), "role": string(r.Body.Role)})
+	return gen.UsersRoleUpdate200JSONResponse{UserId: r.UserId, Role: r.Body.Role}, nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (h *strictHandlers) CampaignsRunsList(ctx context.Context, r gen.CampaignsRunsListRequestObject) (gen.CampaignsRunsListResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Snapshots == nil || h.deps.Stores.Campaign == nil || h.deps.DB == nil {
+		return gen.CampaignsRunsList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "run snapshot store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	campaignID := uuid.UUID(r.CampaignId)
+	if _, err := h.deps.Stores.Campaign.GetCampaignByID(ctx, h.deps.DB, campaignID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.CampaignsRunsList404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "campaign not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsRunsList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch campaign", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	limit := 50
+	if r.Params.Limit != nil && *r.Params.Limit > 0 {
+		limit = min(*r.Params.Limit, 200)
+	}
+	runs, err := h.deps.Stores.Snapshots.ListCampaignPhaseRuns(ctx, h.deps.DB, campaignID, limit)
+	if err != nil {
+		return gen.CampaignsRunsList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to list runs", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	out := make([]gen.CampaignPhaseRun, 0, len(runs))
+	for _, run := range runs {
+		out = append(out, toAPICampaignPhaseRun(run))
+	}
+	return gen.CampaignsRunsList200JSONResponse(out), nil
+}
+
+func (h *strictHandlers) CampaignsRunsDiff(ctx context.Context, r gen.CampaignsRunsDiffRequestObject) (gen.CampaignsRunsDiffResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Snapshots == nil || h.deps.DB == nil {
+		return gen.CampaignsRunsDiff500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "run snapshot store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	threshold := models.DefaultChangeScoreThreshold
+	if r.Params.ScoreThreshold != nil {
+		if *r.Params.ScoreThreshold < 0 || *r.Params.ScoreThreshold > 1 {
+			return gen.CampaignsRunsDiff400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "scoreThreshold must be between 0 and 1", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		threshold = *r.Params.ScoreThreshold
+	}
+	if r.Params.FromRunId == r.Params.ToRunId {
+		return gen.CampaignsRunsDiff400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "fromRunId and toRunId must differ", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	limit := 100
+	if r.Params.Limit != nil && *r.Params.Limit > 0 {
+		limit = min(*r.Params.Limit, 1000)
+	}
+	campaignID := uuid.UUID(r.CampaignId)
+	fromRun, err := h.deps.Stores.Snapshots.GetCampaignPhaseRun(ctx, h.deps.DB, campaignID, uuid.UUID(r.Params.FromRunId))
+	if err == nil {
+		var toRun *models.CampaignPhaseRun
+		if toRun, err = h.deps.Stores.Snapshots.GetCampaignPhaseRun(ctx, h.deps.DB, campaignID, uuid.UUID(r.Params.ToRunId)); err == nil {
+			return h.diffCampaignRuns(ctx, fromRun, toRun, r.Params.After, threshold, limit), nil
+		}
+	}
+	if errors.Is(err, store.ErrNotFound) {
+		return gen.CampaignsRunsDiff404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "run not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.CampaignsRunsDiff500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch run", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+}
+
+// diffCampaignRuns pages through snapshot pairs until limit classified changes are collected; pairs whose
+// differences are below reporting granularity (e.g. a score move that crosses no threshold) are skipped.
+func (h *strictHandlers) diffCampaignRuns(ctx context.Context, fromRun, toRun *models.CampaignPhaseRun, after *openapi_types.UUID, threshold float64, limit int) gen.CampaignsRunsDiffResponseObject {
+	cursor := uuid.Nil
+	if after != nil {
+		cursor = uuid.UUID(*after)
+	}
+	changes := make([]gen.DomainStateChange, 0, limit)
+	exhausted := false
+	for len(changes) < limit && !exhausted {
+		page, err := h.deps.Stores.Snapshots.ListChangedDomainSnapshots(ctx, h.deps.DB, fromRun.RunID, toRun.RunID, cursor, limit)
+		if err != nil {
+			return gen.CampaignsRunsDiff500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to diff runs", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}
+		}
+		exhausted = len(page) < limit
+		for i, change := range page {
+			cursor = change.DomainID
+			if kinds := models.ClassifyDomainChange(change.Before, change.After, threshold); len(kinds) > 0 {
+				change.Kinds = kinds
+				changes = append(changes, toAPIDomainStateChange(change))
+			}
+			if len(changes) == limit {
+				if i < len(page)-1 {
+					exhausted = false
+				}
+				break
+			}
+		}
+	}
+	resp := gen.CampaignRunDiffResponse{
+		FromRun:        toAPICampaignPhaseRun(fromRun),
+		ToRun:          toAPICampaignPhaseRun(toRun),
+		ScoreThreshold: threshold,
+		Changes:        changes,
+	}
+	if !exhausted {
+		next := openapi_types.UUID(cursor)
+		resp.NextCursor = &next
+	}
+	return gen.CampaignsRunsDiff200JSONResponse(resp)
+}
+
+func toAPICampaignPhaseRun(run *models.CampaignPhaseRun) gen.CampaignPhaseRun {
+	return gen.CampaignPhaseRun{
+		RunId:       openapi_types.UUID(run.RunID),
+		CampaignId:  openapi_types.UUID(run.CampaignID),
+		Phase:       mapModelPhaseToCampaignEnum(run.Phase),
+		CompletedAt: run.CompletedAt,
+		DomainCount: run.DomainCount,
+	}
+}
+
+func toAPIDomainStateChange(c *models.DomainStateChange) gen.DomainStateChange {
+	out := gen.DomainStateChange{
+		DomainId: openapi_types.UUID(c.DomainID),
+		Domain:   c.DomainName,
+		Kinds:    make([]gen.DomainChangeKind, 0, len(c.Kinds)),
+		Before:   toAPIDomainStateSnapshot(c.Before),
+		After:    toAPIDomainStateSnapshot(c.After),
+	}
+	for _, k := range c.Kinds {
+		out.Kinds = append(out.Kinds, gen.DomainChangeKind(k))
+	}
+	return out
+}
+
+func toAPIDomainStateSnapshot(s *models.DomainStateSnapshot) *gen.DomainStateSnapshot {
+	if s == nil {
+		return nil
+	}
+	out := &gen.DomainStateSnapshot{}
+	if s.DNSStatus.Valid {
+		out.DnsStatus = &s.DNSStatus.String
+	}
+	if s.HTTPStatus.Valid {
+		out.HttpStatus = &s.HTTPStatus.String
+	}
+	if s.LeadStatus.Valid {
+		out.LeadStatus = &s.LeadStatus.String
+	}
+	if s.IsParked.Valid {
+		out.IsParked = &s.IsParked.Bool
+	}
+	if s.DomainScore.Valid {
+		out.DomainScore = &s.DomainScore.Float64
+	}
+	if s.ContentHash.Valid {
+		out.ContentHash = &s.ContentHash.String
+	}
+	return out
+}
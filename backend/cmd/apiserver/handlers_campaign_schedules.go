@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/scheduler"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (h *strictHandlers) CampaignsSchedulesList(ctx context.Context, r gen.CampaignsSchedulesListRequestObject) (gen.CampaignsSchedulesListResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Schedules == nil || h.deps.Stores.Campaign == nil || h.deps.DB == nil {
+		return gen.CampaignsSchedulesList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "schedule store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	campaignID := uuid.UUID(r.CampaignId)
+	if err := h.scheduleCampaignExists(ctx, campaignID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.CampaignsSchedulesList404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "campaign not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsSchedulesList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch campaign", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	schedules, err := h.deps.Stores.Schedules.ListCampaignSchedules(ctx, h.deps.DB, campaignID)
+	if err != nil {
+		return gen.CampaignsSchedulesList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to list schedules", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	out := make([]gen.CampaignSchedule, 0, len(schedules))
+	for _, s := range schedules {
+		out = append(out, toAPICampaignSchedule(s))
+	}
+	return gen.CampaignsSchedulesList200JSONResponse(out), nil
+}
+
+func (h *strictHandlers) CampaignsSchedulesCreate(ctx context.Context, r gen.CampaignsSchedulesCreateRequestObject) (gen.CampaignsSchedulesCreateResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Schedules == nil || h.deps.Stores.Campaign == nil || h.deps.DB == nil {
+		return gen.CampaignsSchedulesCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "schedule store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil {
+		return gen.CampaignsSchedulesCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "missing body", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	campaignID := uuid.UUID(r.CampaignId)
+	if err := h.scheduleCampaignExists(ctx, campaignID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.CampaignsSchedulesCreate404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "campaign not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsSchedulesCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch campaign", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	now := time.Now().UTC()
+	sched := &models.CampaignSchedule{
+		ID:             uuid.New(),
+		CampaignID:     campaignID,
+		CronExpression: strings.TrimSpace(r.Body.CronExpression),
+		Timezone:       "UTC",
+		Action:         models.CampaignScheduleActionEnum(r.Body.Action),
+		IsEnabled:      r.Body.IsEnabled == nil || *r.Body.IsEnabled,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if userID, ok := sessionUserID(ctx); ok {
+		sched.UserID = uuid.NullUUID{UUID: userID, Valid: true}
+	}
+	if r.Body.Timezone != nil && strings.TrimSpace(*r.Body.Timezone) != "" {
+		sched.Timezone = strings.TrimSpace(*r.Body.Timezone)
+	}
+	if msg := applySchedulePhase(sched, r.Body.Phase); msg != "" {
+		return gen.CampaignsSchedulesCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: msg, Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if msg := planNextScheduleRun(sched, now); msg != "" {
+		return gen.CampaignsSchedulesCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: msg, Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if err := h.deps.Stores.Schedules.CreateCampaignSchedule(ctx, h.deps.DB, sched); err != nil {
+		return gen.CampaignsSchedulesCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to create schedule", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.CampaignsSchedulesCreate201JSONResponse(toAPICampaignSchedule(sched)), nil
+}
+
+func (h *strictHandlers) CampaignsSchedulesGet(ctx context.Context, r gen.CampaignsSchedulesGetRequestObject) (gen.CampaignsSchedulesGetResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Schedules == nil || h.deps.DB == nil {
+		return gen.CampaignsSchedulesGet500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "schedule store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	sched, err := h.deps.Stores.Schedules.GetCampaignSchedule(ctx, h.deps.DB, uuid.UUID(r.CampaignId), uuid.UUID(r.ScheduleId))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.CampaignsSchedulesGet404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "schedule not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsSchedulesGet500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch schedule", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.CampaignsSchedulesGet200JSONResponse(toAPICampaignSchedule(sched)), nil
+}
+
+func (h *strictHandlers) CampaignsSchedulesUpdate(ctx context.Context, r gen.CampaignsSchedulesUpdateRequestObject) (gen.CampaignsSchedulesUpdateResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Schedules == nil || h.deps.DB == nil {
+		return gen.CampaignsSchedulesUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "schedule store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil {
+		return gen.CampaignsSchedulesUpdate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "missing body", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	sched, err := h.deps.Stores.Schedules.GetCampaignSchedule(ctx, h.deps.DB, uuid.UUID(r.CampaignId), uuid.UUID(r.ScheduleId))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.CampaignsSchedulesUpdate404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "schedule not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsSchedulesUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch schedule", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body.CronExpression != nil {
+		sched.CronExpression = strings.TrimSpace(*r.Body.CronExpression)
+	}
+	if r.Body.Timezone != nil {
+		sched.Timezone = strings.TrimSpace(*r.Body.Timezone)
+		if sched.Timezone == "" {
+			sched.Timezone = "UTC"
+		}
+	}
+	if r.Body.IsEnabled != nil {
+		sched.IsEnabled = *r.Body.IsEnabled
+	}
+	phase := r.Body.Phase
+	if r.Body.Action != nil {
+		sched.Action = models.CampaignScheduleActionEnum(*r.Body.Action)
+	}
+	if phase == nil && sched.Action == models.CampaignScheduleActionPhase && sched.Phase != nil {
+		current := mapModelPhaseToCampaignEnum(*sched.Phase)
+		phase = &current
+	}
+	if msg := applySchedulePhase(sched, phase); msg != "" {
+		return gen.CampaignsSchedulesUpdate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: msg, Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if msg := planNextScheduleRun(sched, time.Now().UTC()); msg != "" {
+		return gen.CampaignsSchedulesUpdate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: msg, Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if err := h.deps.Stores.Schedules.UpdateCampaignSchedule(ctx, h.deps.DB, sched); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.CampaignsSchedulesUpdate404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "schedule not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsSchedulesUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to update schedule", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.CampaignsSchedulesUpdate200JSONResponse(toAPICampaignSchedule(sched)), nil
+}
+
+func (h *strictHandlers) CampaignsSchedulesDelete(ctx context.Context, r gen.CampaignsSchedulesDeleteRequestObject) (gen.CampaignsSchedulesDeleteResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Schedules == nil || h.deps.DB == nil {
+		return gen.CampaignsSchedulesDelete500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "schedule store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if err := h.deps.Stores.Schedules.DeleteCampaignSchedule(ctx, h.deps.DB, uuid.UUID(r.CampaignId), uuid.UUID(r.ScheduleId)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.CampaignsSchedulesDelete404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "schedule not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsSchedulesDelete500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to delete schedule", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.CampaignsSchedulesDelete204Response{}, nil
+}
+
+func (h *strictHandlers) scheduleCampaignExists(ctx context.Context, campaignID uuid.UUID) error {
+	_, err := h.deps.Stores.Campaign.GetCampaignByID(ctx, h.deps.DB, campaignID)
+	return err
+}
+
+// applySchedulePhase validates the action and sets the schedule's phase; only phase actions carry one.
+// Domain generation is excluded because it runs once per campaign.
+func applySchedulePhase(sched *models.CampaignSchedule, phase *gen.CampaignPhaseEnum) string {
+	switch sched.Action {
+	case models.CampaignScheduleActionRestart:
+		sched.Phase = nil
+		return ""
+	case models.CampaignScheduleActionPhase:
+	default:
+		return "action must be restart or phase"
+	}
+	if phase == nil || *phase == "" {
+		return "phase is required when action is phase"
+	}
+	mapped, err := mapAPIPhaseToModel(string(*phase))
+	if err != nil {
+		return err.Error()
+	}
+	if mapped == models.PhaseTypeDomainGeneration {
+		return "domain generation cannot be scheduled"
+	}
+	sched.Phase = &mapped
+	return ""
+}
+
+// planNextScheduleRun validates the cron expression and time zone and sets the next run; disabled schedules have none.
+func planNextScheduleRun(sched *models.CampaignSchedule, now time.Time) string {
+	if sched.CronExpression == "" {
+		return "cronExpression is required"
+	}
+	next, err := scheduler.NextRun(sched.CronExpression, sched.Timezone, now)
+	if err != nil {
+		return err.Error()
+	}
+	sched.NextRunAt = sql.NullTime{}
+	if sched.IsEnabled {
+		sched.NextRunAt = sql.NullTime{Time: next, Valid: true}
+	}
+	return ""
+}
+
+func toAPICampaignSchedule(s *models.CampaignSchedule) gen.CampaignSchedule {
+	out := gen.CampaignSchedule{
+		Id:             openapi_types.UUID(s.ID),
+		CampaignId:     openapi_types.UUID(s.CampaignID),
+		CronExpression: s.CronExpression,
+		Timezone:       s.Timezone,
+		Action:         gen.CampaignScheduleAction(s.Action),
+		IsEnabled:      s.IsEnabled,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+	if s.Phase != nil {
+		phase := mapModelPhaseToCampaignEnum(*s.Phase)
+		out.Phase = &phase
+	}
+	if s.NextRunAt.Valid {
+		next := s.NextRunAt.Time
+		out.NextRunAt = &next
+	}
+	if s.LastRunAt.Valid {
+		last := s.LastRunAt.Time
+		out.LastRunAt = &last
+	}
+	if s.LastRunStatus.Valid {
+		status := gen.CampaignScheduleRunStatus(s.LastRunStatus.String)
+		out.LastRunStatus = &status
+	}
+	if s.LastError.Valid && s.LastError.String != "" {
+		lastErr := s.LastError.String
+		out.LastError = &lastErr
+	}
+	return out
+}
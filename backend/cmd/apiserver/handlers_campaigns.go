@@ -19,8 +19,10 @@ import (
 	application "github.com/fntelecomllc/studio/backend/internal/application"
 	domainservices "github.com/fntelecomllc/studio/backend/internal/domain/services"
 	"github.com/fntelecomllc/studio/backend/internal/domainexpert"
+	"github.com/fntelecomllc/studio/backend/internal/httpvalidator"
 	"github.com/fntelecomllc/studio/backend/internal/models"
 	"github.com/fntelecomllc/studio/backend/internal/phases"
+	"github.com/fntelecomllc/studio/backend/internal/proxymanager"
 	"github.com/fntelecomllc/studio/backend/internal/services"
 	"github.com/fntelecomllc/studio/backend/internal/store"
 	"github.com/google/uuid"
@@ -202,6 +204,8 @@ func (h *strictHandlers) CampaignsRejectionSummaryGet(ctx context.Context, r gen
 	resp.Counts.Parked = int(summary.Counts.Parked)
 	resp.Counts.DnsError = int(summary.Counts.DNSError)
 	resp.Counts.DnsTimeout = int(summary.Counts.DNSTimeout)
+	resp.Counts.DnsWildcard = int(summary.Counts.DNSWildcard)
+	resp.Counts.DnsMissingRecords = int(summary.Counts.DNSMissingRecords)
 	resp.Counts.HttpError = int(summary.Counts.HTTPError)
 	resp.Counts.HttpTimeout = int(summary.Counts.HTTPTimeout)
 	resp.Counts.Pending = int(summary.Counts.Pending)
@@ -647,6 +651,18 @@ func mapToDomainGenerationConfig(in map[string]interface{}) (domainservices.Doma
 		cfg.ConstantString = getString("constant_string")
 	}
 	cfg.TLD = getTLD()
+	if v, ok := in["tlds"]; ok {
+		switch arr := v.(type) {
+		case []interface{}:
+			for _, item := range arr {
+				if s, ok2 := item.(string); ok2 {
+					cfg.TLDs = append(cfg.TLDs, s)
+				}
+			}
+		case []string:
+			cfg.TLDs = append(cfg.TLDs, arr...)
+		}
+	}
 	cfg.NumDomains = getInt64("numDomainsToGenerate", 0)
 	if cfg.NumDomains == 0 {
 		cfg.NumDomains = getInt64("num_domains", 0)
@@ -660,11 +676,26 @@ func mapToDomainGenerationConfig(in map[string]interface{}) (domainservices.Doma
 		cfg.OffsetStart = getInt64("offset_start", 0)
 	}
 
-	// Basic required checks mirroring service.Validate (allow 0 to enable constant-only domain e.g. example.com)
-	if cfg.CharacterSet == "" {
-		return cfg, fmt.Errorf("characterSet cannot be empty")
+	cfg.Template = getString("template")
+	cfg.WordLists = domainservices.ParseDomainWordListSlots(in["wordLists"])
+	if len(cfg.WordLists) == 0 {
+		cfg.WordLists = domainservices.ParseDomainWordListSlots(in["word_lists"])
 	}
-	if cfg.TLD == "" {
+
+	// Imported seeds carry no pattern; numDomains optionally caps how many staged domains are used
+	if strings.EqualFold(strings.TrimSpace(getString("source")), domainservices.DomainSourceImport) {
+		cfg.Source = domainservices.DomainSourceImport
+		if err := cfg.Normalize(); err != nil {
+			return cfg, err
+		}
+		if cfg.NumDomains < 0 {
+			return cfg, fmt.Errorf("numDomains must not be negative")
+		}
+		return cfg, nil
+	}
+
+	// Basic required checks mirroring service.Validate (allow 0 to enable constant-only domain e.g. example.com)
+	if cfg.TLD == "" && len(cfg.TLDs) == 0 {
 		return cfg, fmt.Errorf("tld cannot be empty")
 	}
 	if cfg.NumDomains <= 0 {
@@ -676,6 +707,10 @@ func mapToDomainGenerationConfig(in map[string]interface{}) (domainservices.Doma
 	if err := cfg.Normalize(); err != nil {
 		return cfg, err
 	}
+	// Word-list patterns only need a character set when the template has {var:N} segments
+	if cfg.CharacterSet == "" && !cfg.IsWordPattern() {
+		return cfg, fmt.Errorf("characterSet cannot be empty")
+	}
 
 	switch cfg.PatternType {
 	case string(models.PatternTypePrefixVariable):
@@ -702,8 +737,9 @@ func (h *strictHandlers) CampaignsList(ctx context.Context, r gen.CampaignsListR
 	}
 	filter := store.ListCampaignsFilter{Limit: 50, Offset: 0}
 	if v := ctx.Value("user_id"); v != nil {
-		if s, ok := v.(string); ok && s != "" {
-			filter.UserID = s
+		// Admins see every campaign; everyone else sees their own and those shared with them
+		if s, ok := v.(string); ok && s != "" && requestRole(ctx) != models.RoleAdmin {
+			filter.AccessibleTo = s
 		}
 	}
 	rows, err := h.deps.Stores.Campaign.ListCampaigns(ctx, h.deps.DB, filter)
@@ -1125,6 +1161,39 @@ func (h *strictHandlers) CampaignsPhaseConfigure(ctx context.Context, r gen.Camp
 			if len(httpCfg.Keywords) == 0 && len(httpCfg.KeywordSetIDs) == 0 {
 				return gen.CampaignsPhaseConfigure400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "at least one keyword or keyword set is required", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 			}
+			// Optional proxy pool binding: top-level proxyPoolId wins over the configuration map
+			var poolRef string
+			if r.Body.ProxyPoolId != nil {
+				poolRef = uuid.UUID(*r.Body.ProxyPoolId).String()
+			} else if v, ok := incoming["proxyPoolId"].(string); ok {
+				poolRef = strings.TrimSpace(v)
+			}
+			if poolRef != "" {
+				poolID, perr := uuid.Parse(poolRef)
+				if perr != nil {
+					return gen.CampaignsPhaseConfigure400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "invalid proxyPoolId", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+				}
+				if h.deps.Stores.ProxyPools != nil {
+					if _, gerr := h.deps.Stores.ProxyPools.GetProxyPoolByID(ctx, h.deps.DB, poolID); gerr != nil {
+						return gen.CampaignsPhaseConfigure400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "proxy pool not found", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+					}
+				}
+				poolStr := poolID.String()
+				httpCfg.ProxyPoolID = &poolStr
+			}
+			if v, ok := incoming["proxySelectionStrategy"].(string); ok && strings.TrimSpace(v) != "" {
+				strategy := strings.TrimSpace(v)
+				if !proxymanager.IsValidPoolStrategy(strategy) {
+					return gen.CampaignsPhaseConfigure400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "unsupported proxySelectionStrategy: " + strategy, Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+				}
+				httpCfg.ProxySelectionStrategy = &strategy
+			}
+			if v, ok := incoming["respectRobots"].(bool); ok {
+				httpCfg.RespectRobots = &v
+			}
+			if v, ok := incoming["robotsEnforceRootFetch"].(bool); ok {
+				httpCfg.RobotsEnforceRootFetch = &v
+			}
 			if h.deps.Logger != nil {
 				h.deps.Logger.Info(ctx, "HTTP phase configure parsed", map[string]interface{}{
 					"campaign_id":      r.CampaignId,
@@ -1714,7 +1783,8 @@ func mapAPIPhaseToModel(p string) (models.PhaseTypeEnum, error) {
 		string(models.PhaseTypeHTTPKeywordValidation),
 		string(models.PhaseTypeExtraction),
 		string(models.PhaseTypeEnrichment),
-		string(models.PhaseTypeAnalysis):
+		string(models.PhaseTypeAnalysis),
+		string(models.PhaseTypeRDAPLookup):
 		return models.PhaseTypeEnum(internal), nil
 	default:
 		return "", fmt.Errorf("unknown phase: %s", p)
@@ -1735,6 +1805,71 @@ func mapModelPhaseToRestartEnum(phase models.PhaseTypeEnum) gen.CampaignRestartP
 	return gen.CampaignRestartPhaseEnum(phases.ToAPI(string(phase)))
 }
 
+// mapDomainRegistration exposes RDAP lookup results; nil until the domain has been looked up.
+func mapDomainRegistration(gd *models.GeneratedDomain) *gen.DomainRegistration {
+	if gd == nil || !gd.RDAPStatus.Valid {
+		return nil
+	}
+	reg := &gen.DomainRegistration{Status: gen.DomainRegistrationStatus(gd.RDAPStatus.String)}
+	if gd.RDAPRegistrar.Valid {
+		reg.Registrar = &gd.RDAPRegistrar.String
+	}
+	if gd.RDAPRegisteredAt.Valid {
+		reg.RegisteredAt = &gd.RDAPRegisteredAt.Time
+	}
+	if gd.RDAPExpiresAt.Valid {
+		reg.ExpiresAt = &gd.RDAPExpiresAt.Time
+	}
+	if gd.RDAPCheckedAt.Valid {
+		reg.CheckedAt = &gd.RDAPCheckedAt.Time
+	}
+	return reg
+}
+
+// mapDomainTLS exposes the certificate recorded by HTTP validation; nil for plain HTTP or unvalidated domains.
+func mapDomainTLS(gd *models.GeneratedDomain) *gen.DomainTLSCertificate {
+	if gd == nil || !gd.TLSCertificate.Valid || len(gd.TLSCertificate.Raw) == 0 {
+		return nil
+	}
+	var info httpvalidator.TLSInfo
+	if err := json.Unmarshal(gd.TLSCertificate.Raw, &info); err != nil || info.Version == "" {
+		return nil
+	}
+	cert := &gen.DomainTLSCertificate{
+		Version:          info.Version,
+		SelfSigned:       &info.SelfSigned,
+		HostnameMismatch: &info.HostnameMismatch,
+	}
+	if info.Subject != "" {
+		cert.Subject = &info.Subject
+	}
+	if info.Issuer != "" {
+		cert.Issuer = &info.Issuer
+	}
+	if len(info.SANs) > 0 {
+		cert.Sans = &info.SANs
+	}
+	if !info.NotBefore.IsZero() {
+		cert.NotBefore = &info.NotBefore
+	}
+	if !info.NotAfter.IsZero() {
+		cert.NotAfter = &info.NotAfter
+	}
+	return cert
+}
+
+// mapDomainContacts decodes the stored contacts.Set; its JSON shape matches the API schema.
+func mapDomainContacts(gd *models.GeneratedDomain) *gen.DomainContacts {
+	if gd == nil || len(gd.Contacts) == 0 || string(gd.Contacts) == "null" {
+		return nil
+	}
+	var out gen.DomainContacts
+	if err := json.Unmarshal(gd.Contacts, &out); err != nil {
+		return nil
+	}
+	return &out
+}
+
 var restartablePhaseSequence = []models.PhaseTypeEnum{
 	models.PhaseTypeDNSValidation,
 	models.PhaseTypeHTTPKeywordValidation,
@@ -2501,7 +2636,8 @@ func (h *strictHandlers) CampaignsDomainsList(ctx context.Context, r gen.Campaig
 
 	// Build optional filter
 	var domainFilter *store.ListCampaignDomainsFilter
-	if r.Params.DnsStatus != nil || r.Params.HttpStatus != nil || r.Params.DnsReason != nil || r.Params.HttpReason != nil || r.Params.RejectionReason != nil {
+	if r.Params.DnsStatus != nil || r.Params.HttpStatus != nil || r.Params.DnsReason != nil || r.Params.HttpReason != nil || r.Params.RejectionReason != nil || r.Params.ChangedSinceRunId != nil ||
+		r.Params.RdapStatus != nil || r.Params.RegisteredBefore != nil || r.Params.RegisteredAfter != nil || r.Params.ExpiresBefore != nil {
 		f := &store.ListCampaignDomainsFilter{}
 		if r.Params.DnsStatus != nil {
 			v := models.DomainDNSStatusEnum(*r.Params.DnsStatus)
@@ -2525,6 +2661,25 @@ func (h *strictHandlers) CampaignsDomainsList(ctx context.Context, r gen.Campaig
 			}
 			f.RejectionReasons = reasons
 		}
+		if r.Params.ChangedSinceRunId != nil {
+			runID := uuid.UUID(*r.Params.ChangedSinceRunId)
+			if h.deps.Stores.Snapshots != nil {
+				if _, err := h.deps.Stores.Snapshots.GetCampaignPhaseRun(ctx, h.deps.DB, uuid.UUID(r.CampaignId), runID); err != nil {
+					if errors.Is(err, store.ErrNotFound) {
+						return gen.CampaignsDomainsList404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "run not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+					}
+					return gen.CampaignsDomainsList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load run", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+				}
+			}
+			f.ChangedSinceRun = &runID
+		}
+		if r.Params.RdapStatus != nil {
+			v := string(*r.Params.RdapStatus)
+			f.RDAPStatus = &v
+		}
+		f.RegisteredBefore = r.Params.RegisteredBefore
+		f.RegisteredAfter = r.Params.RegisteredAfter
+		f.ExpiresBefore = r.Params.ExpiresBefore
 		domainFilter = f
 	}
 	// Advanced path (cursor-based)
@@ -2605,7 +2760,7 @@ func (h *strictHandlers) CampaignsDomainsList(ctx context.Context, r gen.Campaig
 			if fv, ok := featureMap[domainCopy]; ok {
 				features = mapRawToDomainAnalysisFeatures(fv)
 			}
-			items = append(items, gen.DomainListItem{Id: &id, Domain: &domainCopy, Offset: offsetPtr, CreatedAt: &createdAt, DnsStatus: dnsStatusPtr, HttpStatus: httpStatusPtr, LeadStatus: leadStatusPtr, DnsReason: dnsReasonPtr, HttpReason: httpReasonPtr, DomainScore: domainScorePtr, LeadScore: leadScorePtr, Features: features})
+			items = append(items, gen.DomainListItem{Id: &id, Domain: &domainCopy, Offset: offsetPtr, CreatedAt: &createdAt, DnsStatus: dnsStatusPtr, HttpStatus: httpStatusPtr, LeadStatus: leadStatusPtr, DnsReason: dnsReasonPtr, HttpReason: httpReasonPtr, DomainScore: domainScorePtr, LeadScore: leadScorePtr, Features: features, Registration: mapDomainRegistration(gd), Tls: mapDomainTLS(gd), Contacts: mapDomainContacts(gd)})
 		}
 		resp := gen.CampaignDomainsListResponse{CampaignId: openapi_types.UUID(r.CampaignId), Items: items}
 		if counters != nil {
@@ -2714,7 +2869,7 @@ func (h *strictHandlers) CampaignsDomainsList(ctx context.Context, r gen.Campaig
 			if fv, ok := featureMap[domainCopy]; ok {
 				features = mapRawToDomainAnalysisFeatures(fv)
 			}
-			items = append(items, gen.DomainListItem{Id: &id, Domain: &domainCopy, Offset: offsetPtr, CreatedAt: &createdAt, DnsStatus: dnsStatusPtr, HttpStatus: httpStatusPtr, LeadStatus: leadStatusPtr, DnsReason: dnsReasonPtr, HttpReason: httpReasonPtr, DomainScore: domainScorePtr, LeadScore: leadScorePtr, Features: features})
+			items = append(items, gen.DomainListItem{Id: &id, Domain: &domainCopy, Offset: offsetPtr, CreatedAt: &createdAt, DnsStatus: dnsStatusPtr, HttpStatus: httpStatusPtr, LeadStatus: leadStatusPtr, DnsReason: dnsReasonPtr, HttpReason: httpReasonPtr, DomainScore: domainScorePtr, LeadScore: leadScorePtr, Features: features, Registration: mapDomainRegistration(gd), Tls: mapDomainTLS(gd), Contacts: mapDomainContacts(gd)})
 		}
 		return items
 	}
@@ -2901,6 +3056,14 @@ func (h *strictHandlers) CampaignsDomainGenerationPatternOffset(ctx context.Cont
 		tld = *r.Body.Tld
 		tld = strings.TrimPrefix(tld, ".")
 	}
+	if r.Body.PatternType == gen.PatternOffsetRequestPatternTypeWordList || r.Body.PatternType == gen.PatternOffsetRequestPatternTypeWordTemplate {
+		_, wordHash, err := h.resolveWordPattern(ctx, string(r.Body.PatternType), r.Body.Template, r.Body.WordLists, r.Body.CharacterSet, *constant, tld, r.Body.Tlds)
+		if err != nil {
+			return gen.CampaignsDomainGenerationPatternOffset400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "invalid word pattern: " + err.Error(), Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		current := h.patternOffsetForHash(ctx, wordHash.HashString)
+		return gen.CampaignsDomainGenerationPatternOffset200JSONResponse(gen.PatternOffsetResponse{CurrentOffset: &current}), nil
+	}
 	var prefixNull sql.NullInt32
 	var suffixNull sql.NullInt32
 	legacyVar := 0
@@ -2965,11 +3128,20 @@ func (h *strictHandlers) CampaignsDomainGenerationPatternOffset(ctx context.Cont
 		ConstantString:       models.StringPtr(*constant),
 		TLD:                  tld,
 	}
+	if r.Body.Tlds != nil {
+		params.TLDs = *r.Body.Tlds
+	}
 	hashRes, err := domainexpert.GenerateDomainGenerationPhaseConfigHash(params)
 	if err != nil {
 		return gen.CampaignsDomainGenerationPatternOffset500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to compute pattern hash", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
-	cfgHash := hashRes.HashString
+	current := h.patternOffsetForHash(ctx, hashRes.HashString)
+	data := gen.PatternOffsetResponse{CurrentOffset: &current}
+	return gen.CampaignsDomainGenerationPatternOffset200JSONResponse(data), nil
+}
+
+// patternOffsetForHash returns the last persisted global offset for a config hash (0 when unseen).
+func (h *strictHandlers) patternOffsetForHash(ctx context.Context, cfgHash string) int64 {
 	// Prefer config manager path when available via services, else store fallback
 	var current int64
 	// Try via store
@@ -2982,8 +3154,7 @@ func (h *strictHandlers) CampaignsDomainGenerationPatternOffset(ctx context.Cont
 		log.Printf("pattern-offset: failed to get state by hash: %v", getErr)
 		current = 0
 	}
-	data := gen.PatternOffsetResponse{CurrentOffset: &current}
-	return gen.CampaignsDomainGenerationPatternOffset200JSONResponse(data), nil
+	return current
 }
 
 // augmentDomainFeatures injects feature objects into response.Items by converting each item to a generic map then back.
@@ -3641,7 +3812,7 @@ SELECT
     COUNT(*) AS total_domains,
     COUNT(*) FILTER (WHERE dns_status = 'pending') AS dns_pending,
     COUNT(*) FILTER (WHERE dns_status = 'ok') AS dns_ok,
-    COUNT(*) FILTER (WHERE dns_status = 'error') AS dns_error,
+    COUNT(*) FILTER (WHERE dns_status IN ('error','wildcard')) AS dns_error,
     COUNT(*) FILTER (WHERE dns_status = 'timeout') AS dns_timeout,
     COUNT(*) FILTER (WHERE http_status = 'pending') AS http_pending,
     COUNT(*) FILTER (WHERE http_status = 'ok') AS http_ok,
@@ -3736,25 +3907,9 @@ func (h *strictHandlers) rebuildCampaignDomainCounters(ctx context.Context, camp
 	return &stored, nil
 }
 
-// DiscoveryPreview implements POST /discovery/preview
-// Returns config_hash, next_offset, total_combinations, and prior campaigns for a discovery config
-func (h *strictHandlers) DiscoveryPreview(ctx context.Context, r gen.DiscoveryPreviewRequestObject) (gen.DiscoveryPreviewResponseObject, error) {
-	if h.deps == nil || h.deps.DB == nil || h.deps.Stores.Campaign == nil {
-		return gen.DiscoveryPreview500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{
-			Error:     gen.ApiError{Message: "dependencies not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()},
-			RequestId: reqID(), Success: boolPtr(false),
-		}}, nil
-	}
-
-	body := r.Body
-	if body == nil {
-		return gen.DiscoveryPreview400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{
-			Error:     gen.ApiError{Message: "request body required", Code: gen.BADREQUEST, Timestamp: time.Now()},
-			RequestId: reqID(), Success: boolPtr(false),
-		}}, nil
-	}
-
-	// Build domain generation params for hash calculation
+// discoveryPreviewVariablePattern builds the hash and generator for the character-set patterns
+// (prefix/suffix/both). A non-nil response is returned when the configuration is rejected.
+func discoveryPreviewVariablePattern(body *gen.DiscoveryPreviewJSONRequestBody) (*domainexpert.DomainGenerator, *domainexpert.GenerateDomainGenerationPhaseConfigHashResult, gen.DiscoveryPreviewResponseObject) {
 	prefixLen := 0
 	if body.PrefixVariableLength != nil {
 		prefixLen = *body.PrefixVariableLength
@@ -3776,14 +3931,20 @@ func (h *strictHandlers) DiscoveryPreview(ctx context.Context, r gen.DiscoveryPr
 		ConstantString:       &constStr,
 		TLD:                  body.Tld,
 	}
+	// Normalised like DomainGenerationConfig so ".COM" and "com" count once and hash alike
+	var tlds []string
+	if body.Tlds != nil {
+		tlds = domainservices.NormalizeTLDs(*body.Tlds)
+		params.TLDs = tlds
+	}
 
 	// Calculate config hash
 	hashResult, err := domainexpert.GenerateDomainGenerationPhaseConfigHash(params)
 	if err != nil {
-		return gen.DiscoveryPreview500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{
+		return nil, nil, gen.DiscoveryPreview500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{
 			Error:     gen.ApiError{Message: "failed to compute config hash: " + err.Error(), Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()},
 			RequestId: reqID(), Success: boolPtr(false),
-		}}, nil
+		}}
 	}
 
 	// Calculate total combinations using domain generator
@@ -3795,12 +3956,58 @@ func (h *strictHandlers) DiscoveryPreview(ctx context.Context, r gen.DiscoveryPr
 		constStr,
 		body.Tld,
 	)
+	if err == nil && len(tlds) > 0 {
+		generator, err = generator.WithTLDs(tlds)
+	}
 	if err != nil {
-		return gen.DiscoveryPreview400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{
+		return nil, nil, gen.DiscoveryPreview400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{
 			Error:     gen.ApiError{Message: "invalid pattern configuration: " + err.Error(), Code: gen.BADREQUEST, Timestamp: time.Now()},
 			RequestId: reqID(), Success: boolPtr(false),
+		}}
+	}
+	return generator, hashResult, nil
+}
+
+// DiscoveryPreview implements POST /discovery/preview
+// Returns config_hash, next_offset, total_combinations, and prior campaigns for a discovery config
+func (h *strictHandlers) DiscoveryPreview(ctx context.Context, r gen.DiscoveryPreviewRequestObject) (gen.DiscoveryPreviewResponseObject, error) {
+	if h.deps == nil || h.deps.DB == nil || h.deps.Stores.Campaign == nil {
+		return gen.DiscoveryPreview500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{
+			Error:     gen.ApiError{Message: "dependencies not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()},
+			RequestId: reqID(), Success: boolPtr(false),
+		}}, nil
+	}
+
+	body := r.Body
+	if body == nil {
+		return gen.DiscoveryPreview400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{
+			Error:     gen.ApiError{Message: "request body required", Code: gen.BADREQUEST, Timestamp: time.Now()},
+			RequestId: reqID(), Success: boolPtr(false),
 		}}, nil
 	}
+
+	var hashResult *domainexpert.GenerateDomainGenerationPhaseConfigHashResult
+	var generator *domainexpert.DomainGenerator
+	if body.PatternType == gen.DiscoveryPreviewJSONBodyPatternTypeWordList || body.PatternType == gen.DiscoveryPreviewJSONBodyPatternTypeWordTemplate {
+		constStr := ""
+		if body.ConstantString != nil {
+			constStr = *body.ConstantString
+		}
+		var err error
+		generator, hashResult, err = h.resolveWordPattern(ctx, string(body.PatternType), body.Template, body.WordLists, body.CharacterSet, constStr, body.Tld, body.Tlds)
+		if err != nil {
+			return gen.DiscoveryPreview400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{
+				Error:     gen.ApiError{Message: "invalid pattern configuration: " + err.Error(), Code: gen.BADREQUEST, Timestamp: time.Now()},
+				RequestId: reqID(), Success: boolPtr(false),
+			}}, nil
+		}
+	} else {
+		var resp gen.DiscoveryPreviewResponseObject
+		generator, hashResult, resp = discoveryPreviewVariablePattern(body)
+		if resp != nil {
+			return resp, nil
+		}
+	}
 	totalCombinations := generator.GetTotalCombinations()
 
 	// Get global config state to determine next_offset
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	domainservices "github.com/fntelecomllc/studio/backend/internal/domain/services"
+	"github.com/fntelecomllc/studio/backend/internal/export"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+)
+
+// domainExportBatchSize is the cursor FETCH size; output is flushed to the client after every batch.
+const domainExportBatchSize = 2000
+
+// domainExportErrorTrailer reports failures that happen after the 200 header has been sent.
+const domainExportErrorTrailer = "X-Export-Error"
+
+// CampaignsDomainsExport implements GET /campaigns/{campaignId}/domains/export
+func (h *strictHandlers) CampaignsDomainsExport(ctx context.Context, r gen.CampaignsDomainsExportRequestObject) (gen.CampaignsDomainsExportResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Campaign == nil || h.deps.Stores.Exports == nil || h.deps.DB == nil {
+		return gen.CampaignsDomainsExport500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "dependencies not ready", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	campaignID := uuid.UUID(r.CampaignId)
+	if _, err := h.deps.Stores.Campaign.GetCampaignByID(ctx, h.deps.DB, campaignID); err != nil {
+		if err == store.ErrNotFound {
+			return gen.CampaignsDomainsExport404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "campaign not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsDomainsExport500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load campaign", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+
+	format := export.FormatCSV
+	if r.Params.Format != nil {
+		format = export.Format(*r.Params.Format)
+	}
+	switch format {
+	case export.FormatCSV, export.FormatJSONL, export.FormatParquet:
+	default:
+		return gen.CampaignsDomainsExport400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: fmt.Sprintf("unsupported export format %q", format), Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	columnSpec := ""
+	if r.Params.Columns != nil {
+		columnSpec = *r.Params.Columns
+	}
+	cols, err := export.ParseColumns(columnSpec)
+	if err != nil {
+		return gen.CampaignsDomainsExport400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: err.Error(), Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Params.MinScore != nil && r.Params.MaxScore != nil && *r.Params.MinScore > *r.Params.MaxScore {
+		return gen.CampaignsDomainsExport400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "minScore must not exceed maxScore", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+
+	// Score breakdowns are recomputed from stored features with the campaign weights loaded once
+	var calc *domainservices.ScoreBreakdownCalculator
+	if export.NeedsScoreBreakdown(cols) {
+		calc, err = domainservices.NewScoreBreakdownCalculator(ctx, h.deps.DB.DB, campaignID)
+		if err != nil {
+			return gen.CampaignsDomainsExport500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load scoring profile", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+	}
+
+	return domainExportStream{
+		ctx:        ctx,
+		exports:    h.deps.Stores.Exports,
+		campaignID: campaignID,
+		filter:     domainExportFilterFromParams(r.Params),
+		format:     format,
+		columns:    cols,
+		calc:       calc,
+	}, nil
+}
+
+// domainExportFilterFromParams maps the export query parameters onto the store filter (same semantics as the domains list).
+func domainExportFilterFromParams(p gen.CampaignsDomainsExportParams) store.DomainExportFilter {
+	var f store.DomainExportFilter
+	if p.DnsStatus != nil {
+		v := models.DomainDNSStatusEnum(*p.DnsStatus)
+		f.DNSStatus = &v
+	}
+	if p.HttpStatus != nil {
+		v := models.DomainHTTPStatusEnum(*p.HttpStatus)
+		f.HTTPStatus = &v
+	}
+	if p.LeadStatus != nil {
+		v := models.DomainLeadStatusEnum(*p.LeadStatus)
+		f.LeadStatus = &v
+	}
+	f.DNSReason = p.DnsReason
+	f.HTTPReason = p.HttpReason
+	if p.RejectionReason != nil {
+		for _, reason := range *p.RejectionReason {
+			f.RejectionReasons = append(f.RejectionReasons, models.DomainRejectionReasonEnum(reason))
+		}
+	}
+	if p.MinScore != nil {
+		v := float64(*p.MinScore)
+		f.MinScore = &v
+	}
+	if p.MaxScore != nil {
+		v := float64(*p.MaxScore)
+		f.MaxScore = &v
+	}
+	f.NotParked = p.NotParked != nil && *p.NotParked
+	f.HasContact = p.HasContact != nil && *p.HasContact
+	f.Keyword = p.Keyword != nil && *p.Keyword != ""
+	if p.ChangedSinceRunId != nil {
+		runID := uuid.UUID(*p.ChangedSinceRunId)
+		f.ChangedSinceRun = &runID
+	}
+	if p.RdapStatus != nil {
+		v := string(*p.RdapStatus)
+		f.RDAPStatus = &v
+	}
+	f.RegisteredBefore = p.RegisteredBefore
+	f.RegisteredAfter = p.RegisteredAfter
+	f.ExpiresBefore = p.ExpiresBefore
+	if p.Sort != nil {
+		switch *p.Sort {
+		case "offset_desc":
+			f.SortBy, f.SortOrder = "offset_index", "DESC"
+		case "score_desc":
+			f.SortBy, f.SortOrder = "domain_score", "DESC"
+		case "score_asc":
+			f.SortBy, f.SortOrder = "domain_score", "ASC"
+		case "last_http_fetched_at_desc":
+			f.SortBy, f.SortOrder = "last_http_fetched_at", "DESC"
+		case "domain_asc":
+			f.SortBy, f.SortOrder = "domain_name", "ASC"
+		}
+	}
+	return f
+}
+
+// domainExportStream writes the export straight to the ResponseWriter so rows never accumulate in memory.
+type domainExportStream struct {
+	ctx        context.Context
+	exports    store.DomainExportStore
+	campaignID uuid.UUID
+	filter     store.DomainExportFilter
+	format     export.Format
+	columns    []export.Column
+	calc       *domainservices.ScoreBreakdownCalculator
+}
+
+func (s domainExportStream) VisitCampaignsDomainsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", s.format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"campaign-%s-domains.%s\"", s.campaignID, s.format.Extension()))
+	w.Header().Set("Trailer", domainExportErrorTrailer)
+	w.WriteHeader(http.StatusOK)
+
+	writer, err := export.NewRowWriter(s.format, w, s.columns)
+	if err != nil {
+		w.Header().Set(domainExportErrorTrailer, err.Error())
+		return nil
+	}
+	flusher, _ := w.(http.Flusher)
+	rows := 0
+	streamErr := s.exports.StreamCampaignDomains(s.ctx, s.campaignID, s.filter, domainExportBatchSize, func(batch []*models.GeneratedDomain) error {
+		for _, gd := range batch {
+			row := &export.Row{Domain: gd}
+			if s.calc != nil {
+				var fetchedAt *time.Time
+				if gd.LastHTTPFetchedAt.Valid {
+					fetchedAt = &gd.LastHTTPFetchedAt.Time
+				}
+				var registeredAt *time.Time
+				if gd.RDAPRegisteredAt.Valid {
+					registeredAt = &gd.RDAPRegisteredAt.Time
+				}
+				row.Breakdown = s.calc.Breakdown(gd.FeatureVector.Raw, fetchedAt, gd.IsParked, gd.ParkedConfidence, registeredAt)
+			}
+			if err := writer.WriteRow(row); err != nil {
+				return err
+			}
+		}
+		rows += len(batch)
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if streamErr == nil {
+		streamErr = writer.Close()
+	}
+	if streamErr != nil {
+		// Headers are already committed; surface the failure via trailer and logs rather than a JSON error body.
+		w.Header().Set(domainExportErrorTrailer, streamErr.Error())
+		log.Printf("ERROR campaigns.domains.export campaign=%s format=%s rows=%d err=%v", s.campaignID, s.format, rows, streamErr)
+		return nil
+	}
+	log.Printf("INFO campaigns.domains.export campaign=%s format=%s rows=%d", s.campaignID, s.format, rows)
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/domainexpert"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxDomainImportEntries caps a single upload after normalization and de-duplication.
+	maxDomainImportEntries = 1000000
+	// domainImportStageChunk bounds the array size passed to a single staging insert.
+	domainImportStageChunk = 5000
+)
+
+// CampaignsDomainsImport implements POST /campaigns/{campaignId}/domains/import
+func (h *strictHandlers) CampaignsDomainsImport(ctx context.Context, r gen.CampaignsDomainsImportRequestObject) (gen.CampaignsDomainsImportResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Campaign == nil || h.deps.Stores.Imports == nil || h.deps.DB == nil {
+		return gen.CampaignsDomainsImport500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "dependencies not ready", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil || strings.TrimSpace(r.Body.Content) == "" {
+		return gen.CampaignsDomainsImport400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "content is required", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	campaignID := uuid.UUID(r.CampaignId)
+	if _, err := h.deps.Stores.Campaign.GetCampaignByID(ctx, h.deps.DB, campaignID); err != nil {
+		if err == store.ErrNotFound {
+			return gen.CampaignsDomainsImport404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "campaign not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.CampaignsDomainsImport500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load campaign", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	opts := domainexpert.ImportOptions{}
+	if r.Body.Format != nil {
+		opts.Format = domainexpert.ImportFormat(*r.Body.Format)
+	}
+	if r.Body.Column != nil {
+		opts.Column = *r.Body.Column
+	}
+	if r.Body.Field != nil {
+		opts.Field = *r.Body.Field
+	}
+	parsed, err := domainexpert.ParseDomainImport(strings.NewReader(r.Body.Content), opts)
+	if err != nil {
+		return gen.CampaignsDomainsImport400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "invalid import: " + err.Error(), Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if len(parsed.Domains) > maxDomainImportEntries {
+		return gen.CampaignsDomainsImport400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "import exceeds maximum of 1000000 domains", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+
+	// Stage the whole upload in one transaction so a failure part-way leaves nothing behind
+	tx, err := h.deps.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return gen.CampaignsDomainsImport500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to stage imported domains", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	defer tx.Rollback()
+	var staged int64
+	for start := 0; start < len(parsed.Domains); start += domainImportStageChunk {
+		end := start + domainImportStageChunk
+		if end > len(parsed.Domains) {
+			end = len(parsed.Domains)
+		}
+		n, serr := h.deps.Stores.Imports.StageDomainImport(ctx, tx, campaignID, parsed.Domains[start:end])
+		if serr != nil {
+			return gen.CampaignsDomainsImport500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to stage imported domains", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		staged += n
+	}
+	total, err := h.deps.Stores.Imports.CountStagedImportDomains(ctx, tx, campaignID)
+	if err != nil {
+		return gen.CampaignsDomainsImport500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to count staged domains", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return gen.CampaignsDomainsImport500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to stage imported domains", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+
+	resp := gen.CampaignsDomainsImport200JSONResponse{
+		Staged:      staged,
+		Duplicates:  int64(parsed.Duplicates) + int64(len(parsed.Domains)) - staged,
+		Rejected:    int64(parsed.Rejected),
+		TotalStaged: total,
+	}
+	if len(parsed.RejectedSample) > 0 {
+		samples := make([]gen.DomainImportRejection, len(parsed.RejectedSample))
+		for i, rj := range parsed.RejectedSample {
+			samples[i] = gen.DomainImportRejection{Line: rj.Line, Value: rj.Value, Reason: rj.Reason}
+		}
+		resp.RejectedSamples = &samples
+	}
+	return resp, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	pg_store "github.com/fntelecomllc/studio/backend/internal/store/postgres"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func newImportTestHandlers(t *testing.T) (*strictHandlers, sqlmock.Sqlmock, uuid.UUID) {
+	db, mock := createTestDBWithMock(t)
+	deps := &AppDeps{DB: db}
+	deps.Stores.Campaign = pg_store.NewCampaignStorePostgres(db)
+	deps.Stores.Imports = pg_store.NewDomainImportStorePostgres(db)
+	campaignID := uuid.New()
+	mock.ExpectQuery(`SELECT id, name, current_phase`).
+		WithArgs(campaignID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(campaignID))
+	return &strictHandlers{deps: deps}, mock, campaignID
+}
+
+func TestCampaignsDomainsImport_StagesInOneTransaction(t *testing.T) {
+	h, mock, campaignID := newImportTestHandlers(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO campaign_domain_import_entries`).
+		WithArgs(campaignID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM campaign_domain_import_entries`).
+		WithArgs(campaignID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectCommit()
+
+	resp, err := h.CampaignsDomainsImport(context.Background(), gen.CampaignsDomainsImportRequestObject{
+		CampaignId: openapi_types.UUID(campaignID),
+		Body:       &gen.CampaignsDomainsImportJSONRequestBody{Content: "a.com\nb.com\nc.com\n"},
+	})
+	if err != nil {
+		t.Fatalf("CampaignsDomainsImport: %v", err)
+	}
+	ok, isOK := resp.(gen.CampaignsDomainsImport200JSONResponse)
+	if !isOK {
+		t.Fatalf("expected 200, got %T", resp)
+	}
+	// c.com already belongs to the campaign, so only two rows were staged
+	if ok.Staged != 2 || ok.Duplicates != 1 {
+		t.Fatalf("expected 2 staged and 1 duplicate, got %+v", ok)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestCampaignsDomainsImport_RollsBackOnStagingFailure(t *testing.T) {
+	h, mock, campaignID := newImportTestHandlers(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO campaign_domain_import_entries`).
+		WithArgs(campaignID, sqlmock.AnyArg()).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	resp, _ := h.CampaignsDomainsImport(context.Background(), gen.CampaignsDomainsImportRequestObject{
+		CampaignId: openapi_types.UUID(campaignID),
+		Body:       &gen.CampaignsDomainsImportJSONRequestBody{Content: "a.com\n"},
+	})
+	if _, ok := resp.(gen.CampaignsDomainsImport500JSONResponse); !ok {
+		t.Fatalf("expected 500, got %T", resp)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
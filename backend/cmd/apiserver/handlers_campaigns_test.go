@@ -224,3 +224,29 @@ func (s *stubHTTPService) Validate(ctx context.Context, config interface{}) erro
 func (s *stubHTTPService) GetPhaseType() models.PhaseTypeEnum {
 	return models.PhaseTypeHTTPKeywordValidation
 }
+
+func TestDiscoveryPreviewVariablePattern_NormalizesTLDs(t *testing.T) {
+	preview := func(tlds []string) (int64, string) {
+		prefix := 2
+		body := &gen.DiscoveryPreviewJSONRequestBody{
+			PatternType:          gen.PrefixVariable,
+			PrefixVariableLength: &prefix,
+			CharacterSet:         "ab",
+			Tld:                  ".net",
+			Tlds:                 &tlds,
+		}
+		generator, hash, resp := discoveryPreviewVariablePattern(body)
+		if resp != nil {
+			t.Fatalf("tlds %v rejected: %#v", tlds, resp)
+		}
+		return generator.GetTotalCombinations(), hash.HashString
+	}
+	total, hash := preview([]string{"net", ".COM", ".com"})
+	wantTotal, wantHash := preview([]string{".net", ".com"})
+	if total != wantTotal || total != 8 {
+		t.Fatalf("expected 8 combinations across two TLDs, got %d (normalized %d)", total, wantTotal)
+	}
+	if hash != wantHash {
+		t.Fatalf("hash %s differs from normalized hash %s", hash, wantHash)
+	}
+}
@@ -32,7 +32,11 @@ func (h *strictHandlers) ConfigUpdateAuthentication(ctx context.Context, r gen.C
 		cfg := config.GetDefaultAuthConfig()
 		h.deps.Config.Server.AuthConfig = &cfg
 	}
-	applyAuthConfigPatch(h.deps.Config.Server.AuthConfig, *r.Body)
+	if fail := h.commitConfig(ctx, config.SectionAuthentication, r.Params.IfMatch, func(c *config.AppConfig) {
+		applyAuthConfigPatch(c.Server.AuthConfig, *r.Body)
+	}); fail != nil {
+		return fail, nil
+	}
 	if h.deps.Session != nil {
 		cfg := h.deps.Session.GetConfig()
 		if h.deps.Config.Server.AuthConfig.SessionDuration > 0 {
@@ -42,9 +46,6 @@ func (h *strictHandlers) ConfigUpdateAuthentication(ctx context.Context, r gen.C
 			cfg.IdleTimeout = h.deps.Config.Server.AuthConfig.SessionIdleTimeout
 		}
 	}
-	if err := config.SaveAppConfig(h.deps.Config); err != nil {
-		return gen.ConfigUpdateAuthentication500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to save config", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
-	}
 	api := mapAuthConfigToAPI(*h.deps.Config.Server.AuthConfig)
 	return gen.ConfigUpdateAuthentication200JSONResponse(api), nil
 }
@@ -75,9 +76,8 @@ func (h *strictHandlers) ConfigUpdateDnsValidator(ctx context.Context, r gen.Con
 	if updated.QueryTimeoutSeconds <= 0 {
 		return gen.ConfigUpdateDnsValidator400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "queryTimeoutSeconds must be positive", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
-	h.deps.Config.DNSValidator = updated
-	if err := config.SaveAppConfig(h.deps.Config); err != nil {
-		return gen.ConfigUpdateDnsValidator500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to save config", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	if fail := h.commitConfig(ctx, config.SectionDNSValidator, r.Params.IfMatch, func(c *config.AppConfig) { c.DNSValidator = updated }); fail != nil {
+		return fail, nil
 	}
 	apiCfg, err := convertStruct[gen.DNSValidatorConfigJSON](config.ConvertDNSConfigToJSON(updated))
 	if err != nil {
@@ -109,9 +109,8 @@ func (h *strictHandlers) ConfigUpdateHttp(ctx context.Context, r gen.ConfigUpdat
 	if updated.RequestTimeoutSeconds <= 0 {
 		return gen.ConfigUpdateHttp400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "requestTimeoutSeconds must be positive", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
-	h.deps.Config.HTTPValidator = updated
-	if err := config.SaveAppConfig(h.deps.Config); err != nil {
-		return gen.ConfigUpdateHttp500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to save config", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	if fail := h.commitConfig(ctx, config.SectionHTTPValidator, r.Params.IfMatch, func(c *config.AppConfig) { c.HTTPValidator = updated }); fail != nil {
+		return fail, nil
 	}
 	data := config.ConvertHTTPConfigToJSON(updated)
 	return gen.ConfigUpdateHttp200JSONResponse(map[string]interface{}{"data": data}), nil
@@ -143,9 +142,8 @@ func (h *strictHandlers) ConfigUpdateLogging(ctx context.Context, r gen.ConfigUp
 	if _, ok := valid[newCfg.Level]; !ok {
 		return gen.ConfigUpdateLogging400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "invalid logging level", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
-	h.deps.Config.Logging = newCfg
-	if err := config.SaveAppConfig(h.deps.Config); err != nil {
-		return gen.ConfigUpdateLogging500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to save config", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	if fail := h.commitConfig(ctx, config.SectionLogging, r.Params.IfMatch, func(c *config.AppConfig) { c.Logging = newCfg }); fail != nil {
+		return fail, nil
 	}
 	apiCfg, err := convertStruct[gen.LoggingConfig](h.deps.Config.Logging)
 	if err != nil {
@@ -183,9 +181,8 @@ func (h *strictHandlers) ConfigUpdateRateLimiter(ctx context.Context, r gen.Conf
 	if rl.MaxRequests <= 0 || rl.WindowSeconds <= 0 {
 		return gen.ConfigUpdateRateLimiter400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "maxRequests and windowSeconds must be positive", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
-	h.deps.Config.RateLimiter = rl
-	if err := config.SaveAppConfig(h.deps.Config); err != nil {
-		return gen.ConfigUpdateRateLimiter500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to save config", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	if fail := h.commitConfig(ctx, config.SectionRateLimiter, r.Params.IfMatch, func(c *config.AppConfig) { c.RateLimiter = rl }); fail != nil {
+		return fail, nil
 	}
 	apiCfg, err := convertStruct[gen.RateLimiterConfig](h.deps.Config.RateLimiter)
 	if err != nil {
@@ -219,23 +216,27 @@ func (h *strictHandlers) ConfigUpdateServer(ctx context.Context, r gen.ConfigUpd
 		return gen.ConfigUpdateServer400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "missing body", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
 	body := *r.Body
-	if v, ok := body["streamChunkSize"].(float64); ok {
-		chunk := int(v)
-		if chunk <= 0 {
-			return gen.ConfigUpdateServer400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "streamChunkSize must be positive", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
-		}
-		h.deps.Config.Server.StreamChunkSize = chunk
+	chunk, hasChunk := body["streamChunkSize"].(float64)
+	if hasChunk && int(chunk) <= 0 {
+		return gen.ConfigUpdateServer400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "streamChunkSize must be positive", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
-	if v, ok := body["ginMode"].(string); ok {
-		switch v {
+	ginMode, hasGinMode := body["ginMode"].(string)
+	if hasGinMode {
+		switch ginMode {
 		case "debug", "release", "test":
-			h.deps.Config.Server.GinMode = v
 		default:
-			return gen.ConfigUpdateServer400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: fmt.Sprintf("invalid ginMode: %s", v), Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+			return gen.ConfigUpdateServer400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: fmt.Sprintf("invalid ginMode: %s", ginMode), Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 		}
 	}
-	if err := config.SaveAppConfig(h.deps.Config); err != nil {
-		return gen.ConfigUpdateServer500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to save config", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	if fail := h.commitConfig(ctx, config.SectionServer, r.Params.IfMatch, func(c *config.AppConfig) {
+		if hasChunk {
+			c.Server.StreamChunkSize = int(chunk)
+		}
+		if hasGinMode {
+			c.Server.GinMode = ginMode
+		}
+	}); fail != nil {
+		return fail, nil
 	}
 	resp := map[string]interface{}{
 		"port":            h.deps.Config.Server.Port,
@@ -267,9 +268,8 @@ func (h *strictHandlers) ConfigUpdateWorker(ctx context.Context, r gen.ConfigUpd
 	if b, err := json.Marshal(*r.Body); err == nil {
 		_ = json.Unmarshal(b, &wc)
 	}
-	h.deps.Config.Worker = wc
-	if err := config.SaveAppConfig(h.deps.Config); err != nil {
-		return gen.ConfigUpdateWorker500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to save config", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	if fail := h.commitConfig(ctx, config.SectionWorker, r.Params.IfMatch, func(c *config.AppConfig) { c.Worker = wc }); fail != nil {
+		return fail, nil
 	}
 	apiCfg, err := convertStruct[gen.WorkerConfig](h.deps.Config.Worker)
 	if err != nil {
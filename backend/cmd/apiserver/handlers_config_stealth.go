@@ -25,9 +25,8 @@ func (h *strictHandlers) ConfigUpdateStealth(ctx context.Context, r gen.ConfigUp
 		return gen.ConfigUpdateStealth400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "missing body", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
 	body := *r.Body
-	h.deps.Config.Features.EnableStealth = body.Enabled
-	if err := config.SaveAppConfig(h.deps.Config); err != nil {
-		return gen.ConfigUpdateStealth500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to save stealth config", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	if fail := h.commitConfig(ctx, config.SectionStealth, r.Params.IfMatch, func(c *config.AppConfig) { c.Features.EnableStealth = body.Enabled }); fail != nil {
+		return fail, nil
 	}
 	return gen.ConfigUpdateStealth200JSONResponse{Enabled: boolPtr(h.deps.Config.Features.EnableStealth)}, nil
 }
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/config"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+)
+
+// configUpdateFailure is the error response shared by the config update handlers.
+type configUpdateFailure struct {
+	status int
+	code   gen.ErrorCode
+	msg    string
+}
+
+func (f *configUpdateFailure) write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(f.status)
+	return json.NewEncoder(w).Encode(gen.ErrorEnvelope{
+		Error:     gen.ApiError{Message: f.msg, Code: f.code, Timestamp: time.Now()},
+		RequestId: reqID(),
+		Success:   boolPtr(false),
+	})
+}
+
+func (f *configUpdateFailure) VisitConfigUpdateAuthenticationResponse(w http.ResponseWriter) error {
+	return f.write(w)
+}
+
+func (f *configUpdateFailure) VisitConfigUpdateDnsValidatorResponse(w http.ResponseWriter) error {
+	return f.write(w)
+}
+
+func (f *configUpdateFailure) VisitConfigUpdateHttpResponse(w http.ResponseWriter) error {
+	return f.write(w)
+}
+
+func (f *configUpdateFailure) VisitConfigUpdateLoggingResponse(w http.ResponseWriter) error {
+	return f.write(w)
+}
+
+func (f *configUpdateFailure) VisitConfigUpdateRateLimiterResponse(w http.ResponseWriter) error {
+	return f.write(w)
+}
+
+func (f *configUpdateFailure) VisitConfigUpdateServerResponse(w http.ResponseWriter) error {
+	return f.write(w)
+}
+
+func (f *configUpdateFailure) VisitConfigUpdateWorkerResponse(w http.ResponseWriter) error {
+	return f.write(w)
+}
+
+func (f *configUpdateFailure) VisitConfigUpdateProxyManagerResponse(w http.ResponseWriter) error {
+	return f.write(w)
+}
+
+func (f *configUpdateFailure) VisitConfigUpdateStealthResponse(w http.ResponseWriter) error {
+	return f.write(w)
+}
+
+// configVersions returns the versioner, or a file-only one when none is wired (e.g. in tests).
+func (h *strictHandlers) configVersions() *configVersioner {
+	if h.deps.ConfigVersions != nil {
+		return h.deps.ConfigVersions
+	}
+	return &configVersioner{cfg: h.deps.Config}
+}
+
+// commitConfig saves a change to one config section as a new version and applies it. ifMatch is
+// the client's If-Match header: the version number the change was based on.
+func (h *strictHandlers) commitConfig(ctx context.Context, section string, ifMatch *gen.ConfigIfMatch, mutate func(*config.AppConfig)) *configUpdateFailure {
+	ch := configChange{section: section, changeType: models.ConfigChangeUpdate}
+	if ifMatch != nil {
+		n, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(strings.TrimSpace(*ifMatch), "W/"), `"`))
+		if err != nil || n < 0 {
+			return &configUpdateFailure{status: http.StatusBadRequest, code: gen.BADREQUEST, msg: "If-Match must be a config version number"}
+		}
+		ch.expected = &n
+	}
+	v := h.configVersions()
+	if uid, ok := sessionUserID(ctx); ok {
+		ch.author = uid
+	} else if v.persistent() {
+		return &configUpdateFailure{status: http.StatusUnauthorized, code: gen.UNAUTHORIZED, msg: "authentication required"}
+	}
+	ver, created, err := v.commit(ctx, ch, mutate)
+	switch {
+	case errors.Is(err, store.ErrOptimisticLock):
+		return &configUpdateFailure{status: http.StatusConflict, code: gen.CONFLICT, msg: fmt.Sprintf("%s settings were changed by someone else; reload them and try again", section)}
+	case err != nil:
+		return &configUpdateFailure{status: http.StatusInternalServerError, code: gen.INTERNALSERVERERROR, msg: "failed to save config"}
+	}
+	if created {
+		h.auditConfigChange(ctx, ver)
+	}
+	return nil
+}
+
+func (h *strictHandlers) auditConfigChange(ctx context.Context, ver *models.ConfigVersion) {
+	logAuthEvent("CONFIG_CHANGED", &ver.CreatedBy, "", ver.ChangeType, map[string]interface{}{
+		"section": ver.ConfigKey, "version": ver.VersionNumber, "changes": ver.ChangeDiff,
+	})
+}
+
+// ConfigVersionsList implements GET /config/versions.
+func (h *strictHandlers) ConfigVersionsList(ctx context.Context, r gen.ConfigVersionsListRequestObject) (gen.ConfigVersionsListResponseObject, error) {
+	if h.deps == nil || h.deps.ConfigVersions == nil || !h.deps.ConfigVersions.persistent() {
+		return gen.ConfigVersionsList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "config versioning not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	limit, offset := 50, 0
+	if r.Params.Limit != nil {
+		limit = *r.Params.Limit
+	}
+	if r.Params.Offset != nil {
+		offset = *r.Params.Offset
+	}
+	section := ""
+	if r.Params.Section != nil {
+		section = string(*r.Params.Section)
+	}
+	versions, err := h.deps.ConfigVersions.store.ListConfigVersions(ctx, nil, section, limit, offset)
+	if err != nil {
+		return gen.ConfigVersionsList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to list config versions", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	items := make(gen.ConfigVersionsList200JSONResponse, 0, len(versions))
+	for _, v := range versions {
+		if !config.ValidRuntimeSection(v.ConfigKey) {
+			continue
+		}
+		items = append(items, mapConfigVersionToAPI(v))
+	}
+	return items, nil
+}
+
+// ConfigVersionsRollback implements POST /config/versions/{versionId}/rollback.
+func (h *strictHandlers) ConfigVersionsRollback(ctx context.Context, r gen.ConfigVersionsRollbackRequestObject) (gen.ConfigVersionsRollbackResponseObject, error) {
+	if h.deps == nil || h.deps.ConfigVersions == nil || !h.deps.ConfigVersions.persistent() {
+		return gen.ConfigVersionsRollback500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "config versioning not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	author, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.ConfigVersionsRollback401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	reason := ""
+	if r.Body != nil && r.Body.Reason != nil {
+		reason = strings.TrimSpace(*r.Body.Reason)
+	}
+	ver, created, err := h.deps.ConfigVersions.rollback(ctx, r.VersionId, author, reason)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return gen.ConfigVersionsRollback404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "config version not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	case errors.Is(err, store.ErrOptimisticLock):
+		return gen.ConfigVersionsRollback409JSONResponse{ConflictJSONResponse: gen.ConflictJSONResponse{Error: gen.ApiError{Message: "config was changed during the rollback; try again", Code: gen.CONFLICT, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	case err != nil:
+		return gen.ConfigVersionsRollback500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to roll back config", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if created {
+		h.auditConfigChange(ctx, ver)
+	}
+	return gen.ConfigVersionsRollback200JSONResponse(mapConfigVersionToAPI(ver)), nil
+}
+
+func mapConfigVersionToAPI(v *models.ConfigVersion) gen.ConfigVersion {
+	out := gen.ConfigVersion{
+		Id:         v.ID,
+		Section:    gen.ConfigSection(v.ConfigKey),
+		Version:    v.VersionNumber,
+		Value:      map[string]interface{}{},
+		Changes:    []gen.ConfigFieldChange{},
+		ChangeType: gen.ConfigVersionChangeType(v.ChangeType),
+		Current:    v.IsCurrent,
+		CreatedBy:  v.CreatedBy,
+		CreatedAt:  v.CreatedAt,
+	}
+	_ = json.Unmarshal(v.ConfigValue, &out.Value)
+	if v.ChangeDiff != nil {
+		_ = json.Unmarshal(*v.ChangeDiff, &out.Changes)
+	}
+	if v.ChangeReason.Valid {
+		out.Reason = &v.ChangeReason.String
+	}
+	if v.RollbackVersion.Valid {
+		restored := int(v.RollbackVersion.Int32)
+		out.RestoredVersion = &restored
+	}
+	return out
+}
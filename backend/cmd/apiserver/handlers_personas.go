@@ -8,6 +8,7 @@ import (
 	"time"
 
 	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/httpfingerprint"
 	"github.com/fntelecomllc/studio/backend/internal/models"
 	"github.com/fntelecomllc/studio/backend/internal/store"
 	"github.com/google/uuid"
@@ -66,6 +67,14 @@ func parsePersonaConfig(pt models.PersonaTypeEnum, raw json.RawMessage) (json.Ra
 		if err := json.Unmarshal(raw, &cfg); err != nil {
 			return nil, fmt.Errorf("invalid HTTP persona configuration: %w", err)
 		}
+		if err := httpfingerprint.Validate(cfg); err != nil {
+			return nil, fmt.Errorf("invalid HTTP persona TLS settings: %w", err)
+		}
+		switch cfg.RenderMode {
+		case "", models.HTTPRenderModeStatic, models.HTTPRenderModeHeadless, models.HTTPRenderModeAuto:
+		default:
+			return nil, fmt.Errorf("invalid HTTP persona renderMode %q (static|headless|auto)", cfg.RenderMode)
+		}
 		b, err := json.Marshal(cfg)
 		if err != nil {
 			return nil, fmt.Errorf("marshal http config: %w", err)
@@ -133,6 +133,9 @@ func (f *fakeProxyPoolStore) RemoveProxyFromPool(ctx context.Context, exec store
 func (f *fakeProxyPoolStore) ListProxiesForPool(ctx context.Context, exec store.Querier, poolID uuid.UUID) ([]*models.Proxy, error) {
 	return nil, nil
 }
+func (f *fakeProxyPoolStore) ListPoolMemberships(ctx context.Context, exec store.Querier, poolID uuid.UUID) ([]*models.ProxyPoolMembership, error) {
+	return nil, nil
+}
 
 func newProxiesHandlersForTest() *strictHandlers {
 	deps := &AppDeps{}
@@ -311,3 +314,32 @@ func TestProxiesAndPoolsCRUDSmoke(t *testing.T) {
 		t.Fatalf("expected 204 pool delete")
 	}
 }
+
+func TestProxyPoolsCreateValidatesStrategyAndThresholds(t *testing.T) {
+	h := newProxiesHandlersForTest()
+	ctx := context.Background()
+
+	bogus := gen.ProxyPoolStrategy("failover")
+	resp, err := h.ProxyPoolsCreate(ctx, gen.ProxyPoolsCreateRequestObject{Body: &gen.ProxyPoolsCreateJSONRequestBody{Name: "bad", PoolStrategy: &bogus}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, ok := resp.(gen.ProxyPoolsCreate400JSONResponse); !ok {
+		t.Fatalf("expected 400 for unsupported strategy, got %T", resp)
+	}
+	rate := 1.5
+	resp, _ = h.ProxyPoolsCreate(ctx, gen.ProxyPoolsCreateRequestObject{Body: &gen.ProxyPoolsCreateJSONRequestBody{Name: "bad-rate", MinSuccessRate: &rate}})
+	if _, ok := resp.(gen.ProxyPoolsCreate400JSONResponse); !ok {
+		t.Fatalf("expected 400 for minSuccessRate > 1, got %T", resp)
+	}
+
+	sticky := gen.ProxyPoolStrategySticky
+	resp, _ = h.ProxyPoolsCreate(ctx, gen.ProxyPoolsCreateRequestObject{Body: &gen.ProxyPoolsCreateJSONRequestBody{Name: "sticky", PoolStrategy: &sticky}})
+	created, ok := resp.(gen.ProxyPoolsCreate201JSONResponse)
+	if !ok {
+		t.Fatalf("expected 201, got %T", resp)
+	}
+	if created.PoolStrategy == nil || *created.PoolStrategy != sticky || created.MaxConsecutiveFailures == nil || *created.MaxConsecutiveFailures != 3 {
+		t.Fatalf("unexpected pool %+v", created)
+	}
+}
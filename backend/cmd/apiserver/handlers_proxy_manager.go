@@ -29,9 +29,8 @@ func (h *strictHandlers) ConfigUpdateProxyManager(ctx context.Context, r gen.Con
 	if b, err := json.Marshal(*r.Body); err == nil {
 		_ = json.Unmarshal(b, &pmCfg)
 	}
-	h.deps.Config.ProxyManager = pmCfg
-	if err := config.SaveAppConfig(h.deps.Config); err != nil {
-		return gen.ConfigUpdateProxyManager500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to save config", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	if fail := h.commitConfig(ctx, config.SectionProxyManager, r.Params.IfMatch, func(c *config.AppConfig) { c.ProxyManager = pmCfg }); fail != nil {
+		return fail, nil
 	}
-	return gen.ConfigUpdateProxyManager200JSONResponse(toMap(config.ConvertProxyManagerConfigToJSON(pmCfg))), nil
+	return gen.ConfigUpdateProxyManager200JSONResponse(toMap(config.ConvertProxyManagerConfigToJSON(h.deps.Config.ProxyManager))), nil
 }
@@ -3,10 +3,13 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
 	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/proxymanager"
+	"github.com/fntelecomllc/studio/backend/internal/store"
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
@@ -21,12 +24,7 @@ func (h *strictHandlers) ProxyPoolsList(ctx context.Context, r gen.ProxyPoolsLis
 	}
 	out := make([]gen.ProxyPool, 0, len(pools))
 	for _, p := range pools {
-		id := openapi_types.UUID(p.ID)
-		name := p.Name
-		isEnabled := p.IsEnabled
-		created := p.CreatedAt
-		updated := p.UpdatedAt
-		out = append(out, gen.ProxyPool{Id: &id, Name: &name, IsEnabled: &isEnabled, CreatedAt: &created, UpdatedAt: &updated})
+		out = append(out, mapProxyPool(p))
 	}
 	return gen.ProxyPoolsList200JSONResponse(out), nil
 }
@@ -48,24 +46,21 @@ func (h *strictHandlers) ProxyPoolsCreate(ctx context.Context, r gen.ProxyPoolsC
 			}
 			return true
 		}(),
-		CreatedAt: now,
-		UpdatedAt: now,
+		PoolStrategy:           sql.NullString{String: proxymanager.StrategyRoundRobin, Valid: true},
+		MaxConsecutiveFailures: proxymanager.DefaultMaxConsecutiveFailures,
+		CreatedAt:              now,
+		UpdatedAt:              now,
 	}
 	if r.Body.Description != nil {
 		pool.Description = sql.NullString{String: *r.Body.Description, Valid: *r.Body.Description != ""}
 	}
-	if r.Body.PoolStrategy != nil {
-		pool.PoolStrategy = sql.NullString{String: *r.Body.PoolStrategy, Valid: *r.Body.PoolStrategy != ""}
+	if msg := applyProxyPoolRequest(pool, r.Body); msg != "" {
+		return gen.ProxyPoolsCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: msg, Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
 	if err := h.deps.Stores.ProxyPools.CreateProxyPool(ctx, h.deps.DB, pool); err != nil {
 		return gen.ProxyPoolsCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to create proxy pool", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
-	id := openapi_types.UUID(pool.ID)
-	name := pool.Name
-	isEnabled := pool.IsEnabled
-	created := pool.CreatedAt
-	updated := pool.UpdatedAt
-	data := gen.ProxyPool{Id: &id, Name: &name, IsEnabled: &isEnabled, CreatedAt: &created, UpdatedAt: &updated}
+	data := mapProxyPool(pool)
 	return gen.ProxyPoolsCreate201JSONResponse(data), nil
 }
 
@@ -76,6 +71,7 @@ func (h *strictHandlers) ProxyPoolsDelete(ctx context.Context, r gen.ProxyPoolsD
 	if err := h.deps.Stores.ProxyPools.DeleteProxyPool(ctx, h.deps.DB, uuid.UUID(r.PoolId)); err != nil {
 		return gen.ProxyPoolsDelete500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to delete proxy pool", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
+	h.invalidateProxyPool(uuid.UUID(r.PoolId))
 	return gen.ProxyPoolsDelete204Response{}, nil
 }
 
@@ -99,31 +95,15 @@ func (h *strictHandlers) ProxyPoolsUpdate(ctx context.Context, r gen.ProxyPoolsU
 	if r.Body.IsEnabled != nil {
 		pool.IsEnabled = *r.Body.IsEnabled
 	}
-	if r.Body.PoolStrategy != nil {
-		pool.PoolStrategy = sql.NullString{String: *r.Body.PoolStrategy, Valid: *r.Body.PoolStrategy != ""}
-	}
-	if r.Body.HealthCheckEnabled != nil {
-		pool.HealthCheckEnabled = *r.Body.HealthCheckEnabled
-	}
-	if r.Body.HealthCheckIntervalSeconds != nil {
-		pool.HealthCheckIntervalSeconds = r.Body.HealthCheckIntervalSeconds
-	}
-	if r.Body.MaxRetries != nil {
-		pool.MaxRetries = r.Body.MaxRetries
-	}
-	if r.Body.TimeoutSeconds != nil {
-		pool.TimeoutSeconds = r.Body.TimeoutSeconds
+	if msg := applyProxyPoolRequest(pool, r.Body); msg != "" {
+		return gen.ProxyPoolsUpdate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: msg, Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
 	pool.UpdatedAt = time.Now()
 	if err := h.deps.Stores.ProxyPools.UpdateProxyPool(ctx, h.deps.DB, pool); err != nil {
 		return gen.ProxyPoolsUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to update proxy pool", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
-	id := openapi_types.UUID(pool.ID)
-	name := pool.Name
-	isEnabled := pool.IsEnabled
-	created := pool.CreatedAt
-	updated := pool.UpdatedAt
-	data := gen.ProxyPool{Id: &id, Name: &name, IsEnabled: &isEnabled, CreatedAt: &created, UpdatedAt: &updated}
+	h.invalidateProxyPool(pool.ID)
+	data := mapProxyPool(pool)
 	return gen.ProxyPoolsUpdate200JSONResponse(data), nil
 }
 
@@ -141,6 +121,7 @@ func (h *strictHandlers) ProxyPoolsAddProxy(ctx context.Context, r gen.ProxyPool
 	if err := h.deps.Stores.ProxyPools.AddProxyToPool(ctx, h.deps.DB, m); err != nil {
 		return gen.ProxyPoolsAddProxy400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "failed to add proxy to pool", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
+	h.invalidateProxyPool(m.PoolID)
 	pid := openapi_types.UUID(r.PoolId)
 	xid := openapi_types.UUID(r.Body.ProxyId)
 	return gen.ProxyPoolsAddProxy201JSONResponse(gen.ProxyPoolMembership{AddedAt: func() *time.Time { t := m.AddedAt; return &t }(), IsActive: &m.IsActive, PoolId: &pid, ProxyId: &xid, Weight: m.Weight}), nil
@@ -153,5 +134,126 @@ func (h *strictHandlers) ProxyPoolsRemoveProxy(ctx context.Context, r gen.ProxyP
 	if err := h.deps.Stores.ProxyPools.RemoveProxyFromPool(ctx, h.deps.DB, uuid.UUID(r.PoolId), uuid.UUID(r.ProxyId)); err != nil {
 		return gen.ProxyPoolsRemoveProxy400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "failed to remove proxy from pool", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
 	}
+	h.invalidateProxyPool(uuid.UUID(r.PoolId))
 	return gen.ProxyPoolsRemoveProxy204Response{}, nil
 }
+
+// ProxyPoolsStats implements GET /proxy-pools/{poolId}/stats
+func (h *strictHandlers) ProxyPoolsStats(ctx context.Context, r gen.ProxyPoolsStatsRequestObject) (gen.ProxyPoolsStatsResponseObject, error) {
+	if h.deps == nil || h.deps.ProxyMgr == nil {
+		return gen.ProxyPoolsStats500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "proxy manager not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	stats, err := h.deps.ProxyMgr.PoolStats(ctx, uuid.UUID(r.PoolId))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.ProxyPoolsStats404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "proxy pool not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.ProxyPoolsStats500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to load proxy pool stats", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	out := gen.ProxyPoolStats{
+		PoolId:        openapi_types.UUID(stats.PoolID),
+		Name:          stats.Name,
+		Strategy:      gen.ProxyPoolStrategy(stats.Strategy),
+		EligibleCount: stats.Eligible,
+		Members:       make([]gen.ProxyPoolMemberStats, 0, len(stats.Members)),
+	}
+	for _, m := range stats.Members {
+		member := gen.ProxyPoolMemberStats{
+			ProxyId:             openapi_types.UUID(m.ProxyID),
+			Address:             m.Address,
+			Weight:              m.Weight,
+			IsActive:            m.Active,
+			Eligible:            m.Eligible,
+			Selections:          m.Selections,
+			Successes:           m.Successes,
+			Failures:            m.Failures,
+			ConsecutiveFailures: m.ConsecutiveFailures,
+			SuccessRate:         m.SuccessRate,
+			LatencyMs:           m.LatencyMs,
+		}
+		if !m.LastUsed.IsZero() {
+			lastUsed := m.LastUsed
+			member.LastUsedAt = &lastUsed
+		}
+		out.Members = append(out.Members, member)
+	}
+	return gen.ProxyPoolsStats200JSONResponse(out), nil
+}
+
+// applyProxyPoolRequest copies strategy, health check and threshold settings onto pool, returning a
+// validation message when one is out of range.
+func applyProxyPoolRequest(pool *models.ProxyPool, body *gen.ProxyPoolRequest) string {
+	if body.PoolStrategy != nil {
+		strategy := string(*body.PoolStrategy)
+		if !proxymanager.IsValidPoolStrategy(strategy) {
+			return "unsupported pool strategy: " + strategy
+		}
+		pool.PoolStrategy = sql.NullString{String: strategy, Valid: true}
+	}
+	if body.HealthCheckEnabled != nil {
+		pool.HealthCheckEnabled = *body.HealthCheckEnabled
+	}
+	if body.HealthCheckIntervalSeconds != nil {
+		pool.HealthCheckIntervalSeconds = body.HealthCheckIntervalSeconds
+	}
+	if body.MaxRetries != nil {
+		pool.MaxRetries = body.MaxRetries
+	}
+	if body.TimeoutSeconds != nil {
+		pool.TimeoutSeconds = body.TimeoutSeconds
+	}
+	if body.MaxConsecutiveFailures != nil {
+		if *body.MaxConsecutiveFailures < 0 {
+			return "maxConsecutiveFailures must be >= 0"
+		}
+		pool.MaxConsecutiveFailures = *body.MaxConsecutiveFailures
+	}
+	if body.MinSuccessRate != nil {
+		if *body.MinSuccessRate < 0 || *body.MinSuccessRate > 1 {
+			return "minSuccessRate must be between 0 and 1"
+		}
+		pool.MinSuccessRate = *body.MinSuccessRate
+	}
+	return ""
+}
+
+// invalidateProxyPool makes running campaigns pick up pool edits on their next selection.
+func (h *strictHandlers) invalidateProxyPool(poolID uuid.UUID) {
+	if h.deps.ProxyMgr != nil {
+		h.deps.ProxyMgr.InvalidatePool(poolID)
+	}
+}
+
+func mapProxyPool(p *models.ProxyPool) gen.ProxyPool {
+	id := openapi_types.UUID(p.ID)
+	name := p.Name
+	isEnabled := p.IsEnabled
+	healthCheckEnabled := p.HealthCheckEnabled
+	maxConsecutiveFailures := p.MaxConsecutiveFailures
+	minSuccessRate := p.MinSuccessRate
+	created := p.CreatedAt
+	updated := p.UpdatedAt
+	out := gen.ProxyPool{
+		Id:                         &id,
+		Name:                       &name,
+		IsEnabled:                  &isEnabled,
+		HealthCheckEnabled:         &healthCheckEnabled,
+		HealthCheckIntervalSeconds: p.HealthCheckIntervalSeconds,
+		MaxRetries:                 p.MaxRetries,
+		TimeoutSeconds:             p.TimeoutSeconds,
+		MaxConsecutiveFailures:     &maxConsecutiveFailures,
+		MinSuccessRate:             &minSuccessRate,
+		CreatedAt:                  &created,
+		UpdatedAt:                  &updated,
+	}
+	if p.Description.Valid {
+		desc := p.Description.String
+		out.Description = &desc
+	}
+	strategy := gen.ProxyPoolStrategy(proxymanager.StrategyRoundRobin)
+	if p.PoolStrategy.Valid && proxymanager.IsValidPoolStrategy(p.PoolStrategy.String) {
+		strategy = gen.ProxyPoolStrategy(p.PoolStrategy.String)
+	}
+	out.PoolStrategy = &strategy
+	return out
+}
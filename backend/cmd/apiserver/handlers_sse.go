@@ -17,6 +17,7 @@ type sseLiveResponse struct {
 	userID        uuid.UUID
 	campaignID    *uuid.UUID
 	allowedOrigin string
+	lastEventID   string
 }
 
 func (resp sseLiveResponse) VisitSseEventsCampaignResponse(w http.ResponseWriter) error {
@@ -32,7 +33,7 @@ func (resp sseLiveResponse) stream(w http.ResponseWriter) error {
 	if resp.sse == nil {
 		return fmt.Errorf("sse service not initialized")
 	}
-	client, err := resp.sse.RegisterClient(resp.ctx, w, resp.userID, resp.campaignID, resp.allowedOrigin)
+	client, err := resp.sse.RegisterClient(resp.ctx, w, resp.userID, resp.campaignID, resp.allowedOrigin, resp.lastEventID)
 	if err != nil {
 		return err
 	}
@@ -73,12 +74,19 @@ func (h *strictHandlers) SseEventsCampaign(ctx context.Context, r gen.SseEventsC
 	userID := extractUserID(ctx)
 	campID := uuid.UUID(r.CampaignId)
 	origin := allowedOriginFromContext(ctx)
+	lastEventID := ""
+	if r.Params.LastEventID != nil {
+		lastEventID = *r.Params.LastEventID
+	} else if r.Params.LastEventId != nil {
+		lastEventID = *r.Params.LastEventId
+	}
 	return sseLiveResponse{
 		sse:           h.deps.SSE,
 		ctx:           ctx,
 		userID:        userID,
 		campaignID:    &campID,
 		allowedOrigin: origin,
+		lastEventID:   lastEventID,
 	}, nil
 }
 
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/fntelecomllc/studio/backend/internal/webhooks"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// minWebhookSecretLength guards caller-supplied secrets; generated secrets are much longer.
+const minWebhookSecretLength = 16
+
+// sessionUserID returns the authenticated user from the session middleware (no fallback identity).
+func sessionUserID(ctx context.Context) (uuid.UUID, bool) {
+	switch v := ctx.Value("user_id").(type) {
+	case uuid.UUID:
+		return v, v != uuid.Nil
+	case string:
+		if parsed, err := uuid.Parse(v); err == nil && parsed != uuid.Nil {
+			return parsed, true
+		}
+	}
+	return uuid.Nil, false
+}
+
+func (h *strictHandlers) WebhooksList(ctx context.Context, r gen.WebhooksListRequestObject) (gen.WebhooksListResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Webhooks == nil || h.deps.DB == nil {
+		return gen.WebhooksList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "webhook store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.WebhooksList401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	subs, err := h.deps.Stores.Webhooks.ListWebhookSubscriptions(ctx, h.deps.DB, userID)
+	if err != nil {
+		return gen.WebhooksList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to list webhooks", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	out := make([]gen.WebhookSubscription, 0, len(subs))
+	for _, s := range subs {
+		out = append(out, toAPIWebhookSubscription(s, false))
+	}
+	return gen.WebhooksList200JSONResponse(out), nil
+}
+
+func (h *strictHandlers) WebhooksCreate(ctx context.Context, r gen.WebhooksCreateRequestObject) (gen.WebhooksCreateResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Webhooks == nil || h.deps.DB == nil {
+		return gen.WebhooksCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "webhook store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.WebhooksCreate401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil || strings.TrimSpace(r.Body.Name) == "" {
+		return gen.WebhooksCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "name is required", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	now := time.Now().UTC()
+	sub := &models.WebhookSubscription{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      strings.TrimSpace(r.Body.Name),
+		URL:       strings.TrimSpace(r.Body.Url),
+		IsActive:  r.Body.IsActive == nil || *r.Body.IsActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if msg := h.applyWebhookFilters(ctx, sub, r.Body.EventTypes, r.Body.CampaignId); msg != "" {
+		return gen.WebhooksCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: msg, Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if err := webhooks.ValidateURL(sub.URL); err != nil {
+		return gen.WebhooksCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: err.Error(), Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body.Secret != nil && *r.Body.Secret != "" {
+		if len(*r.Body.Secret) < minWebhookSecretLength {
+			return gen.WebhooksCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "secret must be at least 16 characters", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		sub.Secret = *r.Body.Secret
+	} else {
+		secret, err := webhooks.GenerateSecret()
+		if err != nil {
+			return gen.WebhooksCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to generate secret", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		sub.Secret = secret
+	}
+	if err := h.deps.Stores.Webhooks.CreateWebhookSubscription(ctx, h.deps.DB, sub); err != nil {
+		return gen.WebhooksCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to create webhook", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.WebhooksCreate201JSONResponse(toAPIWebhookSubscription(sub, true)), nil
+}
+
+func (h *strictHandlers) WebhooksGet(ctx context.Context, r gen.WebhooksGetRequestObject) (gen.WebhooksGetResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Webhooks == nil || h.deps.DB == nil {
+		return gen.WebhooksGet500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "webhook store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.WebhooksGet401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	sub, err := h.deps.Stores.Webhooks.GetWebhookSubscription(ctx, h.deps.DB, userID, uuid.UUID(r.WebhookId))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.WebhooksGet404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "webhook not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WebhooksGet500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch webhook", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.WebhooksGet200JSONResponse(toAPIWebhookSubscription(sub, false)), nil
+}
+
+func (h *strictHandlers) WebhooksUpdate(ctx context.Context, r gen.WebhooksUpdateRequestObject) (gen.WebhooksUpdateResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Webhooks == nil || h.deps.DB == nil {
+		return gen.WebhooksUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "webhook store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.WebhooksUpdate401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil {
+		return gen.WebhooksUpdate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "missing body", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	sub, err := h.deps.Stores.Webhooks.GetWebhookSubscription(ctx, h.deps.DB, userID, uuid.UUID(r.WebhookId))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.WebhooksUpdate404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "webhook not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WebhooksUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch webhook", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body.Name != nil && strings.TrimSpace(*r.Body.Name) != "" {
+		sub.Name = strings.TrimSpace(*r.Body.Name)
+	}
+	if r.Body.Url != nil {
+		sub.URL = strings.TrimSpace(*r.Body.Url)
+		if err := webhooks.ValidateURL(sub.URL); err != nil {
+			return gen.WebhooksUpdate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: err.Error(), Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+	}
+	if r.Body.ClearCampaign != nil && *r.Body.ClearCampaign {
+		sub.CampaignID = uuid.NullUUID{}
+	}
+	if msg := h.applyWebhookFilters(ctx, sub, r.Body.EventTypes, r.Body.CampaignId); msg != "" {
+		return gen.WebhooksUpdate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: msg, Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body.IsActive != nil {
+		sub.IsActive = *r.Body.IsActive
+	}
+	rotated := r.Body.RotateSecret != nil && *r.Body.RotateSecret
+	if rotated {
+		secret, err := webhooks.GenerateSecret()
+		if err != nil {
+			return gen.WebhooksUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to generate secret", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		sub.Secret = secret
+	}
+	if err := h.deps.Stores.Webhooks.UpdateWebhookSubscription(ctx, h.deps.DB, sub); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.WebhooksUpdate404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "webhook not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WebhooksUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to update webhook", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.WebhooksUpdate200JSONResponse(toAPIWebhookSubscription(sub, rotated)), nil
+}
+
+func (h *strictHandlers) WebhooksDelete(ctx context.Context, r gen.WebhooksDeleteRequestObject) (gen.WebhooksDeleteResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Webhooks == nil || h.deps.DB == nil {
+		return gen.WebhooksDelete500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "webhook store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.WebhooksDelete401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if err := h.deps.Stores.Webhooks.DeleteWebhookSubscription(ctx, h.deps.DB, userID, uuid.UUID(r.WebhookId)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.WebhooksDelete404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "webhook not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WebhooksDelete500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to delete webhook", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.WebhooksDelete204Response{}, nil
+}
+
+func (h *strictHandlers) WebhooksDeliveriesList(ctx context.Context, r gen.WebhooksDeliveriesListRequestObject) (gen.WebhooksDeliveriesListResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Webhooks == nil || h.deps.DB == nil {
+		return gen.WebhooksDeliveriesList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "webhook store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.WebhooksDeliveriesList401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if _, err := h.deps.Stores.Webhooks.GetWebhookSubscription(ctx, h.deps.DB, userID, uuid.UUID(r.WebhookId)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.WebhooksDeliveriesList404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "webhook not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WebhooksDeliveriesList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch webhook", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	limit, offset := 50, 0
+	if r.Params.Limit != nil {
+		limit = int(*r.Params.Limit)
+	}
+	if r.Params.Offset != nil {
+		offset = int(*r.Params.Offset)
+	}
+	deliveries, err := h.deps.Stores.Webhooks.ListWebhookDeliveries(ctx, h.deps.DB, uuid.UUID(r.WebhookId), limit, offset)
+	if err != nil {
+		return gen.WebhooksDeliveriesList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to list deliveries", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	out := make([]gen.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		out = append(out, toAPIWebhookDelivery(d))
+	}
+	return gen.WebhooksDeliveriesList200JSONResponse(out), nil
+}
+
+func (h *strictHandlers) WebhooksDeadLettersList(ctx context.Context, r gen.WebhooksDeadLettersListRequestObject) (gen.WebhooksDeadLettersListResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Webhooks == nil || h.deps.DB == nil {
+		return gen.WebhooksDeadLettersList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "webhook store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.WebhooksDeadLettersList401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if _, err := h.deps.Stores.Webhooks.GetWebhookSubscription(ctx, h.deps.DB, userID, uuid.UUID(r.WebhookId)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.WebhooksDeadLettersList404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "webhook not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WebhooksDeadLettersList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch webhook", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	limit, offset := 50, 0
+	if r.Params.Limit != nil {
+		limit = int(*r.Params.Limit)
+	}
+	if r.Params.Offset != nil {
+		offset = int(*r.Params.Offset)
+	}
+	letters, err := h.deps.Stores.Webhooks.ListWebhookDeadLetters(ctx, h.deps.DB, uuid.UUID(r.WebhookId), limit, offset)
+	if err != nil {
+		return gen.WebhooksDeadLettersList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to list dead letters", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	out := make([]gen.WebhookDeadLetter, 0, len(letters))
+	for _, l := range letters {
+		item := gen.WebhookDeadLetter{
+			Id:         openapi_types.UUID(l.ID),
+			DeliveryId: openapi_types.UUID(l.DeliveryID),
+			EventType:  l.EventType,
+			Attempts:   l.Attempts,
+			Payload:    webhookPayloadMap(l.Payload),
+			CreatedAt:  l.CreatedAt,
+		}
+		if l.LastStatusCode.Valid {
+			code := int(l.LastStatusCode.Int32)
+			item.LastStatusCode = &code
+		}
+		if l.LastError.Valid {
+			item.LastError = &l.LastError.String
+		}
+		out = append(out, item)
+	}
+	return gen.WebhooksDeadLettersList200JSONResponse(out), nil
+}
+
+func (h *strictHandlers) WebhooksDeadLetterRedeliver(ctx context.Context, r gen.WebhooksDeadLetterRedeliverRequestObject) (gen.WebhooksDeadLetterRedeliverResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.Webhooks == nil || h.deps.DB == nil {
+		return gen.WebhooksDeadLetterRedeliver500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "webhook store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return gen.WebhooksDeadLetterRedeliver401JSONResponse{UnauthorizedJSONResponse: gen.UnauthorizedJSONResponse{Error: gen.ApiError{Message: "authentication required", Code: gen.UNAUTHORIZED, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if _, err := h.deps.Stores.Webhooks.GetWebhookSubscription(ctx, h.deps.DB, userID, uuid.UUID(r.WebhookId)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.WebhooksDeadLetterRedeliver404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "webhook not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WebhooksDeadLetterRedeliver500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch webhook", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	delivery, err := h.deps.Stores.Webhooks.RequeueWebhookDeadLetter(ctx, h.deps.DB, uuid.UUID(r.WebhookId), uuid.UUID(r.DeadLetterId))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.WebhooksDeadLetterRedeliver404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "dead letter not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WebhooksDeadLetterRedeliver500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to requeue delivery", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.WebhooksDeadLetterRedeliver202JSONResponse(toAPIWebhookDelivery(delivery)), nil
+}
+
+// applyWebhookFilters validates and applies the event and campaign filters; it returns a client error message.
+func (h *strictHandlers) applyWebhookFilters(ctx context.Context, sub *models.WebhookSubscription, eventTypes *[]gen.WebhookEventType, campaignID *openapi_types.UUID) string {
+	if eventTypes != nil {
+		raw := make([]string, 0, len(*eventTypes))
+		for _, t := range *eventTypes {
+			raw = append(raw, string(t))
+		}
+		normalized, err := webhooks.NormalizeEventTypes(raw)
+		if err != nil {
+			return err.Error()
+		}
+		sub.EventTypes = pq.StringArray(normalized)
+	}
+	if campaignID != nil {
+		id := uuid.UUID(*campaignID)
+		if h.deps.Stores.Campaign == nil {
+			return "campaign store not initialized"
+		}
+		campaign, err := h.deps.Stores.Campaign.GetCampaignByID(ctx, h.deps.DB, id)
+		if err != nil || campaign.UserID == nil || *campaign.UserID != sub.UserID {
+			return "campaign not found"
+		}
+		sub.CampaignID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+	return ""
+}
+
+func toAPIWebhookSubscription(s *models.WebhookSubscription, includeSecret bool) gen.WebhookSubscription {
+	out := gen.WebhookSubscription{
+		Id:         openapi_types.UUID(s.ID),
+		Name:       s.Name,
+		Url:        s.URL,
+		EventTypes: make([]gen.WebhookEventType, 0, len(s.EventTypes)),
+		IsActive:   s.IsActive,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+	for _, t := range s.EventTypes {
+		out.EventTypes = append(out.EventTypes, gen.WebhookEventType(t))
+	}
+	if s.CampaignID.Valid {
+		id := openapi_types.UUID(s.CampaignID.UUID)
+		out.CampaignId = &id
+	}
+	if includeSecret {
+		secret := s.Secret
+		out.Secret = &secret
+	}
+	return out
+}
+
+func toAPIWebhookDelivery(d *models.WebhookDelivery) gen.WebhookDelivery {
+	out := gen.WebhookDelivery{
+		Id:            openapi_types.UUID(d.ID),
+		EventId:       openapi_types.UUID(d.EventID),
+		EventType:     d.EventType,
+		Status:        gen.WebhookDeliveryStatus(d.Status),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		Payload:       webhookPayloadMap(d.Payload),
+		CreatedAt:     d.CreatedAt,
+	}
+	if d.LastStatusCode.Valid {
+		code := int(d.LastStatusCode.Int32)
+		out.LastStatusCode = &code
+	}
+	if d.LastError.Valid {
+		out.LastError = &d.LastError.String
+	}
+	if d.DeliveredAt.Valid {
+		out.DeliveredAt = &d.DeliveredAt.Time
+	}
+	return out
+}
+
+func webhookPayloadMap(raw json.RawMessage) map[string]interface{} {
+	out := map[string]interface{}{}
+	_ = json.Unmarshal(raw, &out)
+	return out
+}
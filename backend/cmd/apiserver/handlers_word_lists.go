@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	domainservices "github.com/fntelecomllc/studio/backend/internal/domain/services"
+	"github.com/fntelecomllc/studio/backend/internal/domainexpert"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// maxWordListSize caps a single upload; the generator holds every bound list in memory.
+const maxWordListSize = 200000
+
+func (h *strictHandlers) WordListsList(ctx context.Context, r gen.WordListsListRequestObject) (gen.WordListsListResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.WordLists == nil || h.deps.DB == nil {
+		return gen.WordListsList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "word list store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	limit, offset := 50, 0
+	if r.Params.Limit != nil {
+		limit = int(*r.Params.Limit)
+	}
+	if r.Params.Offset != nil {
+		offset = int(*r.Params.Offset)
+	}
+	lists, err := h.deps.Stores.WordLists.ListWordLists(ctx, h.deps.DB, limit, offset)
+	if err != nil {
+		return gen.WordListsList500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to list word lists", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	out := make([]gen.WordList, 0, len(lists))
+	for _, l := range lists {
+		out = append(out, toAPIWordList(l, false, nil))
+	}
+	return gen.WordListsList200JSONResponse(out), nil
+}
+
+func (h *strictHandlers) WordListsCreate(ctx context.Context, r gen.WordListsCreateRequestObject) (gen.WordListsCreateResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.WordLists == nil || h.deps.DB == nil {
+		return gen.WordListsCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "word list store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil || r.Body.Name == nil || strings.TrimSpace(*r.Body.Name) == "" {
+		return gen.WordListsCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "name is required", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	words, rejected, msg := wordListRequestWords(r.Body)
+	if msg != "" {
+		return gen.WordListsCreate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: msg, Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	now := time.Now().UTC()
+	list := &models.DomainWordList{
+		ID:        uuid.New(),
+		Name:      strings.TrimSpace(*r.Body.Name),
+		Words:     words,
+		WordCount: len(words),
+		Checksum:  domainexpert.WordListChecksum(words),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if r.Body.Description != nil {
+		list.Description = sql.NullString{String: *r.Body.Description, Valid: *r.Body.Description != ""}
+	}
+	if v, ok := ctx.Value("user_id").(string); ok {
+		if uid, err := uuid.Parse(v); err == nil {
+			list.CreatedBy = uuid.NullUUID{UUID: uid, Valid: true}
+		}
+	}
+	if err := h.deps.Stores.WordLists.CreateWordList(ctx, h.deps.DB, list); err != nil {
+		if errors.Is(err, store.ErrDuplicateEntry) {
+			return gen.WordListsCreate409JSONResponse{ConflictJSONResponse: gen.ConflictJSONResponse{Error: gen.ApiError{Message: "word list name already exists", Code: gen.CONFLICT, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WordListsCreate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to create word list", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.WordListsCreate201JSONResponse(toAPIWordList(list, false, &rejected)), nil
+}
+
+func (h *strictHandlers) WordListsGet(ctx context.Context, r gen.WordListsGetRequestObject) (gen.WordListsGetResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.WordLists == nil || h.deps.DB == nil {
+		return gen.WordListsGet500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "word list store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	list, err := h.deps.Stores.WordLists.GetWordListByID(ctx, h.deps.DB, uuid.UUID(r.WordListId))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.WordListsGet404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "word list not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WordListsGet500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch word list", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.WordListsGet200JSONResponse(toAPIWordList(list, true, nil)), nil
+}
+
+func (h *strictHandlers) WordListsUpdate(ctx context.Context, r gen.WordListsUpdateRequestObject) (gen.WordListsUpdateResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.WordLists == nil || h.deps.DB == nil {
+		return gen.WordListsUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "word list store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body == nil {
+		return gen.WordListsUpdate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "missing body", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	list, err := h.deps.Stores.WordLists.GetWordListByID(ctx, h.deps.DB, uuid.UUID(r.WordListId))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.WordListsUpdate404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "word list not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WordListsUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch word list", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if allowed, err := h.mayModifyWordList(ctx, list); err != nil {
+		return gen.WordListsUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to check word list ownership", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	} else if !allowed {
+		return gen.WordListsUpdate403JSONResponse{ForbiddenJSONResponse: gen.ForbiddenJSONResponse{Error: gen.ApiError{Message: "only the creator or an admin can change this word list", Code: gen.FORBIDDEN, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if r.Body.Name != nil && strings.TrimSpace(*r.Body.Name) != "" {
+		list.Name = strings.TrimSpace(*r.Body.Name)
+	}
+	if r.Body.Description != nil {
+		list.Description = sql.NullString{String: *r.Body.Description, Valid: *r.Body.Description != ""}
+	}
+	var rejectedPtr *int
+	if r.Body.Words != nil || r.Body.Content != nil {
+		words, rejected, msg := wordListRequestWords(r.Body)
+		if msg != "" {
+			return gen.WordListsUpdate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: msg, Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		checksum := domainexpert.WordListChecksum(words)
+		if checksum != list.Checksum {
+			// Configured campaigns hash the list checksum; changing the words under them would move their offset space.
+			inUse, err := h.deps.Stores.WordLists.WordListInUse(ctx, h.deps.DB, list.ID)
+			if err != nil {
+				return gen.WordListsUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to check word list usage", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+			}
+			if inUse {
+				return gen.WordListsUpdate409JSONResponse{ConflictJSONResponse: gen.ConflictJSONResponse{Error: gen.ApiError{Message: "word list is used by a campaign configuration; create a new list instead of changing its words", Code: gen.CONFLICT, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+			}
+		}
+		list.Words = words
+		list.WordCount = len(words)
+		list.Checksum = checksum
+		rejectedPtr = &rejected
+	}
+	if err := h.deps.Stores.WordLists.UpdateWordList(ctx, h.deps.DB, list); err != nil {
+		if errors.Is(err, store.ErrDuplicateEntry) {
+			return gen.WordListsUpdate400JSONResponse{BadRequestJSONResponse: gen.BadRequestJSONResponse{Error: gen.ApiError{Message: "word list name already exists", Code: gen.BADREQUEST, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WordListsUpdate500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to update word list", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.WordListsUpdate200JSONResponse(toAPIWordList(list, false, rejectedPtr)), nil
+}
+
+func (h *strictHandlers) WordListsDelete(ctx context.Context, r gen.WordListsDeleteRequestObject) (gen.WordListsDeleteResponseObject, error) {
+	if h.deps == nil || h.deps.Stores.WordLists == nil || h.deps.DB == nil {
+		return gen.WordListsDelete500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "word list store not initialized", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	list, err := h.deps.Stores.WordLists.GetWordListByID(ctx, h.deps.DB, uuid.UUID(r.WordListId))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.WordListsDelete404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "word list not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WordListsDelete500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to fetch word list", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if allowed, err := h.mayModifyWordList(ctx, list); err != nil {
+		return gen.WordListsDelete500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to check word list ownership", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	} else if !allowed {
+		return gen.WordListsDelete403JSONResponse{ForbiddenJSONResponse: gen.ForbiddenJSONResponse{Error: gen.ApiError{Message: "only the creator or an admin can delete this word list", Code: gen.FORBIDDEN, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	inUse, err := h.deps.Stores.WordLists.WordListInUse(ctx, h.deps.DB, list.ID)
+	if err != nil {
+		return gen.WordListsDelete500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to check word list usage", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if inUse {
+		return gen.WordListsDelete409JSONResponse{ConflictJSONResponse: gen.ConflictJSONResponse{Error: gen.ApiError{Message: "word list is used by a campaign configuration", Code: gen.CONFLICT, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	if err := h.deps.Stores.WordLists.DeleteWordList(ctx, h.deps.DB, list.ID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return gen.WordListsDelete404JSONResponse{NotFoundJSONResponse: gen.NotFoundJSONResponse{Error: gen.ApiError{Message: "word list not found", Code: gen.NOTFOUND, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+		}
+		return gen.WordListsDelete500JSONResponse{InternalServerErrorJSONResponse: gen.InternalServerErrorJSONResponse{Error: gen.ApiError{Message: "failed to delete word list", Code: gen.INTERNALSERVERERROR, Timestamp: time.Now()}, RequestId: reqID(), Success: boolPtr(false)}}, nil
+	}
+	return gen.WordListsDelete204Response{}, nil
+}
+
+// mayModifyWordList reports whether the session user created the list or holds the admin role.
+// Lists without a recorded creator can only be changed by admins.
+func (h *strictHandlers) mayModifyWordList(ctx context.Context, list *models.DomainWordList) (bool, error) {
+	uid, ok := sessionUserID(ctx)
+	if !ok {
+		return false, nil
+	}
+	if list.CreatedBy.Valid && list.CreatedBy.UUID == uid {
+		return true, nil
+	}
+	if h.deps.Stores.Authorization == nil {
+		return false, errors.New("authorization store not initialized")
+	}
+	role, err := h.deps.Stores.Authorization.GetUserRole(ctx, h.deps.DB, uid)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return role == models.RoleAdmin, nil
+}
+
+// wordListRequestWords merges the words array and newline separated content, then normalizes them.
+// A non-empty message is returned when the result is unusable.
+func wordListRequestWords(body *gen.WordListRequest) ([]string, int, string) {
+	var raw []string
+	if body.Words != nil {
+		raw = append(raw, *body.Words...)
+	}
+	if body.Content != nil {
+		raw = append(raw, strings.Split(strings.ReplaceAll(*body.Content, "\r\n", "\n"), "\n")...)
+	}
+	words, rejected := domainexpert.NormalizeWords(raw)
+	if len(words) == 0 {
+		return nil, rejected, "word list must contain at least one valid word"
+	}
+	if len(words) > maxWordListSize {
+		return nil, rejected, "word list exceeds the maximum of 200000 words"
+	}
+	return words, rejected, ""
+}
+
+func toAPIWordList(l *models.DomainWordList, includeWords bool, rejected *int) gen.WordList {
+	out := gen.WordList{
+		Id:            openapi_types.UUID(l.ID),
+		Name:          l.Name,
+		WordCount:     l.WordCount,
+		Checksum:      l.Checksum,
+		RejectedCount: rejected,
+		CreatedAt:     l.CreatedAt,
+		UpdatedAt:     l.UpdatedAt,
+	}
+	if l.Description.Valid {
+		desc := l.Description.String
+		out.Description = &desc
+	}
+	if includeWords {
+		words := []string(l.Words)
+		out.Words = &words
+	}
+	return out
+}
+
+// resolveWordPattern builds the generator and config hash for a word_list / word_template request,
+// mirroring what the domain generation service computes on Configure so offsets line up.
+func (h *strictHandlers) resolveWordPattern(ctx context.Context, patternType string, template *string, slots *[]gen.DomainWordListSlot, charSet, constant, tld string, tlds *[]string) (*domainexpert.DomainGenerator, *domainexpert.GenerateDomainGenerationPhaseConfigHashResult, error) {
+	if tld != "" && !strings.HasPrefix(tld, ".") {
+		tld = "." + tld
+	}
+	cfg := domainservices.DomainGenerationConfig{
+		PatternType:    patternType,
+		CharacterSet:   charSet,
+		ConstantString: constant,
+		TLD:            tld,
+	}
+	if tlds != nil {
+		cfg.TLDs = *tlds
+	}
+	if template != nil {
+		cfg.Template = *template
+	}
+	if slots != nil {
+		for _, s := range *slots {
+			slot := ""
+			if s.Slot != nil {
+				slot = *s.Slot
+			}
+			cfg.WordLists = append(cfg.WordLists, domainservices.DomainWordListSlot{Slot: slot, WordListID: uuid.UUID(s.WordListId)})
+		}
+	}
+	if err := cfg.Normalize(); err != nil {
+		return nil, nil, err
+	}
+	words, refs, err := domainservices.ResolveDomainWordLists(ctx, h.deps.Stores.WordLists, h.deps.DB, cfg.WordLists)
+	if err != nil {
+		return nil, nil, err
+	}
+	generator, err := domainservices.NewDomainGeneratorFromConfig(cfg, words)
+	if err != nil {
+		return nil, nil, err
+	}
+	hashRes, err := domainexpert.GenerateDomainGenerationPhaseConfigHash(cfg.HashParams(refs))
+	if err != nil {
+		return nil, nil, err
+	}
+	return generator, hashRes, nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	gen "github.com/fntelecomllc/studio/backend/internal/api/gen"
+	"github.com/fntelecomllc/studio/backend/internal/domainexpert"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+type fakeWordListStore struct {
+	store.WordListStore
+	lists   map[uuid.UUID]*models.DomainWordList
+	inUse   map[uuid.UUID]bool
+	deleted []uuid.UUID
+	updated []uuid.UUID
+}
+
+func (f *fakeWordListStore) GetWordListByID(_ context.Context, _ store.Querier, id uuid.UUID) (*models.DomainWordList, error) {
+	if l, ok := f.lists[id]; ok {
+		cp := *l
+		return &cp, nil
+	}
+	return nil, store.ErrNotFound
+}
+
+func (f *fakeWordListStore) UpdateWordList(_ context.Context, _ store.Querier, list *models.DomainWordList) error {
+	f.updated = append(f.updated, list.ID)
+	return nil
+}
+
+func (f *fakeWordListStore) DeleteWordList(_ context.Context, _ store.Querier, id uuid.UUID) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func (f *fakeWordListStore) WordListInUse(_ context.Context, _ store.Querier, id uuid.UUID) (bool, error) {
+	return f.inUse[id], nil
+}
+
+func newWordListTestHandlers(t *testing.T) (*strictHandlers, *fakeWordListStore, uuid.UUID, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	creator, other, admin := uuid.New(), uuid.New(), uuid.New()
+	words := []string{"alpha", "beta"}
+	listID := uuid.New()
+	lists := &fakeWordListStore{
+		lists: map[uuid.UUID]*models.DomainWordList{listID: {
+			ID:        listID,
+			Name:      "nouns",
+			Words:     words,
+			WordCount: len(words),
+			Checksum:  domainexpert.WordListChecksum(words),
+			CreatedBy: uuid.NullUUID{UUID: creator, Valid: true},
+		}},
+		inUse: map[uuid.UUID]bool{},
+	}
+	deps := &AppDeps{DB: createTestDB(t)}
+	deps.Stores.WordLists = lists
+	deps.Stores.Authorization = &fakeAuthorizationStore{roles: map[uuid.UUID]string{
+		creator: models.RoleOperator,
+		other:   models.RoleOperator,
+		admin:   models.RoleAdmin,
+	}}
+	return &strictHandlers{deps: deps}, lists, listID, other, admin
+}
+
+func TestWordListsDelete_RequiresCreatorOrAdmin(t *testing.T) {
+	h, lists, listID, other, admin := newWordListTestHandlers(t)
+	creator := lists.lists[listID].CreatedBy.UUID
+	req := gen.WordListsDeleteRequestObject{WordListId: openapi_types.UUID(listID)}
+
+	resp, err := h.WordListsDelete(context.WithValue(context.Background(), "user_id", other.String()), req)
+	if err != nil {
+		t.Fatalf("WordListsDelete: %v", err)
+	}
+	if _, ok := resp.(gen.WordListsDelete403JSONResponse); !ok {
+		t.Fatalf("expected 403 for another operator, got %T", resp)
+	}
+
+	for _, uid := range []uuid.UUID{creator, admin} {
+		resp, err = h.WordListsDelete(context.WithValue(context.Background(), "user_id", uid.String()), req)
+		if err != nil {
+			t.Fatalf("WordListsDelete: %v", err)
+		}
+		if _, ok := resp.(gen.WordListsDelete204Response); !ok {
+			t.Fatalf("expected 204 for %s, got %T", uid, resp)
+		}
+	}
+	if len(lists.deleted) != 2 {
+		t.Fatalf("expected two deletes, got %d", len(lists.deleted))
+	}
+}
+
+func TestWordListsDelete_ConflictWhileReferenced(t *testing.T) {
+	h, lists, listID, _, admin := newWordListTestHandlers(t)
+	lists.inUse[listID] = true
+
+	resp, err := h.WordListsDelete(context.WithValue(context.Background(), "user_id", admin.String()), gen.WordListsDeleteRequestObject{WordListId: openapi_types.UUID(listID)})
+	if err != nil {
+		t.Fatalf("WordListsDelete: %v", err)
+	}
+	if _, ok := resp.(gen.WordListsDelete409JSONResponse); !ok {
+		t.Fatalf("expected 409 for a referenced list, got %T", resp)
+	}
+	if len(lists.deleted) != 0 {
+		t.Fatalf("referenced list must not be deleted")
+	}
+}
+
+func TestWordListsUpdate_OwnershipAndReferencedWords(t *testing.T) {
+	h, lists, listID, other, admin := newWordListTestHandlers(t)
+	lists.inUse[listID] = true
+	newName := "renamed"
+	newWords := []string{"gamma"}
+	sameWords := []string{"alpha", "beta"}
+	ctxOther := context.WithValue(context.Background(), "user_id", other.String())
+	ctxAdmin := context.WithValue(context.Background(), "user_id", admin.String())
+
+	resp, err := h.WordListsUpdate(ctxOther, gen.WordListsUpdateRequestObject{WordListId: openapi_types.UUID(listID), Body: &gen.WordListRequest{Name: &newName}})
+	if err != nil {
+		t.Fatalf("WordListsUpdate: %v", err)
+	}
+	if _, ok := resp.(gen.WordListsUpdate403JSONResponse); !ok {
+		t.Fatalf("expected 403 for another operator, got %T", resp)
+	}
+
+	resp, err = h.WordListsUpdate(ctxAdmin, gen.WordListsUpdateRequestObject{WordListId: openapi_types.UUID(listID), Body: &gen.WordListRequest{Words: &newWords}})
+	if err != nil {
+		t.Fatalf("WordListsUpdate: %v", err)
+	}
+	if _, ok := resp.(gen.WordListsUpdate409JSONResponse); !ok {
+		t.Fatalf("expected 409 when changing the words of a referenced list, got %T", resp)
+	}
+
+	// Renaming, or resubmitting the same words, leaves the checksum alone and is allowed.
+	resp, err = h.WordListsUpdate(ctxAdmin, gen.WordListsUpdateRequestObject{WordListId: openapi_types.UUID(listID), Body: &gen.WordListRequest{Name: &newName, Words: &sameWords}})
+	if err != nil {
+		t.Fatalf("WordListsUpdate: %v", err)
+	}
+	if _, ok := resp.(gen.WordListsUpdate200JSONResponse); !ok {
+		t.Fatalf("expected 200 for a rename, got %T", resp)
+	}
+	if len(lists.updated) != 1 {
+		t.Fatalf("expected one update, got %d", len(lists.updated))
+	}
+}
@@ -0,0 +1,4 @@
+-- Migration: 000073_domain_word_lists.down.sql
+-- Purpose: Rollback domain_word_lists table
+
+DROP TABLE IF EXISTS public.domain_word_lists;
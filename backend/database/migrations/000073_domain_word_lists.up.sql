@@ -0,0 +1,26 @@
+-- Migration: 000073_domain_word_lists.up.sql
+-- Purpose: Store named word lists used by word_list / word_template domain generation patterns
+--
+-- Words are stored normalized (lowercase, trimmed, de-duplicated, first occurrence order preserved).
+-- checksum is the sha256 of the normalized words joined by '\n' and is folded into the
+-- domain generation config hash so edits to a list never reuse a stale offset.
+
+CREATE TABLE IF NOT EXISTS public.domain_word_lists (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    description TEXT,
+    words TEXT[] NOT NULL DEFAULT '{}',
+    word_count INTEGER NOT NULL DEFAULT 0,
+    checksum TEXT NOT NULL,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT domain_word_lists_name_key UNIQUE (name),
+    CONSTRAINT domain_word_lists_word_count_check CHECK (word_count >= 0)
+);
+
+COMMENT ON TABLE public.domain_word_lists IS
+'Named word lists referenced by word_list and word_template domain generation patterns.';
+
+COMMENT ON COLUMN public.domain_word_lists.checksum IS
+'sha256 of the normalized words joined by newline; part of the domain generation config hash.';
@@ -0,0 +1,4 @@
+-- Migration: 000074_campaign_domain_imports.down.sql
+-- Purpose: Rollback campaign_domain_import_entries table
+
+DROP TABLE IF EXISTS public.campaign_domain_import_entries;
@@ -0,0 +1,21 @@
+-- Migration: 000074_campaign_domain_imports.up.sql
+-- Purpose: Stage imported domain lists for campaigns seeded from an upload instead of generation
+--
+-- Uploads are normalized (lowercase, punycode) before staging. position preserves upload order
+-- across repeated uploads and becomes generated_domains.offset_index when the domain_generation
+-- phase runs in import mode. Rows are removed once the phase has copied them.
+
+CREATE TABLE IF NOT EXISTS public.campaign_domain_import_entries (
+    campaign_id UUID NOT NULL REFERENCES public.lead_generation_campaigns(id) ON DELETE CASCADE,
+    domain_name TEXT NOT NULL,
+    position BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (campaign_id, domain_name),
+    CONSTRAINT campaign_domain_import_entries_position_check CHECK (position >= 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_campaign_domain_import_entries_position
+    ON public.campaign_domain_import_entries (campaign_id, position);
+
+COMMENT ON TABLE public.campaign_domain_import_entries IS
+'Normalized domains uploaded as a campaign seed, waiting for the domain_generation phase (source=import).';
@@ -0,0 +1,6 @@
+-- Migration: 000075_webhooks.down.sql
+-- Purpose: Rollback webhook subscription, delivery and dead-letter tables
+
+DROP TABLE IF EXISTS public.webhook_dead_letters;
+DROP TABLE IF EXISTS public.webhook_deliveries;
+DROP TABLE IF EXISTS public.webhook_subscriptions;
@@ -0,0 +1,63 @@
+-- Migration: 000075_webhooks.up.sql
+-- Purpose: Outbound webhook subscriptions, delivery queue/log and dead-letter table
+--
+-- webhook_deliveries is both the retry queue and the delivery log: one row per (event, subscription),
+-- re-attempted with exponential backoff until it succeeds or exhausts its attempts. Exhausted
+-- deliveries are marked dead and copied to webhook_dead_letters so they can be inspected and redelivered.
+
+CREATE TABLE IF NOT EXISTS public.webhook_subscriptions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    campaign_id UUID REFERENCES public.lead_generation_campaigns(id) ON DELETE CASCADE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_user ON public.webhook_subscriptions (user_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_active ON public.webhook_subscriptions (user_id) WHERE is_active;
+
+CREATE TABLE IF NOT EXISTS public.webhook_deliveries (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES public.webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_status_code INT,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ,
+    CONSTRAINT webhook_deliveries_status_check CHECK (status IN ('pending', 'delivered', 'dead')),
+    CONSTRAINT webhook_deliveries_event_unique UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON public.webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON public.webhook_deliveries (subscription_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS public.webhook_dead_letters (
+    id UUID PRIMARY KEY,
+    delivery_id UUID NOT NULL UNIQUE REFERENCES public.webhook_deliveries(id) ON DELETE CASCADE,
+    subscription_id UUID NOT NULL REFERENCES public.webhook_subscriptions(id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL,
+    last_status_code INT,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_dead_letters_subscription ON public.webhook_dead_letters (subscription_id, created_at DESC);
+
+COMMENT ON TABLE public.webhook_subscriptions IS
+'Per-user outbound webhook endpoints. Empty event_types subscribes to every lifecycle event; campaign_id narrows to one campaign.';
+COMMENT ON TABLE public.webhook_deliveries IS
+'Webhook delivery queue and log (one row per event and subscription).';
+COMMENT ON TABLE public.webhook_dead_letters IS
+'Webhook deliveries that exhausted their retries.';
@@ -0,0 +1,4 @@
+-- Migration: 000076_sse_event_journal.down.sql
+-- Purpose: Rollback the SSE event journal
+
+DROP TABLE IF EXISTS public.sse_event_journal;
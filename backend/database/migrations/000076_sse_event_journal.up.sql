@@ -0,0 +1,17 @@
+-- Migration: 000076_sse_event_journal.up.sql
+-- Purpose: Persist campaign SSE events so clients reconnecting with Last-Event-ID can replay across restarts
+--
+-- seq is the per-campaign monotonically increasing event id emitted as the SSE "id:" field.
+-- Rows are written asynchronously by the SSE service and pruned after a retention window.
+
+CREATE TABLE IF NOT EXISTS public.sse_event_journal (
+    campaign_id UUID NOT NULL REFERENCES public.lead_generation_campaigns(id) ON DELETE CASCADE,
+    seq BIGINT NOT NULL,
+    event_type TEXT NOT NULL,
+    user_id UUID,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (campaign_id, seq)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sse_event_journal_created_at ON public.sse_event_journal (created_at);
@@ -0,0 +1,4 @@
+-- Migration: 000077_campaign_schedules.down.sql
+-- Purpose: Rollback campaign schedules
+
+DROP TABLE IF EXISTS public.campaign_schedules;
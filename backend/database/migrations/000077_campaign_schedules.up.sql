@@ -0,0 +1,30 @@
+-- Migration: 000077_campaign_schedules.up.sql
+-- Purpose: Cron-style schedules that re-run campaign phases
+--
+-- next_run_at is persisted so runs missed while the server was down fire once on startup.
+-- locked_until is a short lease taken while a run is being triggered so concurrent schedulers
+-- never fire the same run twice.
+
+CREATE TABLE IF NOT EXISTS public.campaign_schedules (
+    id UUID PRIMARY KEY,
+    campaign_id UUID NOT NULL REFERENCES public.lead_generation_campaigns(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES public.users(id) ON DELETE SET NULL,
+    cron_expression TEXT NOT NULL,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    action TEXT NOT NULL,
+    phase TEXT,
+    is_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMPTZ,
+    last_run_at TIMESTAMPTZ,
+    last_run_status TEXT,
+    last_error TEXT,
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT campaign_schedules_action_check CHECK (action IN ('restart', 'phase')),
+    CONSTRAINT campaign_schedules_phase_check CHECK (action <> 'phase' OR phase IS NOT NULL),
+    CONSTRAINT campaign_schedules_status_check CHECK (last_run_status IS NULL OR last_run_status IN ('triggered', 'skipped_overlap', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_schedules_campaign ON public.campaign_schedules (campaign_id);
+CREATE INDEX IF NOT EXISTS idx_campaign_schedules_due ON public.campaign_schedules (next_run_at) WHERE is_enabled;
@@ -0,0 +1,5 @@
+-- Migration: 000078_domain_run_snapshots.down.sql
+-- Purpose: Rollback domain run snapshots
+
+DROP TABLE IF EXISTS public.domain_state_snapshots;
+DROP TABLE IF EXISTS public.campaign_phase_runs;
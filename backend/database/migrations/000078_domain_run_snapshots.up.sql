@@ -0,0 +1,29 @@
+-- Migration: 000078_domain_run_snapshots.up.sql
+-- Purpose: Per-run snapshots of domain outcomes for change detection between campaign runs
+--
+-- Each completed phase run (identified by the orchestrator's run id) records the state of every
+-- campaign domain at completion. Two snapshots can then be diffed, and the current state can be
+-- compared against any earlier run without the overwritten results being lost.
+
+CREATE TABLE IF NOT EXISTS public.campaign_phase_runs (
+    run_id UUID PRIMARY KEY,
+    campaign_id UUID NOT NULL REFERENCES public.lead_generation_campaigns(id) ON DELETE CASCADE,
+    phase TEXT NOT NULL,
+    completed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    domain_count BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_phase_runs_campaign ON public.campaign_phase_runs (campaign_id, completed_at DESC);
+
+CREATE TABLE IF NOT EXISTS public.domain_state_snapshots (
+    run_id UUID NOT NULL REFERENCES public.campaign_phase_runs(run_id) ON DELETE CASCADE,
+    domain_id UUID NOT NULL,
+    domain_name TEXT NOT NULL,
+    dns_status TEXT,
+    http_status TEXT,
+    lead_status TEXT,
+    is_parked BOOLEAN,
+    domain_score NUMERIC(6,3),
+    content_hash TEXT,
+    PRIMARY KEY (run_id, domain_id)
+);
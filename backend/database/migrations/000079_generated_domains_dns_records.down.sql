@@ -0,0 +1,5 @@
+-- Migration: 000079_generated_domains_dns_records.down.sql
+-- Purpose: Rollback per record type DNS answers
+
+ALTER TABLE generated_domains
+  DROP COLUMN IF EXISTS dns_records;
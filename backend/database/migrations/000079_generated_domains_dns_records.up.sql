@@ -0,0 +1,7 @@
+-- Migration: 000079_generated_domains_dns_records.up.sql
+-- Purpose: Persist per record type DNS answers (A, AAAA, CNAME, MX, NS, TXT, SOA, CAA) from the latest DNS validation
+
+ALTER TABLE generated_domains
+  ADD COLUMN IF NOT EXISTS dns_records JSONB NULL;
+
+COMMENT ON COLUMN generated_domains.dns_records IS 'Answers from the latest DNS validation keyed by record type, e.g. {"A":["192.0.2.1"],"MX":["10 mail.example.com."]}';
@@ -0,0 +1,6 @@
+-- Migration: 000080_dns_wildcard_rejection_reason.up.sql
+-- Purpose: Rejection reason for domains whose DNS answers only came from a zone wildcard or a
+-- known parking/sinkhole range. Such domains keep dns_status = 'error' (so counters stay balanced)
+-- with dns_reason WILDCARD or SINKHOLE, and are excluded from the HTTP phase.
+
+ALTER TYPE public.domain_rejection_reason_enum ADD VALUE IF NOT EXISTS 'dns_wildcard' AFTER 'dns_timeout';
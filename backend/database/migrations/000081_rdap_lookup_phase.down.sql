@@ -0,0 +1,85 @@
+-- Migration: 000081_rdap_lookup_phase.down.sql
+-- Purpose: Rollback the RDAP lookup phase. Enum values cannot be dropped in place, so
+-- 'rdap_lookup' stays in phase_type_enum/job_type_enum but is no longer referenced.
+
+DELETE FROM phase_executions WHERE phase_type = 'rdap_lookup';
+DELETE FROM campaign_phases WHERE phase_type = 'rdap_lookup';
+
+ALTER TABLE campaign_phases DROP CONSTRAINT IF EXISTS campaign_phases_phase_order_check;
+ALTER TABLE campaign_phases
+    ADD CONSTRAINT campaign_phases_phase_order_check
+    CHECK ((phase_order >= 1) AND (phase_order <= 6));
+
+DROP INDEX IF EXISTS idx_generated_domains_rdap_status;
+
+ALTER TABLE generated_domains
+    DROP COLUMN IF EXISTS rdap_checked_at,
+    DROP COLUMN IF EXISTS rdap_expires_at,
+    DROP COLUMN IF EXISTS rdap_registered_at,
+    DROP COLUMN IF EXISTS rdap_registrar,
+    DROP COLUMN IF EXISTS rdap_status;
+
+CREATE OR REPLACE FUNCTION public.sync_campaign_from_phases(campaign_uuid uuid) RETURNS void
+    LANGUAGE plpgsql
+    AS $$
+DECLARE
+    computed_current_phase phase_type_enum;
+    computed_phase_status phase_status_enum;
+    completed_count INTEGER;
+    current_campaign_phase phase_type_enum;
+    current_campaign_status phase_status_enum;
+BEGIN
+    SELECT current_phase, phase_status INTO current_campaign_phase, current_campaign_status
+    FROM lead_generation_campaigns
+    WHERE id = campaign_uuid;
+
+    -- P0 Fix: Include 'paused' in the active status check alongside 'in_progress' and 'failed'.
+    -- This ensures the campaign row reflects paused phases immediately.
+    SELECT phase_type, status INTO computed_current_phase, computed_phase_status
+    FROM campaign_phases
+    WHERE campaign_id = campaign_uuid
+      AND status IN ('in_progress', 'paused', 'failed')
+    ORDER BY phase_order
+    LIMIT 1;
+
+    IF computed_current_phase IS NULL THEN
+        SELECT phase_type, status INTO computed_current_phase, computed_phase_status
+        FROM campaign_phases
+        WHERE campaign_id = campaign_uuid
+          AND status NOT IN ('completed', 'skipped')
+        ORDER BY phase_order
+        LIMIT 1;
+    END IF;
+
+    IF computed_current_phase IS NULL THEN
+        SELECT phase_type, status INTO computed_current_phase, computed_phase_status
+        FROM campaign_phases
+        WHERE campaign_id = campaign_uuid
+        ORDER BY phase_order DESC
+        LIMIT 1;
+    END IF;
+
+    SELECT COUNT(*) INTO completed_count
+    FROM campaign_phases
+    WHERE campaign_id = campaign_uuid
+      AND status IN ('completed', 'skipped');
+
+    IF computed_current_phase IS DISTINCT FROM current_campaign_phase OR
+       computed_phase_status IS DISTINCT FROM current_campaign_status THEN
+        UPDATE lead_generation_campaigns
+        SET current_phase = computed_current_phase,
+            phase_status = computed_phase_status,
+            completed_phases = completed_count,
+            updated_at = NOW()
+        WHERE id = campaign_uuid;
+
+        RAISE NOTICE 'Campaign % synced: % -> %, % -> %, completed_phases=%',
+            campaign_uuid,
+            current_campaign_phase, computed_current_phase,
+            current_campaign_status, computed_phase_status,
+            completed_count;
+    END IF;
+END;
+$$;
+
+COMMENT ON FUNCTION public.sync_campaign_from_phases(uuid) IS 'P0 Fix: Now includes paused status in priority selection (migration 000070)';
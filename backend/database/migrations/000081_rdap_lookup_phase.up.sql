@@ -0,0 +1,96 @@
+-- Migration: 000081_rdap_lookup_phase.up.sql
+-- Purpose: Optional RDAP lookup phase. Adds the phase/job enum values, a seventh phase slot, and
+-- registration columns on generated_domains. The phase is outside the automatic sequence, so it is
+-- excluded from completed_phases and from the idle fallback in sync_campaign_from_phases.
+
+ALTER TYPE public.phase_type_enum ADD VALUE IF NOT EXISTS 'rdap_lookup' AFTER 'analysis';
+ALTER TYPE public.job_type_enum ADD VALUE IF NOT EXISTS 'rdap_lookup';
+
+ALTER TABLE campaign_phases DROP CONSTRAINT IF EXISTS campaign_phases_phase_order_check;
+ALTER TABLE campaign_phases
+    ADD CONSTRAINT campaign_phases_phase_order_check
+    CHECK ((phase_order >= 1) AND (phase_order <= 7));
+
+ALTER TABLE generated_domains
+    ADD COLUMN IF NOT EXISTS rdap_status TEXT
+        CHECK (rdap_status IN ('registered', 'unregistered', 'unsupported', 'error')),
+    ADD COLUMN IF NOT EXISTS rdap_registrar TEXT,
+    ADD COLUMN IF NOT EXISTS rdap_registered_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS rdap_expires_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS rdap_checked_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN generated_domains.rdap_status IS 'Outcome of the latest RDAP lookup: registered, unregistered, unsupported (no RDAP service for the TLD) or error';
+
+CREATE INDEX IF NOT EXISTS idx_generated_domains_rdap_status
+ON generated_domains(campaign_id, rdap_status)
+WHERE rdap_status IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION public.sync_campaign_from_phases(campaign_uuid uuid) RETURNS void
+    LANGUAGE plpgsql
+    AS $$
+DECLARE
+    computed_current_phase phase_type_enum;
+    computed_phase_status phase_status_enum;
+    completed_count INTEGER;
+    current_campaign_phase phase_type_enum;
+    current_campaign_status phase_status_enum;
+BEGIN
+    SELECT current_phase, phase_status INTO current_campaign_phase, current_campaign_status
+    FROM lead_generation_campaigns
+    WHERE id = campaign_uuid;
+
+    -- P0 Fix: Include 'paused' in the active status check alongside 'in_progress' and 'failed'.
+    -- This ensures the campaign row reflects paused phases immediately.
+    SELECT phase_type, status INTO computed_current_phase, computed_phase_status
+    FROM campaign_phases
+    WHERE campaign_id = campaign_uuid
+      AND status IN ('in_progress', 'paused', 'failed')
+    ORDER BY phase_order
+    LIMIT 1;
+
+    -- The optional rdap_lookup phase only becomes current while it is active; an idle RDAP row
+    -- must not hold the campaign back from reporting its last pipeline phase.
+    IF computed_current_phase IS NULL THEN
+        SELECT phase_type, status INTO computed_current_phase, computed_phase_status
+        FROM campaign_phases
+        WHERE campaign_id = campaign_uuid
+          AND status NOT IN ('completed', 'skipped')
+          AND phase_type <> 'rdap_lookup'
+        ORDER BY phase_order
+        LIMIT 1;
+    END IF;
+
+    IF computed_current_phase IS NULL THEN
+        SELECT phase_type, status INTO computed_current_phase, computed_phase_status
+        FROM campaign_phases
+        WHERE campaign_id = campaign_uuid
+          AND phase_type <> 'rdap_lookup'
+        ORDER BY phase_order DESC
+        LIMIT 1;
+    END IF;
+
+    SELECT COUNT(*) INTO completed_count
+    FROM campaign_phases
+    WHERE campaign_id = campaign_uuid
+      AND status IN ('completed', 'skipped')
+      AND phase_type <> 'rdap_lookup';
+
+    IF computed_current_phase IS DISTINCT FROM current_campaign_phase OR
+       computed_phase_status IS DISTINCT FROM current_campaign_status THEN
+        UPDATE lead_generation_campaigns
+        SET current_phase = computed_current_phase,
+            phase_status = computed_phase_status,
+            completed_phases = completed_count,
+            updated_at = NOW()
+        WHERE id = campaign_uuid;
+
+        RAISE NOTICE 'Campaign % synced: % -> %, % -> %, completed_phases=%',
+            campaign_uuid,
+            current_campaign_phase, computed_current_phase,
+            current_campaign_status, computed_phase_status,
+            completed_count;
+    END IF;
+END;
+$$;
+
+COMMENT ON FUNCTION public.sync_campaign_from_phases(uuid) IS 'Includes paused status in priority selection; ignores the optional rdap_lookup phase unless active (migration 000081)';
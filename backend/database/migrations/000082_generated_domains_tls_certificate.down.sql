@@ -0,0 +1,5 @@
+-- Migration: 000082_generated_domains_tls_certificate.down.sql
+-- Purpose: Rollback TLS certificate details
+
+ALTER TABLE generated_domains
+  DROP COLUMN IF EXISTS tls_certificate;
@@ -0,0 +1,7 @@
+-- Migration: 000082_generated_domains_tls_certificate.up.sql
+-- Purpose: Persist the negotiated TLS version and leaf certificate details from the latest HTTP validation
+
+ALTER TABLE generated_domains
+  ADD COLUMN IF NOT EXISTS tls_certificate JSONB NULL;
+
+COMMENT ON COLUMN generated_domains.tls_certificate IS 'TLS session of the latest HTTP validation, e.g. {"version":"TLS 1.3","subject":"example.com","sans":["example.com"],"issuer":"R3","notBefore":"...","notAfter":"...","selfSigned":false,"hostnameMismatch":false}';
@@ -0,0 +1,9 @@
+-- Migration: 000083_proxy_pool_health_thresholds.down.sql
+-- Purpose: Rollback per-pool health thresholds
+
+ALTER TABLE proxy_pools
+  DROP CONSTRAINT IF EXISTS chk_proxy_pools_min_success_rate,
+  DROP CONSTRAINT IF EXISTS chk_proxy_pools_max_consecutive_failures,
+  DROP CONSTRAINT IF EXISTS chk_proxy_pools_strategy,
+  DROP COLUMN IF EXISTS min_success_rate,
+  DROP COLUMN IF EXISTS max_consecutive_failures;
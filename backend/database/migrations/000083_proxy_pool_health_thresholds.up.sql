@@ -0,0 +1,17 @@
+-- Migration: 000083_proxy_pool_health_thresholds.up.sql
+-- Purpose: Per-pool health thresholds used by the proxy manager when choosing pool members
+
+ALTER TABLE proxy_pools
+  ADD COLUMN IF NOT EXISTS max_consecutive_failures INTEGER NOT NULL DEFAULT 3,
+  ADD COLUMN IF NOT EXISTS min_success_rate DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- 'failover' was accepted before strategies were enforced; it behaves like round robin over healthy members
+UPDATE proxy_pools SET pool_strategy = 'round_robin' WHERE pool_strategy IS NULL OR pool_strategy NOT IN ('round_robin', 'weighted', 'least_recently_used', 'sticky', 'random');
+
+ALTER TABLE proxy_pools
+  ADD CONSTRAINT chk_proxy_pools_strategy CHECK (pool_strategy IN ('round_robin', 'weighted', 'least_recently_used', 'sticky', 'random')),
+  ADD CONSTRAINT chk_proxy_pools_max_consecutive_failures CHECK (max_consecutive_failures >= 0),
+  ADD CONSTRAINT chk_proxy_pools_min_success_rate CHECK (min_success_rate >= 0 AND min_success_rate <= 1);
+
+COMMENT ON COLUMN proxy_pools.max_consecutive_failures IS 'Members failing this many times in a row are skipped until the health check interval elapses; 0 disables the check';
+COMMENT ON COLUMN proxy_pools.min_success_rate IS 'Members whose observed success rate falls below this fraction are skipped; 0 disables the check';
@@ -0,0 +1,5 @@
+-- Migration: 000084_bulk_operations.down.sql
+-- Purpose: Rollback durable bulk operations
+
+DROP TABLE IF EXISTS public.bulk_operation_tasks;
+DROP TABLE IF EXISTS public.bulk_operations;
@@ -0,0 +1,40 @@
+-- Migration: 000084_bulk_operations.up.sql
+-- Purpose: Durable bulk operations with per-campaign sub-tasks
+--
+-- Each task keeps the phase configuration it was submitted with so tasks that never started
+-- (e.g. the server restarted mid-launch) can be resumed by any instance. claimed_until is a
+-- short lease taken while a task is being launched so concurrent instances never start it twice.
+
+CREATE TABLE IF NOT EXISTS public.bulk_operations (
+    id UUID PRIMARY KEY,
+    operation_type TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    cancel_requested BOOLEAN NOT NULL DEFAULT FALSE,
+    progress DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    CONSTRAINT bulk_operations_type_check CHECK (operation_type IN ('domain_generation', 'dns_validation', 'http_validation', 'analysis')),
+    CONSTRAINT bulk_operations_status_check CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_bulk_operations_created ON public.bulk_operations (created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_bulk_operations_active ON public.bulk_operations (created_at) WHERE status IN ('pending', 'running');
+
+CREATE TABLE IF NOT EXISTS public.bulk_operation_tasks (
+    operation_id UUID NOT NULL REFERENCES public.bulk_operations(id) ON DELETE CASCADE,
+    campaign_id UUID NOT NULL REFERENCES public.lead_generation_campaigns(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    config JSONB,
+    items_processed BIGINT NOT NULL DEFAULT 0,
+    items_total BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    claimed_until TIMESTAMPTZ,
+    started_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (operation_id, campaign_id),
+    CONSTRAINT bulk_operation_tasks_status_check CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_bulk_operation_tasks_pending ON public.bulk_operation_tasks (operation_id, position) WHERE status = 'pending';
@@ -0,0 +1,4 @@
+-- Migration: 000085_generated_domains_contacts_shape.down.sql
+-- Purpose: Rollback contacts column comment
+
+COMMENT ON COLUMN generated_domains.contacts IS 'Contact data array (email/phone/etc)';
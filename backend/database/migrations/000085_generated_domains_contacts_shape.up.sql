@@ -0,0 +1,7 @@
+-- Migration: 000085_generated_domains_contacts_shape.up.sql
+-- Purpose: Document the structured contacts document written by HTTP enrichment
+--
+-- The column has existed since 000048 but was never written. Enrichment now stores one object per
+-- domain, merged across the root page and microcrawl pages.
+
+COMMENT ON COLUMN generated_domains.contacts IS 'Contacts found during HTTP enrichment, e.g. {"emails":[{"address":"...","sourceUrl":"..."}],"phones":[{"e164":"+14155550132","raw":"...","sourceUrl":"..."}],"addresses":[...],"socialProfiles":[{"network":"linkedin","url":"...","sourceUrl":"..."}],"organizations":[{"type":"LocalBusiness","name":"...","sourceUrl":"..."}]}';
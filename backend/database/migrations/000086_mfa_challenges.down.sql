@@ -0,0 +1,4 @@
+-- Migration: 000086_mfa_challenges.down.sql
+-- Purpose: Rollback MFA login challenges
+
+DROP TABLE IF EXISTS public.mfa_challenges;
@@ -0,0 +1,19 @@
+-- Migration: 000086_mfa_challenges.up.sql
+-- Purpose: Short-lived second-factor challenges issued by the two-step login
+--
+-- A challenge is created once the password has been verified for a user with MFA enabled; the
+-- session is only created after the challenge is answered. Only a SHA-256 hash of the challenge
+-- token is stored. Expired rows are removed whenever a new challenge is issued.
+
+CREATE TABLE IF NOT EXISTS public.mfa_challenges (
+    token_hash TEXT PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    client_ip TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfa_challenges_expires ON public.mfa_challenges (expires_at);
+CREATE INDEX IF NOT EXISTS idx_mfa_challenges_user ON public.mfa_challenges (user_id);
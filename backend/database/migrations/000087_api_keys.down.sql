@@ -0,0 +1,4 @@
+-- Migration: 000087_api_keys.down.sql
+-- Purpose: Rollback personal API keys
+
+DROP TABLE IF EXISTS public.api_keys;
@@ -0,0 +1,24 @@
+-- Migration: 000087_api_keys.up.sql
+-- Purpose: Personal API keys for headless clients
+--
+-- Keys authenticate as their owner via `Authorization: Bearer <key>`. Only the SHA-256 hash of a key
+-- is stored; key_hint keeps the last four characters so owners can tell keys apart. Rotation swaps
+-- the hash in place, revocation sets revoked_at and keeps the row for the audit trail.
+
+CREATE TABLE IF NOT EXISTS public.api_keys (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    key_hint TEXT NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    last_used_ip TEXT,
+    revoked_at TIMESTAMPTZ,
+    rotated_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_user ON public.api_keys (user_id, created_at DESC);
@@ -0,0 +1,17 @@
+-- Migration: 000088_user_roles_campaign_sharing.down.sql
+-- Purpose: Rollback user roles
+
+DROP INDEX IF EXISTS idx_campaign_access_grants_user_active;
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.views WHERE table_schema = 'auth' AND table_name = 'users') THEN
+        DROP VIEW auth.users;
+        ALTER TABLE public.users DROP CONSTRAINT IF EXISTS users_role_check;
+        ALTER TABLE public.users DROP COLUMN IF EXISTS role;
+        CREATE VIEW auth.users AS SELECT * FROM public.users;
+    ELSE
+        ALTER TABLE public.users DROP CONSTRAINT IF EXISTS users_role_check;
+        ALTER TABLE public.users DROP COLUMN IF EXISTS role;
+    END IF;
+END $$;
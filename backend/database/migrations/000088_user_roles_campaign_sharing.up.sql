@@ -0,0 +1,35 @@
+-- Migration: 000088_user_roles_campaign_sharing.up.sql
+-- Purpose: User roles and per-campaign sharing
+--
+-- Every user gets a role: admin (everything), operator (creates and runs campaigns) or viewer
+-- (read-only). Campaign access is the owner plus active rows in campaign_access_grants, where the
+-- API's read / control / admin levels are stored as access_type 'read' / 'write' / 'admin'.
+
+ALTER TABLE public.users ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'operator';
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'users_role_check') THEN
+        ALTER TABLE public.users ADD CONSTRAINT users_role_check CHECK (role IN ('admin', 'operator', 'viewer'));
+    END IF;
+END $$;
+
+-- The oldest account becomes admin so an existing install keeps someone who can manage roles
+UPDATE public.users SET role = 'admin'
+ WHERE id = (SELECT id FROM public.users ORDER BY created_at, id LIMIT 1)
+   AND NOT EXISTS (SELECT 1 FROM public.users WHERE role = 'admin');
+
+-- Campaigns created before ownership was recorded go to that admin rather than becoming unreachable
+UPDATE lead_generation_campaigns SET user_id = (SELECT id FROM public.users WHERE role = 'admin' ORDER BY created_at, id LIMIT 1)
+ WHERE user_id IS NULL;
+
+-- auth.users is SELECT * over public.users; recreate it so the view picks up the new column
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.views WHERE table_schema = 'auth' AND table_name = 'users') THEN
+        CREATE OR REPLACE VIEW auth.users AS SELECT * FROM public.users;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_campaign_access_grants_user_active
+    ON campaign_access_grants(user_id, campaign_id) WHERE active = TRUE AND revoked = FALSE;
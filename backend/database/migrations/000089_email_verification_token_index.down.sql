@@ -0,0 +1,4 @@
+-- Migration: 000089_email_verification_token_index.down.sql
+-- Purpose: Rollback email verification token index
+
+DROP INDEX IF EXISTS idx_users_email_verification_token;
@@ -0,0 +1,8 @@
+-- Migration: 000089_email_verification_token_index.up.sql
+-- Purpose: Look up users by email verification token
+--
+-- users.email_verification_token holds the SHA-256 hash of the emailed token; the confirm endpoint
+-- finds the user by it. Password reset tokens are already indexed by token_hash.
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_verification_token
+    ON public.users(email_verification_token) WHERE email_verification_token IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Migration: 000090_runtime_config_versions.down.sql
+-- Purpose: Rollback runtime config versioning
+
+DROP INDEX IF EXISTS idx_config_versions_current_key;
+ALTER TABLE config_versions DROP COLUMN IF EXISTS change_diff;
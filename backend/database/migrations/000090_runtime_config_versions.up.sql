@@ -0,0 +1,12 @@
+-- Migration: 000090_runtime_config_versions.up.sql
+-- Purpose: Persist runtime configuration changes made through the /config endpoints
+--
+-- Each section (dns_validator, worker, ...) is a config_key in config_versions. Every change adds
+-- a version with its author and field-level diff; the single is_current row per key is reapplied
+-- at startup. The partial unique index keeps one current version per key and makes two writers
+-- racing on the same base version fail instead of both succeeding.
+
+ALTER TABLE config_versions ADD COLUMN IF NOT EXISTS change_diff JSONB;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_config_versions_current_key
+    ON config_versions(config_key) WHERE is_current = TRUE;
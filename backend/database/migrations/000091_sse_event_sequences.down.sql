@@ -0,0 +1,4 @@
+-- Migration: 000091_sse_event_sequences.down.sql
+-- Purpose: Rollback SSE event id reservations
+
+DROP TABLE IF EXISTS public.sse_event_sequences;
@@ -0,0 +1,16 @@
+-- Migration: 000091_sse_event_sequences.up.sql
+-- Purpose: Reserve SSE event ids in blocks so a restart never reissues an id that was sent but not journaled
+--
+-- The SSE service reserves a block of ids per campaign before emitting them and resumes above
+-- reserved_through after a restart. Journal writes are asynchronous and may be dropped, so the
+-- journal's MAX(seq) alone is not a safe restart point.
+
+CREATE TABLE IF NOT EXISTS public.sse_event_sequences (
+    campaign_id UUID PRIMARY KEY REFERENCES public.lead_generation_campaigns(id) ON DELETE CASCADE,
+    reserved_through BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO public.sse_event_sequences (campaign_id, reserved_through)
+SELECT campaign_id, MAX(seq) FROM public.sse_event_journal GROUP BY campaign_id
+ON CONFLICT (campaign_id) DO NOTHING;
@@ -0,0 +1,37 @@
+-- Migration: 000092_dns_missing_records_rejection_reason.down.sql
+-- Purpose: Rollback dns_missing_records rejection reason (folds affected rows into dns_error)
+
+UPDATE public.generated_domains
+SET rejection_reason = 'dns_error'
+WHERE rejection_reason = 'dns_missing_records';
+
+-- Recreate the enum without dns_missing_records; partial indexes compare against enum literals so they are
+-- dropped and rebuilt around the type swap.
+DROP INDEX IF EXISTS public.idx_generated_domains_qualified;
+DROP INDEX IF EXISTS public.idx_generated_domains_pending;
+
+ALTER TYPE public.domain_rejection_reason_enum RENAME TO domain_rejection_reason_enum_old;
+CREATE TYPE public.domain_rejection_reason_enum AS ENUM (
+    'qualified',
+    'low_score',
+    'no_keywords',
+    'parked',
+    'dns_error',
+    'dns_timeout',
+    'dns_wildcard',
+    'http_error',
+    'http_timeout',
+    'pending'
+);
+ALTER TABLE public.generated_domains
+    ALTER COLUMN rejection_reason TYPE public.domain_rejection_reason_enum
+    USING rejection_reason::text::public.domain_rejection_reason_enum;
+DROP TYPE public.domain_rejection_reason_enum_old;
+
+CREATE INDEX IF NOT EXISTS idx_generated_domains_qualified
+ON public.generated_domains(campaign_id)
+WHERE rejection_reason = 'qualified';
+
+CREATE INDEX IF NOT EXISTS idx_generated_domains_pending
+ON public.generated_domains(campaign_id, created_at)
+WHERE rejection_reason = 'pending';
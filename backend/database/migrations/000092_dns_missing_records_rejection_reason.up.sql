@@ -0,0 +1,5 @@
+-- Migration: 000092_dns_missing_records_rejection_reason.up.sql
+-- Purpose: Rejection reason for domains that resolved but lack a record type the campaign requires
+-- (dns_reason MISSING_RECORDS:<types>). They were previously folded into dns_error.
+
+ALTER TYPE public.domain_rejection_reason_enum ADD VALUE IF NOT EXISTS 'dns_missing_records' AFTER 'dns_wildcard';
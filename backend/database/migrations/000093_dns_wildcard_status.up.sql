@@ -0,0 +1,169 @@
+-- Migration: 000093_dns_wildcard_status.up.sql
+-- Purpose: Persist wildcard/sinkhole DNS outcomes as their own dns_status instead of 'error'.
+-- Wildcard domains are still DNS failures for progress and counter purposes, so both triggers that
+-- tally dns_status fold 'wildcard' into their error buckets. Existing rows are moved over in 000094
+-- (a new enum value cannot be used in the transaction that adds it).
+
+BEGIN;
+
+ALTER TYPE public.domain_dns_status_enum ADD VALUE IF NOT EXISTS 'wildcard' AFTER 'timeout';
+
+CREATE OR REPLACE FUNCTION campaign_domain_counters_upsert() RETURNS TRIGGER AS $$
+DECLARE
+    v_exists BOOLEAN;
+    d_total_delta INT := 0;
+BEGIN
+    IF (TG_OP = 'INSERT') THEN
+        -- Ensure row exists
+        INSERT INTO campaign_domain_counters (campaign_id, total_domains)
+            VALUES (NEW.campaign_id, 0)
+            ON CONFLICT (campaign_id) DO NOTHING;
+        -- Total always increments on insert
+        UPDATE campaign_domain_counters
+           SET total_domains = total_domains + 1,
+               dns_pending = dns_pending + CASE WHEN NEW.dns_status = 'pending' THEN 1 ELSE 0 END,
+               dns_ok = dns_ok + CASE WHEN NEW.dns_status = 'ok' THEN 1 ELSE 0 END,
+               dns_error = dns_error + CASE WHEN NEW.dns_status IN ('error','wildcard') THEN 1 ELSE 0 END,
+               dns_timeout = dns_timeout + CASE WHEN NEW.dns_status = 'timeout' THEN 1 ELSE 0 END,
+               http_pending = http_pending + CASE WHEN NEW.http_status = 'pending' THEN 1 ELSE 0 END,
+               http_ok = http_ok + CASE WHEN NEW.http_status = 'ok' THEN 1 ELSE 0 END,
+               http_error = http_error + CASE WHEN NEW.http_status = 'error' THEN 1 ELSE 0 END,
+               http_timeout = http_timeout + CASE WHEN NEW.http_status = 'timeout' THEN 1 ELSE 0 END,
+               lead_pending = lead_pending + CASE WHEN NEW.lead_status = 'pending' THEN 1 ELSE 0 END,
+               lead_match = lead_match + CASE WHEN NEW.lead_status = 'match' THEN 1 ELSE 0 END,
+               lead_no_match = lead_no_match + CASE WHEN NEW.lead_status = 'no_match' THEN 1 ELSE 0 END,
+               lead_error = lead_error + CASE WHEN NEW.lead_status = 'error' THEN 1 ELSE 0 END,
+               lead_timeout = lead_timeout + CASE WHEN NEW.lead_status = 'timeout' THEN 1 ELSE 0 END,
+               updated_at = NOW()
+         WHERE campaign_id = NEW.campaign_id;
+        RETURN NEW;
+    ELSIF (TG_OP = 'UPDATE') THEN
+        -- Only adjust when status fields change
+        IF (NEW.dns_status IS DISTINCT FROM OLD.dns_status) OR (NEW.http_status IS DISTINCT FROM OLD.http_status) OR (NEW.lead_status IS DISTINCT FROM OLD.lead_status) THEN
+            UPDATE campaign_domain_counters SET
+                dns_pending = dns_pending + CASE WHEN NEW.dns_status = 'pending' THEN 1 ELSE 0 END - CASE WHEN OLD.dns_status = 'pending' THEN 1 ELSE 0 END,
+                dns_ok = dns_ok + CASE WHEN NEW.dns_status = 'ok' THEN 1 ELSE 0 END - CASE WHEN OLD.dns_status = 'ok' THEN 1 ELSE 0 END,
+                dns_error = dns_error + CASE WHEN NEW.dns_status IN ('error','wildcard') THEN 1 ELSE 0 END - CASE WHEN OLD.dns_status IN ('error','wildcard') THEN 1 ELSE 0 END,
+                dns_timeout = dns_timeout + CASE WHEN NEW.dns_status = 'timeout' THEN 1 ELSE 0 END - CASE WHEN OLD.dns_status = 'timeout' THEN 1 ELSE 0 END,
+                http_pending = http_pending + CASE WHEN NEW.http_status = 'pending' THEN 1 ELSE 0 END - CASE WHEN OLD.http_status = 'pending' THEN 1 ELSE 0 END,
+                http_ok = http_ok + CASE WHEN NEW.http_status = 'ok' THEN 1 ELSE 0 END - CASE WHEN OLD.http_status = 'ok' THEN 1 ELSE 0 END,
+                http_error = http_error + CASE WHEN NEW.http_status = 'error' THEN 1 ELSE 0 END - CASE WHEN OLD.http_status = 'error' THEN 1 ELSE 0 END,
+                http_timeout = http_timeout + CASE WHEN NEW.http_status = 'timeout' THEN 1 ELSE 0 END - CASE WHEN OLD.http_status = 'timeout' THEN 1 ELSE 0 END,
+                lead_pending = lead_pending + CASE WHEN NEW.lead_status = 'pending' THEN 1 ELSE 0 END - CASE WHEN OLD.lead_status = 'pending' THEN 1 ELSE 0 END,
+                lead_match = lead_match + CASE WHEN NEW.lead_status = 'match' THEN 1 ELSE 0 END - CASE WHEN OLD.lead_status = 'match' THEN 1 ELSE 0 END,
+                lead_no_match = lead_no_match + CASE WHEN NEW.lead_status = 'no_match' THEN 1 ELSE 0 END - CASE WHEN OLD.lead_status = 'no_match' THEN 1 ELSE 0 END,
+                lead_error = lead_error + CASE WHEN NEW.lead_status = 'error' THEN 1 ELSE 0 END - CASE WHEN OLD.lead_status = 'error' THEN 1 ELSE 0 END,
+                lead_timeout = lead_timeout + CASE WHEN NEW.lead_status = 'timeout' THEN 1 ELSE 0 END - CASE WHEN OLD.lead_status = 'timeout' THEN 1 ELSE 0 END,
+                updated_at = NOW()
+            WHERE campaign_id = NEW.campaign_id;
+        END IF;
+        RETURN NEW;
+    ELSIF (TG_OP = 'DELETE') THEN
+        -- Adjust counts downward (rare path; if deletions introduced later)
+        UPDATE campaign_domain_counters SET
+            total_domains = total_domains - 1,
+            dns_pending = dns_pending - CASE WHEN OLD.dns_status = 'pending' THEN 1 ELSE 0 END,
+            dns_ok = dns_ok - CASE WHEN OLD.dns_status = 'ok' THEN 1 ELSE 0 END,
+            dns_error = dns_error - CASE WHEN OLD.dns_status IN ('error','wildcard') THEN 1 ELSE 0 END,
+            dns_timeout = dns_timeout - CASE WHEN OLD.dns_status = 'timeout' THEN 1 ELSE 0 END,
+            http_pending = http_pending - CASE WHEN OLD.http_status = 'pending' THEN 1 ELSE 0 END,
+            http_ok = http_ok - CASE WHEN OLD.http_status = 'ok' THEN 1 ELSE 0 END,
+            http_error = http_error - CASE WHEN OLD.http_status = 'error' THEN 1 ELSE 0 END,
+            http_timeout = http_timeout - CASE WHEN OLD.http_status = 'timeout' THEN 1 ELSE 0 END,
+            lead_pending = lead_pending - CASE WHEN OLD.lead_status = 'pending' THEN 1 ELSE 0 END,
+            lead_match = lead_match - CASE WHEN OLD.lead_status = 'match' THEN 1 ELSE 0 END,
+            lead_no_match = lead_no_match - CASE WHEN OLD.lead_status = 'no_match' THEN 1 ELSE 0 END,
+            lead_error = lead_error - CASE WHEN OLD.lead_status = 'error' THEN 1 ELSE 0 END,
+            lead_timeout = lead_timeout - CASE WHEN OLD.lead_status = 'timeout' THEN 1 ELSE 0 END,
+            updated_at = NOW()
+        WHERE campaign_id = OLD.campaign_id;
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION trigger_domain_validation_update() RETURNS TRIGGER AS $$
+DECLARE
+    v_campaign_id UUID := NEW.campaign_id;
+    -- DNS stats
+    dns_total INT; dns_completed INT; dns_ok INT; dns_error INT; dns_timeout INT;
+    -- HTTP stats
+    http_total INT; http_completed INT; http_ok INT; http_error INT; http_timeout INT;
+    v_current_phase phase_type_enum;
+    advance_to phase_type_enum;
+    v_progress NUMERIC(5,2);
+    v_total INT; v_processed INT; v_success INT; v_failed INT;
+BEGIN
+    -- Only act when relevant status columns changed
+    IF (OLD.dns_status IS DISTINCT FROM NEW.dns_status) OR (OLD.http_status IS DISTINCT FROM NEW.http_status) THEN
+        -- Aggregate DNS
+        SELECT COUNT(*),
+               COUNT(*) FILTER (WHERE dns_status <> 'pending'),
+               COUNT(*) FILTER (WHERE dns_status = 'ok'),
+               COUNT(*) FILTER (WHERE dns_status IN ('error','wildcard')),
+               COUNT(*) FILTER (WHERE dns_status = 'timeout')
+          INTO dns_total, dns_completed, dns_ok, dns_error, dns_timeout
+          FROM generated_domains WHERE campaign_id = v_campaign_id;
+
+        -- Aggregate HTTP
+        SELECT COUNT(*),
+               COUNT(*) FILTER (WHERE http_status <> 'pending'),
+               COUNT(*) FILTER (WHERE http_status = 'ok'),
+               COUNT(*) FILTER (WHERE http_status = 'error'),
+               COUNT(*) FILTER (WHERE http_status = 'timeout')
+          INTO http_total, http_completed, http_ok, http_error, http_timeout
+          FROM generated_domains WHERE campaign_id = v_campaign_id;
+
+        -- Lock + read current phase
+        SELECT current_phase INTO v_current_phase FROM lead_generation_campaigns WHERE id = v_campaign_id FOR UPDATE;
+
+        advance_to := NULL;
+
+        -- Compute metrics based on current phase
+        IF v_current_phase = 'dns_validation' THEN
+            v_total := dns_total;
+            v_processed := dns_completed;
+            v_success := dns_ok;
+            v_failed := dns_error + dns_timeout;
+            IF dns_total > 0 THEN
+                v_progress := (dns_completed::NUMERIC / dns_total) * 100;
+            ELSE
+                v_progress := 0;
+            END IF;
+            IF dns_total > 0 AND dns_completed = dns_total THEN
+                advance_to := 'http_keyword_validation';
+            END IF;
+        ELSIF v_current_phase = 'http_keyword_validation' THEN
+            v_total := http_total;
+            v_processed := http_completed;
+            v_success := http_ok;
+            v_failed := http_error + http_timeout;
+            IF http_total > 0 THEN
+                v_progress := (http_completed::NUMERIC / http_total) * 100;
+            ELSE
+                v_progress := 0;
+            END IF;
+            IF http_total > 0 AND http_completed = http_total THEN
+                advance_to := 'analysis';
+            END IF;
+        ELSE
+            -- For other phases (domain_generation, analysis) we don't update metrics here
+            v_total := NULL; v_processed := NULL; v_success := NULL; v_failed := NULL; v_progress := NULL;
+        END IF;
+
+        UPDATE lead_generation_campaigns lgc
+           SET current_phase = COALESCE(advance_to, lgc.current_phase),
+               total_items = COALESCE(v_total, lgc.total_items),
+               processed_items = COALESCE(v_processed, lgc.processed_items),
+               successful_items = COALESCE(v_success, lgc.successful_items),
+               failed_items = COALESCE(v_failed, lgc.failed_items),
+               progress_percentage = COALESCE(v_progress, lgc.progress_percentage),
+               updated_at = NOW()
+         WHERE lgc.id = v_campaign_id;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql SECURITY DEFINER;
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Migration: 000094_backfill_dns_wildcard_status.down.sql
+-- Purpose: Rollback the wildcard dns_status backfill
+
+BEGIN;
+
+ALTER TABLE public.generated_domains DISABLE TRIGGER trigger_domain_validation;
+
+UPDATE public.generated_domains
+SET dns_status = 'error'
+WHERE dns_status = 'wildcard';
+
+ALTER TABLE public.generated_domains ENABLE TRIGGER trigger_domain_validation;
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Migration: 000094_backfill_dns_wildcard_status.up.sql
+-- Purpose: Move domains rejected as dns_wildcard (previously stored with dns_status = 'error') onto the
+-- wildcard dns_status added in 000093. Both count as DNS failures, so the per-row phase progress trigger
+-- has nothing to recompute and is paused for the backfill.
+
+BEGIN;
+
+ALTER TABLE public.generated_domains DISABLE TRIGGER trigger_domain_validation;
+
+UPDATE public.generated_domains
+SET dns_status = 'wildcard'
+WHERE dns_status = 'error'
+  AND rejection_reason = 'dns_wildcard';
+
+ALTER TABLE public.generated_domains ENABLE TRIGGER trigger_domain_validation;
+
+COMMIT;
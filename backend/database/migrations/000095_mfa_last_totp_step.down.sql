@@ -0,0 +1,13 @@
+-- Migration: 000095_mfa_last_totp_step.down.sql
+-- Purpose: Rollback the last accepted TOTP step
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.views WHERE table_schema = 'auth' AND table_name = 'users') THEN
+        DROP VIEW auth.users;
+        ALTER TABLE public.users DROP COLUMN IF EXISTS mfa_last_totp_step;
+        CREATE VIEW auth.users AS SELECT * FROM public.users;
+    ELSE
+        ALTER TABLE public.users DROP COLUMN IF EXISTS mfa_last_totp_step;
+    END IF;
+END $$;
@@ -0,0 +1,15 @@
+-- Migration: 000095_mfa_last_totp_step.up.sql
+-- Purpose: Remember the last TOTP time step accepted for each user so a code cannot be replayed
+--
+-- A TOTP code stays valid for its 30-second step plus the drift window. Codes for the recorded step
+-- or any earlier one are rejected, which makes each code single-use.
+
+ALTER TABLE public.users ADD COLUMN IF NOT EXISTS mfa_last_totp_step BIGINT;
+
+-- auth.users is SELECT * over public.users; recreate it so the view picks up the new column
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.views WHERE table_schema = 'auth' AND table_name = 'users') THEN
+        CREATE OR REPLACE VIEW auth.users AS SELECT * FROM public.users;
+    END IF;
+END $$;
@@ -0,0 +1,104 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domainservices "github.com/fntelecomllc/studio/backend/internal/domain/services"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/services"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultKeepRunsPerPhase = 20
+	changeScanPageSize      = 1000
+)
+
+// RunSnapshotHook snapshots domain outcomes after every successful phase run and broadcasts a
+// domain_changes SSE event summarizing what changed since the previous run of the same phase.
+// Domain generation is skipped since it produces no outcomes to compare.
+type RunSnapshotHook struct {
+	Snapshots store.DomainRunSnapshotStore
+	Campaigns store.CampaignStore
+	Deps      domainservices.Dependencies
+	SSE       *services.SSEService
+	// KeepRuns bounds the snapshotted runs retained per phase (default 20).
+	KeepRuns int
+	// ScoreThreshold is the score boundary for crossing counts (default models.DefaultChangeScoreThreshold).
+	ScoreThreshold float64
+}
+
+func (h *RunSnapshotHook) RunPhase(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum, runID uuid.UUID) error {
+	querier, ok := h.Deps.DB.(store.Querier)
+	if !ok || h.Snapshots == nil || runID == uuid.Nil || phase == models.PhaseTypeDomainGeneration {
+		return nil
+	}
+	run := &models.CampaignPhaseRun{RunID: runID, CampaignID: campaignID, Phase: phase, CompletedAt: time.Now().UTC()}
+	if err := h.Snapshots.SnapshotCampaignPhaseRun(ctx, querier, run); err != nil {
+		return fmt.Errorf("snapshot phase run: %w", err)
+	}
+	keep := h.KeepRuns
+	if keep <= 0 {
+		keep = defaultKeepRunsPerPhase
+	}
+	if _, err := h.Snapshots.PruneCampaignPhaseRuns(ctx, querier, campaignID, keep); err != nil && h.Deps.Logger != nil {
+		h.Deps.Logger.Warn(ctx, "RunSnapshotHook: prune failed", map[string]interface{}{"campaign_id": campaignID, "error": err.Error()})
+	}
+
+	prev, err := h.Snapshots.GetPreviousCampaignPhaseRun(ctx, querier, run)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("find previous run: %w", err)
+	}
+	threshold := h.ScoreThreshold
+	if threshold <= 0 {
+		threshold = models.DefaultChangeScoreThreshold
+	}
+	counts, total, err := CountDomainChanges(ctx, h.Snapshots, querier, prev.RunID, run.RunID, threshold)
+	if err != nil {
+		return err
+	}
+	if h.SSE == nil || h.Campaigns == nil {
+		return nil
+	}
+	campaign, err := h.Campaigns.GetCampaignByID(ctx, querier, campaignID)
+	if err != nil || campaign.UserID == nil {
+		return err
+	}
+	h.SSE.BroadcastToCampaign(campaignID, services.CreateDomainChangesEvent(campaignID, *campaign.UserID, phase, run.RunID, prev.RunID, counts, total))
+	return nil
+}
+
+// CountDomainChanges classifies every changed domain between two runs, returning per-kind counts and the
+// number of domains with at least one change.
+func CountDomainChanges(ctx context.Context, snapshots store.DomainRunSnapshotStore, exec store.Querier, fromRunID, toRunID uuid.UUID, threshold float64) (map[string]int64, int64, error) {
+	counts := map[string]int64{}
+	var total int64
+	after := uuid.Nil
+	for {
+		page, err := snapshots.ListChangedDomainSnapshots(ctx, exec, fromRunID, toRunID, after, changeScanPageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, change := range page {
+			kinds := models.ClassifyDomainChange(change.Before, change.After, threshold)
+			if len(kinds) == 0 {
+				continue
+			}
+			total++
+			for _, k := range kinds {
+				counts[string(k)]++
+			}
+		}
+		if len(page) < changeScanPageSize {
+			return counts, total, nil
+		}
+		after = page[len(page)-1].DomainID
+	}
+}
@@ -118,6 +118,7 @@ type CampaignOrchestrator struct {
 	extractionSvc       domainservices.PhaseService
 	enrichmentSvc       domainservices.EnrichmentService
 	analysisSvc         domainservices.AnalysisService
+	rdapLookupSvc       domainservices.RDAPLookupService // optional; nil when RDAP lookups are not configured
 
 	// Real-time communication (interface to allow test stubs)
 	sseService SSEBroadcaster
@@ -137,6 +138,8 @@ type CampaignOrchestrator struct {
 
 	// Optional post-completion hooks
 	hooks []PostCompletionHook
+	// Optional hooks run after each successful phase run
+	phaseHooks []PhaseCompletionHook
 }
 
 // CampaignExecution tracks the overall execution state of a campaign
@@ -1050,6 +1053,14 @@ func (o *CampaignOrchestrator) SetControlManager(control PhaseControlManager) {
 	o.control = control
 }
 
+// SetRDAPLookupService registers the optional RDAP lookup phase. Without it the phase reports as unsupported.
+func (o *CampaignOrchestrator) SetRDAPLookupService(svc domainservices.RDAPLookupService) {
+	if o == nil {
+		return
+	}
+	o.rdapLookupSvc = svc
+}
+
 // RescoreCampaign triggers a synchronous domain rescore via the analysis service.
 // It is lightweight and returns once scores have been recomputed (may be optimized async later).
 func (o *CampaignOrchestrator) RescoreCampaign(ctx context.Context, campaignID uuid.UUID) error {
@@ -1152,6 +1163,21 @@ func (o *CampaignOrchestrator) RegisterPostCompletionHook(h PostCompletionHook)
 	o.hooks = append(o.hooks, h)
 }
 
+// PhaseCompletionHook defines a hook executed synchronously after a phase run completes successfully
+type PhaseCompletionHook interface {
+	RunPhase(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum, runID uuid.UUID) error
+}
+
+// RegisterPhaseCompletionHook registers a hook to run after each successful phase run
+func (o *CampaignOrchestrator) RegisterPhaseCompletionHook(h PhaseCompletionHook) {
+	if h == nil {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.phaseHooks = append(o.phaseHooks, h)
+}
+
 // ConfigurePhase configures a specific phase for a campaign
 func (o *CampaignOrchestrator) ConfigurePhase(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum, config interface{}) error {
 	o.deps.Logger.Info(ctx, "Configuring campaign phase", map[string]interface{}{
@@ -1849,6 +1875,8 @@ func parsePhaseType(phaseType string) (models.PhaseTypeEnum, error) {
 		return models.PhaseTypeEnrichment, nil
 	case "analysis":
 		return models.PhaseTypeAnalysis, nil
+	case "rdap_lookup":
+		return models.PhaseTypeRDAPLookup, nil
 	default:
 		return "", fmt.Errorf("unknown phase type: %s", phaseType)
 	}
@@ -2788,6 +2816,8 @@ func upstreamPhase(phase models.PhaseTypeEnum) (models.PhaseTypeEnum, bool) {
 		return models.PhaseTypeExtraction, true
 	case models.PhaseTypeEnrichment:
 		return models.PhaseTypeAnalysis, true
+	case models.PhaseTypeRDAPLookup:
+		return models.PhaseTypeDomainGeneration, true
 	default:
 		return "", false
 	}
@@ -2821,6 +2851,11 @@ func (o *CampaignOrchestrator) getPhaseService(phase models.PhaseTypeEnum) (doma
 		return o.enrichmentSvc, nil
 	case models.PhaseTypeAnalysis:
 		return o.analysisSvc, nil
+	case models.PhaseTypeRDAPLookup:
+		if o.rdapLookupSvc == nil {
+			return nil, fmt.Errorf("rdap lookup service unavailable")
+		}
+		return o.rdapLookupSvc, nil
 	default:
 		return nil, fmt.Errorf("unsupported phase type: %s", phase)
 	}
@@ -2830,7 +2865,7 @@ func (o *CampaignOrchestrator) ensurePhaseDefaultConfig(ctx context.Context, exe
 	if o.store == nil {
 		return nil
 	}
-	if phase != models.PhaseTypeEnrichment && phase != models.PhaseTypeAnalysis {
+	if phase != models.PhaseTypeEnrichment && phase != models.PhaseTypeAnalysis && phase != models.PhaseTypeRDAPLookup {
 		return nil
 	}
 
@@ -2872,6 +2907,16 @@ func (o *CampaignOrchestrator) ensurePhaseDefaultConfig(ctx context.Context, exe
 		if err := o.analysisSvc.Configure(ctx, campaignID, &domainservices.AnalysisConfig{}); err != nil {
 			return fmt.Errorf("auto-configure analysis defaults: %w", err)
 		}
+	case models.PhaseTypeRDAPLookup:
+		if o.rdapLookupSvc == nil {
+			return fmt.Errorf("rdap lookup service unavailable")
+		}
+		if o.deps.Logger != nil {
+			o.deps.Logger.Info(ctx, "Auto-configuring rdap lookup defaults", map[string]interface{}{"campaign_id": campaignID})
+		}
+		if err := o.rdapLookupSvc.Configure(ctx, campaignID, nil); err != nil {
+			return fmt.Errorf("auto-configure rdap lookup defaults: %w", err)
+		}
 	}
 
 	return nil
@@ -3186,7 +3231,7 @@ func decodePhaseConfiguration(phase models.PhaseTypeEnum, payload []byte) (inter
 		}
 		// Otherwise return as map for Configure to handle gracefully
 		return m, nil
-	case models.PhaseTypeEnrichment:
+	case models.PhaseTypeEnrichment, models.PhaseTypeRDAPLookup:
 		copyBuf := make([]byte, len(payload))
 		copy(copyBuf, payload)
 		return json.RawMessage(copyBuf), nil
@@ -3562,6 +3607,8 @@ func (o *CampaignOrchestrator) handlePhaseCompletion(ctx context.Context, campai
 				})
 			}
 		}
+		// Phase hooks run before auto-advance so they observe this run's final domain state.
+		o.runPhaseCompletionHooks(ctx, campaignID, phase, runID)
 		// Record duration if timestamps available
 		if finalStatus.StartedAt != nil && finalStatus.CompletedAt != nil {
 			elapsed := finalStatus.CompletedAt.Sub(*finalStatus.StartedAt)
@@ -3578,11 +3625,17 @@ func (o *CampaignOrchestrator) handlePhaseCompletion(ctx context.Context, campai
 				phaseKey = "analysis"
 			case models.PhaseTypeEnrichment:
 				phaseKey = "enrichment"
+			case models.PhaseTypeRDAPLookup:
+				phaseKey = "rdap_lookup"
 			}
 			if phaseKey != "" {
 				o.metrics.RecordPhaseDuration(phaseKey, elapsed)
 			}
 		}
+		// Optional phases sit outside the sequence: they neither advance nor complete the campaign.
+		if isOptionalPhase(phase) {
+			return
+		}
 		if o.isLastPhase(phase) {
 			_ = o.HandleCampaignCompletion(ctx, campaignID)
 			return
@@ -3668,6 +3721,11 @@ func (o *CampaignOrchestrator) isLastPhase(phase models.PhaseTypeEnum) bool {
 	return phase == models.PhaseTypeEnrichment
 }
 
+// isOptionalPhase reports phases that run only on request and are not part of the standard sequence
+func isOptionalPhase(phase models.PhaseTypeEnum) bool {
+	return phase == models.PhaseTypeRDAPLookup
+}
+
 // nextPhase returns the next phase in the standard sequence
 func (o *CampaignOrchestrator) nextPhase(current models.PhaseTypeEnum) (models.PhaseTypeEnum, bool) {
 	switch current {
@@ -3883,4 +3941,21 @@ func (o *CampaignOrchestrator) runPostCompletionHooks(ctx context.Context, campa
 	}
 }
 
+// runPhaseCompletionHooks executes registered phase hooks; failures are logged and never block auto-advance
+func (o *CampaignOrchestrator) runPhaseCompletionHooks(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum, runID uuid.UUID) {
+	o.mu.RLock()
+	hooks := append([]PhaseCompletionHook(nil), o.phaseHooks...)
+	o.mu.RUnlock()
+	for _, h := range hooks {
+		if err := h.RunPhase(ctx, campaignID, phase, runID); err != nil && o.deps.Logger != nil {
+			o.deps.Logger.Warn(ctx, "Phase completion hook failed", map[string]interface{}{
+				"campaign_id": campaignID,
+				"phase":       phase,
+				"run_id":      runID,
+				"error":       err.Error(),
+			})
+		}
+	}
+}
+
 // Stealth-specific processing methods removed; stealth-aware services now handle any ordering internally.
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -469,7 +470,7 @@ func TestHydratePhaseConfigurationDomainGeneration(t *testing.T) {
 	default:
 		t.Fatalf("expected domain generation config, got %T", domainSvc.configs[campaignID])
 	}
-	if stored != cfg {
+	if !reflect.DeepEqual(stored, cfg) {
 		t.Fatalf("unexpected domain generation config rehydrated: %#v", stored)
 	}
 }
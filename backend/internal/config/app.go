@@ -23,6 +23,7 @@ type AppConfig struct {
 	Features       FeatureFlags               `json:"features"`
 	Optimization   OptimizationConfig         `json:"optimization"` // N+1 Optimization configuration
 	Reconciliation DomainReconciliationConfig `json:"reconciliation"`
+	RDAP           RDAPConfig                 `json:"rdap"`
 	DNSPersonas    []DNSPersona               `json:"dnsPersonas"`
 	HTTPPersonas   []HTTPPersona              `json:"httpPersonas"`
 	Proxies        []ProxyConfigEntry         `json:"proxies"`
@@ -163,6 +164,7 @@ func ConvertJSONToAppConfig(jsonCfg AppConfigJSON) *AppConfig {
 		ProxyManager:   ConvertJSONToProxyManagerConfig(jsonCfg.ProxyManager),
 		Features:       jsonCfg.Features,
 		Reconciliation: ConvertJSONToDomainReconciliationConfig(jsonCfg.Reconciliation),
+		RDAP:           ConvertJSONToRDAPConfig(jsonCfg.RDAP),
 	}
 
 	if appCfg.Server.GinMode == "" {
@@ -193,6 +195,7 @@ func ConvertAppConfigToJSON(appCfg *AppConfig) AppConfigJSON {
 		ProxyManager:   ConvertProxyManagerConfigToJSON(appCfg.ProxyManager),
 		Features:       appCfg.Features,
 		Reconciliation: ConvertDomainReconciliationConfigToJSON(appCfg.Reconciliation),
+		RDAP:           ConvertRDAPConfigToJSON(appCfg.RDAP),
 	}
 }
 
@@ -243,6 +246,10 @@ func ConvertRateLimiterConfigToJSON(cfg RateLimiterConfig) RateLimiterConfig {
 // remain largely the same but ensure they handle their respective defaults correctly.
 
 func ConvertJSONToDNSConfig(jsonCfg DNSValidatorConfigJSON) DNSValidatorConfig {
+	wildcardDetection := DefaultDNSWildcardDetection
+	if jsonCfg.WildcardDetection != nil {
+		wildcardDetection = *jsonCfg.WildcardDetection
+	}
 	cfg := DNSValidatorConfig{
 		Resolvers:                  jsonCfg.Resolvers,
 		UseSystemResolvers:         jsonCfg.UseSystemResolvers,
@@ -257,6 +264,10 @@ func ConvertJSONToDNSConfig(jsonCfg DNSValidatorConfigJSON) DNSValidatorConfig {
 		MaxConcurrentGoroutines:    jsonCfg.MaxConcurrentGoroutines,
 		RateLimitDPS:               jsonCfg.RateLimitDPS,
 		RateLimitBurst:             jsonCfg.RateLimitBurst,
+		WildcardDetection:          wildcardDetection,
+		WildcardProbes:             jsonCfg.WildcardProbes,
+		WildcardCacheTTL:           time.Duration(jsonCfg.WildcardCacheTTLSeconds) * time.Second,
+		SinkholeCIDRs:              jsonCfg.SinkholeCIDRs,
 		QueryTimeoutSeconds:        jsonCfg.QueryTimeoutSeconds,
 		JSONQueryDelayMinMs:        jsonCfg.QueryDelayMinMs,
 		JSONQueryDelayMaxMs:        jsonCfg.QueryDelayMaxMs,
@@ -283,6 +294,12 @@ func ConvertJSONToDNSConfig(jsonCfg DNSValidatorConfigJSON) DNSValidatorConfig {
 	if cfg.RateLimitBurst == 0 && jsonCfg.RateLimitBurst == 0 {
 		cfg.RateLimitBurst = DefaultRateLimitBurst
 	}
+	if cfg.WildcardProbes == 0 {
+		cfg.WildcardProbes = DefaultDNSWildcardProbes
+	}
+	if cfg.WildcardCacheTTL == 0 {
+		cfg.WildcardCacheTTL = time.Duration(DefaultDNSWildcardCacheTTLSeconds) * time.Second
+	}
 	return cfg
 }
 
@@ -301,6 +318,8 @@ func ConvertDNSConfigToJSON(cfg DNSValidatorConfig) DNSValidatorConfigJSON {
 		jsonDelayMaxMs = int(cfg.QueryDelayMax.Milliseconds())
 	}
 
+	wildcardDetection := cfg.WildcardDetection
+
 	return DNSValidatorConfigJSON{
 		Resolvers:                  cfg.Resolvers,
 		UseSystemResolvers:         cfg.UseSystemResolvers,
@@ -315,6 +334,10 @@ func ConvertDNSConfigToJSON(cfg DNSValidatorConfig) DNSValidatorConfigJSON {
 		MaxConcurrentGoroutines:    cfg.MaxConcurrentGoroutines,
 		RateLimitDPS:               cfg.RateLimitDPS,
 		RateLimitBurst:             cfg.RateLimitBurst,
+		WildcardDetection:          &wildcardDetection,
+		WildcardProbes:             cfg.WildcardProbes,
+		WildcardCacheTTLSeconds:    int(cfg.WildcardCacheTTL.Seconds()),
+		SinkholeCIDRs:              cfg.SinkholeCIDRs,
 	}
 }
 
@@ -337,6 +360,8 @@ func ConvertJSONToHTTPConfig(jsonCfg HTTPValidatorConfigJSON) HTTPValidatorConfi
 		RateLimitBurst:          jsonCfg.RateLimitBurst,
 		MaxBodyReadBytes:        jsonCfg.MaxBodyReadBytes,
 		RequestTimeoutSeconds:   jsonCfg.RequestTimeoutSeconds,
+		Headless:                ConvertJSONToHeadlessConfig(jsonCfg.Headless),
+		Robots:                  ConvertJSONToRobotsConfig(jsonCfg.Robots),
 	}
 	if cfg.DefaultUserAgent == "" {
 		cfg.DefaultUserAgent = DefaultHTTPUserAgent
@@ -388,6 +413,8 @@ func ConvertHTTPConfigToJSON(cfg HTTPValidatorConfig) HTTPValidatorConfigJSON {
 		RateLimitDPS:            cfg.RateLimitDPS,
 		RateLimitBurst:          cfg.RateLimitBurst,
 		MaxBodyReadBytes:        cfg.MaxBodyReadBytes,
+		Headless:                ConvertHeadlessConfigToJSON(cfg.Headless),
+		Robots:                  ConvertRobotsConfigToJSON(cfg.Robots),
 	}
 }
 
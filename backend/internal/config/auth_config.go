@@ -17,7 +17,8 @@ type AuthConfig struct {
 	SessionCookieSecure bool          `json:"sessionCookieSecure" mapstructure:"session_cookie_secure"`
 
 	// Token configuration
-	ResetTokenExpiry time.Duration `json:"resetTokenExpiry" mapstructure:"reset_token_expiry"`
+	ResetTokenExpiry        time.Duration `json:"resetTokenExpiry" mapstructure:"reset_token_expiry"`
+	VerificationTokenExpiry time.Duration `json:"verificationTokenExpiry" mapstructure:"verification_token_expiry"`
 
 	// Account lockout
 	MaxFailedAttempts   int           `json:"maxFailedAttempts" mapstructure:"max_failed_attempts"`
@@ -40,6 +41,12 @@ type AuthConfig struct {
 	SMTPPassword string `json:"smtpPassword" mapstructure:"smtp_password"`
 	FromEmail    string `json:"fromEmail" mapstructure:"from_email"`
 	FromName     string `json:"fromName" mapstructure:"from_name"`
+	// Relays on ports other than 465 must offer STARTTLS unless they are on loopback or this is set
+	SMTPAllowInsecure bool `json:"smtpAllowInsecure" mapstructure:"smtp_allow_insecure"`
+	// Without an SMTP host, mail is written to MailOutboxDir as .eml files (or dropped when unset)
+	MailOutboxDir string `json:"mailOutboxDir" mapstructure:"mail_outbox_dir"`
+	// Frontend origin used to build the links in password reset and verification emails
+	AppBaseURL string `json:"appBaseUrl" mapstructure:"app_base_url"`
 }
 
 // GetDefaultAuthConfig returns default authentication configuration
@@ -54,6 +61,7 @@ func GetDefaultAuthConfig() AuthConfig {
 		SessionCookieDomain:      "",
 		SessionCookieSecure:      true,
 		ResetTokenExpiry:         15 * time.Minute,
+		VerificationTokenExpiry:  24 * time.Hour,
 		MaxFailedAttempts:        5,
 		AccountLockDuration:      15 * time.Minute,
 		RateLimitWindow:          15 * time.Minute,
@@ -62,5 +70,6 @@ func GetDefaultAuthConfig() AuthConfig {
 		CaptchaThreshold:         3,
 		SMTPPort:                 587,
 		FromName:                 "DomainFlow",
+		AppBaseURL:               "http://localhost:3000",
 	}
 }
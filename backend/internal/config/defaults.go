@@ -33,6 +33,11 @@ const (
 	DefaultMaxJobRetries               = 3
 	DefaultJobProcessingTimeoutMinutes = 15
 
+	// DNSValidatorConfig wildcard/sinkhole detection defaults
+	DefaultDNSWildcardDetection       = true
+	DefaultDNSWildcardProbes          = 2
+	DefaultDNSWildcardCacheTTLSeconds = 3600
+
 	// HTTPValidatorConfig Defaults
 	DefaultHTTPUserAgent                   = "DomainFlowBot/1.2 (DefaultStudioAgent)"
 	DefaultMaxBodyReadBytes          int64 = 10 * 1024 * 1024 // 10MB
@@ -40,12 +45,31 @@ const (
 	DefaultHTTPRequestTimeoutSeconds       = 15
 	DefaultHTTPMaxRedirects                = 7
 
+	// Headless browser defaults (rendering stays off until enabled)
+	DefaultHeadlessArtifactDir              = "data/headless"
+	DefaultHeadlessNavigationTimeoutSeconds = 30
+	DefaultHeadlessRenderWaitMs             = 1500
+	DefaultHeadlessMaxConcurrentPages       = 4
+	DefaultHeadlessAutoFallback             = true
+
+	// robots.txt compliance defaults
+	DefaultRobotsRespect              = true
+	DefaultRobotsCacheTTLSeconds      = 3600
+	DefaultRobotsMaxCrawlDelaySeconds = 10
+
 	// ProxyManager defaults
 	DefaultProxyTestTimeoutSeconds               = 10
 	DefaultProxyTestURL                          = "https://httpbin.org/ip"
 	DefaultProxyInitialHealthCheckTimeoutSeconds = 7
 	DefaultProxyMaxConcurrentInitialChecks       = 10
 
+	// RDAP lookup defaults (per RDAP server host)
+	DefaultRDAPBootstrapSource   = "https://data.iana.org/rdap/dns.json"
+	DefaultRDAPBootstrapTTLHours = 24
+	DefaultRDAPTimeoutSeconds    = 10
+	DefaultRDAPRequestsPerSecond = 2.0
+	DefaultRDAPBurst             = 2
+
 	// Global API rate limiter defaults
 	DefaultAPIRateLimitWindowSeconds = 900
 	DefaultAPIRateLimitMaxRequests   = 1000
@@ -55,6 +79,7 @@ const (
 // DefaultAppConfigJSON returns the default application configuration as an AppConfigJSON struct.
 func DefaultAppConfigJSON() AppConfigJSON {
 	defaultFollowRedirects := DefaultHTTPFollowRedirects
+	defaultWildcardDetection := DefaultDNSWildcardDetection
 	return AppConfigJSON{
 		Server: ServerConfig{
 			Port:                     "8080",
@@ -87,6 +112,9 @@ func DefaultAppConfigJSON() AppConfigJSON {
 			MaxConcurrentGoroutines:    10,
 			RateLimitDPS:               DefaultRateLimitDPS,
 			RateLimitBurst:             DefaultRateLimitBurst,
+			WildcardDetection:          &defaultWildcardDetection,
+			WildcardProbes:             DefaultDNSWildcardProbes,
+			WildcardCacheTTLSeconds:    DefaultDNSWildcardCacheTTLSeconds,
 		},
 		HTTPValidator: HTTPValidatorConfigJSON{
 			DefaultUserAgent: DefaultHTTPUserAgent,
@@ -107,6 +135,16 @@ func DefaultAppConfigJSON() AppConfigJSON {
 			RateLimitDPS:            DefaultHTTPRateLimitDPS,
 			RateLimitBurst:          DefaultHTTPRateLimitBurst,
 			MaxBodyReadBytes:        DefaultMaxBodyReadBytes,
+			Headless: HeadlessBrowserConfigJSON{
+				ArtifactDir:              DefaultHeadlessArtifactDir,
+				NavigationTimeoutSeconds: DefaultHeadlessNavigationTimeoutSeconds,
+				RenderWaitMs:             DefaultHeadlessRenderWaitMs,
+				MaxConcurrentPages:       DefaultHeadlessMaxConcurrentPages,
+			},
+			Robots: RobotsConfigJSON{
+				CacheTTLSeconds:      DefaultRobotsCacheTTLSeconds,
+				MaxCrawlDelaySeconds: DefaultRobotsMaxCrawlDelaySeconds,
+			},
 		},
 		Logging: LoggingConfig{
 			Level:       "INFO",
@@ -135,6 +173,13 @@ func DefaultAppConfigJSON() AppConfigJSON {
 			AutoCorrect:          false,
 			MaxCorrectionsPerRun: 50,
 		},
+		RDAP: RDAPConfigJSON{
+			BootstrapSource:   DefaultRDAPBootstrapSource,
+			BootstrapTTLHours: DefaultRDAPBootstrapTTLHours,
+			TimeoutSeconds:    DefaultRDAPTimeoutSeconds,
+			RequestsPerSecond: DefaultRDAPRequestsPerSecond,
+			Burst:             DefaultRDAPBurst,
+		},
 	}
 }
 
@@ -144,6 +144,15 @@ func applyEnvironmentOverrides(config *AppConfig) {
 	if httpTimeout := getEnvAsInt("HTTP_TIMEOUT_SECONDS", 0); httpTimeout > 0 {
 		config.HTTPValidator.RequestTimeoutSeconds = httpTimeout
 	}
+	if headless, err := strconv.ParseBool(os.Getenv("HTTP_HEADLESS_ENABLED")); err == nil {
+		config.HTTPValidator.Headless.Enabled = headless
+	}
+	if chromium := os.Getenv("HTTP_HEADLESS_CHROMIUM_PATH"); chromium != "" {
+		config.HTTPValidator.Headless.ChromiumPath = chromium
+	}
+	if respect, err := strconv.ParseBool(os.Getenv("HTTP_ROBOTS_RESPECT")); err == nil {
+		config.HTTPValidator.Robots.Respect = respect
+	}
 
 	// Global API rate limiter overrides
 	if rlWindow := getEnvAsInt("API_RATE_LIMIT_WINDOW", 0); rlWindow > 0 {
@@ -152,6 +161,35 @@ func applyEnvironmentOverrides(config *AppConfig) {
 	if rlMax := getEnvAsInt("API_RATE_LIMIT_MAX_REQUESTS", 0); rlMax > 0 {
 		config.RateLimiter.MaxRequests = rlMax
 	}
+
+	// Account email overrides (SMTP credentials are best kept out of config.json)
+	mailEnv := map[string]func(*AuthConfig, string){
+		"SMTP_HOST": func(a *AuthConfig, v string) { a.SMTPHost = v },
+		"SMTP_PORT": func(a *AuthConfig, v string) {
+			if port, err := strconv.Atoi(v); err == nil && port > 0 {
+				a.SMTPPort = port
+			}
+		},
+		"SMTP_ALLOW_INSECURE": func(a *AuthConfig, v string) {
+			if allow, err := strconv.ParseBool(v); err == nil {
+				a.SMTPAllowInsecure = allow
+			}
+		},
+		"SMTP_USERNAME":   func(a *AuthConfig, v string) { a.SMTPUsername = v },
+		"SMTP_PASSWORD":   func(a *AuthConfig, v string) { a.SMTPPassword = v },
+		"MAIL_FROM_EMAIL": func(a *AuthConfig, v string) { a.FromEmail = v },
+		"MAIL_OUTBOX_DIR": func(a *AuthConfig, v string) { a.MailOutboxDir = v },
+		"APP_BASE_URL":    func(a *AuthConfig, v string) { a.AppBaseURL = v },
+	}
+	for key, apply := range mailEnv {
+		if v := os.Getenv(key); v != "" {
+			if config.Server.AuthConfig == nil {
+				auth := GetDefaultAuthConfig()
+				config.Server.AuthConfig = &auth
+			}
+			apply(config.Server.AuthConfig, v)
+		}
+	}
 }
 
 // Helper functions
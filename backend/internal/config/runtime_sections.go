@@ -0,0 +1,215 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+)
+
+// Runtime sections are the parts of AppConfig that admins change through the /config endpoints.
+// Each is versioned separately, so edits to different sections never conflict.
+const (
+	SectionAuthentication = "authentication"
+	SectionDNSValidator   = "dns_validator"
+	SectionHTTPValidator  = "http_validator"
+	SectionLogging        = "logging"
+	SectionRateLimiter    = "rate_limiter"
+	SectionServer         = "server"
+	SectionWorker         = "worker"
+	SectionProxyManager   = "proxy_manager"
+	SectionStealth        = "stealth"
+)
+
+// RuntimeSections lists every versioned config section.
+func RuntimeSections() []string {
+	return []string{SectionAuthentication, SectionDNSValidator, SectionHTTPValidator, SectionLogging, SectionRateLimiter,
+		SectionServer, SectionWorker, SectionProxyManager, SectionStealth}
+}
+
+// ValidRuntimeSection reports whether name is a versioned config section.
+func ValidRuntimeSection(name string) bool {
+	for _, s := range RuntimeSections() {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// runtimeAuthSettings is the API-editable part of AuthConfig. SMTP credentials and the other
+// deployment settings stay in the config file and environment.
+type runtimeAuthSettings struct {
+	PasswordMinLength         int   `json:"passwordMinLength"`
+	SessionDurationSeconds    int64 `json:"sessionDurationSeconds"`
+	SessionIdleTimeoutSeconds int64 `json:"sessionIdleTimeoutSeconds"`
+}
+
+// runtimeServerSettings is the API-editable part of ServerConfig.
+type runtimeServerSettings struct {
+	StreamChunkSize int    `json:"streamChunkSize"`
+	GinMode         string `json:"ginMode"`
+}
+
+type runtimeStealthSettings struct {
+	Enabled bool `json:"enabled"`
+}
+
+// SnapshotSection returns the stored form of one runtime section of cfg.
+func SnapshotSection(cfg *AppConfig, section string) (json.RawMessage, error) {
+	var v interface{}
+	switch section {
+	case SectionAuthentication:
+		auth := GetDefaultAuthConfig()
+		if cfg.Server.AuthConfig != nil {
+			auth = *cfg.Server.AuthConfig
+		}
+		v = runtimeAuthSettings{
+			PasswordMinLength:         auth.PasswordMinLength,
+			SessionDurationSeconds:    int64(auth.SessionDuration / time.Second),
+			SessionIdleTimeoutSeconds: int64(auth.SessionIdleTimeout / time.Second),
+		}
+	case SectionDNSValidator:
+		v = ConvertDNSConfigToJSON(cfg.DNSValidator)
+	case SectionHTTPValidator:
+		v = ConvertHTTPConfigToJSON(cfg.HTTPValidator)
+	case SectionLogging:
+		v = cfg.Logging
+	case SectionRateLimiter:
+		v = ConvertRateLimiterConfigToJSON(cfg.RateLimiter)
+	case SectionServer:
+		v = runtimeServerSettings{StreamChunkSize: cfg.Server.StreamChunkSize, GinMode: cfg.Server.GinMode}
+	case SectionWorker:
+		v = ConvertWorkerConfigToJSON(cfg.Worker)
+	case SectionProxyManager:
+		v = ConvertProxyManagerConfigToJSON(cfg.ProxyManager)
+	case SectionStealth:
+		v = runtimeStealthSettings{Enabled: cfg.Features.EnableStealth}
+	default:
+		return nil, fmt.Errorf("unknown config section %q", section)
+	}
+	return json.Marshal(v)
+}
+
+// RestoreSection replaces one runtime section of cfg with a value produced by SnapshotSection.
+func RestoreSection(cfg *AppConfig, section string, raw json.RawMessage) error {
+	var err error
+	switch section {
+	case SectionAuthentication:
+		var s runtimeAuthSettings
+		if err = json.Unmarshal(raw, &s); err == nil {
+			if cfg.Server.AuthConfig == nil {
+				auth := GetDefaultAuthConfig()
+				cfg.Server.AuthConfig = &auth
+			}
+			cfg.Server.AuthConfig.PasswordMinLength = s.PasswordMinLength
+			cfg.Server.AuthConfig.SessionDuration = time.Duration(s.SessionDurationSeconds) * time.Second
+			cfg.Server.AuthConfig.SessionIdleTimeout = time.Duration(s.SessionIdleTimeoutSeconds) * time.Second
+		}
+	case SectionDNSValidator:
+		var j DNSValidatorConfigJSON
+		if err = json.Unmarshal(raw, &j); err == nil {
+			cfg.DNSValidator = ConvertJSONToDNSConfig(j)
+		}
+	case SectionHTTPValidator:
+		var j HTTPValidatorConfigJSON
+		if err = json.Unmarshal(raw, &j); err == nil {
+			cfg.HTTPValidator = ConvertJSONToHTTPConfig(j)
+		}
+	case SectionLogging:
+		var l LoggingConfig
+		if err = json.Unmarshal(raw, &l); err == nil {
+			cfg.Logging = l
+		}
+	case SectionRateLimiter:
+		var r RateLimiterConfig
+		if err = json.Unmarshal(raw, &r); err == nil {
+			cfg.RateLimiter = ConvertJSONToRateLimiterConfig(r)
+		}
+	case SectionServer:
+		var s runtimeServerSettings
+		if err = json.Unmarshal(raw, &s); err == nil {
+			cfg.Server.StreamChunkSize = s.StreamChunkSize
+			cfg.Server.GinMode = s.GinMode
+		}
+	case SectionWorker:
+		var w WorkerConfig
+		if err = json.Unmarshal(raw, &w); err == nil {
+			cfg.Worker = ConvertJSONToWorkerConfig(w)
+		}
+	case SectionProxyManager:
+		var j ProxyManagerConfigJSON
+		if err = json.Unmarshal(raw, &j); err == nil {
+			cfg.ProxyManager = ConvertJSONToProxyManagerConfig(j)
+		}
+	case SectionStealth:
+		var s runtimeStealthSettings
+		if err = json.Unmarshal(raw, &s); err == nil {
+			cfg.Features.EnableStealth = s.Enabled
+		}
+	default:
+		return fmt.Errorf("unknown config section %q", section)
+	}
+	if err != nil {
+		return fmt.Errorf("config section %s: %w", section, err)
+	}
+	return nil
+}
+
+// RuntimeCopy returns a copy of ac whose runtime sections can be changed without affecting ac.
+func (ac *AppConfig) RuntimeCopy() *AppConfig {
+	c := *ac
+	if ac.Server.AuthConfig != nil {
+		auth := *ac.Server.AuthConfig
+		c.Server.AuthConfig = &auth
+	}
+	return &c
+}
+
+// DiffSection lists the fields that differ between two snapshots of a section, sorted by path.
+// Objects are compared field by field; arrays and scalars are compared whole. A nil before
+// reports every field as added.
+func DiffSection(before, after json.RawMessage) ([]models.ConfigFieldChange, error) {
+	var b, a interface{}
+	if len(before) > 0 {
+		if err := json.Unmarshal(before, &b); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(after, &a); err != nil {
+		return nil, err
+	}
+	changes := []models.ConfigFieldChange{}
+	diffValues("", b, a, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+func diffValues(path string, before, after interface{}, changes *[]models.ConfigFieldChange) {
+	bm, bok := before.(map[string]interface{})
+	am, aok := after.(map[string]interface{})
+	if (bok || before == nil) && (aok || after == nil) && (bok || aok) {
+		for k, av := range am {
+			diffValues(joinPath(path, k), bm[k], av, changes)
+		}
+		for k, bv := range bm {
+			if _, ok := am[k]; !ok {
+				diffValues(joinPath(path, k), bv, nil, changes)
+			}
+		}
+		return
+	}
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, models.ConfigFieldChange{Path: path, Before: before, After: after})
+	}
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
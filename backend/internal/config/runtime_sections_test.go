@@ -0,0 +1,68 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRuntimeSectionsRoundTrip(t *testing.T) {
+	src := DefaultConfig()
+	src.Worker.NumWorkers = 17
+	src.DNSValidator.Resolvers = []string{"9.9.9.9:53"}
+	src.Server.GinMode = "release"
+	src.Features.EnableStealth = true
+	for _, section := range RuntimeSections() {
+		raw, err := SnapshotSection(src, section)
+		if err != nil {
+			t.Fatalf("%s: snapshot: %v", section, err)
+		}
+		dst := DefaultConfig()
+		if err := RestoreSection(dst, section, raw); err != nil {
+			t.Fatalf("%s: restore: %v", section, err)
+		}
+		again, _ := SnapshotSection(dst, section)
+		if string(again) != string(raw) {
+			t.Fatalf("%s: round trip changed the section\nbefore: %s\nafter:  %s", section, raw, again)
+		}
+	}
+	if err := RestoreSection(DefaultConfig(), "database", json.RawMessage(`{}`)); err == nil {
+		t.Fatal("expected an error for a section that is not versioned")
+	}
+}
+
+func TestRuntimeCopyLeavesOriginalUntouched(t *testing.T) {
+	orig := DefaultConfig()
+	auth := GetDefaultAuthConfig()
+	orig.Server.AuthConfig = &auth
+	c := orig.RuntimeCopy()
+	c.Server.AuthConfig.PasswordMinLength = 99
+	c.Worker.NumWorkers = 99
+	if orig.Server.AuthConfig.PasswordMinLength == 99 || orig.Worker.NumWorkers == 99 {
+		t.Fatal("changing the copy changed the original")
+	}
+}
+
+func TestDiffSection(t *testing.T) {
+	changes, err := DiffSection(json.RawMessage(`{"a":1,"nested":{"b":"x","gone":true},"list":[1,2]}`),
+		json.RawMessage(`{"a":1,"nested":{"b":"y"},"list":[1,2,3],"added":false}`))
+	if err != nil {
+		t.Fatalf("DiffSection: %v", err)
+	}
+	want := []string{"added", "list", "nested.b", "nested.gone"}
+	if len(changes) != len(want) {
+		t.Fatalf("got %+v, want paths %v", changes, want)
+	}
+	for i, c := range changes {
+		if c.Path != want[i] {
+			t.Fatalf("change %d path = %q, want %q", i, c.Path, want[i])
+		}
+	}
+	if changes[2].Before != "x" || changes[2].After != "y" || changes[3].After != nil {
+		t.Fatalf("unexpected values %+v", changes)
+	}
+
+	initial, _ := DiffSection(nil, json.RawMessage(`{"a":1}`))
+	if len(initial) != 1 || initial[0].Path != "a" || initial[0].Before != nil {
+		t.Fatalf("unexpected initial diff %+v", initial)
+	}
+}
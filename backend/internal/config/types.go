@@ -94,9 +94,14 @@ type DNSValidatorConfig struct {
 	MaxConcurrentGoroutines    int
 	RateLimitDPS               float64
 	RateLimitBurst             int
-	QueryTimeoutSeconds        int `json:"-"`
-	JSONQueryDelayMinMs        int `json:"-"`
-	JSONQueryDelayMaxMs        int `json:"-"`
+	WildcardDetection          bool          // probe random labels per zone and flag domains answering like the wildcard
+	WildcardProbes             int           // nonexistent labels probed per zone
+	WildcardCacheTTL           time.Duration // how long a zone's wildcard answer set is reused
+	SinkholeCIDRs              []string      // known parking/sinkhole ranges; answers inside them are flagged
+	RecordTypes                []string      // record types queried per domain; empty means A and AAAA
+	QueryTimeoutSeconds        int           `json:"-"`
+	JSONQueryDelayMinMs        int           `json:"-"`
+	JSONQueryDelayMaxMs        int           `json:"-"`
 }
 
 // DNSValidatorConfigJSON is used for marshalling/unmarshalling DNSValidator settings.
@@ -114,6 +119,10 @@ type DNSValidatorConfigJSON struct {
 	MaxConcurrentGoroutines    int            `json:"maxConcurrentGoroutines,omitempty"`
 	RateLimitDPS               float64        `json:"rateLimitDps,omitempty"`
 	RateLimitBurst             int            `json:"rateLimitBurst,omitempty"`
+	WildcardDetection          *bool          `json:"wildcardDetection,omitempty"`
+	WildcardProbes             int            `json:"wildcardProbes,omitempty"`
+	WildcardCacheTTLSeconds    int            `json:"wildcardCacheTtlSeconds,omitempty"`
+	SinkholeCIDRs              []string       `json:"sinkholeCidrs,omitempty"`
 }
 
 // HTTPValidatorConfig holds the effective configuration for HTTPValidator.
@@ -131,22 +140,144 @@ type HTTPValidatorConfig struct {
 	RateLimitBurst          int
 	MaxBodyReadBytes        int64
 	RequestTimeoutSeconds   int `json:"-"`
+	Headless                HeadlessBrowserConfig
+	Robots                  RobotsConfig
 }
 
 // HTTPValidatorConfigJSON is used for marshalling/unmarshalling HTTPValidator settings.
 type HTTPValidatorConfigJSON struct {
-	DefaultUserAgent        string            `json:"defaultUserAgent,omitempty"`
-	UserAgents              []string          `json:"userAgents,omitempty"`
-	DefaultHeaders          map[string]string `json:"defaultHeaders,omitempty"`
-	RequestTimeoutSeconds   int               `json:"requestTimeoutSeconds,omitempty"`
-	MaxRedirects            int               `json:"maxRedirects,omitempty"`
-	FollowRedirects         *bool             `json:"followRedirects,omitempty"`
-	MaxDomainsPerRequest    int               `json:"maxDomainsPerRequest,omitempty"`
-	AllowInsecureTLS        bool              `json:"allowInsecureTLS"`
-	MaxConcurrentGoroutines int               `json:"maxConcurrentGoroutines,omitempty"`
-	RateLimitDPS            float64           `json:"rateLimitDps,omitempty"`
-	RateLimitBurst          int               `json:"rateLimitBurst,omitempty"`
-	MaxBodyReadBytes        int64             `json:"maxBodyReadBytes,omitempty"`
+	DefaultUserAgent        string                    `json:"defaultUserAgent,omitempty"`
+	UserAgents              []string                  `json:"userAgents,omitempty"`
+	DefaultHeaders          map[string]string         `json:"defaultHeaders,omitempty"`
+	RequestTimeoutSeconds   int                       `json:"requestTimeoutSeconds,omitempty"`
+	MaxRedirects            int                       `json:"maxRedirects,omitempty"`
+	FollowRedirects         *bool                     `json:"followRedirects,omitempty"`
+	MaxDomainsPerRequest    int                       `json:"maxDomainsPerRequest,omitempty"`
+	AllowInsecureTLS        bool                      `json:"allowInsecureTLS"`
+	MaxConcurrentGoroutines int                       `json:"maxConcurrentGoroutines,omitempty"`
+	RateLimitDPS            float64                   `json:"rateLimitDps,omitempty"`
+	RateLimitBurst          int                       `json:"rateLimitBurst,omitempty"`
+	MaxBodyReadBytes        int64                     `json:"maxBodyReadBytes,omitempty"`
+	Headless                HeadlessBrowserConfigJSON `json:"headless"`
+	Robots                  RobotsConfigJSON          `json:"robots"`
+}
+
+// HeadlessBrowserConfig controls rendering JavaScript-heavy sites in a local Chromium driven over the
+// Chrome DevTools Protocol. Personas choose per fetch whether to render (see models.HTTPRenderMode*).
+type HeadlessBrowserConfig struct {
+	Enabled            bool
+	ChromiumPath       string // Empty searches PATH for chromium, chromium-browser and google-chrome
+	ArtifactDir        string // Screenshots and DOM snapshots are written below this directory
+	NavigationTimeout  time.Duration
+	RenderWait         time.Duration // Settle time after the load event so client-side rendering can finish
+	MaxConcurrentPages int
+	AutoFallback       bool // Personas without a render mode render pages whose static fetch looks like an app shell
+	Screenshots        bool // Capture a PNG screenshot unless the persona overrides it
+	SaveDOMSnapshots   bool // Write the rendered DOM next to the screenshot
+}
+
+// HeadlessBrowserConfigJSON is JSON representation.
+type HeadlessBrowserConfigJSON struct {
+	Enabled                  bool   `json:"enabled"`
+	ChromiumPath             string `json:"chromiumPath,omitempty"`
+	ArtifactDir              string `json:"artifactDir,omitempty"`
+	NavigationTimeoutSeconds int    `json:"navigationTimeoutSeconds,omitempty"`
+	RenderWaitMs             int    `json:"renderWaitMs,omitempty"`
+	MaxConcurrentPages       int    `json:"maxConcurrentPages,omitempty"`
+	AutoFallback             *bool  `json:"autoFallback,omitempty"`
+	Screenshots              bool   `json:"screenshots"`
+	SaveDOMSnapshots         bool   `json:"saveDomSnapshots"`
+}
+
+func ConvertJSONToHeadlessConfig(j HeadlessBrowserConfigJSON) HeadlessBrowserConfig {
+	cfg := HeadlessBrowserConfig{
+		Enabled:            j.Enabled,
+		ChromiumPath:       j.ChromiumPath,
+		ArtifactDir:        j.ArtifactDir,
+		NavigationTimeout:  time.Duration(j.NavigationTimeoutSeconds) * time.Second,
+		RenderWait:         time.Duration(j.RenderWaitMs) * time.Millisecond,
+		MaxConcurrentPages: j.MaxConcurrentPages,
+		AutoFallback:       DefaultHeadlessAutoFallback,
+		Screenshots:        j.Screenshots,
+		SaveDOMSnapshots:   j.SaveDOMSnapshots,
+	}
+	if j.AutoFallback != nil {
+		cfg.AutoFallback = *j.AutoFallback
+	}
+	if cfg.ArtifactDir == "" {
+		cfg.ArtifactDir = DefaultHeadlessArtifactDir
+	}
+	if cfg.NavigationTimeout <= 0 {
+		cfg.NavigationTimeout = DefaultHeadlessNavigationTimeoutSeconds * time.Second
+	}
+	if j.RenderWaitMs <= 0 {
+		cfg.RenderWait = DefaultHeadlessRenderWaitMs * time.Millisecond
+	}
+	if cfg.MaxConcurrentPages <= 0 {
+		cfg.MaxConcurrentPages = DefaultHeadlessMaxConcurrentPages
+	}
+	return cfg
+}
+
+func ConvertHeadlessConfigToJSON(c HeadlessBrowserConfig) HeadlessBrowserConfigJSON {
+	autoFallback := c.AutoFallback
+	return HeadlessBrowserConfigJSON{
+		Enabled:                  c.Enabled,
+		ChromiumPath:             c.ChromiumPath,
+		ArtifactDir:              c.ArtifactDir,
+		NavigationTimeoutSeconds: int(c.NavigationTimeout / time.Second),
+		RenderWaitMs:             int(c.RenderWait / time.Millisecond),
+		MaxConcurrentPages:       c.MaxConcurrentPages,
+		AutoFallback:             &autoFallback,
+		Screenshots:              c.Screenshots,
+		SaveDOMSnapshots:         c.SaveDOMSnapshots,
+	}
+}
+
+// RobotsConfig controls robots.txt compliance. Campaigns can override Respect and EnforceOnRootFetch
+// in their HTTP phase configuration.
+type RobotsConfig struct {
+	Respect            bool // Skip micro-crawl pages disallowed for the persona's user agent
+	EnforceOnRootFetch bool // Also skip root page fetches that robots.txt disallows
+	CacheTTL           time.Duration
+	MaxCrawlDelay      time.Duration // Upper bound on the Crawl-delay honoured between fetches to one host
+}
+
+// RobotsConfigJSON is JSON representation.
+type RobotsConfigJSON struct {
+	Respect              *bool `json:"respect,omitempty"`
+	EnforceOnRootFetch   bool  `json:"enforceOnRootFetch"`
+	CacheTTLSeconds      int   `json:"cacheTtlSeconds,omitempty"`
+	MaxCrawlDelaySeconds int   `json:"maxCrawlDelaySeconds,omitempty"`
+}
+
+func ConvertJSONToRobotsConfig(j RobotsConfigJSON) RobotsConfig {
+	cfg := RobotsConfig{
+		Respect:            DefaultRobotsRespect,
+		EnforceOnRootFetch: j.EnforceOnRootFetch,
+		CacheTTL:           time.Duration(j.CacheTTLSeconds) * time.Second,
+		MaxCrawlDelay:      time.Duration(j.MaxCrawlDelaySeconds) * time.Second,
+	}
+	if j.Respect != nil {
+		cfg.Respect = *j.Respect
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = DefaultRobotsCacheTTLSeconds * time.Second
+	}
+	if cfg.MaxCrawlDelay <= 0 {
+		cfg.MaxCrawlDelay = DefaultRobotsMaxCrawlDelaySeconds * time.Second
+	}
+	return cfg
+}
+
+func ConvertRobotsConfigToJSON(c RobotsConfig) RobotsConfigJSON {
+	respect := c.Respect
+	return RobotsConfigJSON{
+		Respect:              &respect,
+		EnforceOnRootFetch:   c.EnforceOnRootFetch,
+		CacheTTLSeconds:      int(c.CacheTTL / time.Second),
+		MaxCrawlDelaySeconds: int(c.MaxCrawlDelay / time.Second),
+	}
 }
 
 // ProxyManagerConfig holds settings for proxy health checks.
@@ -179,6 +310,7 @@ type AppConfigJSON struct {
 	ProxyManager   ProxyManagerConfigJSON         `json:"proxyManager"`
 	Features       FeatureFlags                   `json:"features"`
 	Reconciliation DomainReconciliationConfigJSON `json:"reconciliation"`
+	RDAP           RDAPConfigJSON                 `json:"rdap,omitempty"`
 }
 
 // DomainReconciliationConfig controls the nightly domain counters reconciliation job.
@@ -228,3 +360,45 @@ func ConvertDomainReconciliationConfigToJSON(c DomainReconciliationConfig) Domai
 		MaxCorrectionsPerRun: c.MaxCorrectionsPerRun,
 	}
 }
+
+// RDAPConfig controls the RDAP client used by the optional rdap_lookup phase.
+type RDAPConfig struct {
+	BootstrapSource   string
+	BootstrapTTLHours int
+	TimeoutSeconds    int
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// RDAPConfigJSON is JSON representation.
+type RDAPConfigJSON struct {
+	BootstrapSource   string  `json:"bootstrapSource,omitempty"`
+	BootstrapTTLHours int     `json:"bootstrapTtlHours,omitempty"`
+	TimeoutSeconds    int     `json:"timeoutSeconds,omitempty"`
+	RequestsPerSecond float64 `json:"requestsPerSecond,omitempty"`
+	Burst             int     `json:"burst,omitempty"`
+}
+
+func ConvertJSONToRDAPConfig(j RDAPConfigJSON) RDAPConfig {
+	cfg := RDAPConfig(j)
+	if cfg.BootstrapSource == "" {
+		cfg.BootstrapSource = DefaultRDAPBootstrapSource
+	}
+	if cfg.BootstrapTTLHours <= 0 {
+		cfg.BootstrapTTLHours = DefaultRDAPBootstrapTTLHours
+	}
+	if cfg.TimeoutSeconds <= 0 {
+		cfg.TimeoutSeconds = DefaultRDAPTimeoutSeconds
+	}
+	if cfg.RequestsPerSecond <= 0 {
+		cfg.RequestsPerSecond = DefaultRDAPRequestsPerSecond
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = DefaultRDAPBurst
+	}
+	return cfg
+}
+
+func ConvertRDAPConfigToJSON(c RDAPConfig) RDAPConfigJSON {
+	return RDAPConfigJSON(c)
+}
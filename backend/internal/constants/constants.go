@@ -21,6 +21,7 @@ const (
 	DNSStatusResolved  = "resolved"
 	DNSStatusTimeout   = "timeout"
 	DNSStatusError     = "error"
+	DNSStatusWildcard  = "wildcard" // resolved only via a zone wildcard or into a sinkhole range
 )
 
 // Go type constants
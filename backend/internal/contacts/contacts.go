@@ -0,0 +1,383 @@
+// Package contacts extracts lead data from crawled HTML: email addresses, phone numbers normalised to
+// E.164, postal addresses, social profile links and schema.org Organization/LocalBusiness JSON-LD.
+package contacts
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// maxPerKind caps each list so a directory page cannot blow up a domain's record.
+const maxPerKind = 20
+
+// Set is everything found for one domain. Every entry keeps the URL of the page it was first seen on.
+type Set struct {
+	Emails         []Email         `json:"emails,omitempty"`
+	Phones         []Phone         `json:"phones,omitempty"`
+	Addresses      []Address       `json:"addresses,omitempty"`
+	SocialProfiles []SocialProfile `json:"socialProfiles,omitempty"`
+	Organizations  []Organization  `json:"organizations,omitempty"`
+}
+
+type Email struct {
+	Address   string `json:"address"`
+	SourceURL string `json:"sourceUrl"`
+}
+
+type Phone struct {
+	E164      string `json:"e164"`
+	Raw       string `json:"raw"`
+	SourceURL string `json:"sourceUrl"`
+}
+
+// Address is a postal address. Structured fields are only set for schema.org PostalAddress values;
+// Text always holds a single-line rendering.
+type Address struct {
+	Street     string `json:"street,omitempty"`
+	Locality   string `json:"locality,omitempty"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postalCode,omitempty"`
+	Country    string `json:"country,omitempty"`
+	Text       string `json:"text"`
+	SourceURL  string `json:"sourceUrl"`
+}
+
+type SocialProfile struct {
+	Network   string `json:"network"`
+	URL       string `json:"url"`
+	SourceURL string `json:"sourceUrl"`
+}
+
+// Organization is a schema.org Organization or LocalBusiness (including subtypes) from JSON-LD.
+type Organization struct {
+	Type      string   `json:"type"`
+	Name      string   `json:"name,omitempty"`
+	URL       string   `json:"url,omitempty"`
+	Email     string   `json:"email,omitempty"`
+	Telephone string   `json:"telephone,omitempty"`
+	Address   *Address `json:"address,omitempty"`
+	SameAs    []string `json:"sameAs,omitempty"`
+	SourceURL string   `json:"sourceUrl"`
+}
+
+// Empty reports whether nothing was found.
+func (s *Set) Empty() bool {
+	return s == nil || len(s.Emails)+len(s.Phones)+len(s.Addresses)+len(s.SocialProfiles)+len(s.Organizations) == 0
+}
+
+// Merge adds other's entries that s does not have yet, keeping the first source URL for duplicates.
+func (s *Set) Merge(other *Set) {
+	if other == nil {
+		return
+	}
+	for _, e := range other.Emails {
+		s.addEmail(e)
+	}
+	for _, p := range other.Phones {
+		s.addPhone(p)
+	}
+	for _, a := range other.Addresses {
+		s.addAddress(a)
+	}
+	for _, p := range other.SocialProfiles {
+		s.addSocial(p)
+	}
+	for _, o := range other.Organizations {
+		s.addOrganization(o)
+	}
+}
+
+func (s *Set) addEmail(e Email) {
+	if len(s.Emails) >= maxPerKind {
+		return
+	}
+	for _, have := range s.Emails {
+		if have.Address == e.Address {
+			return
+		}
+	}
+	s.Emails = append(s.Emails, e)
+}
+
+func (s *Set) addPhone(p Phone) {
+	if len(s.Phones) >= maxPerKind {
+		return
+	}
+	for _, have := range s.Phones {
+		if have.E164 == p.E164 {
+			return
+		}
+	}
+	s.Phones = append(s.Phones, p)
+}
+
+func (s *Set) addAddress(a Address) {
+	if len(s.Addresses) >= maxPerKind {
+		return
+	}
+	key := strings.ToLower(a.Text)
+	for i, have := range s.Addresses {
+		if strings.ToLower(have.Text) == key {
+			// Prefer the structured form when the same address shows up both ways
+			if have.PostalCode == "" && a.PostalCode != "" {
+				s.Addresses[i] = a
+			}
+			return
+		}
+	}
+	s.Addresses = append(s.Addresses, a)
+}
+
+func (s *Set) addSocial(p SocialProfile) {
+	if len(s.SocialProfiles) >= maxPerKind {
+		return
+	}
+	for _, have := range s.SocialProfiles {
+		if strings.EqualFold(have.URL, p.URL) {
+			return
+		}
+	}
+	s.SocialProfiles = append(s.SocialProfiles, p)
+}
+
+func (s *Set) addOrganization(o Organization) {
+	if len(s.Organizations) >= maxPerKind {
+		return
+	}
+	for _, have := range s.Organizations {
+		if have.Type == o.Type && strings.EqualFold(have.Name, o.Name) && have.URL == o.URL {
+			return
+		}
+	}
+	s.Organizations = append(s.Organizations, o)
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,24}`)
+	// Free-text numbers are only trusted in international format; national ones come from tel: links or JSON-LD.
+	intlPhonePattern = regexp.MustCompile(`\+\d[\d\s().-]{6,20}\d`)
+	whitespace       = regexp.MustCompile(`\s+`)
+)
+
+// Extract parses one HTML page. region is the ISO 3166 country used to normalise national phone
+// numbers (see RegionForHost); pageURL is recorded as the source of everything found.
+func Extract(body []byte, pageURL, region string) *Set {
+	set := &Set{}
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return set
+	}
+	base, _ := url.Parse(pageURL)
+
+	var text strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script":
+				if strings.EqualFold(strings.TrimSpace(attr(n, "type")), "application/ld+json") && n.FirstChild != nil {
+					extractJSONLD(set, []byte(n.FirstChild.Data), pageURL, region)
+				}
+				return
+			case "style", "noscript", "template":
+				return
+			case "a":
+				extractLink(set, attr(n, "href"), base, pageURL, region)
+			case "address":
+				if t := collapse(nodeText(n)); t != "" && len(t) <= 300 {
+					set.addAddress(Address{Text: t, SourceURL: pageURL})
+				}
+			}
+		}
+		if n.Type == html.TextNode {
+			text.WriteString(n.Data)
+			text.WriteByte(' ')
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	visible := text.String()
+	for _, m := range emailPattern.FindAllString(visible, -1) {
+		if e, ok := normalizeEmail(m); ok {
+			set.addEmail(Email{Address: e, SourceURL: pageURL})
+		}
+	}
+	for _, m := range intlPhonePattern.FindAllString(visible, -1) {
+		if e164, ok := NormalizePhone(m, region); ok {
+			set.addPhone(Phone{E164: e164, Raw: strings.TrimSpace(m), SourceURL: pageURL})
+		}
+	}
+	return set
+}
+
+func extractLink(set *Set, href string, base *url.URL, pageURL, region string) {
+	href = strings.TrimSpace(href)
+	lower := strings.ToLower(href)
+	switch {
+	case strings.HasPrefix(lower, "mailto:"):
+		addr, _ := url.PathUnescape(strings.SplitN(href[len("mailto:"):], "?", 2)[0])
+		for _, a := range strings.Split(addr, ",") {
+			if e, ok := normalizeEmail(a); ok {
+				set.addEmail(Email{Address: e, SourceURL: pageURL})
+			}
+		}
+	case strings.HasPrefix(lower, "tel:"):
+		raw, _ := url.PathUnescape(href[len("tel:"):])
+		if e164, ok := NormalizePhone(raw, region); ok {
+			set.addPhone(Phone{E164: e164, Raw: raw, SourceURL: pageURL})
+		}
+	default:
+		u, err := url.Parse(href)
+		if err != nil {
+			return
+		}
+		if base != nil {
+			u = base.ResolveReference(u)
+		}
+		if network, profile, ok := socialProfile(u); ok {
+			set.addSocial(SocialProfile{Network: network, URL: profile, SourceURL: pageURL})
+		}
+	}
+}
+
+// ignoredEmailDomains are placeholders and tracking endpoints that show up in page markup.
+var ignoredEmailDomains = map[string]bool{
+	"example.com": true, "example.org": true, "example.net": true, "domain.com": true, "email.com": true,
+	"yourdomain.com": true, "sentry.io": true, "wixpress.com": true, "sentry.wixpress.com": true,
+}
+
+var imageSuffixes = []string{".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp"}
+
+func normalizeEmail(raw string) (string, bool) {
+	e := strings.ToLower(strings.Trim(strings.TrimSpace(raw), ".,;:<>()[]\"'"))
+	if !emailPattern.MatchString(e) || emailPattern.FindString(e) != e {
+		return "", false
+	}
+	for _, suf := range imageSuffixes {
+		if strings.HasSuffix(e, suf) { // retina asset names like logo@2x.png
+			return "", false
+		}
+	}
+	domain := e[strings.LastIndexByte(e, '@')+1:]
+	if ignoredEmailDomains[domain] {
+		return "", false
+	}
+	return e, true
+}
+
+// socialNetworks maps profile hosts to network names.
+var socialNetworks = map[string]string{
+	"facebook.com": "facebook", "fb.com": "facebook",
+	"twitter.com": "twitter", "x.com": "twitter",
+	"linkedin.com":  "linkedin",
+	"instagram.com": "instagram",
+	"youtube.com":   "youtube",
+	"tiktok.com":    "tiktok",
+	"pinterest.com": "pinterest",
+	"github.com":    "github",
+}
+
+// sharePaths are sharing widgets and other links that are not profiles.
+var sharePaths = []string{"/sharer", "/share", "/dialog", "/plugins", "/intent", "/home", "/sharearticle", "/sharing", "/pin/create", "/watch", "/embed", "/hashtag", "/search", "/login", "/signup"}
+
+func socialProfile(u *url.URL) (network, profile string, ok bool) {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", "", false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, prefix := range []string{"www.", "m.", "mobile.", "business."} {
+		host = strings.TrimPrefix(host, prefix)
+	}
+	if strings.HasSuffix(host, ".linkedin.com") {
+		host = "linkedin.com"
+	}
+	network, ok = socialNetworks[host]
+	if !ok {
+		return "", "", false
+	}
+	p := strings.TrimRight(u.EscapedPath(), "/")
+	if p == "" {
+		return "", "", false
+	}
+	lp := strings.ToLower(p)
+	for _, sp := range sharePaths {
+		if lp == sp || strings.HasPrefix(lp, sp+"/") || strings.HasPrefix(lp, sp+".") {
+			return "", "", false
+		}
+	}
+	return network, "https://" + host + p, true
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			b.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.Data == "br":
+			b.WriteString(", ")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if n.Type == html.ElementNode && (n.Data == "p" || n.Data == "div" || n.Data == "span") {
+			b.WriteByte(' ')
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+func collapse(s string) string {
+	s = whitespace.ReplaceAllString(s, " ")
+	s = strings.ReplaceAll(s, " ,", ",")
+	for strings.Contains(s, ",,") {
+		s = strings.ReplaceAll(s, ",,", ",")
+	}
+	return strings.Trim(s, " ,")
+}
+
+// jsonString returns v when it is a string, or the first string of an array.
+func jsonString(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case []interface{}:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				return strings.TrimSpace(s)
+			}
+		}
+	}
+	return ""
+}
+
+// Marshal renders the set for storage; nil for an empty set.
+func (s *Set) Marshal() json.RawMessage {
+	if s.Empty() {
+		return nil
+	}
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil
+	}
+	return raw
+}
@@ -0,0 +1,136 @@
+package contacts
+
+import (
+	"testing"
+)
+
+const page = `<html><head>
+<script type="application/ld+json">
+{"@context":"https://schema.org","@graph":[
+  {"@type":"WebSite","name":"Acme"},
+  {"@type":["Plumber","LocalBusiness"],"name":"Acme Plumbing","url":"https://acme.co.uk/",
+   "telephone":"020 7946 0958","email":"mailto:Hello@Acme.co.uk",
+   "address":{"@type":"PostalAddress","streetAddress":"1 High St","addressLocality":"London",
+              "postalCode":"EC1A 1BB","addressCountry":{"@type":"Country","name":"GB"}},
+   "sameAs":["https://www.facebook.com/acmeplumbing/","https://twitter.com/acme"]}]}
+</script><style>.x{}</style></head>
+<body>
+<a href="mailto:sales@acme.co.uk?subject=Quote">Email us</a>
+<a href="tel:+44%2020%207946%200000">Call</a>
+<a href="https://www.facebook.com/sharer/sharer.php?u=x">Share</a>
+<a href="https://uk.linkedin.com/company/acme">LinkedIn</a>
+<img src="logo@2x.png"> Write to info@acme.co.uk or ring +44 (0)161 496 0000.
+<address>2 Low Road<br>Leeds<br>LS1 1AA</address>
+<p>Placeholder: name@example.com</p>
+</body></html>`
+
+func TestExtract(t *testing.T) {
+	set := Extract([]byte(page), "https://acme.co.uk/", RegionForHost("acme.co.uk"))
+
+	emails := map[string]bool{}
+	for _, e := range set.Emails {
+		emails[e.Address] = true
+		if e.SourceURL != "https://acme.co.uk/" {
+			t.Errorf("email %s has source %q", e.Address, e.SourceURL)
+		}
+	}
+	for _, want := range []string{"hello@acme.co.uk", "sales@acme.co.uk", "info@acme.co.uk"} {
+		if !emails[want] {
+			t.Errorf("missing email %s in %v", want, set.Emails)
+		}
+	}
+	if len(emails) != 3 {
+		t.Errorf("expected 3 emails, got %v", set.Emails)
+	}
+
+	phones := map[string]bool{}
+	for _, p := range set.Phones {
+		phones[p.E164] = true
+	}
+	for _, want := range []string{"+442079460958", "+442079460000"} {
+		if !phones[want] {
+			t.Errorf("missing phone %s in %v", want, set.Phones)
+		}
+	}
+
+	socials := map[string]string{}
+	for _, p := range set.SocialProfiles {
+		socials[p.URL] = p.Network
+	}
+	want := map[string]string{
+		"https://facebook.com/acmeplumbing": "facebook",
+		"https://twitter.com/acme":          "twitter",
+		"https://linkedin.com/company/acme": "linkedin",
+	}
+	if len(socials) != len(want) {
+		t.Errorf("expected %d social profiles, got %v", len(want), set.SocialProfiles)
+	}
+	for u, n := range want {
+		if socials[u] != n {
+			t.Errorf("expected %s profile %s, got %v", n, u, set.SocialProfiles)
+		}
+	}
+
+	if len(set.Organizations) != 1 {
+		t.Fatalf("expected one organization, got %+v", set.Organizations)
+	}
+	org := set.Organizations[0]
+	if org.Type != "LocalBusiness" || org.Name != "Acme Plumbing" || org.Telephone != "+442079460958" || org.Address == nil || org.Address.PostalCode != "EC1A 1BB" {
+		t.Errorf("unexpected organization %+v", org)
+	}
+
+	if len(set.Addresses) != 2 {
+		t.Fatalf("expected two addresses, got %+v", set.Addresses)
+	}
+	if set.Addresses[0].Text != "1 High St, London, EC1A 1BB, GB" || set.Addresses[1].Text != "2 Low Road, Leeds, LS1 1AA" {
+		t.Errorf("unexpected addresses %+v", set.Addresses)
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	cases := []struct {
+		raw, region, want string
+		ok                bool
+	}{
+		{"(415) 555-0132", "US", "+14155550132", true},
+		{"1-415-555-0132 ext. 12", "US", "+14155550132", true},
+		{"011 44 20 7946 0958", "US", "+442079460958", true},
+		{"0044 20 7946 0958", "DE", "+442079460958", true},
+		{"+44 (0)20 7946 0000", "US", "+442079460000", true},
+		{"0044 (0)20 7946 0000", "DE", "+442079460000", true},
+		{"(0)20 7946 0000", "GB", "+442079460000", true},
+		{"030 1234567", "DE", "+49301234567", true},
+		{"06 1234 5678", "IT", "+390612345678", true},
+		{"+1 555 0132", "US", "", false},
+		{"555-0132", "US", "", false},
+		{"call us", "US", "", false},
+		{"12345", "", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := NormalizePhone(tc.raw, tc.region)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("NormalizePhone(%q, %q) = %q, %v; want %q, %v", tc.raw, tc.region, got, ok, tc.want, tc.ok)
+		}
+	}
+	if r := RegionForHost("shop.example.co.uk"); r != "GB" {
+		t.Errorf("expected GB, got %s", r)
+	}
+	if r := RegionForHost("example.com"); r != DefaultRegion {
+		t.Errorf("expected the default region, got %s", r)
+	}
+}
+
+func TestMergeKeepsFirstSource(t *testing.T) {
+	root := Extract([]byte(`<a href="mailto:a@acme.io">a</a>`), "https://acme.io/", "US")
+	contact := Extract([]byte(`<a href="mailto:A@acme.io">a</a><a href="tel:415-555-0132">t</a>`), "https://acme.io/contact", "US")
+	root.Merge(contact)
+	if len(root.Emails) != 1 || root.Emails[0].SourceURL != "https://acme.io/" {
+		t.Errorf("expected the root page to stay the email's source, got %+v", root.Emails)
+	}
+	if len(root.Phones) != 1 || root.Phones[0].SourceURL != "https://acme.io/contact" {
+		t.Errorf("expected the phone from the contact page, got %+v", root.Phones)
+	}
+	if (&Set{}).Marshal() != nil {
+		t.Error("expected an empty set to marshal to nil")
+	}
+}
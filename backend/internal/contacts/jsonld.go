@@ -0,0 +1,160 @@
+package contacts
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// organizationTypes are schema.org types treated as the business behind a site. Any type ending in
+// "Business" or "Organization" also qualifies, which covers most LocalBusiness subtypes.
+var organizationTypes = map[string]bool{
+	"Organization": true, "LocalBusiness": true, "Corporation": true, "NGO": true,
+	"Store": true, "Restaurant": true, "ProfessionalService": true, "LegalService": true,
+	"Dentist": true, "FinancialService": true, "FoodEstablishment": true, "RealEstateAgent": true,
+	"TravelAgency": true, "LodgingBusiness": true, "AutomotiveBusiness": true, "MedicalClinic": true,
+}
+
+// extractJSONLD walks a JSON-LD script, including arrays and @graph, and records every organization
+// node. Their emails, phones, addresses and sameAs links are added to the flat lists as well.
+func extractJSONLD(set *Set, raw []byte, pageURL, region string) {
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return
+	}
+	var walk func(v interface{}, depth int)
+	walk = func(v interface{}, depth int) {
+		if depth > 8 {
+			return
+		}
+		switch t := v.(type) {
+		case []interface{}:
+			for _, item := range t {
+				walk(item, depth+1)
+			}
+		case map[string]interface{}:
+			if typ := organizationType(t["@type"]); typ != "" {
+				addOrganizationNode(set, t, typ, pageURL, region)
+			}
+			keys := make([]string, 0, len(t))
+			for k := range t {
+				if k != "@context" {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys) // stable output order for nested nodes
+			for _, k := range keys {
+				walk(t[k], depth+1)
+			}
+		}
+	}
+	walk(doc, 0)
+}
+
+func organizationType(v interface{}) string {
+	var types []string
+	switch t := v.(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+	}
+	for _, typ := range types {
+		typ = strings.TrimPrefix(strings.TrimPrefix(typ, "https://schema.org/"), "http://schema.org/")
+		if organizationTypes[typ] || strings.HasSuffix(typ, "Business") || strings.HasSuffix(typ, "Organization") {
+			return typ
+		}
+	}
+	return ""
+}
+
+func addOrganizationNode(set *Set, node map[string]interface{}, typ, pageURL, region string) {
+	org := Organization{
+		Type:      typ,
+		Name:      jsonString(node["name"]),
+		URL:       jsonString(node["url"]),
+		SourceURL: pageURL,
+	}
+	if e, ok := normalizeEmail(strings.TrimPrefix(jsonString(node["email"]), "mailto:")); ok {
+		org.Email = e
+		set.addEmail(Email{Address: e, SourceURL: pageURL})
+	}
+	if tel := jsonString(node["telephone"]); tel != "" {
+		org.Telephone = tel
+		if e164, ok := NormalizePhone(tel, region); ok {
+			org.Telephone = e164
+			set.addPhone(Phone{E164: e164, Raw: tel, SourceURL: pageURL})
+		}
+	}
+	if addr := postalAddress(node["address"], pageURL); addr != nil {
+		org.Address = addr
+		set.addAddress(*addr)
+	}
+	var sameAs []string
+	switch t := node["sameAs"].(type) {
+	case string:
+		sameAs = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				sameAs = append(sameAs, s)
+			}
+		}
+	}
+	for _, link := range sameAs {
+		link = strings.TrimSpace(link)
+		if link == "" {
+			continue
+		}
+		org.SameAs = append(org.SameAs, link)
+		extractLink(set, link, nil, pageURL, region)
+	}
+	if org.Name == "" && org.URL == "" && org.Email == "" && org.Telephone == "" && org.Address == nil {
+		return
+	}
+	set.addOrganization(org)
+}
+
+// postalAddress accepts a schema.org PostalAddress object or a plain string.
+func postalAddress(v interface{}, pageURL string) *Address {
+	switch t := v.(type) {
+	case string:
+		if text := collapse(t); text != "" {
+			return &Address{Text: text, SourceURL: pageURL}
+		}
+	case []interface{}:
+		if len(t) > 0 {
+			return postalAddress(t[0], pageURL)
+		}
+	case map[string]interface{}:
+		a := &Address{
+			Street:     jsonString(t["streetAddress"]),
+			Locality:   jsonString(t["addressLocality"]),
+			Region:     jsonString(t["addressRegion"]),
+			PostalCode: jsonString(t["postalCode"]),
+			SourceURL:  pageURL,
+		}
+		switch c := t["addressCountry"].(type) {
+		case map[string]interface{}:
+			a.Country = jsonString(c["name"])
+		default:
+			a.Country = jsonString(c)
+		}
+		parts := []string{}
+		for _, p := range []string{a.Street, a.Locality, strings.TrimSpace(a.Region + " " + a.PostalCode), a.Country} {
+			if p != "" {
+				parts = append(parts, p)
+			}
+		}
+		if len(parts) == 0 {
+			return nil
+		}
+		a.Text = collapse(strings.Join(parts, ", "))
+		return a
+	}
+	return nil
+}
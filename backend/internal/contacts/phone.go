@@ -0,0 +1,87 @@
+package contacts
+
+import (
+	"strings"
+)
+
+// callingCodes maps ISO 3166 regions to their country calling code.
+var callingCodes = map[string]string{
+	"US": "1", "CA": "1", "GB": "44", "IE": "353", "DE": "49", "AT": "43", "CH": "41", "FR": "33",
+	"BE": "32", "NL": "31", "LU": "352", "ES": "34", "PT": "351", "IT": "39", "SE": "46", "NO": "47",
+	"DK": "45", "FI": "358", "PL": "48", "CZ": "420", "AU": "61", "NZ": "64", "IN": "91", "JP": "81",
+	"SG": "65", "ZA": "27", "BR": "55", "MX": "52", "AE": "971",
+}
+
+// ccTLDRegions covers country-code TLDs whose ISO code differs or that are worth special-casing.
+var ccTLDRegions = map[string]string{"uk": "GB"}
+
+// DefaultRegion applies to generic TLDs such as .com, where most sites list North American numbers.
+const DefaultRegion = "US"
+
+// RegionForHost guesses the region for national phone numbers from the host's TLD.
+func RegionForHost(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	tld := host[strings.LastIndexByte(host, '.')+1:]
+	if r, ok := ccTLDRegions[tld]; ok {
+		return r
+	}
+	if r := strings.ToUpper(tld); len(tld) == 2 && callingCodes[r] != "" {
+		return r
+	}
+	return DefaultRegion
+}
+
+// NormalizePhone converts raw to E.164. International numbers (+, 00 or, in North America, 011
+// prefixes) are taken as-is; national numbers get region's calling code with the trunk 0 dropped.
+// Extensions are discarded. ok is false when the result cannot be a valid E.164 number.
+func NormalizePhone(raw, region string) (string, bool) {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	for _, sep := range []string{";ext=", "ext", "x", "#", ";"} {
+		if i := strings.Index(s, sep); i > 0 {
+			s = s[:i]
+		}
+	}
+	// "+44 (0)20 ..." marks the trunk 0 that is dialled only nationally; it is never part of the number
+	s = strings.Replace(s, "(0)", "", 1)
+	international := strings.HasPrefix(s, "+")
+	var digits strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' || r == ' ' || r == '-' || r == '.' || r == '(' || r == ')' || r == '/' || r == '\u00a0':
+		default:
+			return "", false
+		}
+	}
+	d := digits.String()
+	cc := callingCodes[strings.ToUpper(region)]
+	switch {
+	case international:
+	case strings.HasPrefix(d, "00"):
+		d = d[2:]
+	case cc == "1" && strings.HasPrefix(d, "011"):
+		d = d[3:]
+	case cc == "1":
+		if len(d) == 10 {
+			d = "1" + d
+		} else if len(d) != 11 || d[0] != '1' {
+			return "", false
+		}
+	case cc != "":
+		if cc != "39" { // Italian numbers keep their leading 0
+			d = strings.TrimPrefix(d, "0")
+		}
+		d = cc + d
+	default:
+		return "", false
+	}
+	if len(d) < 8 || len(d) > 15 || d[0] == '0' {
+		return "", false
+	}
+	// North American numbers have exactly ten digits after the country code
+	if d[0] == '1' && len(d) != 11 {
+		return "", false
+	}
+	return "+" + d, true
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -20,12 +21,18 @@ import (
 
 	"github.com/fntelecomllc/studio/backend/internal/config"
 	"github.com/fntelecomllc/studio/backend/internal/constants"
+	"github.com/fntelecomllc/studio/backend/internal/httpfingerprint"
 	"github.com/fntelecomllc/studio/backend/internal/models"
 	"github.com/fntelecomllc/studio/backend/internal/proxymanager"
+	"github.com/fntelecomllc/studio/backend/internal/robots"
 	"github.com/google/uuid"
 	"golang.org/x/net/html/charset"
 )
 
+// ErrRobotsDisallowed is returned when the context's robots policy covers root fetches and robots.txt
+// disallows the URL.
+var ErrRobotsDisallowed = errors.New("disallowed by robots.txt")
+
 // ContentFetcher is responsible for fetching URL content with persona and proxy support.
 type ContentFetcher struct {
 	appConfig *config.AppConfig
@@ -184,8 +191,15 @@ func (cf *ContentFetcher) FetchUsingPersonas(
 
 	var resp *http.Response
 	var lastReqError error
+	policy := robots.Shared().PolicyFor(ctx)
 
 	for _, currentURLToTry := range urlsToTry {
+		if policy.EnforceRoot {
+			if allowed, rErr := robots.Shared().Admit(ctx, "content_fetch", currentURLToTry, effectiveUA); rErr == nil && !allowed {
+				lastReqError = fmt.Errorf("%w: %s", ErrRobotsDisallowed, currentURLToTry)
+				continue
+			}
+		}
 		log.Printf("ContentFetcher: Attempting URL: %s (UA: %s)", currentURLToTry, effectiveUA)
 		req, errNewReq := http.NewRequestWithContext(ctx, "GET", currentURLToTry, nil)
 		if errNewReq != nil {
@@ -402,10 +416,12 @@ func (cf *ContentFetcher) createConfiguredClient(
 		baseTransport.ResponseHeaderTimeout = cf.appConfig.HTTPValidator.RequestTimeout
 	}
 
-	// Apply HTTP/2 setting from persona AFTER baseTransport is initialized
+	// Apply persona HTTP/2, TLS ClientHello and header order settings AFTER baseTransport is initialized
+	if err := httpfingerprint.Apply(baseTransport, httpPersonaCfg, cf.appConfig.HTTPValidator.AllowInsecureTLS); err != nil {
+		log.Printf("ContentFetcher: Ignoring invalid TLS fingerprint settings of HTTP persona: %v", err)
+	}
 	if httpPersonaCfg.HTTP2Settings != nil {
-		baseTransport.ForceAttemptHTTP2 = httpPersonaCfg.HTTP2Settings.Enabled
-		log.Printf("ContentFetcher: HTTP/2 ForceAttempt set to %v based on persona %s", httpPersonaCfg.HTTP2Settings.Enabled, httpModelPersona.ID)
+		log.Printf("ContentFetcher: HTTP/2 ForceAttempt set to %v based on persona %s", baseTransport.ForceAttemptHTTP2, httpModelPersona.ID)
 	}
 
 	currentRoundTripper := http.RoundTripper(baseTransport)
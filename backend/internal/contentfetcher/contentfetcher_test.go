@@ -0,0 +1,37 @@
+package contentfetcher
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/config"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/testutil"
+	"github.com/google/uuid"
+)
+
+func TestFetchUsingPersonasAppliesPersonaFingerprint(t *testing.T) {
+	srv := testutil.NewRecordingTLSServer(t)
+	details, _ := json.Marshal(models.HTTPConfigDetails{
+		UserAgent:      "persona-ua",
+		HeaderOrder:    []string{"User-Agent", "Accept-Language", "Host", "Accept"},
+		TLSClientHello: &models.HTTPTLSClientHello{MinVersion: "TLS13", MaxVersion: "TLS13"},
+	})
+	persona := &models.Persona{ID: uuid.New(), PersonaType: models.PersonaTypeHTTP, ConfigDetails: details}
+	cf := NewContentFetcher(&config.AppConfig{HTTPValidator: config.HTTPValidatorConfig{AllowInsecureTLS: true, RequestTimeout: 5 * time.Second, MaxBodyReadBytes: 1 << 20}}, nil)
+
+	body, _, status, _, _, _, err := cf.FetchUsingPersonas(context.Background(), srv.URL, persona, nil, nil)
+	if err != nil || status != 200 || string(body) != "ok" {
+		t.Fatalf("fetch failed: status=%d body=%q err=%v", status, body, err)
+	}
+	if hello := <-srv.Hellos; !reflect.DeepEqual(hello.SupportedVersions, []uint16{tls.VersionTLS13}) {
+		t.Errorf("unexpected supported versions %v", hello.SupportedVersions)
+	}
+	if got := <-srv.HeaderNames; !reflect.DeepEqual(got[:4], []string{"User-Agent", "Accept-Language", "Host", "Accept"}) {
+		t.Errorf("unexpected header order %v", got)
+	}
+}
@@ -44,6 +44,8 @@ type DNSValidator struct {
 	weightedResolvers  []ResolverClient
 	preferredOrderIdx  int
 	currentRotationIdx int
+	wildcard           *wildcardDetector
+	recordTypes        []uint16
 	mu                 sync.Mutex
 }
 
@@ -52,6 +54,10 @@ func New(cfg config.DNSValidatorConfig) *DNSValidator {
 		config:             cfg,
 		currentRotationIdx: 0,
 		preferredOrderIdx:  0,
+		recordTypes:        parseRecordTypes(cfg.RecordTypes),
+	}
+	if cfg.WildcardDetection || len(cfg.SinkholeCIDRs) > 0 {
+		validator.wildcard = newWildcardDetector(cfg.WildcardDetection, cfg.WildcardProbes, cfg.WildcardCacheTTL, cfg.SinkholeCIDRs)
 	}
 
 	var allConfiguredResolvers []ResolverClient
@@ -264,6 +270,10 @@ func (dv *DNSValidator) performSingleDomainAttempt(domain string, ctx context.Co
 		return ValidationResult{Domain: domain, Status: constants.DNSStatusError, Error: "Failed to get resolver: " + err.Error(), Timestamp: startTime.Format(time.RFC3339), DurationMs: time.Since(startTime).Milliseconds()}
 	}
 
+	if err := waitForPersona(ctx); err != nil {
+		return ValidationResult{Domain: domain, Status: constants.DNSStatusError, Error: "Context canceled during persona rate limit wait: " + err.Error(), Resolver: resolverClient.Address, Timestamp: startTime.Format(time.RFC3339), DurationMs: time.Since(startTime).Milliseconds()}
+	}
+
 	if dv.config.QueryDelayMin > 0 && dv.config.QueryDelayMax > 0 && dv.config.QueryDelayMax >= dv.config.QueryDelayMin {
 		delayRange := dv.config.QueryDelayMax - dv.config.QueryDelayMin
 		randomDelay := dv.config.QueryDelayMin
@@ -279,9 +289,8 @@ func (dv *DNSValidator) performSingleDomainAttempt(domain string, ctx context.Co
 
 	var establishedError error // Used to store the primary error, if any.
 	var wgQueryTypes sync.WaitGroup
-	queryResultsChan := make(chan queryTypeResult, 2)
-
-	recordTypesToQuery := []uint16{dns.TypeA, dns.TypeAAAA}
+	recordTypesToQuery := dv.recordTypes
+	queryResultsChan := make(chan queryTypeResult, len(recordTypesToQuery))
 	domainQuerySemaphore := make(chan struct{}, dv.config.ConcurrentQueriesPerDomain)
 
 	// Initial check if context is already cancelled before launching any queries.
@@ -320,13 +329,21 @@ func (dv *DNSValidator) performSingleDomainAttempt(domain string, ctx context.Co
 			queryCtx, queryCancel := context.WithCancel(ctx)
 			defer queryCancel()
 
-			switch resolverClient.Type {
-			case SystemResolver, StandardResolver:
-				ips, errQuery = dv.resolveStandardType(queryCtx, domain, rType, resolverClient)
-			case DoHResolver:
-				ips, errQuery = dv.queryDoHRecord(queryCtx, domain, rType, resolverClient)
-			default:
-				errQuery = fmt.Errorf("unknown resolver type for %s", resolverClient.Address)
+			if waitErr := waitForResolver(queryCtx, resolverClient.Address); waitErr != nil {
+				errQuery = fmt.Errorf("resolver rate limit wait for %s: %w", domain, waitErr)
+			} else {
+				switch resolverClient.Type {
+				case SystemResolver, StandardResolver:
+					if rType == dns.TypeA || rType == dns.TypeAAAA {
+						ips, errQuery = dv.resolveStandardType(queryCtx, domain, rType, resolverClient)
+					} else {
+						ips, errQuery = dv.exchangeStandardRecord(queryCtx, domain, rType, resolverClient)
+					}
+				case DoHResolver:
+					ips, errQuery = dv.queryDoHRecord(queryCtx, domain, rType, resolverClient)
+				default:
+					errQuery = fmt.Errorf("unknown resolver type for %s", resolverClient.Address)
+				}
 			}
 			queryResultsChan <- queryTypeResult{ips: ips, err: errQuery, recordType: rType}
 		}(recordType)
@@ -335,42 +352,38 @@ func (dv *DNSValidator) performSingleDomainAttempt(domain string, ctx context.Co
 	wgQueryTypes.Wait()
 	close(queryResultsChan)
 
-	var collectedA, collectedAAAA []string
-	var errA, errAAAA error
+	var finalIPs []string
+	records := make(map[string][]string)
+	var queryErrs []error
 
 	for res := range queryResultsChan {
-		if res.recordType == dns.TypeA {
-			collectedA = res.ips
-			errA = res.err
+		if res.err != nil {
+			queryErrs = append(queryErrs, res.err)
+			continue
 		}
-		if res.recordType == dns.TypeAAAA {
-			collectedAAAA = res.ips
-			errAAAA = res.err
+		if len(res.ips) == 0 {
+			continue
+		}
+		records[dns.TypeToString[res.recordType]] = res.ips
+		if res.recordType == dns.TypeA || res.recordType == dns.TypeAAAA {
+			finalIPs = append(finalIPs, res.ips...)
 		}
 	}
 
-	finalIPs := append(collectedA, collectedAAAA...)
-
 	// If context was not cancelled during launch, and no other error is yet established,
 	// determine error status based on query results.
 	if !contextCancelledDuringLaunch { // This means establishedError is currently nil.
-		if len(finalIPs) == 0 { // No IPs found from A or AAAA queries.
-			if errA != nil && errAAAA != nil { // Both queries failed.
-				// Prioritize A error if it's NXDOMAIN, or if AAAA timed out and A didn't. Otherwise, AAAA error.
-				if isNXDOMAIN(errA) || (!isNXDOMAIN(errAAAA) && isTimeout(errAAAA) && !isTimeout(errA)) {
-					establishedError = errA
-				} else {
-					establishedError = errAAAA
+		if len(records) == 0 { // No answers for any queried type.
+			establishedError = pickQueryError(queryErrs)
+			if establishedError == nil {
+				names := make([]string, 0, len(recordTypesToQuery))
+				for _, rType := range recordTypesToQuery {
+					names = append(names, dns.TypeToString[rType])
 				}
-			} else if errA != nil { // Only A query failed.
-				establishedError = errA
-			} else if errAAAA != nil { // Only AAAA query failed.
-				establishedError = errAAAA
-			} else { // Both errA and errAAAA are nil, but len(finalIPs) == 0.
-				establishedError = fmt.Errorf("no A or AAAA records found (no specific query errors)")
+				establishedError = fmt.Errorf("no %s records found (no specific query errors)", strings.Join(names, " or "))
 			}
 		}
-		// If len(finalIPs) > 0, establishedError remains nil (its initial value).
+		// If any type returned answers, establishedError remains nil (its initial value).
 	}
 	// Now, establishedError holds the definitive error from any stage, or is nil if successful.
 
@@ -402,21 +415,44 @@ func (dv *DNSValidator) performSingleDomainAttempt(domain string, ctx context.Co
 		// If status is still "Error", it's some other kind of error not specifically handled above.
 		result.Error = establishedError.Error()
 	} else {
-		// If establishedError is nil, it implies queries were successful and IPs were found.
-		// (because if IPs were not found, establishedError would have been set in the block above).
-		// Thus, len(finalIPs) should be > 0 here.
+		// If establishedError is nil, at least one queried type returned answers
+		// (otherwise establishedError would have been set in the block above).
 		result.Status = constants.DNSStatusResolved
 		result.IPs = deduplicateIPs(finalIPs)
+		result.Records = records
+		if reason := dv.classifyAnswer(ctx, domain, resolverClient, result.IPs); reason != "" {
+			result.Status = constants.DNSStatusWildcard
+			result.Reason = reason
+		}
 	}
 	return result
 }
 
 type queryTypeResult struct {
-	ips        []string
+	ips        []string // answer data; IPs for A/AAAA, presentation format otherwise
 	err        error
 	recordType uint16
 }
 
+// pickQueryError chooses the error that best describes a failed attempt: NXDOMAIN wins over
+// timeouts, which win over anything else.
+func pickQueryError(errs []error) error {
+	for _, err := range errs {
+		if isNXDOMAIN(err) {
+			return err
+		}
+	}
+	for _, err := range errs {
+		if isTimeout(err) {
+			return err
+		}
+	}
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
 func isNXDOMAIN(err error) bool {
 	if err == nil {
 		return false
@@ -567,6 +603,15 @@ func (dv *DNSValidator) queryDoHRecord(ctx context.Context, domain string, recor
 	}
 
 	var ips []string
+	if recordType != dns.TypeA && recordType != dns.TypeAAAA {
+		// Other types may be answered for an alias target, so only the type is matched.
+		for _, answer := range dohResp.Answer {
+			if answer.Type == int(recordType) {
+				ips = append(ips, answer.Data)
+			}
+		}
+		return ips, nil
+	}
 	if dohResp.Answer != nil {
 		for _, answer := range dohResp.Answer {
 			if answer.Type == int(recordType) && strings.TrimSuffix(answer.Name, ".") == strings.TrimSuffix(queryDomain, ".") {
@@ -0,0 +1,146 @@
+package dnsvalidator
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/config"
+	"github.com/fntelecomllc/studio/backend/internal/constants"
+	"github.com/miekg/dns"
+)
+
+// startDNSServer serves a tiny zone for mail.example.test over UDP on loopback.
+func startDNSServer(t *testing.T) string {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	mux := dns.NewServeMux()
+	mux.HandleFunc(".", func(w dns.ResponseWriter, req *dns.Msg) {
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+		q := req.Question[0]
+		if q.Name != "mail.example.test." {
+			resp.Rcode = dns.RcodeNameError
+			_ = w.WriteMsg(resp)
+			return
+		}
+		hdr := dns.RR_Header{Name: q.Name, Rrtype: q.Qtype, Class: dns.ClassINET, Ttl: 60}
+		switch q.Qtype {
+		case dns.TypeA:
+			resp.Answer = append(resp.Answer, &dns.A{Hdr: hdr, A: net.ParseIP("192.0.2.10")})
+		case dns.TypeMX:
+			resp.Answer = append(resp.Answer, &dns.MX{Hdr: hdr, Preference: 10, Mx: "mx.example.test."})
+		case dns.TypeTXT:
+			resp.Answer = append(resp.Answer, &dns.TXT{Hdr: hdr, Txt: []string{"v=spf1 -all"}})
+		}
+		_ = w.WriteMsg(resp)
+	})
+	srv := &dns.Server{PacketConn: pc, Handler: mux}
+	go func() { _ = srv.ActivateAndServe() }()
+	t.Cleanup(func() { _ = srv.Shutdown() })
+	return pc.LocalAddr().String()
+}
+
+func newTestValidator(resolver string) *DNSValidator {
+	return New(config.DNSValidatorConfig{
+		Resolvers:                  []string{resolver},
+		QueryTimeout:               2 * time.Second,
+		ResolverStrategy:           constants.DNSStrategyRandomRotation,
+		ConcurrentQueriesPerDomain: 4,
+	})
+}
+
+func TestValidateSingleDomainQueriesConfiguredTypes(t *testing.T) {
+	dv := newTestValidator(startDNSServer(t)).WithRecordTypes("a", "MX", "TXT", "CAA", "PTR")
+
+	res := dv.ValidateSingleDomain("mail.example.test", context.Background())
+	if res.Status != constants.DNSStatusResolved {
+		t.Fatalf("status = %q (%s), want resolved", res.Status, res.Error)
+	}
+	if len(res.IPs) != 1 || res.IPs[0] != "192.0.2.10" {
+		t.Fatalf("IPs = %v", res.IPs)
+	}
+	if got := res.Records["MX"]; len(got) != 1 || got[0] != "10 mx.example.test." {
+		t.Fatalf("MX = %v", got)
+	}
+	if got := res.Records["TXT"]; len(got) != 1 || got[0] != `"v=spf1 -all"` {
+		t.Fatalf("TXT = %v", got)
+	}
+	if _, ok := res.Records["CAA"]; ok {
+		t.Fatalf("empty CAA answer should not be recorded: %v", res.Records)
+	}
+}
+
+func TestValidateSingleDomainNXDOMAINForOtherTypes(t *testing.T) {
+	dv := newTestValidator(startDNSServer(t)).WithRecordTypes("MX", "TXT")
+
+	res := dv.ValidateSingleDomain("missing.example.test", context.Background())
+	if res.Status != constants.DNSStatusNotFound {
+		t.Fatalf("status = %q (%s), want not found", res.Status, res.Error)
+	}
+}
+
+func TestValidateSingleDomainDoHRecordTypes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := DoHJSONResponse{Status: dns.RcodeSuccess}
+		switch r.URL.Query().Get("type") {
+		case "MX":
+			resp.Answer = []DoHAnswer{{Name: "mail.example.test.", Type: int(dns.TypeMX), Data: "10 mx.example.test."}}
+		case "CNAME":
+			resp.Answer = []DoHAnswer{{Name: "mail.example.test.", Type: int(dns.TypeCNAME), Data: "alias.example.test."}}
+		}
+		w.Header().Set("Content-Type", "application/dns-json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	dv := newTestValidator(srv.URL).WithRecordTypes("MX", "CNAME")
+	res := dv.ValidateSingleDomain("mail.example.test", context.Background())
+	if res.Status != constants.DNSStatusResolved {
+		t.Fatalf("status = %q (%s), want resolved", res.Status, res.Error)
+	}
+	if got := res.Records["MX"]; len(got) != 1 || got[0] != "10 mx.example.test." {
+		t.Fatalf("MX = %v", got)
+	}
+	if got := res.Records["CNAME"]; len(got) != 1 || got[0] != "alias.example.test." {
+		t.Fatalf("CNAME = %v", got)
+	}
+	if len(res.IPs) != 0 {
+		t.Fatalf("no address types were queried, got IPs %v", res.IPs)
+	}
+}
+
+func TestDefaultRecordTypesKeepLegacyError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(DoHJSONResponse{Status: dns.RcodeSuccess})
+	}))
+	defer srv.Close()
+
+	res := newTestValidator(srv.URL).ValidateSingleDomain("empty.example.test", context.Background())
+	if res.Error != "no A or AAAA records found (no specific query errors)" {
+		t.Fatalf("error = %q", res.Error)
+	}
+}
+
+func TestWaitForPersonaChargesEveryPersona(t *testing.T) {
+	ctx := WithPersonaRateLimits(context.Background(),
+		PersonaRateLimit{PersonaID: "unlimited-" + t.Name()},
+		PersonaRateLimit{PersonaID: "limited-" + t.Name(), DPS: 20, Burst: 1},
+	)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := waitForPersona(ctx); err != nil {
+			t.Fatalf("waitForPersona: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("expected the second persona's 20/s limit to throttle, took %v", elapsed)
+	}
+}
@@ -0,0 +1,74 @@
+package dnsvalidator
+
+import (
+	"context"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/ratelimit"
+)
+
+// PersonaRateLimit bounds how fast validations run under a DNS persona. Limits are enforced through
+// process-wide token buckets, so campaigns sharing a persona or a resolver share its budget.
+type PersonaRateLimit struct {
+	PersonaID     string
+	DPS           float64 // domain attempts per second across the persona
+	Burst         int
+	ResolverDPS   float64 // queries per second sent to each resolver
+	ResolverBurst int
+}
+
+// PersonaRateLimitFromConfig builds the PersonaRateLimit described by a DNS persona's config.
+func PersonaRateLimitFromConfig(personaID string, cfg models.DNSConfigDetails) PersonaRateLimit {
+	return PersonaRateLimit{
+		PersonaID:     personaID,
+		DPS:           cfg.RateLimitDps,
+		Burst:         cfg.RateLimitBurst,
+		ResolverDPS:   cfg.ResolverRateLimitDps,
+		ResolverBurst: cfg.ResolverRateLimitBurst,
+	}
+}
+
+type personaRateLimitKey struct{}
+
+// WithPersonaRateLimits returns a context under which ValidateDomainsBulk and ValidateSingleDomain honour
+// the limits of every persona in use.
+func WithPersonaRateLimits(ctx context.Context, limits ...PersonaRateLimit) context.Context {
+	if len(limits) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, personaRateLimitKey{}, limits)
+}
+
+func personaRateLimitsFromContext(ctx context.Context) []PersonaRateLimit {
+	limits, _ := ctx.Value(personaRateLimitKey{}).([]PersonaRateLimit)
+	return limits
+}
+
+// waitForPersona takes one domain attempt from the bucket of each persona carried by ctx.
+func waitForPersona(ctx context.Context) error {
+	for _, limit := range personaRateLimitsFromContext(ctx) {
+		if limit.PersonaID == "" {
+			continue
+		}
+		if err := ratelimit.Shared().Wait(ctx, "dns", ratelimit.ScopePersona, limit.PersonaID, limit.DPS, limit.Burst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForResolver takes one query from the resolver bucket when ctx carries a per resolver limit. Resolver
+// buckets are shared by all personas, so the strictest configured limit applies.
+func waitForResolver(ctx context.Context, resolver string) error {
+	var strictest *PersonaRateLimit
+	limits := personaRateLimitsFromContext(ctx)
+	for i := range limits {
+		if limits[i].ResolverDPS > 0 && (strictest == nil || limits[i].ResolverDPS < strictest.ResolverDPS) {
+			strictest = &limits[i]
+		}
+	}
+	if strictest == nil {
+		return nil
+	}
+	return ratelimit.Shared().Wait(ctx, "dns", ratelimit.ScopeResolver, resolver, strictest.ResolverDPS, strictest.ResolverBurst)
+}
@@ -0,0 +1,113 @@
+package dnsvalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// supportedRecordTypes lists the record types a validation can be asked to query.
+var supportedRecordTypes = map[string]uint16{
+	"A":     dns.TypeA,
+	"AAAA":  dns.TypeAAAA,
+	"CNAME": dns.TypeCNAME,
+	"MX":    dns.TypeMX,
+	"NS":    dns.TypeNS,
+	"TXT":   dns.TypeTXT,
+	"SOA":   dns.TypeSOA,
+	"CAA":   dns.TypeCAA,
+}
+
+var defaultRecordTypes = []uint16{dns.TypeA, dns.TypeAAAA}
+
+// SupportedRecordType reports whether name (case-insensitive) is a record type the validator can query.
+func SupportedRecordType(name string) bool {
+	_, ok := supportedRecordTypes[strings.ToUpper(strings.TrimSpace(name))]
+	return ok
+}
+
+// parseRecordTypes resolves record type names to query types, ignoring unsupported and duplicate names.
+// An empty result means the default A and AAAA.
+func parseRecordTypes(types []string) []uint16 {
+	var parsed []uint16
+	seen := make(map[uint16]struct{}, len(types))
+	for _, name := range types {
+		rType, ok := supportedRecordTypes[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			continue
+		}
+		if _, dup := seen[rType]; dup {
+			continue
+		}
+		seen[rType] = struct{}{}
+		parsed = append(parsed, rType)
+	}
+	if len(parsed) == 0 {
+		return defaultRecordTypes
+	}
+	return parsed
+}
+
+// WithRecordTypes returns a validator that queries the given record types (see
+// config.DNSValidatorConfig.RecordTypes). It shares dv's resolvers and wildcard cache.
+func (dv *DNSValidator) WithRecordTypes(types ...string) *DNSValidator {
+	cfg := dv.config
+	cfg.RecordTypes = types
+	return &DNSValidator{
+		config:            cfg,
+		activeResolvers:   dv.activeResolvers,
+		weightedResolvers: dv.weightedResolvers,
+		wildcard:          dv.wildcard,
+		recordTypes:       parseRecordTypes(types),
+	}
+}
+
+// exchangeStandardRecord sends a single query for recordType straight to a standard resolver,
+// falling back to TCP when the UDP answer is truncated. Answers are returned in presentation format
+// without the owner/TTL header, e.g. "10 mail.example.com." for MX.
+func (dv *DNSValidator) exchangeStandardRecord(ctx context.Context, domain string, recordType uint16, resolver ResolverClient) ([]string, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(domain), recordType)
+	msg.RecursionDesired = true
+
+	client := &dns.Client{Net: "udp", Timeout: dv.config.QueryTimeout, Dialer: resolver.Dialer}
+	resp, _, err := client.ExchangeContext(ctx, msg, resolver.Address)
+	if err == nil && resp != nil && resp.Truncated {
+		client.Net = "tcp"
+		resp, _, err = client.ExchangeContext(ctx, msg, resolver.Address)
+	}
+	if err != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil, fmt.Errorf("exchangeStandardRecord: %s: %w", domain, context.Canceled)
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("exchangeStandardRecord: %s: %w", domain, context.DeadlineExceeded)
+		}
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, &net.DNSError{Err: "timeout", Name: domain, Server: resolver.Address, IsTimeout: true}
+		}
+		return nil, fmt.Errorf("query %s %s via %s: %w", domain, dns.TypeToString[recordType], resolver.Address, err)
+	}
+
+	switch resp.Rcode {
+	case dns.RcodeSuccess:
+	case dns.RcodeNameError:
+		return nil, &net.DNSError{Err: "no such host", Name: domain, Server: resolver.Address, IsNotFound: true}
+	default:
+		return nil, fmt.Errorf("resolver %s returned RCODE %d (%s) for %s type %s", resolver.Address, resp.Rcode, dns.RcodeToString[resp.Rcode], domain, dns.TypeToString[recordType])
+	}
+
+	var records []string
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype != recordType {
+			continue
+		}
+		records = append(records, strings.TrimPrefix(rr.String(), rr.Header().String()))
+	}
+	return records, nil
+}
@@ -0,0 +1,213 @@
+package dnsvalidator
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// Reasons attached to results classified as constants.DNSStatusWildcard.
+const (
+	ReasonWildcard = "WILDCARD" // answers match the zone's wildcard answer set
+	ReasonSinkhole = "SINKHOLE" // answers fall inside a configured parking/sinkhole range
+)
+
+const wildcardLabelChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// wildcardDetector probes random nonexistent labels per zone and caches the answer set, so domains
+// that only resolve because their zone answers every label can be told apart from real ones.
+type wildcardDetector struct {
+	enabled   bool
+	probes    int
+	ttl       time.Duration
+	sinkholes []*net.IPNet
+	now       func() time.Time
+
+	mu    sync.Mutex
+	zones map[string]*wildcardZone
+}
+
+type wildcardZone struct {
+	ready   chan struct{}
+	ips     map[string]struct{}
+	expires time.Time
+}
+
+func newWildcardDetector(enabled bool, probes int, ttl time.Duration, sinkholeCIDRs []string) *wildcardDetector {
+	if probes <= 0 {
+		probes = 2
+	}
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	return &wildcardDetector{
+		enabled:   enabled,
+		probes:    probes,
+		ttl:       ttl,
+		sinkholes: parseSinkholes(sinkholeCIDRs),
+		now:       time.Now,
+		zones:     make(map[string]*wildcardZone),
+	}
+}
+
+// parseSinkholes accepts CIDRs or bare addresses; invalid entries are logged and skipped.
+func parseSinkholes(entries []string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil {
+				bits := 128
+				if ip.To4() != nil {
+					ip, bits = ip.To4(), 32
+				}
+				nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+				continue
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			log.Printf("DNSValidator: Warning - Ignoring invalid sinkhole range '%s': %v", entry, err)
+			continue
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets
+}
+
+func (w *wildcardDetector) inSinkhole(ips []string) bool {
+	for _, raw := range ips {
+		ip := net.ParseIP(raw)
+		if ip == nil {
+			continue
+		}
+		for _, n := range w.sinkholes {
+			if n.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// zoneAnswers returns the wildcard answer set for zone, probing it at most once per TTL. Concurrent
+// callers for the same zone wait for the first probe. Probes that fail with anything other than
+// NXDOMAIN are not cached, so a flaky resolver cannot hide a wildcard for the whole TTL.
+func (w *wildcardDetector) zoneAnswers(ctx context.Context, zone string, probe func(ctx context.Context, name string) ([]string, error)) map[string]struct{} {
+	w.mu.Lock()
+	entry, ok := w.zones[zone]
+	if ok && (entry.expires.IsZero() || w.now().Before(entry.expires)) {
+		w.mu.Unlock()
+		select {
+		case <-entry.ready:
+			return entry.ips
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	entry = &wildcardZone{ready: make(chan struct{}), ips: make(map[string]struct{})}
+	w.zones[zone] = entry
+	w.mu.Unlock()
+
+	cacheable := true
+	for i := 0; i < w.probes; i++ {
+		ips, err := probe(ctx, randomLabel()+"."+zone)
+		if err != nil && !isNXDOMAIN(err) {
+			cacheable = false
+		}
+		for _, ip := range ips {
+			entry.ips[ip] = struct{}{}
+		}
+	}
+
+	w.mu.Lock()
+	if cacheable {
+		entry.expires = w.now().Add(w.ttl)
+	} else {
+		delete(w.zones, zone)
+	}
+	w.mu.Unlock()
+	close(entry.ready)
+	return entry.ips
+}
+
+func randomLabel() string {
+	b := make([]byte, 20)
+	for i := range b {
+		b[i] = wildcardLabelChars[rand.Intn(len(wildcardLabelChars))]
+	}
+	return string(b)
+}
+
+// parentZone returns the zone a domain's wildcard would live in, e.g. "com" for "example.com".
+func parentZone(domain string) string {
+	domain = strings.TrimSuffix(domain, ".")
+	if i := strings.Index(domain, "."); i >= 0 {
+		return strings.ToLower(domain[i+1:])
+	}
+	return ""
+}
+
+// classifyAnswer returns ReasonSinkhole or ReasonWildcard when ips only tell us the domain's zone
+// (or a sinkhole) answers, and "" when the answer looks genuine.
+func (dv *DNSValidator) classifyAnswer(ctx context.Context, domain string, resolver ResolverClient, ips []string) string {
+	w := dv.wildcard
+	if w == nil || len(ips) == 0 {
+		return ""
+	}
+	if w.inSinkhole(ips) {
+		return ReasonSinkhole
+	}
+	zone := parentZone(domain)
+	if !w.enabled || zone == "" {
+		return ""
+	}
+	// The probe outlives a single domain's deadline so one cancelled domain cannot leave the
+	// shared cache half-filled.
+	probeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), dv.config.QueryTimeout*2+time.Second)
+	defer cancel()
+	answers := w.zoneAnswers(probeCtx, zone, func(pCtx context.Context, name string) ([]string, error) {
+		return dv.probeAddresses(pCtx, name, resolver)
+	})
+	for _, ip := range ips {
+		if _, ok := answers[ip]; ok {
+			return ReasonWildcard
+		}
+	}
+	return ""
+}
+
+// probeAddresses resolves A and AAAA for name through resolver.
+func (dv *DNSValidator) probeAddresses(ctx context.Context, name string, resolver ResolverClient) ([]string, error) {
+	if err := waitForResolver(ctx, resolver.Address); err != nil {
+		return nil, err
+	}
+	var ips []string
+	var firstErr error
+	for _, rType := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		var found []string
+		var err error
+		if resolver.Type == DoHResolver {
+			found, err = dv.queryDoHRecord(ctx, name, rType, resolver)
+		} else {
+			found, err = dv.resolveStandardType(ctx, name, rType, resolver)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		ips = append(ips, found...)
+	}
+	if len(ips) > 0 {
+		return ips, nil
+	}
+	return nil, firstErr
+}
@@ -0,0 +1,125 @@
+package dnsvalidator
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/config"
+	"github.com/fntelecomllc/studio/backend/internal/constants"
+	"github.com/miekg/dns"
+)
+
+// startWildcardDNSServer answers every label under wild.test. with the same address and only
+// www.real.test. under real.test. A queries for any other label are counted as probes.
+func startWildcardDNSServer(t *testing.T, probes *int32) string {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	mux := dns.NewServeMux()
+	mux.HandleFunc(".", func(w dns.ResponseWriter, req *dns.Msg) {
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+		q := req.Question[0]
+		known := q.Name == "www.real.test." || q.Name == "shop.wild.test." || q.Name == "blog.wild.test."
+		if !known && q.Qtype == dns.TypeA {
+			atomic.AddInt32(probes, 1)
+		}
+		hdr := dns.RR_Header{Name: q.Name, Rrtype: q.Qtype, Class: dns.ClassINET, Ttl: 60}
+		switch {
+		case strings.HasSuffix(q.Name, ".wild.test.") && q.Qtype == dns.TypeA:
+			resp.Answer = append(resp.Answer, &dns.A{Hdr: hdr, A: net.ParseIP("198.51.100.7")})
+		case q.Name == "www.real.test." && q.Qtype == dns.TypeA:
+			resp.Answer = append(resp.Answer, &dns.A{Hdr: hdr, A: net.ParseIP("203.0.113.20")})
+		case !strings.HasSuffix(q.Name, ".wild.test.") && q.Name != "www.real.test.":
+			resp.Rcode = dns.RcodeNameError
+		}
+		_ = w.WriteMsg(resp)
+	})
+	srv := &dns.Server{PacketConn: pc, Handler: mux}
+	go func() { _ = srv.ActivateAndServe() }()
+	t.Cleanup(func() { _ = srv.Shutdown() })
+	return pc.LocalAddr().String()
+}
+
+func newWildcardValidator(resolver string, sinkholes ...string) *DNSValidator {
+	return New(config.DNSValidatorConfig{
+		Resolvers:                  []string{resolver},
+		QueryTimeout:               2 * time.Second,
+		ResolverStrategy:           constants.DNSStrategyRandomRotation,
+		ConcurrentQueriesPerDomain: 2,
+		WildcardDetection:          true,
+		WildcardProbes:             2,
+		WildcardCacheTTL:           time.Minute,
+		SinkholeCIDRs:              sinkholes,
+	})
+}
+
+func TestWildcardZoneIsFlaggedAndCached(t *testing.T) {
+	var probes int32
+	dv := newWildcardValidator(startWildcardDNSServer(t, &probes))
+
+	first := dv.ValidateSingleDomain("shop.wild.test", context.Background())
+	if first.Status != constants.DNSStatusWildcard || first.Reason != ReasonWildcard {
+		t.Fatalf("shop.wild.test: status=%q reason=%q err=%q", first.Status, first.Reason, first.Error)
+	}
+	if len(first.IPs) != 1 || first.IPs[0] != "198.51.100.7" {
+		t.Fatalf("answers should be kept on wildcard results, got %v", first.IPs)
+	}
+	probed := atomic.LoadInt32(&probes)
+	if probed == 0 {
+		t.Fatalf("expected the zone to be probed")
+	}
+
+	second := dv.ValidateSingleDomain("blog.wild.test", context.Background())
+	if second.Status != constants.DNSStatusWildcard {
+		t.Fatalf("blog.wild.test: status=%q", second.Status)
+	}
+	if got := atomic.LoadInt32(&probes); got != probed {
+		t.Fatalf("wildcard answers should be cached per zone: probes went from %d to %d", probed, got)
+	}
+}
+
+func TestNonWildcardZoneResolves(t *testing.T) {
+	var probes int32
+	dv := newWildcardValidator(startWildcardDNSServer(t, &probes))
+
+	res := dv.ValidateSingleDomain("www.real.test", context.Background())
+	if res.Status != constants.DNSStatusResolved || res.Reason != "" {
+		t.Fatalf("www.real.test: status=%q reason=%q err=%q", res.Status, res.Reason, res.Error)
+	}
+}
+
+func TestSinkholeRangesAreFlagged(t *testing.T) {
+	var probes int32
+	dv := newWildcardValidator(startWildcardDNSServer(t, &probes), "203.0.113.0/24", "not-a-cidr")
+
+	res := dv.ValidateSingleDomain("www.real.test", context.Background())
+	if res.Status != constants.DNSStatusWildcard || res.Reason != ReasonSinkhole {
+		t.Fatalf("www.real.test: status=%q reason=%q err=%q", res.Status, res.Reason, res.Error)
+	}
+}
+
+func TestParseSinkholesAcceptsBareAddresses(t *testing.T) {
+	w := newWildcardDetector(false, 0, 0, []string{"192.0.2.1", "2001:db8::/32"})
+	if !w.inSinkhole([]string{"192.0.2.1"}) || w.inSinkhole([]string{"192.0.2.2"}) {
+		t.Fatalf("bare IPv4 should match only itself")
+	}
+	if !w.inSinkhole([]string{"2001:db8::5"}) {
+		t.Fatalf("IPv6 CIDR should match")
+	}
+}
+
+func TestParentZone(t *testing.T) {
+	cases := map[string]string{"example.com": "com", "a.b.co.uk.": "b.co.uk", "localhost": ""}
+	for in, want := range cases {
+		if got := parentZone(in); got != want {
+			t.Errorf("parentZone(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/fntelecomllc/studio/backend/internal/extraction"
 	"github.com/fntelecomllc/studio/backend/internal/featureflags"
+	"github.com/fntelecomllc/studio/backend/internal/robots"
 )
 
 // AdaptiveCrawlingService implements intelligent crawling strategies based on site characteristics and extraction results
@@ -222,6 +223,11 @@ func (s *AdaptiveCrawlingService) ExecuteAdaptiveCrawl(ctx context.Context, doma
 	// Create adaptive context with timeout
 	crawlCtx, cancel := context.WithTimeout(ctx, time.Duration(strategy.CrawlTimeout)*time.Second)
 	defer cancel()
+	if !strategy.RespectRobots {
+		policy := robots.Shared().PolicyFor(crawlCtx)
+		policy.Respect, policy.EnforceRoot = false, false
+		crawlCtx = robots.WithPolicy(crawlCtx, policy)
+	}
 
 	// Perform the crawl with adaptive strategy
 	result, keywords, err := s.microcrawler.Crawl(crawlCtx, "https://"+domainName, strategy.MaxPages)
@@ -241,6 +247,8 @@ func (s *AdaptiveCrawlingService) ExecuteAdaptiveCrawl(ctx context.Context, doma
 			"gain_ratio":            result.GainRatio,
 			"effectiveness_score":   effectiveness,
 			"diminishing_returns":   result.DiminishingReturns,
+			"robots_skipped":        result.RobotsSkipped,
+			"sitemap_urls":          result.SitemapURLs,
 		})
 	}
 
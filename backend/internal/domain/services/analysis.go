@@ -1578,6 +1578,14 @@ func (s *analysisService) scoreDomains(ctx context.Context, campaignID uuid.UUID
 		// Non-fatal: fallback to zero (no progress events)
 		totalCount = 0
 	}
+	// Registration dates only matter when the profile opts into domain age scoring
+	var registeredAt map[string]time.Time
+	if weightsMap["domain_age_weight"] > 0 {
+		var rErr error
+		if registeredAt, rErr = loadRegistrationDates(ctx, dbx, campaignID); rErr != nil {
+			return "", rErr
+		}
+	}
 	rows, err := dbx.QueryContext(ctx, `SELECT domain_name, feature_vector, last_http_fetched_at, is_parked, parked_confidence FROM generated_domains WHERE campaign_id = $1 AND feature_vector IS NOT NULL`, campaignID)
 	if err != nil {
 		return "", fmt.Errorf("query feature vectors: %w", err)
@@ -1700,6 +1708,11 @@ func (s *analysisService) scoreDomains(ctx context.Context, campaignID uuid.UUID
 		if w, ok := weightsMap["tf_lite_weight"]; ok && w > 0 && tfLite > 0 {
 			rel += tfLite * w
 		}
+		if w := weightsMap["domain_age_weight"]; w > 0 {
+			if ts, ok := registeredAt[domain]; ok {
+				rel += registrationAgeScore(&ts, now) * w
+			}
+		}
 		// Parked penalty if low confidence parked (parked_confidence < .9 but flagged?) using configurable factor
 		if isParkedB && parkedConf.Valid && parkedConf.Float64 < 0.9 {
 			rel *= parkedPenaltyFactor
@@ -1882,14 +1895,10 @@ func (s *analysisService) ScoreBreakdown(ctx context.Context, campaignID uuid.UU
 	if dbx == nil {
 		return nil, fmt.Errorf("db unavailable")
 	}
-	weightsMap, penaltyPtr, err := loadCampaignScoringWeights(ctx, dbx, campaignID)
+	calc, err := NewScoreBreakdownCalculator(ctx, dbx, campaignID)
 	if err != nil {
 		return nil, err
 	}
-	parkedPenaltyFactor := 0.5
-	if penaltyPtr != nil {
-		parkedPenaltyFactor = *penaltyPtr
-	}
 	row := dbx.QueryRowContext(ctx, `SELECT feature_vector, last_http_fetched_at, is_parked, parked_confidence FROM generated_domains WHERE campaign_id=$1 AND domain_name=$2`, campaignID, domain)
 	var raw json.RawMessage
 	var fetchedAt *time.Time
@@ -1898,6 +1907,46 @@ func (s *analysisService) ScoreBreakdown(ctx context.Context, campaignID uuid.UU
 	if err := row.Scan(&raw, &fetchedAt, &isParked, &parkedConf); err != nil {
 		return nil, err
 	}
+	var registeredAt *time.Time
+	if calc.weights["domain_age_weight"] > 0 {
+		if err := dbx.QueryRowContext(ctx, `SELECT rdap_registered_at FROM generated_domains WHERE campaign_id=$1 AND domain_name=$2`, campaignID, domain).Scan(&registeredAt); err != nil {
+			return nil, err
+		}
+	}
+	return calc.Breakdown(raw, fetchedAt, isParked, parkedConf, registeredAt), nil
+}
+
+// ScoreBreakdownCalculator recomputes component scores from stored domain features, loading the
+// campaign's scoring weights once so bulk callers (exports) avoid a query per domain.
+type ScoreBreakdownCalculator struct {
+	weights             map[string]float64
+	parkedPenaltyFactor float64
+	tfLiteEnabled       bool
+	now                 time.Time
+}
+
+// NewScoreBreakdownCalculator loads the campaign scoring profile (or normalized defaults) from db.
+func NewScoreBreakdownCalculator(ctx context.Context, db *sql.DB, campaignID uuid.UUID) (*ScoreBreakdownCalculator, error) {
+	weightsMap, penaltyPtr, err := loadCampaignScoringWeights(ctx, db, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	parkedPenaltyFactor := 0.5
+	if penaltyPtr != nil {
+		parkedPenaltyFactor = *penaltyPtr
+	}
+	v, ok := os.LookupEnv("ENABLE_TF_LITE")
+	return &ScoreBreakdownCalculator{
+		weights:             weightsMap,
+		parkedPenaltyFactor: parkedPenaltyFactor,
+		tfLiteEnabled:       ok && (v == "1" || strings.EqualFold(v, "true")),
+		now:                 time.Now(),
+	}, nil
+}
+
+// Breakdown returns the component scores plus "final" for one domain's stored feature vector.
+// registeredAt is the RDAP registration date, nil when unknown.
+func (c *ScoreBreakdownCalculator) Breakdown(raw json.RawMessage, fetchedAt *time.Time, isParked sql.NullBool, parkedConf sql.NullFloat64, registeredAt *time.Time) map[string]float64 {
 	fv := map[string]interface{}{}
 	_ = json.Unmarshal(raw, &fv)
 	kwUnique := asFloat(fv["kw_unique"])
@@ -1905,10 +1954,9 @@ func (s *analysisService) ScoreBreakdown(ctx context.Context, campaignID uuid.UU
 	contentBytes := asFloat(fv["content_bytes"])
 	titleHas := boolVal(fv["title_has_keyword"])
 	isParkedB := isParked.Valid && isParked.Bool
-	now := time.Now()
 	freshness := 0.0
 	if fetchedAt != nil {
-		age := now.Sub(*fetchedAt).Hours() / 24.0
+		age := c.now.Sub(*fetchedAt).Hours() / 24.0
 		if age <= 1 {
 			freshness = 1
 		} else if age < 7 {
@@ -1935,7 +1983,7 @@ func (s *analysisService) ScoreBreakdown(ctx context.Context, campaignID uuid.UU
 		titleScore = 1
 	}
 	var tfLite float64
-	if v, ok := os.LookupEnv("ENABLE_TF_LITE"); ok && (v == "1" || strings.EqualFold(v, "true")) && contentBytes > 0 && kwHitsTotal > 0 {
+	if c.tfLiteEnabled && contentBytes > 0 && kwHitsTotal > 0 {
 		perKB := kwHitsTotal / (contentBytes / 1024.0)
 		if perKB < 0 {
 			perKB = 0
@@ -1948,6 +1996,7 @@ func (s *analysisService) ScoreBreakdown(ctx context.Context, campaignID uuid.UU
 		idfN := clamp(idfApprox/2.4, 0, 1)
 		tfLite = perKBn * idfN
 	}
+	weightsMap := c.weights
 	rel := density*weightsMap["keyword_density_weight"] +
 		kwCoverage*weightsMap["unique_keyword_coverage_weight"] +
 		nonParked*weightsMap["non_parked_weight"] +
@@ -1957,10 +2006,12 @@ func (s *analysisService) ScoreBreakdown(ctx context.Context, campaignID uuid.UU
 	if w, ok := weightsMap["tf_lite_weight"]; ok && w > 0 && tfLite > 0 {
 		rel += tfLite * w
 	}
+	domainAge := registrationAgeScore(registeredAt, c.now)
+	rel += domainAge * weightsMap["domain_age_weight"]
 	if isParkedB && parkedConf.Valid && parkedConf.Float64 < 0.9 {
-		rel *= parkedPenaltyFactor
+		rel *= c.parkedPenaltyFactor
 	}
-	breakdown := map[string]float64{
+	return map[string]float64{
 		"density":        density,
 		"coverage":       kwCoverage,
 		"non_parked":     nonParked,
@@ -1968,9 +2019,9 @@ func (s *analysisService) ScoreBreakdown(ctx context.Context, campaignID uuid.UU
 		"title_keyword":  titleScore,
 		"freshness":      freshness,
 		"tf_lite":        tfLite,
+		"domain_age":     domainAge,
 		"final":          rel,
 	}
-	return breakdown, nil
 }
 
 // RescoreCampaign recomputes scores (alias of ScoreDomains for now; placeholder for profile diff logic)
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/fntelecomllc/studio/backend/internal/constants"
 	"github.com/fntelecomllc/studio/backend/internal/dnsvalidator"
 	"github.com/fntelecomllc/studio/backend/internal/domain/services/infra"
 	"github.com/fntelecomllc/studio/backend/internal/models"
@@ -38,9 +39,10 @@ type dnsBulkValidator interface {
 var _ dnsBulkValidator = (*dnsvalidator.DNSValidator)(nil)
 
 type dnsValidationService struct {
-	validator dnsBulkValidator
-	store     store.CampaignStore
-	deps      Dependencies
+	validator    dnsBulkValidator
+	store        store.CampaignStore
+	personaStore store.PersonaStore
+	deps         Dependencies
 
 	// Infrastructure Adapters
 	auditLogger   AuditLogger
@@ -92,13 +94,15 @@ type dnsExecution struct {
 func NewDNSValidationService(
 	dnsValidator *dnsvalidator.DNSValidator,
 	store store.CampaignStore,
+	personaStore store.PersonaStore,
 	deps Dependencies,
 ) DNSValidationService {
 	svc := &dnsValidationService{
-		validator:  dnsValidator,
-		store:      store,
-		deps:       deps,
-		executions: make(map[uuid.UUID]*dnsExecution),
+		validator:    dnsValidator,
+		store:        store,
+		personaStore: personaStore,
+		deps:         deps,
+		executions:   make(map[uuid.UUID]*dnsExecution),
 	}
 	// Prefer injected deps, fall back to minimal adapters
 	if deps.AuditLogger != nil {
@@ -213,6 +217,9 @@ func (s *dnsValidationService) Configure(ctx context.Context, campaignID uuid.UU
 		}
 	}
 
+	dnsConfig.ValidationTypes = normalizeRecordTypes(dnsConfig.ValidationTypes)
+	dnsConfig.RequiredRecords = normalizeRecordTypes(dnsConfig.RequiredRecords)
+
 	// Validate configuration
 	if err := s.Validate(ctx, dnsConfig); err != nil {
 		return fmt.Errorf("invalid DNS validation configuration: %w (personas=%d batch=%d timeout=%d maxRetries=%d)", err, len(dnsConfig.PersonaIDs), dnsConfig.BatchSize, dnsConfig.Timeout, dnsConfig.MaxRetries)
@@ -471,6 +478,9 @@ func (s *dnsValidationService) executeValidation(execution *dnsExecution) {
 		return
 	}
 
+	ctx = s.withPersonaRateLimit(ctx, campaignID, config)
+	validator := s.validatorFor(config)
+
 	jitterMin, jitterMax := 0, 0
 	if order, jMin, jMax, ok := s.loadStealthForDNS(execution.cancelCtx, execution.campaignID); ok {
 		if len(order) > 0 {
@@ -506,7 +516,7 @@ func (s *dnsValidationService) executeValidation(execution *dnsExecution) {
 		}
 
 		batch := domains[start:end]
-		results, err := s.validateDomainBatch(ctx, batch, config)
+		results, err := s.validateDomainBatch(ctx, validator, batch, config)
 		if err != nil {
 			if errors.Is(err, context.Canceled) && s.isStopRequested(execution) {
 				s.handleFailure(execution, "DNS validation cancelled by user")
@@ -605,15 +615,94 @@ func (s *dnsValidationService) executeValidation(execution *dnsExecution) {
 	s.handleCompletion(execution, len(domains), validCount, invalidCount)
 }
 
+// withPersonaRateLimit attaches the rate limits of every configured DNS persona so the validator throttles
+// against buckets shared with other campaigns using the same personas.
+func (s *dnsValidationService) withPersonaRateLimit(ctx context.Context, campaignID uuid.UUID, config DNSValidationConfig) context.Context {
+	if s.personaStore == nil || len(config.PersonaIDs) == 0 {
+		return ctx
+	}
+	var exec store.Querier
+	if q, ok := s.deps.DB.(store.Querier); ok {
+		exec = q
+	}
+	limits := make([]dnsvalidator.PersonaRateLimit, 0, len(config.PersonaIDs))
+	for _, personaID := range config.PersonaIDs {
+		persona, err := s.personaStore.GetPersonaByID(ctx, exec, personaID)
+		if err != nil || persona.PersonaType != models.PersonaTypeDNS || len(persona.ConfigDetails) == 0 {
+			if err != nil && s.deps.Logger != nil {
+				s.deps.Logger.Warn(ctx, "Failed to load DNS persona for rate limiting", map[string]interface{}{"campaign_id": campaignID, "persona_id": personaID, "error": err.Error()})
+			}
+			continue
+		}
+		var personaCfg models.DNSConfigDetails
+		if err := json.Unmarshal(persona.ConfigDetails, &personaCfg); err != nil {
+			continue
+		}
+		limits = append(limits, dnsvalidator.PersonaRateLimitFromConfig(persona.ID.String(), personaCfg))
+	}
+	return dnsvalidator.WithPersonaRateLimits(ctx, limits...)
+}
+
+// normalizeRecordTypes upper-cases and de-duplicates DNS record type names.
+func normalizeRecordTypes(types []string) []string {
+	if len(types) == 0 {
+		return types
+	}
+	out := make([]string, 0, len(types))
+	seen := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		t = strings.ToUpper(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if _, dup := seen[t]; dup {
+			continue
+		}
+		seen[t] = struct{}{}
+		out = append(out, t)
+	}
+	return out
+}
+
+// queriedRecordTypes is the set of record types a run must query: the configured validation types
+// plus any required record not already listed. Empty means the validator default (A and AAAA).
+func queriedRecordTypes(config DNSValidationConfig) []string {
+	types := normalizeRecordTypes(config.ValidationTypes)
+	if len(types) == 0 && len(config.RequiredRecords) > 0 {
+		types = []string{"A", "AAAA"}
+	}
+	return normalizeRecordTypes(append(types, config.RequiredRecords...))
+}
+
+// missingRequiredRecords returns the required record types absent from records.
+func missingRequiredRecords(required []string, records map[string][]string) []string {
+	var missing []string
+	for _, rt := range normalizeRecordTypes(required) {
+		if len(records[rt]) == 0 {
+			missing = append(missing, rt)
+		}
+	}
+	return missing
+}
+
+// validatorFor returns the validator for a run, narrowed to the record types the run queries.
+func (s *dnsValidationService) validatorFor(config DNSValidationConfig) dnsBulkValidator {
+	if dv, ok := s.validator.(*dnsvalidator.DNSValidator); ok && dv != nil {
+		return dv.WithRecordTypes(queriedRecordTypes(config)...)
+	}
+	return s.validator
+}
+
 // validateDomainBatch validates a batch of domains using the dnsvalidator engine
 // dnsResultOutcome captures normalized status + reason
 type dnsResultOutcome struct {
-	ok     bool
-	status string // ok|error|timeout
-	reason *string
+	ok      bool
+	status  string // ok|error|timeout|wildcard
+	reason  *string
+	records map[string][]string // answers keyed by record type
 }
 
-func (s *dnsValidationService) validateDomainBatch(ctx context.Context, domains []string, config DNSValidationConfig) (map[string]dnsResultOutcome, error) {
+func (s *dnsValidationService) validateDomainBatch(ctx context.Context, validator dnsBulkValidator, domains []string, config DNSValidationConfig) (map[string]dnsResultOutcome, error) {
 	results := make(map[string]dnsResultOutcome)
 
 	if ctx == nil {
@@ -625,11 +714,11 @@ func (s *dnsValidationService) validateDomainBatch(ctx context.Context, domains
 	default:
 	}
 
-	if s.validator == nil {
+	if validator == nil {
 		return nil, fmt.Errorf("dns validator unavailable")
 	}
 
-	validationResults := s.validator.ValidateDomainsBulk(domains, ctx, config.BatchSize)
+	validationResults := validator.ValidateDomainsBulk(domains, ctx, config.BatchSize)
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -639,8 +728,21 @@ func (s *dnsValidationService) validateDomainBatch(ctx context.Context, domains
 		var reason *string
 		lowerErr := strings.ToLower(vr.Error)
 		switch {
-		case (vr.Status == "Resolved" || vr.Status == "resolved") && len(vr.IPs) > 0:
+		case (vr.Status == "Resolved" || vr.Status == "resolved") && (len(vr.IPs) > 0 || len(vr.Records) > 0):
+			if missing := missingRequiredRecords(config.RequiredRecords, vr.Records); len(missing) > 0 {
+				r := "MISSING_RECORDS:" + strings.Join(missing, ",")
+				reason = &r
+				break
+			}
 			status = "ok"
+		case vr.Status == constants.DNSStatusWildcard:
+			// Resolved, but only through a zone wildcard or into a sinkhole; keep it out of HTTP.
+			status = constants.DNSStatusWildcard
+			r := vr.Reason
+			if r == "" {
+				r = dnsvalidator.ReasonWildcard
+			}
+			reason = &r
 		case strings.EqualFold(vr.Status, "Timeout"):
 			status = "timeout"
 			r := "TIMEOUT"
@@ -666,7 +768,7 @@ func (s *dnsValidationService) validateDomainBatch(ctx context.Context, domains
 				}
 			}
 		}
-		results[vr.Domain] = dnsResultOutcome{ok: status == "ok", status: status, reason: reason}
+		results[vr.Domain] = dnsResultOutcome{ok: status == "ok", status: status, reason: reason, records: vr.Records}
 	}
 	return results, nil
 }
@@ -901,6 +1003,12 @@ func (s *dnsValidationService) Validate(ctx context.Context, config interface{})
 		return fmt.Errorf("max retries cannot be negative")
 	}
 
+	for _, rt := range append(append([]string{}, dnsConfig.ValidationTypes...), dnsConfig.RequiredRecords...) {
+		if !dnsvalidator.SupportedRecordType(rt) {
+			return fmt.Errorf("unsupported DNS record type %q", rt)
+		}
+	}
+
 	return nil
 }
 
@@ -1234,7 +1342,7 @@ func (s *dnsValidationService) storeValidationResults(ctx context.Context, campa
 		status := outcome.status
 		if status == "ok" {
 			validCount++
-		} else if status == "error" {
+		} else if status == "error" || status == constants.DNSStatusWildcard {
 			invalidCount++
 		}
 		// Normalize / enrich DNS reason taxonomy
@@ -1258,12 +1366,20 @@ func (s *dnsValidationService) storeValidationResults(ctx context.Context, campa
 				outcome.reason = &val
 			}
 		}
+		var records *json.RawMessage
+		if len(outcome.records) > 0 {
+			if raw, err := json.Marshal(outcome.records); err == nil {
+				rm := json.RawMessage(raw)
+				records = &rm
+			}
+		}
 		bulk = append(bulk, models.DNSValidationResult{
 			DNSCampaignID:    campaignID,
 			DomainName:       domain,
 			ValidationStatus: status,
 			LastCheckedAt:    func() *time.Time { t := time.Now(); return &t }(),
 			Reason:           outcome.reason,
+			DNSRecords:       records,
 		})
 	}
 
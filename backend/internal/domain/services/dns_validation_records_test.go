@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fntelecomllc/studio/backend/internal/constants"
+	"github.com/fntelecomllc/studio/backend/internal/dnsvalidator"
+	"github.com/google/uuid"
+)
+
+type staticValidator struct {
+	results []dnsvalidator.ValidationResult
+}
+
+func (v staticValidator) ValidateDomainsBulk(domains []string, ctx context.Context, batchSize int) []dnsvalidator.ValidationResult {
+	return v.results
+}
+
+func TestValidateDomainBatchRequiredRecords(t *testing.T) {
+	svc := &dnsValidationService{validator: staticValidator{results: []dnsvalidator.ValidationResult{
+		{Domain: "mail.example.com", Status: "Resolved", IPs: []string{"192.0.2.1"}, Records: map[string][]string{"A": {"192.0.2.1"}, "MX": {"10 mx.example.com."}}},
+		{Domain: "web.example.com", Status: "Resolved", IPs: []string{"192.0.2.2"}, Records: map[string][]string{"A": {"192.0.2.2"}}},
+		{Domain: "txt.example.com", Status: "Resolved", Records: map[string][]string{"MX": {"5 mx.example.com."}}},
+	}}}
+
+	cfg := DNSValidationConfig{RequiredRecords: []string{"mx"}}
+	out, err := svc.validateDomainBatch(context.Background(), svc.validatorFor(cfg), []string{"mail.example.com", "web.example.com", "txt.example.com"}, cfg)
+	if err != nil {
+		t.Fatalf("validateDomainBatch: %v", err)
+	}
+	if got := out["mail.example.com"]; !got.ok || got.records["MX"][0] != "10 mx.example.com." {
+		t.Fatalf("mail.example.com should be ok with MX records, got %+v", got)
+	}
+	if got := out["web.example.com"]; got.ok || got.status != "error" || got.reason == nil || *got.reason != "MISSING_RECORDS:MX" {
+		t.Fatalf("web.example.com should fail for missing MX, got %+v", got)
+	}
+	if got := out["txt.example.com"]; !got.ok {
+		t.Fatalf("a domain with only the required records should be ok, got %+v", got)
+	}
+}
+
+func TestValidateDomainBatchWildcardIsRejected(t *testing.T) {
+	svc := &dnsValidationService{validator: staticValidator{results: []dnsvalidator.ValidationResult{
+		{Domain: "parked.example.com", Status: constants.DNSStatusWildcard, Reason: dnsvalidator.ReasonSinkhole, IPs: []string{"192.0.2.1"}},
+	}}}
+
+	out, err := svc.validateDomainBatch(context.Background(), svc.validator, []string{"parked.example.com"}, DNSValidationConfig{})
+	if err != nil {
+		t.Fatalf("validateDomainBatch: %v", err)
+	}
+	got := out["parked.example.com"]
+	if got.ok || got.status != constants.DNSStatusWildcard || got.reason == nil || *got.reason != dnsvalidator.ReasonSinkhole {
+		t.Fatalf("sinkholed domain should be wildcard with reason SINKHOLE, got %+v", got)
+	}
+}
+
+func TestQueriedRecordTypes(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  DNSValidationConfig
+		want []string
+	}{
+		{name: "default", cfg: DNSValidationConfig{}, want: nil},
+		{name: "required only keeps defaults", cfg: DNSValidationConfig{RequiredRecords: []string{"mx"}}, want: []string{"A", "AAAA", "MX"}},
+		{name: "union without duplicates", cfg: DNSValidationConfig{ValidationTypes: []string{"A", "txt"}, RequiredRecords: []string{"TXT", "CAA"}}, want: []string{"A", "TXT", "CAA"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := queriedRecordTypes(tc.cfg)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("got %v want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateRejectsUnsupportedRecordTypes(t *testing.T) {
+	svc := &dnsValidationService{}
+	base := DNSValidationConfig{PersonaIDs: []uuid.UUID{uuid.New()}, Timeout: 5}
+	ok := base
+	ok.ValidationTypes = []string{"A", "MX", "caa"}
+	if err := svc.Validate(context.Background(), ok); err != nil {
+		t.Fatalf("expected supported types to validate, got %v", err)
+	}
+	bad := base
+	bad.RequiredRecords = []string{"PTR"}
+	if err := svc.Validate(context.Background(), bad); err == nil {
+		t.Fatalf("expected PTR to be rejected")
+	}
+}
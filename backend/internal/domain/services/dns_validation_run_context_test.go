@@ -101,7 +101,7 @@ func TestDNSValidationService_ExecuteCancelsOnContextDone(t *testing.T) {
 	store := newDNSValidationStubStore([]string{"one.test", "two.test"})
 	store.phase = &models.CampaignPhase{PhaseType: models.PhaseTypeDomainGeneration, Status: models.PhaseStatusCompleted}
 	deps := Dependencies{Logger: noopLogger{}}
-	svc := NewDNSValidationService(nil, store, deps).(*dnsValidationService)
+	svc := NewDNSValidationService(nil, store, nil, deps).(*dnsValidationService)
 	svc.validator = validator
 
 	cfg := DNSValidationConfig{PersonaIDs: []uuid.UUID{uuid.New()}, BatchSize: 1, Timeout: 5, MaxRetries: 1}
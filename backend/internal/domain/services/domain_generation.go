@@ -3,7 +3,6 @@ package services
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,13 +26,28 @@ type DomainGenerationConfig struct {
 	CharacterSet         string `json:"character_set"`
 	ConstantString       string `json:"constant_string"`
 	TLD                  string `json:"tld"`
-	NumDomains           int64  `json:"num_domains"`
-	BatchSize            int    `json:"batch_size"`
-	OffsetStart          int64  `json:"offset_start"`
+	// TLDs fans the label space out across several TLDs; TLD mirrors the first entry.
+	TLDs        []string `json:"tlds,omitempty"`
+	NumDomains  int64    `json:"num_domains"`
+	BatchSize   int      `json:"batch_size"`
+	OffsetStart int64    `json:"offset_start"`
+
+	// Word-list patterns (word_list, word_template). Template is only set for word_template;
+	// word_list joins the lists in WordLists order with ConstantString.
+	Template  string               `json:"template,omitempty"`
+	WordLists []DomainWordListSlot `json:"word_lists,omitempty"`
+
+	// Source selects where domains come from: "" generates from the pattern, "import" seeds the
+	// campaign from domains staged via the import endpoint (pattern fields are ignored).
+	Source string `json:"source,omitempty"`
 }
 
 // Normalize standardizes pattern type casing and derives per-segment lengths from legacy fields.
 func (c *DomainGenerationConfig) Normalize() error {
+	if c.normalizeSource() {
+		return nil
+	}
+	c.normalizeTLDs()
 	pattern := strings.TrimSpace(strings.ToLower(c.PatternType))
 	switch pattern {
 	case "", "prefix", string(models.PatternTypePrefixVariable):
@@ -42,10 +56,19 @@ func (c *DomainGenerationConfig) Normalize() error {
 		pattern = string(models.PatternTypeSuffixVariable)
 	case "both", string(models.PatternTypeBothVariable):
 		pattern = string(models.PatternTypeBothVariable)
+	case "words", string(models.PatternTypeWordList):
+		pattern = string(models.PatternTypeWordList)
+	case "template", string(models.PatternTypeWordTemplate):
+		pattern = string(models.PatternTypeWordTemplate)
 	default:
 		return fmt.Errorf("unsupported pattern type: %s", c.PatternType)
 	}
 
+	if pattern == string(models.PatternTypeWordList) || pattern == string(models.PatternTypeWordTemplate) {
+		c.PatternType = pattern
+		return c.normalizeWordPattern()
+	}
+
 	if c.VariableLength < 0 {
 		return fmt.Errorf("variable length must be >= 0")
 	}
@@ -96,11 +119,49 @@ func (c *DomainGenerationConfig) Normalize() error {
 	return nil
 }
 
+// normalizeTLDs lowercases and de-duplicates TLDs (keeping order, which fixes the offset layout)
+// and mirrors the first one into TLD. A single TLD collapses to the plain TLD field.
+func (c *DomainGenerationConfig) normalizeTLDs() {
+	if len(c.TLDs) == 0 {
+		return
+	}
+	tlds := NormalizeTLDs(c.TLDs)
+	c.TLDs = nil
+	if len(tlds) > 0 {
+		c.TLD = tlds[0]
+	}
+	if len(tlds) > 1 {
+		c.TLDs = tlds
+	}
+}
+
+// NormalizeTLDs lowercases TLDs, adds the leading dot and drops blanks and duplicates, keeping order.
+func NormalizeTLDs(in []string) []string {
+	seen := make(map[string]bool, len(in))
+	tlds := make([]string, 0, len(in))
+	for _, tld := range in {
+		tld = strings.ToLower(strings.TrimSpace(tld))
+		if tld == "" {
+			continue
+		}
+		if !strings.HasPrefix(tld, ".") {
+			tld = "." + tld
+		}
+		if !seen[tld] {
+			seen[tld] = true
+			tlds = append(tlds, tld)
+		}
+	}
+	return tlds
+}
+
 // domainGenerationService implements DomainGenerationService
 // Orchestrates the existing domainexpert.DomainGenerator engine
 type domainGenerationService struct {
-	store store.CampaignStore
-	deps  Dependencies
+	store     store.CampaignStore
+	wordLists store.WordListStore
+	imports   store.DomainImportStore
+	deps      Dependencies
 
 	// Infrastructure Adapters
 	auditLogger AuditLogger
@@ -166,10 +227,14 @@ const domainStopMessage = "Domain generation stopped by user"
 // NewDomainGenerationService creates a new domain generation service
 func NewDomainGenerationService(
 	store store.CampaignStore,
+	wordLists store.WordListStore,
+	imports store.DomainImportStore,
 	deps Dependencies,
 ) DomainGenerationService {
 	svc := &domainGenerationService{
 		store:           store,
+		wordLists:       wordLists,
+		imports:         imports,
 		deps:            deps,
 		executions:      make(map[uuid.UUID]*domainExecution),
 		controlWatchers: make(map[uuid.UUID]domainControlWatcher),
@@ -264,22 +329,22 @@ func (s *domainGenerationService) Configure(ctx context.Context, campaignID uuid
 			}
 			return 0
 		}
-		// TLD: accept tld string or first of tlds array
-		getTLD := func() string {
-			if s := getString("tld"); s != "" {
-				return s
-			}
+		// TLDs: accept a tlds array (multi-TLD fan-out); TLD is derived from it during Normalize
+		getTLDs := func() []string {
+			var out []string
 			if raw, ok := v["tlds"]; ok {
-				if arr, ok2 := raw.([]interface{}); ok2 && len(arr) > 0 {
-					if s, ok3 := arr[0].(string); ok3 {
-						return s
+				if arr, ok2 := raw.([]interface{}); ok2 {
+					for _, item := range arr {
+						if s, ok3 := item.(string); ok3 {
+							out = append(out, s)
+						}
 					}
 				}
-				if arrS, ok2 := raw.([]string); ok2 && len(arrS) > 0 {
-					return arrS[0]
+				if arrS, ok2 := raw.([]string); ok2 {
+					out = append(out, arrS...)
 				}
 			}
-			return ""
+			return out
 		}
 
 		domainConfig = DomainGenerationConfig{
@@ -289,7 +354,8 @@ func (s *domainGenerationService) Configure(ctx context.Context, campaignID uuid
 			SuffixVariableLength: getInt("suffixVariableLength"),
 			CharacterSet:         getString("characterSet"),
 			ConstantString:       getString("constantString"),
-			TLD:                  getTLD(),
+			TLD:                  getString("tld"),
+			TLDs:                 getTLDs(),
 			NumDomains:           getInt64("numDomainsToGenerate"),
 			BatchSize:            getInt("batchSize"),
 			OffsetStart:          getInt64("offsetStart"),
@@ -322,6 +388,12 @@ func (s *domainGenerationService) Configure(ctx context.Context, campaignID uuid
 		if domainConfig.OffsetStart == 0 {
 			domainConfig.OffsetStart = getInt64("offset_start")
 		}
+		domainConfig.Template = getString("template")
+		domainConfig.WordLists = ParseDomainWordListSlots(v["wordLists"])
+		if len(domainConfig.WordLists) == 0 {
+			domainConfig.WordLists = ParseDomainWordListSlots(v["word_lists"])
+		}
+		domainConfig.Source = getString("source")
 		if domainConfig.PatternType == "" {
 			domainConfig.PatternType = string(models.PatternTypePrefixVariable)
 		}
@@ -338,45 +410,34 @@ func (s *domainGenerationService) Configure(ctx context.Context, campaignID uuid
 		return fmt.Errorf("invalid domain generation configuration: %w", err)
 	}
 
+	if domainConfig.IsImport() {
+		return s.configureImport(ctx, campaignID, domainConfig)
+	}
+
+	// Resolve word lists (word-list patterns only) so the generator and hash see the stored contents
+	var dbExec store.Querier
+	if q, ok := s.deps.DB.(store.Querier); ok {
+		dbExec = q
+	}
+	var words map[string][]string
+	var wordRefs []models.DomainWordListRef
+	if domainConfig.IsWordPattern() {
+		var werr error
+		words, wordRefs, werr = ResolveDomainWordLists(ctx, s.wordLists, dbExec, domainConfig.WordLists)
+		if werr != nil {
+			return fmt.Errorf("invalid domain generation configuration: %w", werr)
+		}
+	}
+
 	// Create domain generator using existing domainexpert engine
-	generator, err := domainexpert.NewDomainGenerator(
-		domainexpert.CampaignPatternType(domainConfig.PatternType),
-		domainConfig.PrefixVariableLength,
-		domainConfig.SuffixVariableLength,
-		domainConfig.CharacterSet,
-		domainConfig.ConstantString,
-		domainConfig.TLD,
-	)
+	generator, err := NewDomainGeneratorFromConfig(domainConfig, words)
 	if err != nil {
 		return fmt.Errorf("failed to create domain generator: %w", err)
 	}
 
 	// Compute config hash for authoritative global offset management
 	// Map to models.DomainGenerationCampaignParams for hashing utility
-	cs := domainConfig.ConstantString
-	var csPtr *string
-	if cs != "" {
-		csPtr = &cs
-	}
-	prefixNull := sql.NullInt32{}
-	suffixNull := sql.NullInt32{}
-	// Mark lengths as valid when the pattern uses the segment. Zero-length segments remain valid if explicitly configured.
-	if domainConfig.PatternType == string(models.PatternTypePrefixVariable) || domainConfig.PatternType == string(models.PatternTypeBothVariable) {
-		prefixNull = sql.NullInt32{Int32: int32(domainConfig.PrefixVariableLength), Valid: true}
-	}
-	if domainConfig.PatternType == string(models.PatternTypeSuffixVariable) || domainConfig.PatternType == string(models.PatternTypeBothVariable) {
-		suffixNull = sql.NullInt32{Int32: int32(domainConfig.SuffixVariableLength), Valid: true}
-	}
-
-	hashInput := models.DomainGenerationCampaignParams{
-		PatternType:          domainConfig.PatternType,
-		VariableLength:       domainConfig.VariableLength,
-		PrefixVariableLength: prefixNull,
-		SuffixVariableLength: suffixNull,
-		CharacterSet:         domainConfig.CharacterSet,
-		ConstantString:       csPtr,
-		TLD:                  domainConfig.TLD,
-	}
+	hashInput := domainConfig.HashParams(wordRefs)
 	hashRes, herr := domainexpert.GenerateDomainGenerationPhaseConfigHash(hashInput)
 	if herr != nil {
 		return fmt.Errorf("failed to compute config hash: %w", herr)
@@ -439,8 +500,9 @@ func (s *domainGenerationService) Execute(ctx context.Context, campaignID uuid.U
 		return nil, fmt.Errorf("domain generation already started for campaign %s", campaignID)
 	}
 
-	// Guard: Discovery phase is immutable - reject re-execution if domains already exist
-	if s.store != nil {
+	// Guard: Discovery phase is immutable - reject re-execution if domains already exist.
+	// Imports are the exception: they append after the existing domains and skip names already present.
+	if s.store != nil && !execution.config.IsImport() {
 		var exec store.Querier
 		if q, ok := s.deps.DB.(store.Querier); ok {
 			exec = q
@@ -473,7 +535,11 @@ func (s *domainGenerationService) Execute(ctx context.Context, campaignID uuid.U
 	}
 
 	// Start execution in goroutine
-	go s.executeGeneration(execution)
+	if execution.config.IsImport() {
+		go s.executeImport(execution)
+	} else {
+		go s.executeGeneration(execution)
+	}
 
 	return execution.progressCh, nil
 }
@@ -1290,11 +1356,19 @@ func (s *domainGenerationService) Validate(ctx context.Context, config interface
 		return fmt.Errorf("invalid configuration type")
 	}
 
-	if domainConfig.CharacterSet == "" {
+	// Imported seeds have no pattern; NumDomains optionally caps how many staged domains are used
+	if domainConfig.IsImport() {
+		if domainConfig.NumDomains < 0 {
+			return fmt.Errorf("number of domains must not be negative")
+		}
+		return nil
+	}
+
+	if domainConfig.CharacterSet == "" && !domainConfig.IsWordPattern() {
 		return fmt.Errorf("character set cannot be empty")
 	}
 
-	if domainConfig.TLD == "" {
+	if domainConfig.TLD == "" && len(domainConfig.TLDs) == 0 {
 		return fmt.Errorf("TLD cannot be empty")
 	}
 
@@ -1307,6 +1381,11 @@ func (s *domainGenerationService) Validate(ctx context.Context, config interface
 		return err
 	}
 
+	// Word-list patterns are structurally validated here; list contents are checked when Configure resolves them
+	if cfgCopy.IsWordPattern() {
+		return nil
+	}
+
 	switch cfgCopy.PatternType {
 	case string(models.PatternTypePrefixVariable):
 		if cfgCopy.PrefixVariableLength <= 0 {
@@ -1322,14 +1401,7 @@ func (s *domainGenerationService) Validate(ctx context.Context, config interface
 		}
 	}
 
-	_, err := domainexpert.NewDomainGenerator(
-		domainexpert.CampaignPatternType(cfgCopy.PatternType),
-		cfgCopy.PrefixVariableLength,
-		cfgCopy.SuffixVariableLength,
-		cfgCopy.CharacterSet,
-		cfgCopy.ConstantString,
-		cfgCopy.TLD,
-	)
+	_, err := NewDomainGeneratorFromConfig(cfgCopy, nil)
 
 	return err
 }
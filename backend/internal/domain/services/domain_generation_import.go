@@ -0,0 +1,323 @@
+// Import mode for the domain generation phase: seeds a campaign from an uploaded domain list
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+)
+
+// DomainSourceImport marks a domain generation config that copies staged imports instead of generating.
+const DomainSourceImport = "import"
+
+// importSourcePattern is recorded as generated_domains.source_pattern for imported domains.
+const importSourcePattern = "import"
+
+// IsImport reports whether the phase seeds the campaign from staged imports.
+func (c DomainGenerationConfig) IsImport() bool {
+	return c.Source == DomainSourceImport
+}
+
+// normalizeSource canonicalizes Source and, for imports, clears the pattern fields that no longer apply.
+// It reports whether the config is an import so Normalize can skip pattern handling.
+func (c *DomainGenerationConfig) normalizeSource() bool {
+	c.Source = strings.ToLower(strings.TrimSpace(c.Source))
+	if c.Source != DomainSourceImport {
+		c.Source = ""
+		return false
+	}
+	c.PatternType = ""
+	c.VariableLength = 0
+	c.PrefixVariableLength = 0
+	c.SuffixVariableLength = 0
+	c.CharacterSet = ""
+	c.ConstantString = ""
+	c.TLD = ""
+	c.TLDs = nil
+	c.OffsetStart = 0
+	c.Template = ""
+	c.WordLists = nil
+	return true
+}
+
+// configureImport records an import-mode execution. No generator or config hash is involved:
+// imported domains do not participate in the global offset space.
+func (s *domainGenerationService) configureImport(ctx context.Context, campaignID uuid.UUID, domainConfig DomainGenerationConfig) error {
+	if s.imports == nil {
+		return fmt.Errorf("invalid domain generation configuration: domain import store not available")
+	}
+	var exec store.Querier
+	if q, ok := s.deps.DB.(store.Querier); ok {
+		exec = q
+	}
+	staged, err := s.imports.CountStagedImportDomains(ctx, exec, campaignID)
+	if err != nil {
+		return fmt.Errorf("failed to count imported domains: %w", err)
+	}
+	total := staged
+	if domainConfig.NumDomains > 0 && domainConfig.NumDomains < total {
+		total = domainConfig.NumDomains
+	}
+
+	s.mu.Lock()
+	execution := &domainExecution{
+		campaignID: campaignID,
+		config:     domainConfig,
+		status:     models.PhaseStatusConfigured,
+		itemsTotal: total,
+	}
+	execution.pauseCond = sync.NewCond(&execution.pauseMu)
+	s.executions[campaignID] = execution
+	s.mu.Unlock()
+
+	s.deps.Logger.Info(ctx, "Domain generation phase configured for import", map[string]interface{}{
+		"campaign_id":    campaignID,
+		"staged_domains": staged,
+		"num_domains":    domainConfig.NumDomains,
+	})
+
+	if s.store != nil {
+		raw, marshalErr := json.Marshal(domainConfig)
+		if marshalErr != nil {
+			return fmt.Errorf("failed to marshal domain generation config: %w", marshalErr)
+		}
+		if err := s.store.UpdatePhaseConfiguration(ctx, exec, campaignID, models.PhaseTypeDomainGeneration, raw); err != nil {
+			return fmt.Errorf("failed to persist domain generation config: %w", err)
+		}
+	}
+	return nil
+}
+
+// executeImport copies staged imports into generated_domains in position order, using the same
+// CampaignStore.CreateGeneratedDomains path (and therefore the same status defaults and counters) as generation.
+func (s *domainGenerationService) executeImport(execution *domainExecution) {
+	defer close(execution.progressCh)
+
+	ctx := execution.cancelCtx
+	campaignID := execution.campaignID
+	config := execution.config
+
+	var exec store.Querier
+	if q, ok := s.deps.DB.(store.Querier); ok {
+		exec = q
+	}
+	if s.imports == nil {
+		s.updateExecutionStatus(campaignID, models.PhaseStatusFailed, "Domain import store not available")
+		return
+	}
+	staged, err := s.imports.CountStagedImportDomains(ctx, exec, campaignID)
+	if err != nil {
+		s.updateExecutionStatus(campaignID, models.PhaseStatusFailed, fmt.Sprintf("Failed to count imported domains: %v", err))
+		return
+	}
+	total := staged
+	if config.NumDomains > 0 && config.NumDomains < total {
+		total = config.NumDomains
+	}
+	if total <= 0 {
+		s.updateExecutionStatus(campaignID, models.PhaseStatusFailed, "No imported domains staged for campaign")
+		return
+	}
+
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	s.mu.Lock()
+	execution.itemsTotal = total
+	s.mu.Unlock()
+
+	var processedCount int64
+	lastPosition := int64(-1)
+	stopRequested := false
+	for processedCount < total {
+		if s.processPendingControlSignals(ctx, execution) {
+			if s.isStopRequested(execution) {
+				stopRequested = true
+				break
+			}
+			return
+		}
+		s.waitWhilePaused(execution)
+		if s.isStopRequested(execution) {
+			stopRequested = true
+			break
+		}
+		select {
+		case <-ctx.Done():
+			if s.isStopRequested(execution) {
+				stopRequested = true
+				break
+			}
+			s.updateExecutionStatus(campaignID, models.PhaseStatusPaused, "Execution cancelled by caller context")
+			return
+		default:
+		}
+		if stopRequested {
+			break
+		}
+
+		limit := int64(batchSize)
+		if remaining := total - processedCount; remaining < limit {
+			limit = remaining
+		}
+		entries, err := s.imports.ListStagedImportDomains(ctx, exec, campaignID, lastPosition, int(limit))
+		if err != nil {
+			s.updateExecutionStatus(campaignID, models.PhaseStatusFailed, fmt.Sprintf("Failed to read imported domains: %v", err))
+			return
+		}
+		if len(entries) == 0 {
+			break
+		}
+		if err := s.persistImportBatch(ctx, campaignID, entries); err != nil {
+			s.updateExecutionStatus(campaignID, models.PhaseStatusFailed, fmt.Sprintf("Failed to store domains: %v", err))
+			return
+		}
+		processedCount += int64(len(entries))
+		lastPosition = entries[len(entries)-1].Position
+
+		if s.metrics != nil {
+			s.metrics.RecordMetric("domains_imported_batch", float64(len(entries)))
+			s.metrics.RecordMetric("domains_imported_total", float64(processedCount))
+		}
+		s.mu.Lock()
+		execution.itemsProcessed = processedCount
+		s.mu.Unlock()
+
+		if !s.publishImportProgress(ctx, execution, models.PhaseStatusInProgress, fmt.Sprintf("Imported %d domains", processedCount)) {
+			return
+		}
+		if s.isStopRequested(execution) {
+			stopRequested = true
+			break
+		}
+	}
+
+	if stopRequested {
+		s.emitStopProgress(ctx, execution)
+		s.updateExecutionStatus(campaignID, models.PhaseStatusFailed, domainStopMessage)
+		return
+	}
+
+	// The staged list may have shrunk between Configure and Execute; report what was actually copied.
+	s.mu.Lock()
+	execution.itemsTotal = processedCount
+	s.mu.Unlock()
+
+	s.publishImportProgress(ctx, execution, models.PhaseStatusCompleted, fmt.Sprintf("Imported %d domains", processedCount))
+
+	if err := s.imports.ClearStagedImportDomains(safeWriteContext(ctx), exec, campaignID); err != nil && s.deps.Logger != nil {
+		s.deps.Logger.Warn(ctx, "Failed to clear staged domain imports", map[string]interface{}{
+			"campaign_id": campaignID,
+			"error":       err.Error(),
+		})
+	}
+
+	s.updateExecutionStatus(campaignID, models.PhaseStatusCompleted, "Domain import completed successfully")
+	s.deps.Logger.Info(ctx, "Domain import completed", map[string]interface{}{
+		"campaign_id":      campaignID,
+		"domains_imported": processedCount,
+	})
+}
+
+// persistImportBatch writes one batch of staged domains, inside a transaction when the store supports it.
+func (s *domainGenerationService) persistImportBatch(ctx context.Context, campaignID uuid.UUID, entries []*models.StagedImportDomain) error {
+	writeCtx := safeWriteContext(ctx)
+	now := time.Now().UTC()
+	genModels := make([]*models.GeneratedDomain, len(entries))
+	for i, e := range entries {
+		tld := sql.NullString{}
+		if idx := strings.LastIndex(e.DomainName, "."); idx >= 0 {
+			tld = sql.NullString{String: e.DomainName[idx:], Valid: true}
+		}
+		genModels[i] = &models.GeneratedDomain{
+			ID:            uuid.New(),
+			CampaignID:    campaignID,
+			DomainName:    e.DomainName,
+			SourcePattern: sql.NullString{String: importSourcePattern, Valid: true},
+			TLD:           tld,
+			GeneratedAt:   now,
+			CreatedAt:     now,
+			OffsetIndex:   e.Position,
+		}
+	}
+
+	if t, ok := s.store.(store.Transactor); ok {
+		tx, err := t.BeginTxx(writeCtx, nil)
+		if err != nil {
+			return fmt.Errorf("begin tx: %w", err)
+		}
+		if err := s.store.CreateGeneratedDomains(writeCtx, tx, genModels); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to persist imported domains: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("commit tx: %w", err)
+		}
+		return nil
+	}
+
+	var exec store.Querier
+	if q, ok := s.deps.DB.(store.Querier); ok {
+		exec = q
+	}
+	if err := s.store.CreateGeneratedDomains(writeCtx, exec, genModels); err != nil {
+		return fmt.Errorf("failed to persist imported domains: %w", err)
+	}
+	return nil
+}
+
+// publishImportProgress persists and broadcasts progress; it returns false when the context was cancelled.
+func (s *domainGenerationService) publishImportProgress(ctx context.Context, execution *domainExecution, status models.PhaseStatusEnum, message string) bool {
+	s.mu.RLock()
+	total := execution.itemsTotal
+	processed := execution.itemsProcessed
+	s.mu.RUnlock()
+
+	progressPct := 100.0
+	if total > 0 {
+		progressPct = float64(processed) / float64(total) * 100
+	}
+	if s.store != nil {
+		var exec store.Querier
+		if q, ok := s.deps.DB.(store.Querier); ok {
+			exec = q
+		}
+		_ = s.store.UpdatePhaseProgress(ctx, exec, execution.campaignID, models.PhaseTypeDomainGeneration, progressPct, &total, &processed, nil, nil)
+	}
+
+	progress := PhaseProgress{
+		CampaignID:     execution.campaignID,
+		Phase:          models.PhaseTypeDomainGeneration,
+		Status:         status,
+		ProgressPct:    progressPct,
+		ItemsTotal:     total,
+		ItemsProcessed: processed,
+		Message:        message,
+		Timestamp:      time.Now(),
+	}
+	select {
+	case execution.progressCh <- progress:
+	case <-ctx.Done():
+		return false
+	}
+
+	if s.deps.EventBus != nil {
+		if err := s.deps.EventBus.PublishProgress(ctx, progress); err != nil && s.deps.Logger != nil {
+			s.deps.Logger.Warn(ctx, "Failed to publish progress event", map[string]interface{}{
+				"campaign_id": execution.campaignID,
+				"error":       err.Error(),
+			})
+		}
+	}
+	return true
+}
@@ -0,0 +1,223 @@
+// Word-list support for the domain generation phase (word_list / word_template patterns)
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/fntelecomllc/studio/backend/internal/domainexpert"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+)
+
+// DomainWordListSlot binds a template slot (e.g. "noun" in "{adjective}{noun}") to a stored word list.
+type DomainWordListSlot struct {
+	Slot       string    `json:"slot"`
+	WordListID uuid.UUID `json:"word_list_id"`
+}
+
+// IsWordPattern reports whether the config generates domains from word lists.
+func (c DomainGenerationConfig) IsWordPattern() bool {
+	return c.PatternType == string(models.PatternTypeWordList) || c.PatternType == string(models.PatternTypeWordTemplate)
+}
+
+// EffectiveTemplate returns the template the generator runs; word_list patterns derive it from the slot order.
+func (c DomainGenerationConfig) EffectiveTemplate() string {
+	if c.PatternType == string(models.PatternTypeWordList) {
+		slots := make([]string, len(c.WordLists))
+		for i, wl := range c.WordLists {
+			slots[i] = wl.Slot
+		}
+		return domainexpert.WordListTemplate(slots)
+	}
+	return c.Template
+}
+
+// normalizeWordPattern validates slot bindings for word-list patterns and clears the variable-length fields.
+func (c *DomainGenerationConfig) normalizeWordPattern() error {
+	c.VariableLength = 0
+	c.PrefixVariableLength = 0
+	c.SuffixVariableLength = 0
+	c.Template = strings.ToLower(strings.TrimSpace(c.Template))
+
+	bound := make(map[string]bool, len(c.WordLists))
+	for i := range c.WordLists {
+		slot := strings.ToLower(strings.TrimSpace(c.WordLists[i].Slot))
+		if slot == "" && c.PatternType == string(models.PatternTypeWordList) {
+			slot = fmt.Sprintf("list%d", i+1)
+		}
+		if slot == "" {
+			return fmt.Errorf("word list %d is missing a slot name", i)
+		}
+		if c.WordLists[i].WordListID == uuid.Nil {
+			return fmt.Errorf("word list for slot %q is missing wordListId", slot)
+		}
+		if bound[slot] {
+			return fmt.Errorf("slot %q is bound more than once", slot)
+		}
+		bound[slot] = true
+		c.WordLists[i].Slot = slot
+	}
+
+	switch c.PatternType {
+	case string(models.PatternTypeWordList):
+		if len(c.WordLists) == 0 {
+			return fmt.Errorf("word_list pattern requires at least one word list")
+		}
+		c.Template = ""
+		if _, err := domainexpert.TemplateSlots(c.EffectiveTemplate()); err != nil {
+			return fmt.Errorf("invalid word list slots: %w", err)
+		}
+	case string(models.PatternTypeWordTemplate):
+		if c.Template == "" {
+			return fmt.Errorf("word_template pattern requires a template")
+		}
+		slots, err := domainexpert.TemplateSlots(c.Template)
+		if err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+		used := make(map[string]bool, len(slots))
+		for _, slot := range slots {
+			if !bound[slot] {
+				return fmt.Errorf("template slot %q has no word list", slot)
+			}
+			used[slot] = true
+		}
+		for slot := range bound {
+			if !used[slot] {
+				return fmt.Errorf("word list slot %q is not referenced by the template", slot)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseDomainWordListSlots converts a generic JSON value (camelCase or snake_case keys) to slot bindings.
+func ParseDomainWordListSlots(raw interface{}) []DomainWordListSlot {
+	switch v := raw.(type) {
+	case []DomainWordListSlot:
+		return v
+	case []interface{}:
+		out := make([]DomainWordListSlot, 0, len(v))
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			slot, _ := m["slot"].(string)
+			idStr, _ := m["wordListId"].(string)
+			if idStr == "" {
+				idStr, _ = m["word_list_id"].(string)
+			}
+			id, err := uuid.Parse(idStr)
+			if err != nil {
+				id = uuid.Nil
+			}
+			out = append(out, DomainWordListSlot{Slot: slot, WordListID: id})
+		}
+		return out
+	}
+	return nil
+}
+
+// ResolveDomainWordLists loads the word lists bound to a normalized config, returning the words per slot
+// and the content checksums used by the config hasher.
+func ResolveDomainWordLists(ctx context.Context, wordLists store.WordListStore, exec store.Querier, slots []DomainWordListSlot) (map[string][]string, []models.DomainWordListRef, error) {
+	if wordLists == nil {
+		return nil, nil, fmt.Errorf("word list store not available")
+	}
+	ids := make([]uuid.UUID, 0, len(slots))
+	for _, s := range slots {
+		ids = append(ids, s.WordListID)
+	}
+	lists, err := wordLists.GetWordListsByIDs(ctx, exec, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load word lists: %w", err)
+	}
+	byID := make(map[uuid.UUID]*models.DomainWordList, len(lists))
+	for _, l := range lists {
+		byID[l.ID] = l
+	}
+	words := make(map[string][]string, len(slots))
+	refs := make([]models.DomainWordListRef, 0, len(slots))
+	for _, s := range slots {
+		l, ok := byID[s.WordListID]
+		if !ok {
+			return nil, nil, fmt.Errorf("word list %s for slot %q not found", s.WordListID, s.Slot)
+		}
+		if len(l.Words) == 0 {
+			return nil, nil, fmt.Errorf("word list %q for slot %q is empty", l.Name, s.Slot)
+		}
+		words[s.Slot] = []string(l.Words)
+		refs = append(refs, models.DomainWordListRef{Slot: s.Slot, WordListID: l.ID, Checksum: l.Checksum})
+	}
+	return words, refs, nil
+}
+
+// NewDomainGeneratorFromConfig builds the domainexpert generator for a normalized config.
+// words is only consulted for word-list patterns (see ResolveDomainWordLists).
+// Multi-TLD configs are fanned out with TLD as the outermost offset dimension.
+func NewDomainGeneratorFromConfig(c DomainGenerationConfig, words map[string][]string) (*domainexpert.DomainGenerator, error) {
+	var generator *domainexpert.DomainGenerator
+	var err error
+	switch c.PatternType {
+	case string(models.PatternTypeWordList):
+		slots := make([]string, len(c.WordLists))
+		for i, wl := range c.WordLists {
+			slots[i] = wl.Slot
+		}
+		generator, err = domainexpert.NewWordListDomainGenerator(slots, words, c.ConstantString, c.TLD)
+	case string(models.PatternTypeWordTemplate):
+		generator, err = domainexpert.NewWordTemplateDomainGenerator(c.Template, words, c.CharacterSet, c.ConstantString, c.TLD)
+	default:
+		generator, err = domainexpert.NewDomainGenerator(
+			domainexpert.CampaignPatternType(c.PatternType),
+			c.PrefixVariableLength,
+			c.SuffixVariableLength,
+			c.CharacterSet,
+			c.ConstantString,
+			c.TLD,
+		)
+	}
+	if err != nil || len(c.TLDs) < 2 {
+		return generator, err
+	}
+	return generator.WithTLDs(c.TLDs)
+}
+
+// HashParams maps a normalized config to the params consumed by domainexpert.GenerateDomainGenerationPhaseConfigHash.
+// refs must carry checksums for word-list patterns and is ignored otherwise.
+func (c DomainGenerationConfig) HashParams(refs []models.DomainWordListRef) models.DomainGenerationCampaignParams {
+	cs := c.ConstantString
+	var csPtr *string
+	if cs != "" {
+		csPtr = &cs
+	}
+	prefixNull := sql.NullInt32{}
+	suffixNull := sql.NullInt32{}
+	// Mark lengths as valid when the pattern uses the segment. Zero-length segments remain valid if explicitly configured.
+	if c.PatternType == string(models.PatternTypePrefixVariable) || c.PatternType == string(models.PatternTypeBothVariable) {
+		prefixNull = sql.NullInt32{Int32: int32(c.PrefixVariableLength), Valid: true}
+	}
+	if c.PatternType == string(models.PatternTypeSuffixVariable) || c.PatternType == string(models.PatternTypeBothVariable) {
+		suffixNull = sql.NullInt32{Int32: int32(c.SuffixVariableLength), Valid: true}
+	}
+	params := models.DomainGenerationCampaignParams{
+		PatternType:          c.PatternType,
+		VariableLength:       c.VariableLength,
+		PrefixVariableLength: prefixNull,
+		SuffixVariableLength: suffixNull,
+		CharacterSet:         c.CharacterSet,
+		ConstantString:       csPtr,
+		TLD:                  c.TLD,
+		TLDs:                 c.TLDs,
+	}
+	if c.IsWordPattern() {
+		params.Template = c.EffectiveTemplate()
+		params.WordLists = refs
+	}
+	return params
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"sort"
 	"strings"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/fntelecomllc/studio/backend/internal/contacts"
 	"github.com/fntelecomllc/studio/backend/internal/extraction"
 	"github.com/fntelecomllc/studio/backend/internal/featureflags"
 	"github.com/fntelecomllc/studio/backend/internal/keywordscanner"
@@ -26,6 +28,8 @@ import (
 
 	"github.com/fntelecomllc/studio/backend/internal/httpvalidator"
 	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/proxymanager"
+	"github.com/fntelecomllc/studio/backend/internal/robots"
 	"github.com/fntelecomllc/studio/backend/internal/store"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -315,6 +319,7 @@ func (s *httpValidationService) maybeAdaptiveMicroCrawl(ctx context.Context, cam
 		return nil, nil
 	}
 	client := &http.Client{Timeout: 5 * time.Second}
+	policy := robots.Shared().PolicyFor(ctx)
 	bytesUsed := 0
 	keywordPatterns := make(map[string]struct{}, 32)
 	pagesExamined := 0
@@ -329,7 +334,15 @@ func (s *httpValidationService) maybeAdaptiveMicroCrawl(ctx context.Context, cam
 		if bytesUsed >= byteBudget {
 			break
 		}
+		if policy.Respect {
+			if allowed, rerr := robots.Shared().Admit(ctx, "http_microcrawl", link, policy.UserAgent); rerr == nil && !allowed {
+				continue
+			}
+		}
 		req, _ := http.NewRequestWithContext(ctx, "GET", link, nil)
+		if policy.UserAgent != "" {
+			req.Header.Set("User-Agent", policy.UserAgent)
+		}
 		resp, err := client.Do(req)
 		if err != nil {
 			continue
@@ -524,6 +537,40 @@ func mergeAggregateIntoVector(fv map[string]interface{}, agg extraction.FeatureA
 	}
 }
 
+// enrichFeatureVectorWithTLS records certificate signals; days to expiry goes negative once expired.
+func enrichFeatureVectorWithTLS(fv map[string]interface{}, info *httpvalidator.TLSInfo, now time.Time) {
+	if fv == nil {
+		return
+	}
+	fv["has_tls"] = info != nil
+	if info == nil {
+		return
+	}
+	fv["tls_version"] = info.Version
+	fv["tls_self_signed"] = info.SelfSigned
+	fv["tls_hostname_mismatch"] = info.HostnameMismatch
+	fv["tls_san_count"] = len(info.SANs)
+	if !info.NotAfter.IsZero() {
+		fv["tls_days_to_expiry"] = int(math.Floor(info.NotAfter.Sub(now).Hours() / 24))
+	}
+	if !info.NotBefore.IsZero() {
+		fv["tls_cert_age_days"] = int(now.Sub(info.NotBefore).Hours() / 24)
+	}
+}
+
+// enrichFeatureVectorWithContacts records contact counts; kw_contact backs the "has contact" filters.
+func enrichFeatureVectorWithContacts(fv map[string]interface{}, set *contacts.Set) {
+	if fv == nil || set == nil {
+		return
+	}
+	fv["contact_emails"] = len(set.Emails)
+	fv["contact_phones"] = len(set.Phones)
+	fv["contact_addresses"] = len(set.Addresses)
+	fv["contact_socials"] = len(set.SocialProfiles)
+	fv["contact_organizations"] = len(set.Organizations)
+	fv["kw_contact"] = len(set.Emails) + len(set.Phones) + len(set.Addresses)
+}
+
 func microcrawlResultFromVector(fv map[string]interface{}) *extraction.MicrocrawlResult {
 	if fv == nil {
 		return nil
@@ -691,6 +738,15 @@ func (s *httpValidationService) Validate(ctx context.Context, config interface{}
 		return fmt.Errorf("at least one keyword (predefined or ad-hoc) must be provided")
 	}
 
+	if httpConfig.ProxyPoolID != nil {
+		if _, err := uuid.Parse(*httpConfig.ProxyPoolID); err != nil {
+			return fmt.Errorf("invalid proxy pool ID %q", *httpConfig.ProxyPoolID)
+		}
+	}
+	if httpConfig.ProxySelectionStrategy != nil && !proxymanager.IsValidPoolStrategy(*httpConfig.ProxySelectionStrategy) {
+		return fmt.Errorf("unsupported proxy selection strategy %q", *httpConfig.ProxySelectionStrategy)
+	}
+
 	s.deps.Logger.Debug(ctx, "HTTP validation configuration validated", map[string]interface{}{
 		"persona_count":     len(httpConfig.PersonaIDs),
 		"keyword_count":     len(httpConfig.Keywords),
@@ -1048,6 +1104,17 @@ func (s *httpValidationService) executeHTTPValidation(execution *httpValidationE
 		s.updateExecutionStatus(campaignID, models.PhaseStatusFailed, fmt.Sprintf("failed to get persona/proxy: %v", err))
 		return
 	}
+	// A bound proxy pool supplies a proxy per domain and replaces the single proxy above
+	validateCtx := ctx
+	selector, err := s.resolveProxySelector(ctx, campaignID, persona)
+	if err != nil {
+		s.updateExecutionStatus(campaignID, models.PhaseStatusFailed, fmt.Sprintf("failed to resolve proxy pool: %v", err))
+		return
+	}
+	if selector != nil {
+		validateCtx = httpvalidator.WithProxySelector(ctx, selector)
+		proxy = nil
+	}
 
 	// Process in batches for progress visibility
 	const batchSize = 50
@@ -1070,6 +1137,7 @@ func (s *httpValidationService) executeHTTPValidation(execution *httpValidationE
 	microcrawlEnabled := isFeatureEnabled("ENABLE_HTTP_MICROCRAWL")
 	microMaxPages := 3
 	microByteBudget := 150000
+	var respectRobots, robotsEnforceRoot *bool
 	// Phase config overrides
 	if s.store != nil {
 		var exec store.Querier
@@ -1091,9 +1159,12 @@ func (s *httpValidationService) executeHTTPValidation(execution *httpValidationE
 				if cfg.MicroCrawlByteBudget != nil && *cfg.MicroCrawlByteBudget > 0 {
 					microByteBudget = *cfg.MicroCrawlByteBudget
 				}
+				respectRobots, robotsEnforceRoot = cfg.RespectRobots, cfg.RobotsEnforceRootFetch
 			}
 		}
 	}
+	robotsPolicy := campaignRobotsPolicy(ctx, persona, respectRobots, robotsEnforceRoot)
+	validateCtx = robots.WithPolicy(validateCtx, robotsPolicy)
 	enrichmentEnabled, forced := enforceEnrichmentForAnalysis(enrichmentEnabled)
 	if forced {
 		enrichmentForced = true
@@ -1111,8 +1182,10 @@ func (s *httpValidationService) executeHTTPValidation(execution *httpValidationE
 
 	// Batch-level reusable structures
 	var enrichmentVectors map[string]map[string]interface{}
+	var contactSets map[string]*contacts.Set
 	if enrichmentEnabled {
 		enrichmentVectors = make(map[string]map[string]interface{}, 2048)
+		contactSets = make(map[string]*contacts.Set, 2048)
 	}
 
 	// helper parked heuristic (MVP)
@@ -1157,7 +1230,7 @@ func (s *httpValidationService) executeHTTPValidation(execution *httpValidationE
 			s.updateExecutionStatus(campaignID, models.PhaseStatusFailed, "http validator unavailable")
 			return
 		}
-		results := s.validator.ValidateDomainsBulk(ctx, genBatch, 25, persona, proxy)
+		results := s.validator.ValidateDomainsBulk(validateCtx, genBatch, 25, persona, proxy)
 		if s.mtx.validationBatchSeconds != nil {
 			s.mtx.validationBatchSeconds.Observe(time.Since(batchStart).Seconds())
 		}
@@ -1237,6 +1310,13 @@ func (s *httpValidationService) executeHTTPValidation(execution *httpValidationE
 					"fetched_at":    time.Now().UTC().Format(time.RFC3339),
 					"content_bytes": r.ContentLength,
 				}
+				enrichFeatureVectorWithTLS(fv, r.TLS, time.Now())
+				pageURL := r.FinalURL
+				if pageURL == "" {
+					pageURL = "https://" + r.Domain + "/"
+				}
+				contactRegion := contacts.RegionForHost(r.Domain)
+				domainContacts := contacts.Extract(r.RawBody, pageURL, contactRegion)
 				ss := StructuralSignals{}
 				// Structural parsing (HTML) & naive language heuristic
 				if len(r.RawBody) > 0 {
@@ -1320,7 +1400,12 @@ func (s *httpValidationService) executeHTTPValidation(execution *httpValidationE
 				if microcrawlEnabled && !isParked {
 					kwuBaseline, _ := fv["kw_unique"].(int)
 					if kwuBaseline < 2 && r.ContentLength < 60000 && conf < 0.5 {
-						pagesExamined, exhausted, addedKw, newPatterns := s.microCrawlEnhance(ctx, campaignID, r, keywordSetIDs, adHocKeywords, microMaxPages, microByteBudget)
+						pagesExamined, exhausted, addedKw, newPatterns, robotsSkipped := s.microCrawlEnhance(robots.WithPolicy(ctx, robotsPolicy), campaignID, r, keywordSetIDs, adHocKeywords, microMaxPages, microByteBudget, func(link string, body []byte) {
+							domainContacts.Merge(contacts.Extract(body, link, contactRegion))
+						})
+						if robotsSkipped > 0 {
+							fv["microcrawl_robots_skipped"] = robotsSkipped
+						}
 						if pagesExamined > 0 {
 							if s.mtx.microCrawl != nil {
 								s.mtx.microCrawl.Inc()
@@ -1417,7 +1502,11 @@ func (s *httpValidationService) executeHTTPValidation(execution *httpValidationE
 					}
 				}
 				enrichFeatureVectorWithRichness(fv, patternCounts, microcrawlPatterns, r, ss, isParked, conf)
+				enrichFeatureVectorWithContacts(fv, domainContacts)
 				enrichmentVectors[r.Domain] = fv
+				if !domainContacts.Empty() {
+					contactSets[r.Domain] = domainContacts
+				}
 			}
 		}
 
@@ -1437,6 +1526,9 @@ func (s *httpValidationService) executeHTTPValidation(execution *httpValidationE
 				if err := s.persistExtractionFeatureRows(ctx, campaignID, enrichmentVectors); err != nil && s.deps.Logger != nil {
 					s.deps.Logger.Warn(ctx, "Failed to persist analysis-ready feature rows", map[string]interface{}{"campaign_id": campaignID, "error": err.Error()})
 				}
+				if err := s.persistContacts(ctx, campaignID, contactSets); err != nil && s.deps.Logger != nil {
+					s.deps.Logger.Warn(ctx, "Failed to persist domain contacts", map[string]interface{}{"campaign_id": campaignID, "error": err.Error()})
+				}
 				// Emit SSE enrichment sample
 				if s.deps.SSE != nil {
 					limit := 25
@@ -1461,10 +1553,13 @@ func (s *httpValidationService) executeHTTPValidation(execution *httpValidationE
 					})
 					s.deps.SSE.Send(string(msg))
 				}
-				// reset map
+				// reset maps
 				for k := range enrichmentVectors {
 					delete(enrichmentVectors, k)
 				}
+				for k := range contactSets {
+					delete(contactSets, k)
+				}
 			}
 			// metrics for batch persistence
 			if s.mtx.enrichmentBatches != nil {
@@ -1721,6 +1816,61 @@ func (s *httpValidationService) getPersonaAndProxy(ctx context.Context, campaign
 	return nil, nil, nil
 }
 
+// resolveProxySelector returns a per-domain proxy selector when the campaign or its persona is bound to
+// a proxy pool, or nil when neither is. The campaign's binding takes precedence over the persona's.
+func (s *httpValidationService) resolveProxySelector(ctx context.Context, campaignID uuid.UUID, persona *models.Persona) (proxymanager.ProxySelector, error) {
+	poolID, strategy := s.boundProxyPool(ctx, campaignID, persona)
+	if poolID == nil {
+		return nil, nil
+	}
+	if s.deps.ProxyPools == nil {
+		return nil, fmt.Errorf("bound to proxy pool %s but proxy pools are not available", poolID)
+	}
+	if strategy != "" && !proxymanager.IsValidPoolStrategy(strategy) {
+		s.deps.Logger.Warn(ctx, "Ignoring unsupported proxy selection strategy", map[string]interface{}{"campaign_id": campaignID, "strategy": strategy})
+		strategy = ""
+	}
+	s.deps.Logger.Info(ctx, "HTTP validation using proxy pool", map[string]interface{}{"campaign_id": campaignID, "proxy_pool_id": poolID, "strategy_override": strategy})
+	return s.deps.ProxyPools.SelectorForPool(ctx, *poolID, strategy)
+}
+
+// boundProxyPool finds the proxy pool for a campaign: campaign params, then the HTTP phase configuration,
+// then the persona's HTTP config.
+func (s *httpValidationService) boundProxyPool(ctx context.Context, campaignID uuid.UUID, persona *models.Persona) (*uuid.UUID, string) {
+	var exec store.Querier
+	if q, ok := s.deps.DB.(store.Querier); ok {
+		exec = q
+	}
+	if s.store != nil {
+		if params, err := s.store.GetHTTPKeywordParams(ctx, exec, campaignID); err == nil && params != nil && params.ProxyPoolID != nil {
+			strategy := ""
+			if params.ProxySelectionStrategy != nil {
+				strategy = *params.ProxySelectionStrategy
+			}
+			return params.ProxyPoolID, strategy
+		}
+		if phase, err := s.store.GetCampaignPhase(ctx, exec, campaignID, models.PhaseTypeHTTPKeywordValidation); err == nil && phase != nil && phase.Configuration != nil {
+			var cfg models.HTTPPhaseConfigRequest
+			if uErr := json.Unmarshal(*phase.Configuration, &cfg); uErr == nil && cfg.ProxyPoolID != nil {
+				if poolID, pErr := uuid.Parse(*cfg.ProxyPoolID); pErr == nil {
+					strategy := ""
+					if cfg.ProxySelectionStrategy != nil {
+						strategy = *cfg.ProxySelectionStrategy
+					}
+					return &poolID, strategy
+				}
+			}
+		}
+	}
+	if persona != nil && persona.PersonaType == models.PersonaTypeHTTP && len(persona.ConfigDetails) > 0 {
+		var details models.HTTPConfigDetails
+		if err := json.Unmarshal(persona.ConfigDetails, &details); err == nil && details.ProxyPoolID != nil {
+			return details.ProxyPoolID, ""
+		}
+	}
+	return nil, ""
+}
+
 func sanitizeHTTPStatusCode(code int) *int32 {
 	if code >= 100 && code <= 599 {
 		c := int32(code)
@@ -1774,6 +1924,9 @@ func (s *httpValidationService) storeHTTPResults(ctx context.Context, campaignID
 			case strings.Contains(ls, "headlessfailed"):
 				val := "HEADLESS_FAILED"
 				reasonPtr = &val
+			case strings.Contains(ls, "skippedrobots"):
+				val := "ROBOTS_DISALLOWED"
+				reasonPtr = &val
 			case strings.Contains(ls, "headlesstimeout"):
 				status = "timeout"
 				val := "TIMEOUT"
@@ -1850,6 +2003,13 @@ func (s *httpValidationService) storeHTTPResults(ctx context.Context, campaignID
 			t := r.ExtractedTitle
 			titlePtr = &t
 		}
+		var certPtr *json.RawMessage
+		if r.TLS != nil {
+			if raw, err := json.Marshal(r.TLS); err == nil {
+				cert := json.RawMessage(raw)
+				certPtr = &cert
+			}
+		}
 		bulk = append(bulk, models.HTTPKeywordResult{
 			HTTPKeywordCampaignID: campaignID,
 			DomainName:            r.Domain,
@@ -1858,6 +2018,7 @@ func (s *httpValidationService) storeHTTPResults(ctx context.Context, campaignID
 			PageTitle:             titlePtr,
 			LastCheckedAt:         func() *time.Time { t := time.Now(); return &t }(),
 			Reason:                reasonPtr,
+			TLSCertificate:        certPtr,
 		})
 	}
 	var exec store.Querier
@@ -1889,12 +2050,12 @@ func (s *httpValidationService) storeHTTPResults(ctx context.Context, campaignID
 		return nil
 	}
 	valueStrings := make([]string, 0, len(bulk))
-	valueArgs := make([]interface{}, 0, len(bulk)*5+1)
+	valueArgs := make([]interface{}, 0, len(bulk)*6+1)
 	valueArgs = append(valueArgs, campaignID) // $1
 	for i, r := range bulk {
-		idx := i*5 + 2 // domain starts at $2
+		idx := i*6 + 2 // domain starts at $2
 		// Cast http_status_code placeholder to integer explicitly to prevent Postgres inferring text type when NULLs present
-		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d::integer,$%d::timestamptz,$%d)", idx, idx+1, idx+2, idx+3, idx+4))
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d::integer,$%d::timestamptz,$%d,$%d::jsonb)", idx, idx+1, idx+2, idx+3, idx+4, idx+5))
 		// Ensure HTTPStatusCode stored as primitive int or NULL to satisfy integer column type
 		var httpCode interface{}
 		if r.HTTPStatusCode != nil {
@@ -1902,12 +2063,16 @@ func (s *httpValidationService) storeHTTPResults(ctx context.Context, campaignID
 		} else {
 			httpCode = nil
 		}
-		valueArgs = append(valueArgs, r.DomainName, r.ValidationStatus, httpCode, r.LastCheckedAt, r.Reason)
+		var cert interface{}
+		if r.TLSCertificate != nil {
+			cert = string(*r.TLSCertificate)
+		}
+		valueArgs = append(valueArgs, r.DomainName, r.ValidationStatus, httpCode, r.LastCheckedAt, r.Reason, cert)
 	}
 	valuesClause := strings.Join(valueStrings, ",")
 	// NOTE: Schema columns: http_status (enum), http_status_code, last_validated_at. Some legacy code referenced http_checked_at/http_reason which do not exist.
 	// We cast validation_status (text) to domain_http_status_enum explicitly to satisfy Postgres type requirements.
-	query := fmt.Sprintf(`WITH updates(domain_name,validation_status,http_status_code,last_checked_at,reason) AS (VALUES %s)
+	query := fmt.Sprintf(`WITH updates(domain_name,validation_status,http_status_code,last_checked_at,reason,tls_certificate) AS (VALUES %s)
 	UPDATE generated_domains gd
 	SET http_status = u.validation_status::domain_http_status_enum,
 			http_status_code = CASE
@@ -1915,7 +2080,8 @@ func (s *httpValidationService) storeHTTPResults(ctx context.Context, campaignID
 				WHEN u.http_status_code BETWEEN 100 AND 599 THEN u.http_status_code
 				ELSE NULL
 			END,
-			last_validated_at = u.last_checked_at
+			last_validated_at = u.last_checked_at,
+			tls_certificate = u.tls_certificate
 	FROM updates u
 	WHERE gd.domain_name = u.domain_name
 		AND gd.campaign_id = $1
@@ -2209,6 +2375,47 @@ WHERE gd.campaign_id = $1 AND gd.domain_name = incoming.domain_name`, strings.Jo
 	return nil
 }
 
+// persistContacts stores each domain's merged contacts. Domains without contacts are left untouched so
+// a later fetch that finds nothing does not erase earlier results.
+func (s *httpValidationService) persistContacts(ctx context.Context, campaignID uuid.UUID, sets map[string]*contacts.Set) error {
+	if len(sets) == 0 {
+		return nil
+	}
+	exec, ok := s.deps.DB.(store.Querier)
+	if !ok || exec == nil {
+		return nil
+	}
+	domains := make([]string, 0, len(sets))
+	for d := range sets {
+		domains = append(domains, d)
+	}
+	sort.Strings(domains)
+	valueStrings := make([]string, 0, len(domains))
+	args := make([]interface{}, 0, len(domains)*2+1)
+	args = append(args, campaignID)
+	idx := 2
+	for _, d := range domains {
+		raw := sets[d].Marshal()
+		if raw == nil {
+			continue
+		}
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d)", idx, idx+1))
+		args = append(args, d, string(sanitizeJSONForPostgres(raw)))
+		idx += 2
+	}
+	if len(valueStrings) == 0 {
+		return nil
+	}
+	query := fmt.Sprintf(`UPDATE generated_domains gd
+SET contacts = v.column2::jsonb
+FROM (VALUES %s) AS v
+WHERE gd.campaign_id = $1 AND gd.domain_name = v.column1::text`, strings.Join(valueStrings, ","))
+	if _, err := exec.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("bulk contacts update failed: %w", err)
+	}
+	return nil
+}
+
 const upsertExtractionFeatureSQL = `
 INSERT INTO domain_extraction_features (
 	campaign_id,
@@ -2576,22 +2783,23 @@ func vectorStringIntMap(v interface{}) map[string]int {
 	return out
 }
 
-// microCrawlEnhance performs a bounded depth-1 crawl of internal links for a single domain result.
-// Returns: pagesExamined, exhausted(bool), newUniqueKeywordCount, mergedKeywordPatterns
-func (s *httpValidationService) microCrawlEnhance(ctx context.Context, campaignID uuid.UUID, root *httpvalidator.ValidationResult, keywordSetIDs []string, adHocKeywords []string, maxPages int, byteBudget int) (int, bool, int, []string) {
+// microCrawlEnhance performs a bounded depth-1 crawl of internal links for a single domain result,
+// skipping links the robots policy carried by ctx disallows. onPage, if set, sees every fetched page.
+// Returns: pagesExamined, exhausted(bool), newUniqueKeywordCount, mergedKeywordPatterns, robotsSkipped
+func (s *httpValidationService) microCrawlEnhance(ctx context.Context, campaignID uuid.UUID, root *httpvalidator.ValidationResult, keywordSetIDs []string, adHocKeywords []string, maxPages int, byteBudget int, onPage func(pageURL string, body []byte)) (int, bool, int, []string, int) {
 	// Preconditions
 	if root == nil || len(root.RawBody) == 0 || maxPages <= 0 || byteBudget <= 0 || s.kwScanner == nil {
-		return 0, false, 0, nil
+		return 0, false, 0, nil, 0
 	}
 	// Parse root HTML to extract candidate internal links
 	doc, err := html.Parse(bytes.NewReader(root.RawBody))
 	if err != nil {
-		return 0, false, 0, nil
+		return 0, false, 0, nil, 0
 	}
 	// Extract host from final URL
 	u, perr := url.Parse(root.FinalURL)
 	if perr != nil || u.Host == "" {
-		return 0, false, 0, nil
+		return 0, false, 0, nil, 0
 	}
 	host := u.Host
 	// Collect links
@@ -2631,7 +2839,7 @@ func (s *httpValidationService) microCrawlEnhance(ctx context.Context, campaignI
 	}
 	f(doc)
 	if len(links) == 0 {
-		return 0, false, 0, nil
+		return 0, false, 0, nil, 0
 	}
 	// Deduplicate
 	uniq := make(map[string]struct{}, len(links))
@@ -2648,6 +2856,8 @@ func (s *httpValidationService) microCrawlEnhance(ctx context.Context, campaignI
 		dedup = dedup[:maxPages]
 	}
 	client := &http.Client{Timeout: 6 * time.Second}
+	policy := robots.Shared().PolicyFor(ctx)
+	robotsSkipped := 0
 	bytesUsed := 0
 	keywordPatterns := make(map[string]struct{}, 32)
 	// Root patterns baseline
@@ -2663,7 +2873,20 @@ func (s *httpValidationService) microCrawlEnhance(ctx context.Context, campaignI
 			exhausted = true
 			break
 		}
+		if policy.Respect {
+			allowed, rerr := robots.Shared().Admit(ctx, "http_microcrawl", link, policy.UserAgent)
+			if rerr != nil && ctx.Err() != nil {
+				break
+			}
+			if rerr == nil && !allowed {
+				robotsSkipped++
+				continue
+			}
+		}
 		req, _ := http.NewRequestWithContext(ctx, "GET", link, nil)
+		if policy.UserAgent != "" {
+			req.Header.Set("User-Agent", policy.UserAgent)
+		}
 		resp, err := client.Do(req)
 		if err != nil {
 			continue
@@ -2675,6 +2898,9 @@ func (s *httpValidationService) microCrawlEnhance(ctx context.Context, campaignI
 		if len(body) == 0 {
 			continue
 		}
+		if onPage != nil {
+			onPage(link, body)
+		}
 		if len(keywordSetIDs) > 0 {
 			if hitsBySet, err := s.kwScanner.ScanBySetIDs(ctx, exec, body, keywordSetIDs); err == nil {
 				for _, patterns := range hitsBySet {
@@ -2697,13 +2923,32 @@ func (s *httpValidationService) microCrawlEnhance(ctx context.Context, campaignI
 	}
 	totalUnique := len(keywordPatterns)
 	if totalUnique == 0 {
-		return pagesExamined, exhausted, 0, nil
+		return pagesExamined, exhausted, 0, nil, robotsSkipped
 	}
 	merged := make([]string, 0, totalUnique)
 	for k := range keywordPatterns {
 		merged = append(merged, k)
 	}
-	return pagesExamined, exhausted, totalUnique, merged
+	return pagesExamined, exhausted, totalUnique, merged, robotsSkipped
+}
+
+// campaignRobotsPolicy applies a campaign's robots.txt overrides to the server default and binds the
+// policy to the persona's user agent.
+func campaignRobotsPolicy(ctx context.Context, persona *models.Persona, respect, enforceRoot *bool) robots.Policy {
+	policy := robots.Shared().PolicyFor(ctx)
+	if respect != nil {
+		policy.Respect = *respect
+	}
+	if enforceRoot != nil {
+		policy.EnforceRoot = *enforceRoot
+	}
+	if persona != nil && persona.PersonaType == models.PersonaTypeHTTP && len(persona.ConfigDetails) > 0 {
+		var details models.HTTPConfigDetails
+		if err := json.Unmarshal(persona.ConfigDetails, &details); err == nil && details.UserAgent != "" {
+			policy.UserAgent = details.UserAgent
+		}
+	}
+	return policy
 }
 
 func coalesceKeywordSources(cfg *models.HTTPPhaseConfigRequest) ([]string, []string) {
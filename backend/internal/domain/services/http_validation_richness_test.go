@@ -2,6 +2,7 @@ package services
 
 import (
 	"testing"
+	"time"
 
 	"github.com/fntelecomllc/studio/backend/internal/httpvalidator"
 )
@@ -54,3 +55,27 @@ func TestEnrichFeatureVectorWithRichnessSetsRichness(t *testing.T) {
 		t.Fatalf("expected richness score to be non-negative, got %f", score)
 	}
 }
+
+func TestEnrichFeatureVectorWithTLS(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	fv := map[string]interface{}{}
+	enrichFeatureVectorWithTLS(fv, &httpvalidator.TLSInfo{
+		Version:    "TLS 1.2",
+		SANs:       []string{"example.com", "www.example.com"},
+		NotBefore:  now.AddDate(0, 0, -60),
+		NotAfter:   now.AddDate(0, 0, -2),
+		SelfSigned: true,
+	}, now)
+	if fv["has_tls"] != true || fv["tls_version"] != "TLS 1.2" || fv["tls_self_signed"] != true || fv["tls_san_count"] != 2 {
+		t.Fatalf("unexpected TLS features %v", fv)
+	}
+	if fv["tls_days_to_expiry"] != -2 || fv["tls_cert_age_days"] != 60 {
+		t.Fatalf("unexpected certificate dates %v", fv)
+	}
+
+	plain := map[string]interface{}{}
+	enrichFeatureVectorWithTLS(plain, nil, now)
+	if plain["has_tls"] != false || len(plain) != 1 {
+		t.Fatalf("expected only has_tls=false for plain HTTP, got %v", plain)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/proxymanager"
 	"github.com/google/uuid"
 )
 
@@ -205,6 +206,12 @@ type AnalysisService interface {
 	ScoreBreakdown(ctx context.Context, campaignID uuid.UUID, domain string) (map[string]float64, error)
 }
 
+// RDAPLookupService handles the optional RDAP registration lookup phase
+// Records registration status, registrar and creation/expiry dates per domain
+type RDAPLookupService interface {
+	PhaseService
+}
+
 // EventBus interface for publishing phase events
 type EventBus interface {
 	PublishProgress(ctx context.Context, progress PhaseProgress) error
@@ -227,6 +234,13 @@ type Dependencies struct {
 	Cache              Cache
 	SSE                SSE
 	StealthIntegration StealthIntegration
+	ProxyPools         ProxyPoolSelector
+}
+
+// ProxyPoolSelector hands out proxies from a proxy pool according to the pool's strategy.
+// strategy overrides the pool's own strategy when non-empty.
+type ProxyPoolSelector interface {
+	SelectorForPool(ctx context.Context, poolID uuid.UUID, strategy string) (proxymanager.ProxySelector, error)
 }
 
 // Infrastructure Adapter Interfaces
@@ -25,7 +25,7 @@ func BenchmarkMicroCrawlEnhance(b *testing.B) {
 	root := &httpvalidator.ValidationResult{FinalURL: srv.URL + "/", RawBody: []byte(body)}
 	for i := 0; i < b.N; i++ {
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		svc.microCrawlEnhance(ctx, uuid.New(), root, nil, nil, 3, 60000)
+		svc.microCrawlEnhance(ctx, uuid.New(), root, nil, nil, 3, 60000, nil)
 		cancel()
 	}
 }
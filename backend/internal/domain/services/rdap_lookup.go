@@ -0,0 +1,878 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/rdap"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+)
+
+// RegistrationLookup resolves registration data for a single domain; *rdap.Client satisfies it.
+type RegistrationLookup interface {
+	Lookup(ctx context.Context, domain string) (*rdap.Registration, error)
+}
+
+type rdapLookupService struct {
+	store             store.CampaignStore
+	deps              Dependencies
+	lookup            RegistrationLookup
+	mu                sync.RWMutex
+	statuses          map[uuid.UUID]*PhaseStatus
+	executions        map[uuid.UUID]*rdapLookupExecution
+	ctrlMu            sync.Mutex
+	controlWatchers   map[uuid.UUID]rdapControlWatcher
+	controlWatcherSeq uint64
+}
+
+type rdapLookupExecution struct {
+	campaignID    uuid.UUID
+	runID         uuid.UUID
+	cancel        context.CancelFunc
+	controlCh     <-chan ControlCommand
+	paused        bool
+	stopRequested bool
+	cancelOnce    sync.Once
+}
+
+type rdapControlWatcher struct {
+	cancel   context.CancelFunc
+	token    uint64
+	commands chan ControlCommand
+}
+
+var _ ControlAwarePhase = (*rdapLookupService)(nil)
+
+const (
+	rdapBatchSize             = 100
+	rdapProgressEmitInterval  = 25
+	rdapControlBuffer         = 8
+	rdapDefaultRequestsPerSec = 2.0
+	rdapDefaultBurst          = 2
+	rdapDefaultConcurrency    = 4
+	rdapDefaultRecheckHours   = 24 * 7
+	rdapRequestsPerSecMin     = 0.1
+	rdapRequestsPerSecMax     = 50
+	rdapBurstMax              = 50
+	rdapConcurrencyMax        = 32
+	rdapRecheckHoursMax       = 24 * 365
+	rdapScopeAll              = "all"
+	rdapScopeResolved         = "resolved"
+	rdapScopeUnresolved       = "unresolved"
+	rdapStopMessage           = "RDAP lookup stopped by user"
+	rdapStopStatusMessage     = "rdap lookup stopped by user"
+)
+
+type rdapLookupConfig struct {
+	RequestsPerSecond float64
+	Burst             int
+	Concurrency       int
+	Scope             string
+	RecheckAfterHours int
+}
+
+type rdapLookupConfigOverrides struct {
+	RequestsPerSecond *float64 `json:"requestsPerSecond,omitempty"`
+	Burst             *int     `json:"burst,omitempty"`
+	Concurrency       *int     `json:"concurrency,omitempty"`
+	Scope             *string  `json:"scope,omitempty"`
+	RecheckAfterHours *int     `json:"recheckAfterHours,omitempty"`
+}
+
+type rdapCandidate struct {
+	ID          uuid.UUID `db:"id"`
+	DomainName  string    `db:"domain_name"`
+	OffsetIndex int64     `db:"offset_index"`
+}
+
+type rdapOutcome struct {
+	candidate rdapCandidate
+	reg       *rdap.Registration
+	err       error
+}
+
+// NewRDAPLookupService constructs the optional RDAP lookup phase. Lookups are throttled per RDAP
+// server host by the lookup client; the phase configuration overrides the client's default rate.
+func NewRDAPLookupService(store store.CampaignStore, deps Dependencies, lookup RegistrationLookup) RDAPLookupService {
+	return &rdapLookupService{
+		store:           store,
+		deps:            deps,
+		lookup:          lookup,
+		statuses:        make(map[uuid.UUID]*PhaseStatus),
+		executions:      make(map[uuid.UUID]*rdapLookupExecution),
+		controlWatchers: make(map[uuid.UUID]rdapControlWatcher),
+	}
+}
+
+func (s *rdapLookupService) GetPhaseType() models.PhaseTypeEnum {
+	return models.PhaseTypeRDAPLookup
+}
+
+func (s *rdapLookupService) Configure(ctx context.Context, campaignID uuid.UUID, config interface{}) error {
+	if s.deps.Logger != nil {
+		s.deps.Logger.Info(ctx, "Configuring RDAP lookup service", map[string]interface{}{"campaign_id": campaignID})
+	}
+	raw, err := marshalEnrichmentConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rdap lookup config: %w", err)
+	}
+	sanitized, snapshot, err := sanitizeRDAPConfigPayload(raw)
+	if err != nil {
+		return fmt.Errorf("invalid rdap lookup config: %w", err)
+	}
+	if s.store != nil {
+		if err := s.store.UpdatePhaseConfiguration(ctx, s.getQuerier(), campaignID, models.PhaseTypeRDAPLookup, sanitized); err != nil {
+			return fmt.Errorf("failed to persist rdap lookup config: %w", err)
+		}
+	}
+	s.updateStatus(campaignID, func(st *PhaseStatus) {
+		if st.Status == models.PhaseStatusNotStarted {
+			st.Status = models.PhaseStatusConfigured
+		}
+		st.Configuration = snapshot
+	})
+	return nil
+}
+
+func (s *rdapLookupService) Execute(ctx context.Context, campaignID uuid.UUID) (<-chan PhaseProgress, error) {
+	if s.deps.Logger != nil {
+		s.deps.Logger.Info(ctx, "Starting RDAP lookup execution", map[string]interface{}{"campaign_id": campaignID})
+	}
+	if s.lookup == nil {
+		return nil, fmt.Errorf("rdap lookup is not configured on this server")
+	}
+	runID := uuid.Nil
+	if runCtx, ok := PhaseRunFromContext(ctx); ok {
+		runID = runCtx.RunID
+	} else if s.deps.Logger != nil {
+		s.deps.Logger.Warn(ctx, "rdap_lookup.run_context.missing", map[string]interface{}{"campaign_id": campaignID})
+	}
+
+	exec := s.getQuerier()
+	if exec == nil {
+		return nil, fmt.Errorf("rdap lookup requires database access: no querier available")
+	}
+	cfg := s.loadConfig(ctx, exec, campaignID)
+	cutoff := time.Now().Add(-time.Duration(cfg.RecheckAfterHours) * time.Hour)
+
+	total, err := s.countCandidates(ctx, exec, campaignID, cfg, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("count rdap lookup candidates: %w", err)
+	}
+
+	started := time.Now()
+	s.updateStatus(campaignID, func(st *PhaseStatus) {
+		st.Status = models.PhaseStatusInProgress
+		st.StartedAt = &started
+		st.CompletedAt = nil
+		st.ProgressPct = 0
+		st.ItemsTotal = int64(total)
+		st.ItemsProcessed = 0
+		st.LastError = ""
+	})
+
+	progressCh := make(chan PhaseProgress, 4)
+	runCtx, cancel := context.WithCancel(ctx)
+	execution := &rdapLookupExecution{campaignID: campaignID, runID: runID, cancel: cancel}
+	s.mu.Lock()
+	s.executions[campaignID] = execution
+	s.mu.Unlock()
+	s.ctrlMu.Lock()
+	if watcher, ok := s.controlWatchers[campaignID]; ok {
+		execution.controlCh = watcher.commands
+	}
+	s.ctrlMu.Unlock()
+	go s.run(runCtx, campaignID, exec, cfg, cutoff, total, progressCh, execution)
+
+	return progressCh, nil
+}
+
+func (s *rdapLookupService) GetStatus(ctx context.Context, campaignID uuid.UUID) (*PhaseStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if st, ok := s.statuses[campaignID]; ok {
+		cp := *st
+		if cp.Configuration == nil {
+			cp.Configuration = map[string]interface{}{}
+		}
+		cp.Configuration["runtime_controls"] = s.Capabilities()
+		return &cp, nil
+	}
+	return &PhaseStatus{
+		CampaignID:    campaignID,
+		Phase:         models.PhaseTypeRDAPLookup,
+		Status:        models.PhaseStatusNotStarted,
+		Configuration: map[string]interface{}{"runtime_controls": s.Capabilities()},
+	}, nil
+}
+
+func (s *rdapLookupService) Cancel(ctx context.Context, campaignID uuid.UUID) error {
+	if s.deps.Logger != nil {
+		s.deps.Logger.Warn(ctx, "RDAP lookup execution cancelled", map[string]interface{}{"campaign_id": campaignID})
+	}
+	if execution := s.getExecution(campaignID); execution != nil {
+		s.mu.Lock()
+		execution.stopRequested = true
+		s.mu.Unlock()
+		s.requestStop(execution)
+	}
+	s.updateStatus(campaignID, func(st *PhaseStatus) {
+		st.Status = models.PhaseStatusFailed
+		st.LastError = "rdap lookup cancelled"
+	})
+	return nil
+}
+
+func (s *rdapLookupService) Validate(ctx context.Context, config interface{}) error {
+	raw, err := marshalEnrichmentConfig(config)
+	if err != nil {
+		return fmt.Errorf("invalid rdap lookup config: %w", err)
+	}
+	if _, _, err := sanitizeRDAPConfigPayload(raw); err != nil {
+		return fmt.Errorf("invalid rdap lookup config: %w", err)
+	}
+	return nil
+}
+
+func (s *rdapLookupService) Capabilities() PhaseControlCapabilities {
+	return PhaseControlCapabilities{
+		CanPause:   true,
+		CanResume:  true,
+		CanStop:    true,
+		CanRestart: true,
+	}
+}
+
+func (s *rdapLookupService) run(ctx context.Context, campaignID uuid.UUID, exec store.Querier, cfg rdapLookupConfig, cutoff time.Time, total int, progressCh chan<- PhaseProgress, execution *rdapLookupExecution) {
+	defer close(progressCh)
+	defer s.clearExecution(campaignID)
+	if !s.runContextMatches(ctx, execution) {
+		s.failStaleRun(ctx, execution, 0, total, progressCh)
+		return
+	}
+
+	counts := map[string]int{}
+	examined := 0
+	s.emitProgress(ctx, campaignID, models.PhaseStatusInProgress, examined, total, "Starting RDAP lookups", progressCh)
+
+	lookupCtx := rdap.WithRateLimit(ctx, cfg.RequestsPerSecond, cfg.Burst)
+	var runErr error
+	lastOffset := int64(-1)
+	for runErr == nil && examined < total {
+		batch, err := s.fetchCandidates(ctx, exec, campaignID, cfg, cutoff, lastOffset, rdapBatchSize)
+		if err != nil {
+			runErr = fmt.Errorf("fetch rdap lookup batch after offset=%d: %w", lastOffset, err)
+			break
+		}
+		if len(batch) == 0 {
+			break
+		}
+		lastOffset = batch[len(batch)-1].OffsetIndex
+
+		for start := 0; start < len(batch) && runErr == nil; start += cfg.Concurrency {
+			if s.interrupted(ctx, execution, examined, total, progressCh) {
+				return
+			}
+			end := start + cfg.Concurrency
+			if end > len(batch) {
+				end = len(batch)
+			}
+			outcomes := s.lookupGroup(lookupCtx, batch[start:end])
+			// Lookups cut short by stop/cancel are not recorded.
+			if ctx.Err() != nil && s.interrupted(ctx, execution, examined, total, progressCh) {
+				return
+			}
+			for _, outcome := range outcomes {
+				status, err := s.recordOutcome(ctx, exec, outcome)
+				if err != nil {
+					runErr = fmt.Errorf("record rdap result for domain %s: %w", outcome.candidate.DomainName, err)
+					break
+				}
+				counts[status]++
+				examined++
+				if examined%rdapProgressEmitInterval == 0 || examined == total {
+					s.emitProgress(ctx, campaignID, models.PhaseStatusInProgress, examined, total, fmt.Sprintf("Looked up %d/%d domains", examined, total), progressCh)
+				}
+			}
+		}
+	}
+
+	if !s.runContextMatches(ctx, execution) {
+		s.failStaleRun(ctx, execution, examined, total, progressCh)
+		return
+	}
+	// Every lookup failing points at connectivity or the bootstrap source rather than the domains.
+	if runErr == nil && examined > 0 && counts[models.RDAPStatusError] == examined {
+		runErr = fmt.Errorf("all %d RDAP lookups failed", examined)
+	}
+	if runErr != nil {
+		s.failWithError(campaignID, runErr)
+		s.emitProgress(ctx, campaignID, models.PhaseStatusFailed, examined, total, runErr.Error(), progressCh)
+		if s.deps.Logger != nil {
+			s.deps.Logger.Error(ctx, "RDAP lookup execution failed", runErr, map[string]interface{}{"campaign_id": campaignID})
+		}
+		return
+	}
+
+	completed := time.Now()
+	s.updateStatus(campaignID, func(st *PhaseStatus) {
+		st.Status = models.PhaseStatusCompleted
+		st.CompletedAt = &completed
+		st.ProgressPct = 100
+		st.ItemsProcessed = int64(examined)
+	})
+	summary := fmt.Sprintf("looked_up=%d registered=%d unregistered=%d unsupported=%d error=%d", examined,
+		counts[models.RDAPStatusRegistered], counts[models.RDAPStatusUnregistered], counts[models.RDAPStatusUnsupported], counts[models.RDAPStatusError])
+	s.emitProgress(ctx, campaignID, models.PhaseStatusCompleted, examined, total, summary, progressCh)
+	if s.deps.Logger != nil {
+		s.deps.Logger.Info(ctx, "RDAP lookup execution completed", map[string]interface{}{
+			"campaign_id":  campaignID,
+			"looked_up":    examined,
+			"registered":   counts[models.RDAPStatusRegistered],
+			"unregistered": counts[models.RDAPStatusUnregistered],
+			"unsupported":  counts[models.RDAPStatusUnsupported],
+			"errors":       counts[models.RDAPStatusError],
+		})
+	}
+	if s.store != nil {
+		if err := s.store.CompletePhase(ctx, exec, campaignID, models.PhaseTypeRDAPLookup); err != nil && s.deps.Logger != nil {
+			s.deps.Logger.Warn(ctx, "Failed to mark rdap lookup phase complete", map[string]interface{}{"campaign_id": campaignID, "error": err.Error()})
+		}
+	}
+}
+
+// interrupted drains pending control signals and reports whether the run must end, emitting the
+// terminal progress event when it does.
+func (s *rdapLookupService) interrupted(ctx context.Context, execution *rdapLookupExecution, examined, total int, progressCh chan<- PhaseProgress) bool {
+	campaignID := execution.campaignID
+	if !s.runContextMatches(ctx, execution) {
+		s.failStaleRun(ctx, execution, examined, total, progressCh)
+		return true
+	}
+	if s.processPendingControlSignals(ctx, execution) {
+		s.failWithError(campaignID, errors.New(rdapStopMessage))
+		s.emitProgress(ctx, campaignID, models.PhaseStatusFailed, examined, total, rdapStopMessage, progressCh)
+		return true
+	}
+	if err := ctx.Err(); err != nil {
+		if s.isStopRequested(execution) {
+			s.failWithError(campaignID, errors.New(rdapStopMessage))
+			s.emitProgress(ctx, campaignID, models.PhaseStatusFailed, examined, total, rdapStopMessage, progressCh)
+		} else {
+			s.failWithError(campaignID, err)
+			s.emitProgress(ctx, campaignID, models.PhaseStatusFailed, examined, total, "RDAP lookup cancelled", progressCh)
+		}
+		return true
+	}
+	return false
+}
+
+// lookupGroup runs one lookup per candidate concurrently and returns outcomes in candidate order.
+func (s *rdapLookupService) lookupGroup(ctx context.Context, group []rdapCandidate) []rdapOutcome {
+	outcomes := make([]rdapOutcome, len(group))
+	var wg sync.WaitGroup
+	for i, candidate := range group {
+		wg.Add(1)
+		go func(i int, candidate rdapCandidate) {
+			defer wg.Done()
+			reg, err := s.lookup.Lookup(ctx, candidate.DomainName)
+			outcomes[i] = rdapOutcome{candidate: candidate, reg: reg, err: err}
+		}(i, candidate)
+	}
+	wg.Wait()
+	return outcomes
+}
+
+// recordOutcome persists one lookup. Failed lookups never overwrite an earlier successful result;
+// they are retried once the recheck window for that result lapses.
+func (s *rdapLookupService) recordOutcome(ctx context.Context, exec store.Querier, outcome rdapOutcome) (string, error) {
+	if outcome.err != nil {
+		status := models.RDAPStatusError
+		if errors.Is(outcome.err, rdap.ErrNoServer) {
+			status = models.RDAPStatusUnsupported
+		}
+		_, err := exec.ExecContext(ctx, `UPDATE generated_domains
+			SET rdap_status = $2, rdap_checked_at = NOW()
+			WHERE id = $1 AND (rdap_status IS NULL OR rdap_status IN ('error', 'unsupported'))`,
+			outcome.candidate.ID, status)
+		return status, err
+	}
+	reg := outcome.reg
+	status := models.RDAPStatusUnregistered
+	var registrar *string
+	if reg != nil && reg.Registered {
+		status = models.RDAPStatusRegistered
+		if reg.Registrar != "" {
+			registrar = &reg.Registrar
+		}
+	}
+	var registeredAt, expiresAt *time.Time
+	if reg != nil {
+		registeredAt, expiresAt = reg.RegisteredAt, reg.ExpiresAt
+	}
+	_, err := exec.ExecContext(ctx, `UPDATE generated_domains
+		SET rdap_status = $2, rdap_registrar = $3, rdap_registered_at = $4, rdap_expires_at = $5, rdap_checked_at = NOW()
+		WHERE id = $1`,
+		outcome.candidate.ID, status, registrar, registeredAt, expiresAt)
+	return status, err
+}
+
+func rdapScopeCondition(scope string) string {
+	switch scope {
+	case rdapScopeResolved:
+		return " AND dns_status = 'ok'"
+	case rdapScopeUnresolved:
+		return " AND (dns_status IS NULL OR dns_status <> 'ok')"
+	default:
+		return ""
+	}
+}
+
+func (s *rdapLookupService) countCandidates(ctx context.Context, exec store.Querier, campaignID uuid.UUID, cfg rdapLookupConfig, cutoff time.Time) (int, error) {
+	var total int
+	query := `SELECT COUNT(*) FROM generated_domains
+		WHERE campaign_id = $1 AND (rdap_checked_at IS NULL OR rdap_checked_at < $2)` + rdapScopeCondition(cfg.Scope)
+	if err := exec.GetContext(ctx, &total, query, campaignID, cutoff); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (s *rdapLookupService) fetchCandidates(ctx context.Context, exec store.Querier, campaignID uuid.UUID, cfg rdapLookupConfig, cutoff time.Time, afterOffset int64, limit int) ([]rdapCandidate, error) {
+	batch := []rdapCandidate{}
+	query := `SELECT id, domain_name, offset_index FROM generated_domains
+		WHERE campaign_id = $1 AND (rdap_checked_at IS NULL OR rdap_checked_at < $2) AND offset_index > $3` + rdapScopeCondition(cfg.Scope) + `
+		ORDER BY offset_index ASC
+		LIMIT $4`
+	if err := exec.SelectContext(ctx, &batch, query, campaignID, cutoff, afterOffset, limit); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+func (s *rdapLookupService) loadConfig(ctx context.Context, exec store.Querier, campaignID uuid.UUID) rdapLookupConfig {
+	cfg := defaultRDAPLookupConfig()
+	if s.store == nil {
+		return cfg
+	}
+	raw, err := s.store.GetPhaseConfig(ctx, exec, campaignID, models.PhaseTypeRDAPLookup)
+	if err != nil {
+		if s.deps.Logger != nil {
+			s.deps.Logger.Warn(ctx, "Failed to load rdap lookup config, using defaults", map[string]interface{}{"campaign_id": campaignID, "error": err.Error()})
+		}
+		return cfg
+	}
+	if raw == nil {
+		return cfg
+	}
+	payload := bytes.TrimSpace([]byte(*raw))
+	if len(payload) == 0 || bytes.Equal(payload, []byte("null")) {
+		return cfg
+	}
+	var overrides rdapLookupConfigOverrides
+	if err := json.Unmarshal(payload, &overrides); err != nil {
+		if s.deps.Logger != nil {
+			s.deps.Logger.Warn(ctx, "Invalid rdap lookup config payload, using defaults", map[string]interface{}{"campaign_id": campaignID, "error": err.Error()})
+		}
+		return cfg
+	}
+	_ = cfg.applyOverrides(overrides)
+	return cfg
+}
+
+func defaultRDAPLookupConfig() rdapLookupConfig {
+	return rdapLookupConfig{
+		RequestsPerSecond: rdapDefaultRequestsPerSec,
+		Burst:             rdapDefaultBurst,
+		Concurrency:       rdapDefaultConcurrency,
+		Scope:             rdapScopeAll,
+		RecheckAfterHours: rdapDefaultRecheckHours,
+	}
+}
+
+func (cfg *rdapLookupConfig) applyOverrides(overrides rdapLookupConfigOverrides) error {
+	if overrides.RequestsPerSecond != nil {
+		cfg.RequestsPerSecond = clampFloat(*overrides.RequestsPerSecond, rdapRequestsPerSecMin, rdapRequestsPerSecMax)
+	}
+	if overrides.Burst != nil {
+		cfg.Burst = clampInt(*overrides.Burst, 1, rdapBurstMax)
+	}
+	if overrides.Concurrency != nil {
+		cfg.Concurrency = clampInt(*overrides.Concurrency, 1, rdapConcurrencyMax)
+	}
+	if overrides.Scope != nil {
+		scope := strings.ToLower(strings.TrimSpace(*overrides.Scope))
+		switch scope {
+		case rdapScopeAll, rdapScopeResolved, rdapScopeUnresolved:
+			cfg.Scope = scope
+		default:
+			return fmt.Errorf("scope must be one of %s, %s, %s", rdapScopeAll, rdapScopeResolved, rdapScopeUnresolved)
+		}
+	}
+	if overrides.RecheckAfterHours != nil {
+		cfg.RecheckAfterHours = clampInt(*overrides.RecheckAfterHours, 0, rdapRecheckHoursMax)
+	}
+	return nil
+}
+
+func sanitizeRDAPConfigPayload(raw json.RawMessage) (json.RawMessage, map[string]interface{}, error) {
+	cfg := defaultRDAPLookupConfig()
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && !bytes.Equal(trimmed, []byte("null")) {
+		var overrides rdapLookupConfigOverrides
+		if err := json.Unmarshal(trimmed, &overrides); err != nil {
+			return nil, nil, err
+		}
+		if err := cfg.applyOverrides(overrides); err != nil {
+			return nil, nil, err
+		}
+	}
+	snapshot := map[string]interface{}{
+		"requestsPerSecond": cfg.RequestsPerSecond,
+		"burst":             cfg.Burst,
+		"concurrency":       cfg.Concurrency,
+		"scope":             cfg.Scope,
+		"recheckAfterHours": cfg.RecheckAfterHours,
+	}
+	sanitized, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, nil, err
+	}
+	return json.RawMessage(sanitized), snapshot, nil
+}
+
+func (s *rdapLookupService) emitProgress(ctx context.Context, campaignID uuid.UUID, status models.PhaseStatusEnum, processed, total int, message string, progressCh chan<- PhaseProgress) {
+	var progress float64
+	if total > 0 {
+		progress = math.Round(math.Min(math.Max(float64(processed)/float64(total), 0), 1)*1000) / 10
+	} else if status == models.PhaseStatusCompleted {
+		progress = 100
+	}
+	s.updateStatus(campaignID, func(st *PhaseStatus) {
+		st.Status = status
+		st.ProgressPct = progress
+		st.ItemsTotal = int64(total)
+		st.ItemsProcessed = int64(processed)
+	})
+	progressMsg := PhaseProgress{
+		CampaignID:     campaignID,
+		Phase:          models.PhaseTypeRDAPLookup,
+		Status:         status,
+		ProgressPct:    progress,
+		ItemsProcessed: int64(processed),
+		ItemsTotal:     int64(total),
+		Message:        message,
+		Timestamp:      time.Now(),
+	}
+	select {
+	case <-ctx.Done():
+		return
+	case progressCh <- progressMsg:
+	default:
+	}
+	if s.deps.EventBus != nil {
+		_ = s.deps.EventBus.PublishProgress(ctx, progressMsg)
+	}
+}
+
+func (s *rdapLookupService) getQuerier() store.Querier {
+	if q, ok := s.deps.DB.(store.Querier); ok && q != nil {
+		return q
+	}
+	if s.store != nil {
+		if db := s.store.UnderlyingDB(); db != nil {
+			return db
+		}
+	}
+	return nil
+}
+
+func (s *rdapLookupService) getExecution(campaignID uuid.UUID) *rdapLookupExecution {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.executions[campaignID]
+}
+
+func (s *rdapLookupService) clearExecution(campaignID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.executions, campaignID)
+	s.mu.Unlock()
+}
+
+func (s *rdapLookupService) failWithError(campaignID uuid.UUID, err error) {
+	s.updateStatus(campaignID, func(st *PhaseStatus) {
+		st.Status = models.PhaseStatusFailed
+		st.LastError = err.Error()
+	})
+}
+
+func (s *rdapLookupService) updateStatus(campaignID uuid.UUID, mutate func(*PhaseStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.statuses[campaignID]
+	if !ok {
+		st = &PhaseStatus{CampaignID: campaignID, Phase: models.PhaseTypeRDAPLookup, Status: models.PhaseStatusNotStarted}
+		s.statuses[campaignID] = st
+	}
+	if mutate != nil {
+		mutate(st)
+	}
+}
+
+// AttachControlChannel wires the orchestrator control bus into RDAP lookup execution.
+func (s *rdapLookupService) AttachControlChannel(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum, commands <-chan ControlCommand) {
+	if phase != models.PhaseTypeRDAPLookup || commands == nil {
+		return
+	}
+	controlCtx, cancel := context.WithCancel(context.Background())
+	downstream := make(chan ControlCommand, rdapControlBuffer)
+	token := s.registerControlWatcher(campaignID, cancel, downstream)
+	go s.consumeControlSignals(controlCtx, campaignID, token, commands, downstream)
+}
+
+func (s *rdapLookupService) registerControlWatcher(campaignID uuid.UUID, cancel context.CancelFunc, downstream chan ControlCommand) uint64 {
+	s.ctrlMu.Lock()
+	s.controlWatcherSeq++
+	token := s.controlWatcherSeq
+	if existing, ok := s.controlWatchers[campaignID]; ok && existing.cancel != nil {
+		existing.cancel()
+	}
+	s.controlWatchers[campaignID] = rdapControlWatcher{cancel: cancel, token: token, commands: downstream}
+	s.ctrlMu.Unlock()
+	s.mu.Lock()
+	if exec, ok := s.executions[campaignID]; ok {
+		exec.controlCh = downstream
+	}
+	s.mu.Unlock()
+	return token
+}
+
+func (s *rdapLookupService) clearControlWatcher(campaignID uuid.UUID, token uint64, downstream chan ControlCommand) {
+	s.ctrlMu.Lock()
+	if current, ok := s.controlWatchers[campaignID]; ok && current.token == token {
+		delete(s.controlWatchers, campaignID)
+	}
+	s.ctrlMu.Unlock()
+	s.mu.Lock()
+	if exec, ok := s.executions[campaignID]; ok && exec.controlCh == downstream {
+		exec.controlCh = nil
+	}
+	s.mu.Unlock()
+}
+
+func (s *rdapLookupService) consumeControlSignals(ctx context.Context, campaignID uuid.UUID, token uint64, upstream <-chan ControlCommand, downstream chan ControlCommand) {
+	defer func() {
+		close(downstream)
+		s.clearControlWatcher(campaignID, token, downstream)
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case cmd, ok := <-upstream:
+			if !ok {
+				return
+			}
+			if err := s.dispatchControlCommand(campaignID, downstream, cmd); err != nil {
+				ackControlCommand(cmd, err)
+			}
+		}
+	}
+}
+
+func (s *rdapLookupService) dispatchControlCommand(campaignID uuid.UUID, downstream chan ControlCommand, cmd ControlCommand) error {
+	s.mu.RLock()
+	execution := s.executions[campaignID]
+	status := models.PhaseStatusNotStarted
+	if st, ok := s.statuses[campaignID]; ok && st != nil {
+		status = st.Status
+	}
+	var controlCh <-chan ControlCommand
+	if execution != nil {
+		controlCh = execution.controlCh
+	}
+	s.mu.RUnlock()
+	if execution == nil {
+		return fmt.Errorf("%w: no rdap lookup execution found for campaign %s", ErrPhaseExecutionMissing, campaignID)
+	}
+	if controlCh != downstream {
+		return fmt.Errorf("rdap lookup control channel not bound for campaign %s", campaignID)
+	}
+	if status != models.PhaseStatusInProgress && status != models.PhaseStatusPaused {
+		return ErrPhaseNotRunning
+	}
+	select {
+	case downstream <- cmd:
+		return nil
+	default:
+		return fmt.Errorf("rdap lookup control channel backpressure for campaign %s", campaignID)
+	}
+}
+
+func ackControlCommand(cmd ControlCommand, err error) {
+	if cmd.Ack != nil {
+		cmd.Ack <- err
+	}
+}
+
+func (s *rdapLookupService) processPendingControlSignals(ctx context.Context, execution *rdapLookupExecution) bool {
+	s.mu.RLock()
+	controlCh := execution.controlCh
+	s.mu.RUnlock()
+	if controlCh == nil {
+		return false
+	}
+	for {
+		select {
+		case cmd, ok := <-controlCh:
+			if !ok {
+				return s.isStopRequested(execution)
+			}
+			if s.handleControlCommand(ctx, execution, controlCh, cmd) {
+				return true
+			}
+		default:
+			return false
+		}
+	}
+}
+
+// handleControlCommand applies one signal and reports whether the run must stop. Pause blocks here
+// until resume or stop arrives so in-flight rate limiter tokens are not wasted on abandoned lookups.
+func (s *rdapLookupService) handleControlCommand(ctx context.Context, execution *rdapLookupExecution, controlCh <-chan ControlCommand, cmd ControlCommand) bool {
+	switch cmd.Signal {
+	case ControlSignalPause:
+		s.setPaused(execution, true)
+		ackControlCommand(cmd, nil)
+		for {
+			select {
+			case <-ctx.Done():
+				s.failWithError(execution.campaignID, errors.New("execution cancelled while paused"))
+				return true
+			case next, ok := <-controlCh:
+				if !ok {
+					if s.isStopRequested(execution) {
+						return true
+					}
+					continue
+				}
+				switch next.Signal {
+				case ControlSignalResume:
+					s.setPaused(execution, false)
+					ackControlCommand(next, nil)
+					return false
+				case ControlSignalStop:
+					s.stop(execution)
+					ackControlCommand(next, nil)
+					return true
+				case ControlSignalPause:
+					ackControlCommand(next, nil)
+				default:
+					ackControlCommand(next, fmt.Errorf("unknown control signal: %s", next.Signal))
+				}
+			}
+		}
+	case ControlSignalResume:
+		s.setPaused(execution, false)
+		ackControlCommand(cmd, nil)
+		return false
+	case ControlSignalStop:
+		s.stop(execution)
+		ackControlCommand(cmd, nil)
+		return true
+	default:
+		ackControlCommand(cmd, fmt.Errorf("unknown control signal: %s", cmd.Signal))
+		return false
+	}
+}
+
+func (s *rdapLookupService) setPaused(execution *rdapLookupExecution, paused bool) {
+	s.mu.Lock()
+	changed := execution.paused != paused
+	execution.paused = paused
+	s.mu.Unlock()
+	if !changed {
+		return
+	}
+	status := models.PhaseStatusInProgress
+	if paused {
+		status = models.PhaseStatusPaused
+	}
+	s.updateStatus(execution.campaignID, func(st *PhaseStatus) { st.Status = status })
+	if s.store == nil {
+		return
+	}
+	if exec := s.getQuerier(); exec != nil {
+		if paused {
+			_ = s.store.PausePhase(context.Background(), exec, execution.campaignID, models.PhaseTypeRDAPLookup)
+		} else {
+			_ = s.store.UpdatePhaseStatus(context.Background(), exec, execution.campaignID, models.PhaseTypeRDAPLookup, models.PhaseStatusInProgress)
+		}
+	}
+}
+
+func (s *rdapLookupService) stop(execution *rdapLookupExecution) {
+	s.mu.Lock()
+	execution.stopRequested = true
+	s.mu.Unlock()
+	s.requestStop(execution)
+	s.updateStatus(execution.campaignID, func(st *PhaseStatus) {
+		st.Status = models.PhaseStatusFailed
+		st.LastError = rdapStopStatusMessage
+	})
+	if s.store != nil {
+		if exec := s.getQuerier(); exec != nil {
+			_ = s.store.FailPhase(context.Background(), exec, execution.campaignID, models.PhaseTypeRDAPLookup, rdapStopStatusMessage, nil)
+		}
+	}
+}
+
+func (s *rdapLookupService) isStopRequested(execution *rdapLookupExecution) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return execution != nil && execution.stopRequested
+}
+
+func (s *rdapLookupService) requestStop(execution *rdapLookupExecution) {
+	if execution == nil {
+		return
+	}
+	execution.cancelOnce.Do(func() {
+		if execution.cancel != nil {
+			execution.cancel()
+		}
+	})
+}
+
+func (s *rdapLookupService) runContextMatches(ctx context.Context, execution *rdapLookupExecution) bool {
+	if execution == nil {
+		return true
+	}
+	runCtx, ok := PhaseRunFromContext(ctx)
+	if !ok || runCtx.RunID == uuid.Nil || execution.runID == uuid.Nil {
+		return true
+	}
+	return runCtx.RunID == execution.runID
+}
+
+func (s *rdapLookupService) failStaleRun(ctx context.Context, execution *rdapLookupExecution, processed, total int, progressCh chan<- PhaseProgress) {
+	if execution == nil {
+		return
+	}
+	if s.deps.Logger != nil {
+		s.deps.Logger.Warn(ctx, "rdap_lookup.run_context.stale", map[string]interface{}{"campaign_id": execution.campaignID})
+	}
+	s.failWithError(execution.campaignID, errors.New(staleExecutionMessage))
+	s.emitProgress(ctx, execution.campaignID, models.PhaseStatusFailed, processed, total, staleExecutionMessage, progressCh)
+	s.requestStop(execution)
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/rdap"
+)
+
+type fakeRegistrationLookup map[string]*rdap.Registration
+
+func (f fakeRegistrationLookup) Lookup(ctx context.Context, domain string) (*rdap.Registration, error) {
+	if domain == "example.zz" {
+		return nil, fmt.Errorf("%w: %s", rdap.ErrNoServer, domain)
+	}
+	if reg, ok := f[domain]; ok {
+		return reg, nil
+	}
+	return &rdap.Registration{Domain: domain, Registered: false}, nil
+}
+
+func TestRDAPLookupService_RecordsRegistrationData(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	exec := sqlx.NewDb(db, "postgres")
+
+	campaignID := uuid.New()
+	registeredAt := time.Date(2004, 6, 1, 0, 0, 0, 0, time.UTC)
+	stub := &stubCampaignStore{}
+	svc := NewRDAPLookupService(stub, Dependencies{Logger: &minimalLogger{}}, fakeRegistrationLookup{
+		"taken.com": {Domain: "taken.com", Registered: true, Registrar: "Example Registrar", RegisteredAt: &registeredAt},
+	}).(*rdapLookupService)
+
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	cutoff := time.Now().Add(-time.Hour)
+	mock.ExpectQuery(`SELECT id, domain_name, offset_index FROM generated_domains\s+WHERE campaign_id = \$1 AND \(rdap_checked_at IS NULL OR rdap_checked_at < \$2\) AND offset_index > \$3 AND dns_status = 'ok'`).
+		WithArgs(campaignID, cutoff, int64(-1), rdapBatchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "domain_name", "offset_index"}).
+			AddRow(ids[0], "taken.com", 0).
+			AddRow(ids[1], "free.com", 1).
+			AddRow(ids[2], "example.zz", 2))
+	mock.ExpectExec(`UPDATE generated_domains\s+SET rdap_status = \$2, rdap_registrar = \$3`).
+		WithArgs(ids[0], models.RDAPStatusRegistered, "Example Registrar", registeredAt, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE generated_domains\s+SET rdap_status = \$2, rdap_registrar = \$3`).
+		WithArgs(ids[1], models.RDAPStatusUnregistered, nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE generated_domains\s+SET rdap_status = \$2, rdap_checked_at = NOW\(\)\s+WHERE id = \$1 AND \(rdap_status IS NULL`).
+		WithArgs(ids[2], models.RDAPStatusUnsupported).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	cfg := defaultRDAPLookupConfig()
+	cfg.Scope = rdapScopeResolved
+	execution := &rdapLookupExecution{campaignID: campaignID}
+	svc.executions[campaignID] = execution
+	progressCh := make(chan PhaseProgress, 16)
+	svc.run(context.Background(), campaignID, exec, cfg, cutoff, 3, progressCh, execution)
+	var last PhaseProgress
+	for p := range progressCh {
+		last = p
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+	if last.Status != models.PhaseStatusCompleted || last.ItemsProcessed != 3 {
+		t.Fatalf("expected completed progress for 3 domains, got %+v", last)
+	}
+	if !stub.completed {
+		t.Fatal("expected phase to be marked complete")
+	}
+}
+
+func TestSanitizeRDAPConfigPayload(t *testing.T) {
+	raw, snapshot, err := sanitizeRDAPConfigPayload([]byte(`{"requestsPerSecond":500,"concurrency":0,"scope":"Unresolved"}`))
+	if err != nil {
+		t.Fatalf("sanitize: %v", err)
+	}
+	if snapshot["requestsPerSecond"] != float64(rdapRequestsPerSecMax) || snapshot["concurrency"] != 1 || snapshot["scope"] != rdapScopeUnresolved {
+		t.Fatalf("unexpected snapshot %v (raw %s)", snapshot, raw)
+	}
+	if _, _, err := sanitizeRDAPConfigPayload([]byte(`{"scope":"registered"}`)); err == nil {
+		t.Fatal("expected unknown scope to be rejected")
+	}
+}
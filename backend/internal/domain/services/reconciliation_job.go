@@ -72,7 +72,7 @@ func runCountersReconciliation(ctx context.Context, db *sqlx.DB, logger Logger,
          COUNT(*) AS total,
          COUNT(*) FILTER (WHERE dns_status='pending') AS dns_pending,
          COUNT(*) FILTER (WHERE dns_status='ok') AS dns_ok,
-         COUNT(*) FILTER (WHERE dns_status='error') AS dns_error,
+         COUNT(*) FILTER (WHERE dns_status IN ('error','wildcard')) AS dns_error,
          COUNT(*) FILTER (WHERE dns_status='timeout') AS dns_timeout,
          COUNT(*) FILTER (WHERE http_status='pending') AS http_pending,
          COUNT(*) FILTER (WHERE http_status='ok') AS http_ok,
@@ -188,7 +188,7 @@ JOIN campaign_domain_counters c ON c.campaign_id = a.campaign_id;`
 	COUNT(*) AS total,
 	COUNT(*) FILTER (WHERE dns_status='pending') AS dns_pending,
 	COUNT(*) FILTER (WHERE dns_status='ok') AS dns_ok,
-	COUNT(*) FILTER (WHERE dns_status='error') AS dns_error,
+	COUNT(*) FILTER (WHERE dns_status IN ('error','wildcard')) AS dns_error,
 	COUNT(*) FILTER (WHERE dns_status='timeout') AS dns_timeout,
 	COUNT(*) FILTER (WHERE http_status='pending') AS http_pending,
 	COUNT(*) FILTER (WHERE http_status='ok') AS http_ok,
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"os"
 	"testing"
@@ -54,3 +55,23 @@ func TestScoreBreakdown_WithTFLite(t *testing.T) {
 		t.Fatalf("unmet expectations: %v", err)
 	}
 }
+
+func TestScoreBreakdownCalculator_DomainAge(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	calc := &ScoreBreakdownCalculator{
+		weights:             map[string]float64{"keyword_density_weight": 0.5, "domain_age_weight": 0.5},
+		parkedPenaltyFactor: 0.5,
+		now:                 now,
+	}
+	registered := now.AddDate(-5, 0, 0)
+	bd := calc.Breakdown(json.RawMessage(`{}`), nil, sql.NullBool{}, sql.NullFloat64{}, &registered)
+	if bd["domain_age"] < 0.49 || bd["domain_age"] > 0.51 {
+		t.Fatalf("expected domain_age ~0.5 for a 5 year old domain, got %v", bd["domain_age"])
+	}
+	if bd["final"] < 0.24 || bd["final"] > 0.26 {
+		t.Fatalf("expected final ~0.25, got %v", bd["final"])
+	}
+	if bd := calc.Breakdown(json.RawMessage(`{}`), nil, sql.NullBool{}, sql.NullFloat64{}, nil); bd["domain_age"] != 0 {
+		t.Fatalf("expected unknown registration date to score 0, got %v", bd["domain_age"])
+	}
+}
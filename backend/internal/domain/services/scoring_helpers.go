@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"time"
 )
 
 // Allowed scoring weight keys and default weights (sum does not have to be 1; we will normalize internally)
@@ -18,6 +19,20 @@ var DefaultScoringWeights = map[string]float64{
 	"title_keyword_weight":           0.10,
 	"freshness_weight":               0.10,
 	"tf_lite_weight":                 0.00, // experimental; default 0 (off)
+	"domain_age_weight":              0.00, // opt-in; needs rdap_lookup registration dates
+}
+
+// domainAgeFullScoreYears is the registration age at which the domain age component saturates.
+const domainAgeFullScoreYears = 10.0
+
+// registrationAgeScore maps an RDAP registration date to [0,1], linear up to domainAgeFullScoreYears.
+// Domains without a known registration date score 0.
+func registrationAgeScore(registeredAt *time.Time, now time.Time) float64 {
+	if registeredAt == nil || registeredAt.IsZero() {
+		return 0
+	}
+	years := now.Sub(*registeredAt).Hours() / (24 * 365.25)
+	return clamp(years/domainAgeFullScoreYears, 0, 1)
 }
 
 // ValidateScoringWeights ensures keys are a subset of allowed and each value is in [0,1].
@@ -108,3 +123,22 @@ func normalizeDefaults() map[string]float64 {
 	}
 	return out
 }
+
+// loadRegistrationDates returns RDAP registration dates for the campaign's domains keyed by domain name.
+func loadRegistrationDates(ctx context.Context, db *sql.DB, campaignID interface{}) (map[string]time.Time, error) {
+	rows, err := db.QueryContext(ctx, `SELECT domain_name, rdap_registered_at FROM generated_domains WHERE campaign_id = $1 AND rdap_registered_at IS NOT NULL`, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("load registration dates: %w", err)
+	}
+	defer rows.Close()
+	out := make(map[string]time.Time)
+	for rows.Next() {
+		var domain string
+		var ts time.Time
+		if err := rows.Scan(&domain, &ts); err != nil {
+			return nil, fmt.Errorf("scan registration date: %w", err)
+		}
+		out[domain] = ts
+	}
+	return out, rows.Err()
+}
@@ -22,6 +22,8 @@ type GenerateDomainGenerationPhaseConfigHashInput struct {
 	CharacterSet         string
 	ConstantString       string
 	TLD                  string
+	Template             string
+	WordLists            []models.DomainWordListRef
 }
 
 // GenerateDomainGenerationPhaseConfigHashResult holds the generated hash and the normalized params used.
@@ -35,8 +37,9 @@ type GenerateDomainGenerationPhaseConfigHashResult struct {
 // It returns the hex-encoded SHA256 hash string and the normalized parameters used for hashing.
 func GenerateDomainGenerationPhaseConfigHash(params models.DomainGenerationCampaignParams) (*GenerateDomainGenerationPhaseConfigHashResult, error) {
 	// Normalize CharacterSet: convert to lowercase and sort characters
+	isWordPattern := params.Template != "" || len(params.WordLists) > 0
 	charSetValue := params.CharacterSet
-	if charSetValue == "" {
+	if charSetValue == "" && !isWordPattern {
 		slog.Warn("GenerateDomainGenerationPhaseConfigHash: CharacterSet is empty, using empty string for hashing.")
 	}
 	charSet := strings.ToLower(charSetValue)
@@ -45,9 +48,27 @@ func GenerateDomainGenerationPhaseConfigHash(params models.DomainGenerationCampa
 	normalizedCharSet := strings.Join(chars, "")
 
 	// Normalize TLD: convert to lowercase and remove leading/trailing dots if any, ensure single leading dot.
-	normalizedTLD := strings.ToLower(strings.Trim(params.TLD, "."))
-	if normalizedTLD != "" && !strings.HasPrefix(normalizedTLD, ".") {
-		normalizedTLD = "." + normalizedTLD
+	normalizedTLD := normalizeHashTLD(params.TLD)
+
+	// Multi-TLD fan-out: de-duplicate while keeping order (TLD index is the outermost offset dimension).
+	// A single distinct TLD hashes exactly like the plain TLD field so existing offsets carry over.
+	var normalizedTLDs []string
+	if len(params.TLDs) > 0 {
+		seen := make(map[string]bool, len(params.TLDs))
+		for _, tld := range params.TLDs {
+			n := normalizeHashTLD(tld)
+			if n == "" || seen[n] {
+				continue
+			}
+			seen[n] = true
+			normalizedTLDs = append(normalizedTLDs, n)
+		}
+		if len(normalizedTLDs) > 0 {
+			normalizedTLD = normalizedTLDs[0]
+		}
+		if len(normalizedTLDs) < 2 {
+			normalizedTLDs = nil
+		}
 	}
 
 	prefixLen := 0
@@ -58,7 +79,7 @@ func GenerateDomainGenerationPhaseConfigHash(params models.DomainGenerationCampa
 	if params.SuffixVariableLength.Valid {
 		suffixLen = int(params.SuffixVariableLength.Int32)
 	}
-	if prefixLen == 0 && suffixLen == 0 {
+	if prefixLen == 0 && suffixLen == 0 && !isWordPattern {
 		slog.Warn("GenerateDomainGenerationPhaseConfigHash: both prefix and suffix lengths are 0; hashing will treat as 0 values.")
 	}
 
@@ -76,6 +97,22 @@ func GenerateDomainGenerationPhaseConfigHash(params models.DomainGenerationCampa
 		CharacterSet:         normalizedCharSet,
 		ConstantString:       constStrValue,
 		TLD:                  normalizedTLD,
+		TLDs:                 normalizedTLDs,
+	}
+
+	// Word-list patterns: hash the template and each slot's content checksum (not the list ID) so that
+	// editing a list starts a fresh offset space. Slots are sorted since the template fixes their order.
+	if isWordPattern {
+		normalizedParams.Template = strings.ToLower(strings.TrimSpace(params.Template))
+		refs := make([]models.NormalizedWordListRef, 0, len(params.WordLists))
+		for _, ref := range params.WordLists {
+			if ref.Checksum == "" {
+				return nil, fmt.Errorf("word list %s for slot %q has no checksum; resolve word lists before hashing", ref.WordListID, ref.Slot)
+			}
+			refs = append(refs, models.NormalizedWordListRef{Slot: strings.ToLower(ref.Slot), Checksum: ref.Checksum})
+		}
+		sort.Slice(refs, func(i, j int) bool { return refs[i].Slot < refs[j].Slot })
+		normalizedParams.WordLists = refs
 	}
 
 	// Marshal the normalized struct to JSON for hashing
@@ -95,3 +132,11 @@ func GenerateDomainGenerationPhaseConfigHash(params models.DomainGenerationCampa
 		NormalizedParams: normalizedParams,
 	}, nil
 }
+
+func normalizeHashTLD(tld string) string {
+	normalized := strings.ToLower(strings.Trim(tld, "."))
+	if normalized != "" && !strings.HasPrefix(normalized, ".") {
+		normalized = "." + normalized
+	}
+	return normalized
+}
@@ -16,6 +16,9 @@ const (
 	PatternPrefix CampaignPatternType = "prefix_variable" // [VARIABLE][CONSTANT][TLD]
 	PatternSuffix CampaignPatternType = "suffix_variable" // [CONSTANT][VARIABLE][TLD]
 	PatternBoth   CampaignPatternType = "both_variable"   // [VARIABLE][CONSTANT][VARIABLE][TLD]
+
+	PatternWordList     CampaignPatternType = "word_list"     // [WORD1][CONSTANT][WORD2]...[TLD]
+	PatternWordTemplate CampaignPatternType = "word_template" // template of {slot}, {var:N}, {const} and literal segments + [TLD]
 )
 
 // DomainGenerator holds the configuration for a domain generation task.
@@ -27,6 +30,15 @@ type DomainGenerator struct {
 	ConstantString       string // The static part of the domain
 	TLD                  string // Top-Level Domain, e.g., ".com"
 
+	// TLDs is set when fanning out across several TLDs (see WithTLDs). TLD is the outermost
+	// dimension of the offset space: every label for TLDs[0] comes before any label for TLDs[1].
+	TLDs              []string
+	labelCombinations int64
+
+	// Template is set for word-list patterns; segments is its compiled form.
+	Template string
+	segments []templateSegment
+
 	charsetSize       int
 	totalCombinations int64
 }
@@ -180,27 +192,32 @@ func (dg *DomainGenerator) GenerateDomainAtOffset(offset int64) (string, error)
 	var varPart1 strings.Builder
 
 	tempOffset := offset
+	tld := dg.TLD
+	if len(dg.TLDs) > 1 {
+		tld = dg.TLDs[offset/dg.labelCombinations]
+		tempOffset = offset % dg.labelCombinations
+	}
 
 	switch dg.PatternType {
 	case PatternPrefix:
 		// Generate [VARIABLE][CONSTANT][TLD] (or constant-only if VariableLength=0)
 		if dg.PrefixVariableLength == 0 { // constant-only
-			return dg.ConstantString + dg.TLD, nil
+			return dg.ConstantString + tld, nil
 		}
 		generateVariableString(tempOffset, dg.PrefixVariableLength, dg.CharacterSet, dg.charsetSize, &varPart1)
-		return varPart1.String() + dg.ConstantString + dg.TLD, nil
+		return varPart1.String() + dg.ConstantString + tld, nil
 	case PatternSuffix:
 		// Generate [CONSTANT][VARIABLE][TLD] (or constant-only)
 		if dg.SuffixVariableLength == 0 {
-			return dg.ConstantString + dg.TLD, nil
+			return dg.ConstantString + tld, nil
 		}
 		generateVariableString(tempOffset, dg.SuffixVariableLength, dg.CharacterSet, dg.charsetSize, &varPart1)
-		return dg.ConstantString + varPart1.String() + dg.TLD, nil
+		return dg.ConstantString + varPart1.String() + tld, nil
 	case PatternBoth:
 		// Generate [VARIABLE1][CONSTANT][VARIABLE2][TLD] or constant-only
 		totalLen := dg.PrefixVariableLength + dg.SuffixVariableLength
 		if totalLen == 0 {
-			return dg.ConstantString + dg.TLD, nil
+			return dg.ConstantString + tld, nil
 		}
 		var varFull strings.Builder
 		generateVariableString(tempOffset, totalLen, dg.CharacterSet, dg.charsetSize, &varFull)
@@ -213,12 +230,56 @@ func (dg *DomainGenerator) GenerateDomainAtOffset(offset int64) (string, error)
 		if dg.SuffixVariableLength > 0 {
 			var2Str = fullVarStr[len(fullVarStr)-dg.SuffixVariableLength:]
 		}
-		return var1Str + dg.ConstantString + var2Str + dg.TLD, nil
+		return var1Str + dg.ConstantString + var2Str + tld, nil
+	case PatternWordList, PatternWordTemplate:
+		return dg.generateTemplateAtOffset(tempOffset) + tld, nil
 	default:
 		return "", fmt.Errorf("unknown pattern type: %s", dg.PatternType)
 	}
 }
 
+// WithTLDs fans the generator out across several TLDs. Offsets keep the single-TLD order within
+// each TLD, so the first TLD's offsets match a generator configured with that TLD alone.
+func (dg *DomainGenerator) WithTLDs(tlds []string) (*DomainGenerator, error) {
+	var distinct []string
+	seen := make(map[string]bool, len(tlds))
+	for _, tld := range tlds {
+		if err := validateTLD(tld); err != nil {
+			return nil, fmt.Errorf("invalid TLD %q: %w", tld, err)
+		}
+		if !seen[tld] {
+			seen[tld] = true
+			distinct = append(distinct, tld)
+		}
+	}
+	if len(distinct) == 0 {
+		return nil, fmt.Errorf("at least one TLD is required")
+	}
+	labels := dg.labelCombinations
+	if labels == 0 {
+		labels = dg.totalCombinations
+	}
+	if labels > math.MaxInt64/int64(len(distinct)) {
+		return nil, fmt.Errorf("%d labels across %d TLDs exceeds the maximum offset space", labels, len(distinct))
+	}
+	dg.TLD = distinct[0]
+	dg.TLDs = nil
+	if len(distinct) > 1 {
+		dg.TLDs = distinct
+	}
+	dg.labelCombinations = labels
+	dg.totalCombinations = labels * int64(len(distinct))
+	return dg, nil
+}
+
+// GetLabelCombinations returns the number of labels generated per TLD.
+func (dg *DomainGenerator) GetLabelCombinations() int64 {
+	if dg.labelCombinations > 0 {
+		return dg.labelCombinations
+	}
+	return dg.totalCombinations
+}
+
 // generateVariableString constructs the variable part of the domain based on the offset.
 // It effectively converts the offset into a base-N number, where N is charsetSize.
 func generateVariableString(offset int64, length int, charSet []rune, charsetSize int, builder *strings.Builder) {
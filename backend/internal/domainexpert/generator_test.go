@@ -0,0 +1,75 @@
+package domainexpert
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+)
+
+func TestWithTLDsOutermostDimension(t *testing.T) {
+	single, err := NewDomainGenerator(PatternPrefix, 2, 0, "ab", "x", ".com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	multi, err := NewDomainGenerator(PatternPrefix, 2, 0, "ab", "x", ".com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := multi.WithTLDs([]string{".com", ".net", ".com", ".io"}); err != nil {
+		t.Fatalf("WithTLDs: %v", err)
+	}
+	if multi.GetLabelCombinations() != 4 || multi.GetTotalCombinations() != 12 {
+		t.Fatalf("expected 4 labels x 3 TLDs, got %d / %d", multi.GetLabelCombinations(), multi.GetTotalCombinations())
+	}
+	// The first TLD's block matches the single-TLD generator offset for offset.
+	for i := int64(0); i < 4; i++ {
+		a, _ := single.GenerateDomainAtOffset(i)
+		b, _ := multi.GenerateDomainAtOffset(i)
+		if a != b {
+			t.Fatalf("offset %d: single=%s multi=%s", i, a, b)
+		}
+	}
+	cases := map[int64]string{4: "aax.net", 7: "bbx.net", 8: "aax.io", 11: "bbx.io"}
+	for offset, want := range cases {
+		got, err := multi.GenerateDomainAtOffset(offset)
+		if err != nil || got != want {
+			t.Fatalf("offset %d: expected %s, got %s (err=%v)", offset, want, got, err)
+		}
+	}
+	if _, err := multi.GenerateDomainAtOffset(12); err == nil {
+		t.Fatalf("expected out-of-range error")
+	}
+	if _, err := multi.WithTLDs([]string{"com"}); err == nil {
+		t.Fatalf("expected error for TLD without leading dot")
+	}
+}
+
+func TestConfigHashMultiTLD(t *testing.T) {
+	constant := "x"
+	base := models.DomainGenerationCampaignParams{
+		PatternType:          string(models.PatternTypePrefixVariable),
+		PrefixVariableLength: sql.NullInt32{Int32: 2, Valid: true},
+		CharacterSet:         "ab",
+		ConstantString:       &constant,
+		TLD:                  ".com",
+	}
+	plain, _ := GenerateDomainGenerationPhaseConfigHash(base)
+
+	one := base
+	one.TLDs = []string{"COM", ".com"}
+	oneRes, _ := GenerateDomainGenerationPhaseConfigHash(one)
+	if oneRes.HashString != plain.HashString {
+		t.Fatalf("a single distinct TLD must hash like the plain tld field")
+	}
+
+	fan := base
+	fan.TLDs = []string{".com", ".net"}
+	fanRes, _ := GenerateDomainGenerationPhaseConfigHash(fan)
+	reordered := base
+	reordered.TLDs = []string{".net", ".com"}
+	reorderedRes, _ := GenerateDomainGenerationPhaseConfigHash(reordered)
+	if fanRes.HashString == plain.HashString || fanRes.HashString == reorderedRes.HashString {
+		t.Fatalf("multi-TLD hash must differ from single TLD and depend on TLD order")
+	}
+}
@@ -0,0 +1,237 @@
+// File: backend/internal/domainexpert/importer.go
+package domainexpert
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// ImportFormat identifies the layout of an uploaded domain list.
+type ImportFormat string
+
+const (
+	ImportFormatAuto    ImportFormat = ""
+	ImportFormatNewline ImportFormat = "newline"
+	ImportFormatCSV     ImportFormat = "csv"
+	ImportFormatJSONL   ImportFormat = "jsonl"
+)
+
+// MaxImportRejectSamples bounds how many rejected lines are reported back to the caller.
+const MaxImportRejectSamples = 20
+
+// ImportOptions controls how an upload is parsed.
+// Column is the CSV header (or zero-based index when no header matches) and Field the JSONL key;
+// both default to "domain" and fall back to the first column / a bare JSON string.
+type ImportOptions struct {
+	Format ImportFormat
+	Column string
+	Field  string
+}
+
+// ImportRejection describes a line that could not be turned into a domain.
+type ImportRejection struct {
+	Line   int    `json:"line"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// ImportResult is the normalized, de-duplicated content of an upload, in upload order.
+type ImportResult struct {
+	Domains        []string
+	Duplicates     int
+	Rejected       int
+	RejectedSample []ImportRejection
+}
+
+var importProfile = idna.New(
+	idna.MapForLookup(),
+	idna.Transitional(false),
+	idna.StrictDomainName(true),
+	idna.ValidateLabels(true),
+	idna.VerifyDNSLength(true),
+)
+
+// NormalizeImportDomain lowercases an entry, strips schemes, paths, ports and a trailing dot,
+// and converts internationalized names to their punycode (A-label) form.
+func NormalizeImportDomain(raw string) (string, error) {
+	d := strings.TrimSpace(raw)
+	d = strings.Trim(d, "\"'")
+	if d == "" {
+		return "", fmt.Errorf("empty value")
+	}
+	if strings.Contains(d, "://") {
+		u, err := url.Parse(d)
+		if err != nil || u.Hostname() == "" {
+			return "", fmt.Errorf("unparseable URL")
+		}
+		d = u.Hostname()
+	} else {
+		if i := strings.IndexAny(d, "/?#"); i >= 0 {
+			d = d[:i]
+		}
+		if host, _, ok := strings.Cut(d, ":"); ok {
+			d = host
+		}
+	}
+	d = strings.TrimPrefix(strings.TrimSuffix(d, "."), "*.")
+	ascii, err := importProfile.ToASCII(d)
+	if err != nil {
+		return "", fmt.Errorf("invalid domain: %v", err)
+	}
+	ascii = strings.ToLower(ascii)
+	if !strings.Contains(ascii, ".") {
+		return "", fmt.Errorf("domain has no TLD")
+	}
+	if len(ascii) > 253 {
+		return "", fmt.Errorf("domain exceeds 253 characters")
+	}
+	return ascii, nil
+}
+
+// ParseDomainImport reads an upload and returns its normalized domains. Lines that cannot be parsed
+// are counted and sampled rather than failing the whole upload; only reader/format errors are returned.
+func ParseDomainImport(r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	format := opts.Format
+	if format == ImportFormatAuto {
+		format = detectImportFormat(data)
+	}
+
+	res := &ImportResult{}
+	seen := make(map[string]struct{})
+	add := func(line int, value string) {
+		d, nerr := NormalizeImportDomain(value)
+		if nerr != nil {
+			res.Rejected++
+			if len(res.RejectedSample) < MaxImportRejectSamples {
+				res.RejectedSample = append(res.RejectedSample, ImportRejection{Line: line, Value: value, Reason: nerr.Error()})
+			}
+			return
+		}
+		if _, dup := seen[d]; dup {
+			res.Duplicates++
+			return
+		}
+		seen[d] = struct{}{}
+		res.Domains = append(res.Domains, d)
+	}
+
+	switch format {
+	case ImportFormatNewline:
+		sc := bufio.NewScanner(bytes.NewReader(data))
+		sc.Buffer(make([]byte, 64*1024), 1024*1024)
+		line := 0
+		for sc.Scan() {
+			line++
+			text := strings.TrimSpace(sc.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+			add(line, text)
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+	case ImportFormatCSV:
+		cr := csv.NewReader(bytes.NewReader(data))
+		cr.FieldsPerRecord = -1
+		cr.TrimLeadingSpace = true
+		records, err := cr.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		column := strings.ToLower(strings.TrimSpace(opts.Column))
+		if column == "" {
+			column = "domain"
+		}
+		col, start := 0, 0
+		if len(records) > 0 {
+			for i, h := range records[0] {
+				name := strings.ToLower(strings.TrimSpace(h))
+				if name == column || (opts.Column == "" && (name == "domain_name" || name == "hostname")) {
+					col, start = i, 1
+					break
+				}
+			}
+			if start == 0 && opts.Column != "" {
+				if _, err := fmt.Sscanf(opts.Column, "%d", &col); err != nil || col < 0 {
+					return nil, fmt.Errorf("CSV column %q not found in header", opts.Column)
+				}
+			}
+		}
+		for i := start; i < len(records); i++ {
+			if col >= len(records[i]) || strings.TrimSpace(records[i][col]) == "" {
+				continue
+			}
+			add(i+1, records[i][col])
+		}
+	case ImportFormatJSONL:
+		field := opts.Field
+		if field == "" {
+			field = "domain"
+		}
+		sc := bufio.NewScanner(bytes.NewReader(data))
+		sc.Buffer(make([]byte, 64*1024), 1024*1024)
+		line := 0
+		for sc.Scan() {
+			line++
+			text := strings.TrimSpace(sc.Text())
+			if text == "" {
+				continue
+			}
+			var v interface{}
+			if err := json.Unmarshal([]byte(text), &v); err != nil {
+				res.Rejected++
+				if len(res.RejectedSample) < MaxImportRejectSamples {
+					res.RejectedSample = append(res.RejectedSample, ImportRejection{Line: line, Value: text, Reason: "invalid JSON"})
+				}
+				continue
+			}
+			switch t := v.(type) {
+			case string:
+				add(line, t)
+			case map[string]interface{}:
+				s, _ := t[field].(string)
+				add(line, s)
+			default:
+				add(line, "")
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+	return res, nil
+}
+
+// detectImportFormat sniffs the first non-empty line: JSON objects/strings mean JSONL, commas mean CSV.
+func detectImportFormat(data []byte) ImportFormat {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "{") || strings.HasPrefix(line, "\""):
+			return ImportFormatJSONL
+		case strings.Contains(line, ","):
+			return ImportFormatCSV
+		default:
+			return ImportFormatNewline
+		}
+	}
+	return ImportFormatNewline
+}
@@ -0,0 +1,63 @@
+package domainexpert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeImportDomain(t *testing.T) {
+	cases := map[string]string{
+		"Example.COM":                    "example.com",
+		"  https://Shop.Example.com/a?b": "shop.example.com",
+		"example.org.":                   "example.org",
+		"example.net:8080/path":          "example.net",
+		"münchen.de":                     "xn--mnchen-3ya.de",
+		"*.wild.io":                      "wild.io",
+	}
+	for in, want := range cases {
+		got, err := NormalizeImportDomain(in)
+		if err != nil || got != want {
+			t.Errorf("%q: expected %q, got %q (err=%v)", in, want, got, err)
+		}
+	}
+	for _, bad := range []string{"", "localhost", "bad_domain.com", "-lead.com", "a..b.com"} {
+		if d, err := NormalizeImportDomain(bad); err == nil {
+			t.Errorf("%q: expected rejection, got %q", bad, d)
+		}
+	}
+}
+
+func TestParseDomainImportFormats(t *testing.T) {
+	newline := "# expired feed\nexample.com\nEXAMPLE.com\n\nnot a domain\nbücher.de\n"
+	res, err := ParseDomainImport(strings.NewReader(newline), ImportOptions{})
+	if err != nil {
+		t.Fatalf("newline: %v", err)
+	}
+	if strings.Join(res.Domains, ",") != "example.com,xn--bcher-kva.de" || res.Duplicates != 1 || res.Rejected != 1 {
+		t.Fatalf("newline: unexpected result %+v", res)
+	}
+	if len(res.RejectedSample) != 1 || res.RejectedSample[0].Line != 5 {
+		t.Fatalf("newline: unexpected rejection sample %+v", res.RejectedSample)
+	}
+
+	csvInput := "id,Domain,owner\n1,alpha.com,a\n2,beta.net,b\n3,alpha.com,c\n"
+	res, err = ParseDomainImport(strings.NewReader(csvInput), ImportOptions{})
+	if err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	if strings.Join(res.Domains, ",") != "alpha.com,beta.net" || res.Duplicates != 1 {
+		t.Fatalf("csv: unexpected result %+v", res)
+	}
+	if _, err := ParseDomainImport(strings.NewReader(csvInput), ImportOptions{Column: "hostname_missing"}); err == nil {
+		t.Fatalf("csv: expected error for unknown column")
+	}
+
+	jsonl := "{\"host\":\"one.io\"}\n\"two.io\"\n{broken\n{\"host\":\"ONE.io\"}\n"
+	res, err = ParseDomainImport(strings.NewReader(jsonl), ImportOptions{Format: ImportFormatJSONL, Field: "host"})
+	if err != nil {
+		t.Fatalf("jsonl: %v", err)
+	}
+	if strings.Join(res.Domains, ",") != "one.io,two.io" || res.Duplicates != 1 || res.Rejected != 1 {
+		t.Fatalf("jsonl: unexpected result %+v", res)
+	}
+}
@@ -0,0 +1,306 @@
+// File: backend/internal/domainexpert/wordlist.go
+package domainexpert
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Template grammar for word-list patterns:
+//
+//	{slot}   a word from the word list bound to "slot"
+//	{var:N}  N characters from the character set (same encoding as the variable patterns)
+//	{const}  the configured constant string
+//	other    literal characters copied verbatim (a-z, 0-9 and '-')
+//
+// Offsets are decoded as a mixed-radix number with the right-most segment least significant,
+// matching generateVariableString, so GenerateDomainAtOffset stays deterministic.
+
+type segmentKind int
+
+const (
+	segmentLiteral segmentKind = iota
+	segmentWords
+	segmentVariable
+)
+
+type templateSegment struct {
+	kind    segmentKind
+	literal string
+	slot    string
+	words   []string
+	length  int
+	size    int64
+}
+
+var (
+	slotNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+	wordPattern     = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]*[a-z0-9])?$`)
+)
+
+// MaxLabelLength is the DNS limit on a single label; rendered templates must fit within it.
+const MaxLabelLength = 63
+
+// MaxWordLength bounds a single word so a template can still fit within a 63 character DNS label.
+const MaxWordLength = MaxLabelLength
+
+// NormalizeWords lowercases, trims and de-duplicates words, dropping entries that cannot appear in a DNS label
+// (including words with a leading or trailing hyphen, which would end up at the edge of a label).
+// Order of first occurrence is preserved so offsets remain stable for an unchanged upload.
+func NormalizeWords(words []string) (normalized []string, rejected int) {
+	seen := make(map[string]struct{}, len(words))
+	normalized = make([]string, 0, len(words))
+	for _, w := range words {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w == "" {
+			continue
+		}
+		if len(w) > MaxWordLength || !wordPattern.MatchString(w) {
+			rejected++
+			continue
+		}
+		if _, dup := seen[w]; dup {
+			continue
+		}
+		seen[w] = struct{}{}
+		normalized = append(normalized, w)
+	}
+	return normalized, rejected
+}
+
+// WordListChecksum returns a stable content hash for an already normalized word list.
+func WordListChecksum(words []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(words, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// TemplateSlots returns the distinct word-list slots referenced by a template in order of appearance.
+func TemplateSlots(template string) ([]string, error) {
+	segments, err := parseTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+	var slots []string
+	seen := map[string]bool{}
+	for _, seg := range segments {
+		if seg.kind == segmentWords && !seen[seg.slot] {
+			seen[seg.slot] = true
+			slots = append(slots, seg.slot)
+		}
+	}
+	return slots, nil
+}
+
+// WordListTemplate builds the template equivalent of a word_list pattern: each slot in order, joined by {const}.
+func WordListTemplate(slots []string) string {
+	parts := make([]string, len(slots))
+	for i, slot := range slots {
+		parts[i] = "{" + slot + "}"
+	}
+	return strings.Join(parts, "{const}")
+}
+
+// NewWordListDomainGenerator creates a generator producing the cartesian product of the given
+// word lists in slot order, joined by constantStr.
+func NewWordListDomainGenerator(slots []string, wordLists map[string][]string, constantStr string, tld string) (*DomainGenerator, error) {
+	if len(slots) == 0 {
+		return nil, fmt.Errorf("word_list pattern requires at least one word list")
+	}
+	return newTemplateDomainGenerator(PatternWordList, WordListTemplate(slots), wordLists, "", constantStr, tld)
+}
+
+// NewWordTemplateDomainGenerator creates a generator for a word_template pattern.
+// charSet is only required when the template contains {var:N} segments.
+func NewWordTemplateDomainGenerator(template string, wordLists map[string][]string, charSet string, constantStr string, tld string) (*DomainGenerator, error) {
+	return newTemplateDomainGenerator(PatternWordTemplate, template, wordLists, charSet, constantStr, tld)
+}
+
+func newTemplateDomainGenerator(patternType CampaignPatternType, template string, wordLists map[string][]string, charSet string, constantStr string, tld string) (*DomainGenerator, error) {
+	if err := validateTLD(tld); err != nil {
+		return nil, err
+	}
+	segments, err := parseTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+
+	var distinctRunes []rune
+	uniqueRunes := make(map[rune]bool)
+	for _, r := range charSet {
+		if !uniqueRunes[r] {
+			uniqueRunes[r] = true
+			distinctRunes = append(distinctRunes, r)
+		}
+	}
+
+	total := int64(1)
+	maxLabel := 0
+	for i := range segments {
+		seg := &segments[i]
+		switch seg.kind {
+		case segmentLiteral:
+			if seg.slot == "const" {
+				seg.literal = constantStr
+			}
+			seg.size = 1
+			maxLabel += len(seg.literal)
+		case segmentWords:
+			words := wordLists[seg.slot]
+			if len(words) == 0 {
+				return nil, fmt.Errorf("word list for slot %q is missing or empty", seg.slot)
+			}
+			seg.words = words
+			seg.size = int64(len(words))
+			longest := 0
+			for _, w := range words {
+				if len(w) > longest {
+					longest = len(w)
+				}
+			}
+			maxLabel += longest
+		case segmentVariable:
+			if len(distinctRunes) == 0 {
+				return nil, fmt.Errorf("character set cannot be empty when template contains {var} segments")
+			}
+			maxLabel += seg.length
+			seg.size = power(int64(len(distinctRunes)), int64(seg.length))
+			if seg.size == math.MaxInt64 {
+				return nil, fmt.Errorf("variable segment of length %d overflows the offset space", seg.length)
+			}
+		}
+		if total > math.MaxInt64/seg.size {
+			return nil, fmt.Errorf("template %q exceeds the maximum offset space", template)
+		}
+		total *= seg.size
+	}
+	if maxLabel > MaxLabelLength {
+		return nil, fmt.Errorf("template %q renders labels up to %d characters; labels must not exceed %d", template, maxLabel, MaxLabelLength)
+	}
+	// Words and character sets are checked on their own; only fixed text can pin a hyphen to the label edge.
+	var head, tail string
+	for _, seg := range segments {
+		if seg.kind != segmentLiteral {
+			break
+		}
+		head += seg.literal
+	}
+	for i := len(segments) - 1; i >= 0 && segments[i].kind == segmentLiteral; i-- {
+		tail = segments[i].literal + tail
+	}
+	if strings.HasPrefix(head, "-") || strings.HasSuffix(tail, "-") {
+		return nil, fmt.Errorf("template %q must not start or end with a hyphen", template)
+	}
+
+	return &DomainGenerator{
+		PatternType:       patternType,
+		CharacterSet:      distinctRunes,
+		ConstantString:    constantStr,
+		TLD:               tld,
+		Template:          template,
+		segments:          segments,
+		charsetSize:       len(distinctRunes),
+		totalCombinations: total,
+	}, nil
+}
+
+func validateTLD(tld string) error {
+	if tld == "" {
+		return fmt.Errorf("TLD cannot be empty")
+	}
+	if !strings.HasPrefix(tld, ".") {
+		return fmt.Errorf("TLD must start with a dot")
+	}
+	if len(tld) < 2 {
+		return fmt.Errorf("TLD must contain at least one character after the dot")
+	}
+	return nil
+}
+
+// parseTemplate compiles a template into segments. Word lists and character sets are bound later.
+func parseTemplate(template string) ([]templateSegment, error) {
+	template = strings.ToLower(strings.TrimSpace(template))
+	if template == "" {
+		return nil, fmt.Errorf("template cannot be empty")
+	}
+	var segments []templateSegment
+	var literal strings.Builder
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			segments = append(segments, templateSegment{kind: segmentLiteral, literal: literal.String()})
+			literal.Reset()
+		}
+	}
+	hasDynamic := false
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		if c == '}' {
+			return nil, fmt.Errorf("unexpected '}' at position %d", i)
+		}
+		if c != '{' {
+			if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '-' {
+				return nil, fmt.Errorf("invalid literal character %q at position %d", c, i)
+			}
+			literal.WriteByte(c)
+			continue
+		}
+		end := strings.IndexByte(template[i:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder at position %d", i)
+		}
+		token := template[i+1 : i+end]
+		i += end
+		flushLiteral()
+		switch {
+		case token == "const":
+			segments = append(segments, templateSegment{kind: segmentLiteral, slot: "const"})
+		case token == "var" || strings.HasPrefix(token, "var:"):
+			length := 1
+			if token != "var" {
+				n, err := strconv.Atoi(strings.TrimPrefix(token, "var:"))
+				if err != nil || n <= 0 {
+					return nil, fmt.Errorf("invalid variable placeholder {%s}: length must be a positive integer", token)
+				}
+				length = n
+			}
+			segments = append(segments, templateSegment{kind: segmentVariable, length: length})
+			hasDynamic = true
+		case slotNamePattern.MatchString(token):
+			segments = append(segments, templateSegment{kind: segmentWords, slot: token})
+			hasDynamic = true
+		default:
+			return nil, fmt.Errorf("invalid placeholder {%s}", token)
+		}
+	}
+	flushLiteral()
+	if !hasDynamic {
+		return nil, fmt.Errorf("template must reference at least one word list or {var:N} segment")
+	}
+	return segments, nil
+}
+
+// generateTemplateAtOffset renders the label (without TLD) for an in-range offset.
+func (dg *DomainGenerator) generateTemplateAtOffset(offset int64) string {
+	parts := make([]string, len(dg.segments))
+	remaining := offset
+	for i := len(dg.segments) - 1; i >= 0; i-- {
+		seg := dg.segments[i]
+		switch seg.kind {
+		case segmentLiteral:
+			parts[i] = seg.literal
+		case segmentWords:
+			parts[i] = seg.words[remaining%seg.size]
+			remaining /= seg.size
+		case segmentVariable:
+			var b strings.Builder
+			generateVariableString(remaining%seg.size, seg.length, dg.CharacterSet, dg.charsetSize, &b)
+			parts[i] = b.String()
+			remaining /= seg.size
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,163 @@
+package domainexpert
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestWordListGeneratorCartesianOrder(t *testing.T) {
+	lists := map[string][]string{
+		"adjective": {"red", "blue"},
+		"noun":      {"fox", "owl", "cat"},
+	}
+	gen, err := NewWordListDomainGenerator([]string{"adjective", "noun"}, lists, "-", ".com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := gen.GetTotalCombinations(); got != 6 {
+		t.Fatalf("expected 6 combinations, got %d", got)
+	}
+	want := []string{"red-fox.com", "red-owl.com", "red-cat.com", "blue-fox.com", "blue-owl.com", "blue-cat.com"}
+	domains, next, err := gen.GenerateBatch(0, 10)
+	if err != nil {
+		t.Fatalf("GenerateBatch: %v", err)
+	}
+	if next != 6 || strings.Join(domains, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected batch %v (next=%d)", domains, next)
+	}
+	for i, w := range want {
+		d, err := gen.GenerateDomainAtOffset(int64(i))
+		if err != nil || d != w {
+			t.Fatalf("offset %d: expected %s, got %s (err=%v)", i, w, d, err)
+		}
+	}
+}
+
+func TestWordTemplateGeneratorMixedSegments(t *testing.T) {
+	lists := map[string][]string{"keyword": {"shop", "store"}, "city": {"paris", "rome"}}
+	gen, err := NewWordTemplateDomainGenerator("{keyword}-{city}{var:1}", lists, "ab", "", ".io")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := gen.GetTotalCombinations(); got != 8 {
+		t.Fatalf("expected 8 combinations, got %d", got)
+	}
+	first, _ := gen.GenerateDomainAtOffset(0)
+	last, _ := gen.GenerateDomainAtOffset(7)
+	if first != "shop-parisa.io" || last != "store-romeb.io" {
+		t.Fatalf("unexpected first/last: %s %s", first, last)
+	}
+	if _, err := gen.GenerateDomainAtOffset(8); err == nil {
+		t.Fatalf("expected out-of-range error")
+	}
+}
+
+func TestWordTemplateValidation(t *testing.T) {
+	cases := map[string]string{
+		"missing list":     "{adjective}{noun}",
+		"no dynamic parts": "static",
+		"bad placeholder":  "{Bad Slot}",
+		"unterminated":     "{noun",
+		"bad literal":      "{noun}_x",
+	}
+	lists := map[string][]string{"noun": {"fox"}}
+	for name, tpl := range cases {
+		if _, err := NewWordTemplateDomainGenerator(tpl, lists, "", "", ".com"); err == nil {
+			t.Errorf("%s: expected error for template %q", name, tpl)
+		}
+	}
+	if _, err := NewWordTemplateDomainGenerator("{noun}{var:2}", lists, "", "", ".com"); err == nil {
+		t.Errorf("expected error for {var} without character set")
+	}
+}
+
+func TestWordTemplateLabelBounds(t *testing.T) {
+	lists := map[string][]string{"noun": {"fox", strings.Repeat("a", 40)}, "city": {"rome", strings.Repeat("b", 20)}}
+	// 40 + 1 + 20 = 61 fits; two more characters push the longest label past 63.
+	if _, err := NewWordTemplateDomainGenerator("{noun}-{city}", lists, "", "", ".com"); err != nil {
+		t.Fatalf("unexpected error for a 61 character label: %v", err)
+	}
+	if _, err := NewWordTemplateDomainGenerator("{noun}-{city}{var:3}", lists, "ab", "", ".com"); err == nil {
+		t.Errorf("expected error for labels longer than %d characters", MaxLabelLength)
+	}
+	if _, err := NewWordListDomainGenerator([]string{"noun", "city"}, lists, "-and-", ".com"); err == nil {
+		t.Errorf("expected error when the constant pushes word_list labels past %d characters", MaxLabelLength)
+	}
+	for _, tpl := range []string{"-{noun}", "{noun}x-", "{const}{noun}"} {
+		if _, err := NewWordTemplateDomainGenerator(tpl, lists, "", "-", ".com"); err == nil {
+			t.Errorf("expected edge hyphen error for template %q", tpl)
+		}
+	}
+}
+
+func TestNormalizeWords(t *testing.T) {
+	words, rejected := NormalizeWords([]string{" Red ", "red", "", "blue", "no spaces", "ünicode", "x-ray", "-lead", "trail-", "-"})
+	if strings.Join(words, ",") != "red,blue,x-ray" || rejected != 5 {
+		t.Fatalf("unexpected normalize result %v rejected=%d", words, rejected)
+	}
+	if WordListChecksum(words) != WordListChecksum([]string{"red", "blue", "x-ray"}) {
+		t.Fatalf("checksum should be stable")
+	}
+}
+
+func TestConfigHashWordListsUseChecksum(t *testing.T) {
+	id := uuid.New()
+	base := models.DomainGenerationCampaignParams{
+		PatternType: string(models.PatternTypeWordList),
+		TLD:         ".com",
+		Template:    "{a}{const}{b}",
+		WordLists: []models.DomainWordListRef{
+			{Slot: "b", WordListID: id, Checksum: "bbb"},
+			{Slot: "a", WordListID: id, Checksum: "aaa"},
+		},
+	}
+	h1, err := GenerateDomainGenerationPhaseConfigHash(base)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	changed := base
+	changed.WordLists = []models.DomainWordListRef{
+		{Slot: "a", WordListID: uuid.New(), Checksum: "aaa"},
+		{Slot: "b", WordListID: id, Checksum: "ccc"},
+	}
+	h2, err := GenerateDomainGenerationPhaseConfigHash(changed)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	if h1.HashString == h2.HashString {
+		t.Fatalf("expected hash to change when list contents change")
+	}
+	changed.WordLists[1].Checksum = "bbb"
+	h3, _ := GenerateDomainGenerationPhaseConfigHash(changed)
+	if h1.HashString != h3.HashString {
+		t.Fatalf("expected hash to ignore list IDs and slot ordering")
+	}
+	missing := base
+	missing.WordLists = []models.DomainWordListRef{{Slot: "a", WordListID: id}}
+	if _, err := GenerateDomainGenerationPhaseConfigHash(missing); err == nil {
+		t.Fatalf("expected error for unresolved word list checksum")
+	}
+}
+
+func TestConfigHashLegacyParamsUnchanged(t *testing.T) {
+	constant := "shop"
+	res, err := GenerateDomainGenerationPhaseConfigHash(models.DomainGenerationCampaignParams{
+		PatternType:          string(models.PatternTypePrefixVariable),
+		PrefixVariableLength: sql.NullInt32{Int32: 3, Valid: true},
+		CharacterSet:         "cba",
+		ConstantString:       &constant,
+		TLD:                  "com",
+	})
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	raw, _ := json.Marshal(res.NormalizedParams)
+	if strings.Contains(string(raw), "template") || strings.Contains(string(raw), "wordLists") {
+		t.Fatalf("legacy params must hash without word-list fields: %s", raw)
+	}
+}
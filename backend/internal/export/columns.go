@@ -0,0 +1,327 @@
+// Package export writes campaign domain rows as CSV, JSONL or Parquet for bulk download.
+package export
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/contacts"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+)
+
+// ColumnType is the value type a column produces; it fixes the Parquet physical type and the CSV/JSON rendering.
+type ColumnType int
+
+const (
+	TypeString ColumnType = iota
+	TypeInt64
+	TypeDouble
+	TypeBool
+	TypeTimestamp
+)
+
+const (
+	// FeaturePrefix selects a single feature_vector key, e.g. "features.kw_unique" (exported as a double).
+	FeaturePrefix = "features."
+	// ScoreBreakdownPrefix selects a score breakdown component, e.g. "scoreBreakdown.density".
+	ScoreBreakdownPrefix = "scoreBreakdown."
+)
+
+// Row is one exported domain. Breakdown is only populated when a score breakdown column is selected.
+type Row struct {
+	Domain    *models.GeneratedDomain
+	Breakdown map[string]float64
+
+	features map[string]interface{}
+	decoded  bool
+
+	contacts        *contacts.Set
+	contactsDecoded bool
+}
+
+// Feature returns a feature_vector value, decoding the vector once per row.
+func (r *Row) Feature(key string) (interface{}, bool) {
+	if !r.decoded {
+		r.decoded = true
+		if r.Domain != nil && r.Domain.FeatureVector.Valid {
+			_ = json.Unmarshal(r.Domain.FeatureVector.Raw, &r.features)
+		}
+	}
+	v, ok := r.features[key]
+	return v, ok && v != nil
+}
+
+// Contacts returns the domain's extracted contacts, decoding them once per row; nil when none were found.
+func (r *Row) Contacts() *contacts.Set {
+	if !r.contactsDecoded {
+		r.contactsDecoded = true
+		if r.Domain != nil && len(r.Domain.Contacts) > 0 {
+			var set contacts.Set
+			if json.Unmarshal(r.Domain.Contacts, &set) == nil && !set.Empty() {
+				r.contacts = &set
+			}
+		}
+	}
+	return r.contacts
+}
+
+// contactList joins one value per contact entry with "; ", or reports NULL when there are none.
+func contactList(r *Row, values func(*contacts.Set) []string) (interface{}, bool) {
+	set := r.Contacts()
+	if set == nil {
+		return nil, false
+	}
+	list := values(set)
+	if len(list) == 0 {
+		return nil, false
+	}
+	return strings.Join(list, "; "), true
+}
+
+// Column is a selectable export column. Value returns a string, int64, float64, bool or time.Time
+// matching Type, and false for NULL.
+type Column struct {
+	Name  string
+	Type  ColumnType
+	Value func(*Row) (interface{}, bool)
+}
+
+// DefaultColumns is used when the caller does not select columns.
+var DefaultColumns = []string{"id", "domain", "offset", "createdAt", "dnsStatus", "httpStatus", "leadStatus", "domainScore", "rejectionReason"}
+
+// scoreBreakdownComponents maps API component names to the keys produced by the score breakdown calculator.
+var scoreBreakdownComponents = map[string]string{
+	"density":       "density",
+	"coverage":      "coverage",
+	"nonParked":     "non_parked",
+	"contentLength": "content_length",
+	"titleKeyword":  "title_keyword",
+	"freshness":     "freshness",
+	"domainAge":     "domain_age",
+	"tfLite":        "tf_lite",
+	"final":         "final",
+}
+
+func nullString(v sql.NullString) (interface{}, bool) {
+	return v.String, v.Valid
+}
+
+var baseColumns = map[string]Column{
+	"id":     {Name: "id", Type: TypeString, Value: func(r *Row) (interface{}, bool) { return r.Domain.ID.String(), true }},
+	"domain": {Name: "domain", Type: TypeString, Value: func(r *Row) (interface{}, bool) { return r.Domain.DomainName, true }},
+	"offset": {Name: "offset", Type: TypeInt64, Value: func(r *Row) (interface{}, bool) { return r.Domain.OffsetIndex, true }},
+	"tld":    {Name: "tld", Type: TypeString, Value: func(r *Row) (interface{}, bool) { return nullString(r.Domain.TLD) }},
+	"sourcePattern": {Name: "sourcePattern", Type: TypeString, Value: func(r *Row) (interface{}, bool) {
+		return nullString(r.Domain.SourcePattern)
+	}},
+	"createdAt": {Name: "createdAt", Type: TypeTimestamp, Value: func(r *Row) (interface{}, bool) { return r.Domain.CreatedAt, true }},
+	"dnsStatus": {Name: "dnsStatus", Type: TypeString, Value: func(r *Row) (interface{}, bool) {
+		if r.Domain.DNSStatus == nil {
+			return nil, false
+		}
+		return string(*r.Domain.DNSStatus), true
+	}},
+	"dnsReason": {Name: "dnsReason", Type: TypeString, Value: func(r *Row) (interface{}, bool) { return nullString(r.Domain.DNSReason) }},
+	"dnsIp":     {Name: "dnsIp", Type: TypeString, Value: func(r *Row) (interface{}, bool) { return nullString(r.Domain.DNSIP) }},
+	"httpStatus": {Name: "httpStatus", Type: TypeString, Value: func(r *Row) (interface{}, bool) {
+		if r.Domain.HTTPStatus == nil {
+			return nil, false
+		}
+		return string(*r.Domain.HTTPStatus), true
+	}},
+	"httpStatusCode": {Name: "httpStatusCode", Type: TypeInt64, Value: func(r *Row) (interface{}, bool) {
+		return int64(r.Domain.HTTPStatusCode.Int32), r.Domain.HTTPStatusCode.Valid
+	}},
+	"httpReason": {Name: "httpReason", Type: TypeString, Value: func(r *Row) (interface{}, bool) { return nullString(r.Domain.HTTPReason) }},
+	"httpTitle":  {Name: "httpTitle", Type: TypeString, Value: func(r *Row) (interface{}, bool) { return nullString(r.Domain.HTTPTitle) }},
+	"leadStatus": {Name: "leadStatus", Type: TypeString, Value: func(r *Row) (interface{}, bool) {
+		if r.Domain.LeadStatus == nil {
+			return nil, false
+		}
+		return string(*r.Domain.LeadStatus), true
+	}},
+	"leadScore": {Name: "leadScore", Type: TypeDouble, Value: func(r *Row) (interface{}, bool) {
+		return r.Domain.LeadScore.Float64, r.Domain.LeadScore.Valid
+	}},
+	"domainScore": {Name: "domainScore", Type: TypeDouble, Value: func(r *Row) (interface{}, bool) {
+		return r.Domain.DomainScore.Float64, r.Domain.DomainScore.Valid
+	}},
+	"relevanceScore": {Name: "relevanceScore", Type: TypeDouble, Value: func(r *Row) (interface{}, bool) {
+		return r.Domain.RelevanceScore.Float64, r.Domain.RelevanceScore.Valid
+	}},
+	"rejectionReason": {Name: "rejectionReason", Type: TypeString, Value: func(r *Row) (interface{}, bool) {
+		if r.Domain.RejectionReason == nil {
+			return nil, false
+		}
+		return string(*r.Domain.RejectionReason), true
+	}},
+	"isParked": {Name: "isParked", Type: TypeBool, Value: func(r *Row) (interface{}, bool) {
+		return r.Domain.IsParked.Bool, r.Domain.IsParked.Valid
+	}},
+	"parkedConfidence": {Name: "parkedConfidence", Type: TypeDouble, Value: func(r *Row) (interface{}, bool) {
+		return r.Domain.ParkedConfidence.Float64, r.Domain.ParkedConfidence.Valid
+	}},
+	"contentLang": {Name: "contentLang", Type: TypeString, Value: func(r *Row) (interface{}, bool) { return nullString(r.Domain.ContentLang) }},
+	"lastHttpFetchedAt": {Name: "lastHttpFetchedAt", Type: TypeTimestamp, Value: func(r *Row) (interface{}, bool) {
+		return r.Domain.LastHTTPFetchedAt.Time, r.Domain.LastHTTPFetchedAt.Valid
+	}},
+	"featureVector": {Name: "featureVector", Type: TypeString, Value: func(r *Row) (interface{}, bool) {
+		if !r.Domain.FeatureVector.Valid || string(r.Domain.FeatureVector.Raw) == "null" {
+			return nil, false
+		}
+		return string(r.Domain.FeatureVector.Raw), true
+	}},
+	"rdapStatus":    {Name: "rdapStatus", Type: TypeString, Value: func(r *Row) (interface{}, bool) { return nullString(r.Domain.RDAPStatus) }},
+	"rdapRegistrar": {Name: "rdapRegistrar", Type: TypeString, Value: func(r *Row) (interface{}, bool) { return nullString(r.Domain.RDAPRegistrar) }},
+	"registeredAt": {Name: "registeredAt", Type: TypeTimestamp, Value: func(r *Row) (interface{}, bool) {
+		return r.Domain.RDAPRegisteredAt.Time, r.Domain.RDAPRegisteredAt.Valid
+	}},
+	"expiresAt": {Name: "expiresAt", Type: TypeTimestamp, Value: func(r *Row) (interface{}, bool) {
+		return r.Domain.RDAPExpiresAt.Time, r.Domain.RDAPExpiresAt.Valid
+	}},
+	"emails": {Name: "emails", Type: TypeString, Value: func(r *Row) (interface{}, bool) {
+		return contactList(r, func(s *contacts.Set) (out []string) {
+			for _, e := range s.Emails {
+				out = append(out, e.Address)
+			}
+			return out
+		})
+	}},
+	"phones": {Name: "phones", Type: TypeString, Value: func(r *Row) (interface{}, bool) {
+		return contactList(r, func(s *contacts.Set) (out []string) {
+			for _, p := range s.Phones {
+				out = append(out, p.E164)
+			}
+			return out
+		})
+	}},
+	"addresses": {Name: "addresses", Type: TypeString, Value: func(r *Row) (interface{}, bool) {
+		return contactList(r, func(s *contacts.Set) (out []string) {
+			for _, a := range s.Addresses {
+				out = append(out, a.Text)
+			}
+			return out
+		})
+	}},
+	"socialProfiles": {Name: "socialProfiles", Type: TypeString, Value: func(r *Row) (interface{}, bool) {
+		return contactList(r, func(s *contacts.Set) (out []string) {
+			for _, p := range s.SocialProfiles {
+				out = append(out, p.URL)
+			}
+			return out
+		})
+	}},
+	"organizationName": {Name: "organizationName", Type: TypeString, Value: func(r *Row) (interface{}, bool) {
+		if set := r.Contacts(); set != nil {
+			for _, o := range set.Organizations {
+				if o.Name != "" {
+					return o.Name, true
+				}
+			}
+		}
+		return nil, false
+	}},
+	"contacts": {Name: "contacts", Type: TypeString, Value: func(r *Row) (interface{}, bool) {
+		if r.Contacts() == nil {
+			return nil, false
+		}
+		return string(r.Domain.Contacts), true
+	}},
+}
+
+// ParseColumns resolves a comma separated column list. Besides the base columns it accepts
+// features.<key> (numeric feature_vector values; booleans become 0/1) and scoreBreakdown.<component>.
+func ParseColumns(spec string) ([]Column, error) {
+	names := DefaultColumns
+	if strings.TrimSpace(spec) != "" {
+		names = strings.Split(spec, ",")
+	}
+	cols := make([]Column, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, raw := range names {
+		name := strings.TrimSpace(raw)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		switch {
+		case strings.HasPrefix(name, FeaturePrefix):
+			key := strings.TrimPrefix(name, FeaturePrefix)
+			if key == "" {
+				return nil, fmt.Errorf("column %q is missing a feature key", name)
+			}
+			cols = append(cols, Column{Name: name, Type: TypeDouble, Value: func(r *Row) (interface{}, bool) {
+				return featureNumber(r, key)
+			}})
+		case strings.HasPrefix(name, ScoreBreakdownPrefix):
+			component, ok := scoreBreakdownComponents[strings.TrimPrefix(name, ScoreBreakdownPrefix)]
+			if !ok {
+				return nil, fmt.Errorf("unknown score breakdown component in column %q", name)
+			}
+			cols = append(cols, Column{Name: name, Type: TypeDouble, Value: func(r *Row) (interface{}, bool) {
+				v, ok := r.Breakdown[component]
+				return v, ok
+			}})
+		default:
+			col, ok := baseColumns[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown column %q", name)
+			}
+			cols = append(cols, col)
+		}
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("no columns selected")
+	}
+	return cols, nil
+}
+
+// NeedsScoreBreakdown reports whether any selected column reads Row.Breakdown.
+func NeedsScoreBreakdown(cols []Column) bool {
+	for _, c := range cols {
+		if strings.HasPrefix(c.Name, ScoreBreakdownPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func featureNumber(r *Row, key string) (interface{}, bool) {
+	v, ok := r.Feature(key)
+	if !ok {
+		return nil, false
+	}
+	switch t := v.(type) {
+	case float64:
+		return t, true
+	case bool:
+		if t {
+			return 1.0, true
+		}
+		return 0.0, true
+	}
+	return nil, false
+}
+
+// formatText renders a non-NULL value for CSV output.
+func formatText(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case int64:
+		return fmt.Sprintf("%d", t)
+	case float64:
+		return formatFloat(t)
+	case bool:
+		if t {
+			return "true"
+		}
+		return "false"
+	case time.Time:
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,217 @@
+package export
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func sampleRows() []*Row {
+	ok := models.DomainDNSStatusOK
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []*Row{
+		{Domain: &models.GeneratedDomain{
+			ID: uuid.MustParse("11111111-1111-1111-1111-111111111111"), DomainName: "alpha.com", OffsetIndex: 0, CreatedAt: created,
+			DNSStatus: &ok, DomainScore: sql.NullFloat64{Float64: 0.75, Valid: true}, IsParked: sql.NullBool{Bool: true, Valid: true},
+			FeatureVector: models.NullJSONRaw{Raw: json.RawMessage(`{"kw_unique":3,"title_has_keyword":true}`), Valid: true},
+		}, Breakdown: map[string]float64{"density": 0.5}},
+		{Domain: &models.GeneratedDomain{
+			ID: uuid.MustParse("22222222-2222-2222-2222-222222222222"), DomainName: "beta, \"quoted\".net", OffsetIndex: 1, CreatedAt: created,
+		}},
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	cols, err := ParseColumns("")
+	if err != nil || len(cols) != len(DefaultColumns) {
+		t.Fatalf("default columns: %v (%d)", err, len(cols))
+	}
+	cols, err = ParseColumns("domain, features.kw_unique,scoreBreakdown.density,domain")
+	if err != nil || len(cols) != 3 || !NeedsScoreBreakdown(cols) {
+		t.Fatalf("unexpected columns %v err=%v", cols, err)
+	}
+	for _, bad := range []string{"nope", "features.", "scoreBreakdown.bogus"} {
+		if _, err := ParseColumns(bad); err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+}
+
+func TestCSVAndJSONLWriters(t *testing.T) {
+	cols, _ := ParseColumns("domain,dnsStatus,domainScore,features.title_has_keyword,scoreBreakdown.density,createdAt")
+	var buf bytes.Buffer
+	w, _ := NewRowWriter(FormatCSV, &buf, cols)
+	for _, r := range sampleRows() {
+		if err := w.WriteRow(r); err != nil {
+			t.Fatalf("csv write: %v", err)
+		}
+	}
+	_ = w.Close()
+	want := "domain,dnsStatus,domainScore,features.title_has_keyword,scoreBreakdown.density,createdAt\n" +
+		"alpha.com,ok,0.75,1,0.5,2025-01-02T03:04:05Z\n" +
+		"\"beta, \"\"quoted\"\".net\",,,,,2025-01-02T03:04:05Z\n"
+	if buf.String() != want {
+		t.Fatalf("csv mismatch:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	w, _ = NewRowWriter(FormatJSONL, &buf, cols)
+	for _, r := range sampleRows() {
+		_ = w.WriteRow(r)
+	}
+	_ = w.Close()
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[0] != `{"domain":"alpha.com","dnsStatus":"ok","domainScore":0.75,"features.title_has_keyword":1,"scoreBreakdown.density":0.5,"createdAt":"2025-01-02T03:04:05Z"}` {
+		t.Fatalf("jsonl mismatch:\n%s", buf.String())
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &decoded); err != nil || decoded["dnsStatus"] != nil {
+		t.Fatalf("jsonl second line invalid: %s (%v)", lines[1], err)
+	}
+}
+
+func TestContactColumns(t *testing.T) {
+	cols, err := ParseColumns("domain,emails,phones,organizationName")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rows := sampleRows()
+	rows[0].Domain.Contacts = json.RawMessage(`{"emails":[{"address":"a@alpha.com","sourceUrl":"https://alpha.com/"},{"address":"b@alpha.com","sourceUrl":"https://alpha.com/contact"}],` +
+		`"phones":[{"e164":"+14155550132","raw":"(415) 555-0132","sourceUrl":"https://alpha.com/"}],"organizations":[{"type":"LocalBusiness","name":"Alpha Inc","sourceUrl":"https://alpha.com/"}]}`)
+	var buf bytes.Buffer
+	w, _ := NewRowWriter(FormatCSV, &buf, cols)
+	for _, r := range rows {
+		_ = w.WriteRow(r)
+	}
+	_ = w.Close()
+	want := "domain,emails,phones,organizationName\n" +
+		"alpha.com,a@alpha.com; b@alpha.com,+14155550132,Alpha Inc\n" +
+		"\"beta, \"\"quoted\"\".net\",,,\n"
+	if buf.String() != want {
+		t.Fatalf("csv mismatch:\n%s", buf.String())
+	}
+}
+
+func TestParquetLayout(t *testing.T) {
+	cols, _ := ParseColumns("domain,offset,domainScore,isParked,createdAt")
+	var buf bytes.Buffer
+	w, _ := NewRowWriter(FormatParquet, &buf, cols)
+	for _, r := range sampleRows() {
+		if err := w.WriteRow(r); err != nil {
+			t.Fatalf("parquet write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	data := buf.Bytes()
+	if string(data[:4]) != parquetMagic || string(data[len(data)-4:]) != parquetMagic {
+		t.Fatalf("missing magic")
+	}
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footer := data[len(data)-8-footerLen : len(data)-8]
+	r := &thriftReader{b: footer}
+	meta := r.readStruct()
+	if r.pos != len(footer) {
+		t.Fatalf("footer not fully consumed: %d of %d", r.pos, len(footer))
+	}
+	if meta[3].(int64) != 2 {
+		t.Fatalf("expected 2 rows, got %v", meta[3])
+	}
+	schema := meta[2].([]interface{})
+	if len(schema) != len(cols)+1 || string(schema[1].(map[int16]interface{})[4].([]byte)) != "domain" {
+		t.Fatalf("unexpected schema %v", schema)
+	}
+	groups := meta[4].([]interface{})
+	chunks := groups[0].(map[int16]interface{})[1].([]interface{})
+
+	// domainScore column: second row is NULL, so one PLAIN double follows the definition levels.
+	cm := chunks[2].(map[int16]interface{})[3].(map[int16]interface{})
+	off := int(cm[9].(int64))
+	pr := &thriftReader{b: data[off:]}
+	header := pr.readStruct()
+	page := data[off+pr.pos : off+pr.pos+int(header[3].(int32))]
+	levelsLen := int(binary.LittleEndian.Uint32(page[:4]))
+	values := page[4+levelsLen:]
+	if header[5].(map[int16]interface{})[1].(int32) != 2 || len(values) != 8 || math.Float64frombits(binary.LittleEndian.Uint64(values)) != 0.75 {
+		t.Fatalf("unexpected domainScore page: header=%v values=%v", header, values)
+	}
+	if int64(len(data)) < cm[9].(int64)+cm[7].(int64) {
+		t.Fatalf("column chunk exceeds file")
+	}
+}
+
+// thriftReader decodes Thrift compact structs into field-id maps for layout assertions.
+type thriftReader struct {
+	b   []byte
+	pos int
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.b[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case 1:
+		return true
+	case 2:
+		return false
+	case 5:
+		u := uint32(r.uvarint())
+		return int32(u>>1) ^ -int32(u&1)
+	case 6:
+		u := r.uvarint()
+		return int64(u>>1) ^ -int64(u&1)
+	case 8:
+		n := int(r.uvarint())
+		s := r.b[r.pos : r.pos+n]
+		r.pos += n
+		return s
+	case 9:
+		h := r.b[r.pos]
+		r.pos++
+		size := int(h >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		out := make([]interface{}, size)
+		for i := range out {
+			out[i] = r.value(h & 0x0F)
+		}
+		return out
+	case 12:
+		return r.readStruct()
+	}
+	panic("unsupported thrift type")
+}
+
+func (r *thriftReader) readStruct() map[int16]interface{} {
+	out := map[int16]interface{}{}
+	var last int16
+	for {
+		h := r.b[r.pos]
+		r.pos++
+		if h == 0 {
+			return out
+		}
+		typ := h & 0x0F
+		id := last + int16(h>>4)
+		if h>>4 == 0 {
+			u := r.uvarint()
+			id = int16(u>>1) ^ -int16(u&1)
+		}
+		last = id
+		out[id] = r.value(typ)
+	}
+}
@@ -0,0 +1,388 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+)
+
+// Minimal Parquet writer: flat schema of OPTIONAL columns, one uncompressed PLAIN data page (v1) per
+// column chunk, definition levels RLE encoded. Rows are buffered per row group so memory stays bounded
+// by parquetRowGroupRows / parquetRowGroupBytes regardless of export size.
+
+const (
+	parquetMagic         = "PAR1"
+	parquetRowGroupRows  = 10000
+	parquetRowGroupBytes = 16 << 20
+	parquetCreatedBy     = "studio domain export"
+)
+
+// Parquet thrift enum values (parquet.thrift).
+const (
+	pqTypeBoolean   = 0
+	pqTypeInt64     = 2
+	pqTypeDouble    = 5
+	pqTypeByteArray = 6
+
+	pqRepetitionOptional = 1
+
+	pqConvertedUTF8            = 0
+	pqConvertedTimestampMicros = 10
+
+	pqEncodingPlain = 0
+	pqEncodingRLE   = 3
+
+	pqCodecUncompressed = 0
+	pqPageTypeData      = 0
+)
+
+type parquetColumn struct {
+	col     Column
+	values  bytes.Buffer
+	defined []bool
+	// boolean values are bit-packed at page build time
+	bools []bool
+}
+
+type parquetRowGroup struct {
+	numRows int64
+	chunks  []parquetChunkMeta
+}
+
+type parquetChunkMeta struct {
+	offset     int64
+	size       int64
+	numValues  int64
+	physical   int32
+	columnName string
+}
+
+type parquetWriter struct {
+	w         io.Writer
+	offset    int64
+	cols      []*parquetColumn
+	rows      int64
+	totalRows int64
+	groups    []parquetRowGroup
+	started   bool
+	err       error
+}
+
+func newParquetWriter(w io.Writer, cols []Column) *parquetWriter {
+	pw := &parquetWriter{w: w}
+	for _, c := range cols {
+		pw.cols = append(pw.cols, &parquetColumn{col: c})
+	}
+	return pw
+}
+
+func (p *parquetWriter) write(b []byte) {
+	if p.err != nil {
+		return
+	}
+	n, err := p.w.Write(b)
+	p.offset += int64(n)
+	p.err = err
+}
+
+func (p *parquetWriter) WriteRow(r *Row) error {
+	if p.err != nil {
+		return p.err
+	}
+	if !p.started {
+		p.started = true
+		p.write([]byte(parquetMagic))
+	}
+	buffered := 0
+	for _, pc := range p.cols {
+		v, ok := pc.col.Value(r)
+		pc.defined = append(pc.defined, ok)
+		if ok {
+			pc.appendValue(v)
+		}
+		buffered += pc.values.Len()
+	}
+	p.rows++
+	if p.rows >= parquetRowGroupRows || buffered >= parquetRowGroupBytes {
+		p.flushRowGroup()
+	}
+	return p.err
+}
+
+// Flush is a no-op: Parquet output is only emitted per complete row group.
+func (p *parquetWriter) Flush() error { return p.err }
+
+func (p *parquetWriter) Close() error {
+	if !p.started {
+		p.started = true
+		p.write([]byte(parquetMagic))
+	}
+	if p.rows > 0 {
+		p.flushRowGroup()
+	}
+	footer := p.fileMetaData()
+	p.write(footer)
+	var lenBuf [4]byte
+	binary.LittleEndian.PutUint32(lenBuf[:], uint32(len(footer)))
+	p.write(lenBuf[:])
+	p.write([]byte(parquetMagic))
+	return p.err
+}
+
+func (pc *parquetColumn) appendValue(v interface{}) {
+	var scratch [8]byte
+	switch pc.col.Type {
+	case TypeString:
+		s, _ := v.(string)
+		binary.LittleEndian.PutUint32(scratch[:4], uint32(len(s)))
+		pc.values.Write(scratch[:4])
+		pc.values.WriteString(s)
+	case TypeInt64:
+		n, _ := v.(int64)
+		binary.LittleEndian.PutUint64(scratch[:], uint64(n))
+		pc.values.Write(scratch[:])
+	case TypeTimestamp:
+		t, _ := v.(time.Time)
+		binary.LittleEndian.PutUint64(scratch[:], uint64(t.UnixMicro()))
+		pc.values.Write(scratch[:])
+	case TypeDouble:
+		f, _ := v.(float64)
+		binary.LittleEndian.PutUint64(scratch[:], math.Float64bits(f))
+		pc.values.Write(scratch[:])
+	case TypeBool:
+		b, _ := v.(bool)
+		pc.bools = append(pc.bools, b)
+	}
+}
+
+func (pc *parquetColumn) physicalType() int32 {
+	switch pc.col.Type {
+	case TypeInt64, TypeTimestamp:
+		return pqTypeInt64
+	case TypeDouble:
+		return pqTypeDouble
+	case TypeBool:
+		return pqTypeBoolean
+	}
+	return pqTypeByteArray
+}
+
+func (p *parquetWriter) flushRowGroup() {
+	group := parquetRowGroup{numRows: p.rows}
+	for _, pc := range p.cols {
+		levels := encodeDefinitionLevels(pc.defined)
+		var data []byte
+		if pc.col.Type == TypeBool {
+			data = packBools(pc.bools)
+		} else {
+			data = pc.values.Bytes()
+		}
+		pageSize := len(levels) + len(data)
+
+		var tw thriftWriter
+		tw.i32(1, pqPageTypeData)
+		tw.i32(2, int32(pageSize))
+		tw.i32(3, int32(pageSize))
+		tw.structBegin(5)
+		tw.i32(1, int32(len(pc.defined)))
+		tw.i32(2, pqEncodingPlain)
+		tw.i32(3, pqEncodingRLE)
+		tw.i32(4, pqEncodingRLE)
+		tw.structEnd()
+		tw.stop()
+
+		start := p.offset
+		p.write(tw.buf.Bytes())
+		p.write(levels)
+		p.write(data)
+		group.chunks = append(group.chunks, parquetChunkMeta{
+			offset:     start,
+			size:       p.offset - start,
+			numValues:  int64(len(pc.defined)),
+			physical:   pc.physicalType(),
+			columnName: pc.col.Name,
+		})
+		pc.values.Reset()
+		pc.defined = pc.defined[:0]
+		pc.bools = pc.bools[:0]
+	}
+	p.groups = append(p.groups, group)
+	p.totalRows += p.rows
+	p.rows = 0
+}
+
+// encodeDefinitionLevels writes bit-width-1 definition levels as RLE runs, prefixed with their byte length.
+func encodeDefinitionLevels(defined []bool) []byte {
+	var runs []byte
+	for i := 0; i < len(defined); {
+		j := i
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+		runs = binary.AppendUvarint(runs, uint64(j-i)<<1)
+		if defined[i] {
+			runs = append(runs, 1)
+		} else {
+			runs = append(runs, 0)
+		}
+		i = j
+	}
+	out := make([]byte, 4, 4+len(runs))
+	binary.LittleEndian.PutUint32(out, uint32(len(runs)))
+	return append(out, runs...)
+}
+
+// packBools encodes PLAIN booleans: one bit per value, least significant bit first.
+func packBools(values []bool) []byte {
+	out := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v {
+			out[i/8] |= 1 << (uint(i) % 8)
+		}
+	}
+	return out
+}
+
+func (p *parquetWriter) fileMetaData() []byte {
+	var tw thriftWriter
+	tw.i32(1, 1)
+
+	tw.listBegin(2, thriftStruct, len(p.cols)+1)
+	tw.elemBegin()
+	tw.binary(4, "schema")
+	tw.i32(5, int32(len(p.cols)))
+	tw.elemEnd()
+	for _, pc := range p.cols {
+		tw.elemBegin()
+		tw.i32(1, pc.physicalType())
+		tw.i32(3, pqRepetitionOptional)
+		tw.binary(4, pc.col.Name)
+		switch pc.col.Type {
+		case TypeString:
+			tw.i32(6, pqConvertedUTF8)
+		case TypeTimestamp:
+			tw.i32(6, pqConvertedTimestampMicros)
+		}
+		tw.elemEnd()
+	}
+
+	tw.i64(3, p.totalRows)
+
+	tw.listBegin(4, thriftStruct, len(p.groups))
+	for _, g := range p.groups {
+		tw.elemBegin()
+		var total int64
+		tw.listBegin(1, thriftStruct, len(g.chunks))
+		for _, c := range g.chunks {
+			total += c.size
+			tw.elemBegin()
+			tw.i64(2, c.offset)
+			tw.structBegin(3)
+			tw.i32(1, c.physical)
+			tw.listBegin(2, thriftI32, 2)
+			tw.listI32(pqEncodingPlain)
+			tw.listI32(pqEncodingRLE)
+			tw.listBegin(3, thriftBinary, 1)
+			tw.listBinary(c.columnName)
+			tw.i32(4, pqCodecUncompressed)
+			tw.i64(5, c.numValues)
+			tw.i64(6, c.size)
+			tw.i64(7, c.size)
+			tw.i64(9, c.offset)
+			tw.structEnd()
+			tw.elemEnd()
+		}
+		tw.i64(2, total)
+		tw.i64(3, g.numRows)
+		tw.elemEnd()
+	}
+	tw.binary(6, parquetCreatedBy)
+	tw.stop()
+	return tw.buf.Bytes()
+}
+
+// thriftWriter implements the subset of the Thrift compact protocol needed for Parquet metadata.
+type thriftWriter struct {
+	buf   bytes.Buffer
+	last  int16
+	stack []int16
+}
+
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+func (t *thriftWriter) uvarint(v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	t.buf.Write(tmp[:n])
+}
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	if delta := id - t.last; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.uvarint(uint64((int64(id) << 1) ^ (int64(id) >> 15)))
+	}
+	t.last = id
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.uvarint(uint64(uint32((v << 1) ^ (v >> 31))))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.uvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (t *thriftWriter) binary(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.listBinary(s)
+}
+
+func (t *thriftWriter) structBegin(id int16) {
+	t.field(id, thriftStruct)
+	t.elemBegin()
+}
+
+func (t *thriftWriter) structEnd() { t.elemEnd() }
+
+func (t *thriftWriter) stop() { t.buf.WriteByte(0) }
+
+func (t *thriftWriter) listBegin(id int16, elemType byte, size int) {
+	t.field(id, thriftList)
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		t.buf.WriteByte(0xF0 | elemType)
+		t.uvarint(uint64(size))
+	}
+}
+
+func (t *thriftWriter) listI32(v int32) { t.uvarint(uint64(uint32((v << 1) ^ (v >> 31)))) }
+
+func (t *thriftWriter) listBinary(s string) {
+	t.uvarint(uint64(len(s)))
+	t.buf.WriteString(s)
+}
+
+// elemBegin/elemEnd bracket a nested struct (struct field or list element), saving the field id context.
+func (t *thriftWriter) elemBegin() {
+	t.stack = append(t.stack, t.last)
+	t.last = 0
+}
+
+func (t *thriftWriter) elemEnd() {
+	t.stop()
+	t.last = t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+}
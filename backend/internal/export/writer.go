@@ -0,0 +1,145 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Format is an export file format.
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatJSONL   Format = "jsonl"
+	FormatParquet Format = "parquet"
+)
+
+// ContentType returns the HTTP media type for the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSONL:
+		return "application/x-ndjson"
+	case FormatParquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Extension returns the file extension (without dot) for the format.
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// RowWriter streams rows to an underlying writer. Flush pushes buffered output through (CSV/JSONL only;
+// Parquet buffers one row group). Close writes any trailer and must be called once after the last row.
+type RowWriter interface {
+	WriteRow(*Row) error
+	Flush() error
+	Close() error
+}
+
+// NewRowWriter creates a writer for format over w.
+func NewRowWriter(format Format, w io.Writer, cols []Column) (RowWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, cols)
+	case FormatJSONL:
+		return &jsonlWriter{w: bufio.NewWriterSize(w, 64*1024), cols: cols}, nil
+	case FormatParquet:
+		return newParquetWriter(w, cols), nil
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	cols   []Column
+	record []string
+}
+
+func newCSVWriter(w io.Writer, cols []Column) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w), cols: cols, record: make([]string, len(cols))}
+	for i, c := range cols {
+		cw.record[i] = c.Name
+	}
+	if err := cw.w.Write(cw.record); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (c *csvWriter) WriteRow(r *Row) error {
+	for i, col := range c.cols {
+		if v, ok := col.Value(r); ok {
+			c.record[i] = formatText(v)
+		} else {
+			c.record[i] = ""
+		}
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error { return c.Flush() }
+
+type jsonlWriter struct {
+	w    *bufio.Writer
+	cols []Column
+	line []byte
+}
+
+// WriteRow emits one JSON object per line with keys in column order.
+func (j *jsonlWriter) WriteRow(r *Row) error {
+	j.line = append(j.line[:0], '{')
+	for i, col := range j.cols {
+		if i > 0 {
+			j.line = append(j.line, ',')
+		}
+		key, _ := json.Marshal(col.Name)
+		j.line = append(j.line, key...)
+		j.line = append(j.line, ':')
+		v, ok := col.Value(r)
+		if !ok {
+			j.line = append(j.line, "null"...)
+			continue
+		}
+		switch t := v.(type) {
+		case float64:
+			if math.IsNaN(t) || math.IsInf(t, 0) {
+				j.line = append(j.line, "null"...)
+			} else {
+				j.line = strconv.AppendFloat(j.line, t, 'g', -1, 64)
+			}
+		case time.Time:
+			j.line = strconv.AppendQuote(j.line, t.UTC().Format(time.RFC3339Nano))
+		default:
+			b, err := json.Marshal(t)
+			if err != nil {
+				return err
+			}
+			j.line = append(j.line, b...)
+		}
+	}
+	j.line = append(j.line, '}', '\n')
+	_, err := j.w.Write(j.line)
+	return err
+}
+
+func (j *jsonlWriter) Flush() error { return j.w.Flush() }
+
+func (j *jsonlWriter) Close() error { return j.w.Flush() }
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -56,6 +56,8 @@ type MicrocrawlResult struct {
 	BaseUniqueBefore    int
 	GainRatio           float64
 	DiminishingReturns  bool
+	RobotsSkipped       int // Pages not fetched because robots.txt disallows them
+	SitemapURLs         int // Same-site candidates found in sitemaps
 }
 
 // FeatureAggregate is the derived, analysis-ready aggregation (subset of planned columns).
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fntelecomllc/studio/backend/internal/robots"
 	"golang.org/x/net/html"
 )
 
@@ -30,9 +31,10 @@ func (n NoopMicrocrawler) Crawl(ctx context.Context, rootURL string, budgetPages
 
 // HTTPMicrocrawler implements real HTTP-based microcrawl functionality
 type HTTPMicrocrawler struct {
-	client    *http.Client
-	userAgent string
-	timeout   time.Duration
+	client      *http.Client
+	userAgent   string
+	timeout     time.Duration
+	useSitemaps bool // also pick candidates from sitemap.xml (MICROCRAWL_SITEMAPS=false disables)
 }
 
 // NewHTTPMicrocrawler creates a production microcrawler with HTTP client
@@ -54,8 +56,9 @@ func NewHTTPMicrocrawler() *HTTPMicrocrawler {
 				return nil
 			},
 		},
-		userAgent: "DomainFlow-Microcrawler/1.0",
-		timeout:   timeout,
+		userAgent:   "DomainFlow-Microcrawler/1.0",
+		timeout:     timeout,
+		useSitemaps: os.Getenv("MICROCRAWL_SITEMAPS") != "false",
 	}
 }
 
@@ -71,6 +74,24 @@ func (m *HTTPMicrocrawler) Crawl(ctx context.Context, rootURL string, budgetPage
 		return MicrocrawlResult{}, nil, fmt.Errorf("invalid root URL: %w", err)
 	}
 
+	// robots.txt: secondary pages follow the policy's Respect flag, the root page its EnforceRoot flag
+	policy := robots.Shared().PolicyFor(ctx)
+	robotsSkipped := 0
+	admit := func(pageURL string, root bool) bool {
+		if (root && !policy.EnforceRoot) || (!root && !policy.Respect) {
+			return true
+		}
+		allowed, err := robots.Shared().Admit(ctx, "microcrawl", pageURL, m.userAgent)
+		if err == nil && !allowed {
+			robotsSkipped++
+			return false
+		}
+		return err == nil
+	}
+	if !admit(rootURL, true) {
+		return MicrocrawlResult{RobotsSkipped: robotsSkipped}, nil, nil
+	}
+
 	// Initialize tracking
 	visited := make(map[string]bool)
 	toVisit := []string{rootURL}
@@ -92,13 +113,20 @@ func (m *HTTPMicrocrawler) Crawl(ctx context.Context, rootURL string, budgetPage
 		toVisit = toVisit[1:]
 	}
 
-	// Discover additional URLs from root page
-	additionalURLs, err := m.extractLinksFromPage(ctx, rootURL, baseURL)
-	if err == nil {
-		for _, link := range additionalURLs {
-			if !visited[link] && len(toVisit) < budgetPages*3 { // limit discovery to avoid explosion
-				toVisit = append(toVisit, link)
-			}
+	// Discover additional URLs from the root page and the site's sitemaps, best candidates first
+	candidates, err := m.extractLinksFromPage(ctx, rootURL, baseURL)
+	if err != nil {
+		candidates = nil
+	}
+	sitemapURLs := 0
+	if m.useSitemaps {
+		fromSitemaps := m.discoverSitemapURLs(ctx, baseURL)
+		sitemapURLs = len(fromSitemaps)
+		candidates = append(candidates, fromSitemaps...)
+	}
+	for _, link := range rankCandidateURLs(candidates, baseURL, allKeywords) {
+		if !visited[link] && len(toVisit) < budgetPages*3 { // limit discovery to avoid explosion
+			toVisit = append(toVisit, link)
 		}
 	}
 
@@ -111,6 +139,10 @@ func (m *HTTPMicrocrawler) Crawl(ctx context.Context, rootURL string, budgetPage
 		if visited[currentURL] {
 			continue
 		}
+		if !admit(currentURL, false) {
+			visited[currentURL] = true
+			continue
+		}
 
 		keywords, err := m.extractPageKeywords(ctx, currentURL, "microcrawl")
 		if err != nil {
@@ -162,6 +194,8 @@ func (m *HTTPMicrocrawler) Crawl(ctx context.Context, rootURL string, budgetPage
 		BaseUniqueBefore:    baseKeywordCount,
 		GainRatio:           gainRatio,
 		DiminishingReturns:  gainRatio < 0.3 && pagesVisited >= 2,
+		RobotsSkipped:       robotsSkipped,
+		SitemapURLs:         sitemapURLs,
 	}
 
 	return result, keywordHits, nil
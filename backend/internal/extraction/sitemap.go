@@ -0,0 +1,239 @@
+package extraction
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/fntelecomllc/studio/backend/internal/robots"
+)
+
+// Sitemap discovery limits keep a microcrawl cheap even on sites with huge sitemap trees.
+const (
+	maxSitemapFetches   = 6
+	maxSitemapBytes     = 5 * 1024 * 1024 // per sitemap, after decompression
+	maxSitemapCandidate = 500
+)
+
+// sitemapDocument covers both <urlset> and <sitemapindex> documents.
+type sitemapDocument struct {
+	URLs     []sitemapLoc `xml:"url"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc string `xml:"loc"`
+}
+
+// discoverSitemapURLs returns same-site page URLs listed in the site's sitemaps: those named in
+// robots.txt, or /sitemap.xml when robots.txt names none. Sitemap index files are followed
+// breadth-first until maxSitemapFetches documents have been read. Sitemaps hosted off-site are
+// never fetched, so robots.txt and sitemap indexes cannot point the crawler at other hosts.
+func (m *HTTPMicrocrawler) discoverSitemapURLs(ctx context.Context, baseURL *url.URL) []string {
+	queue := []string{}
+	if rules, err := robots.Shared().Rules(ctx, baseURL, m.userAgent); err == nil {
+		for _, loc := range rules.Sitemaps {
+			if sameSiteSitemap(loc, baseURL) {
+				queue = append(queue, loc)
+			}
+		}
+	}
+	if len(queue) == 0 {
+		queue = append(queue, baseURL.Scheme+"://"+baseURL.Host+"/sitemap.xml")
+	}
+
+	seenSitemaps := make(map[string]bool)
+	seenPages := make(map[string]bool)
+	var pages []string
+	for fetches := 0; len(queue) > 0 && fetches < maxSitemapFetches && len(pages) < maxSitemapCandidate; {
+		loc := queue[0]
+		queue = queue[1:]
+		if seenSitemaps[loc] {
+			continue
+		}
+		seenSitemaps[loc] = true
+		fetches++
+		doc, err := m.fetchSitemap(ctx, loc)
+		if err != nil {
+			continue
+		}
+		for _, s := range doc.Sitemaps {
+			if child := strings.TrimSpace(s.Loc); child != "" && sameSiteSitemap(child, baseURL) {
+				queue = append(queue, child)
+			}
+		}
+		for _, u := range doc.URLs {
+			pu, err := url.Parse(strings.TrimSpace(u.Loc))
+			if err != nil || !sameSite(pu.Host, baseURL.Host) {
+				continue
+			}
+			pu.Fragment = ""
+			page := pu.String()
+			if !seenPages[page] {
+				seenPages[page] = true
+				pages = append(pages, page)
+				if len(pages) >= maxSitemapCandidate {
+					break
+				}
+			}
+		}
+	}
+	return pages
+}
+
+// fetchSitemap downloads and decodes one sitemap, transparently gunzipping .xml.gz files.
+func (m *HTTPMicrocrawler) fetchSitemap(ctx context.Context, loc string) (*sitemapDocument, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", loc, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", m.userAgent)
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxSitemapBytes))
+	if err != nil {
+		return nil, err
+	}
+	// Servers send gzipped sitemaps as application/x-gzip, so the client does not decode them itself.
+	if len(raw) >= 2 && raw[0] == 0x1f && raw[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		raw, err = io.ReadAll(io.LimitReader(zr, maxSitemapBytes))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var doc sitemapDocument
+	if err := xml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("parse sitemap %s: %w", loc, err)
+	}
+	return &doc, nil
+}
+
+// sameSiteSitemap reports whether loc is an http(s) URL on the same site as baseURL.
+func sameSiteSitemap(loc string, baseURL *url.URL) bool {
+	u, err := url.Parse(strings.TrimSpace(loc))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	return sameSite(u.Host, baseURL.Host)
+}
+
+// sameSite treats a host and its www. variant as one site.
+func sameSite(a, b string) bool {
+	return strings.TrimPrefix(strings.ToLower(a), "www.") == strings.TrimPrefix(strings.ToLower(b), "www.")
+}
+
+// pageSegmentScores rewards path segments that usually describe what a business does.
+var pageSegmentScores = map[string]float64{
+	"about": 5, "about-us": 5, "company": 3, "team": 2, "who-we-are": 4,
+	"contact": 4, "contact-us": 4,
+	"services": 4, "service": 4, "solutions": 3, "what-we-do": 4,
+	"pricing": 4, "prices": 4, "plans": 3,
+	"products": 3, "product": 3, "shop": 2, "features": 2,
+}
+
+// lowValueSegments mark listings and plumbing that rarely add keywords of their own.
+var lowValueSegments = map[string]bool{
+	"tag": true, "tags": true, "category": true, "categories": true, "author": true, "page": true,
+	"feed": true, "wp-content": true, "wp-json": true, "cdn-cgi": true, "login": true, "cart": true,
+	"search": true, "privacy": true, "privacy-policy": true, "terms": true, "cookies": true,
+}
+
+var nonPageExtensions = map[string]bool{
+	".pdf": true, ".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".svg": true, ".webp": true,
+	".css": true, ".js": true, ".json": true, ".xml": true, ".gz": true, ".zip": true, ".mp4": true, ".mp3": true,
+}
+
+// scoreCandidateURL ranks a crawl candidate: well-known business pages first, then slugs that repeat
+// keywords already seen on the root page, with deep, listing and query-string URLs last. ok is false
+// for assets that are not pages at all.
+func scoreCandidateURL(u *url.URL, rootKeywords map[string]KeywordHit) (score float64, ok bool) {
+	p := strings.ToLower(strings.TrimSuffix(u.Path, "/"))
+	if nonPageExtensions[path.Ext(p)] {
+		return 0, false
+	}
+	segments := strings.FieldsFunc(p, func(r rune) bool { return r == '/' })
+	score = 1.0
+	keywordHits := 0
+	for i, seg := range segments {
+		name := strings.TrimSuffix(seg, path.Ext(seg))
+		if s, ok := pageSegmentScores[name]; ok {
+			score += s / float64(i+1) // a /services page beats /blog/services
+		}
+		if lowValueSegments[name] {
+			score -= 2
+		}
+		for _, tok := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			if _, ok := rootKeywords[tok]; ok && keywordHits < 3 {
+				keywordHits++
+			}
+		}
+	}
+	score += float64(keywordHits)
+	if len(segments) > 1 {
+		score -= 0.5 * float64(len(segments)-1)
+	}
+	if u.RawQuery != "" {
+		score -= 1
+	}
+	return score, true
+}
+
+// rankCandidateURLs orders crawl candidates by scoreCandidateURL, dropping duplicates, the root itself
+// and non-page URLs. Ties keep the shorter URL first so results are stable.
+func rankCandidateURLs(candidates []string, root *url.URL, rootKeywords map[string]KeywordHit) []string {
+	type scored struct {
+		url   string
+		score float64
+	}
+	seen := map[string]bool{strings.TrimSuffix(root.String(), "/"): true}
+	ranked := make([]scored, 0, len(candidates))
+	for _, c := range candidates {
+		u, err := url.Parse(c)
+		if err != nil || !sameSite(u.Host, root.Host) {
+			continue
+		}
+		u.Fragment = ""
+		key := strings.TrimSuffix(u.String(), "/")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if s, ok := scoreCandidateURL(u, rootKeywords); ok {
+			ranked = append(ranked, scored{url: u.String(), score: s})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		if len(ranked[i].url) != len(ranked[j].url) {
+			return len(ranked[i].url) < len(ranked[j].url)
+		}
+		return ranked[i].url < ranked[j].url
+	})
+	out := make([]string, len(ranked))
+	for i, r := range ranked {
+		out[i] = r.url
+	}
+	return out
+}
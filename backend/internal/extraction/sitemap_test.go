@@ -0,0 +1,151 @@
+package extraction
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestCrawlSpendsBudgetOnRankedSitemapPages(t *testing.T) {
+	var mu sync.Mutex
+	var fetched []string
+	page := func(words string) []byte {
+		return []byte("<html><head><title>" + words + "</title></head><body><p>" + words + "</p></body></html>")
+	}
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("User-agent: *\nSitemap: " + srv.URL + "/sitemap_index.xml\n"))
+	})
+	mux.HandleFunc("/sitemap_index.xml", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><sitemap><loc>` + srv.URL + `/pages.xml.gz</loc></sitemap></sitemapindex>`))
+	})
+	mux.HandleFunc("/pages.xml.gz", func(w http.ResponseWriter, _ *http.Request) {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, _ = zw.Write([]byte(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<url><loc>` + srv.URL + `/blog/2019/05/hello-world</loc></url>
+<url><loc>` + srv.URL + `/tag/misc</loc></url>
+<url><loc>` + srv.URL + `/pricing</loc></url>
+<url><loc>` + srv.URL + `/about-us</loc></url>
+<url><loc>` + srv.URL + `/brochure.pdf</loc></url>
+<url><loc>https://elsewhere.example/about</loc></url>
+</urlset>`))
+		_ = zw.Close()
+		w.Header().Set("Content-Type", "application/x-gzip")
+		_, _ = w.Write(buf.Bytes())
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fetched = append(fetched, r.URL.Path)
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/":
+			// JS navigation: no crawlable links on the root page
+			_, _ = w.Write(page("widgets factory"))
+		case "/about-us":
+			_, _ = w.Write(page("family business since 1950"))
+		case "/pricing":
+			_, _ = w.Write(page("affordable monthly subscription"))
+		default:
+			_, _ = w.Write(page("random musings"))
+		}
+	})
+	srv = httptest.NewServer(mux)
+	defer srv.Close()
+
+	m := NewHTTPMicrocrawler()
+	result, _, err := m.Crawl(context.Background(), srv.URL+"/", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PagesVisited != 3 || result.SitemapURLs != 5 {
+		t.Fatalf("expected 3 pages from 5 same-site sitemap entries, got %+v", result)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	var secondary []string
+	for _, p := range fetched {
+		if p != "/" {
+			secondary = append(secondary, p)
+		}
+	}
+	if !reflect.DeepEqual(secondary, []string{"/about-us", "/pricing"}) {
+		t.Errorf("expected the budget to go to about and pricing pages, fetched %v", secondary)
+	}
+}
+
+func TestRankCandidateURLs(t *testing.T) {
+	root, _ := url.Parse("https://www.example.com/")
+	keywords := map[string]KeywordHit{"widgets": {KeywordID: "widgets"}}
+	got := rankCandidateURLs([]string{
+		"https://example.com/news/2020/01/announcement",
+		"https://www.example.com/category/general",
+		"https://www.example.com/blue-widgets",
+		"https://www.example.com/contact#form",
+		"https://www.example.com/contact",
+		"https://www.example.com/",
+		"https://www.example.com/logo.png",
+		"https://www.example.com/services",
+		"https://www.example.com/search?q=x",
+		"https://other.example/about",
+	}, root, keywords)
+	want := []string{
+		"https://www.example.com/contact",
+		"https://www.example.com/services",
+		"https://www.example.com/blue-widgets",
+		"https://example.com/news/2020/01/announcement",
+		"https://www.example.com/category/general",
+		"https://www.example.com/search?q=x",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected ranking:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestDiscoverSitemapURLsSkipsOffSiteSitemaps(t *testing.T) {
+	var offSiteHits int
+	var mu sync.Mutex
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		offSiteHits++
+		mu.Unlock()
+		_, _ = w.Write([]byte(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>http://internal/admin</loc></url></urlset>`))
+	}))
+	defer other.Close()
+
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("User-agent: *\nSitemap: " + other.URL + "/sitemap.xml\nSitemap: " + srv.URL + "/sitemap_index.xml\n"))
+	})
+	mux.HandleFunc("/sitemap_index.xml", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<sitemap><loc>` + other.URL + `/child.xml</loc></sitemap>
+<sitemap><loc>file:///etc/passwd</loc></sitemap>
+<sitemap><loc>` + srv.URL + `/pages.xml</loc></sitemap>
+</sitemapindex>`))
+	})
+	mux.HandleFunc("/pages.xml", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>` + srv.URL + `/about</loc></url></urlset>`))
+	})
+	srv = httptest.NewServer(mux)
+	defer srv.Close()
+
+	base, _ := url.Parse(srv.URL + "/")
+	pages := NewHTTPMicrocrawler().discoverSitemapURLs(context.Background(), base)
+	if !reflect.DeepEqual(pages, []string{srv.URL + "/about"}) {
+		t.Errorf("expected only the same-site page, got %v", pages)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if offSiteHits != 0 {
+		t.Errorf("off-site sitemaps were fetched %d times", offSiteHits)
+	}
+}
@@ -0,0 +1,234 @@
+// Package httpfingerprint applies HTTP persona fingerprint settings (TLS ClientHello parameters and
+// request header order) to an http.Transport so that requests made under a persona look the way the
+// persona describes on the wire.
+package httpfingerprint
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/fntelecomllc/studio/backend/internal/config"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+)
+
+// maxHeaderBlockBytes bounds how much of a request is buffered while looking for the end of its header
+// block; larger blocks are written through unchanged.
+const maxHeaderBlockBytes = 64 << 10
+
+// TLSConfig builds a client tls.Config from a persona ClientHello description. A nil hello yields a
+// config carrying only the insecure flag. Unknown versions, cipher suites or curves are rejected. Note that
+// crypto/tls ignores CipherSuites for TLS 1.3 and chooses its own curve order, so for those only the
+// offered set is persona controlled.
+func TLSConfig(hello *models.HTTPTLSClientHello, insecureSkipVerify bool) (*tls.Config, error) {
+	cfg := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+	if hello == nil {
+		return cfg, nil
+	}
+	if hello.MinVersion != "" {
+		v, ok := config.GetTLSVersion(hello.MinVersion)
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS minVersion: %s", hello.MinVersion)
+		}
+		cfg.MinVersion = v
+	}
+	if hello.MaxVersion != "" {
+		v, ok := config.GetTLSVersion(hello.MaxVersion)
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS maxVersion: %s", hello.MaxVersion)
+		}
+		cfg.MaxVersion = v
+	}
+	if cfg.MinVersion != 0 && cfg.MaxVersion != 0 && cfg.MinVersion > cfg.MaxVersion {
+		return nil, fmt.Errorf("TLS minVersion %s is above maxVersion %s", hello.MinVersion, hello.MaxVersion)
+	}
+	if len(hello.CipherSuites) > 0 {
+		suites, err := config.GetCipherSuites(hello.CipherSuites)
+		if err != nil {
+			return nil, err
+		}
+		cfg.CipherSuites = suites
+	}
+	if len(hello.CurvePreferences) > 0 {
+		curves, err := config.GetCurvePreferences(hello.CurvePreferences)
+		if err != nil {
+			return nil, err
+		}
+		cfg.CurvePreferences = curves
+	}
+	return cfg, nil
+}
+
+// Validate reports whether the persona fingerprint settings can be applied.
+func Validate(cfg models.HTTPConfigDetails) error {
+	_, err := TLSConfig(cfg.TLSClientHello, false)
+	return err
+}
+
+// Apply configures t with the persona's HTTP/2 preference, TLS ClientHello and header order. When the
+// ClientHello settings are invalid an error is returned and t keeps its TLS config and header order.
+//
+// Go's HTTP/1.1 writer always emits headers sorted by name, so a configured header order is enforced by
+// rewriting each connection's header block as it is written. That only works for HTTP/1.1 on connections
+// carrying a single request, so when HeaderOrder is set HTTP/2 is not negotiated and keep-alives are
+// disabled. Writes that do not start an HTTP request (SOCKS handshakes, TLS records) pass through
+// unchanged, so HTTPS requests sent through a proxy keep the TLS settings but not the header order.
+func Apply(t *http.Transport, cfg models.HTTPConfigDetails, insecureSkipVerify bool) error {
+	if cfg.HTTP2Settings != nil {
+		t.ForceAttemptHTTP2 = cfg.HTTP2Settings.Enabled
+	}
+	tlsCfg, err := TLSConfig(cfg.TLSClientHello, insecureSkipVerify)
+	if err != nil {
+		return err
+	}
+	t.TLSClientConfig = tlsCfg
+	if len(cfg.HeaderOrder) == 0 {
+		return nil
+	}
+	order := make([]string, 0, len(cfg.HeaderOrder))
+	for _, name := range cfg.HeaderOrder {
+		if name = strings.TrimSpace(name); name != "" {
+			order = append(order, http.CanonicalHeaderKey(name))
+		}
+	}
+	dial := t.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	t.ForceAttemptHTTP2 = false
+	t.DisableKeepAlives = true
+	t.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return &orderedHeaderConn{Conn: conn, order: order}, nil
+	}
+	t.DialTLSContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		raw, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		hc := tlsCfg.Clone()
+		if hc.ServerName == "" {
+			host, _, splitErr := net.SplitHostPort(addr)
+			if splitErr != nil {
+				host = addr
+			}
+			hc.ServerName = host
+		}
+		hc.NextProtos = []string{"http/1.1"}
+		tc := tls.Client(raw, hc)
+		if t.TLSHandshakeTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, t.TLSHandshakeTimeout)
+			defer cancel()
+		}
+		if err := tc.HandshakeContext(ctx); err != nil {
+			raw.Close()
+			return nil, err
+		}
+		return &orderedHeaderConn{Conn: tc, order: order}, nil
+	}
+	return nil
+}
+
+// orderedHeaderConn rewrites the first HTTP/1.1 header block written to the connection so that headers
+// listed in order come first, in that order; the remaining headers keep their relative position. Writes
+// before it that are not the start of a request line are passed through.
+type orderedHeaderConn struct {
+	net.Conn
+	order []string
+	buf   []byte
+	done  bool
+}
+
+// ConnectionState exposes the underlying TLS session, which net/http cannot see through the wrapper.
+// It returns the zero state for plain connections.
+func (c *orderedHeaderConn) ConnectionState() tls.ConnectionState {
+	if tc, ok := c.Conn.(*tls.Conn); ok {
+		return tc.ConnectionState()
+	}
+	return tls.ConnectionState{}
+}
+
+func (c *orderedHeaderConn) Write(p []byte) (int, error) {
+	if c.done || (len(c.buf) == 0 && !startsRequestLine(p)) {
+		return c.Conn.Write(p)
+	}
+	c.buf = append(c.buf, p...)
+	end := bytes.Index(c.buf, []byte("\r\n\r\n"))
+	if end < 0 && len(c.buf) < maxHeaderBlockBytes {
+		return len(p), nil
+	}
+	out := c.buf
+	if end >= 0 {
+		out = append(ReorderHeaderBlock(c.buf[:end+4], c.order), c.buf[end+4:]...)
+	}
+	c.buf, c.done = nil, true
+	if _, err := c.Conn.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// startsRequestLine reports whether p begins with an HTTP method token followed by a space. Methods are
+// upper-case letters, which neither SOCKS handshakes nor TLS records start with.
+func startsRequestLine(p []byte) bool {
+	for i, b := range p {
+		switch {
+		case b >= 'A' && b <= 'Z':
+		case b == ' ':
+			return i > 0
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// ReorderHeaderBlock reorders the header lines of a raw HTTP/1.x header block (request line included,
+// terminated by a blank line). Header names are matched case-insensitively.
+func ReorderHeaderBlock(block []byte, order []string) []byte {
+	lines := strings.Split(strings.TrimSuffix(string(block), "\r\n\r\n"), "\r\n")
+	if len(lines) < 2 || len(order) == 0 {
+		return block
+	}
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		name = http.CanonicalHeaderKey(name)
+		if _, seen := rank[name]; !seen {
+			rank[name] = i
+		}
+	}
+	ranked := make([][]string, len(order))
+	rest := make([]string, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		name, _, _ := strings.Cut(line, ":")
+		if i, ok := rank[http.CanonicalHeaderKey(strings.TrimSpace(name))]; ok {
+			ranked[i] = append(ranked[i], line)
+			continue
+		}
+		rest = append(rest, line)
+	}
+	var b strings.Builder
+	b.Grow(len(block))
+	b.WriteString(lines[0])
+	b.WriteString("\r\n")
+	for _, group := range ranked {
+		for _, line := range group {
+			b.WriteString(line)
+			b.WriteString("\r\n")
+		}
+	}
+	for _, line := range rest {
+		b.WriteString(line)
+		b.WriteString("\r\n")
+	}
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
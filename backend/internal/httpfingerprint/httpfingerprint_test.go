@@ -0,0 +1,215 @@
+package httpfingerprint
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/testutil"
+)
+
+func TestTLSConfig(t *testing.T) {
+	cfg, err := TLSConfig(&models.HTTPTLSClientHello{
+		MinVersion:       "TLS12",
+		MaxVersion:       "tls13",
+		CipherSuites:     []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"},
+		CurvePreferences: []string{"X25519", "CurveP256"},
+	}, true)
+	if err != nil {
+		t.Fatalf("TLSConfig: %v", err)
+	}
+	if cfg.MinVersion != tls.VersionTLS12 || cfg.MaxVersion != tls.VersionTLS13 || !cfg.InsecureSkipVerify {
+		t.Fatalf("unexpected versions/insecure: %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.CipherSuites, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384}) {
+		t.Fatalf("unexpected cipher suites %v", cfg.CipherSuites)
+	}
+	if !reflect.DeepEqual(cfg.CurvePreferences, []tls.CurveID{tls.X25519, tls.CurveP256}) {
+		t.Fatalf("unexpected curves %v", cfg.CurvePreferences)
+	}
+
+	for name, hello := range map[string]*models.HTTPTLSClientHello{
+		"bad version":   {MinVersion: "SSL3"},
+		"inverted":      {MinVersion: "TLS13", MaxVersion: "TLS12"},
+		"unknown suite": {CipherSuites: []string{"TLS_FAKE"}},
+		"unknown curve": {CurvePreferences: []string{"P999"}},
+	} {
+		if _, err := TLSConfig(hello, false); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestReorderHeaderBlock(t *testing.T) {
+	block := "GET / HTTP/1.1\r\nHost: example.com\r\nUser-Agent: ua\r\nAccept: */*\r\nAccept-Language: en\r\nX-Extra: 1\r\n\r\n"
+	got := string(ReorderHeaderBlock([]byte(block), []string{"accept-language", "User-Agent", "Missing", "Host"}))
+	want := "GET / HTTP/1.1\r\nAccept-Language: en\r\nUser-Agent: ua\r\nHost: example.com\r\nAccept: */*\r\nX-Extra: 1\r\n\r\n"
+	if got != want {
+		t.Fatalf("unexpected block:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestApplyAgainstLocalTLSServer(t *testing.T) {
+	srv := testutil.NewRecordingTLSServer(t)
+	transport := &http.Transport{}
+	err := Apply(transport, models.HTTPConfigDetails{
+		HeaderOrder: []string{"accept-language", "user-agent", "accept", "host"},
+		TLSClientHello: &models.HTTPTLSClientHello{
+			MinVersion:       "TLS12",
+			MaxVersion:       "TLS12",
+			CipherSuites:     []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			CurvePreferences: []string{"CurveP384", "CurveP256"},
+		},
+	}, true)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("User-Agent", "persona-ua")
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("Accept-Language", "en-GB")
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	resp.Body.Close()
+
+	hello := <-srv.Hellos
+	if !reflect.DeepEqual(hello.SupportedVersions, []uint16{tls.VersionTLS12}) {
+		t.Errorf("unexpected supported versions %v", hello.SupportedVersions)
+	}
+	if !reflect.DeepEqual(hello.CipherSuites, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}) {
+		t.Errorf("unexpected cipher suites %v", hello.CipherSuites)
+	}
+	// crypto/tls picks its own curve order, so only the offered set is persona controlled.
+	curves := slices.Clone(hello.SupportedCurves)
+	slices.Sort(curves)
+	if !reflect.DeepEqual(curves, []tls.CurveID{tls.CurveP256, tls.CurveP384}) {
+		t.Errorf("unexpected curves %v", hello.SupportedCurves)
+	}
+	if !reflect.DeepEqual(hello.SupportedProtos, []string{"http/1.1"}) {
+		t.Errorf("expected http/1.1 only ALPN, got %v", hello.SupportedProtos)
+	}
+	if got := <-srv.HeaderNames; !reflect.DeepEqual(got[:4], []string{"Accept-Language", "User-Agent", "Accept", "Host"}) {
+		t.Errorf("unexpected header order %v", got)
+	}
+}
+
+func TestApplyThroughSOCKS5Proxy(t *testing.T) {
+	srv := testutil.NewRecordingTLSServer(t)
+	proxyAddr := startSOCKS5Proxy(t)
+	transport := &http.Transport{Proxy: http.ProxyURL(&url.URL{Scheme: "socks5", Host: proxyAddr})}
+	err := Apply(transport, models.HTTPConfigDetails{
+		HeaderOrder:    []string{"user-agent", "host"},
+		TLSClientHello: &models.HTTPTLSClientHello{MaxVersion: "TLS12", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+	}, true)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	resp, err := (&http.Client{Transport: transport, Timeout: 5 * time.Second}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request through socks5 proxy: %v", err)
+	}
+	resp.Body.Close()
+	if hello := <-srv.Hellos; !reflect.DeepEqual(hello.CipherSuites, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}) {
+		t.Errorf("expected persona cipher suites through the proxy, got %v", hello.CipherSuites)
+	}
+}
+
+func TestStartsRequestLine(t *testing.T) {
+	for in, want := range map[string]bool{
+		"GET / HTTP/1.1\r\n":     true,
+		"CONNECT a:443 HTTP/1.1": true,
+		"\x05\x01\x00":           false,
+		"\x16\x03\x01":           false,
+		" GET":                   false,
+		"GET":                    false,
+	} {
+		if got := startsRequestLine([]byte(in)); got != want {
+			t.Errorf("startsRequestLine(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+// startSOCKS5Proxy runs a minimal no-auth SOCKS5 CONNECT proxy on a loopback port.
+func startSOCKS5Proxy(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSOCKS5(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func serveSOCKS5(conn net.Conn) {
+	defer conn.Close()
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(conn, head); err != nil || head[0] != 5 {
+		return
+	}
+	if _, err := io.ReadFull(conn, make([]byte, head[1])); err != nil {
+		return
+	}
+	if _, err := conn.Write([]byte{5, 0}); err != nil {
+		return
+	}
+	req := make([]byte, 4)
+	if _, err := io.ReadFull(conn, req); err != nil || req[1] != 1 {
+		return
+	}
+	var host string
+	switch req[3] {
+	case 1:
+		ip := make([]byte, 4)
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return
+		}
+		host = net.IP(ip).String()
+	case 3:
+		n := make([]byte, 1)
+		if _, err := io.ReadFull(conn, n); err != nil {
+			return
+		}
+		name := make([]byte, n[0])
+		if _, err := io.ReadFull(conn, name); err != nil {
+			return
+		}
+		host = string(name)
+	default:
+		return
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return
+	}
+	upstream, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))))
+	if err != nil {
+		_, _ = conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	defer upstream.Close()
+	if _, err := conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0}); err != nil {
+		return
+	}
+	go func() { _, _ = io.Copy(upstream, conn) }()
+	_, _ = io.Copy(conn, upstream)
+}
@@ -0,0 +1,151 @@
+package httpvalidator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/net/websocket"
+)
+
+// cdpMaxMessageBytes bounds a single DevTools message; rendered DOMs and base64 screenshots are large.
+const cdpMaxMessageBytes = 64 << 20
+
+var errCDPClosed = errors.New("devtools connection closed")
+
+// cdpMessage is a Chrome DevTools Protocol frame: a command response when ID is set, otherwise an event.
+type cdpMessage struct {
+	ID     int64           `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *cdpError       `json:"error,omitempty"`
+}
+
+type cdpError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *cdpError) Error() string { return fmt.Sprintf("devtools error %d: %s", e.Code, e.Message) }
+
+// cdpConn is a minimal DevTools client for one browser or page websocket endpoint. Events are handed to
+// onEvent from the read loop, so handlers must not block or issue commands themselves.
+type cdpConn struct {
+	ws      *websocket.Conn
+	onEvent func(method string, params json.RawMessage)
+	nextID  int64
+
+	mu      sync.Mutex
+	pending map[int64]chan cdpMessage
+	closed  chan struct{}
+	err     error
+}
+
+func dialCDP(ctx context.Context, wsURL string, onEvent func(string, json.RawMessage)) (*cdpConn, error) {
+	cfg, err := websocket.NewConfig(wsURL, "http://localhost/")
+	if err != nil {
+		return nil, err
+	}
+	ws, err := cfg.DialContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("dial devtools %s: %w", wsURL, err)
+	}
+	ws.MaxPayloadBytes = cdpMaxMessageBytes
+	c := &cdpConn{ws: ws, onEvent: onEvent, pending: make(map[int64]chan cdpMessage), closed: make(chan struct{})}
+	go c.readLoop()
+	return c, nil
+}
+
+func (c *cdpConn) readLoop() {
+	var err error
+	for {
+		var msg cdpMessage
+		if err = websocket.JSON.Receive(c.ws, &msg); err != nil {
+			break
+		}
+		if msg.ID == 0 {
+			if c.onEvent != nil && msg.Method != "" {
+				c.onEvent(msg.Method, msg.Params)
+			}
+			continue
+		}
+		c.mu.Lock()
+		ch := c.pending[msg.ID]
+		delete(c.pending, msg.ID)
+		c.mu.Unlock()
+		if ch != nil {
+			ch <- msg
+		}
+	}
+	c.mu.Lock()
+	c.err = err
+	c.pending = nil
+	c.mu.Unlock()
+	close(c.closed)
+}
+
+// Call sends a command and decodes its result into result when non-nil.
+func (c *cdpConn) Call(ctx context.Context, method string, params, result interface{}) error {
+	id := atomic.AddInt64(&c.nextID, 1)
+	ch := make(chan cdpMessage, 1)
+	c.mu.Lock()
+	if c.pending == nil {
+		c.mu.Unlock()
+		return errCDPClosed
+	}
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	req := map[string]interface{}{"id": id, "method": method}
+	if params != nil {
+		req["params"] = params
+	}
+	if err := websocket.JSON.Send(c.ws, req); err != nil {
+		c.forget(id)
+		return fmt.Errorf("%s: %w", method, err)
+	}
+
+	select {
+	case msg := <-ch:
+		if msg.Error != nil {
+			return fmt.Errorf("%s: %w", method, msg.Error)
+		}
+		if result != nil && len(msg.Result) > 0 {
+			if err := json.Unmarshal(msg.Result, result); err != nil {
+				return fmt.Errorf("%s: decode result: %w", method, err)
+			}
+		}
+		return nil
+	case <-c.closed:
+		return fmt.Errorf("%s: %w", method, errCDPClosed)
+	case <-ctx.Done():
+		c.forget(id)
+		return ctx.Err()
+	}
+}
+
+func (c *cdpConn) forget(id int64) {
+	c.mu.Lock()
+	if c.pending != nil {
+		delete(c.pending, id)
+	}
+	c.mu.Unlock()
+}
+
+// Alive reports whether the websocket is still open.
+func (c *cdpConn) Alive() bool {
+	select {
+	case <-c.closed:
+		return false
+	default:
+		return true
+	}
+}
+
+func (c *cdpConn) Close() error {
+	return c.ws.Close()
+}
@@ -0,0 +1,493 @@
+package httpvalidator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/config"
+	"golang.org/x/net/html"
+)
+
+// errHeadlessUnavailable means no page could be rendered because the browser itself is missing or
+// could not be started, as opposed to the target site failing to load.
+var errHeadlessUnavailable = errors.New("headless browser unavailable")
+
+var devToolsListeningRe = regexp.MustCompile(`DevTools listening on (ws://\S+)`)
+
+// headlessBrowser owns one lazily started Chromium process. Each render runs in its own browser
+// context so cookies, cache and proxy settings never leak between validations.
+type headlessBrowser struct {
+	cfg   config.HeadlessBrowserConfig
+	pages chan struct{}
+	// launch starts the browser and returns its browser-level DevTools websocket URL.
+	launch func(ctx context.Context) (string, error)
+
+	mu      sync.Mutex
+	cmd     *exec.Cmd
+	dataDir string
+	conn    *cdpConn
+	wsURL   string
+}
+
+// renderOptions carries the per-persona request shape into the browser.
+type renderOptions struct {
+	UserAgent   string
+	Headers     map[string]string
+	ProxyServer string // scheme://host:port, empty for a direct connection
+	IgnoreTLS   bool
+	Screenshot  bool
+}
+
+// renderedPage is what the browser saw for the main document once scripts had a chance to run.
+type renderedPage struct {
+	FinalURL   string
+	StatusCode int
+	Headers    map[string][]string
+	DOM        []byte
+	Screenshot []byte
+}
+
+type documentResponse struct {
+	URL     string
+	Status  int
+	Headers map[string][]string
+}
+
+func newHeadlessBrowser(cfg config.HeadlessBrowserConfig) *headlessBrowser {
+	pages := cfg.MaxConcurrentPages
+	if pages <= 0 {
+		pages = 1
+	}
+	b := &headlessBrowser{cfg: cfg, pages: make(chan struct{}, pages)}
+	b.launch = b.startChromium
+	return b
+}
+
+// browser returns a live browser connection, (re)starting Chromium if it never started or has died.
+func (b *headlessBrowser) browser(ctx context.Context) (*cdpConn, string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.conn != nil && b.conn.Alive() {
+		return b.conn, b.wsURL, nil
+	}
+	b.stopLocked()
+	wsURL, err := b.launch(ctx)
+	if err != nil {
+		b.stopLocked()
+		return nil, "", fmt.Errorf("%w: %v", errHeadlessUnavailable, err)
+	}
+	conn, err := dialCDP(ctx, wsURL, nil)
+	if err != nil {
+		b.stopLocked()
+		return nil, "", fmt.Errorf("%w: %v", errHeadlessUnavailable, err)
+	}
+	b.conn, b.wsURL = conn, wsURL
+	return conn, wsURL, nil
+}
+
+func (b *headlessBrowser) startChromium(ctx context.Context) (string, error) {
+	bin, err := b.chromiumBinary()
+	if err != nil {
+		return "", err
+	}
+	dataDir, err := os.MkdirTemp("", "studio-chromium-")
+	if err != nil {
+		return "", err
+	}
+	b.dataDir = dataDir
+	// Not tied to ctx: the browser outlives the validation that happened to start it.
+	cmd := exec.Command(bin,
+		"--headless=new",
+		"--remote-debugging-port=0",
+		"--remote-allow-origins=*",
+		"--user-data-dir="+dataDir,
+		"--no-first-run",
+		"--no-default-browser-check",
+		"--disable-gpu",
+		"--disable-dev-shm-usage",
+		"--disable-extensions",
+		"--disable-background-networking",
+		"--mute-audio",
+		"--hide-scrollbars",
+		"about:blank",
+	)
+	if os.Geteuid() == 0 {
+		cmd.Args = append(cmd.Args, "--no-sandbox")
+	}
+	configureBrowserProcess(cmd)
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return "", err
+	}
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("start %s: %w", bin, err)
+	}
+	b.cmd = cmd
+
+	found := make(chan string, 1)
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			if m := devToolsListeningRe.FindStringSubmatch(scanner.Text()); m != nil {
+				found <- m[1]
+				break
+			}
+		}
+		// Keep draining so a chatty browser never blocks on a full pipe.
+		_, _ = io.Copy(io.Discard, stderr)
+	}()
+
+	timeout := time.NewTimer(20 * time.Second)
+	defer timeout.Stop()
+	select {
+	case wsURL := <-found:
+		log.Printf("[headless] Chromium %s started (pid %d)", bin, cmd.Process.Pid)
+		return wsURL, nil
+	case <-timeout.C:
+		return "", errors.New("timed out waiting for the DevTools endpoint")
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (b *headlessBrowser) chromiumBinary() (string, error) {
+	if b.cfg.ChromiumPath != "" {
+		return b.cfg.ChromiumPath, nil
+	}
+	for _, name := range []string{"chromium", "chromium-browser", "google-chrome", "google-chrome-stable"} {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New("no Chromium binary found; set httpValidator.headless.chromiumPath")
+}
+
+// Close stops the browser process and removes its profile directory.
+func (b *headlessBrowser) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stopLocked()
+	return nil
+}
+
+func (b *headlessBrowser) stopLocked() {
+	if b.conn != nil {
+		_ = b.conn.Close()
+		b.conn = nil
+	}
+	if b.cmd != nil && b.cmd.Process != nil {
+		_ = b.cmd.Process.Kill()
+		_ = b.cmd.Wait()
+	}
+	b.cmd = nil
+	if b.dataDir != "" {
+		_ = os.RemoveAll(b.dataDir)
+		b.dataDir = ""
+	}
+}
+
+// render loads target in a fresh browser context and returns the document after the load event plus
+// the configured settle time.
+func (b *headlessBrowser) render(ctx context.Context, target string, opts renderOptions) (*renderedPage, error) {
+	select {
+	case b.pages <- struct{}{}:
+		defer func() { <-b.pages }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	browser, wsURL, err := b.browser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxParams := map[string]interface{}{"disposeOnDetach": true}
+	if opts.ProxyServer != "" {
+		ctxParams["proxyServer"] = opts.ProxyServer
+	}
+	var created struct {
+		BrowserContextID string `json:"browserContextId"`
+	}
+	if err := browser.Call(ctx, "Target.createBrowserContext", ctxParams, &created); err != nil {
+		return nil, err
+	}
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = browser.Call(cleanupCtx, "Target.disposeBrowserContext", map[string]string{"browserContextId": created.BrowserContextID}, nil)
+	}()
+
+	var tgt struct {
+		TargetID string `json:"targetId"`
+	}
+	if err := browser.Call(ctx, "Target.createTarget", map[string]string{"url": "about:blank", "browserContextId": created.BrowserContextID}, &tgt); err != nil {
+		return nil, err
+	}
+
+	var (
+		mu        sync.Mutex
+		documents = map[string]documentResponse{}
+		loaded    = make(chan struct{})
+		loadOnce  sync.Once
+		// A late load event from the initial about:blank must not count as the target's.
+		navigating atomic.Bool
+	)
+	page, err := dialCDP(ctx, pageWebSocketURL(wsURL, tgt.TargetID), func(method string, params json.RawMessage) {
+		switch method {
+		case "Network.responseReceived":
+			var ev struct {
+				RequestID string `json:"requestId"`
+				Type      string `json:"type"`
+				Response  struct {
+					URL     string                 `json:"url"`
+					Status  int                    `json:"status"`
+					Headers map[string]interface{} `json:"headers"`
+				} `json:"response"`
+			}
+			if json.Unmarshal(params, &ev) != nil || ev.Type != "Document" {
+				return
+			}
+			mu.Lock()
+			documents[ev.RequestID] = documentResponse{URL: ev.Response.URL, Status: ev.Response.Status, Headers: cdpHeaders(ev.Response.Headers)}
+			mu.Unlock()
+		case "Page.loadEventFired":
+			if navigating.Load() {
+				loadOnce.Do(func() { close(loaded) })
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer page.Close()
+
+	for _, method := range []string{"Network.enable", "Page.enable"} {
+		if err := page.Call(ctx, method, nil, nil); err != nil {
+			return nil, err
+		}
+	}
+	if opts.UserAgent != "" {
+		if err := page.Call(ctx, "Network.setUserAgentOverride", map[string]string{"userAgent": opts.UserAgent}, nil); err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.Headers) > 0 {
+		if err := page.Call(ctx, "Network.setExtraHTTPHeaders", map[string]interface{}{"headers": opts.Headers}, nil); err != nil {
+			return nil, err
+		}
+	}
+	if opts.IgnoreTLS {
+		if err := page.Call(ctx, "Security.setIgnoreCertificateErrors", map[string]bool{"ignore": true}, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	var nav struct {
+		LoaderID  string `json:"loaderId"`
+		ErrorText string `json:"errorText"`
+	}
+	navigating.Store(true)
+	if err := page.Call(ctx, "Page.navigate", map[string]string{"url": target}, &nav); err != nil {
+		return nil, err
+	}
+	if nav.ErrorText != "" {
+		return nil, fmt.Errorf("navigation failed: %s", nav.ErrorText)
+	}
+	select {
+	case <-loaded:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("page did not finish loading: %w", ctx.Err())
+	}
+	if b.cfg.RenderWait > 0 {
+		wait := time.NewTimer(b.cfg.RenderWait)
+		select {
+		case <-wait.C:
+		case <-ctx.Done():
+			wait.Stop()
+			return nil, fmt.Errorf("page did not settle: %w", ctx.Err())
+		}
+	}
+
+	var eval struct {
+		Result struct {
+			Value struct {
+				URL  string `json:"url"`
+				HTML string `json:"html"`
+			} `json:"value"`
+		} `json:"result"`
+		ExceptionDetails json.RawMessage `json:"exceptionDetails"`
+	}
+	err = page.Call(ctx, "Runtime.evaluate", map[string]interface{}{
+		"expression":    "({url: location.href, html: document.documentElement ? document.documentElement.outerHTML : ''})",
+		"returnByValue": true,
+	}, &eval)
+	if err != nil {
+		return nil, err
+	}
+	if len(eval.ExceptionDetails) > 0 {
+		return nil, fmt.Errorf("reading rendered DOM failed: %s", eval.ExceptionDetails)
+	}
+
+	out := &renderedPage{FinalURL: eval.Result.Value.URL, DOM: []byte(eval.Result.Value.HTML)}
+	mu.Lock()
+	// The main document's request id is the navigation's loader id and survives redirects.
+	if doc, ok := documents[nav.LoaderID]; ok {
+		out.StatusCode, out.Headers = doc.Status, doc.Headers
+		if out.FinalURL == "" {
+			out.FinalURL = doc.URL
+		}
+	}
+	mu.Unlock()
+
+	if opts.Screenshot {
+		var shot struct {
+			Data string `json:"data"`
+		}
+		if err := page.Call(ctx, "Page.captureScreenshot", map[string]string{"format": "png"}, &shot); err != nil {
+			log.Printf("[headless] screenshot of %s failed: %v", target, err)
+		} else if data, err := base64.StdEncoding.DecodeString(shot.Data); err == nil {
+			out.Screenshot = data
+		}
+	}
+	return out, nil
+}
+
+// pageWebSocketURL derives a target's websocket endpoint from the browser endpoint's host.
+func pageWebSocketURL(browserWS, targetID string) string {
+	u, err := url.Parse(browserWS)
+	if err != nil {
+		return browserWS
+	}
+	u.Path = "/devtools/page/" + targetID
+	return u.String()
+}
+
+// cdpHeaders converts DevTools headers, which join repeated values with newlines, to the net/http shape.
+func cdpHeaders(in map[string]interface{}) map[string][]string {
+	out := make(map[string][]string, len(in))
+	for k, v := range in {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		out[CanonicalHeaderKey(k)] = strings.Split(s, "\n")
+	}
+	return out
+}
+
+// saveArtifacts writes the screenshot and DOM snapshot under ArtifactDir/<date>/ and returns their
+// paths relative to ArtifactDir.
+func (b *headlessBrowser) saveArtifacts(domain string, page *renderedPage, saveDOM bool) (screenshotPath, domPath string, err error) {
+	if len(page.Screenshot) == 0 && !saveDOM {
+		return "", "", nil
+	}
+	now := time.Now().UTC()
+	relDir := now.Format("2006-01-02")
+	if err := os.MkdirAll(filepath.Join(b.cfg.ArtifactDir, relDir), 0o755); err != nil {
+		return "", "", err
+	}
+	base := fmt.Sprintf("%s-%d", artifactName(domain), now.UnixNano())
+	if len(page.Screenshot) > 0 {
+		rel := filepath.Join(relDir, base+".png")
+		if err := os.WriteFile(filepath.Join(b.cfg.ArtifactDir, rel), page.Screenshot, 0o644); err != nil {
+			return "", "", err
+		}
+		screenshotPath = rel
+	}
+	if saveDOM {
+		rel := filepath.Join(relDir, base+".html")
+		if err := os.WriteFile(filepath.Join(b.cfg.ArtifactDir, rel), page.DOM, 0o644); err != nil {
+			return screenshotPath, "", err
+		}
+		domPath = rel
+	}
+	return screenshotPath, domPath, nil
+}
+
+func artifactName(domain string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		}
+		return '_'
+	}, domain)
+}
+
+// spaMountIDs are the ids frameworks commonly render into.
+var spaMountIDs = map[string]bool{"root": true, "app": true, "__next": true, "__nuxt": true, "svelte": true, "main": true}
+
+// looksLikeSPAShell reports whether an HTML document is an empty client-side application shell:
+// it loads scripts but has almost no visible text, and either an empty mount element or a
+// <noscript> asking for JavaScript.
+func looksLikeSPAShell(body []byte) bool {
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	var (
+		scripts    int
+		text       strings.Builder
+		emptyMount bool
+		noscriptJS bool
+		walk       func(n *html.Node)
+	)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script":
+				scripts++
+				return
+			case "style", "template":
+				return
+			case "noscript":
+				var b strings.Builder
+				for c := n.FirstChild; c != nil; c = c.NextSibling {
+					b.WriteString(c.Data)
+				}
+				if strings.Contains(strings.ToLower(b.String()), "javascript") {
+					noscriptJS = true
+				}
+				return
+			}
+			for _, a := range n.Attr {
+				if a.Key == "id" && spaMountIDs[a.Val] && !hasContent(n) {
+					emptyMount = true
+				}
+			}
+		}
+		if n.Type == html.TextNode {
+			text.WriteString(strings.TrimSpace(n.Data))
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return scripts > 0 && len(text.String()) < 200 && (emptyMount || noscriptJS)
+}
+
+func hasContent(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode || (c.Type == html.TextNode && strings.TrimSpace(c.Data) != "") {
+			return true
+		}
+	}
+	return false
+}
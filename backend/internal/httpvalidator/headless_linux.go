@@ -0,0 +1,13 @@
+//go:build linux
+
+package httpvalidator
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// configureBrowserProcess makes the kernel kill Chromium if the server exits without closing it.
+func configureBrowserProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+}
@@ -0,0 +1,7 @@
+//go:build !linux
+
+package httpvalidator
+
+import "os/exec"
+
+func configureBrowserProcess(cmd *exec.Cmd) {}
@@ -0,0 +1,178 @@
+package httpvalidator
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/config"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+)
+
+const spaShell = `<!doctype html><html><head><title>App</title></head><body><div id="root"></div><script src="/static/app.js"></script></body></html>`
+
+// fakeDevTools speaks just enough CDP to drive one render; navigated records the URL and user agent
+// each page was asked to load.
+func fakeDevTools(t *testing.T, navigated chan<- [2]string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle("/devtools/browser/fake", websocket.Handler(func(ws *websocket.Conn) {
+		for {
+			var msg cdpMessage
+			if websocket.JSON.Receive(ws, &msg) != nil {
+				return
+			}
+			result := map[string]string{}
+			switch msg.Method {
+			case "Target.createBrowserContext":
+				result["browserContextId"] = "ctx-1"
+			case "Target.createTarget":
+				result["targetId"] = "page-1"
+			}
+			_ = websocket.JSON.Send(ws, map[string]interface{}{"id": msg.ID, "result": result})
+		}
+	}))
+	mux.Handle("/devtools/page/page-1", websocket.Handler(func(ws *websocket.Conn) {
+		var ua string
+		for {
+			var msg cdpMessage
+			if websocket.JSON.Receive(ws, &msg) != nil {
+				return
+			}
+			var params map[string]interface{}
+			_ = json.Unmarshal(msg.Params, &params)
+			var result interface{} = map[string]string{}
+			var events []map[string]interface{}
+			switch msg.Method {
+			case "Network.setUserAgentOverride":
+				ua, _ = params["userAgent"].(string)
+			case "Page.navigate":
+				navigated <- [2]string{params["url"].(string), ua}
+				result = map[string]string{"frameId": "f1", "loaderId": "loader-1"}
+				events = []map[string]interface{}{
+					{"method": "Network.responseReceived", "params": map[string]interface{}{
+						"requestId": "loader-1", "type": "Document",
+						"response": map[string]interface{}{"url": params["url"], "status": 200, "headers": map[string]string{"content-type": "text/html", "set-cookie": "a=1\nb=2"}},
+					}},
+					{"method": "Page.loadEventFired", "params": map[string]interface{}{"timestamp": 1}},
+				}
+			case "Runtime.evaluate":
+				result = map[string]interface{}{"result": map[string]interface{}{"type": "object", "value": map[string]string{
+					"url":  "https://spa.example/home",
+					"html": `<html><head><title>Rendered Shop</title></head><body><div id="root"><h1>Buy widgets</h1></div></body></html>`,
+				}}}
+			case "Page.captureScreenshot":
+				result = map[string]string{"data": base64.StdEncoding.EncodeToString([]byte("\x89PNG fake"))}
+			}
+			_ = websocket.JSON.Send(ws, map[string]interface{}{"id": msg.ID, "result": result})
+			for _, ev := range events {
+				_ = websocket.JSON.Send(ws, ev)
+			}
+		}
+	}))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newHeadlessTestValidator(t *testing.T, devtools *httptest.Server, screenshots bool) *HTTPValidator {
+	t.Helper()
+	hv := NewHTTPValidator(&config.AppConfig{HTTPValidator: config.HTTPValidatorConfig{
+		RequestTimeout: 5 * time.Second,
+		Headless: config.HeadlessBrowserConfig{
+			Enabled:            true,
+			ArtifactDir:        t.TempDir(),
+			NavigationTimeout:  5 * time.Second,
+			MaxConcurrentPages: 2,
+			AutoFallback:       true,
+			Screenshots:        screenshots,
+			SaveDOMSnapshots:   true,
+		},
+	}})
+	hv.headless.launch = func(context.Context) (string, error) {
+		return "ws" + strings.TrimPrefix(devtools.URL, "http") + "/devtools/browser/fake", nil
+	}
+	t.Cleanup(func() { _ = hv.Close() })
+	return hv
+}
+
+func TestValidateSingleDomainRendersSPAShellInAutoMode(t *testing.T) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(spaShell))
+	}))
+	defer site.Close()
+	navigated := make(chan [2]string, 1)
+	hv := newHeadlessTestValidator(t, fakeDevTools(t, navigated), true)
+
+	result := hv.validateSingleDomain(context.Background(), "spa.example", site.URL, nil, nil)
+	if !result.IsHeadless || !result.IsSuccess || result.StatusCode != 200 {
+		t.Fatalf("expected a successful headless result, got %+v", result)
+	}
+	if nav := <-navigated; nav[0] != site.URL || nav[1] != defaultUserAgent {
+		t.Errorf("unexpected navigation %v", nav)
+	}
+	if result.ExtractedTitle != "Rendered Shop" || !strings.Contains(string(result.RawBody), "Buy widgets") {
+		t.Errorf("expected rendered DOM for keyword scanning, got title %q body %q", result.ExtractedTitle, result.RawBody)
+	}
+	if result.FinalURL != "https://spa.example/home" || len(result.ResponseHeaders["Set-Cookie"]) != 2 {
+		t.Errorf("unexpected final URL %q or headers %v", result.FinalURL, result.ResponseHeaders)
+	}
+	shot, err := os.ReadFile(filepath.Join(hv.headless.cfg.ArtifactDir, result.ScreenshotPath))
+	if err != nil || string(shot) != "\x89PNG fake" {
+		t.Errorf("expected screenshot on disk at %q: %v", result.ScreenshotPath, err)
+	}
+	if _, err := os.Stat(filepath.Join(hv.headless.cfg.ArtifactDir, result.DOMSnapshotPath)); err != nil {
+		t.Errorf("expected DOM snapshot on disk: %v", err)
+	}
+}
+
+func TestValidateSingleDomainHonoursStaticRenderMode(t *testing.T) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(spaShell))
+	}))
+	defer site.Close()
+	navigated := make(chan [2]string, 1)
+	hv := newHeadlessTestValidator(t, fakeDevTools(t, navigated), false)
+	details, _ := json.Marshal(models.HTTPConfigDetails{UserAgent: "persona-ua", RenderMode: models.HTTPRenderModeStatic})
+	persona := &models.Persona{ID: uuid.New(), PersonaType: models.PersonaTypeHTTP, ConfigDetails: details}
+
+	if result := hv.validateSingleDomain(context.Background(), "spa.example", site.URL, persona, nil); result.IsHeadless {
+		t.Fatal("static personas must never be rendered")
+	}
+
+	details, _ = json.Marshal(models.HTTPConfigDetails{UserAgent: "persona-ua", RenderMode: models.HTTPRenderModeHeadless})
+	persona.ConfigDetails = details
+	result := hv.validateSingleDomain(context.Background(), "spa.example", site.URL, persona, nil)
+	if !result.IsHeadless || result.ScreenshotPath != "" {
+		t.Fatalf("expected a headless result without screenshot, got %+v", result)
+	}
+	if nav := <-navigated; nav[1] != "persona-ua" {
+		t.Errorf("expected persona user agent in the browser, got %q", nav[1])
+	}
+}
+
+func TestLooksLikeSPAShell(t *testing.T) {
+	cases := map[string]bool{
+		spaShell: true,
+		`<html><body><noscript>You need to enable JavaScript to run this app.</noscript><div id="app-root"></div><script src="main.js"></script></body></html>`: true,
+		`<html><body><div id="root"><p>Server rendered article text</p></div><script src="hydrate.js"></script></body></html>`:                                  false,
+		`<html><body><div id="root"></div></body></html>`: false,
+		`<html><body><h1>Welcome</h1><p>` + strings.Repeat("Plenty of static text. ", 20) + `</p><div id="app"></div><script></script></body></html>`: false,
+	}
+	for doc, want := range cases {
+		if got := looksLikeSPAShell([]byte(doc)); got != want {
+			t.Errorf("looksLikeSPAShell(%.60q) = %v, want %v", doc, got, want)
+		}
+	}
+}
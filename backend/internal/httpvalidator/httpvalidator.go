@@ -9,10 +9,12 @@ import (
 	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"sync"
 
@@ -21,7 +23,10 @@ import (
 	"time"
 
 	"github.com/fntelecomllc/studio/backend/internal/config"
+	"github.com/fntelecomllc/studio/backend/internal/httpfingerprint"
 	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/ratelimit"
+	"github.com/fntelecomllc/studio/backend/internal/robots"
 	"golang.org/x/net/html" // Added for HTML parsing
 )
 
@@ -37,10 +42,23 @@ func CanonicalHeaderKey(key string) string {
 
 type HTTPValidator struct {
 	appConfig *config.AppConfig
+	headless  *headlessBrowser // nil unless httpValidator.headless.enabled
 }
 
 func NewHTTPValidator(appCfg *config.AppConfig) *HTTPValidator {
-	return &HTTPValidator{appConfig: appCfg}
+	hv := &HTTPValidator{appConfig: appCfg}
+	if appCfg != nil && appCfg.HTTPValidator.Headless.Enabled {
+		hv.headless = newHeadlessBrowser(appCfg.HTTPValidator.Headless)
+	}
+	return hv
+}
+
+// Close stops the headless browser if one was started.
+func (hv *HTTPValidator) Close() error {
+	if hv.headless == nil {
+		return nil
+	}
+	return hv.headless.Close()
 }
 
 // Function to extract title from HTML content
@@ -159,18 +177,56 @@ func (hv *HTTPValidator) processHTTPBatch(
 			defer wg.Done()
 			defer func() { <-semaphore }()
 
+			// Throttle before starting the request timeout so time spent rate limited is not counted against it
+			if err := hv.waitForPersonaRateLimit(ctx, persona); err != nil {
+				results[idx] = &ValidationResult{
+					Domain:       d.DomainName,
+					AttemptedURL: d.DomainName,
+					Status:       "ErrorCancelled",
+					Error:        fmt.Sprintf("Rate limit wait aborted: %v", err),
+					Timestamp:    time.Now(),
+					IsSuccess:    false,
+				}
+				return
+			}
+
 			// Create individual domain timeout context
 			requestTimeout := hv.appConfig.HTTPValidator.RequestTimeout
 			if requestTimeout <= 0 {
 				requestTimeout = 15 * time.Second // Conservative timeout
 			}
+			if hv.headless != nil {
+				// Room for a render after the static fetch
+				requestTimeout += hv.headless.cfg.NavigationTimeout + hv.headless.cfg.RenderWait
+			}
 
 			domainCtx, domainCancel := context.WithTimeout(ctx, requestTimeout)
 			defer domainCancel()
 
+			domainProxy := proxy
+			selector := proxySelectorFrom(ctx)
+			if selector != nil {
+				selected, err := selector.SelectProxy(d.DomainName)
+				if err != nil {
+					results[idx] = &ValidationResult{
+						Domain:       d.DomainName,
+						AttemptedURL: d.DomainName,
+						Status:       "ErrorProxyUnavailable",
+						Error:        fmt.Sprintf("No proxy available: %v", err),
+						Timestamp:    time.Now(),
+						IsSuccess:    false,
+					}
+					return
+				}
+				domainProxy = selected
+			}
+
 			// Use existing validation logic
-			result := hv.validateSingleDomain(domainCtx, d.DomainName, d.DomainName, persona, proxy)
+			result := hv.validateSingleDomain(domainCtx, d.DomainName, d.DomainName, persona, domainProxy)
 			results[idx] = result
+			if selector != nil && domainProxy != nil && result.Status != "ErrorCancelled" {
+				selector.ReportProxyResult(domainProxy.ID, !proxyFailed(result), time.Duration(result.DurationMs)*time.Millisecond)
+			}
 		}(i, domain)
 	}
 
@@ -186,11 +242,28 @@ func (hv *HTTPValidator) Validate(
 	persona *models.Persona,
 	proxy *models.Proxy,
 ) (*ValidationResult, error) {
+	if err := hv.waitForPersonaRateLimit(ctx, persona); err != nil {
+		return &ValidationResult{Domain: domain, AttemptedURL: initialURL, Status: "ErrorCancelled", Error: fmt.Sprintf("Rate limit wait aborted: %v", err), Timestamp: time.Now()}, nil
+	}
 	result := hv.validateSingleDomain(ctx, domain, initialURL, persona, proxy)
 	return result, nil
 }
 
+// waitForPersonaRateLimit blocks until the persona's process-wide token bucket admits one more request.
+// Personas without a positive rateLimitDps are not limited.
+func (hv *HTTPValidator) waitForPersonaRateLimit(ctx context.Context, persona *models.Persona) error {
+	if persona == nil || persona.PersonaType != models.PersonaTypeHTTP || len(persona.ConfigDetails) == 0 {
+		return nil
+	}
+	var personaCfg models.HTTPConfigDetails
+	if err := json.Unmarshal(persona.ConfigDetails, &personaCfg); err != nil {
+		return nil
+	}
+	return ratelimit.Shared().Wait(ctx, "http", ratelimit.ScopePersona, persona.ID.String(), personaCfg.RateLimitDps, personaCfg.RateLimitBurst)
+}
+
 // validateSingleDomain performs HTTP validation for a single domain (extracted from original Validate method)
+// and renders it in the headless browser when the persona's render mode asks for it.
 func (hv *HTTPValidator) validateSingleDomain(
 	ctx context.Context,
 	domain string,
@@ -198,14 +271,98 @@ func (hv *HTTPValidator) validateSingleDomain(
 	persona *models.Persona,
 	proxy *models.Proxy,
 ) *ValidationResult {
-	startTime := time.Now()
-	result := &ValidationResult{
+	personaCfg := hv.personaConfig(persona, domain)
+	if skipped := robotsSkip(ctx, domain, initialURL, personaCfg); skipped != nil {
+		return skipped
+	}
+	mode := hv.renderMode(personaCfg)
+
+	if mode == models.HTTPRenderModeHeadless {
+		result, err := hv.validateHeadless(ctx, domain, initialURL, personaCfg, proxy)
+		if err == nil {
+			return result
+		}
+		log.Printf("HTTPValidator: Headless render of %s unavailable, falling back to static fetch: %v", domain, err)
+	}
+
+	result := hv.validateStatic(ctx, domain, initialURL, personaCfg, proxy)
+	if mode == models.HTTPRenderModeAuto && result.StatusCode >= 200 && result.StatusCode < 300 &&
+		isHTMLResponse(result.ResponseHeaders) && looksLikeSPAShell(result.RawBody) {
+		rendered, err := hv.validateHeadless(ctx, domain, initialURL, personaCfg, proxy)
+		switch {
+		case err != nil:
+			log.Printf("HTTPValidator: %s looks client-rendered but headless browser is unavailable: %v", domain, err)
+		case rendered.Status == "HeadlessFailed":
+			log.Printf("HTTPValidator: %s looks client-rendered but headless render failed, keeping static result: %s", domain, rendered.Error)
+		default:
+			rendered.DurationMs += result.DurationMs
+			return rendered
+		}
+	}
+	return result
+}
+
+// robotsSkip returns a skipped result when the context's robots policy covers root fetches and the
+// site's robots.txt disallows the root URL for the persona's user agent.
+func robotsSkip(ctx context.Context, domain, initialURL string, personaCfg models.HTTPConfigDetails) *ValidationResult {
+	policy := robots.Shared().PolicyFor(ctx)
+	if !policy.EnforceRoot {
+		return nil
+	}
+	target := initialURL
+	if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+		target = "https://" + target
+	}
+	ua := policy.UserAgent
+	if ua == "" {
+		ua = personaCfg.UserAgent
+	}
+	if ua == "" {
+		ua = defaultUserAgent
+	}
+	allowed, err := robots.Shared().Admit(ctx, "http_root", target, ua)
+	if err != nil {
+		log.Printf("HTTPValidator: robots.txt check for %s failed, fetching anyway: %v", domain, err)
+		return nil
+	}
+	if allowed {
+		return nil
+	}
+	return &ValidationResult{
 		Domain:       domain,
-		AttemptedURL: initialURL,
-		Timestamp:    startTime,
-		IsSuccess:    false,
+		AttemptedURL: target,
+		Status:       "SkippedRobots",
+		Error:        "Disallowed by robots.txt",
+		Timestamp:    time.Now(),
+	}
+}
+
+// renderMode resolves the persona's render mode against what the deployment supports.
+func (hv *HTTPValidator) renderMode(personaCfg models.HTTPConfigDetails) string {
+	if hv.headless == nil {
+		return models.HTTPRenderModeStatic
+	}
+	switch personaCfg.RenderMode {
+	case models.HTTPRenderModeHeadless, models.HTTPRenderModeAuto, models.HTTPRenderModeStatic:
+		return personaCfg.RenderMode
 	}
+	if hv.headless.cfg.AutoFallback {
+		return models.HTTPRenderModeAuto
+	}
+	return models.HTTPRenderModeStatic
+}
 
+func isHTMLResponse(headers map[string][]string) bool {
+	for _, v := range headers["Content-Type"] {
+		if strings.Contains(strings.ToLower(v), "text/html") {
+			return true
+		}
+	}
+	return false
+}
+
+// personaConfig decodes an HTTP persona's settings, falling back to the app defaults.
+func (hv *HTTPValidator) personaConfig(persona *models.Persona, domain string) models.HTTPConfigDetails {
 	var personaCfg models.HTTPConfigDetails
 	if persona != nil && persona.PersonaType == models.PersonaTypeHTTP && len(persona.ConfigDetails) > 0 {
 		if err := json.Unmarshal(persona.ConfigDetails, &personaCfg); err != nil {
@@ -222,6 +379,24 @@ func (hv *HTTPValidator) validateSingleDomain(
 			log.Printf("HTTPValidator: No persona provided for domain %s. Using app defaults.", domain)
 		}
 	}
+	return personaCfg
+}
+
+// validateStatic fetches the domain with a plain HTTP client.
+func (hv *HTTPValidator) validateStatic(
+	ctx context.Context,
+	domain string,
+	initialURL string,
+	personaCfg models.HTTPConfigDetails,
+	proxy *models.Proxy,
+) *ValidationResult {
+	startTime := time.Now()
+	result := &ValidationResult{
+		Domain:       domain,
+		AttemptedURL: initialURL,
+		Timestamp:    startTime,
+		IsSuccess:    false,
+	}
 
 	requestTimeout := hv.appConfig.HTTPValidator.RequestTimeout
 	if personaCfg.RequestTimeoutSeconds > 0 {
@@ -260,9 +435,16 @@ func (hv *HTTPValidator) validateSingleDomain(
 		},
 	}
 
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: hv.appConfig.HTTPValidator.AllowInsecureTLS,
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: hv.appConfig.HTTPValidator.AllowInsecureTLS},
+	}
+	if personaCfg.TargetRateLimitDps > 0 {
+		transport.DialContext = ratelimit.Shared().DialContextPerTarget(nil, "http", personaCfg.TargetRateLimitDps, personaCfg.TargetRateLimitBurst)
+	}
+	if err := httpfingerprint.Apply(transport, personaCfg, hv.appConfig.HTTPValidator.AllowInsecureTLS); err != nil {
+		log.Printf("HTTPValidator: Ignoring invalid TLS fingerprint settings for domain %s: %v", domain, err)
 	}
+	client.Transport = transport
 
 	if proxy != nil && proxy.Address != "" && proxy.IsEnabled && proxy.IsHealthy {
 		var protocol string
@@ -279,17 +461,13 @@ func (hv *HTTPValidator) validateSingleDomain(
 			if proxy.Username.Valid && proxy.Username.String != "" {
 				log.Printf("Warning: Authenticated proxy %s used, but plaintext password retrieval is not implemented in HTTPValidator.", proxy.ID)
 			}
-			client.Transport = &http.Transport{
-				Proxy:           http.ProxyURL(proxyURL),
-				TLSClientConfig: tlsConfig,
-			}
+			transport.Proxy = http.ProxyURL(proxyURL)
 			result.UsedProxyID = proxy.ID.String()
 		}
-	} else {
-		client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", parsedURL.String(), nil)
+	var conns connTracker
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, conns.trace()), "GET", parsedURL.String(), nil)
 	if err != nil {
 		result.Error = fmt.Sprintf("Failed to create request: %v", err)
 		result.Status = "ErrorRequestCreation"
@@ -320,6 +498,11 @@ func (hv *HTTPValidator) validateSingleDomain(
 
 	result.StatusCode = resp.StatusCode
 	result.FinalURL = resp.Request.URL.String()
+	tlsState := resp.TLS
+	if tlsState == nil && resp.Request.URL.Scheme == "https" {
+		tlsState = conns.state()
+	}
+	result.TLS = tlsInfoFromState(tlsState, resp.Request.URL.Hostname())
 
 	result.ResponseHeaders = make(map[string][]string)
 	for key, values := range resp.Header {
@@ -351,6 +534,14 @@ func (hv *HTTPValidator) validateSingleDomain(
 	if readErr != nil {
 		result.ContentHashError = fmt.Sprintf("Failed to read response body: %v", readErr)
 	}
+	applyBodyAndRules(result, bodyBytes, strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "text/html"), personaCfg)
+	result.DurationMs = time.Since(startTime).Milliseconds()
+	return result
+}
+
+// applyBodyAndRules records the body digest, title and snippet, then judges the result against the
+// persona's allowed status codes.
+func applyBodyAndRules(result *ValidationResult, bodyBytes []byte, isHTML bool, personaCfg models.HTTPConfigDetails) {
 	result.RawBody = bodyBytes
 	result.ContentLength = len(bodyBytes)
 
@@ -359,8 +550,7 @@ func (hv *HTTPValidator) validateSingleDomain(
 		result.ContentHash = hex.EncodeToString(hash[:])
 
 		// Extract Title and Snippet if content type is HTML
-		contentType := resp.Header.Get("Content-Type")
-		if strings.Contains(strings.ToLower(contentType), "text/html") {
+		if isHTML {
 			doc, parseErr := html.Parse(bytes.NewReader(bodyBytes))
 			if parseErr == nil {
 				result.ExtractedTitle = extractTitle(doc)
@@ -400,17 +590,93 @@ func (hv *HTTPValidator) validateSingleDomain(
 			result.Error = fmt.Sprintf("Validation failed: Status code %d not in allowed list or other rule violation.", result.StatusCode)
 		}
 	}
-
-	result.DurationMs = time.Since(startTime).Milliseconds()
-	return result
 }
 
+// ValidateHeadless renders the domain in the headless browser regardless of the persona's render mode.
 func (hv *HTTPValidator) ValidateHeadless(
 	ctx context.Context, domain string, initialURL string,
 	persona *models.Persona, proxy *models.Proxy,
 ) (*ValidationResult, error) {
-	log.Printf("ValidateHeadless called for %s (Placeholder - Not Implemented)", domain)
-	return &ValidationResult{
-		Domain: domain, AttemptedURL: initialURL, Status: "ErrorNotImplemented", Error: "Headless validation is not implemented",
-	}, fmt.Errorf("headless validation not implemented")
+	if err := hv.waitForPersonaRateLimit(ctx, persona); err != nil {
+		return &ValidationResult{Domain: domain, AttemptedURL: initialURL, Status: "ErrorCancelled", Error: fmt.Sprintf("Rate limit wait aborted: %v", err), Timestamp: time.Now()}, nil
+	}
+	return hv.validateHeadless(ctx, domain, initialURL, hv.personaConfig(persona, domain), proxy)
+}
+
+// validateHeadless renders the domain and judges the rendered document like a static fetch. The error
+// is only set when no browser is available; page failures are reported as a "HeadlessFailed" result.
+func (hv *HTTPValidator) validateHeadless(
+	ctx context.Context,
+	domain string,
+	initialURL string,
+	personaCfg models.HTTPConfigDetails,
+	proxy *models.Proxy,
+) (*ValidationResult, error) {
+	startTime := time.Now()
+	result := &ValidationResult{Domain: domain, AttemptedURL: initialURL, Timestamp: startTime, IsHeadless: true}
+	if hv.headless == nil {
+		result.Status = "ErrorHeadlessDisabled"
+		result.Error = "Headless validation is disabled"
+		return result, errHeadlessUnavailable
+	}
+	cfg := hv.headless.cfg
+
+	if !strings.HasPrefix(initialURL, "http://") && !strings.HasPrefix(initialURL, "https://") {
+		result.AttemptedURL = "https://" + initialURL
+	}
+	opts := renderOptions{
+		UserAgent:  personaCfg.UserAgent,
+		Headers:    personaCfg.Headers,
+		IgnoreTLS:  hv.appConfig.HTTPValidator.AllowInsecureTLS,
+		Screenshot: cfg.Screenshots,
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = defaultUserAgent
+	}
+	if personaCfg.CaptureScreenshot != nil {
+		opts.Screenshot = *personaCfg.CaptureScreenshot
+	}
+	if proxy != nil && proxy.Address != "" && proxy.IsEnabled && proxy.IsHealthy {
+		protocol := "http"
+		if proxy.Protocol != nil {
+			protocol = string(*proxy.Protocol)
+		}
+		if proxy.Username.Valid && proxy.Username.String != "" {
+			log.Printf("Warning: Authenticated proxy %s used, but plaintext password retrieval is not implemented in HTTPValidator.", proxy.ID)
+		}
+		opts.ProxyServer = fmt.Sprintf("%s://%s", protocol, proxy.Address)
+		result.UsedProxyID = proxy.ID.String()
+	}
+
+	renderCtx, cancel := context.WithTimeout(ctx, cfg.NavigationTimeout+cfg.RenderWait)
+	defer cancel()
+	page, err := hv.headless.render(renderCtx, result.AttemptedURL, opts)
+	if err != nil {
+		result.DurationMs = time.Since(startTime).Milliseconds()
+		if errors.Is(err, errHeadlessUnavailable) {
+			result.Status = "ErrorHeadlessUnavailable"
+			result.Error = err.Error()
+			return result, err
+		}
+		result.Status = "HeadlessFailed"
+		result.Error = fmt.Sprintf("Headless render failed: %v", err)
+		return result, nil
+	}
+
+	result.StatusCode = page.StatusCode
+	result.FinalURL = page.FinalURL
+	result.ResponseHeaders = page.Headers
+	body := page.DOM
+	if limit := hv.appConfig.HTTPValidator.MaxBodyReadBytes; limit > 0 && int64(len(body)) > limit {
+		body = body[:limit]
+	}
+	applyBodyAndRules(result, body, true, personaCfg)
+
+	if shot, dom, err := hv.headless.saveArtifacts(domain, page, cfg.SaveDOMSnapshots); err != nil {
+		log.Printf("HTTPValidator: Failed to store headless artifacts for %s: %v", domain, err)
+	} else {
+		result.ScreenshotPath, result.DOMSnapshotPath = shot, dom
+	}
+	result.DurationMs = time.Since(startTime).Milliseconds()
+	return result, nil
 }
@@ -0,0 +1,195 @@
+package httpvalidator
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/config"
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/robots"
+	"github.com/fntelecomllc/studio/backend/internal/testutil"
+	"github.com/google/uuid"
+)
+
+func TestValidateSingleDomainAppliesPersonaFingerprint(t *testing.T) {
+	srv := testutil.NewRecordingTLSServer(t)
+	details, _ := json.Marshal(models.HTTPConfigDetails{
+		UserAgent:   "persona-ua",
+		Headers:     map[string]string{"Accept": "text/html", "Accept-Language": "de-DE"},
+		HeaderOrder: []string{"Host", "Accept-Language", "User-Agent", "Accept"},
+		TLSClientHello: &models.HTTPTLSClientHello{
+			MinVersion:   "TLS12",
+			MaxVersion:   "TLS12",
+			CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+		},
+	})
+	persona := &models.Persona{ID: uuid.New(), PersonaType: models.PersonaTypeHTTP, ConfigDetails: details}
+	hv := NewHTTPValidator(&config.AppConfig{HTTPValidator: config.HTTPValidatorConfig{AllowInsecureTLS: true, RequestTimeout: 5 * time.Second}})
+
+	result := hv.validateSingleDomain(context.Background(), "localhost", srv.URL, persona, nil)
+	if result.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d (%s)", result.StatusCode, result.Error)
+	}
+	hello := <-srv.Hellos
+	if !reflect.DeepEqual(hello.SupportedVersions, []uint16{tls.VersionTLS12}) {
+		t.Errorf("unexpected supported versions %v", hello.SupportedVersions)
+	}
+	if !reflect.DeepEqual(hello.CipherSuites, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384}) {
+		t.Errorf("unexpected cipher suites %v", hello.CipherSuites)
+	}
+	if got := <-srv.HeaderNames; !reflect.DeepEqual(got[:4], []string{"Host", "Accept-Language", "User-Agent", "Accept"}) {
+		t.Errorf("unexpected header order %v", got)
+	}
+	if result.TLS == nil || result.TLS.Version != "TLS 1.2" {
+		t.Errorf("expected TLS 1.2 details through the header-ordering dialer, got %+v", result.TLS)
+	}
+}
+
+func TestValidateDomainsBulkHonoursPersonaRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("ok")) }))
+	defer srv.Close()
+	details, _ := json.Marshal(models.HTTPConfigDetails{UserAgent: "persona-ua", RateLimitDps: 20, RateLimitBurst: 1})
+	persona := &models.Persona{ID: uuid.New(), PersonaType: models.PersonaTypeHTTP, ConfigDetails: details}
+	hv := NewHTTPValidator(&config.AppConfig{HTTPValidator: config.HTTPValidatorConfig{RequestTimeout: 5 * time.Second, MaxConcurrentGoroutines: 4}})
+
+	domains := make([]*models.GeneratedDomain, 4)
+	for i := range domains {
+		domains[i] = &models.GeneratedDomain{DomainName: srv.URL}
+	}
+	start := time.Now()
+	results := hv.ValidateDomainsBulk(context.Background(), domains, 4, persona, nil)
+	if elapsed := time.Since(start); elapsed < 140*time.Millisecond {
+		t.Fatalf("expected 4 requests at 20/s burst 1 to take ~150ms, took %v", elapsed)
+	}
+	for _, r := range results {
+		if r.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected result %+v", r)
+		}
+	}
+}
+
+func TestValidateSingleDomainCapturesTLSCertificate(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("ok")) }))
+	defer srv.Close()
+	hv := NewHTTPValidator(&config.AppConfig{HTTPValidator: config.HTTPValidatorConfig{AllowInsecureTLS: true, RequestTimeout: 5 * time.Second}})
+
+	result := hv.validateSingleDomain(context.Background(), "127.0.0.1", srv.URL, nil, nil)
+	if result.TLS == nil {
+		t.Fatalf("expected TLS details, got none (%s)", result.Error)
+	}
+	leaf := srv.Certificate()
+	if result.TLS.Version == "" || !result.TLS.NotAfter.Equal(leaf.NotAfter.UTC()) {
+		t.Errorf("unexpected TLS details %+v", result.TLS)
+	}
+	if !result.TLS.SelfSigned || result.TLS.HostnameMismatch {
+		t.Errorf("expected self-signed certificate valid for 127.0.0.1, got %+v", result.TLS)
+	}
+	if len(result.TLS.SANs) == 0 {
+		t.Errorf("expected SANs, got %+v", result.TLS)
+	}
+
+	mismatch := hv.validateSingleDomain(context.Background(), "localhost", strings.Replace(srv.URL, "127.0.0.1", "localhost", 1), nil, nil)
+	if mismatch.TLS == nil || !mismatch.TLS.HostnameMismatch {
+		t.Fatalf("expected hostname mismatch for localhost, got %+v (%s)", mismatch.TLS, mismatch.Error)
+	}
+
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer plain.Close()
+	if r := hv.validateSingleDomain(context.Background(), "127.0.0.1", plain.URL, nil, nil); r.TLS != nil {
+		t.Fatalf("expected no TLS details for plain HTTP, got %+v", r.TLS)
+	}
+}
+
+type scriptedSelector struct {
+	mu      sync.Mutex
+	proxies map[string]*models.Proxy
+	reports map[uuid.UUID]bool
+}
+
+func (s *scriptedSelector) SelectProxy(host string) (*models.Proxy, error) {
+	if p, ok := s.proxies[host]; ok {
+		return p, nil
+	}
+	return nil, errors.New("pool exhausted")
+}
+
+func (s *scriptedSelector) ReportProxyResult(proxyID uuid.UUID, success bool, _ time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports[proxyID] = success
+}
+
+func TestValidateDomainsBulkUsesProxySelectorPerDomain(t *testing.T) {
+	forward := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Via", "pool-proxy")
+		_, _ = w.Write([]byte("proxied " + r.Host))
+	}))
+	defer forward.Close()
+	dead := httptest.NewServer(http.NotFoundHandler())
+	deadAddr := strings.TrimPrefix(dead.URL, "http://")
+	dead.Close()
+
+	good := &models.Proxy{ID: uuid.New(), Address: strings.TrimPrefix(forward.URL, "http://"), IsEnabled: true, IsHealthy: true}
+	bad := &models.Proxy{ID: uuid.New(), Address: deadAddr, IsEnabled: true, IsHealthy: true}
+	sel := &scriptedSelector{
+		proxies: map[string]*models.Proxy{"http://one.test": good, "http://two.test": bad},
+		reports: map[uuid.UUID]bool{},
+	}
+	hv := NewHTTPValidator(&config.AppConfig{HTTPValidator: config.HTTPValidatorConfig{RequestTimeout: 5 * time.Second, MaxConcurrentGoroutines: 4}})
+	domains := []*models.GeneratedDomain{{DomainName: "http://one.test"}, {DomainName: "http://two.test"}, {DomainName: "http://three.test"}}
+
+	results := hv.ValidateDomainsBulk(WithProxySelector(context.Background(), sel), domains, 3, nil, nil)
+	if results[0].StatusCode != http.StatusOK || results[0].UsedProxyID != good.ID.String() {
+		t.Fatalf("expected first domain through the working proxy, got %+v", results[0])
+	}
+	if results[1].StatusCode != 0 || results[1].UsedProxyID != bad.ID.String() {
+		t.Fatalf("expected second domain to fail through the dead proxy, got %+v", results[1])
+	}
+	if results[2].Status != "ErrorProxyUnavailable" {
+		t.Fatalf("expected third domain to report no proxy, got %+v", results[2])
+	}
+	if ok, seen := sel.reports[good.ID]; !seen || !ok {
+		t.Errorf("expected success reported for working proxy, got %v", sel.reports)
+	}
+	if ok, seen := sel.reports[bad.ID]; !seen || ok {
+		t.Errorf("expected failure reported for dead proxy, got %v", sel.reports)
+	}
+}
+
+func TestValidateSingleDomainEnforcesRobotsOnRootWhenAsked(t *testing.T) {
+	var pageHits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			_, _ = w.Write([]byte("User-agent: persona-bot\nDisallow: /\n"))
+			return
+		}
+		atomic.AddInt32(&pageHits, 1)
+		_, _ = w.Write([]byte("<html><title>ok</title></html>"))
+	}))
+	defer srv.Close()
+	details, _ := json.Marshal(models.HTTPConfigDetails{UserAgent: "Mozilla/5.0 (compatible; persona-bot/1.0)"})
+	persona := &models.Persona{ID: uuid.New(), PersonaType: models.PersonaTypeHTTP, ConfigDetails: details}
+	hv := NewHTTPValidator(&config.AppConfig{HTTPValidator: config.HTTPValidatorConfig{RequestTimeout: 5 * time.Second}})
+
+	if result := hv.validateSingleDomain(context.Background(), "localhost", srv.URL, persona, nil); result.StatusCode != 200 {
+		t.Fatalf("root fetches ignore robots.txt by default, got %+v", result)
+	}
+	ctx := robots.WithPolicy(context.Background(), robots.Policy{Respect: true, EnforceRoot: true})
+	result := hv.validateSingleDomain(ctx, "localhost", srv.URL, persona, nil)
+	if result.Status != "SkippedRobots" || result.StatusCode != 0 {
+		t.Fatalf("expected the root fetch to be skipped, got %+v", result)
+	}
+	if n := atomic.LoadInt32(&pageHits); n != 1 {
+		t.Errorf("expected only the unenforced fetch to reach the page, got %d hits", n)
+	}
+}
@@ -31,5 +31,19 @@ type ValidationResult struct {
 
 	UsedProxyID string `json:"usedProxyId,omitempty"` // ID of the proxy used for this validation
 
+	TLS *TLSInfo `json:"tls,omitempty"` // Handshake and leaf certificate details; nil for plain HTTP or failed handshakes
+
 	RawBody []byte `json:"-"` // Raw response body, not included in JSON response by default, but available for internal processing
 }
+
+// TLSInfo describes the TLS session of the final response and its leaf certificate.
+type TLSInfo struct {
+	Version          string    `json:"version"`           // Negotiated protocol version, e.g. "TLS 1.3"
+	Subject          string    `json:"subject,omitempty"` // Leaf certificate subject common name
+	SANs             []string  `json:"sans,omitempty"`    // DNS names and IP addresses from the subjectAltName extension
+	Issuer           string    `json:"issuer,omitempty"`  // Issuer common name, falling back to the organization
+	NotBefore        time.Time `json:"notBefore"`
+	NotAfter         time.Time `json:"notAfter"`
+	SelfSigned       bool      `json:"selfSigned"`       // Leaf is signed by its own key
+	HostnameMismatch bool      `json:"hostnameMismatch"` // Leaf is not valid for the host that served the final response
+}
@@ -0,0 +1,29 @@
+package httpvalidator
+
+import (
+	"context"
+
+	"github.com/fntelecomllc/studio/backend/internal/proxymanager"
+)
+
+type proxySelectorKey struct{}
+
+// WithProxySelector returns a context under which bulk validation asks sel for a proxy per domain
+// instead of using the single proxy passed to ValidateDomainsBulk.
+func WithProxySelector(ctx context.Context, sel proxymanager.ProxySelector) context.Context {
+	if sel == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, proxySelectorKey{}, sel)
+}
+
+func proxySelectorFrom(ctx context.Context) proxymanager.ProxySelector {
+	sel, _ := ctx.Value(proxySelectorKey{}).(proxymanager.ProxySelector)
+	return sel
+}
+
+// proxyFailed reports whether a result counts against the proxy: the fetch itself failed or timed out.
+// Any HTTP status, even an error page, means the proxy carried the request.
+func proxyFailed(result *ValidationResult) bool {
+	return result.StatusCode == 0 && (result.Status == "ErrorFetchFailed" || result.Status == "ErrorTimeout")
+}
@@ -0,0 +1,76 @@
+package httpvalidator
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http/httptrace"
+	"sync"
+)
+
+// connectionStater is implemented by *tls.Conn and by connection wrappers that sit on top of one
+// (httpfingerprint's header ordering), whose state net/http cannot see through.
+type connectionStater interface {
+	ConnectionState() tls.ConnectionState
+}
+
+// connTracker records the connection used by the most recent request of a redirect chain.
+type connTracker struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (t *connTracker) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{GotConn: func(info httptrace.GotConnInfo) {
+		t.mu.Lock()
+		t.conn = info.Conn
+		t.mu.Unlock()
+	}}
+}
+
+// state returns the handshake state of the last connection, if it was a completed TLS session.
+func (t *connTracker) state() *tls.ConnectionState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cs, ok := t.conn.(connectionStater)
+	if !ok {
+		return nil
+	}
+	state := cs.ConnectionState()
+	if !state.HandshakeComplete {
+		return nil
+	}
+	return &state
+}
+
+// tlsInfoFromState summarises state's leaf certificate. host is the server name the final response
+// came from and is used for the hostname mismatch check, independently of InsecureSkipVerify.
+func tlsInfoFromState(state *tls.ConnectionState, host string) *TLSInfo {
+	if state == nil {
+		return nil
+	}
+	info := &TLSInfo{Version: tls.VersionName(state.Version)}
+	if len(state.PeerCertificates) == 0 {
+		return info
+	}
+	leaf := state.PeerCertificates[0]
+	info.Subject = leaf.Subject.CommonName
+	info.Issuer = leaf.Issuer.CommonName
+	if info.Issuer == "" && len(leaf.Issuer.Organization) > 0 {
+		info.Issuer = leaf.Issuer.Organization[0]
+	}
+	info.SANs = append(info.SANs, leaf.DNSNames...)
+	for _, ip := range leaf.IPAddresses {
+		info.SANs = append(info.SANs, ip.String())
+	}
+	info.NotBefore = leaf.NotBefore.UTC()
+	info.NotAfter = leaf.NotAfter.UTC()
+	info.SelfSigned = isSelfSigned(leaf)
+	info.HostnameMismatch = host != "" && leaf.VerifyHostname(host) != nil
+	return info
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
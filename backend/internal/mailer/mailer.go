@@ -0,0 +1,215 @@
+// Package mailer delivers account emails such as password resets and address verification.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message is a plain-text email to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends messages. Implementations must be safe for concurrent use.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Sender is the From address used by both mailers.
+type Sender struct {
+	Email string
+	Name  string
+}
+
+// compose renders msg as an RFC 5322 message with a quoted-printable UTF-8 body.
+func compose(from Sender, msg Message, now time.Time) ([]byte, error) {
+	if strings.ContainsAny(msg.To+msg.Subject, "\r\n") {
+		return nil, errors.New("mailer: header values must not contain line breaks")
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, fmt.Errorf("mailer: invalid recipient: %w", err)
+	}
+	sender := mail.Address{Name: from.Name, Address: from.Email}
+	id := make([]byte, 12)
+	_, _ = rand.Read(id)
+	domain := "localhost"
+	if at := strings.LastIndex(from.Email, "@"); at >= 0 {
+		domain = from.Email[at+1:]
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", sender.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", hex.EncodeToString(id), domain)
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(msg.Body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SMTPConfig configures SMTPMailer. Port 465 uses implicit TLS; other ports must upgrade with
+// STARTTLS unless the relay is on a loopback address or AllowInsecure is set. Username enables
+// PLAIN auth, which net/smtp only allows over TLS or to localhost.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     Sender
+	// AllowInsecure sends in plain text when a non-loopback relay does not offer STARTTLS
+	AllowInsecure bool
+	// Timeout bounds the whole exchange when ctx has no earlier deadline; defaults to 30s
+	Timeout time.Duration
+}
+
+// SMTPMailer sends mail through an SMTP relay.
+type SMTPMailer struct {
+	cfg SMTPConfig
+	now func() time.Time
+}
+
+// NewSMTPMailer creates an SMTPMailer.
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	return &SMTPMailer{cfg: cfg, now: time.Now}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	body, err := compose(m.cfg.From, msg, m.now())
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	tlsConfig := &tls.Config{ServerName: m.cfg.Host, MinVersion: tls.VersionTLS12}
+	dialer := &net.Dialer{}
+	var conn net.Conn
+	if m.cfg.Port == 465 {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("mailer: connect %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("mailer: %w", err)
+	}
+	defer c.Close()
+
+	if m.cfg.Port != 465 {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("mailer: starttls: %w", err)
+			}
+		} else if m.requireTLS() {
+			// Messages carry reset and verification links, so never hand them over in the clear
+			return fmt.Errorf("mailer: %s does not offer STARTTLS; refusing to send in plain text", addr)
+		}
+	}
+	if m.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return fmt.Errorf("mailer: auth: %w", err)
+		}
+	}
+	if err := c.Mail(m.cfg.From.Email); err != nil {
+		return fmt.Errorf("mailer: MAIL FROM: %w", err)
+	}
+	to, _ := mail.ParseAddress(msg.To) // validated by compose
+	if err := c.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("mailer: RCPT TO: %w", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("mailer: DATA: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("mailer: write body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("mailer: %w", err)
+	}
+	return c.Quit()
+}
+
+// requireTLS reports whether a relay without STARTTLS must be refused.
+func (m *SMTPMailer) requireTLS() bool {
+	if m.cfg.AllowInsecure || strings.EqualFold(m.cfg.Host, "localhost") {
+		return false
+	}
+	ip := net.ParseIP(m.cfg.Host)
+	return ip == nil || !ip.IsLoopback()
+}
+
+// LogMailer is the development mailer. With Dir set it writes each message there as an .eml file;
+// otherwise it logs only the recipient and subject and drops the message.
+type LogMailer struct {
+	From Sender
+	Dir  string
+}
+
+func (m *LogMailer) Send(_ context.Context, msg Message) error {
+	now := time.Now()
+	body, err := compose(m.From, msg, now)
+	if err != nil {
+		return err
+	}
+	if m.Dir == "" {
+		// Bodies carry single-use reset and verification links, so only the envelope is logged
+		log.Printf("mailer: not sending %q to %s (no SMTP host or outbox directory configured)", msg.Subject, msg.To)
+		return nil
+	}
+	if err := os.MkdirAll(m.Dir, 0o700); err != nil {
+		return fmt.Errorf("mailer: %w", err)
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000Z"), hex.EncodeToString(suffix))
+	return os.WriteFile(filepath.Join(m.Dir, name), body, 0o600)
+}
+
+var (
+	_ Mailer = (*SMTPMailer)(nil)
+	_ Mailer = (*LogMailer)(nil)
+)
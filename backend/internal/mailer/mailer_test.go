@@ -0,0 +1,177 @@
+package mailer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"log"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTP accepts one session on a local port and returns the envelope and message it received.
+type fakeSMTP struct {
+	ln   net.Listener
+	done chan struct{}
+	from string
+	rcpt []string
+	data string
+}
+
+func startFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeSMTP{ln: ln, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+		reply := func(line string) { w.WriteString(line + "\r\n"); w.Flush() }
+		reply("220 localhost ESMTP test")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.TrimRight(line, "\r\n")
+			switch verb := strings.ToUpper(strings.SplitN(cmd, " ", 2)[0]); verb {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "MAIL":
+				s.from = cmd
+				reply("250 OK")
+			case "RCPT":
+				s.rcpt = append(s.rcpt, cmd)
+				reply("250 OK")
+			case "DATA":
+				reply("354 go ahead")
+				var b strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					b.WriteString(l)
+				}
+				s.data = b.String()
+				reply("250 queued")
+			case "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("502 unsupported")
+			}
+		}
+	}()
+	return s
+}
+
+func TestSMTPMailerDeliversToRelay(t *testing.T) {
+	srv := startFakeSMTP(t)
+	defer srv.ln.Close()
+	host, portStr, _ := net.SplitHostPort(srv.ln.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	m := NewSMTPMailer(SMTPConfig{Host: host, Port: port, From: Sender{Email: "noreply@example.com", Name: "DomainFlow"}, Timeout: 5 * time.Second})
+	err := m.Send(context.Background(), Message{To: "ops@example.com", Subject: "Réinitialiser", Body: "Open https://app.example.com/reset?token=abc\nThanks"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	<-srv.done
+
+	if !strings.HasPrefix(srv.from, "MAIL FROM:<noreply@example.com>") {
+		t.Fatalf("unexpected MAIL FROM %q", srv.from)
+	}
+	if len(srv.rcpt) != 1 || !strings.Contains(srv.rcpt[0], "<ops@example.com>") {
+		t.Fatalf("unexpected RCPT %v", srv.rcpt)
+	}
+	parsed, err := mail.ReadMessage(strings.NewReader(srv.data))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	if got := parsed.Header.Get("Subject"); !strings.Contains(got, "=?utf-8?q?") {
+		t.Fatalf("subject not MIME encoded: %q", got)
+	}
+	body, _ := readAllQP(parsed)
+	if !strings.Contains(body, "https://app.example.com/reset?token=abc") {
+		t.Fatalf("body missing link: %q", body)
+	}
+}
+
+func TestSMTPMailerRequiresSTARTTLSOffLoopback(t *testing.T) {
+	cases := []struct {
+		host  string
+		allow bool
+		want  bool
+	}{
+		{"127.0.0.1", false, false},
+		{"::1", false, false},
+		{"localhost", false, false},
+		{"smtp.example.com", false, true},
+		{"203.0.113.10", false, true},
+		{"smtp.example.com", true, false},
+	}
+	for _, tc := range cases {
+		m := NewSMTPMailer(SMTPConfig{Host: tc.host, AllowInsecure: tc.allow})
+		if got := m.requireTLS(); got != tc.want {
+			t.Errorf("requireTLS(%q, allowInsecure=%v) = %v, want %v", tc.host, tc.allow, got, tc.want)
+		}
+	}
+}
+
+func readAllQP(m *mail.Message) (string, error) {
+	var b strings.Builder
+	_, err := bufio.NewReader(quotedprintable.NewReader(m.Body)).WriteTo(&b)
+	return b.String(), err
+}
+
+func TestLogMailerWritesOutboxFiles(t *testing.T) {
+	dir := t.TempDir()
+	m := &LogMailer{From: Sender{Email: "noreply@example.com"}, Dir: dir}
+	if err := m.Send(context.Background(), Message{To: "ops@example.com", Subject: "Verify", Body: "token"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("expected one .eml file, got %v", files)
+	}
+	raw, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(raw), "To: <ops@example.com>") {
+		t.Fatalf("unexpected message:\n%s", raw)
+	}
+
+	if err := m.Send(context.Background(), Message{To: "ops@example.com", Subject: "x\r\nBcc: victim@example.com"}); err == nil {
+		t.Fatal("expected header injection to be rejected")
+	}
+}
+
+func TestLogMailerWithoutOutboxDoesNotLogBody(t *testing.T) {
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+
+	m := &LogMailer{From: Sender{Email: "noreply@example.com"}}
+	if err := m.Send(context.Background(), Message{To: "ops@example.com", Subject: "Reset", Body: "https://app.example.com/reset?token=secret-token"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if strings.Contains(out.String(), "secret-token") || !strings.Contains(out.String(), "ops@example.com") {
+		t.Fatalf("expected only the envelope to be logged, got %q", out.String())
+	}
+}
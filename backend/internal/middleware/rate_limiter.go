@@ -27,6 +27,10 @@ type RateLimitConfig struct {
 	// General API limits
 	GeneralMaxRequests int           // Max requests per window for general endpoints
 	GeneralWindow      time.Duration // Time window for general requests
+
+	// Personal API key limits, counted per key rather than per IP
+	APIKeyMaxRequests int           // Max requests per window for one API key
+	APIKeyWindow      time.Duration // Time window for API key requests
 }
 
 // DefaultRateLimitConfig returns default rate limiting configuration
@@ -39,6 +43,8 @@ func DefaultRateLimitConfig() *RateLimitConfig {
 		LogoutWindow:       time.Minute,
 		GeneralMaxRequests: 1000,
 		GeneralWindow:      15 * time.Minute,
+		APIKeyMaxRequests:  600,
+		APIKeyWindow:       time.Minute,
 	}
 }
 
@@ -99,6 +105,20 @@ func (rl *RateLimiter) RateLimitMiddleware(endpointType string) func(http.Handle
 	}
 }
 
+// Allow counts one request for identifier (an IP address or any other key, such as an API key ID)
+// against the limits of endpointType and reports whether it may proceed.
+func (rl *RateLimiter) Allow(identifier, endpointType string) bool {
+	if rl.isBlocked(identifier, endpointType) {
+		return false
+	}
+	return rl.checkRateLimit(identifier, endpointType)
+}
+
+// RespondRateLimited writes the standard 429 envelope.
+func (rl *RateLimiter) RespondRateLimited(w http.ResponseWriter, r *http.Request) {
+	rl.respondRateLimited(w, r, "", "")
+}
+
 // getClientIP extracts the client IP from the request
 func (rl *RateLimiter) getClientIP(r *http.Request) string {
 	// Check X-Forwarded-For header first (for reverse proxies)
@@ -192,6 +212,8 @@ func (rl *RateLimiter) getLimits(endpointType string) (maxAttempts int, window,
 		return rl.config.LoginMaxAttempts, rl.config.LoginWindow, rl.config.LoginBlockDuration
 	case "logout":
 		return rl.config.LogoutMaxAttempts, rl.config.LogoutWindow, time.Minute
+	case "api_key":
+		return rl.config.APIKeyMaxRequests, rl.config.APIKeyWindow, rl.config.APIKeyWindow
 	default:
 		return rl.config.GeneralMaxRequests, rl.config.GeneralWindow, time.Minute
 	}
@@ -236,10 +258,17 @@ func (rl *RateLimiter) logRateLimitEvent(clientIP, endpointType string, attempts
 			$3, $4, NOW()
 		)`
 
-	metadata, _ := json.Marshal(map[string]interface{}{
+	meta := map[string]interface{}{
 		"endpoint_type": endpointType,
 		"attempts":      attempts,
-	})
+	}
+	// Identifiers that are not addresses (API key IDs) go into the metadata instead of ip_address
+	var ip interface{} = clientIP
+	if net.ParseIP(clientIP) == nil {
+		meta["identifier"] = clientIP
+		ip = nil
+	}
+	metadata, _ := json.Marshal(meta)
 
 	// Calculate risk score based on attempts
 	riskScore := float64(attempts) / 10.0
@@ -247,7 +276,7 @@ func (rl *RateLimiter) logRateLimitEvent(clientIP, endpointType string, attempts
 		riskScore = 1.0
 	}
 
-	_, _ = rl.db.Exec(query, uuid.New(), clientIP, string(metadata), riskScore)
+	_, _ = rl.db.Exec(query, uuid.New(), ip, string(metadata), riskScore)
 }
 
 // RecordLoginAttempt records a login attempt (for persistent tracking)
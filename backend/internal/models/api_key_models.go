@@ -0,0 +1,51 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// API key scopes. read allows GET and HEAD requests; write allows every other method as well.
+const (
+	APIKeyScopeRead  = "read"
+	APIKeyScopeWrite = "write"
+)
+
+// APIKey is a user's personal API key. The key itself is only shown when it is created or rotated;
+// KeyHash is its SHA-256 hash.
+type APIKey struct {
+	ID         uuid.UUID      `db:"id" json:"id"`
+	UserID     uuid.UUID      `db:"user_id" json:"userId"`
+	Name       string         `db:"name" json:"name"`
+	KeyHash    string         `db:"key_hash" json:"-"`
+	KeyHint    string         `db:"key_hint" json:"keyHint"`
+	Scopes     pq.StringArray `db:"scopes" json:"scopes"`
+	ExpiresAt  sql.NullTime   `db:"expires_at" json:"expiresAt,omitempty"`
+	LastUsedAt sql.NullTime   `db:"last_used_at" json:"lastUsedAt,omitempty"`
+	LastUsedIP sql.NullString `db:"last_used_ip" json:"lastUsedIp,omitempty"`
+	RevokedAt  sql.NullTime   `db:"revoked_at" json:"revokedAt,omitempty"`
+	RotatedAt  sql.NullTime   `db:"rotated_at" json:"rotatedAt,omitempty"`
+	CreatedAt  time.Time      `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time      `db:"updated_at" json:"updatedAt"`
+}
+
+// Usable reports whether the key may authenticate at now.
+func (k *APIKey) Usable(now time.Time) bool {
+	if k.RevokedAt.Valid {
+		return false
+	}
+	return !k.ExpiresAt.Valid || now.Before(k.ExpiresAt.Time)
+}
+
+// HasScope reports whether the key grants scope; write implies read.
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope || (s == APIKeyScopeWrite && scope == APIKeyScopeRead) {
+			return true
+		}
+	}
+	return false
+}
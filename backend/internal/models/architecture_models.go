@@ -22,15 +22,36 @@ type ConfigLock struct {
 	UpdatedAt  time.Time        `db:"updated_at" json:"updatedAt"`
 }
 
-// ConfigVersion maps to config_versions table
+// Config version change types
+const (
+	ConfigChangeCreate   = "create"
+	ConfigChangeUpdate   = "update"
+	ConfigChangeRollback = "rollback"
+)
+
+// ConfigVersion maps to config_versions table. Runtime config sections use the section name as
+// config_key; versions of a key are numbered from 1 and exactly one is current.
 type ConfigVersion struct {
-	ID          uuid.UUID       `db:"id" json:"id"`
-	ConfigHash  string          `db:"config_hash" json:"configHash"`
-	Version     int             `db:"version" json:"version"`
-	LockType    string          `db:"lock_type" json:"lockType"`
-	ConfigState json.RawMessage `db:"config_state" json:"configState"`
-	CreatedAt   time.Time       `db:"created_at" json:"createdAt"`
-	UpdatedAt   time.Time       `db:"updated_at" json:"updatedAt"`
+	ID              uuid.UUID        `db:"id" json:"id"`
+	ConfigKey       string           `db:"config_key" json:"configKey"`
+	VersionNumber   int              `db:"version_number" json:"versionNumber"`
+	ConfigValue     json.RawMessage  `db:"config_value" json:"configValue"`
+	ConfigHash      string           `db:"config_hash" json:"configHash"`
+	ChangeType      string           `db:"change_type" json:"changeType"`
+	ChangeReason    sql.NullString   `db:"change_reason" json:"changeReason,omitempty"`
+	ChangeDiff      *json.RawMessage `db:"change_diff" json:"changeDiff,omitempty"`
+	RollbackVersion sql.NullInt32    `db:"rollback_version" json:"rollbackVersion,omitempty"`
+	IsCurrent       bool             `db:"is_current" json:"isCurrent"`
+	CreatedBy       uuid.UUID        `db:"created_by" json:"createdBy"`
+	CreatedAt       time.Time        `db:"created_at" json:"createdAt"`
+}
+
+// ConfigFieldChange is one entry of a ConfigVersion's diff. Path uses dots for nested fields;
+// Before or After is nil when the field was added or removed.
+type ConfigFieldChange struct {
+	Path   string      `json:"path"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
 }
 
 // ServiceArchitectureMetric maps to service_architecture_metrics table
@@ -0,0 +1,162 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// User roles. Admins can do everything, operators create and run campaigns and manage personas and
+// proxies, viewers are read-only.
+const (
+	RoleAdmin    = "admin"
+	RoleOperator = "operator"
+	RoleViewer   = "viewer"
+)
+
+// Campaign access levels granted per user. read sees a campaign, control also configures and runs
+// it, admin also deletes it and manages who else has access. Owners hold admin on their campaigns.
+const (
+	CampaignAccessRead    = "read"
+	CampaignAccessControl = "control"
+	CampaignAccessAdmin   = "admin"
+)
+
+// ValidRole reports whether role is one of the known user roles.
+func ValidRole(role string) bool {
+	return roleRank(role) > 0
+}
+
+// RoleAtLeast reports whether role ranks at or above min.
+func RoleAtLeast(role, min string) bool {
+	return roleRank(role) > 0 && roleRank(role) >= roleRank(min)
+}
+
+func roleRank(role string) int {
+	switch role {
+	case RoleViewer:
+		return 1
+	case RoleOperator:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+// ValidCampaignAccessLevel reports whether level is one of the known campaign access levels.
+func ValidCampaignAccessLevel(level string) bool {
+	return accessRank(level) > 0
+}
+
+// CampaignAccessAtLeast reports whether level ranks at or above min.
+func CampaignAccessAtLeast(level, min string) bool {
+	return accessRank(level) > 0 && accessRank(level) >= accessRank(min)
+}
+
+func accessRank(level string) int {
+	switch level {
+	case CampaignAccessRead:
+		return 1
+	case CampaignAccessControl:
+		return 2
+	case CampaignAccessAdmin:
+		return 3
+	}
+	return 0
+}
+
+// CampaignAccessFromGrantType maps a campaign_access_grants.access_type to an access level; types
+// outside the three the API issues map to "".
+func CampaignAccessFromGrantType(accessType string) string {
+	switch accessType {
+	case "read", "viewer":
+		return CampaignAccessRead
+	case "write", "collaborator":
+		return CampaignAccessControl
+	case "admin", "owner", "manager":
+		return CampaignAccessAdmin
+	}
+	return ""
+}
+
+// GrantTypeForCampaignAccess is the access_type stored for level.
+func GrantTypeForCampaignAccess(level string) string {
+	if level == CampaignAccessControl {
+		return "write"
+	}
+	return level
+}
+
+// CampaignAccess is what a user holds on one campaign before their role is taken into account.
+type CampaignAccess struct {
+	OwnerID    uuid.NullUUID  `db:"owner_id"`
+	GrantTypes pq.StringArray `db:"grant_types"`
+}
+
+// Level returns userID's effective access level given role: owners hold admin, grants add their
+// level, viewers are capped at read and admins hold admin on every campaign. "" means no access.
+func (a *CampaignAccess) Level(userID uuid.UUID, role string) string {
+	switch role {
+	case RoleAdmin:
+		return CampaignAccessAdmin
+	case RoleOperator, RoleViewer:
+	default:
+		return ""
+	}
+	level := ""
+	if a.OwnerID.Valid && a.OwnerID.UUID == userID {
+		level = CampaignAccessAdmin
+	}
+	for _, t := range a.GrantTypes {
+		if l := CampaignAccessFromGrantType(t); accessRank(l) > accessRank(level) {
+			level = l
+		}
+	}
+	if level != "" && role == RoleViewer {
+		level = CampaignAccessRead
+	}
+	return level
+}
+
+// CampaignAccessGrant is an active share of a campaign with another user.
+type CampaignAccessGrant struct {
+	ID         uuid.UUID    `db:"id" json:"id"`
+	CampaignID uuid.UUID    `db:"campaign_id" json:"campaignId"`
+	UserID     uuid.UUID    `db:"user_id" json:"userId"`
+	UserEmail  string       `db:"user_email" json:"userEmail"`
+	AccessType string       `db:"access_type" json:"-"`
+	GrantedBy  uuid.UUID    `db:"granted_by" json:"grantedBy"`
+	GrantedAt  time.Time    `db:"granted_at" json:"grantedAt"`
+	ExpiresAt  sql.NullTime `db:"expires_at" json:"expiresAt,omitempty"`
+}
+
+// Level is the grant's campaign access level.
+func (g *CampaignAccessGrant) Level() string {
+	return CampaignAccessFromGrantType(g.AccessType)
+}
+
+// Authorization decision outcomes as stored in authorization_decisions.
+const (
+	AuthorizationAllow = "allow"
+	AuthorizationDeny  = "deny"
+)
+
+// AuthorizationDecision is one allow/deny decision taken by the API's access checks.
+type AuthorizationDecision struct {
+	UserID             uuid.NullUUID  `db:"user_id"`
+	ResourceType       string         `db:"resource_type"`
+	ResourceID         uuid.NullUUID  `db:"resource_id"`
+	Action             string         `db:"action"`
+	Decision           string         `db:"decision"`
+	IPAddress          sql.NullString `db:"ip_address"`
+	UserAgent          sql.NullString `db:"user_agent"`
+	RequestMethod      string         `db:"request_method"`
+	RequestPath        string         `db:"request_path"`
+	PolicyEvaluated    string         `db:"policy_evaluated"`
+	PermissionsChecked pq.StringArray `db:"permissions_checked"`
+	Reason             string         `db:"reason"`
+	EvaluationTimeMs   int            `db:"evaluation_time_ms"`
+}
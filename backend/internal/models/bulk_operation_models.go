@@ -0,0 +1,66 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BulkOperationTypeEnum is the campaign phase a bulk operation drives across its campaigns.
+type BulkOperationTypeEnum string
+
+const (
+	BulkOperationTypeDomainGeneration BulkOperationTypeEnum = "domain_generation"
+	BulkOperationTypeDNSValidation    BulkOperationTypeEnum = "dns_validation"
+	BulkOperationTypeHTTPValidation   BulkOperationTypeEnum = "http_validation"
+	// BulkOperationTypeAnalysis only observes the analysis phase; its tasks are never launched.
+	BulkOperationTypeAnalysis BulkOperationTypeEnum = "analysis"
+)
+
+// BulkOperationStatusEnum is the lifecycle state shared by bulk operations and their tasks.
+type BulkOperationStatusEnum string
+
+const (
+	BulkOperationStatusPending   BulkOperationStatusEnum = "pending"
+	BulkOperationStatusRunning   BulkOperationStatusEnum = "running"
+	BulkOperationStatusCompleted BulkOperationStatusEnum = "completed"
+	BulkOperationStatusFailed    BulkOperationStatusEnum = "failed"
+	BulkOperationStatusCancelled BulkOperationStatusEnum = "cancelled"
+)
+
+// IsTerminal reports whether the status can no longer change.
+func (s BulkOperationStatusEnum) IsTerminal() bool {
+	return s == BulkOperationStatusCompleted || s == BulkOperationStatusFailed || s == BulkOperationStatusCancelled
+}
+
+// BulkOperation is a durable bulk request spanning several campaigns. Status and Progress are the
+// last aggregate computed from its tasks.
+type BulkOperation struct {
+	ID              uuid.UUID               `db:"id" json:"id"`
+	Type            BulkOperationTypeEnum   `db:"operation_type" json:"type"`
+	Status          BulkOperationStatusEnum `db:"status" json:"status"`
+	CancelRequested bool                    `db:"cancel_requested" json:"cancelRequested"`
+	Progress        float64                 `db:"progress" json:"progress"`
+	CampaignCount   int                     `db:"campaign_count" json:"campaignCount"` // computed by listings
+	CreatedAt       time.Time               `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time               `db:"updated_at" json:"updatedAt"`
+	CompletedAt     sql.NullTime            `db:"completed_at" json:"completedAt,omitempty"`
+}
+
+// BulkOperationTask is one campaign's share of a bulk operation. Config is the phase configuration
+// submitted with the operation, kept so a task that never started can be launched after a restart.
+type BulkOperationTask struct {
+	OperationID    uuid.UUID               `db:"operation_id" json:"operationId"`
+	CampaignID     uuid.UUID               `db:"campaign_id" json:"campaignId"`
+	Position       int                     `db:"position" json:"position"`
+	Status         BulkOperationStatusEnum `db:"status" json:"status"`
+	Config         *json.RawMessage        `db:"config" json:"config,omitempty"`
+	ItemsProcessed int64                   `db:"items_processed" json:"itemsProcessed"`
+	ItemsTotal     int64                   `db:"items_total" json:"itemsTotal"`
+	LastError      sql.NullString          `db:"last_error" json:"lastError,omitempty"`
+	ClaimedUntil   sql.NullTime            `db:"claimed_until" json:"-"`
+	StartedAt      sql.NullTime            `db:"started_at" json:"startedAt,omitempty"`
+	UpdatedAt      time.Time               `db:"updated_at" json:"updatedAt"`
+}
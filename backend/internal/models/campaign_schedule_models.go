@@ -0,0 +1,47 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CampaignScheduleActionEnum is what a schedule does when it fires.
+type CampaignScheduleActionEnum string
+
+const (
+	// CampaignScheduleActionRestart re-runs the campaign pipeline like POST /campaigns/{id}/restart.
+	CampaignScheduleActionRestart CampaignScheduleActionEnum = "restart"
+	// CampaignScheduleActionPhase starts a single phase.
+	CampaignScheduleActionPhase CampaignScheduleActionEnum = "phase"
+)
+
+// CampaignScheduleRunStatusEnum records the outcome of the most recent scheduled run.
+type CampaignScheduleRunStatusEnum string
+
+const (
+	CampaignScheduleRunTriggered      CampaignScheduleRunStatusEnum = "triggered"
+	CampaignScheduleRunSkippedOverlap CampaignScheduleRunStatusEnum = "skipped_overlap"
+	CampaignScheduleRunFailed         CampaignScheduleRunStatusEnum = "failed"
+)
+
+// CampaignSchedule is a cron-style schedule that re-runs campaign phases.
+// NextRunAt is null while the schedule is disabled.
+type CampaignSchedule struct {
+	ID             uuid.UUID                  `db:"id" json:"id"`
+	CampaignID     uuid.UUID                  `db:"campaign_id" json:"campaignId"`
+	UserID         uuid.NullUUID              `db:"user_id" json:"userId,omitempty"`
+	CronExpression string                     `db:"cron_expression" json:"cronExpression" validate:"required"`
+	Timezone       string                     `db:"timezone" json:"timezone"`
+	Action         CampaignScheduleActionEnum `db:"action" json:"action"`
+	Phase          *PhaseTypeEnum             `db:"phase" json:"phase,omitempty"`
+	IsEnabled      bool                       `db:"is_enabled" json:"isEnabled"`
+	NextRunAt      sql.NullTime               `db:"next_run_at" json:"nextRunAt,omitempty"`
+	LastRunAt      sql.NullTime               `db:"last_run_at" json:"lastRunAt,omitempty"`
+	LastRunStatus  sql.NullString             `db:"last_run_status" json:"lastRunStatus,omitempty"`
+	LastError      sql.NullString             `db:"last_error" json:"lastError,omitempty"`
+	LockedUntil    sql.NullTime               `db:"locked_until" json:"-"`
+	CreatedAt      time.Time                  `db:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time                  `db:"updated_at" json:"updatedAt"`
+}
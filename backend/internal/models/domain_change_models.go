@@ -0,0 +1,110 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultChangeScoreThreshold is the domain_score boundary used to report threshold crossings (scores are 0-1).
+const DefaultChangeScoreThreshold = 0.5
+
+// CampaignPhaseRun records a completed phase run whose domain outcomes were snapshotted.
+type CampaignPhaseRun struct {
+	RunID       uuid.UUID     `db:"run_id" json:"runId"`
+	CampaignID  uuid.UUID     `db:"campaign_id" json:"campaignId"`
+	Phase       PhaseTypeEnum `db:"phase" json:"phase"`
+	CompletedAt time.Time     `db:"completed_at" json:"completedAt"`
+	DomainCount int64         `db:"domain_count" json:"domainCount"`
+}
+
+// DomainStateSnapshot is the outcome of one domain at the end of a phase run.
+type DomainStateSnapshot struct {
+	RunID       uuid.UUID       `db:"run_id" json:"runId"`
+	DomainID    uuid.UUID       `db:"domain_id" json:"domainId"`
+	DomainName  string          `db:"domain_name" json:"domainName"`
+	DNSStatus   sql.NullString  `db:"dns_status" json:"dnsStatus,omitempty"`
+	HTTPStatus  sql.NullString  `db:"http_status" json:"httpStatus,omitempty"`
+	LeadStatus  sql.NullString  `db:"lead_status" json:"leadStatus,omitempty"`
+	IsParked    sql.NullBool    `db:"is_parked" json:"isParked,omitempty"`
+	DomainScore sql.NullFloat64 `db:"domain_score" json:"domainScore,omitempty"`
+	ContentHash sql.NullString  `db:"content_hash" json:"contentHash,omitempty"`
+}
+
+// DomainChangeKindEnum classifies how a domain changed between two runs.
+type DomainChangeKindEnum string
+
+const (
+	DomainChangeAdded            DomainChangeKindEnum = "added"
+	DomainChangeRemoved          DomainChangeKindEnum = "removed"
+	DomainChangeWentLive         DomainChangeKindEnum = "went_live"
+	DomainChangeWentDown         DomainChangeKindEnum = "went_down"
+	DomainChangeWentParked       DomainChangeKindEnum = "went_parked"
+	DomainChangeUnparked         DomainChangeKindEnum = "unparked"
+	DomainChangeContentChanged   DomainChangeKindEnum = "content_changed"
+	DomainChangeScoreCrossedUp   DomainChangeKindEnum = "score_crossed_up"
+	DomainChangeScoreCrossedDown DomainChangeKindEnum = "score_crossed_down"
+	DomainChangeStatusChanged    DomainChangeKindEnum = "status_changed"
+)
+
+// DomainStateChange pairs a domain's snapshots in two runs; Before or After is nil when the domain
+// exists in only one of them.
+type DomainStateChange struct {
+	DomainID   uuid.UUID              `json:"domainId"`
+	DomainName string                 `json:"domainName"`
+	Kinds      []DomainChangeKindEnum `json:"kinds"`
+	Before     *DomainStateSnapshot   `json:"before,omitempty"`
+	After      *DomainStateSnapshot   `json:"after,omitempty"`
+}
+
+// ClassifyDomainChange returns the kinds of change between two snapshots of the same domain, or nil when
+// nothing tracked changed. A domain is live when its HTTP status is ok. Content changes are only reported
+// when both runs fetched the page; score crossings compare against threshold (inclusive on the high side).
+// status_changed covers DNS, HTTP or lead status changes not described by another kind.
+func ClassifyDomainChange(before, after *DomainStateSnapshot, threshold float64) []DomainChangeKindEnum {
+	switch {
+	case before == nil && after == nil:
+		return nil
+	case before == nil:
+		return []DomainChangeKindEnum{DomainChangeAdded}
+	case after == nil:
+		return []DomainChangeKindEnum{DomainChangeRemoved}
+	}
+	var kinds []DomainChangeKindEnum
+	wasLive, isLive := snapshotLive(before), snapshotLive(after)
+	switch {
+	case !wasLive && isLive:
+		kinds = append(kinds, DomainChangeWentLive)
+	case wasLive && !isLive:
+		kinds = append(kinds, DomainChangeWentDown)
+	}
+	wasParked := before.IsParked.Valid && before.IsParked.Bool
+	isParked := after.IsParked.Valid && after.IsParked.Bool
+	switch {
+	case !wasParked && isParked:
+		kinds = append(kinds, DomainChangeWentParked)
+	case wasParked && !isParked && after.IsParked.Valid:
+		kinds = append(kinds, DomainChangeUnparked)
+	}
+	if before.ContentHash.Valid && after.ContentHash.Valid && before.ContentHash.String != "" &&
+		after.ContentHash.String != "" && before.ContentHash.String != after.ContentHash.String {
+		kinds = append(kinds, DomainChangeContentChanged)
+	}
+	wasAbove := before.DomainScore.Valid && before.DomainScore.Float64 >= threshold
+	isAbove := after.DomainScore.Valid && after.DomainScore.Float64 >= threshold
+	switch {
+	case !wasAbove && isAbove:
+		kinds = append(kinds, DomainChangeScoreCrossedUp)
+	case wasAbove && !isAbove && after.DomainScore.Valid:
+		kinds = append(kinds, DomainChangeScoreCrossedDown)
+	}
+	if len(kinds) == 0 && (before.DNSStatus != after.DNSStatus || before.HTTPStatus != after.HTTPStatus || before.LeadStatus != after.LeadStatus) {
+		kinds = append(kinds, DomainChangeStatusChanged)
+	}
+	return kinds
+}
+
+func snapshotLive(s *DomainStateSnapshot) bool {
+	return s.HTTPStatus.Valid && s.HTTPStatus.String == string(DomainHTTPStatusOK)
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func snapshot(http string, parked bool, score float64, hash string) *DomainStateSnapshot {
+	return &DomainStateSnapshot{
+		DNSStatus:   sql.NullString{String: "ok", Valid: true},
+		HTTPStatus:  sql.NullString{String: http, Valid: true},
+		LeadStatus:  sql.NullString{String: "pending", Valid: true},
+		IsParked:    sql.NullBool{Bool: parked, Valid: true},
+		DomainScore: sql.NullFloat64{Float64: score, Valid: true},
+		ContentHash: sql.NullString{String: hash, Valid: hash != ""},
+	}
+}
+
+func TestClassifyDomainChange(t *testing.T) {
+	cases := []struct {
+		name          string
+		before, after *DomainStateSnapshot
+		want          []DomainChangeKindEnum
+	}{
+		{"added", nil, snapshot("ok", false, 0.1, ""), []DomainChangeKindEnum{DomainChangeAdded}},
+		{"removed", snapshot("ok", false, 0.1, ""), nil, []DomainChangeKindEnum{DomainChangeRemoved}},
+		{"unchanged", snapshot("ok", false, 0.4, "h"), snapshot("ok", false, 0.4, "h"), nil},
+		{"went live and crossed", snapshot("error", false, 0.4, ""), snapshot("ok", false, 0.6, "h"),
+			[]DomainChangeKindEnum{DomainChangeWentLive, DomainChangeScoreCrossedUp}},
+		{"parked with new content", snapshot("ok", false, 0.2, "h1"), snapshot("ok", true, 0.2, "h2"),
+			[]DomainChangeKindEnum{DomainChangeWentParked, DomainChangeContentChanged}},
+		{"went down and dropped", snapshot("ok", false, 0.5, "h"), snapshot("timeout", false, 0.3, "h"),
+			[]DomainChangeKindEnum{DomainChangeWentDown, DomainChangeScoreCrossedDown}},
+		{"score move below threshold", snapshot("ok", false, 0.1, "h"), snapshot("ok", false, 0.3, "h"), nil},
+		{"other status change", snapshot("error", false, 0.1, ""), snapshot("timeout", false, 0.1, ""),
+			[]DomainChangeKindEnum{DomainChangeStatusChanged}},
+	}
+	for _, tc := range cases {
+		if got := ClassifyDomainChange(tc.before, tc.after, DefaultChangeScoreThreshold); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // NullJSONRaw wraps json.RawMessage to allow scanning NULL into a pointer-compatible type when using sqlx
@@ -75,6 +76,12 @@ type HTTPPhaseConfigRequest struct {
 	MicroCrawlEnabled    *bool `json:"microCrawlEnabled,omitempty" description:"Enable adaptive depth-1 micro-crawl"`
 	MicroCrawlMaxPages   *int  `json:"microCrawlMaxPages,omitempty" description:"Maximum number of micro-crawl secondary pages"`
 	MicroCrawlByteBudget *int  `json:"microCrawlByteBudget,omitempty" description:"Total byte budget for micro-crawl secondary pages"`
+	// Proxy pool binding; overrides any pool bound to the persona
+	ProxyPoolID            *string `json:"proxyPoolId,omitempty" description:"Proxy pool supplying a proxy per domain"`
+	ProxySelectionStrategy *string `json:"proxySelectionStrategy,omitempty" description:"Overrides the pool's strategy for this campaign"`
+	// robots.txt overrides; unset fields fall back to the server's robots configuration
+	RespectRobots          *bool `json:"respectRobots,omitempty" description:"Honour robots.txt during micro-crawls"`
+	RobotsEnforceRootFetch *bool `json:"robotsEnforceRootFetch,omitempty" description:"Also honour robots.txt for the root page fetch"`
 }
 
 // PersonaTypeEnum defines the type of persona
@@ -206,6 +213,8 @@ type DNSConfigDetails struct {
 	MaxConcurrentGoroutines    int            `json:"maxConcurrentGoroutines" validate:"gt=0"`
 	RateLimitDps               float64        `json:"rateLimitDps" validate:"gte=0"`
 	RateLimitBurst             int            `json:"rateLimitBurst" validate:"gte=0"`
+	ResolverRateLimitDps       float64        `json:"resolverRateLimitDps,omitempty" validate:"gte=0"`
+	ResolverRateLimitBurst     int            `json:"resolverRateLimitBurst,omitempty" validate:"gte=0"`
 }
 
 // HTTPTLSClientHello holds TLS ClientHello fingerprinting details
@@ -239,9 +248,21 @@ type HTTPConfigDetails struct {
 	AllowedStatusCodes    []int               `json:"allowedStatusCodes,omitempty" validate:"omitempty,dive,gte=100,lte=599"`
 	RateLimitDps          float64             `json:"rateLimitDps,omitempty" validate:"gte=0"`
 	RateLimitBurst        int                 `json:"rateLimitBurst,omitempty" validate:"gte=0"`
+	TargetRateLimitDps    float64             `json:"targetRateLimitDps,omitempty" validate:"gte=0"`
+	TargetRateLimitBurst  int                 `json:"targetRateLimitBurst,omitempty" validate:"gte=0"`
+	ProxyPoolID           *uuid.UUID          `json:"proxyPoolId,omitempty"` // Proxy pool used when the campaign binds none
+	RenderMode            string              `json:"renderMode,omitempty" validate:"omitempty,oneof=static headless auto"`
+	CaptureScreenshot     *bool               `json:"captureScreenshot,omitempty"` // Overrides the headless screenshots setting
 	Notes                 string              `json:"notes,omitempty"`
 }
 
+// HTTP persona render modes. An empty mode behaves as auto when headless auto fallback is enabled.
+const (
+	HTTPRenderModeStatic   = "static"   // Plain HTTP fetch only
+	HTTPRenderModeHeadless = "headless" // Always render in the headless browser
+	HTTPRenderModeAuto     = "auto"     // Render only when the static fetch looks like a client-side app shell
+)
+
 // Persona represents a DNS or HTTP persona
 // Persona represents a persona configuration
 type Persona struct {
@@ -443,6 +464,17 @@ const (
 	PhaseTypeExtraction            PhaseTypeEnum = "extraction"
 	PhaseTypeEnrichment            PhaseTypeEnum = "enrichment"
 	PhaseTypeAnalysis              PhaseTypeEnum = "analysis"
+	// PhaseTypeRDAPLookup is optional: it is never part of the automatic sequence and must be
+	// configured and started explicitly once domains exist.
+	PhaseTypeRDAPLookup PhaseTypeEnum = "rdap_lookup"
+)
+
+// RDAP lookup outcomes stored in generated_domains.rdap_status
+const (
+	RDAPStatusRegistered   = "registered"
+	RDAPStatusUnregistered = "unregistered"
+	RDAPStatusUnsupported  = "unsupported" // no RDAP service is published for the TLD
+	RDAPStatusError        = "error"
 )
 
 // PhaseStatusEnum defines the execution status of a phase
@@ -532,7 +564,7 @@ type LeadItem struct {
 // DomainGenerationCampaignParams holds parameters for a domain generation campaign
 type DomainGenerationCampaignParams struct {
 	CampaignID                uuid.UUID     `db:"campaign_id" json:"-"`
-	PatternType               string        `db:"pattern_type" json:"patternType" validate:"required,oneof=prefix_variable suffix_variable both_variable"`
+	PatternType               string        `db:"pattern_type" json:"patternType" validate:"required,oneof=prefix_variable suffix_variable both_variable word_list word_template"`
 	VariableLength            int           `db:"variable_length" json:"variableLength" validate:"gte=0"`
 	PrefixVariableLength      sql.NullInt32 `db:"prefix_variable_length" json:"prefixVariableLength,omitempty"`
 	SuffixVariableLength      sql.NullInt32 `db:"suffix_variable_length" json:"suffixVariableLength,omitempty"`
@@ -544,6 +576,49 @@ type DomainGenerationCampaignParams struct {
 	CurrentOffset             int64         `db:"current_offset" json:"currentOffset" validate:"gte=0"`
 	CreatedAt                 time.Time     `db:"created_at" json:"createdAt"`
 	UpdatedAt                 time.Time     `db:"updated_at" json:"updatedAt"`
+
+	// Word-list patterns (word_list / word_template); not persisted in the legacy params table
+	Template  string              `db:"-" json:"template,omitempty"`
+	WordLists []DomainWordListRef `db:"-" json:"wordLists,omitempty"`
+	// TLDs fans generation out across several TLDs (outermost offset dimension); TLD holds the first
+	TLDs []string `db:"-" json:"tlds,omitempty"`
+}
+
+// DomainWordListRef binds a template slot to a stored word list.
+// Checksum is the word list content checksum at resolution time and is what the config hasher uses,
+// so editing a list yields a new offset space instead of silently reusing the old one.
+type DomainWordListRef struct {
+	Slot       string    `json:"slot"`
+	WordListID uuid.UUID `json:"wordListId"`
+	Checksum   string    `json:"checksum,omitempty"`
+}
+
+// NormalizedWordListRef is the hash-relevant projection of a DomainWordListRef.
+type NormalizedWordListRef struct {
+	Slot     string `json:"slot"`
+	Checksum string `json:"checksum"`
+}
+
+// DomainWordList is a server-side list of words used by word-list domain generation patterns.
+type DomainWordList struct {
+	ID          uuid.UUID      `db:"id" json:"id"`
+	Name        string         `db:"name" json:"name" validate:"required"`
+	Description sql.NullString `db:"description" json:"description,omitempty"`
+	Words       pq.StringArray `db:"words" json:"words"`
+	WordCount   int            `db:"word_count" json:"wordCount"`
+	Checksum    string         `db:"checksum" json:"checksum"`
+	CreatedBy   uuid.NullUUID  `db:"created_by" json:"createdBy,omitempty"`
+	CreatedAt   time.Time      `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time      `db:"updated_at" json:"updatedAt"`
+}
+
+// StagedImportDomain is a normalized domain uploaded as a campaign seed, waiting for the
+// domain_generation phase to run in import mode. Position becomes the domain's offset_index.
+type StagedImportDomain struct {
+	CampaignID uuid.UUID `db:"campaign_id" json:"campaignId"`
+	DomainName string    `db:"domain_name" json:"domainName"`
+	Position   int64     `db:"position" json:"position"`
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
 }
 
 // NormalizedDomainGenerationParams holds the core, normalized parameters for domain generation hashing and storage.
@@ -555,6 +630,11 @@ type NormalizedDomainGenerationParams struct {
 	CharacterSet         string `json:"characterSet"` // Should be sorted for consistent hashing
 	ConstantString       string `json:"constantString"`
 	TLD                  string `json:"tld"`
+	// Word-list pattern fields are omitted when empty so hashes of character-set patterns stay unchanged.
+	Template  string                  `json:"template,omitempty"`
+	WordLists []NormalizedWordListRef `json:"wordLists,omitempty"`
+	// TLDs is only set for multi-TLD fan-out; order is significant because it fixes the offset layout.
+	TLDs []string `json:"tlds,omitempty"`
 }
 
 // DomainGenerationPhaseConfigState tracks the global last offset for a unique domain generation configuration.
@@ -570,10 +650,11 @@ type DomainGenerationPhaseConfigState struct {
 type DomainDNSStatusEnum string
 
 const (
-	DomainDNSStatusPending DomainDNSStatusEnum = "pending"
-	DomainDNSStatusOK      DomainDNSStatusEnum = "ok"
-	DomainDNSStatusError   DomainDNSStatusEnum = "error"
-	DomainDNSStatusTimeout DomainDNSStatusEnum = "timeout"
+	DomainDNSStatusPending  DomainDNSStatusEnum = "pending"
+	DomainDNSStatusOK       DomainDNSStatusEnum = "ok"
+	DomainDNSStatusError    DomainDNSStatusEnum = "error"
+	DomainDNSStatusTimeout  DomainDNSStatusEnum = "timeout"
+	DomainDNSStatusWildcard DomainDNSStatusEnum = "wildcard" // resolved only via a zone wildcard or into a sinkhole
 )
 
 // DomainHTTPStatusEnum defines HTTP validation status for domains
@@ -602,15 +683,17 @@ const (
 type DomainRejectionReasonEnum string
 
 const (
-	DomainRejectionReasonQualified   DomainRejectionReasonEnum = "qualified"    // Not rejected, became a lead
-	DomainRejectionReasonLowScore    DomainRejectionReasonEnum = "low_score"    // Keywords found but score below threshold
-	DomainRejectionReasonNoKeywords  DomainRejectionReasonEnum = "no_keywords"  // No keyword matches found
-	DomainRejectionReasonParked      DomainRejectionReasonEnum = "parked"       // Domain is parked/placeholder
-	DomainRejectionReasonDNSError    DomainRejectionReasonEnum = "dns_error"    // DNS validation failed
-	DomainRejectionReasonDNSTimeout  DomainRejectionReasonEnum = "dns_timeout"  // DNS validation timed out
-	DomainRejectionReasonHTTPError   DomainRejectionReasonEnum = "http_error"   // HTTP validation failed
-	DomainRejectionReasonHTTPTimeout DomainRejectionReasonEnum = "http_timeout" // HTTP validation timed out
-	DomainRejectionReasonPending     DomainRejectionReasonEnum = "pending"      // Validation not yet complete
+	DomainRejectionReasonQualified         DomainRejectionReasonEnum = "qualified"           // Not rejected, became a lead
+	DomainRejectionReasonLowScore          DomainRejectionReasonEnum = "low_score"           // Keywords found but score below threshold
+	DomainRejectionReasonNoKeywords        DomainRejectionReasonEnum = "no_keywords"         // No keyword matches found
+	DomainRejectionReasonParked            DomainRejectionReasonEnum = "parked"              // Domain is parked/placeholder
+	DomainRejectionReasonDNSError          DomainRejectionReasonEnum = "dns_error"           // DNS validation failed
+	DomainRejectionReasonDNSTimeout        DomainRejectionReasonEnum = "dns_timeout"         // DNS validation timed out
+	DomainRejectionReasonDNSWildcard       DomainRejectionReasonEnum = "dns_wildcard"        // Resolved only via a zone wildcard or into a sinkhole range
+	DomainRejectionReasonDNSMissingRecords DomainRejectionReasonEnum = "dns_missing_records" // Resolved but lacks a required record type
+	DomainRejectionReasonHTTPError         DomainRejectionReasonEnum = "http_error"          // HTTP validation failed
+	DomainRejectionReasonHTTPTimeout       DomainRejectionReasonEnum = "http_timeout"        // HTTP validation timed out
+	DomainRejectionReasonPending           DomainRejectionReasonEnum = "pending"             // Validation not yet complete
 )
 
 // ValidRejectionReasons returns all valid rejection reason values
@@ -622,6 +705,8 @@ func ValidRejectionReasons() []DomainRejectionReasonEnum {
 		DomainRejectionReasonParked,
 		DomainRejectionReasonDNSError,
 		DomainRejectionReasonDNSTimeout,
+		DomainRejectionReasonDNSWildcard,
+		DomainRejectionReasonDNSMissingRecords,
 		DomainRejectionReasonHTTPError,
 		DomainRejectionReasonHTTPTimeout,
 		DomainRejectionReasonPending,
@@ -686,6 +771,16 @@ type GeneratedDomain struct {
 	MicrocrawlExhausted    bool            `db:"microcrawl_exhausted" json:"microcrawlExhausted"`
 	ContentLang            sql.NullString  `db:"content_lang" json:"contentLang,omitempty"`
 	LastHTTPFetchedAt      sql.NullTime    `db:"last_http_fetched_at" json:"lastHttpFetchedAt,omitempty"`
+
+	// Registration data from the RDAP lookup phase
+	RDAPStatus       sql.NullString `db:"rdap_status" json:"rdapStatus,omitempty"`
+	RDAPRegistrar    sql.NullString `db:"rdap_registrar" json:"rdapRegistrar,omitempty"`
+	RDAPRegisteredAt sql.NullTime   `db:"rdap_registered_at" json:"rdapRegisteredAt,omitempty"`
+	RDAPExpiresAt    sql.NullTime   `db:"rdap_expires_at" json:"rdapExpiresAt,omitempty"`
+	RDAPCheckedAt    sql.NullTime   `db:"rdap_checked_at" json:"rdapCheckedAt,omitempty"`
+
+	// TLS version and leaf certificate from the latest HTTP validation (httpvalidator.TLSInfo JSON)
+	TLSCertificate NullJSONRaw `db:"tls_certificate" json:"tlsCertificate,omitempty"`
 }
 
 // ScoringProfile represents a set of weights for scoring domains.
@@ -830,6 +925,7 @@ type HTTPKeywordResult struct {
 	FoundKeywordsFromSets   *json.RawMessage `db:"found_keywords_from_sets" json:"foundKeywordsFromSets,omitempty" firestore:"foundKeywordsFromSets,omitempty"`
 	FoundAdHocKeywords      *[]string        `db:"found_ad_hoc_keywords" json:"foundAdHocKeywords,omitempty" firestore:"foundAdHocKeywords,omitempty"`
 	ContentHash             *string          `db:"content_hash" json:"contentHash,omitempty" firestore:"contentHash,omitempty"`
+	TLSCertificate          *json.RawMessage `db:"tls_certificate" json:"tlsCertificate,omitempty" firestore:"tlsCertificate,omitempty"`
 	ValidatedByPersonaID    uuid.NullUUID    `db:"validated_by_persona_id" json:"validatedByPersonaId,omitempty" firestore:"validatedByPersonaId,omitempty"`
 	UsedProxyID             uuid.NullUUID    `db:"used_proxy_id" json:"usedProxyId,omitempty" firestore:"usedProxyId,omitempty"`
 	Attempts                *int             `db:"attempts" json:"attempts,omitempty" firestore:"attempts,omitempty" validate:"omitempty,gte=0"`
@@ -877,11 +973,13 @@ type ProxyPool struct {
 	Name                       string         `db:"name" json:"name" validate:"required"`
 	Description                sql.NullString `db:"description" json:"description,omitempty"`
 	IsEnabled                  bool           `db:"is_enabled" json:"isEnabled"`
-	PoolStrategy               sql.NullString `db:"pool_strategy" json:"poolStrategy,omitempty"` // round_robin, random, weighted, failover
+	PoolStrategy               sql.NullString `db:"pool_strategy" json:"poolStrategy,omitempty"` // round_robin, weighted, least_recently_used, sticky, random
 	HealthCheckEnabled         bool           `db:"health_check_enabled" json:"healthCheckEnabled"`
 	HealthCheckIntervalSeconds *int           `db:"health_check_interval_seconds" json:"healthCheckIntervalSeconds,omitempty"`
 	MaxRetries                 *int           `db:"max_retries" json:"maxRetries,omitempty"`
 	TimeoutSeconds             *int           `db:"timeout_seconds" json:"timeoutSeconds,omitempty"`
+	MaxConsecutiveFailures     int            `db:"max_consecutive_failures" json:"maxConsecutiveFailures" validate:"gte=0"`
+	MinSuccessRate             float64        `db:"min_success_rate" json:"minSuccessRate" validate:"gte=0,lte=1"`
 	CreatedAt                  time.Time      `db:"created_at" json:"createdAt"`
 	UpdatedAt                  time.Time      `db:"updated_at" json:"updatedAt"`
 
@@ -952,6 +1050,8 @@ const (
 	PatternTypePrefixVariable DomainPatternType = "prefix_variable"
 	PatternTypeSuffixVariable DomainPatternType = "suffix_variable"
 	PatternTypeBothVariable   DomainPatternType = "both_variable"
+	PatternTypeWordList       DomainPatternType = "word_list"     // words from each list in order, joined by the constant string
+	PatternTypeWordTemplate   DomainPatternType = "word_template" // template mixing {slot} word lists, {var:N} segments, {const} and literals
 )
 
 // DereferenceGeneratedDomainSlice converts a slice of *GeneratedDomain to []GeneratedDomain.
@@ -5,7 +5,7 @@ import (
 )
 
 func TestDomainRejectionReasonEnumValues(t *testing.T) {
-	// Test that all expected enum values exist (9 values, NO legacy)
+	// Test that all expected enum values exist (11 values, NO legacy)
 	expectedValues := []DomainRejectionReasonEnum{
 		DomainRejectionReasonQualified,
 		DomainRejectionReasonLowScore,
@@ -13,13 +13,15 @@ func TestDomainRejectionReasonEnumValues(t *testing.T) {
 		DomainRejectionReasonParked,
 		DomainRejectionReasonDNSError,
 		DomainRejectionReasonDNSTimeout,
+		DomainRejectionReasonDNSWildcard,
+		DomainRejectionReasonDNSMissingRecords,
 		DomainRejectionReasonHTTPError,
 		DomainRejectionReasonHTTPTimeout,
 		DomainRejectionReasonPending,
 	}
 
-	if len(expectedValues) != 9 {
-		t.Errorf("Expected 9 rejection reason enum values, got %d", len(expectedValues))
+	if len(expectedValues) != 11 {
+		t.Errorf("Expected 11 rejection reason enum values, got %d", len(expectedValues))
 	}
 
 	// Verify string values match database enum
@@ -33,6 +35,8 @@ func TestDomainRejectionReasonEnumValues(t *testing.T) {
 		{DomainRejectionReasonParked, "parked"},
 		{DomainRejectionReasonDNSError, "dns_error"},
 		{DomainRejectionReasonDNSTimeout, "dns_timeout"},
+		{DomainRejectionReasonDNSWildcard, "dns_wildcard"},
+		{DomainRejectionReasonDNSMissingRecords, "dns_missing_records"},
 		{DomainRejectionReasonHTTPError, "http_error"},
 		{DomainRejectionReasonHTTPTimeout, "http_timeout"},
 		{DomainRejectionReasonPending, "pending"},
@@ -59,6 +63,8 @@ func TestDomainRejectionReasonEnumIsValid(t *testing.T) {
 		{"parked is valid", DomainRejectionReasonParked, true},
 		{"dns_error is valid", DomainRejectionReasonDNSError, true},
 		{"dns_timeout is valid", DomainRejectionReasonDNSTimeout, true},
+		{"dns_wildcard is valid", DomainRejectionReasonDNSWildcard, true},
+		{"dns_missing_records is valid", DomainRejectionReasonDNSMissingRecords, true},
 		{"http_error is valid", DomainRejectionReasonHTTPError, true},
 		{"http_timeout is valid", DomainRejectionReasonHTTPTimeout, true},
 		{"pending is valid", DomainRejectionReasonPending, true},
@@ -80,8 +86,8 @@ func TestDomainRejectionReasonEnumIsValid(t *testing.T) {
 func TestValidRejectionReasons(t *testing.T) {
 	reasons := ValidRejectionReasons()
 
-	if len(reasons) != 9 {
-		t.Errorf("Expected 9 valid rejection reasons, got %d", len(reasons))
+	if len(reasons) != 11 {
+		t.Errorf("Expected 11 valid rejection reasons, got %d", len(reasons))
 	}
 
 	// Verify all returned values are valid
@@ -108,6 +114,8 @@ func TestParseRejectionReason(t *testing.T) {
 	}{
 		{"valid qualified", "qualified", DomainRejectionReasonQualified, false},
 		{"valid dns_timeout", "dns_timeout", DomainRejectionReasonDNSTimeout, false},
+		{"valid dns_wildcard", "dns_wildcard", DomainRejectionReasonDNSWildcard, false},
+		{"valid dns_missing_records", "dns_missing_records", DomainRejectionReasonDNSMissingRecords, false},
 		{"valid http_timeout", "http_timeout", DomainRejectionReasonHTTPTimeout, false},
 		{"valid pending", "pending", DomainRejectionReasonPending, false},
 		{"invalid legacy", "legacy", "", true},
@@ -43,37 +43,6 @@ type SecurityEvent struct {
 	CreatedAt           time.Time        `db:"created_at" json:"createdAt"`
 }
 
-// AuthorizationDecision represents a decision record from authorization_decisions table
-type AuthorizationDecision struct {
-	ID                uuid.UUID        `db:"id" json:"id"`
-	DecisionID        string           `db:"decision_id" json:"decisionId"`
-	UserID            uuid.UUID        `db:"user_id" json:"userId"`
-	ResourceType      string           `db:"resource_type" json:"resourceType"`
-	ResourceID        string           `db:"resource_id" json:"resourceId"`
-	Action            string           `db:"action" json:"action"`
-	Decision          string           `db:"decision" json:"decision"`
-	PolicyVersion     sql.NullString   `db:"policy_version" json:"policyVersion,omitempty"`
-	EvaluatedPolicies pq.StringArray   `db:"evaluated_policies" json:"evaluatedPolicies,omitempty"`
-	ConditionsMet     *json.RawMessage `db:"conditions_met" json:"conditionsMet,omitempty"`
-	DecisionTimeMs    int              `db:"decision_time_ms" json:"decisionTimeMs"`
-	Context           *json.RawMessage `db:"context" json:"context,omitempty"`
-	SecurityEventID   uuid.NullUUID    `db:"security_event_id" json:"securityEventId,omitempty"`
-	CreatedAt         time.Time        `db:"created_at" json:"createdAt"`
-}
-
-// CampaignAccessGrant maps to campaign_access_grants table
-type CampaignAccessGrant struct {
-	ID         uuid.UUID     `db:"id" json:"id"`
-	CampaignID uuid.UUID     `db:"campaign_id" json:"campaignId"`
-	UserID     uuid.UUID     `db:"user_id" json:"userId"`
-	AccessType string        `db:"access_type" json:"accessType"`
-	GrantedBy  uuid.NullUUID `db:"granted_by" json:"grantedBy,omitempty"`
-	GrantedAt  time.Time     `db:"granted_at" json:"grantedAt"`
-	ExpiresAt  *time.Time    `db:"expires_at" json:"expiresAt,omitempty"`
-	CreatedAt  time.Time     `db:"created_at" json:"createdAt"`
-	IsActive   bool          `db:"is_active" json:"isActive"`
-}
-
 // CacheConfiguration represents cache_configurations table
 type CacheConfiguration struct {
 	ID                uuid.UUID  `db:"id" json:"id"`
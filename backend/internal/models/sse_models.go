@@ -0,0 +1,18 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SSEJournalEntry is one persisted campaign SSE event. Seq is the per-campaign event id sent as the SSE "id:" field.
+type SSEJournalEntry struct {
+	CampaignID uuid.UUID       `db:"campaign_id" json:"campaignId"`
+	Seq        int64           `db:"seq" json:"seq"`
+	EventType  string          `db:"event_type" json:"eventType"`
+	UserID     uuid.NullUUID   `db:"user_id" json:"userId,omitempty"`
+	Payload    json.RawMessage `db:"payload" json:"payload"`
+	CreatedAt  time.Time       `db:"created_at" json:"createdAt"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// WebhookDeliveryStatusEnum is the lifecycle state of a single webhook delivery.
+type WebhookDeliveryStatusEnum string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatusEnum = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatusEnum = "delivered"
+	WebhookDeliveryStatusDead      WebhookDeliveryStatusEnum = "dead"
+)
+
+// WebhookSubscription is a user-owned outbound webhook endpoint.
+// An empty EventTypes list subscribes to every lifecycle event; CampaignID narrows delivery to one campaign.
+type WebhookSubscription struct {
+	ID         uuid.UUID      `db:"id" json:"id"`
+	UserID     uuid.UUID      `db:"user_id" json:"userId"`
+	Name       string         `db:"name" json:"name" validate:"required"`
+	URL        string         `db:"url" json:"url" validate:"required,url"`
+	Secret     string         `db:"secret" json:"-"`
+	EventTypes pq.StringArray `db:"event_types" json:"eventTypes"`
+	CampaignID uuid.NullUUID  `db:"campaign_id" json:"campaignId,omitempty"`
+	IsActive   bool           `db:"is_active" json:"isActive"`
+	CreatedAt  time.Time      `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time      `db:"updated_at" json:"updatedAt"`
+}
+
+// WebhookDelivery is one event queued for (or delivered to) one subscription.
+type WebhookDelivery struct {
+	ID             uuid.UUID                 `db:"id" json:"id"`
+	SubscriptionID uuid.UUID                 `db:"subscription_id" json:"subscriptionId"`
+	EventID        uuid.UUID                 `db:"event_id" json:"eventId"`
+	EventType      string                    `db:"event_type" json:"eventType"`
+	Payload        json.RawMessage           `db:"payload" json:"payload"`
+	Status         WebhookDeliveryStatusEnum `db:"status" json:"status"`
+	Attempts       int                       `db:"attempts" json:"attempts"`
+	NextAttemptAt  time.Time                 `db:"next_attempt_at" json:"nextAttemptAt"`
+	LastStatusCode sql.NullInt32             `db:"last_status_code" json:"lastStatusCode,omitempty"`
+	LastError      sql.NullString            `db:"last_error" json:"lastError,omitempty"`
+	CreatedAt      time.Time                 `db:"created_at" json:"createdAt"`
+	DeliveredAt    sql.NullTime              `db:"delivered_at" json:"deliveredAt,omitempty"`
+}
+
+// WebhookDeadLetter records a delivery that exhausted its retry budget.
+type WebhookDeadLetter struct {
+	ID             uuid.UUID       `db:"id" json:"id"`
+	DeliveryID     uuid.UUID       `db:"delivery_id" json:"deliveryId"`
+	SubscriptionID uuid.UUID       `db:"subscription_id" json:"subscriptionId"`
+	EventType      string          `db:"event_type" json:"eventType"`
+	Payload        json.RawMessage `db:"payload" json:"payload"`
+	Attempts       int             `db:"attempts" json:"attempts"`
+	LastStatusCode sql.NullInt32   `db:"last_status_code" json:"lastStatusCode,omitempty"`
+	LastError      sql.NullString  `db:"last_error" json:"lastError,omitempty"`
+	CreatedAt      time.Time       `db:"created_at" json:"createdAt"`
+}
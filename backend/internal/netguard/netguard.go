@@ -0,0 +1,62 @@
+// Package netguard keeps server-initiated requests to user-supplied URLs (webhooks, crawled links)
+// away from loopback, private and link-local addresses.
+package netguard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+// cgnat is the shared address space (RFC 6598), which netip does not classify as private.
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublicIP reports whether ip is a globally routable unicast address.
+func IsPublicIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	return !cgnat.Contains(ip)
+}
+
+// CheckHost rejects hosts that are obviously internal: localhost names and literal non-public IPs.
+// Names that resolve to internal addresses are caught at dial time by Control.
+func CheckHost(host string) error {
+	h := strings.TrimSuffix(strings.ToLower(strings.Trim(host, "[]")), ".")
+	if h == "localhost" || strings.HasSuffix(h, ".localhost") {
+		return fmt.Errorf("host %q is not publicly routable", host)
+	}
+	if ip, err := netip.ParseAddr(h); err == nil && !IsPublicIP(ip) {
+		return fmt.Errorf("address %s is not publicly routable", ip)
+	}
+	return nil
+}
+
+// Control is a net.Dialer Control hook that refuses connections to non-public addresses, so a public
+// name that resolves to an internal address is still blocked.
+func Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !IsPublicIP(ip) {
+		return fmt.Errorf("connection to non-public address %s blocked", ip)
+	}
+	return nil
+}
+
+// DialContext dials like net.Dialer but only to public addresses.
+func DialContext(d *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	guarded := *d
+	guarded.Control = Control
+	return guarded.DialContext
+}
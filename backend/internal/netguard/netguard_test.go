@@ -0,0 +1,41 @@
+package netguard
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"8.8.8.8":         true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fe80::1":         false,
+		"fd00::1":         false,
+		"::ffff:10.0.0.1": false,
+	} {
+		if got := IsPublicIP(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("IsPublicIP(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	for _, host := range []string{"localhost", "api.localhost", "127.0.0.1", "[::1]", "169.254.169.254"} {
+		if CheckHost(host) == nil {
+			t.Errorf("CheckHost(%q) accepted an internal host", host)
+		}
+	}
+	for _, host := range []string{"example.com", "93.184.216.34"} {
+		if err := CheckHost(host); err != nil {
+			t.Errorf("CheckHost(%q): %v", host, err)
+		}
+	}
+}
@@ -10,6 +10,7 @@ package phases
 //   http_keyword_validation -> extraction (legacy HTTP keyword checks)
 //   analysis                -> analysis
 //   enrichment              -> enrichment
+//   rdap_lookup             -> rdap (optional; not part of the canonical sequence)
 //
 // The translation is intentionally lossless and symmetric; unknown inputs
 // return the original string so calling code can decide how to handle errors.
@@ -32,6 +33,7 @@ func ToInternal(api string) string {
 }
 
 // AllInternal returns the canonical ordered list of internal phase identifiers.
+// Optional phases such as rdap_lookup are excluded.
 func AllInternal() []string {
 	return []string{
 		PhaseDomainGeneration,
@@ -62,12 +64,14 @@ const (
 	PhaseExtractionInternal    = "extraction"
 	PhaseEnrichmentInternal    = "enrichment"
 	PhaseAnalysis              = "analysis"
+	PhaseRDAPLookupInternal    = "rdap_lookup"
 
 	PhaseDiscovery         = "discovery"
 	PhaseValidation        = "validation"
 	PhaseExtraction        = "extraction"
 	PhaseFeatureExtraction = "feature_extraction"
 	PhaseEnrichment        = "enrichment"
+	PhaseRDAP              = "rdap"
 )
 
 var internalToAPI = map[string]string{
@@ -77,6 +81,7 @@ var internalToAPI = map[string]string{
 	PhaseExtractionInternal:    PhaseFeatureExtraction,
 	PhaseEnrichmentInternal:    PhaseEnrichment,
 	PhaseAnalysis:              PhaseAnalysis,
+	PhaseRDAPLookupInternal:    PhaseRDAP,
 }
 
 var apiToInternal = map[string]string{
@@ -86,4 +91,5 @@ var apiToInternal = map[string]string{
 	PhaseFeatureExtraction: PhaseExtractionInternal,
 	PhaseEnrichment:        PhaseEnrichmentInternal,
 	PhaseAnalysis:          PhaseAnalysis,
+	PhaseRDAP:              PhaseRDAPLookupInternal,
 }
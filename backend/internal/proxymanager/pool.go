@@ -0,0 +1,537 @@
+package proxymanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Pool strategies accepted in proxy_pools.pool_strategy.
+const (
+	StrategyRoundRobin        = "round_robin"
+	StrategyWeighted          = "weighted"
+	StrategyLeastRecentlyUsed = "least_recently_used"
+	StrategySticky            = "sticky"
+	StrategyRandom            = "random"
+)
+
+// DefaultMaxConsecutiveFailures is the failure threshold given to new pools.
+const DefaultMaxConsecutiveFailures = 3
+
+const (
+	poolRefreshInterval   = time.Minute
+	defaultPoolCooldown   = time.Minute
+	minSuccessSamples     = 5
+	outcomeSmoothing      = 0.2   // weight of the newest outcome in the success and latency averages
+	latencyReferenceMs    = 500.0 // latency at which a member's weighted score is halved
+	maxStickyHostsPerPool = 10000
+)
+
+var (
+	// ErrPoolDisabled is returned when selecting from a pool that has been switched off.
+	ErrPoolDisabled = errors.New("proxy pool is disabled")
+	// ErrNoEligibleProxy is returned when every member of a pool is disabled or over its health thresholds.
+	ErrNoEligibleProxy = errors.New("proxy pool has no eligible proxies")
+
+	// Pool metrics are labelled by pool only; per-member figures are served by PoolStats.
+	poolSelections = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_pool_selections_total",
+			Help: "Proxies handed out from proxy pools, by pool and strategy",
+		},
+		[]string{"pool", "strategy"},
+	)
+	poolOutcomes = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxy_pool_outcomes_total",
+			Help: "Requests made through pool proxies, by pool and outcome",
+		},
+		[]string{"pool", "outcome"},
+	)
+	poolEligible = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "proxy_pool_eligible_proxies",
+			Help: "Pool members within their pool's health thresholds at the last selection",
+		},
+		[]string{"pool"},
+	)
+)
+
+// IsValidPoolStrategy reports whether s names a supported pool strategy.
+func IsValidPoolStrategy(s string) bool {
+	switch s {
+	case StrategyRoundRobin, StrategyWeighted, StrategyLeastRecentlyUsed, StrategySticky, StrategyRandom:
+		return true
+	}
+	return false
+}
+
+// ProxySelector chooses a proxy for each target host, e.g. from a campaign's proxy pool, and is told
+// how the proxy fared so it can steer later selections.
+type ProxySelector interface {
+	SelectProxy(host string) (*models.Proxy, error)
+	ReportProxyResult(proxyID uuid.UUID, success bool, latency time.Duration)
+}
+
+// PoolThresholds decide when a member is skipped. A member over either threshold is retried once per
+// cooldown so a recovered proxy rejoins the rotation.
+type PoolThresholds struct {
+	MaxConsecutiveFailures int     // 0 disables the check
+	MinSuccessRate         float64 // smoothed success rate in [0,1]; 0 disables the check
+	Cooldown               time.Duration
+}
+
+// PoolStats is a snapshot of how a pool has distributed work across its members.
+type PoolStats struct {
+	PoolID   uuid.UUID
+	Name     string
+	Strategy string
+	Eligible int
+	Members  []PoolMemberStats
+}
+
+// PoolMemberStats describes one member of a PoolStats snapshot.
+type PoolMemberStats struct {
+	ProxyID             uuid.UUID
+	Address             string
+	Weight              int
+	Active              bool
+	Eligible            bool
+	Selections          int64
+	Successes           int64
+	Failures            int64
+	ConsecutiveFailures int
+	SuccessRate         float64
+	LatencyMs           float64
+	LastUsed            time.Time
+}
+
+type poolMember struct {
+	proxy  models.Proxy
+	weight int
+	active bool
+
+	selections          int64
+	successes           int64
+	failures            int64
+	consecutiveFailures int
+	successRate         float64
+	latencyMs           float64
+	lastUsed            time.Time
+	retryAfter          time.Time
+	// suspect marks a member whose last recorded health check failed; cleared by its first success.
+	suspect bool
+}
+
+type proxyPool struct {
+	mu         sync.Mutex
+	id         uuid.UUID
+	name       string
+	enabled    bool
+	strategy   string
+	thresholds PoolThresholds
+	members    []*poolMember
+	cursor     int
+	sticky     map[string]uuid.UUID
+	loadedAt   time.Time
+	stale      bool
+	now        func() time.Time
+	rng        *rand.Rand
+}
+
+func newProxyPool(cfg *models.ProxyPool, members []*poolMember, now func() time.Time) *proxyPool {
+	p := &proxyPool{
+		id:     cfg.ID,
+		sticky: make(map[string]uuid.UUID),
+		now:    now,
+		rng:    rand.New(rand.NewSource(now().UnixNano())),
+	}
+	p.refresh(cfg, members)
+	return p
+}
+
+// refresh applies new pool settings and membership, keeping the counters of members still present.
+func (p *proxyPool) refresh(cfg *models.ProxyPool, members []*poolMember) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.name = cfg.Name
+	p.enabled = cfg.IsEnabled
+	p.strategy = StrategyRoundRobin
+	if cfg.PoolStrategy.Valid && IsValidPoolStrategy(cfg.PoolStrategy.String) {
+		p.strategy = cfg.PoolStrategy.String
+	}
+	p.thresholds = PoolThresholds{
+		MaxConsecutiveFailures: cfg.MaxConsecutiveFailures,
+		MinSuccessRate:         cfg.MinSuccessRate,
+		Cooldown:               defaultPoolCooldown,
+	}
+	if cfg.HealthCheckIntervalSeconds != nil && *cfg.HealthCheckIntervalSeconds > 0 {
+		p.thresholds.Cooldown = time.Duration(*cfg.HealthCheckIntervalSeconds) * time.Second
+	}
+	previous := make(map[uuid.UUID]*poolMember, len(p.members))
+	for _, m := range p.members {
+		previous[m.proxy.ID] = m
+	}
+	for i, m := range members {
+		if old, ok := previous[m.proxy.ID]; ok {
+			old.proxy, old.weight, old.active = m.proxy, m.weight, m.active
+			members[i] = old
+		}
+	}
+	p.members = members
+	if p.cursor >= len(p.members) {
+		p.cursor = 0
+	}
+	p.loadedAt = p.now()
+	p.stale = false
+}
+
+func newPoolMember(proxy *models.Proxy, membership *models.ProxyPoolMembership) *poolMember {
+	m := &poolMember{proxy: *proxy, weight: 1, active: true, successRate: 1}
+	if membership != nil {
+		m.active = membership.IsActive
+		if membership.Weight != nil && *membership.Weight > 0 {
+			m.weight = *membership.Weight
+		}
+	}
+	if proxy.LatencyMs.Valid && proxy.LatencyMs.Int32 > 0 {
+		m.latencyMs = float64(proxy.LatencyMs.Int32)
+	}
+	m.suspect = !proxy.IsHealthy && proxy.LastCheckedAt.Valid
+	return m
+}
+
+// overThreshold reports whether m has breached one of the pool's health thresholds.
+func (p *proxyPool) overThreshold(m *poolMember) bool {
+	if m.suspect {
+		return true
+	}
+	if p.thresholds.MaxConsecutiveFailures > 0 && m.consecutiveFailures >= p.thresholds.MaxConsecutiveFailures {
+		return true
+	}
+	return p.thresholds.MinSuccessRate > 0 && m.successes+m.failures >= minSuccessSamples && m.successRate < p.thresholds.MinSuccessRate
+}
+
+func (p *proxyPool) eligible(m *poolMember, now time.Time) bool {
+	if !m.active || !m.proxy.IsEnabled {
+		return false
+	}
+	return !p.overThreshold(m) || !now.Before(m.retryAfter)
+}
+
+// score weights a member by its configured weight, smoothed success rate and latency.
+func (m *poolMember) score() float64 {
+	s := float64(m.weight) * m.successRate
+	if m.latencyMs > 0 {
+		s *= latencyReferenceMs / (latencyReferenceMs + m.latencyMs)
+	}
+	if s < 1e-6 {
+		s = 1e-6
+	}
+	return s
+}
+
+// pick chooses a member for host with the given strategy, or the pool's own when strategy is empty.
+func (p *proxyPool) pick(strategy, host string) (*poolMember, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.enabled {
+		return nil, "", fmt.Errorf("%w: %s", ErrPoolDisabled, p.name)
+	}
+	if strategy == "" {
+		strategy = p.strategy
+	}
+	now := p.now()
+	candidates := make([]*poolMember, 0, len(p.members))
+	for _, m := range p.members {
+		if p.eligible(m, now) {
+			candidates = append(candidates, m)
+		}
+	}
+	poolEligible.WithLabelValues(p.id.String()).Set(float64(len(candidates)))
+	if len(candidates) == 0 {
+		return nil, strategy, fmt.Errorf("%w: %s", ErrNoEligibleProxy, p.name)
+	}
+
+	var chosen *poolMember
+	switch strategy {
+	case StrategyRandom:
+		chosen = candidates[p.rng.Intn(len(candidates))]
+	case StrategyLeastRecentlyUsed:
+		chosen = candidates[0]
+		for _, m := range candidates[1:] {
+			if m.lastUsed.Before(chosen.lastUsed) {
+				chosen = m
+			}
+		}
+	case StrategyWeighted:
+		total := 0.0
+		for _, m := range candidates {
+			total += m.score()
+		}
+		r := p.rng.Float64() * total
+		chosen = candidates[len(candidates)-1]
+		for _, m := range candidates {
+			if r -= m.score(); r < 0 {
+				chosen = m
+				break
+			}
+		}
+	case StrategySticky:
+		key := strings.ToLower(strings.TrimSuffix(host, "."))
+		if id, ok := p.sticky[key]; ok {
+			for _, m := range candidates {
+				if m.proxy.ID == id {
+					chosen = m
+					break
+				}
+			}
+		}
+		if chosen == nil {
+			chosen = p.nextRoundRobin(now)
+			if len(p.sticky) >= maxStickyHostsPerPool {
+				p.sticky = make(map[string]uuid.UUID)
+			}
+			p.sticky[key] = chosen.proxy.ID
+		}
+	default:
+		chosen = p.nextRoundRobin(now)
+	}
+
+	if p.overThreshold(chosen) {
+		// Half-open: this request probes the member, the next probe waits another cooldown
+		chosen.retryAfter = now.Add(p.thresholds.Cooldown)
+	}
+	chosen.selections++
+	chosen.lastUsed = now
+	poolSelections.WithLabelValues(p.id.String(), strategy).Inc()
+	return chosen, strategy, nil
+}
+
+// nextRoundRobin returns the next eligible member after the cursor; callers ensure one exists.
+func (p *proxyPool) nextRoundRobin(now time.Time) *poolMember {
+	for i := 0; i < len(p.members); i++ {
+		idx := (p.cursor + i) % len(p.members)
+		if m := p.members[idx]; p.eligible(m, now) {
+			p.cursor = (idx + 1) % len(p.members)
+			return m
+		}
+	}
+	return nil
+}
+
+func (p *proxyPool) record(proxyID uuid.UUID, success bool, latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var m *poolMember
+	for _, candidate := range p.members {
+		if candidate.proxy.ID == proxyID {
+			m = candidate
+			break
+		}
+	}
+	if m == nil {
+		return
+	}
+	now := p.now()
+	outcome := 0.0
+	if success {
+		outcome = 1
+		m.successes++
+		m.consecutiveFailures = 0
+		m.suspect = false
+		if latency > 0 {
+			ms := float64(latency.Milliseconds())
+			if m.latencyMs == 0 {
+				m.latencyMs = ms
+			} else {
+				m.latencyMs += outcomeSmoothing * (ms - m.latencyMs)
+			}
+		}
+		poolOutcomes.WithLabelValues(p.id.String(), "success").Inc()
+	} else {
+		m.failures++
+		m.consecutiveFailures++
+		poolOutcomes.WithLabelValues(p.id.String(), "failure").Inc()
+	}
+	m.successRate += outcomeSmoothing * (outcome - m.successRate)
+	if !success && p.overThreshold(m) {
+		if now.After(m.retryAfter) {
+			log.Printf("ProxyManager: Proxy ID '%s' in pool '%s' exceeded pool health thresholds (consecutive failures: %d, success rate: %.2f); skipping for %s", proxyID, p.name, m.consecutiveFailures, m.successRate, p.thresholds.Cooldown)
+		}
+		m.retryAfter = now.Add(p.thresholds.Cooldown)
+	}
+}
+
+func (p *proxyPool) snapshot() *PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	stats := &PoolStats{PoolID: p.id, Name: p.name, Strategy: p.strategy, Members: make([]PoolMemberStats, 0, len(p.members))}
+	for _, m := range p.members {
+		eligible := p.enabled && p.eligible(m, now)
+		if eligible {
+			stats.Eligible++
+		}
+		stats.Members = append(stats.Members, PoolMemberStats{
+			ProxyID:             m.proxy.ID,
+			Address:             m.proxy.Address,
+			Weight:              m.weight,
+			Active:              m.active,
+			Eligible:            eligible,
+			Selections:          m.selections,
+			Successes:           m.successes,
+			Failures:            m.failures,
+			ConsecutiveFailures: m.consecutiveFailures,
+			SuccessRate:         m.successRate,
+			LatencyMs:           m.latencyMs,
+			LastUsed:            m.lastUsed,
+		})
+	}
+	return stats
+}
+
+// poolSelector hands out proxies from one pool.
+type poolSelector struct {
+	pool     *proxyPool
+	strategy string
+}
+
+func (s *poolSelector) SelectProxy(host string) (*models.Proxy, error) {
+	m, _, err := s.pool.pick(s.strategy, host)
+	if err != nil {
+		return nil, err
+	}
+	s.pool.mu.Lock()
+	proxy := m.proxy
+	s.pool.mu.Unlock()
+	// The pool's thresholds have already vetted the member; the validator skips proxies not marked healthy
+	proxy.IsHealthy = true
+	return &proxy, nil
+}
+
+func (s *poolSelector) ReportProxyResult(proxyID uuid.UUID, success bool, latency time.Duration) {
+	s.pool.record(proxyID, success, latency)
+}
+
+// SetPoolStore lets the manager load proxy pools for pool-scoped selection.
+func (pm *ProxyManager) SetPoolStore(poolStore store.ProxyPoolStore) {
+	pm.poolsMu.Lock()
+	defer pm.poolsMu.Unlock()
+	pm.poolStore = poolStore
+}
+
+// SelectorForPool returns a selector drawing proxies from the pool. strategy overrides the pool's
+// own strategy when non-empty.
+func (pm *ProxyManager) SelectorForPool(ctx context.Context, poolID uuid.UUID, strategy string) (ProxySelector, error) {
+	if strategy != "" && !IsValidPoolStrategy(strategy) {
+		return nil, fmt.Errorf("unsupported proxy pool strategy %q", strategy)
+	}
+	pool, err := pm.loadPool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	pool.mu.Lock()
+	enabled, name := pool.enabled, pool.name
+	pool.mu.Unlock()
+	if !enabled {
+		return nil, fmt.Errorf("%w: %s", ErrPoolDisabled, name)
+	}
+	return &poolSelector{pool: pool, strategy: strategy}, nil
+}
+
+// PoolStats returns the selection distribution and member health for a pool.
+func (pm *ProxyManager) PoolStats(ctx context.Context, poolID uuid.UUID) (*PoolStats, error) {
+	pool, err := pm.loadPool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	return pool.snapshot(), nil
+}
+
+// InvalidatePool makes the next selection reload the pool's settings and members.
+// Counters of members that remain in the pool are kept.
+func (pm *ProxyManager) InvalidatePool(poolID uuid.UUID) {
+	pm.poolsMu.Lock()
+	pool := pm.pools[poolID]
+	pm.poolsMu.Unlock()
+	if pool != nil {
+		pool.mu.Lock()
+		pool.stale = true
+		pool.mu.Unlock()
+	}
+}
+
+func (pm *ProxyManager) loadPool(ctx context.Context, poolID uuid.UUID) (*proxyPool, error) {
+	pm.poolsMu.Lock()
+	pool, poolStore := pm.pools[poolID], pm.poolStore
+	pm.poolsMu.Unlock()
+	now := time.Now
+	if pool != nil {
+		pool.mu.Lock()
+		fresh := !pool.stale && pool.now().Sub(pool.loadedAt) < poolRefreshInterval
+		now = pool.now
+		pool.mu.Unlock()
+		if fresh {
+			return pool, nil
+		}
+	}
+	if poolStore == nil {
+		return nil, fmt.Errorf("proxy pool store not configured")
+	}
+	cfg, err := poolStore.GetProxyPoolByID(ctx, pm.db, poolID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			pm.poolsMu.Lock()
+			delete(pm.pools, poolID)
+			pm.poolsMu.Unlock()
+		}
+		return nil, fmt.Errorf("load proxy pool %s: %w", poolID, err)
+	}
+	proxies, err := poolStore.ListProxiesForPool(ctx, pm.db, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("load proxies for pool %s: %w", poolID, err)
+	}
+	memberships, err := poolStore.ListPoolMemberships(ctx, pm.db, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("load memberships for pool %s: %w", poolID, err)
+	}
+	// Members keep membership order so round robin is stable across reloads
+	byID := make(map[uuid.UUID]*models.Proxy, len(proxies))
+	for _, p := range proxies {
+		if p != nil {
+			byID[p.ID] = p
+		}
+	}
+	members := make([]*poolMember, 0, len(proxies))
+	for _, m := range memberships {
+		if p, ok := byID[m.ProxyID]; ok {
+			members = append(members, newPoolMember(p, m))
+		}
+	}
+
+	pm.poolsMu.Lock()
+	defer pm.poolsMu.Unlock()
+	if pm.pools == nil {
+		pm.pools = make(map[uuid.UUID]*proxyPool)
+	}
+	if existing, ok := pm.pools[poolID]; ok {
+		existing.refresh(cfg, members)
+		return existing, nil
+	}
+	pool = newProxyPool(cfg, members, now)
+	pm.pools[poolID] = pool
+	log.Printf("ProxyManager: Loaded proxy pool '%s' (%s) with %d members, strategy '%s'", cfg.Name, poolID, len(members), pool.strategy)
+	return pool, nil
+}
@@ -0,0 +1,256 @@
+package proxymanager
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func testPool(t *testing.T, strategy string, maxFailures int, n int) (*proxyPool, []uuid.UUID, *fakeClock) {
+	t.Helper()
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	interval := 30
+	cfg := &models.ProxyPool{
+		ID:                         uuid.New(),
+		Name:                       "test-pool",
+		IsEnabled:                  true,
+		PoolStrategy:               sql.NullString{String: strategy, Valid: true},
+		HealthCheckIntervalSeconds: &interval,
+		MaxConsecutiveFailures:     maxFailures,
+	}
+	ids := make([]uuid.UUID, n)
+	members := make([]*poolMember, n)
+	for i := range members {
+		ids[i] = uuid.New()
+		members[i] = newPoolMember(&models.Proxy{ID: ids[i], Address: "10.0.0.1:8080", IsEnabled: true, IsHealthy: true}, nil)
+	}
+	return newProxyPool(cfg, members, clock.now), ids, clock
+}
+
+func mustPick(t *testing.T, p *proxyPool, host string) uuid.UUID {
+	t.Helper()
+	m, _, err := p.pick("", host)
+	if err != nil {
+		t.Fatalf("pick: %v", err)
+	}
+	return m.proxy.ID
+}
+
+func TestPoolRoundRobinSkipsMembersOverThreshold(t *testing.T) {
+	p, ids, clock := testPool(t, StrategyRoundRobin, 2, 3)
+
+	for i := 0; i < 3; i++ {
+		if got := mustPick(t, p, "a.test"); got != ids[i] {
+			t.Fatalf("pick %d: expected member %d", i, i)
+		}
+	}
+	p.record(ids[1], false, 0)
+	p.record(ids[1], false, 0)
+	for i := 0; i < 4; i++ {
+		if got := mustPick(t, p, "a.test"); got == ids[1] {
+			t.Fatal("member over the failure threshold should be skipped")
+		}
+	}
+
+	clock.t = clock.t.Add(31 * time.Second)
+	seen := false
+	for i := 0; i < 3; i++ {
+		if mustPick(t, p, "a.test") == ids[1] {
+			seen = true
+		}
+	}
+	if !seen {
+		t.Fatal("expected member to be probed once the cooldown elapsed")
+	}
+	p.record(ids[1], true, 20*time.Millisecond)
+	if p.overThreshold(p.members[1]) {
+		t.Fatal("a success should bring the member back under the threshold")
+	}
+}
+
+func TestPoolNoEligibleMembers(t *testing.T) {
+	p, ids, _ := testPool(t, StrategyRandom, 1, 2)
+	p.record(ids[0], false, 0)
+	p.record(ids[1], false, 0)
+	if _, _, err := p.pick("", "a.test"); !errors.Is(err, ErrNoEligibleProxy) {
+		t.Fatalf("expected ErrNoEligibleProxy, got %v", err)
+	}
+}
+
+func TestPoolWeightedPrefersFastReliableMembers(t *testing.T) {
+	p, ids, _ := testPool(t, StrategyWeighted, 0, 2)
+	for i := 0; i < 10; i++ {
+		p.record(ids[0], true, 50*time.Millisecond)
+		p.record(ids[1], i%2 == 0, 2*time.Second)
+	}
+	counts := map[uuid.UUID]int{}
+	for i := 0; i < 2000; i++ {
+		counts[mustPick(t, p, "a.test")]++
+	}
+	if counts[ids[0]] < 4*counts[ids[1]] || counts[ids[1]] == 0 {
+		t.Fatalf("expected the fast reliable member to dominate without starving the other, got %v", counts)
+	}
+}
+
+func TestPoolLeastRecentlyUsed(t *testing.T) {
+	p, ids, clock := testPool(t, StrategyLeastRecentlyUsed, 0, 3)
+	order := []uuid.UUID{}
+	for i := 0; i < 6; i++ {
+		clock.t = clock.t.Add(time.Second)
+		order = append(order, mustPick(t, p, "a.test"))
+	}
+	for i, id := range order {
+		if id != ids[i%3] {
+			t.Fatalf("expected members in least recently used order, got %v", order)
+		}
+	}
+}
+
+func TestPoolStickyKeepsHostOnProxy(t *testing.T) {
+	p, _, _ := testPool(t, StrategySticky, 1, 3)
+	first := mustPick(t, p, "Shop.Example.")
+	other := mustPick(t, p, "other.example")
+	if first == other {
+		t.Fatal("expected a new host to get the next member")
+	}
+	for i := 0; i < 5; i++ {
+		if got := mustPick(t, p, "shop.example"); got != first {
+			t.Fatalf("expected host to stay on %s, got %s", first, got)
+		}
+	}
+	p.record(first, false, 0)
+	if got := mustPick(t, p, "shop.example"); got == first {
+		t.Fatal("expected host to move off a member over the threshold")
+	}
+}
+
+func TestPoolStrategyOverride(t *testing.T) {
+	p, ids, _ := testPool(t, StrategyRoundRobin, 0, 2)
+	for i := 0; i < 5; i++ {
+		m, strategy, err := p.pick(StrategySticky, "a.test")
+		if err != nil || strategy != StrategySticky {
+			t.Fatalf("pick: %v (strategy %s)", err, strategy)
+		}
+		if m.proxy.ID != ids[0] {
+			t.Fatal("expected sticky override to keep the host on one member")
+		}
+	}
+}
+
+type fakePoolStore struct {
+	pool        *models.ProxyPool
+	proxies     []*models.Proxy
+	memberships []*models.ProxyPoolMembership
+	loads       int
+}
+
+func (f *fakePoolStore) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return nil, nil
+}
+func (f *fakePoolStore) CreateProxyPool(ctx context.Context, exec store.Querier, pool *models.ProxyPool) error {
+	return nil
+}
+func (f *fakePoolStore) GetProxyPoolByID(ctx context.Context, exec store.Querier, id uuid.UUID) (*models.ProxyPool, error) {
+	if f.pool == nil || f.pool.ID != id {
+		return nil, store.ErrNotFound
+	}
+	f.loads++
+	cp := *f.pool
+	return &cp, nil
+}
+func (f *fakePoolStore) UpdateProxyPool(ctx context.Context, exec store.Querier, pool *models.ProxyPool) error {
+	return nil
+}
+func (f *fakePoolStore) DeleteProxyPool(ctx context.Context, exec store.Querier, id uuid.UUID) error {
+	return nil
+}
+func (f *fakePoolStore) ListProxyPools(ctx context.Context, exec store.Querier) ([]*models.ProxyPool, error) {
+	return nil, nil
+}
+func (f *fakePoolStore) AddProxyToPool(ctx context.Context, exec store.Querier, m *models.ProxyPoolMembership) error {
+	return nil
+}
+func (f *fakePoolStore) RemoveProxyFromPool(ctx context.Context, exec store.Querier, poolID, proxyID uuid.UUID) error {
+	return nil
+}
+func (f *fakePoolStore) ListProxiesForPool(ctx context.Context, exec store.Querier, poolID uuid.UUID) ([]*models.Proxy, error) {
+	return f.proxies, nil
+}
+func (f *fakePoolStore) ListPoolMemberships(ctx context.Context, exec store.Querier, poolID uuid.UUID) ([]*models.ProxyPoolMembership, error) {
+	return f.memberships, nil
+}
+
+func TestSelectorForPoolLoadsMembership(t *testing.T) {
+	poolID := uuid.New()
+	active, inactive, suspect := uuid.New(), uuid.New(), uuid.New()
+	weight := 5
+	fs := &fakePoolStore{
+		pool: &models.ProxyPool{ID: poolID, Name: "edge", IsEnabled: true, PoolStrategy: sql.NullString{String: StrategyRoundRobin, Valid: true}, MaxConsecutiveFailures: 3},
+		proxies: []*models.Proxy{
+			{ID: inactive, Address: "10.0.0.2:3128", IsEnabled: true, IsHealthy: true},
+			{ID: active, Address: "10.0.0.1:3128", IsEnabled: true, IsHealthy: false},
+			{ID: suspect, Address: "10.0.0.3:3128", IsEnabled: true, IsHealthy: false, LastCheckedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+		},
+		memberships: []*models.ProxyPoolMembership{
+			{PoolID: poolID, ProxyID: active, Weight: &weight, IsActive: true},
+			{PoolID: poolID, ProxyID: inactive, IsActive: false},
+			{PoolID: poolID, ProxyID: suspect, IsActive: true},
+		},
+	}
+	pm := &ProxyManager{}
+	pm.SetPoolStore(fs)
+
+	sel, err := pm.SelectorForPool(context.Background(), poolID, "")
+	if err != nil {
+		t.Fatalf("SelectorForPool: %v", err)
+	}
+	// The suspect member is probed once, then skipped until its cooldown elapses
+	picked := map[uuid.UUID]int{}
+	for i := 0; i < 6; i++ {
+		p, err := sel.SelectProxy("a.test")
+		if err != nil {
+			t.Fatalf("SelectProxy: %v", err)
+		}
+		if !p.IsHealthy {
+			t.Fatal("selected proxies are handed out as healthy")
+		}
+		picked[p.ID]++
+	}
+	if picked[inactive] != 0 || picked[suspect] != 1 || picked[active] != 5 {
+		t.Fatalf("unexpected distribution %v", picked)
+	}
+
+	stats, err := pm.PoolStats(context.Background(), poolID)
+	if err != nil {
+		t.Fatalf("PoolStats: %v", err)
+	}
+	if stats.Strategy != StrategyRoundRobin || len(stats.Members) != 3 || stats.Members[0].Weight != 5 || stats.Members[0].Selections != 5 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+
+	fs.pool.IsEnabled = false
+	pm.InvalidatePool(poolID)
+	if _, err := pm.SelectorForPool(context.Background(), poolID, ""); !errors.Is(err, ErrPoolDisabled) {
+		t.Fatalf("expected ErrPoolDisabled after reload, got %v", err)
+	}
+	if fs.loads != 2 {
+		t.Fatalf("expected one reload after invalidation, got %d loads", fs.loads)
+	}
+	if _, err := pm.SelectorForPool(context.Background(), uuid.New(), ""); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown pool, got %v", err)
+	}
+	if _, err := pm.SelectorForPool(context.Background(), poolID, "failover"); err == nil {
+		t.Fatal("expected unsupported strategy override to be rejected")
+	}
+}
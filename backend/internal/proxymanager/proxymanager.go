@@ -145,6 +145,10 @@ type ProxyManager struct {
 	config             config.ProxyManagerConfig
 	proxyStore         store.ProxyStore
 	db                 store.Querier
+
+	poolsMu   sync.Mutex
+	poolStore store.ProxyPoolStore
+	pools     map[uuid.UUID]*proxyPool
 }
 
 func NewProxyManager(entries []config.ProxyConfigEntry, cfg config.ProxyManagerConfig, proxyStore store.ProxyStore, db store.Querier) *ProxyManager {
@@ -0,0 +1,249 @@
+// Package ratelimit provides process-wide token buckets used to enforce persona, resolver, target and
+// server rate limits across every campaign running in the process.
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// ScopePersona limits all requests made under one persona.
+	ScopePersona = "persona"
+	// ScopeResolver limits queries sent to one DNS resolver.
+	ScopeResolver = "resolver"
+	// ScopeTarget limits connections opened to one target IP.
+	ScopeTarget = "target"
+	// ScopeServer limits requests sent to one lookup server, e.g. an RDAP service host.
+	ScopeServer = "server"
+
+	// maxIdleBuckets bounds the registry; beyond it buckets idle for bucketIdleTTL are evicted.
+	maxIdleBuckets = 10000
+	bucketIdleTTL  = 10 * time.Minute
+)
+
+var (
+	throttledSeconds = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "persona_rate_limit_throttled_seconds_total",
+			Help: "Time spent waiting on persona, resolver and target rate limits",
+		},
+		[]string{"kind", "scope"},
+	)
+	throttledWaits = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "persona_rate_limit_throttled_total",
+			Help: "Number of requests delayed by persona, resolver and target rate limits",
+		},
+		[]string{"kind", "scope"},
+	)
+)
+
+// Bucket is a token bucket refilled at rate tokens per second up to burst tokens.
+type Bucket struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	last     time.Time
+	lastUsed time.Time
+	now      func() time.Time
+}
+
+// NewBucket creates a full bucket allowing dps events per second with the given burst (at least 1).
+func NewBucket(dps float64, burst int) *Bucket {
+	b := &Bucket{now: time.Now}
+	b.SetLimit(dps, burst)
+	b.tokens = b.burst
+	return b
+}
+
+// SetLimit updates the bucket's rate and burst, keeping the tokens already accrued.
+func (b *Bucket) SetLimit(dps float64, burst int) {
+	if burst < 1 {
+		burst = 1
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rate, b.burst = dps, float64(burst)
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// reserve takes a token, returning how long the caller must wait before using it.
+func (b *Bucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last, b.lastUsed = now, now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used.
+func (b *Bucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens++; b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// Wait blocks until a token is available or ctx is done, returning the time spent waiting. A nil bucket
+// never waits.
+func (b *Bucket) Wait(ctx context.Context) (time.Duration, error) {
+	if b == nil {
+		return 0, nil
+	}
+	delay := b.reserve()
+	if delay <= 0 {
+		return 0, nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	start := time.Now()
+	select {
+	case <-timer.C:
+		return delay, nil
+	case <-ctx.Done():
+		b.cancel()
+		return time.Since(start), ctx.Err()
+	}
+}
+
+// Registry holds named buckets so limits are shared by every caller using the same key.
+type Registry struct {
+	mu      sync.Mutex
+	buckets map[string]*Bucket
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{buckets: make(map[string]*Bucket)}
+}
+
+var shared = NewRegistry()
+
+// Shared returns the process-wide registry.
+func Shared() *Registry { return shared }
+
+// Bucket returns the bucket for key, creating it or updating its limits as needed. It returns nil when
+// dps is not positive, meaning unlimited.
+func (r *Registry) Bucket(key string, dps float64, burst int) *Bucket {
+	if dps <= 0 {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.buckets[key]
+	if !ok {
+		if len(r.buckets) >= maxIdleBuckets {
+			r.evictIdleLocked()
+		}
+		b = NewBucket(dps, burst)
+		r.buckets[key] = b
+		return b
+	}
+	b.mu.Lock()
+	changed := b.rate != dps || b.burst != float64(max(burst, 1))
+	b.mu.Unlock()
+	if changed {
+		b.SetLimit(dps, burst)
+	}
+	return b
+}
+
+func (r *Registry) evictIdleLocked() {
+	cutoff := time.Now().Add(-bucketIdleTTL)
+	for key, b := range r.buckets {
+		b.mu.Lock()
+		idle := b.lastUsed.Before(cutoff)
+		b.mu.Unlock()
+		if idle {
+			delete(r.buckets, key)
+		}
+	}
+}
+
+// Wait takes a token from the bucket identified by kind, scope and key, recording any time spent
+// throttled. kind names the caller (e.g. "dns" or "http"); dps <= 0 disables the limit.
+func (r *Registry) Wait(ctx context.Context, kind, scope, key string, dps float64, burst int) error {
+	b := r.Bucket(kind+":"+scope+":"+key, dps, burst)
+	if b == nil {
+		return nil
+	}
+	waited, err := b.Wait(ctx)
+	if waited > 0 {
+		throttledSeconds.WithLabelValues(kind, scope).Add(waited.Seconds())
+		throttledWaits.WithLabelValues(kind, scope).Inc()
+	}
+	return err
+}
+
+// DialContextPerTarget wraps dial so every connection waits on a ScopeTarget bucket keyed by the
+// resolved IP of the dialed address. The connection is opened to that IP; like net.Dialer, the resolved
+// addresses are tried in order until one connects, each attempt taking a token from its own bucket.
+func (r *Registry) DialContextPerTarget(dial func(ctx context.Context, network, addr string) (net.Conn, error), kind string, dps float64, burst int) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if dial == nil {
+		dial = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+	}
+	if dps <= 0 {
+		return dial
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		ips := []string{host}
+		if net.ParseIP(host) == nil {
+			addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+			if err != nil {
+				return nil, err
+			}
+			if len(addrs) == 0 {
+				return nil, &net.DNSError{Err: "no addresses", Name: host, IsNotFound: true}
+			}
+			ips = ips[:0]
+			for _, a := range addrs {
+				ips = append(ips, a.IP.String())
+			}
+		}
+		return r.dialTargets(ctx, dial, network, ips, port, kind, dps, burst)
+	}
+}
+
+// dialTargets dials ips in order, returning the first connection or the last dial error. It stops early
+// when ctx is done.
+func (r *Registry) dialTargets(ctx context.Context, dial func(ctx context.Context, network, addr string) (net.Conn, error), network string, ips []string, port, kind string, dps float64, burst int) (net.Conn, error) {
+	var lastErr error
+	for _, ip := range ips {
+		if err := r.Wait(ctx, kind, ScopeTarget, ip, dps, burst); err != nil {
+			return nil, err
+		}
+		conn, err := dial(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
+}
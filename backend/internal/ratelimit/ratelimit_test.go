@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func newTestBucket(dps float64, burst int) (*Bucket, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	b := NewBucket(dps, burst)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBucketReserve(t *testing.T) {
+	b, now := newTestBucket(2, 2)
+	for i := 0; i < 2; i++ {
+		if d := b.reserve(); d != 0 {
+			t.Fatalf("burst reservation %d waited %v", i, d)
+		}
+	}
+	if d := b.reserve(); d != 500*time.Millisecond {
+		t.Fatalf("expected 500ms wait, got %v", d)
+	}
+	if d := b.reserve(); d != time.Second {
+		t.Fatalf("expected queued reservation to wait 1s, got %v", d)
+	}
+	*now = now.Add(10 * time.Second)
+	if d := b.reserve(); d != 0 {
+		t.Fatalf("expected refilled bucket, waited %v", d)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens != 1 {
+		t.Fatalf("refill must cap at burst, tokens=%v", b.tokens)
+	}
+}
+
+func TestBucketWaitCancelReturnsToken(t *testing.T) {
+	b, _ := newTestBucket(0.001, 1)
+	if _, err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("first wait: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := b.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens != 0 {
+		t.Fatalf("cancelled wait should return its token, tokens=%v", b.tokens)
+	}
+}
+
+func TestRegistrySharesBuckets(t *testing.T) {
+	r := NewRegistry()
+	if r.Bucket("k", 0, 5) != nil {
+		t.Fatal("non-positive rate must be unlimited")
+	}
+	a := r.Bucket("k", 5, 1)
+	if b := r.Bucket("k", 5, 1); a != b {
+		t.Fatal("same key must share a bucket")
+	}
+	r.Bucket("k", 10, 3)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.rate != 10 || a.burst != 3 {
+		t.Fatalf("expected limits to follow latest config, got rate=%v burst=%v", a.rate, a.burst)
+	}
+}
+
+func TestRegistryWaitThrottles(t *testing.T) {
+	r := NewRegistry()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := r.Wait(context.Background(), "test", ScopePersona, "p", 50, 1); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Fatalf("expected ~40ms of throttling at 50/s burst 1, got %v", elapsed)
+	}
+}
+
+func TestDialTargetsFallsBackToNextAddress(t *testing.T) {
+	r := NewRegistry()
+	var dialed []string
+	dial := func(_ context.Context, _, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		if addr == "192.0.2.1:80" {
+			return nil, errors.New("connection refused")
+		}
+		client, server := net.Pipe()
+		server.Close()
+		return client, nil
+	}
+	conn, err := r.dialTargets(context.Background(), dial, "tcp", []string{"192.0.2.1", "192.0.2.2"}, "80", "test", 100, 1)
+	if err != nil {
+		t.Fatalf("dialTargets: %v", err)
+	}
+	conn.Close()
+	if len(dialed) != 2 || dialed[1] != "192.0.2.2:80" {
+		t.Fatalf("expected fallback to the second address, dialed %v", dialed)
+	}
+	for _, ip := range []string{"192.0.2.1", "192.0.2.2"} {
+		b := r.Bucket("test:"+ScopeTarget+":"+ip, 100, 1)
+		b.mu.Lock()
+		tokens := b.tokens
+		b.mu.Unlock()
+		if tokens >= 1 {
+			t.Errorf("expected a token taken for %s, tokens=%v", ip, tokens)
+		}
+	}
+}
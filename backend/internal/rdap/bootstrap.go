@@ -0,0 +1,140 @@
+// Package rdap looks up domain registration data over RDAP (RFC 9083), discovering the authoritative
+// server for each TLD from an IANA bootstrap file (RFC 9224).
+package rdap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// DefaultBootstrapSource is the IANA-published DNS bootstrap registry.
+const DefaultBootstrapSource = "https://data.iana.org/rdap/dns.json"
+
+// maxBootstrapBytes bounds the bootstrap document; the IANA file is well under 100KB.
+const maxBootstrapBytes = 4 << 20
+
+// Bootstrap maps TLDs to the base URLs of their RDAP services.
+type Bootstrap struct {
+	Publication string
+	services    map[string][]string
+}
+
+type bootstrapFile struct {
+	Version     string       `json:"version"`
+	Publication string       `json:"publication"`
+	Services    [][][]string `json:"services"`
+}
+
+// ParseBootstrap decodes a bootstrap document in the IANA format:
+// {"services": [[["com","net"], ["https://rdap.example/"]], ...]}.
+func ParseBootstrap(data []byte) (*Bootstrap, error) {
+	var file bootstrapFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decode rdap bootstrap: %w", err)
+	}
+	b := &Bootstrap{Publication: file.Publication, services: make(map[string][]string)}
+	for i, entry := range file.Services {
+		if len(entry) != 2 {
+			return nil, fmt.Errorf("rdap bootstrap service %d: expected [tlds, urls], got %d elements", i, len(entry))
+		}
+		var urls []string
+		for _, u := range entry[1] {
+			u = strings.TrimSpace(u)
+			if u == "" {
+				continue
+			}
+			if !strings.HasSuffix(u, "/") {
+				u += "/"
+			}
+			urls = append(urls, u)
+		}
+		if len(urls) == 0 {
+			continue
+		}
+		// RFC 9224 recommends preferring HTTPS when a service lists both schemes.
+		urls = preferHTTPS(urls)
+		for _, tld := range entry[0] {
+			tld = strings.ToLower(strings.Trim(strings.TrimSpace(tld), "."))
+			if tld != "" {
+				b.services[tld] = urls
+			}
+		}
+	}
+	if len(b.services) == 0 {
+		return nil, fmt.Errorf("rdap bootstrap lists no services")
+	}
+	return b, nil
+}
+
+func preferHTTPS(urls []string) []string {
+	out := make([]string, 0, len(urls))
+	for _, u := range urls {
+		if strings.HasPrefix(strings.ToLower(u), "https://") {
+			out = append(out, u)
+		}
+	}
+	for _, u := range urls {
+		if !strings.HasPrefix(strings.ToLower(u), "https://") {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// ServersFor returns the RDAP base URLs for domain, matching the longest registered suffix so entries
+// such as "co.uk" win over "uk". It returns nil when no service covers the domain.
+func (b *Bootstrap) ServersFor(domain string) []string {
+	if b == nil {
+		return nil
+	}
+	labels := strings.Split(strings.ToLower(strings.Trim(strings.TrimSpace(domain), ".")), ".")
+	for i := 1; i < len(labels); i++ {
+		if urls, ok := b.services[strings.Join(labels[i:], ".")]; ok {
+			return urls
+		}
+	}
+	return nil
+}
+
+// LoadBootstrap reads a bootstrap document from source, which is either an http(s) URL or a local
+// file path (optionally prefixed with file://).
+func LoadBootstrap(ctx context.Context, source string, client *http.Client) (*Bootstrap, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		source = DefaultBootstrapSource
+	}
+	lower := strings.ToLower(source)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		data, err := os.ReadFile(strings.TrimPrefix(source, "file://"))
+		if err != nil {
+			return nil, fmt.Errorf("read rdap bootstrap: %w", err)
+		}
+		return ParseBootstrap(data)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build rdap bootstrap request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch rdap bootstrap: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch rdap bootstrap: unexpected status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBootstrapBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read rdap bootstrap: %w", err)
+	}
+	return ParseBootstrap(data)
+}
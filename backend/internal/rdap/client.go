@@ -0,0 +1,280 @@
+package rdap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/ratelimit"
+)
+
+const (
+	rateLimitKind       = "rdap"
+	maxResponseBytes    = 1 << 20
+	defaultTimeout      = 10 * time.Second
+	defaultBootstrapTTL = 24 * time.Hour
+)
+
+var (
+	// ErrNoServer is returned when the bootstrap lists no RDAP service for the domain's TLD.
+	ErrNoServer = errors.New("rdap: no server for tld")
+	// ErrRateLimited is returned when every server for the domain answered 429.
+	ErrRateLimited = errors.New("rdap: rate limited by server")
+)
+
+// Config controls a Client. Zero values fall back to package defaults.
+type Config struct {
+	BootstrapSource   string        // URL or file path of the IANA bootstrap document
+	BootstrapTTL      time.Duration // how long a loaded bootstrap is reused before reloading
+	Timeout           time.Duration // per-request timeout
+	RequestsPerSecond float64       // default per-server limit; <= 0 disables it
+	Burst             int
+	UserAgent         string
+	HTTPClient        *http.Client
+}
+
+// Registration is the subset of an RDAP domain object the lookup phase persists.
+type Registration struct {
+	Domain       string
+	Registered   bool
+	Registrar    string
+	RegisteredAt *time.Time
+	ExpiresAt    *time.Time
+	Statuses     []string
+	Server       string
+}
+
+// Client resolves registration data for domains, discovering servers from the bootstrap document and
+// throttling requests per server host through the process-wide rate limit registry.
+type Client struct {
+	cfg     Config
+	http    *http.Client
+	limits  *ratelimit.Registry
+	now     func() time.Time
+	mu      sync.Mutex
+	boot    *Bootstrap
+	bootExp time.Time
+}
+
+// NewClient creates a Client; the bootstrap document is loaded on first use.
+func NewClient(cfg Config) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.BootstrapTTL <= 0 {
+		cfg.BootstrapTTL = defaultBootstrapTTL
+	}
+	if cfg.BootstrapSource == "" {
+		cfg.BootstrapSource = DefaultBootstrapSource
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: cfg.Timeout}
+	}
+	return &Client{cfg: cfg, http: httpClient, limits: ratelimit.Shared(), now: time.Now}
+}
+
+type rateLimitKey struct{}
+
+type rateLimit struct {
+	dps   float64
+	burst int
+}
+
+// WithRateLimit returns a context under which lookups use dps/burst per server instead of the
+// client's configured default. dps <= 0 leaves the default in place.
+func WithRateLimit(ctx context.Context, dps float64, burst int) context.Context {
+	if dps <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, rateLimitKey{}, rateLimit{dps: dps, burst: burst})
+}
+
+func (c *Client) limitFor(ctx context.Context) rateLimit {
+	if rl, ok := ctx.Value(rateLimitKey{}).(rateLimit); ok {
+		return rl
+	}
+	return rateLimit{dps: c.cfg.RequestsPerSecond, burst: c.cfg.Burst}
+}
+
+// bootstrap returns the cached bootstrap, reloading it once the TTL lapses. A failed reload keeps
+// serving the previous document so a flaky bootstrap host does not stall running lookups.
+func (c *Client) bootstrap(ctx context.Context) (*Bootstrap, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.boot != nil && c.now().Before(c.bootExp) {
+		return c.boot, nil
+	}
+	b, err := LoadBootstrap(ctx, c.cfg.BootstrapSource, c.http)
+	if err != nil {
+		if c.boot != nil {
+			log.Printf("RDAP: Warning - bootstrap reload from '%s' failed, keeping previous copy: %v", c.cfg.BootstrapSource, err)
+			c.bootExp = c.now().Add(time.Minute)
+			return c.boot, nil
+		}
+		return nil, err
+	}
+	c.boot, c.bootExp = b, c.now().Add(c.cfg.BootstrapTTL)
+	return b, nil
+}
+
+// Lookup queries the domain's RDAP servers in bootstrap order. A 404 from a server means the domain
+// is not registered and is reported as Registered=false rather than an error.
+func (c *Client) Lookup(ctx context.Context, domain string) (*Registration, error) {
+	domain = strings.ToLower(strings.Trim(strings.TrimSpace(domain), "."))
+	b, err := c.bootstrap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	servers := b.ServersFor(domain)
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoServer, domain)
+	}
+	limit := c.limitFor(ctx)
+	var lastErr error
+	for _, base := range servers {
+		reg, err := c.query(ctx, base, domain, limit)
+		if err == nil {
+			return reg, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (c *Client) query(ctx context.Context, base, domain string, limit rateLimit) (*Registration, error) {
+	target, err := url.Parse(base + "domain/" + url.PathEscape(domain))
+	if err != nil {
+		return nil, fmt.Errorf("rdap server %s: %w", base, err)
+	}
+	if err := c.limits.Wait(ctx, rateLimitKind, ratelimit.ScopeServer, target.Host, limit.dps, limit.burst); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/rdap+json, application/json")
+	if c.cfg.UserAgent != "" {
+		req.Header.Set("User-Agent", c.cfg.UserAgent)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rdap query %s: %w", target.Host, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return &Registration{Domain: domain, Registered: false, Server: target.Host}, nil
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return nil, fmt.Errorf("%w: %s", ErrRateLimited, target.Host)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("rdap query %s: unexpected status %d", target.Host, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("rdap query %s: %w", target.Host, err)
+	}
+	reg, err := parseDomain(body)
+	if err != nil {
+		return nil, fmt.Errorf("rdap query %s: %w", target.Host, err)
+	}
+	reg.Domain, reg.Server = domain, target.Host
+	return reg, nil
+}
+
+type domainObject struct {
+	ObjectClassName string   `json:"objectClassName"`
+	LDHName         string   `json:"ldhName"`
+	Status          []string `json:"status"`
+	Events          []struct {
+		Action string `json:"eventAction"`
+		Date   string `json:"eventDate"`
+	} `json:"events"`
+	Entities []entity `json:"entities"`
+}
+
+type entity struct {
+	Roles    []string          `json:"roles"`
+	VCard    []json.RawMessage `json:"vcardArray"`
+	Entities []entity          `json:"entities"`
+}
+
+func parseDomain(body []byte) (*Registration, error) {
+	var obj domainObject
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return nil, fmt.Errorf("decode domain object: %w", err)
+	}
+	if obj.ObjectClassName != "" && obj.ObjectClassName != "domain" {
+		return nil, fmt.Errorf("unexpected object class %q", obj.ObjectClassName)
+	}
+	reg := &Registration{Registered: true, Statuses: obj.Status}
+	for _, ev := range obj.Events {
+		ts, err := time.Parse(time.RFC3339, ev.Date)
+		if err != nil {
+			continue
+		}
+		ts = ts.UTC()
+		switch ev.Action {
+		case "registration":
+			reg.RegisteredAt = &ts
+		case "expiration":
+			reg.ExpiresAt = &ts
+		}
+	}
+	reg.Registrar = registrarName(obj.Entities)
+	return reg, nil
+}
+
+// registrarName returns the vCard "fn" of the first entity carrying the registrar role.
+func registrarName(entities []entity) string {
+	for _, e := range entities {
+		for _, role := range e.Roles {
+			if role == "registrar" {
+				if name := vcardFN(e.VCard); name != "" {
+					return name
+				}
+			}
+		}
+		if name := registrarName(e.Entities); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// vcardFN extracts the formatted name from a jCard: ["vcard", [["fn", {}, "text", "Name"], ...]].
+func vcardFN(vcard []json.RawMessage) string {
+	if len(vcard) < 2 {
+		return ""
+	}
+	var props [][]json.RawMessage
+	if err := json.Unmarshal(vcard[1], &props); err != nil {
+		return ""
+	}
+	for _, prop := range props {
+		if len(prop) < 4 {
+			continue
+		}
+		var name string
+		if err := json.Unmarshal(prop[0], &name); err != nil || name != "fn" {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(prop[3], &value); err == nil {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
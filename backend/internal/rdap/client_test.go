@@ -0,0 +1,157 @@
+package rdap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/ratelimit"
+)
+
+const registeredDomainJSON = `{
+  "objectClassName": "domain",
+  "ldhName": "EXAMPLE.TEST",
+  "status": ["client transfer prohibited"],
+  "events": [
+    {"eventAction": "registration", "eventDate": "2001-03-04T05:06:07Z"},
+    {"eventAction": "expiration", "eventDate": "2031-03-04T05:06:07Z"},
+    {"eventAction": "last changed", "eventDate": "2024-01-01T00:00:00Z"}
+  ],
+  "entities": [
+    {"roles": ["technical"], "vcardArray": ["vcard", [["fn", {}, "text", "Tech Contact"]]]},
+    {"roles": ["registrar"], "vcardArray": ["vcard", [["version", {}, "text", "4.0"], ["fn", {}, "text", "Example Registrar, Inc."]]]}
+  ]
+}`
+
+// newStandIn starts a local RDAP server answering example.test and 404 for everything else.
+func newStandIn(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits != nil {
+			atomic.AddInt32(hits, 1)
+		}
+		if r.URL.Path == "/rdap/domain/example.test" {
+			w.Header().Set("Content-Type", "application/rdap+json")
+			_, _ = w.Write([]byte(registeredDomainJSON))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func writeBootstrap(t *testing.T, services string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dns.json")
+	doc := fmt.Sprintf(`{"version":"1.0","publication":"2024-01-01T00:00:00Z","services":[%s]}`, services)
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatalf("write bootstrap: %v", err)
+	}
+	return path
+}
+
+func TestBootstrapServersForLongestMatch(t *testing.T) {
+	b, err := ParseBootstrap([]byte(`{"services":[
+		[["uk"], ["https://rdap.uk.example/"]],
+		[["co.uk"], ["http://rdap.co.example", "https://rdap.co.example/"]]
+	]}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := b.ServersFor("shop.example.co.uk"); len(got) != 2 || got[0] != "https://rdap.co.example/" || got[1] != "http://rdap.co.example/" {
+		t.Fatalf("co.uk servers = %v", got)
+	}
+	if got := b.ServersFor("example.uk"); len(got) != 1 || got[0] != "https://rdap.uk.example/" {
+		t.Fatalf("uk servers = %v", got)
+	}
+	if got := b.ServersFor("example.com"); got != nil {
+		t.Fatalf("expected no servers for .com, got %v", got)
+	}
+}
+
+func TestParseBootstrapRejectsMalformed(t *testing.T) {
+	if _, err := ParseBootstrap([]byte(`{"services":[[["com"]]]}`)); err == nil {
+		t.Fatal("expected error for service without urls")
+	}
+	if _, err := ParseBootstrap([]byte(`{"services":[]}`)); err == nil {
+		t.Fatal("expected error for empty bootstrap")
+	}
+}
+
+func TestClientLookupAgainstStandIn(t *testing.T) {
+	srv := newStandIn(t, nil)
+	c := NewClient(Config{BootstrapSource: writeBootstrap(t, fmt.Sprintf(`[["test"], [%q]]`, srv.URL+"/rdap"))})
+
+	reg, err := c.Lookup(context.Background(), "Example.Test.")
+	if err != nil {
+		t.Fatalf("lookup registered: %v", err)
+	}
+	if !reg.Registered || reg.Registrar != "Example Registrar, Inc." {
+		t.Fatalf("unexpected registration: %+v", reg)
+	}
+	if reg.RegisteredAt == nil || !reg.RegisteredAt.Equal(time.Date(2001, 3, 4, 5, 6, 7, 0, time.UTC)) {
+		t.Fatalf("registered at = %v", reg.RegisteredAt)
+	}
+	if reg.ExpiresAt == nil || reg.ExpiresAt.Year() != 2031 {
+		t.Fatalf("expires at = %v", reg.ExpiresAt)
+	}
+
+	reg, err = c.Lookup(context.Background(), "available.test")
+	if err != nil {
+		t.Fatalf("lookup unregistered: %v", err)
+	}
+	if reg.Registered {
+		t.Fatalf("expected 404 to mean unregistered, got %+v", reg)
+	}
+
+	if _, err := c.Lookup(context.Background(), "example.invalid"); !errors.Is(err, ErrNoServer) {
+		t.Fatalf("expected ErrNoServer, got %v", err)
+	}
+}
+
+func TestClientFallsBackToNextServer(t *testing.T) {
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+	srv := newStandIn(t, nil)
+	c := NewClient(Config{BootstrapSource: writeBootstrap(t, fmt.Sprintf(`[["test"], [%q, %q]]`, broken.URL+"/rdap", srv.URL+"/rdap"))})
+
+	reg, err := c.Lookup(context.Background(), "example.test")
+	if err != nil {
+		t.Fatalf("lookup: %v", err)
+	}
+	if !strings.HasPrefix(srv.URL, "http://"+reg.Server) {
+		t.Fatalf("expected answer from second server, got %s", reg.Server)
+	}
+}
+
+func TestClientRateLimitsPerServer(t *testing.T) {
+	var hits int32
+	srv := newStandIn(t, &hits)
+	c := NewClient(Config{BootstrapSource: writeBootstrap(t, fmt.Sprintf(`[["test"], [%q]]`, srv.URL+"/rdap"))})
+	c.limits = ratelimit.NewRegistry()
+
+	ctx := WithRateLimit(context.Background(), 10, 1)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.Lookup(ctx, "example.test"); err != nil {
+			t.Fatalf("lookup %d: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("expected lookups to be throttled to 10/s, took %v", elapsed)
+	}
+	if atomic.LoadInt32(&hits) != 3 {
+		t.Fatalf("expected 3 requests, got %d", hits)
+	}
+}
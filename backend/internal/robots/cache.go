@@ -0,0 +1,306 @@
+package robots
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// DefaultTTL is how long a fetched robots.txt is trusted.
+	DefaultTTL = time.Hour
+	// DefaultMaxCrawlDelay caps the Crawl-delay a site can impose on one fetch.
+	DefaultMaxCrawlDelay = 10 * time.Second
+
+	// unreachableTTL keeps an unreachable host disallowed briefly before retrying its robots.txt.
+	unreachableTTL = 5 * time.Minute
+	fetchTimeout   = 10 * time.Second
+	maxEntries     = 10000
+)
+
+var (
+	skippedURLs = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "robots_disallowed_urls_total",
+			Help: "URLs not fetched because robots.txt disallows them",
+		},
+		[]string{"kind"},
+	)
+	robotsFetches = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "robots_fetches_total",
+			Help: "robots.txt fetches by outcome",
+		},
+		[]string{"outcome"},
+	)
+	crawlDelaySeconds = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "robots_crawl_delay_seconds_total",
+			Help: "Time spent waiting to honour Crawl-delay",
+		},
+		[]string{"kind"},
+	)
+)
+
+// Options configures a Cache.
+type Options struct {
+	TTL           time.Duration
+	MaxCrawlDelay time.Duration
+	Client        *http.Client // Defaults to a client with a short timeout and at most 5 redirects
+}
+
+// Cache holds robots.txt rules per scheme and host. Concurrent lookups for the same host share one
+// fetch.
+type Cache struct {
+	mu        sync.Mutex
+	opts      Options
+	entries   map[string]*entry
+	nextFetch map[string]time.Time // host and agent -> earliest time Crawl-delay allows the next fetch
+	policy    Policy               // applies to contexts without a policy of their own
+	now       func() time.Time
+}
+
+type entry struct {
+	ready   chan struct{}
+	rules   *Rules
+	expires time.Time
+}
+
+// NewCache creates an empty cache.
+func NewCache(opts Options) *Cache {
+	c := &Cache{entries: make(map[string]*entry), nextFetch: make(map[string]time.Time), policy: Policy{Respect: true}, now: time.Now}
+	c.Configure(opts)
+	return c
+}
+
+var shared = NewCache(Options{})
+
+// Shared returns the process-wide cache.
+func Shared() *Cache { return shared }
+
+// Configure replaces the cache's options; zero values fall back to the defaults. Cached entries keep
+// their original expiry.
+func (c *Cache) Configure(opts Options) {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultTTL
+	}
+	if opts.MaxCrawlDelay <= 0 {
+		opts.MaxCrawlDelay = DefaultMaxCrawlDelay
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{
+			Timeout: fetchTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 5 {
+					return errors.New("too many robots.txt redirects")
+				}
+				return nil
+			},
+		}
+	}
+	c.mu.Lock()
+	c.opts = opts
+	c.mu.Unlock()
+}
+
+// Rules returns the robots.txt rules for the scheme and host of target, fetching them when missing
+// or expired. A missing robots.txt (4xx) allows everything; an unreachable one (5xx or network
+// error) disallows everything.
+func (c *Cache) Rules(ctx context.Context, target *url.URL, userAgent string) (*Rules, error) {
+	if target.Host == "" {
+		return nil, fmt.Errorf("robots: URL %q has no host", target)
+	}
+	key := strings.ToLower(target.Scheme + "://" + target.Host)
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok {
+		select {
+		case <-e.ready:
+			if c.now().After(e.expires) {
+				ok = false
+			}
+		default:
+		}
+	}
+	if !ok {
+		if len(c.entries) >= maxEntries {
+			c.evictExpiredLocked()
+		}
+		e = &entry{ready: make(chan struct{})}
+		c.entries[key] = e
+		opts := c.opts
+		c.mu.Unlock()
+		// Detached from ctx so one caller's cancellation does not poison the entry for everyone else.
+		rules, ttl := fetch(context.WithoutCancel(ctx), opts, key, userAgent)
+		c.mu.Lock()
+		e.rules, e.expires = rules, c.now().Add(ttl)
+		c.mu.Unlock()
+		close(e.ready)
+		return rules, nil
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-e.ready:
+		return e.rules, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Cache) evictExpiredLocked() {
+	now := c.now()
+	for k, e := range c.entries {
+		select {
+		case <-e.ready:
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		default:
+		}
+	}
+	for k, t := range c.nextFetch {
+		if now.After(t) {
+			delete(c.nextFetch, k)
+		}
+	}
+}
+
+func fetch(ctx context.Context, opts Options, origin, userAgent string) (*Rules, time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		robotsFetches.WithLabelValues("unreachable").Inc()
+		return disallowAll(), unreachableTTL
+	}
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+	resp, err := opts.Client.Do(req)
+	if err != nil {
+		robotsFetches.WithLabelValues("unreachable").Inc()
+		return disallowAll(), min(opts.TTL, unreachableTTL)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxRobotsBytes))
+		if err != nil {
+			robotsFetches.WithLabelValues("unreachable").Inc()
+			return disallowAll(), min(opts.TTL, unreachableTTL)
+		}
+		robotsFetches.WithLabelValues("ok").Inc()
+		return Parse(body), opts.TTL
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		robotsFetches.WithLabelValues("missing").Inc()
+		return allowAll(), opts.TTL
+	default:
+		robotsFetches.WithLabelValues("unreachable").Inc()
+		return disallowAll(), min(opts.TTL, unreachableTTL)
+	}
+}
+
+// Allowed reports whether userAgent may fetch rawURL.
+func (c *Cache) Allowed(ctx context.Context, rawURL, userAgent string) (bool, *Group, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false, nil, err
+	}
+	rules, err := c.Rules(ctx, u, userAgent)
+	if err != nil {
+		return false, nil, err
+	}
+	g := rules.ForAgent(userAgent)
+	return g.Allowed(requestPath(u)), g, nil
+}
+
+// Admit reports whether userAgent may fetch rawURL and, if so, waits until the host's Crawl-delay
+// (capped at MaxCrawlDelay) permits the fetch. kind names the caller in the skip and delay counters.
+func (c *Cache) Admit(ctx context.Context, kind, rawURL, userAgent string) (bool, error) {
+	allowed, g, err := c.Allowed(ctx, rawURL, userAgent)
+	if err != nil {
+		return false, err
+	}
+	if !allowed {
+		skippedURLs.WithLabelValues(kind).Inc()
+		return false, nil
+	}
+	if g.CrawlDelay <= 0 {
+		return true, nil
+	}
+
+	u, _ := url.Parse(rawURL)
+	key := strings.ToLower(u.Host + "|" + userAgent)
+	c.mu.Lock()
+	delay := min(g.CrawlDelay, c.opts.MaxCrawlDelay)
+	now := c.now()
+	start := now
+	if next, ok := c.nextFetch[key]; ok && next.After(now) {
+		start = next
+	}
+	c.nextFetch[key] = start.Add(delay)
+	c.mu.Unlock()
+
+	wait := start.Sub(now)
+	if wait <= 0 {
+		return true, nil
+	}
+	crawlDelaySeconds.WithLabelValues(kind).Add(wait.Seconds())
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// Policy says whether fetches made under a context must honour robots.txt, and as which user agent.
+type Policy struct {
+	Respect     bool   // Check robots.txt before crawling secondary pages
+	EnforceRoot bool   // Also check it before the root page fetch
+	UserAgent   string // User agent whose group applies; empty uses the fetcher's own
+}
+
+type policyKey struct{}
+
+// WithPolicy returns a context carrying p, e.g. a campaign's robots override.
+func WithPolicy(ctx context.Context, p Policy) context.Context {
+	return context.WithValue(ctx, policyKey{}, p)
+}
+
+// PolicyFrom returns the policy attached to ctx, if any.
+func PolicyFrom(ctx context.Context) (Policy, bool) {
+	p, ok := ctx.Value(policyKey{}).(Policy)
+	return p, ok
+}
+
+// SetDefaultPolicy sets the policy used for contexts that carry none. New caches respect robots.txt
+// for crawls but not for root fetches.
+func (c *Cache) SetDefaultPolicy(p Policy) {
+	c.mu.Lock()
+	c.policy = p
+	c.mu.Unlock()
+}
+
+// PolicyFor returns the policy attached to ctx, or the cache's default.
+func (c *Cache) PolicyFor(ctx context.Context) Policy {
+	if p, ok := PolicyFrom(ctx); ok {
+		return p
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.policy
+}
@@ -0,0 +1,191 @@
+// Package robots parses robots.txt files and caches them per host so crawlers can honour Allow,
+// Disallow and Crawl-delay rules for the user agent they fetch with.
+package robots
+
+import (
+	"bufio"
+	"bytes"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRobotsBytes is the portion of a robots.txt that is parsed (RFC 9309 requires at least 500 KiB).
+const maxRobotsBytes = 512 * 1024
+
+// Rules is a parsed robots.txt.
+type Rules struct {
+	groups   []group
+	Sitemaps []string // Absolute sitemap URLs listed in the file
+}
+
+type group struct {
+	agents     []string // lowercased product tokens, "*" for the default group
+	rules      []rule
+	crawlDelay time.Duration
+}
+
+type rule struct {
+	pattern string
+	allow   bool
+}
+
+// Group is the merged set of rules that applies to one user agent.
+type Group struct {
+	rules      []rule
+	CrawlDelay time.Duration
+}
+
+// allowAll is used when robots.txt is missing: everything may be crawled.
+func allowAll() *Rules { return &Rules{} }
+
+// disallowAll is used when robots.txt is unreachable: nothing may be crawled until it can be read.
+func disallowAll() *Rules {
+	return &Rules{groups: []group{{agents: []string{"*"}, rules: []rule{{pattern: "/"}}}}}
+}
+
+// Parse reads a robots.txt body. Unknown lines are ignored, as are rules appearing before any
+// User-agent line.
+func Parse(body []byte) *Rules {
+	if len(body) > maxRobotsBytes {
+		body = body[:maxRobotsBytes]
+	}
+	r := &Rules{}
+	var cur *group
+	lastWasAgent := false
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRobotsBytes)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		switch key {
+		case "user-agent":
+			if !lastWasAgent || cur == nil {
+				r.groups = append(r.groups, group{})
+				cur = &r.groups[len(r.groups)-1]
+			}
+			cur.agents = append(cur.agents, strings.ToLower(value))
+			lastWasAgent = true
+			continue
+		case "allow", "disallow":
+			// An empty Disallow allows everything, which is the same as having no rule.
+			if cur != nil && value != "" {
+				cur.rules = append(cur.rules, rule{pattern: value, allow: key == "allow"})
+			}
+		case "crawl-delay":
+			if cur != nil {
+				if secs, err := strconv.ParseFloat(value, 64); err == nil && secs > 0 {
+					cur.crawlDelay = time.Duration(secs * float64(time.Second))
+				}
+			}
+		case "sitemap":
+			if u, err := url.Parse(value); err == nil && u.IsAbs() {
+				r.Sitemaps = append(r.Sitemaps, value)
+			}
+		}
+		lastWasAgent = false
+	}
+	return r
+}
+
+// ForAgent returns the rules for userAgent: the groups naming the longest token contained in the
+// user agent, or the "*" groups when none match. The result is never nil.
+func (r *Rules) ForAgent(userAgent string) *Group {
+	ua := strings.ToLower(userAgent)
+	best := -1
+	for _, g := range r.groups {
+		for _, a := range g.agents {
+			if a != "*" && a != "" && strings.Contains(ua, a) && len(a) > best {
+				best = len(a)
+			}
+		}
+	}
+	out := &Group{}
+	for _, g := range r.groups {
+		matched := false
+		for _, a := range g.agents {
+			if (best < 0 && a == "*") || (best >= 0 && len(a) == best && strings.Contains(ua, a)) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			out.rules = append(out.rules, g.rules...)
+			if g.crawlDelay > out.CrawlDelay {
+				out.CrawlDelay = g.crawlDelay
+			}
+		}
+	}
+	return out
+}
+
+// Allowed reports whether the URL path (with query) may be fetched. The longest matching pattern
+// wins and Allow wins ties; /robots.txt itself is always allowed.
+func (g *Group) Allowed(pathAndQuery string) bool {
+	if pathAndQuery == "" {
+		pathAndQuery = "/"
+	}
+	if pathAndQuery == "/robots.txt" {
+		return true
+	}
+	allowed, bestLen := true, -1
+	for _, rl := range g.rules {
+		if !matchPattern(rl.pattern, pathAndQuery) {
+			continue
+		}
+		if n := len(rl.pattern); n > bestLen || (n == bestLen && rl.allow) {
+			allowed, bestLen = rl.allow, n
+		}
+	}
+	return allowed
+}
+
+// matchPattern matches a robots path pattern, where * matches any run of characters and a trailing $
+// anchors the end, against the start of path.
+func matchPattern(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	if anchored {
+		pattern = pattern[:len(pattern)-1]
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	pos := len(parts[0])
+	if len(parts) == 1 {
+		return !anchored || pos == len(path)
+	}
+	for i := 1; i < len(parts); i++ {
+		part := parts[i]
+		if anchored && i == len(parts)-1 {
+			return len(path)-pos >= len(part) && strings.HasSuffix(path, part)
+		}
+		idx := strings.Index(path[pos:], part)
+		if idx < 0 {
+			return false
+		}
+		pos += idx + len(part)
+	}
+	return true
+}
+
+// requestPath returns the part of u that robots rules are matched against.
+func requestPath(u *url.URL) string {
+	p := u.EscapedPath()
+	if p == "" {
+		p = "/"
+	}
+	if u.RawQuery != "" {
+		p += "?" + u.RawQuery
+	}
+	return p
+}
@@ -0,0 +1,164 @@
+package robots
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const sample = `# comment
+User-agent: *
+Disallow: /private/
+Allow: /private/press
+Disallow: /*.pdf$
+Crawl-delay: 2
+
+User-agent: DomainFlowBot
+User-agent: other-bot
+Disallow: /
+Allow: /public
+
+User-agent: DomainFlowBot-News
+Disallow:
+
+Sitemap: https://example.com/sitemap.xml
+Sitemap: /relative.xml
+`
+
+func TestGroupAllowed(t *testing.T) {
+	rules := Parse([]byte(sample))
+	cases := []struct {
+		ua, path string
+		want     bool
+	}{
+		{"Mozilla/5.0", "/", true},
+		{"Mozilla/5.0", "/private/secret", false},
+		{"Mozilla/5.0", "/private/press/2024", true},
+		{"Mozilla/5.0", "/files/report.pdf", false},
+		{"Mozilla/5.0", "/files/report.pdf?download=1", true},
+		{"Mozilla/5.0", "/robots.txt", true},
+		{"Mozilla/5.0 (compatible; DomainFlowBot/1.0)", "/", false},
+		{"Mozilla/5.0 (compatible; DomainFlowBot/1.0)", "/public/page", true},
+		{"Other-Bot/2", "/private/press", false},
+		// The longest matching agent token wins, and its empty Disallow allows everything.
+		{"DomainFlowBot-News/1.0", "/anything", true},
+	}
+	for _, tc := range cases {
+		if got := rules.ForAgent(tc.ua).Allowed(tc.path); got != tc.want {
+			t.Errorf("ForAgent(%q).Allowed(%q) = %v, want %v", tc.ua, tc.path, got, tc.want)
+		}
+	}
+	if d := rules.ForAgent("curl/8").CrawlDelay; d != 2*time.Second {
+		t.Errorf("expected 2s crawl delay for the default group, got %v", d)
+	}
+	if len(rules.Sitemaps) != 1 || rules.Sitemaps[0] != "https://example.com/sitemap.xml" {
+		t.Errorf("expected only the absolute sitemap, got %v", rules.Sitemaps)
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	cases := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"/a", "/abc", true},
+		{"/a$", "/abc", false},
+		{"/a$", "/a", true},
+		{"/*/edit", "/posts/1/edit", true},
+		{"/*/edit$", "/posts/1/edit?x", false},
+		{"/*.php*", "/index.php?q=1", true},
+		{"*", "/", true},
+		{"/b", "/abc", false},
+	}
+	for _, tc := range cases {
+		if got := matchPattern(tc.pattern, tc.path); got != tc.want {
+			t.Errorf("matchPattern(%q, %q) = %v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestCacheFetchOutcomes(t *testing.T) {
+	var fetches int32
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+		}
+		atomic.AddInt32(&fetches, 1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("User-agent: *\nDisallow: /blocked\n"))
+	}))
+	defer srv.Close()
+
+	c := NewCache(Options{TTL: time.Minute})
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if ok, _, err := c.Allowed(ctx, srv.URL+"/blocked/page", "test"); err != nil || ok {
+		t.Fatalf("expected /blocked to be disallowed, got %v, %v", ok, err)
+	}
+	if ok, _, _ := c.Allowed(ctx, srv.URL+"/open", "test"); !ok {
+		t.Fatal("expected /open to be allowed")
+	}
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Fatalf("expected robots.txt to be cached, fetched %d times", n)
+	}
+
+	// A missing robots.txt allows everything once the cached copy expires.
+	status = http.StatusNotFound
+	now = now.Add(2 * time.Minute)
+	if ok, _, _ := c.Allowed(ctx, srv.URL+"/blocked/page", "test"); !ok {
+		t.Fatal("expected a 404 robots.txt to allow everything")
+	}
+
+	// A failing robots.txt disallows everything.
+	status = http.StatusServiceUnavailable
+	now = now.Add(2 * time.Minute)
+	if ok, _, _ := c.Allowed(ctx, srv.URL+"/open", "test"); ok {
+		t.Fatal("expected a 503 robots.txt to disallow everything")
+	}
+	if n := atomic.LoadInt32(&fetches); n != 3 {
+		t.Fatalf("expected 3 robots.txt fetches, got %d", n)
+	}
+}
+
+func TestAdmitHonoursCappedCrawlDelay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("User-agent: *\nCrawl-delay: 3600\nDisallow: /nope\n"))
+	}))
+	defer srv.Close()
+	c := NewCache(Options{MaxCrawlDelay: 50 * time.Millisecond})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if ok, err := c.Admit(ctx, "test", srv.URL+"/page", "test"); !ok || err != nil {
+			t.Fatalf("expected admission, got %v, %v", ok, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("expected two capped delays between three fetches, took %v", elapsed)
+	}
+	if ok, _ := c.Admit(ctx, "test", srv.URL+"/nope", "test"); ok {
+		t.Error("expected a disallowed URL to be refused")
+	}
+}
+
+func TestPolicyFor(t *testing.T) {
+	c := NewCache(Options{})
+	if p := c.PolicyFor(context.Background()); !p.Respect || p.EnforceRoot {
+		t.Errorf("unexpected default policy %+v", p)
+	}
+	c.SetDefaultPolicy(Policy{Respect: false})
+	ctx := WithPolicy(context.Background(), Policy{Respect: true, EnforceRoot: true, UserAgent: "ua"})
+	if p := c.PolicyFor(ctx); !p.EnforceRoot || p.UserAgent != "ua" {
+		t.Errorf("expected the context policy, got %+v", p)
+	}
+	if p := c.PolicyFor(context.Background()); p.Respect {
+		t.Errorf("expected the configured default, got %+v", p)
+	}
+}
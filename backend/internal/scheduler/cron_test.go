@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronRejectsInvalidExpressions(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("expected %q to be rejected", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	base := time.Date(2025, time.March, 14, 10, 7, 30, 0, time.UTC) // Friday
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 3, 14, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2025, 3, 17, 9, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"30 2 1 jan,jul *", time.Date(2025, 7, 1, 2, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 20th OR a Saturday, whichever comes first.
+		{"0 0 20 * sat", time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		c, err := ParseCron(tc.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.expr, err)
+		}
+		if got := c.Next(base); !got.Equal(tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.expr, tc.want, got)
+		}
+	}
+}
+
+func TestNextRunUsesScheduleTimeZone(t *testing.T) {
+	after := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+	next, err := NextRun("0 9 * * *", "America/New_York", after)
+	if err != nil {
+		t.Fatalf("next run: %v", err)
+	}
+	// 09:00 EDT is 13:00 UTC.
+	if want := time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, next)
+	}
+	if _, err := NextRun("0 9 * * *", "Mars/Olympus", after); err == nil {
+		t.Fatal("expected unknown time zone to be rejected")
+	}
+	if _, err := NextRun("0 0 30 2 *", "UTC", after); err == nil {
+		t.Fatal("expected expression that never matches to be rejected")
+	}
+}
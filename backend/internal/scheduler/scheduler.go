@@ -0,0 +1,203 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+)
+
+// CampaignRunner starts scheduled work; cmd/apiserver implements it on top of the campaign orchestrator.
+type CampaignRunner interface {
+	RestartCampaign(ctx context.Context, campaignID uuid.UUID) error
+	StartPhase(ctx context.Context, campaignID uuid.UUID, phase models.PhaseTypeEnum) error
+	// ActivePhase returns a phase of the campaign that is still running or paused, if any. An error means
+	// the phase state is unknown and the run is skipped.
+	ActivePhase(ctx context.Context, campaignID uuid.UUID) (models.PhaseTypeEnum, bool, error)
+}
+
+// Config tunes schedule polling.
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int // schedules claimed per poll
+	// Lease bounds how long a claimed schedule stays locked if the scheduler dies while triggering it.
+	Lease      time.Duration
+	RunTimeout time.Duration // timeout for triggering a single run
+	// StartupDelay gives in-flight phase restoration a head start so overlap checks see restored phases.
+	StartupDelay time.Duration
+}
+
+// DefaultConfig polls every 30 seconds.
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: 30 * time.Second,
+		BatchSize:    20,
+		Lease:        5 * time.Minute,
+		RunTimeout:   2 * time.Minute,
+		StartupDelay: 10 * time.Second,
+	}
+}
+
+// Scheduler fires due campaign schedules.
+type Scheduler struct {
+	store  store.CampaignScheduleStore
+	runner CampaignRunner
+	cfg    Config
+	now    func() time.Time
+
+	startOnce sync.Once
+}
+
+// New creates a scheduler; zero config fields fall back to DefaultConfig.
+func New(st store.CampaignScheduleStore, runner CampaignRunner, cfg Config) *Scheduler {
+	def := DefaultConfig()
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = def.PollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = def.BatchSize
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = def.Lease
+	}
+	if cfg.RunTimeout <= 0 {
+		cfg.RunTimeout = def.RunTimeout
+	}
+	if cfg.StartupDelay < 0 {
+		cfg.StartupDelay = 0
+	}
+	return &Scheduler{store: st, runner: runner, cfg: cfg, now: time.Now}
+}
+
+// NextRun validates a cron expression and time zone and returns the first run after the given time, in UTC.
+func NextRun(cronExpr, timezone string, after time.Time) (time.Time, error) {
+	c, err := ParseCron(cronExpr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	next := c.Next(after.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q never matches", cronExpr)
+	}
+	return next.UTC(), nil
+}
+
+// LoadLocation resolves an IANA time zone name; empty means UTC.
+func LoadLocation(timezone string) (*time.Location, error) {
+	if strings.TrimSpace(timezone) == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", timezone)
+	}
+	return loc, nil
+}
+
+// Start launches the poll loop; the first poll runs after StartupDelay so runs missed while the
+// server was down fire once. It stops when ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.startOnce.Do(func() {
+		go s.loop(ctx)
+	})
+}
+
+func (s *Scheduler) loop(ctx context.Context) {
+	if s.cfg.StartupDelay > 0 {
+		timer := time.NewTimer(s.cfg.StartupDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.RunDue(ctx); err != nil {
+			log.Printf("[scheduler] poll failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue claims due schedules and fires them, returning how many were processed.
+func (s *Scheduler) RunDue(ctx context.Context) (int, error) {
+	due, err := s.store.ClaimDueCampaignSchedules(ctx, nil, s.now(), s.cfg.BatchSize, s.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+	for _, sched := range due {
+		s.fire(ctx, sched)
+	}
+	return len(due), nil
+}
+
+// fire triggers one claimed schedule and records the outcome. The next run is computed from now rather
+// than from the missed slot, so a long outage produces a single catch-up run instead of a burst.
+func (s *Scheduler) fire(ctx context.Context, sched *models.CampaignSchedule) {
+	now := s.now()
+	var nextPtr *time.Time
+	next, nextErr := NextRun(sched.CronExpression, sched.Timezone, now)
+	if nextErr == nil {
+		nextPtr = &next
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, s.cfg.RunTimeout)
+	status, runErr := s.trigger(runCtx, sched)
+	cancel()
+
+	lastError := ""
+	switch {
+	case runErr != nil:
+		lastError = runErr.Error()
+	case nextErr != nil:
+		// The expression was valid when saved; the run happened but no further run can be computed.
+		lastError = "no further runs: " + nextErr.Error()
+	}
+	if err := s.store.RecordCampaignScheduleRun(ctx, nil, sched, now, status, lastError, nextPtr); err != nil {
+		log.Printf("[scheduler] record run failed schedule=%s campaign=%s err=%v", sched.ID, sched.CampaignID, err)
+	}
+	log.Printf("[scheduler] schedule fired schedule=%s campaign=%s action=%s status=%s next=%v err=%q",
+		sched.ID, sched.CampaignID, sched.Action, status, nextPtr, lastError)
+}
+
+func (s *Scheduler) trigger(ctx context.Context, sched *models.CampaignSchedule) (models.CampaignScheduleRunStatusEnum, error) {
+	// An unknown phase state may hide a running phase, so the run is skipped rather than risking an overlap.
+	if phase, active, err := s.runner.ActivePhase(ctx, sched.CampaignID); err != nil {
+		return models.CampaignScheduleRunSkippedOverlap, fmt.Errorf("check active phases: %w", err)
+	} else if active {
+		return models.CampaignScheduleRunSkippedOverlap, fmt.Errorf("previous run still active (phase %s)", phase)
+	}
+	var err error
+	switch sched.Action {
+	case models.CampaignScheduleActionRestart:
+		err = s.runner.RestartCampaign(ctx, sched.CampaignID)
+	case models.CampaignScheduleActionPhase:
+		if sched.Phase == nil {
+			return models.CampaignScheduleRunFailed, fmt.Errorf("phase schedule has no phase")
+		}
+		err = s.runner.StartPhase(ctx, sched.CampaignID, *sched.Phase)
+	default:
+		return models.CampaignScheduleRunFailed, fmt.Errorf("unknown schedule action %q", sched.Action)
+	}
+	if err != nil {
+		return models.CampaignScheduleRunFailed, err
+	}
+	return models.CampaignScheduleRunTriggered, nil
+}
@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+)
+
+func TestRunDueRecordsOutcomes(t *testing.T) {
+	now := time.Date(2025, time.May, 5, 8, 0, 0, 0, time.UTC)
+	dns := models.PhaseTypeDNSValidation
+	restart := &models.CampaignSchedule{ID: uuid.New(), CampaignID: uuid.New(), CronExpression: "0 * * * *", Action: models.CampaignScheduleActionRestart}
+	busy := &models.CampaignSchedule{ID: uuid.New(), CampaignID: uuid.New(), CronExpression: "0 * * * *", Action: models.CampaignScheduleActionRestart}
+	phase := &models.CampaignSchedule{ID: uuid.New(), CampaignID: uuid.New(), CronExpression: "0 * * * *", Action: models.CampaignScheduleActionPhase, Phase: &dns}
+	unknown := &models.CampaignSchedule{ID: uuid.New(), CampaignID: uuid.New(), CronExpression: "0 * * * *", Action: models.CampaignScheduleActionRestart}
+
+	st := &fakeScheduleStore{due: []*models.CampaignSchedule{restart, busy, phase, unknown}}
+	runner := &fakeRunner{
+		active:    map[uuid.UUID]bool{busy.CampaignID: true},
+		activeErr: map[uuid.UUID]error{unknown.CampaignID: errors.New("status unavailable")},
+		phaseErr:  errors.New("phase not configured"),
+	}
+	s := New(st, runner, Config{})
+	s.now = func() time.Time { return now }
+
+	n, err := s.RunDue(context.Background())
+	if err != nil || n != 4 {
+		t.Fatalf("expected 4 schedules processed, got %d err=%v", n, err)
+	}
+	if len(runner.restarted) != 1 || runner.restarted[0] != restart.CampaignID {
+		t.Fatalf("expected only the idle campaign restarted, got %v", runner.restarted)
+	}
+	want := map[uuid.UUID]models.CampaignScheduleRunStatusEnum{
+		restart.ID: models.CampaignScheduleRunTriggered,
+		busy.ID:    models.CampaignScheduleRunSkippedOverlap,
+		phase.ID:   models.CampaignScheduleRunFailed,
+		unknown.ID: models.CampaignScheduleRunSkippedOverlap,
+	}
+	nextHour := now.Add(time.Hour)
+	for id, status := range want {
+		rec, ok := st.runs[id]
+		if !ok {
+			t.Fatalf("schedule %s: no run recorded", id)
+		}
+		if rec.status != status {
+			t.Errorf("schedule %s: expected status %s, got %s", id, status, rec.status)
+		}
+		if rec.next == nil || !rec.next.Equal(nextHour) {
+			t.Errorf("schedule %s: expected next run %v, got %v", id, nextHour, rec.next)
+		}
+	}
+	if st.runs[phase.ID].lastError != "phase not configured" {
+		t.Errorf("expected runner error recorded, got %q", st.runs[phase.ID].lastError)
+	}
+	if st.runs[unknown.ID].lastError != "check active phases: status unavailable" {
+		t.Errorf("expected active phase check error recorded, got %q", st.runs[unknown.ID].lastError)
+	}
+}
+
+type recordedRun struct {
+	status    models.CampaignScheduleRunStatusEnum
+	lastError string
+	next      *time.Time
+}
+
+type fakeScheduleStore struct {
+	store.CampaignScheduleStore
+	due  []*models.CampaignSchedule
+	runs map[uuid.UUID]recordedRun
+}
+
+func (f *fakeScheduleStore) ClaimDueCampaignSchedules(context.Context, store.Querier, time.Time, int, time.Duration) ([]*models.CampaignSchedule, error) {
+	due := f.due
+	f.due = nil
+	return due, nil
+}
+
+func (f *fakeScheduleStore) RecordCampaignScheduleRun(_ context.Context, _ store.Querier, claimed *models.CampaignSchedule, _ time.Time, status models.CampaignScheduleRunStatusEnum, lastError string, next *time.Time) error {
+	if f.runs == nil {
+		f.runs = map[uuid.UUID]recordedRun{}
+	}
+	f.runs[claimed.ID] = recordedRun{status: status, lastError: lastError, next: next}
+	return nil
+}
+
+type fakeRunner struct {
+	active    map[uuid.UUID]bool
+	activeErr map[uuid.UUID]error
+	phaseErr  error
+	restarted []uuid.UUID
+}
+
+func (f *fakeRunner) RestartCampaign(_ context.Context, id uuid.UUID) error {
+	f.restarted = append(f.restarted, id)
+	return nil
+}
+
+func (f *fakeRunner) StartPhase(context.Context, uuid.UUID, models.PhaseTypeEnum) error {
+	return f.phaseErr
+}
+
+func (f *fakeRunner) ActivePhase(_ context.Context, id uuid.UUID) (models.PhaseTypeEnum, bool, error) {
+	if err := f.activeErr[id]; err != nil {
+		return "", false, err
+	}
+	if f.active[id] {
+		return models.PhaseTypeHTTPKeywordValidation, true, nil
+	}
+	return "", false, nil
+}
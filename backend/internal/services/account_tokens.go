@@ -0,0 +1,25 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// NewAccountToken returns a random token for an emailed link (password reset, email verification)
+// and the hash to store in its place, so a database leak does not expose usable tokens.
+func NewAccountToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashAccountToken(token), nil
+}
+
+// HashAccountToken returns the hex SHA-256 of token as stored by NewAccountToken.
+func HashAccountToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// APIKeyPrefix marks personal API keys so they are recognisable in Authorization headers and secret scanners
+const APIKeyPrefix = "dfk_"
+
 // APIKeyService handles API key generation, validation, and rotation
 type APIKeyService struct {
 	encryptionService *EncryptionService
@@ -45,7 +48,7 @@ func (s *APIKeyService) GenerateAPIKey() (string, error) {
 	}
 
 	// Convert to hex string
-	return hex.EncodeToString(bytes), nil
+	return APIKeyPrefix + hex.EncodeToString(bytes), nil
 }
 
 // HashAPIKey creates a SHA256 hash of the API key
@@ -4,9 +4,14 @@ package services
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base32"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/pquerna/otp"
@@ -25,6 +30,18 @@ func NewMFAService(issuer string) *MFAService {
 	}
 }
 
+// totpOpts are the parameters every enrolled secret uses. Authenticator apps widely ignore the
+// algorithm in the otpauth URL and always compute SHA-1 codes, so SHA-1 is the only safe choice.
+var totpOpts = totp.ValidateOpts{
+	Period:    30,
+	Skew:      1,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// BackupCodeCount is how many single-use backup codes an enrollment issues.
+const BackupCodeCount = 8
+
 // TOTPSecret represents a TOTP secret for a user
 type TOTPSecret struct {
 	UserID      string    `json:"userId"`
@@ -40,15 +57,17 @@ func (s *MFAService) GenerateTOTPSecret(userID, userEmail string) (*TOTPSecret,
 	key, err := totp.Generate(totp.GenerateOpts{
 		Issuer:      s.issuer,
 		AccountName: userEmail,
-		SecretSize:  32,
-		Algorithm:   otp.AlgorithmSHA256,
+		SecretSize:  20,
+		Period:      totpOpts.Period,
+		Digits:      totpOpts.Digits,
+		Algorithm:   totpOpts.Algorithm,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate TOTP key: %w", err)
 	}
 
 	// Generate backup codes
-	backupCodes, err := s.generateBackupCodes(8)
+	backupCodes, err := s.generateBackupCodes(BackupCodeCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate backup codes: %w", err)
 	}
@@ -62,20 +81,46 @@ func (s *MFAService) GenerateTOTPSecret(userID, userEmail string) (*TOTPSecret,
 	}, nil
 }
 
-// VerifyTOTP verifies a TOTP token
+// VerifyTOTP verifies a TOTP token, accepting the neighbouring 30-second steps for clock drift
 func (s *MFAService) VerifyTOTP(secret, token string) (bool, error) {
-	return totp.Validate(token, secret), nil
+	token = strings.ReplaceAll(strings.TrimSpace(token), " ", "")
+	return totp.ValidateCustom(token, secret, time.Now(), totpOpts)
+}
+
+// VerifyTOTPStep verifies a TOTP token like VerifyTOTP but only accepts time steps after lastStep,
+// returning the step the token matched. Callers persist that step so an accepted code, or any code
+// older than it, cannot be used again.
+func (s *MFAService) VerifyTOTPStep(secret, token string, lastStep int64) (int64, bool) {
+	return verifyTOTPStepAt(secret, token, lastStep, time.Now())
+}
+
+func verifyTOTPStepAt(secret, token string, lastStep int64, now time.Time) (int64, bool) {
+	token = strings.ReplaceAll(strings.TrimSpace(token), " ", "")
+	if len(token) != totpOpts.Digits.Length() {
+		return 0, false
+	}
+	period := int64(totpOpts.Period)
+	current := now.Unix() / period
+	for step := current - int64(totpOpts.Skew); step <= current+int64(totpOpts.Skew); step++ {
+		if step <= lastStep {
+			continue
+		}
+		code, err := totp.GenerateCodeCustom(secret, time.Unix(step*period, 0), totpOpts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(code), []byte(token)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
 }
 
 // VerifyTOTPWithWindow verifies a TOTP token with a time window
 func (s *MFAService) VerifyTOTPWithWindow(secret, token string, window int) (bool, error) {
 	// Validate with custom window (number of 30-second periods)
-	opts := totp.ValidateOpts{
-		Period:    30,
-		Skew:      uint(window),
-		Digits:    otp.DigitsSix,
-		Algorithm: otp.AlgorithmSHA256,
-	}
+	opts := totpOpts
+	opts.Skew = uint(window)
 
 	valid, err := totp.ValidateCustom(token, secret, time.Now(), opts)
 	return valid, err
@@ -100,13 +145,53 @@ func (s *MFAService) generateBackupCodes(count int) ([]string, error) {
 	return codes, nil
 }
 
-// HashBackupCode hashes a backup code for storage
+// HashBackupCode hashes a backup code for storage. Codes are compared case-insensitively and with
+// or without the dash.
 func (s *MFAService) HashBackupCode(code string) string {
-	// In production, use bcrypt or argon2
-	// This is simplified for demonstration
-	hash := make([]byte, 32)
-	copy(hash, []byte(code))
-	return hex.EncodeToString(hash)
+	sum := sha256.Sum256([]byte(normalizeBackupCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// RedeemBackupCode checks code against the stored hashes. On a match it returns the hashes that
+// remain, so the caller can persist them and the code cannot be used twice.
+func (s *MFAService) RedeemBackupCode(hashes []string, code string) ([]string, bool) {
+	want := s.HashBackupCode(code)
+	for i, h := range hashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(want)) == 1 {
+			remaining := make([]string, 0, len(hashes)-1)
+			remaining = append(remaining, hashes[:i]...)
+			return append(remaining, hashes[i+1:]...), true
+		}
+	}
+	return hashes, false
+}
+
+func normalizeBackupCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// MFAEncryptionKey returns the 32-byte key used to encrypt TOTP secrets and backup codes at rest.
+// MFA_ENCRYPTION_KEY holds it base64 or hex encoded; without it the key is derived from the session
+// signing secret. When neither is configured there is no key: deriving one from the built-in
+// development secret would leave every stored MFA secret readable to anyone with the source.
+func MFAEncryptionKey() ([]byte, error) {
+	raw := strings.TrimSpace(os.Getenv("MFA_ENCRYPTION_KEY"))
+	if raw == "" {
+		secret, ok := configuredSessionSecret()
+		if !ok {
+			return nil, fmt.Errorf("neither MFA_ENCRYPTION_KEY nor SESSION_SIGNING_SECRET is set")
+		}
+		sum := sha256.Sum256([]byte("domainflow-mfa:" + secret))
+		return sum[:], nil
+	}
+	if key, err := hex.DecodeString(raw); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(raw); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	return nil, fmt.Errorf("MFA_ENCRYPTION_KEY must be 32 bytes, hex or base64 encoded")
 }
 
 // MFAMethod represents different MFA methods
@@ -195,7 +280,7 @@ type RecoveryOptions struct {
 // GetRecoveryOptions returns available recovery options for a user
 func (s *MFAService) GetRecoveryOptions(enrollment *MFAEnrollment) *RecoveryOptions {
 	options := &RecoveryOptions{
-		BackupCodesRemaining: 8 - len(enrollment.BackupCodesUsed),
+		BackupCodesRemaining: BackupCodeCount - len(enrollment.BackupCodesUsed),
 		AlternativeMethods:   []MFAMethod{},
 	}
 
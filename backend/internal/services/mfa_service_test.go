@@ -0,0 +1,99 @@
+package services
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+func TestTOTPEnrollmentAndVerification(t *testing.T) {
+	svc := NewMFAService("DomainFlow")
+	secret, err := svc.GenerateTOTPSecret("user-1", "user@example.com")
+	if err != nil {
+		t.Fatalf("generate secret: %v", err)
+	}
+	if !strings.HasPrefix(secret.QRCode, "otpauth://totp/") || len(secret.BackupCodes) != BackupCodeCount {
+		t.Fatalf("unexpected enrollment %+v", secret)
+	}
+	code, err := totp.GenerateCodeCustom(secret.Secret, time.Now(), totp.ValidateOpts{Period: 30, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1})
+	if err != nil {
+		t.Fatalf("generate code: %v", err)
+	}
+	if ok, _ := svc.VerifyTOTP(secret.Secret, code); !ok {
+		t.Error("expected the current code to verify")
+	}
+	if ok, _ := svc.VerifyTOTP(secret.Secret, "000000"); ok && code != "000000" {
+		t.Error("expected a wrong code to be rejected")
+	}
+}
+
+func TestVerifyTOTPStepRejectsReplay(t *testing.T) {
+	svc := NewMFAService("DomainFlow")
+	secret, err := svc.GenerateTOTPSecret("user-1", "user@example.com")
+	if err != nil {
+		t.Fatalf("generate secret: %v", err)
+	}
+	now := time.Unix(1_700_000_010, 0)
+	code, err := totp.GenerateCodeCustom(secret.Secret, now, totpOpts)
+	if err != nil {
+		t.Fatalf("generate code: %v", err)
+	}
+	step, ok := verifyTOTPStepAt(secret.Secret, code, 0, now)
+	if !ok || step != now.Unix()/30 {
+		t.Fatalf("expected the current code to match step %d, got %d %v", now.Unix()/30, step, ok)
+	}
+	if _, ok := verifyTOTPStepAt(secret.Secret, code, step, now.Add(10*time.Second)); ok {
+		t.Error("expected a replayed code to be rejected")
+	}
+	previous, _ := totp.GenerateCodeCustom(secret.Secret, now.Add(-30*time.Second), totpOpts)
+	if _, ok := verifyTOTPStepAt(secret.Secret, previous, step, now); ok && previous != code {
+		t.Error("expected a code older than the last accepted step to be rejected")
+	}
+	next, _ := totp.GenerateCodeCustom(secret.Secret, now.Add(30*time.Second), totpOpts)
+	if got, ok := verifyTOTPStepAt(secret.Secret, next, step, now.Add(30*time.Second)); !ok || got != step+1 {
+		t.Errorf("expected the next step's code to verify, got %d %v", got, ok)
+	}
+}
+
+func TestRedeemBackupCodeIsSingleUse(t *testing.T) {
+	svc := NewMFAService("DomainFlow")
+	hashes := []string{svc.HashBackupCode("ABCD-1234"), svc.HashBackupCode("WXYZ-9876")}
+
+	remaining, ok := svc.RedeemBackupCode(hashes, " abcd1234 ")
+	if !ok || len(remaining) != 1 || remaining[0] != hashes[1] {
+		t.Fatalf("expected the first code to redeem, got %v %v", ok, remaining)
+	}
+	if _, ok := svc.RedeemBackupCode(remaining, "ABCD-1234"); ok {
+		t.Error("expected a redeemed code to be rejected")
+	}
+}
+
+func TestMFAEncryptionKey(t *testing.T) {
+	key := strings.Repeat("ab", 32)
+	t.Setenv("MFA_ENCRYPTION_KEY", key)
+	got, err := MFAEncryptionKey()
+	if err != nil || hex.EncodeToString(got) != key {
+		t.Fatalf("expected the hex key, got %x, %v", got, err)
+	}
+
+	t.Setenv("MFA_ENCRYPTION_KEY", "too-short")
+	if _, err := MFAEncryptionKey(); err == nil {
+		t.Error("expected a malformed key to be rejected")
+	}
+
+	t.Setenv("MFA_ENCRYPTION_KEY", "")
+	t.Setenv("SESSION_SIGNING_SECRET", "")
+	t.Setenv("AUTH_SESSION_SECRET", "")
+	if _, err := MFAEncryptionKey(); err == nil {
+		t.Error("expected no key without MFA_ENCRYPTION_KEY or a session secret")
+	}
+
+	t.Setenv("SESSION_SIGNING_SECRET", "configured-secret")
+	if got, err := MFAEncryptionKey(); err != nil || len(got) != 32 {
+		t.Errorf("expected a derived 32-byte key, got %d bytes, %v", len(got), err)
+	}
+}
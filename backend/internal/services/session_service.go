@@ -378,16 +378,24 @@ func (s *SessionService) GetMetrics() *SessionMetrics {
 // getSessionSecret retrieves the session signing secret from environment or config.
 // Falls back to a default for development only.
 func getSessionSecret() string {
+	if secret, ok := configuredSessionSecret(); ok {
+		return secret
+	}
+	// Development-only fallback (never use in production!)
+	return "dev-only-session-secret-change-in-production"
+}
+
+// configuredSessionSecret returns the session signing secret from the environment, if one is set.
+func configuredSessionSecret() (string, bool) {
 	// Try environment variable first (recommended for production)
 	if secret := os.Getenv("SESSION_SIGNING_SECRET"); secret != "" {
-		return secret
+		return secret, true
 	}
 	// Fallback to AUTH_SESSION_SECRET for compatibility
 	if secret := os.Getenv("AUTH_SESSION_SECRET"); secret != "" {
-		return secret
+		return secret, true
 	}
-	// Development-only fallback (never use in production!)
-	return "dev-only-session-secret-change-in-production"
+	return "", false
 }
 
 // SignSessionCookie creates a signed session cookie value.
@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+)
+
+// Campaign events are numbered with a per-campaign, monotonically increasing sequence that is sent as the SSE
+// "id:" field. The most recent events of each campaign are retained in a bounded ring buffer (and optionally
+// journaled to Postgres) so a client reconnecting with Last-Event-ID receives everything it missed. When the
+// gap can no longer be filled the client gets an explicit reset event and must refetch campaign state.
+
+const (
+	defaultReplayBufferSize    = 500
+	defaultReplayMaxAge        = 15 * time.Minute
+	defaultJournalRetention    = 24 * time.Hour
+	defaultMaxJournalReplay    = 5000
+	journalQueueSize           = 2048
+	journalBatchSize           = 200
+	journalFlushInterval       = 500 * time.Millisecond
+	journalPruneInterval       = time.Hour
+	journalLookupTimeout       = 2 * time.Second
+	journalSeqBlock            = 1000
+	journalReserveRetry        = 5 * time.Second
+	replayResetGapTooOld       = "gap_too_old"
+	replayResetUnknownSequence = "unknown_sequence"
+	replayResetInvalidID       = "invalid_last_event_id"
+)
+
+// campaignReplayLog holds the sequence counter and the retained events of one campaign.
+type campaignReplayLog struct {
+	last      int64
+	reserved  int64 // highest id reserved in the journal; ids above it may be reissued after a restart
+	reserving bool  // a reservation of the next block is in flight
+	retryAt   time.Time
+	ring      []SSEEvent
+	start     int // index of the oldest retained event
+	length    int
+}
+
+func (l *campaignReplayLog) append(event SSEEvent, capacity int) {
+	if capacity <= 0 {
+		return
+	}
+	if l.ring == nil {
+		l.ring = make([]SSEEvent, capacity)
+	}
+	if l.length < len(l.ring) {
+		l.ring[(l.start+l.length)%len(l.ring)] = event
+		l.length++
+		return
+	}
+	l.ring[l.start] = event
+	l.start = (l.start + 1) % len(l.ring)
+}
+
+// oldest returns the sequence of the oldest retained event, or last+1 when nothing is retained.
+func (l *campaignReplayLog) oldest() int64 {
+	if l.length == 0 {
+		return l.last + 1
+	}
+	return l.ring[l.start].seq
+}
+
+// since returns the retained events with seq > after.
+func (l *campaignReplayLog) since(after int64) []SSEEvent {
+	out := make([]SSEEvent, 0, l.length)
+	for i := 0; i < l.length; i++ {
+		if ev := l.ring[(l.start+i)%len(l.ring)]; ev.seq > after {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// evictBefore drops retained events older than cutoff; the counter is kept so ids never repeat.
+func (l *campaignReplayLog) evictBefore(cutoff time.Time) {
+	for l.length > 0 && l.ring[l.start].Timestamp.Before(cutoff) {
+		l.ring[l.start] = SSEEvent{}
+		l.start = (l.start + 1) % len(l.ring)
+		l.length--
+	}
+	if l.length == 0 {
+		l.ring, l.start = nil, 0
+	}
+}
+
+// SetReplayJournal persists campaign events to journal so replay survives restarts and outlives the
+// in-memory buffer. Writes are batched in the background; the journal is pruned after the retention window.
+func (s *SSEService) SetReplayJournal(ctx context.Context, journal store.SSEEventJournalStore) {
+	if journal == nil {
+		return
+	}
+	s.replayMu.Lock()
+	s.journal = journal
+	s.journalCh = make(chan *models.SSEJournalEntry, journalQueueSize)
+	s.replayMu.Unlock()
+	go s.runJournalWriter(ctx)
+}
+
+// sequenceEvent assigns the next id of the event's campaign and retains the event for replay.
+// Events without a campaign and keep-alives are not sequenced. Callers must hold replayMu.
+func (s *SSEService) sequenceEvent(event SSEEvent) SSEEvent {
+	if !sequenced(event) {
+		return event
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	rl := s.replayLog(*event.CampaignID)
+	rl.last++
+	event.seq = rl.last
+	event.ID = strconv.FormatInt(rl.last, 10)
+	rl.append(event, s.replayBufferSize)
+	if s.journalCh != nil {
+		s.enqueueJournal(event)
+	}
+	return event
+}
+
+func sequenced(event SSEEvent) bool {
+	return event.CampaignID != nil && event.Event != SSEEventKeepAlive
+}
+
+// replayLog returns the campaign's log. A log that was not prepared by reserveReplaySeqs starts at the
+// service start time in microseconds so ids from an earlier process are always detected as stale.
+// Callers must hold replayMu.
+func (s *SSEService) replayLog(campaignID uuid.UUID) *campaignReplayLog {
+	if rl, ok := s.replayLogs[campaignID]; ok {
+		return rl
+	}
+	rl := &campaignReplayLog{last: s.startTime.UnixMicro()}
+	s.replayLogs[campaignID] = rl
+	return rl
+}
+
+// reserveReplaySeqs makes sure the campaign's ids are reserved in the journal before they are issued, so a
+// restarted process resumes above every id that was ever sent even when journal writes were dropped. Ids are
+// reserved a block at a time and the next block is requested once half of the current one is used. The
+// journal is called without holding replayMu; the result is installed afterwards, and a log created
+// concurrently by another caller wins. Failed reservations are retried after journalReserveRetry.
+// It is a no-op without a journal.
+func (s *SSEService) reserveReplaySeqs(campaignID uuid.UUID) {
+	s.replayMu.Lock()
+	journal := s.journal
+	rl, exists := s.replayLogs[campaignID]
+	var floor int64
+	if exists {
+		if rl.reserving || rl.reserved-rl.last > journalSeqBlock/2 || time.Now().Before(rl.retryAt) {
+			journal = nil
+		} else {
+			rl.reserving = true
+			floor = rl.last
+		}
+	}
+	s.replayMu.Unlock()
+	if journal == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), journalLookupTimeout)
+	through, err := journal.ReserveSSEEventSeqs(ctx, nil, campaignID, floor, journalSeqBlock)
+	cancel()
+	if err != nil {
+		log.Printf("[SSE] journal sequence reservation failed campaign=%s err=%v", campaignID, err)
+	}
+
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+	if !exists {
+		if _, ok := s.replayLogs[campaignID]; ok {
+			return
+		}
+		if err == nil {
+			s.replayLogs[campaignID] = &campaignReplayLog{last: through - journalSeqBlock, reserved: through}
+			return
+		}
+		rl = s.replayLog(campaignID)
+	}
+	rl.reserving = false
+	if err != nil {
+		rl.retryAt = time.Now().Add(journalReserveRetry)
+	} else if through > rl.reserved {
+		rl.reserved = through
+	}
+}
+
+func (s *SSEService) enqueueJournal(event SSEEvent) {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		log.Printf("[SSE] journal marshal failed campaign=%s seq=%d err=%v", event.CampaignID, event.seq, err)
+		return
+	}
+	entry := &models.SSEJournalEntry{
+		CampaignID: *event.CampaignID,
+		Seq:        event.seq,
+		EventType:  string(event.Event),
+		Payload:    payload,
+		CreatedAt:  event.Timestamp.UTC(),
+	}
+	if event.UserID != nil {
+		entry.UserID = uuid.NullUUID{UUID: *event.UserID, Valid: true}
+	}
+	select {
+	case s.journalCh <- entry:
+	default:
+		log.Printf("[SSE] journal queue full; dropping campaign=%s seq=%d", event.CampaignID, event.seq)
+	}
+}
+
+func (s *SSEService) runJournalWriter(ctx context.Context) {
+	flush := time.NewTicker(journalFlushInterval)
+	defer flush.Stop()
+	prune := time.NewTicker(journalPruneInterval)
+	defer prune.Stop()
+
+	batch := make([]*models.SSEJournalEntry, 0, journalBatchSize)
+	write := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.journal.AppendSSEEvents(ctx, nil, batch); err != nil {
+			log.Printf("[SSE] journal append failed events=%d err=%v", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case entry := <-s.journalCh:
+			batch = append(batch, entry)
+			if len(batch) >= journalBatchSize {
+				write()
+			}
+		case <-flush.C:
+			write()
+		case <-prune.C:
+			if n, err := s.journal.PruneSSEEvents(ctx, nil, time.Now().Add(-s.journalRetention)); err != nil {
+				log.Printf("[SSE] journal prune failed err=%v", err)
+			} else if n > 0 {
+				log.Printf("[SSE] journal pruned events=%d", n)
+			}
+		}
+	}
+}
+
+// replayPlan is computed while the client is registered so that replayed and live events neither overlap nor gap.
+type replayPlan struct {
+	campaignID uuid.UUID
+	lastID     string
+	after      int64
+	head       int64
+	oldest     int64 // oldest seq retained in memory
+	buffered   []SSEEvent
+	journal    bool // events (after, oldest) must be loaded from the journal
+	reset      string
+}
+
+// planReplay decides what a client resuming after lastEventID needs. Callers must hold replayMu.
+func (s *SSEService) planReplay(campaignID uuid.UUID, lastEventID string) *replayPlan {
+	lastEventID = strings.TrimSpace(lastEventID)
+	if lastEventID == "" {
+		return nil
+	}
+	rl := s.replayLog(campaignID)
+	plan := &replayPlan{campaignID: campaignID, lastID: lastEventID, head: rl.last, oldest: rl.oldest()}
+	after, err := strconv.ParseInt(lastEventID, 10, 64)
+	switch {
+	case err != nil || after < 0:
+		plan.reset = replayResetInvalidID
+	case after > rl.last:
+		plan.reset = replayResetUnknownSequence
+	case after >= plan.oldest-1:
+		plan.after = after
+		plan.buffered = rl.since(after)
+	case s.journal == nil:
+		plan.reset = replayResetGapTooOld
+	default:
+		// The journal fills (after, oldest); the buffer covers the rest.
+		plan.after = after
+		plan.buffered = rl.since(plan.oldest - 1)
+		plan.journal = true
+	}
+	return plan
+}
+
+// replayEvents resolves the plan into the events to write, loading older events from the journal when needed.
+func (s *SSEService) replayEvents(ctx context.Context, plan *replayPlan) []SSEEvent {
+	if plan.reset != "" {
+		return []SSEEvent{resetEvent(plan)}
+	}
+	if !plan.journal {
+		return plan.buffered
+	}
+	through := plan.oldest - 1
+	limit := s.maxJournalReplay
+	entries, err := s.journal.ListSSEEventsAfter(ctx, nil, plan.campaignID, plan.after, through, limit)
+	if err != nil {
+		log.Printf("[SSE] journal replay failed campaign=%s after=%d err=%v", plan.campaignID, plan.after, err)
+		plan.reset = replayResetGapTooOld
+		return []SSEEvent{resetEvent(plan)}
+	}
+	// The journal must cover the whole gap contiguously; pruned or dropped rows force a reset.
+	if len(entries) == 0 || entries[0].Seq != plan.after+1 || entries[len(entries)-1].Seq != through || int64(len(entries)) != through-plan.after {
+		plan.reset = replayResetGapTooOld
+		return []SSEEvent{resetEvent(plan)}
+	}
+	events := make([]SSEEvent, 0, len(entries)+len(plan.buffered))
+	for _, e := range entries {
+		events = append(events, journalEntryToEvent(e))
+	}
+	return append(events, plan.buffered...)
+}
+
+// resetEvent tells the client its Last-Event-ID cannot be resumed. It carries the current head id so the
+// next reconnect resumes from there once the client has refetched campaign state.
+func resetEvent(plan *replayPlan) SSEEvent {
+	campaignID := plan.campaignID
+	return SSEEvent{
+		ID:         strconv.FormatInt(plan.head, 10),
+		Event:      SSEEventReset,
+		CampaignID: &campaignID,
+		Data: map[string]interface{}{
+			"campaign_id":     campaignID.String(),
+			"last_event_id":   plan.lastID,
+			"latest_event_id": strconv.FormatInt(plan.head, 10),
+			"reason":          plan.reset,
+			"message":         "Missed events are no longer available; refetch campaign state",
+		},
+		Timestamp: time.Now(),
+		seq:       plan.head,
+	}
+}
+
+func journalEntryToEvent(e *models.SSEJournalEntry) SSEEvent {
+	campaignID := e.CampaignID
+	event := SSEEvent{
+		ID:         strconv.FormatInt(e.Seq, 10),
+		Event:      SSEEventType(e.EventType),
+		CampaignID: &campaignID,
+		Timestamp:  e.CreatedAt,
+		seq:        e.Seq,
+	}
+	if e.UserID.Valid {
+		userID := e.UserID.UUID
+		event.UserID = &userID
+	}
+	if err := json.Unmarshal(e.Payload, &event.Data); err != nil {
+		log.Printf("[SSE] journal payload decode failed campaign=%s seq=%d err=%v", e.CampaignID, e.Seq, err)
+	}
+	return event
+}
+
+// evictReplayBuffers drops buffered events older than the replay max age.
+func (s *SSEService) evictReplayBuffers() {
+	if s.replayMaxAge <= 0 {
+		return
+	}
+	cutoff := time.Now().Add(-s.replayMaxAge)
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+	for _, rl := range s.replayLogs {
+		rl.evictBefore(cutoff)
+	}
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+)
+
+func TestBroadcastAssignsPerCampaignSequence(t *testing.T) {
+	s := NewSSEService()
+	a, b, user := uuid.New(), uuid.New(), uuid.New()
+
+	s.BroadcastToCampaign(a, CreatePhaseStartedEvent(a, user, models.PhaseTypeDNSValidation))
+	s.BroadcastToCampaign(a, CreatePhaseCompletedEvent(a, user, models.PhaseTypeDNSValidation, nil))
+	s.BroadcastToUser(user, CreatePhaseStartedEvent(b, user, models.PhaseTypeDNSValidation))
+	s.BroadcastEvent(SSEEvent{Event: SSEEventKeywordSetCreated, UserID: &user})
+
+	logA, logB := s.replayLogs[a], s.replayLogs[b]
+	if logA == nil || logB == nil || len(s.replayLogs) != 2 {
+		t.Fatalf("expected replay logs for both campaigns only, got %d", len(s.replayLogs))
+	}
+	events := logA.since(0)
+	if len(events) != 2 || events[1].seq != events[0].seq+1 || events[1].ID != strconv.FormatInt(events[1].seq, 10) {
+		t.Fatalf("unexpected campaign sequence %+v", events)
+	}
+	if logB.length != 1 {
+		t.Fatalf("expected independent sequence for second campaign, got %d events", logB.length)
+	}
+}
+
+func TestRegisterClientReplaysMissedEvents(t *testing.T) {
+	s := NewSSEService()
+	campaign, user := uuid.New(), uuid.New()
+	for i := 0; i < 3; i++ {
+		s.BroadcastToCampaign(campaign, CreateCampaignProgressEvent(campaign, user, map[string]interface{}{"items_processed": int64(i)}))
+	}
+	first := s.replayLogs[campaign].since(0)[0].seq
+
+	w := newSyncRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if _, err := s.RegisterClient(ctx, w, user, &campaign, "", strconv.FormatInt(first, 10)); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	ids := w.ids()
+	want := []string{strconv.FormatInt(first+1, 10), strconv.FormatInt(first+2, 10)}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected replayed ids %v, got %v", want, ids)
+	}
+	if strings.Count(w.body(), "event: keep_alive") != 1 {
+		t.Fatalf("expected initial keep_alive without replaying it")
+	}
+
+	// Live events follow the replay in order.
+	s.BroadcastToCampaign(campaign, CreatePhaseStartedEvent(campaign, user, models.PhaseTypeHTTPKeywordValidation))
+	deadline := time.Now().Add(2 * time.Second)
+	for len(w.ids()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if ids := w.ids(); len(ids) != 3 || ids[2] != strconv.FormatInt(first+3, 10) {
+		t.Fatalf("expected live event id %d after replay, got %v", first+3, ids)
+	}
+}
+
+func TestRegisterClientSendsResetWhenGapTooOld(t *testing.T) {
+	s := NewSSEService()
+	s.replayBufferSize = 2
+	campaign, user := uuid.New(), uuid.New()
+	for i := 0; i < 4; i++ {
+		s.BroadcastToCampaign(campaign, CreateCampaignProgressEvent(campaign, user, nil))
+	}
+	head := s.replayLogs[campaign].last
+
+	cases := map[string]string{
+		strconv.FormatInt(head-3, 10): replayResetGapTooOld,
+		strconv.FormatInt(head+5, 10): replayResetUnknownSequence,
+		"not-a-number":                replayResetInvalidID,
+	}
+	for lastID, reason := range cases {
+		w := newSyncRecorder()
+		ctx, cancel := context.WithCancel(context.Background())
+		if _, err := s.RegisterClient(ctx, w, user, &campaign, "", lastID); err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		cancel()
+		body := w.body()
+		if !strings.Contains(body, "event: reset") || !strings.Contains(body, `"reason":"`+reason+`"`) {
+			t.Fatalf("last id %s: expected reset %s, got %s", lastID, reason, body)
+		}
+		if ids := w.ids(); len(ids) != 1 || ids[0] != strconv.FormatInt(head, 10) {
+			t.Fatalf("last id %s: expected reset to carry head id %d, got %v", lastID, head, ids)
+		}
+	}
+}
+
+func TestRegisterClientReplaysFromJournal(t *testing.T) {
+	s := NewSSEService()
+	s.replayBufferSize = 1
+	campaign, user := uuid.New(), uuid.New()
+	journal := &fakeSSEJournal{reserved: 100}
+	s.journal = journal
+
+	for i := 0; i < 3; i++ {
+		s.BroadcastToCampaign(campaign, CreateCampaignProgressEvent(campaign, user, nil))
+	}
+	if s.replayLogs[campaign].last != 103 {
+		t.Fatalf("expected counter seeded from journal, got %d", s.replayLogs[campaign].last)
+	}
+	journal.entries = []*models.SSEJournalEntry{
+		{CampaignID: campaign, Seq: 101, EventType: string(SSEEventCampaignProgress), Payload: []byte(`{}`), CreatedAt: time.Now()},
+		{CampaignID: campaign, Seq: 102, EventType: string(SSEEventCampaignProgress), Payload: []byte(`{}`), CreatedAt: time.Now()},
+	}
+
+	w := newSyncRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if _, err := s.RegisterClient(ctx, w, user, &campaign, "", "100"); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if got := strings.Join(w.ids(), ","); got != "101,102,103" {
+		t.Fatalf("expected journal then buffer replay, got %s", got)
+	}
+}
+
+func TestReservedSequencesSurviveRestartWithoutJournaledEvents(t *testing.T) {
+	campaign, user := uuid.New(), uuid.New()
+	journal := &fakeSSEJournal{}
+
+	s := NewSSEService()
+	s.journal = journal
+	for i := 0; i < journalSeqBlock/2+1; i++ {
+		s.BroadcastToCampaign(campaign, CreateCampaignProgressEvent(campaign, user, nil))
+	}
+	last := s.replayLogs[campaign].last
+	if journal.reserves != 2 || s.replayLogs[campaign].reserved != 2*journalSeqBlock {
+		t.Fatalf("expected the next block reserved once half was used, got reserves=%d reserved=%d", journal.reserves, s.replayLogs[campaign].reserved)
+	}
+
+	// Nothing was journaled; a restarted service must still resume above every id already sent.
+	restarted := NewSSEService()
+	restarted.journal = journal
+	restarted.BroadcastToCampaign(campaign, CreateCampaignProgressEvent(campaign, user, nil))
+	if got := restarted.replayLogs[campaign].last; got <= last {
+		t.Fatalf("restart reissued id %d (previous process reached %d)", got, last)
+	}
+}
+
+// syncRecorder is a minimal flushable ResponseWriter that is safe to read while the client writer runs.
+type syncRecorder struct {
+	mu     sync.Mutex
+	header http.Header
+	buf    strings.Builder
+}
+
+func newSyncRecorder() *syncRecorder { return &syncRecorder{header: http.Header{}} }
+
+func (r *syncRecorder) Header() http.Header { return r.header }
+func (r *syncRecorder) WriteHeader(int)     {}
+func (r *syncRecorder) Flush()              {}
+
+func (r *syncRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.buf.Write(p)
+}
+
+func (r *syncRecorder) body() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.buf.String()
+}
+
+func (r *syncRecorder) ids() []string {
+	var ids []string
+	for _, line := range strings.Split(r.body(), "\n") {
+		if id, ok := strings.CutPrefix(line, "id: "); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+type fakeSSEJournal struct {
+	reserved int64
+	reserves int
+	entries  []*models.SSEJournalEntry
+}
+
+func (f *fakeSSEJournal) AppendSSEEvents(context.Context, store.Querier, []*models.SSEJournalEntry) error {
+	return nil
+}
+
+func (f *fakeSSEJournal) ListSSEEventsAfter(_ context.Context, _ store.Querier, _ uuid.UUID, after, through int64, limit int) ([]*models.SSEJournalEntry, error) {
+	var out []*models.SSEJournalEntry
+	for _, e := range f.entries {
+		if e.Seq > after && e.Seq <= through && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeSSEJournal) ReserveSSEEventSeqs(_ context.Context, _ store.Querier, _ uuid.UUID, floor, n int64) (int64, error) {
+	f.reserves++
+	if floor > f.reserved {
+		f.reserved = floor
+	}
+	f.reserved += n
+	return f.reserved, nil
+}
+
+func (f *fakeSSEJournal) PruneSSEEvents(context.Context, store.Querier, time.Time) (int64, error) {
+	return 0, fmt.Errorf("not implemented")
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
 	"github.com/google/uuid"
 )
 
@@ -35,12 +36,16 @@ const (
 	SSEEventCountersReconciled SSEEventType = "counters_reconciled"
 	SSEEventAnalysisCompleted  SSEEventType = "analysis_completed"
 	SSEEventModeChanged        SSEEventType = "mode_changed"
+	// SSEEventDomainChanges summarizes how domain outcomes changed since the previous run of a phase.
+	SSEEventDomainChanges SSEEventType = "domain_changes"
 	// Keyword set lifecycle events
 	SSEEventKeywordSetCreated SSEEventType = "keyword_set_created"
 	SSEEventKeywordSetUpdated SSEEventType = "keyword_set_updated"
 	SSEEventKeywordSetDeleted SSEEventType = "keyword_set_deleted"
 	SSEEventKeepAlive         SSEEventType = "keep_alive"
 	SSEEventError             SSEEventType = "error"
+	// SSEEventReset tells a resuming client that its Last-Event-ID can no longer be replayed.
+	SSEEventReset SSEEventType = "reset"
 )
 
 var knownSSEEventTypes = map[SSEEventType]struct{}{
@@ -58,11 +63,13 @@ var knownSSEEventTypes = map[SSEEventType]struct{}{
 	SSEEventCountersReconciled: {},
 	SSEEventAnalysisCompleted:  {},
 	SSEEventModeChanged:        {},
+	SSEEventDomainChanges:      {},
 	SSEEventKeywordSetCreated:  {},
 	SSEEventKeywordSetUpdated:  {},
 	SSEEventKeywordSetDeleted:  {},
 	SSEEventKeepAlive:          {},
 	SSEEventError:              {},
+	SSEEventReset:              {},
 }
 
 const (
@@ -76,6 +83,12 @@ type SSEConfig struct {
 	KeepAliveInterval time.Duration
 	StaleClientTTL    time.Duration
 	CleanupInterval   time.Duration
+	// ReplayBufferSize and ReplayMaxAge bound the per-campaign events retained for Last-Event-ID replay.
+	ReplayBufferSize int
+	ReplayMaxAge     time.Duration
+	// JournalRetention is how long journaled events are kept when a replay journal is configured.
+	JournalRetention time.Duration
+	MaxJournalReplay int
 }
 
 // SSEEvent represents a server-sent event
@@ -86,6 +99,8 @@ type SSEEvent struct {
 	Timestamp  time.Time              `json:"timestamp"`
 	CampaignID *uuid.UUID             `json:"campaign_id,omitempty"`
 	UserID     *uuid.UUID             `json:"user_id,omitempty"`
+	// seq is the per-campaign replay sequence assigned at broadcast time (0 when unsequenced)
+	seq int64
 }
 
 type canonicalEnvelope struct {
@@ -105,6 +120,8 @@ type SSEClient struct {
 	Cancel         context.CancelFunc
 	LastSeen       time.Time
 	writeMu        sync.Mutex
+	// outbox preserves broadcast order so the last id a client saw never skips an undelivered event
+	outbox chan SSEEvent
 }
 
 // SSEService manages Server-Sent Events connections and broadcasting
@@ -120,19 +137,39 @@ type SSEService struct {
 	startTime   time.Time
 	totalEvents uint64
 	cleanupOnce sync.Once
+	// observers see every broadcast event regardless of connected clients (e.g. webhook dispatch)
+	observers  []SSEEventObserver
+	observerMu sync.RWMutex
+	// replay state; replayMu is always acquired before mutex
+	replayMu         sync.Mutex
+	replayLogs       map[uuid.UUID]*campaignReplayLog
+	replayBufferSize int
+	replayMaxAge     time.Duration
+	journal          store.SSEEventJournalStore
+	journalCh        chan *models.SSEJournalEntry
+	journalRetention time.Duration
+	maxJournalReplay int
 }
 
+// SSEEventObserver is notified synchronously for every broadcast event and must not block.
+type SSEEventObserver func(event SSEEvent)
+
 // NewSSEService creates a new SSE service
 func NewSSEService() *SSEService {
 	cfg := defaultSSEConfig()
 	return &SSEService{
-		clients:         make(map[string]*SSEClient),
-		keepAlive:       cfg.KeepAliveInterval,
-		staleClientTTL:  cfg.StaleClientTTL,
-		cleanupInterval: cfg.CleanupInterval,
-		maxClients:      1000, // Reasonable limit to prevent resource exhaustion
-		eventBuffer:     100,  // Buffer size for event channels
-		startTime:       time.Now(),
+		clients:          make(map[string]*SSEClient),
+		keepAlive:        cfg.KeepAliveInterval,
+		staleClientTTL:   cfg.StaleClientTTL,
+		cleanupInterval:  cfg.CleanupInterval,
+		maxClients:       1000, // Reasonable limit to prevent resource exhaustion
+		eventBuffer:      100,  // Buffer size for event channels
+		startTime:        time.Now(),
+		replayLogs:       make(map[uuid.UUID]*campaignReplayLog),
+		replayBufferSize: cfg.ReplayBufferSize,
+		replayMaxAge:     cfg.ReplayMaxAge,
+		journalRetention: cfg.JournalRetention,
+		maxJournalReplay: cfg.MaxJournalReplay,
 	}
 }
 
@@ -141,6 +178,10 @@ func defaultSSEConfig() SSEConfig {
 		KeepAliveInterval: defaultKeepAliveInterval,
 		StaleClientTTL:    defaultStaleClientTTL,
 		CleanupInterval:   defaultCleanupInterval,
+		ReplayBufferSize:  defaultReplayBufferSize,
+		ReplayMaxAge:      defaultReplayMaxAge,
+		JournalRetention:  defaultJournalRetention,
+		MaxJournalReplay:  defaultMaxJournalReplay,
 	}
 }
 
@@ -168,18 +209,26 @@ func (s *SSEService) runCleanupLoop(ctx context.Context) {
 	}
 }
 
-// RegisterClient registers a new SSE client
-func (s *SSEService) RegisterClient(ctx context.Context, w http.ResponseWriter, userID uuid.UUID, campaignID *uuid.UUID, allowedOrigin string) (*SSEClient, error) {
+// RegisterClient registers a new SSE client. For campaign streams a non-empty lastEventID (the client's
+// Last-Event-ID) replays the campaign events it missed, or sends a reset event when they are no longer available.
+func (s *SSEService) RegisterClient(ctx context.Context, w http.ResponseWriter, userID uuid.UUID, campaignID *uuid.UUID, allowedOrigin, lastEventID string) (*SSEClient, error) {
 	flusher, ok := w.(http.Flusher)
 	if !ok {
 		log.Printf("[SSE] registration failed: flusher unavailable (user=%s campaign=%v)", userID.String(), campaignID)
 		return nil, fmt.Errorf("streaming not supported by this response writer")
 	}
 
+	if campaignID != nil {
+		s.reserveReplaySeqs(*campaignID)
+	}
+	// Hold replayMu until the client is registered: events broadcast afterwards are queued on its outbox,
+	// everything before is covered by the replay plan.
+	s.replayMu.Lock()
 	s.mutex.Lock()
 	// Check client limit
 	if len(s.clients) >= s.maxClients {
 		s.mutex.Unlock()
+		s.replayMu.Unlock()
 		log.Printf("[SSE] registration failed: max clients reached (user=%s campaign=%v)", userID.String(), campaignID)
 		return nil, fmt.Errorf("maximum number of SSE clients reached")
 	}
@@ -214,14 +263,19 @@ func (s *SSEService) RegisterClient(ctx context.Context, w http.ResponseWriter,
 		Context:        clientCtx,
 		Cancel:         cancel,
 		LastSeen:       time.Now(),
+		outbox:         make(chan SSEEvent, s.eventBuffer),
 	}
 
 	s.clients[clientID] = client
 	s.mutex.Unlock()
+	var plan *replayPlan
+	if campaignID != nil {
+		plan = s.planReplay(*campaignID, lastEventID)
+	}
+	s.replayMu.Unlock()
 	log.Printf("[SSE] client registered id=%s user=%s campaign=%v origin=%s total=%d", clientID, userID.String(), campaignID, allowedOrigin, len(s.clients))
 
 	initialEvent := SSEEvent{
-		ID:         uuid.New().String(),
 		Event:      SSEEventKeepAlive,
 		Data:       map[string]interface{}{"message": "SSE connection established"},
 		Timestamp:  time.Now(),
@@ -234,7 +288,20 @@ func (s *SSEService) RegisterClient(ctx context.Context, w http.ResponseWriter,
 		return nil, err
 	}
 
-	// Start keep-alive routine for this client
+	if plan != nil {
+		replay := s.replayEvents(clientCtx, plan)
+		for _, event := range replay {
+			if err := s.emitEventToClient(client, event); err != nil {
+				log.Printf("[SSE] replay send failed id=%s err=%v", clientID, err)
+				s.UnregisterClient(clientID)
+				return nil, err
+			}
+		}
+		log.Printf("[SSE] client resumed id=%s campaign=%s last_event_id=%s replayed=%d reset=%s", clientID, plan.campaignID, plan.lastID, len(replay), plan.reset)
+	}
+
+	// Start the ordered writer and keep-alive routine for this client
+	go s.clientWriter(client)
 	go s.clientKeepAlive(client)
 
 	return client, nil
@@ -264,33 +331,76 @@ func (s *SSEService) UnregisterClient(clientID string) {
 	}
 }
 
+// AddObserver registers fn to receive every event passed to the Broadcast* methods.
+func (s *SSEService) AddObserver(fn SSEEventObserver) {
+	if fn == nil {
+		return
+	}
+	s.observerMu.Lock()
+	s.observers = append(s.observers, fn)
+	s.observerMu.Unlock()
+}
+
+func (s *SSEService) notifyObservers(event SSEEvent) {
+	s.observerMu.RLock()
+	defer s.observerMu.RUnlock()
+	for _, fn := range s.observers {
+		fn(event)
+	}
+}
+
 // BroadcastEvent sends an event to all connected clients
 func (s *SSEService) BroadcastEvent(event SSEEvent) {
+	if sequenced(event) {
+		s.reserveReplaySeqs(*event.CampaignID)
+	}
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+	event = s.sequenceEvent(event)
+	s.notifyObservers(event)
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
 	for _, client := range s.clients {
 		// Filter events by user and optionally by campaign
 		if s.shouldSendEventToClient(client, event) {
-			go s.sendEventToClient(client, event)
+			s.enqueueEvent(client, event)
 		}
 	}
 }
 
 // BroadcastToUser sends an event to all clients for a specific user
 func (s *SSEService) BroadcastToUser(userID uuid.UUID, event SSEEvent) {
+	if sequenced(event) {
+		s.reserveReplaySeqs(*event.CampaignID)
+	}
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+	event = s.sequenceEvent(event)
+	s.notifyObservers(event)
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
 	for _, client := range s.clients {
 		if client.UserID == userID && s.shouldSendEventToClient(client, event) {
-			go s.sendEventToClient(client, event)
+			s.enqueueEvent(client, event)
 		}
 	}
 }
 
 // BroadcastToCampaign sends an event to all clients watching a specific campaign
 func (s *SSEService) BroadcastToCampaign(campaignID uuid.UUID, event SSEEvent) {
+	if event.CampaignID == nil || *event.CampaignID != campaignID {
+		clone := campaignID
+		event.CampaignID = &clone
+	}
+	if sequenced(event) {
+		s.reserveReplaySeqs(campaignID)
+	}
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+	event = s.sequenceEvent(event)
+	s.notifyObservers(event)
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -298,7 +408,7 @@ func (s *SSEService) BroadcastToCampaign(campaignID uuid.UUID, event SSEEvent) {
 	for _, client := range s.clients {
 		if client.CampaignID != nil && *client.CampaignID == campaignID {
 			targetIDs = append(targetIDs, client.ID)
-			go s.sendEventToClient(client, event)
+			s.enqueueEvent(client, event)
 		}
 	}
 
@@ -337,6 +447,33 @@ func (s *SSEService) shouldSendEventToClient(client *SSEClient, event SSEEvent)
 	return event.UserID != nil && *event.UserID == client.UserID
 }
 
+// enqueueEvent queues a broadcast event on the client's outbox without blocking the broadcaster.
+// A client that cannot keep up is disconnected; it resumes from its Last-Event-ID on reconnect.
+func (s *SSEService) enqueueEvent(client *SSEClient, event SSEEvent) {
+	select {
+	case client.outbox <- event:
+	default:
+		log.Printf("[SSE] outbox full; disconnecting id=%s event=%s", client.ID, event.Event)
+		go s.UnregisterClient(client.ID)
+	}
+}
+
+// clientWriter writes queued events to the client in broadcast order.
+func (s *SSEService) clientWriter(client *SSEClient) {
+	for {
+		select {
+		case <-client.Context.Done():
+			return
+		case event := <-client.outbox:
+			if err := s.emitEventToClient(client, event); err != nil {
+				log.Printf("[SSE] send failed id=%s event=%s err=%v", client.ID, event.Event, err)
+				s.UnregisterClient(client.ID)
+				return
+			}
+		}
+	}
+}
+
 // sendEventToClient sends an SSE event to a specific client
 func (s *SSEService) sendEventToClient(client *SSEClient, event SSEEvent) {
 	if err := s.emitEventToClient(client, event); err != nil {
@@ -352,9 +489,6 @@ func (s *SSEService) emitEventToClient(client *SSEClient, event SSEEvent) error
 	default:
 	}
 
-	if event.ID == "" {
-		event.ID = uuid.New().String()
-	}
 	if event.Timestamp.IsZero() {
 		event.Timestamp = time.Now()
 	}
@@ -372,8 +506,12 @@ func (s *SSEService) emitEventToClient(client *SSEClient, event SSEEvent) error
 	client.writeMu.Lock()
 	defer client.writeMu.Unlock()
 
-	if _, err := fmt.Fprintf(client.ResponseWriter, "id: %s\n", event.ID); err != nil {
-		return err
+	// Only the client's own campaign sequence sets the id, so EventSource's Last-Event-ID stays resumable;
+	// keep-alives and events of other campaigns leave it unchanged.
+	if event.seq > 0 && client.CampaignID != nil && event.CampaignID != nil && *event.CampaignID == *client.CampaignID {
+		if _, err := fmt.Fprintf(client.ResponseWriter, "id: %s\n", event.ID); err != nil {
+			return err
+		}
 	}
 	if _, err := fmt.Fprintf(client.ResponseWriter, "event: %s\n", event.Event); err != nil {
 		return err
@@ -457,6 +595,7 @@ func (s *SSEService) Cleanup() {
 			delete(s.clients, clientID)
 		}
 	}
+	go s.evictReplayBuffers()
 }
 
 func sampleClientIDs(ids []string, max int) []string {
@@ -894,6 +1033,25 @@ func CreateModeChangedEvent(campaignID uuid.UUID, userID uuid.UUID, mode string)
 	}
 }
 
+// CreateDomainChangesEvent emits the per-kind change counts between a phase run and the previous run of that phase
+func CreateDomainChangesEvent(campaignID uuid.UUID, userID uuid.UUID, phase models.PhaseTypeEnum, runID, previousRunID uuid.UUID, counts map[string]int64, total int64) SSEEvent {
+	return SSEEvent{
+		Event:      SSEEventDomainChanges,
+		CampaignID: &campaignID,
+		UserID:     &userID,
+		Data: map[string]interface{}{
+			"campaign_id":     campaignID.String(),
+			"phase":           string(phase),
+			"run_id":          runID.String(),
+			"previous_run_id": previousRunID.String(),
+			"total_changed":   total,
+			"counts":          counts,
+			"message":         fmt.Sprintf("%d domains changed since the previous %s run", total, phase),
+		},
+		Timestamp: time.Now(),
+	}
+}
+
 // CreatePhaseAutoStartedEvent emits when a phase is started automatically (chained) in full_sequence mode
 func CreatePhaseAutoStartedEvent(campaignID uuid.UUID, userID uuid.UUID, phase models.PhaseTypeEnum) SSEEvent {
 	return SSEEvent{
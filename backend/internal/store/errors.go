@@ -14,5 +14,5 @@ var (
 	ErrUpdateFailed = errors.New("database record update failed")
 
 	// ErrOptimisticLock is returned when an update operation fails due to a version mismatch in optimistic locking.
-	// ErrOptimisticLock = errors.New("database record update failed due to version mismatch (optimistic lock)")
+	ErrOptimisticLock = errors.New("database record update failed due to version mismatch (optimistic lock)")
 )
@@ -174,6 +174,11 @@ type ListCampaignDomainsFilter struct {
 	LeadStatus       *models.DomainLeadStatusEnum       // Filter by lead status
 	RejectionReason  *models.DomainRejectionReasonEnum  // Filter by single rejection reason (P0-1)
 	RejectionReasons []models.DomainRejectionReasonEnum // Filter by multiple rejection reasons (P0-8)
+	ChangedSinceRun  *uuid.UUID                         // Only domains whose state differs from their snapshot in this run
+	RDAPStatus       *string                            // Filter by RDAP lookup outcome (models.RDAPStatus*)
+	RegisteredBefore *time.Time                         // Registration date strictly before this instant
+	RegisteredAfter  *time.Time                         // Registration date strictly after this instant
+	ExpiresBefore    *time.Time                         // Registration expiry strictly before this instant
 }
 
 // ListCampaignsFilter and ListValidationResultsFilter remain the same
@@ -182,6 +187,8 @@ type ListCampaignsFilter struct {
 	CurrentPhase *models.PhaseTypeEnum   // Phases-based filtering
 	PhaseStatus  *models.PhaseStatusEnum // Status of current phase
 	UserID       string
+	// AccessibleTo limits results to campaigns the user owns or holds a live grant on
+	AccessibleTo string
 	Limit        int
 	Offset       int
 	SortBy       string
@@ -213,15 +220,17 @@ type DiscoveryLineageCampaign struct {
 type RejectionSummary struct {
 	CampaignID uuid.UUID `json:"campaignId"`
 	Counts     struct {
-		Qualified   int64 `db:"qualified" json:"qualified"`
-		LowScore    int64 `db:"low_score" json:"lowScore"`
-		NoKeywords  int64 `db:"no_keywords" json:"noKeywords"`
-		Parked      int64 `db:"parked" json:"parked"`
-		DNSError    int64 `db:"dns_error" json:"dnsError"`
-		DNSTimeout  int64 `db:"dns_timeout" json:"dnsTimeout"`
-		HTTPError   int64 `db:"http_error" json:"httpError"`
-		HTTPTimeout int64 `db:"http_timeout" json:"httpTimeout"`
-		Pending     int64 `db:"pending" json:"pending"`
+		Qualified         int64 `db:"qualified" json:"qualified"`
+		LowScore          int64 `db:"low_score" json:"lowScore"`
+		NoKeywords        int64 `db:"no_keywords" json:"noKeywords"`
+		Parked            int64 `db:"parked" json:"parked"`
+		DNSError          int64 `db:"dns_error" json:"dnsError"`
+		DNSTimeout        int64 `db:"dns_timeout" json:"dnsTimeout"`
+		DNSWildcard       int64 `db:"dns_wildcard" json:"dnsWildcard"`
+		DNSMissingRecords int64 `db:"dns_missing_records" json:"dnsMissingRecords"`
+		HTTPError         int64 `db:"http_error" json:"httpError"`
+		HTTPTimeout       int64 `db:"http_timeout" json:"httpTimeout"`
+		Pending           int64 `db:"pending" json:"pending"`
 	} `json:"counts"`
 	Totals struct {
 		Analyzed  int64 `json:"analyzed"`
@@ -381,4 +390,208 @@ type ProxyPoolStore interface {
 	AddProxyToPool(ctx context.Context, exec Querier, m *models.ProxyPoolMembership) error
 	RemoveProxyFromPool(ctx context.Context, exec Querier, poolID, proxyID uuid.UUID) error
 	ListProxiesForPool(ctx context.Context, exec Querier, poolID uuid.UUID) ([]*models.Proxy, error)
+	ListPoolMemberships(ctx context.Context, exec Querier, poolID uuid.UUID) ([]*models.ProxyPoolMembership, error)
+}
+
+// WordListStore defines operations for word lists referenced by word-list domain generation patterns.
+type WordListStore interface {
+	CreateWordList(ctx context.Context, exec Querier, list *models.DomainWordList) error
+	GetWordListByID(ctx context.Context, exec Querier, id uuid.UUID) (*models.DomainWordList, error)
+	GetWordListsByIDs(ctx context.Context, exec Querier, ids []uuid.UUID) ([]*models.DomainWordList, error)
+	UpdateWordList(ctx context.Context, exec Querier, list *models.DomainWordList) error
+	DeleteWordList(ctx context.Context, exec Querier, id uuid.UUID) error
+	// WordListInUse reports whether any campaign's domain generation config binds the list to a slot.
+	WordListInUse(ctx context.Context, exec Querier, id uuid.UUID) (bool, error)
+	// ListWordLists returns list metadata only; Words is left empty to keep listings cheap.
+	ListWordLists(ctx context.Context, exec Querier, limit, offset int) ([]*models.DomainWordList, error)
+}
+
+// DomainImportStore stages uploaded domain lists until the domain_generation phase runs in import mode.
+type DomainImportStore interface {
+	// StageDomainImport appends normalized domains in order, skipping names already staged or already
+	// generated for the campaign. It returns how many rows were added.
+	StageDomainImport(ctx context.Context, exec Querier, campaignID uuid.UUID, domains []string) (int64, error)
+	// ListStagedImportDomains returns staged domains with position > afterPosition, ordered by position,
+	// leaving out names the campaign has generated since they were staged.
+	ListStagedImportDomains(ctx context.Context, exec Querier, campaignID uuid.UUID, afterPosition int64, limit int) ([]*models.StagedImportDomain, error)
+	CountStagedImportDomains(ctx context.Context, exec Querier, campaignID uuid.UUID) (int64, error)
+	ClearStagedImportDomains(ctx context.Context, exec Querier, campaignID uuid.UUID) error
+}
+
+// DomainExportFilter selects and orders the rows streamed by DomainExportStore. It mirrors the
+// GET /campaigns/{campaignId}/domains filters so an export reproduces the listing in full.
+type DomainExportFilter struct {
+	ListCampaignDomainsFilter
+	MinScore   *float64 // domain_score >= MinScore
+	MaxScore   *float64 // domain_score <= MaxScore
+	NotParked  bool     // exclude is_parked domains
+	HasContact bool     // feature_vector kw_contact > 0
+	Keyword    bool     // feature_vector kw_unique > 0
+	SortBy     string   // offset_index (default) | domain_score | last_http_fetched_at | domain_name
+	SortOrder  string   // ASC (default) | DESC
+}
+
+// DomainExportStore streams campaign domains for bulk export.
+type DomainExportStore interface {
+	// StreamCampaignDomains runs the export query behind a server-side cursor inside its own read-only
+	// transaction and passes rows to fn in batches of at most batchSize. An error from fn aborts the stream.
+	StreamCampaignDomains(ctx context.Context, campaignID uuid.UUID, filter DomainExportFilter, batchSize int, fn func([]*models.GeneratedDomain) error) error
+}
+
+// WebhookStore persists outbound webhook subscriptions and their delivery queue.
+type WebhookStore interface {
+	CreateWebhookSubscription(ctx context.Context, exec Querier, sub *models.WebhookSubscription) error
+	// GetWebhookSubscription returns ErrNotFound unless the subscription exists and belongs to userID.
+	GetWebhookSubscription(ctx context.Context, exec Querier, userID, id uuid.UUID) (*models.WebhookSubscription, error)
+	GetWebhookSubscriptionsByIDs(ctx context.Context, exec Querier, ids []uuid.UUID) ([]*models.WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, exec Querier, sub *models.WebhookSubscription) error
+	DeleteWebhookSubscription(ctx context.Context, exec Querier, userID, id uuid.UUID) error
+	ListWebhookSubscriptions(ctx context.Context, exec Querier, userID uuid.UUID) ([]*models.WebhookSubscription, error)
+	// ListMatchingWebhookSubscriptions returns the owner's active subscriptions whose event and campaign
+	// filters accept the event. A nil userID means the owner of campaignID.
+	ListMatchingWebhookSubscriptions(ctx context.Context, exec Querier, userID uuid.UUID, eventType string, campaignID *uuid.UUID) ([]*models.WebhookSubscription, error)
+
+	// EnqueueWebhookDeliveries inserts pending deliveries; an event already queued for a subscription is skipped.
+	EnqueueWebhookDeliveries(ctx context.Context, exec Querier, deliveries []*models.WebhookDelivery) error
+	// ClaimDueWebhookDeliveries leases up to limit due pending deliveries by pushing next_attempt_at forward by
+	// lease, so concurrent dispatchers (or a crashed one) never send the same attempt twice.
+	ClaimDueWebhookDeliveries(ctx context.Context, exec Querier, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	MarkWebhookDeliveryDelivered(ctx context.Context, exec Querier, id uuid.UUID, attempts int, statusCode int) error
+	ScheduleWebhookDeliveryRetry(ctx context.Context, exec Querier, id uuid.UUID, attempts int, next time.Time, statusCode *int, lastError string) error
+	// DeadLetterWebhookDelivery marks the delivery dead and copies it into the dead-letter table.
+	DeadLetterWebhookDelivery(ctx context.Context, exec Querier, delivery *models.WebhookDelivery) error
+	ListWebhookDeliveries(ctx context.Context, exec Querier, subscriptionID uuid.UUID, limit, offset int) ([]*models.WebhookDelivery, error)
+	ListWebhookDeadLetters(ctx context.Context, exec Querier, subscriptionID uuid.UUID, limit, offset int) ([]*models.WebhookDeadLetter, error)
+	// RequeueWebhookDeadLetter removes the dead letter and resets its delivery to pending with a fresh retry budget.
+	RequeueWebhookDeadLetter(ctx context.Context, exec Querier, subscriptionID, deadLetterID uuid.UUID) (*models.WebhookDelivery, error)
+}
+
+// SSEEventJournalStore persists campaign SSE events for Last-Event-ID replay.
+type SSEEventJournalStore interface {
+	// AppendSSEEvents inserts journal entries; an already journaled (campaign, seq) is skipped.
+	AppendSSEEvents(ctx context.Context, exec Querier, entries []*models.SSEJournalEntry) error
+	// ListSSEEventsAfter returns up to limit entries with afterSeq < seq <= throughSeq in ascending seq order.
+	ListSSEEventsAfter(ctx context.Context, exec Querier, campaignID uuid.UUID, afterSeq, throughSeq int64, limit int) ([]*models.SSEJournalEntry, error)
+	// ReserveSSEEventSeqs reserves the next n ids of the campaign above both the previous reservation and
+	// floor, returning the new highest reserved id. Ids in (result-n, result] are never reserved again.
+	ReserveSSEEventSeqs(ctx context.Context, exec Querier, campaignID uuid.UUID, floor, n int64) (int64, error)
+	PruneSSEEvents(ctx context.Context, exec Querier, olderThan time.Time) (int64, error)
+}
+
+// CampaignScheduleStore persists cron-style campaign schedules.
+type CampaignScheduleStore interface {
+	CreateCampaignSchedule(ctx context.Context, exec Querier, schedule *models.CampaignSchedule) error
+	// GetCampaignSchedule returns ErrNotFound unless the schedule exists and belongs to campaignID.
+	GetCampaignSchedule(ctx context.Context, exec Querier, campaignID, id uuid.UUID) (*models.CampaignSchedule, error)
+	ListCampaignSchedules(ctx context.Context, exec Querier, campaignID uuid.UUID) ([]*models.CampaignSchedule, error)
+	UpdateCampaignSchedule(ctx context.Context, exec Querier, schedule *models.CampaignSchedule) error
+	DeleteCampaignSchedule(ctx context.Context, exec Querier, campaignID, id uuid.UUID) error
+	// ClaimDueCampaignSchedules leases up to limit enabled schedules whose next run is due by setting
+	// locked_until to now+lease; schedules already leased by another scheduler are skipped.
+	ClaimDueCampaignSchedules(ctx context.Context, exec Querier, now time.Time, limit int, lease time.Duration) ([]*models.CampaignSchedule, error)
+	// RecordCampaignScheduleRun stores the outcome of a claimed run and releases the lease. nextRunAt is only
+	// applied when the schedule was not edited since it was claimed; edits compute their own next run.
+	RecordCampaignScheduleRun(ctx context.Context, exec Querier, claimed *models.CampaignSchedule, ranAt time.Time, status models.CampaignScheduleRunStatusEnum, lastError string, nextRunAt *time.Time) error
+}
+
+// DomainRunSnapshotStore records per-run domain outcomes so runs can be compared.
+type DomainRunSnapshotStore interface {
+	// SnapshotCampaignPhaseRun records the run and copies the current state of every campaign domain into it,
+	// setting run.DomainCount. Snapshotting an already recorded run is a no-op.
+	SnapshotCampaignPhaseRun(ctx context.Context, exec Querier, run *models.CampaignPhaseRun) error
+	GetCampaignPhaseRun(ctx context.Context, exec Querier, campaignID, runID uuid.UUID) (*models.CampaignPhaseRun, error)
+	// ListCampaignPhaseRuns returns the campaign's snapshotted runs, newest first.
+	ListCampaignPhaseRuns(ctx context.Context, exec Querier, campaignID uuid.UUID, limit int) ([]*models.CampaignPhaseRun, error)
+	// GetPreviousCampaignPhaseRun returns the latest run of the same phase completed before the given run,
+	// or ErrNotFound when it is the first.
+	GetPreviousCampaignPhaseRun(ctx context.Context, exec Querier, run *models.CampaignPhaseRun) (*models.CampaignPhaseRun, error)
+	// ListChangedDomainSnapshots pairs the snapshots of domains whose tracked state differs between the two
+	// runs (including domains present in only one), ordered by domain id after afterDomainID. Kinds is left
+	// empty; callers classify pairs with models.ClassifyDomainChange.
+	ListChangedDomainSnapshots(ctx context.Context, exec Querier, fromRunID, toRunID, afterDomainID uuid.UUID, limit int) ([]*models.DomainStateChange, error)
+	// PruneCampaignPhaseRuns keeps the newest keep runs of each phase of the campaign and deletes older ones.
+	PruneCampaignPhaseRuns(ctx context.Context, exec Querier, campaignID uuid.UUID, keep int) (int64, error)
+}
+
+// ListBulkOperationsFilter narrows the bulk operation history. Zero values mean no filter.
+type ListBulkOperationsFilter struct {
+	Type          *models.BulkOperationTypeEnum
+	Status        *models.BulkOperationStatusEnum
+	CreatedAfter  *time.Time // created_at >= CreatedAfter
+	CreatedBefore *time.Time // created_at < CreatedBefore
+	// AccessibleTo limits results to operations whose every campaign the user owns or holds a live grant on
+	AccessibleTo *uuid.UUID
+	Limit        int
+	Offset       int
+}
+
+// BulkOperationStore persists bulk operations and their per-campaign tasks.
+type BulkOperationStore interface {
+	Transactor
+	// CreateBulkOperation inserts the operation and its tasks; callers pass a transaction so both land together.
+	CreateBulkOperation(ctx context.Context, exec Querier, op *models.BulkOperation, tasks []*models.BulkOperationTask) error
+	// GetBulkOperation returns the operation with CampaignCount filled in, or ErrNotFound.
+	GetBulkOperation(ctx context.Context, exec Querier, id uuid.UUID) (*models.BulkOperation, error)
+	// ListBulkOperations returns a page of operations matching the filter, newest first, and the total match count.
+	ListBulkOperations(ctx context.Context, exec Querier, filter ListBulkOperationsFilter) ([]*models.BulkOperation, int64, error)
+	// ListBulkOperationTasks returns the operation's tasks in submission order.
+	ListBulkOperationTasks(ctx context.Context, exec Querier, operationID uuid.UUID) ([]*models.BulkOperationTask, error)
+	// UpdateBulkOperationProgress stores a freshly computed aggregate. completed_at is set the first time the
+	// status becomes terminal.
+	UpdateBulkOperationProgress(ctx context.Context, exec Querier, id uuid.UUID, status models.BulkOperationStatusEnum, progress float64) error
+	// UpdateBulkOperationTask stores the task's status, counters, error and start time and releases its claim.
+	UpdateBulkOperationTask(ctx context.Context, exec Querier, task *models.BulkOperationTask) error
+	// RequestBulkOperationCancel flags the operation cancelled and cancels its tasks that have not started.
+	// It returns ErrNotFound when the operation does not exist.
+	RequestBulkOperationCancel(ctx context.Context, exec Querier, id uuid.UUID) error
+	// ClaimPendingBulkOperationTasks leases up to limit pending tasks of operations that were not cancelled by
+	// setting claimed_until to now+lease, oldest operation first. Tasks already leased elsewhere are skipped.
+	// A nil operationID claims across all operations.
+	ClaimPendingBulkOperationTasks(ctx context.Context, exec Querier, operationID *uuid.UUID, limit int, lease time.Duration) ([]*models.BulkOperationTask, error)
+}
+
+// APIKeyStore persists personal API keys. Lookups by owner return ErrNotFound for other users' keys.
+type APIKeyStore interface {
+	CreateAPIKey(ctx context.Context, exec Querier, key *models.APIKey) error
+	// ListAPIKeys returns the owner's keys, revoked ones included, newest first.
+	ListAPIKeys(ctx context.Context, exec Querier, userID uuid.UUID) ([]*models.APIKey, error)
+	GetAPIKey(ctx context.Context, exec Querier, userID, id uuid.UUID) (*models.APIKey, error)
+	// GetAPIKeyByHash finds a key by the hash of its secret, whether or not it is still usable.
+	GetAPIKeyByHash(ctx context.Context, exec Querier, keyHash string) (*models.APIKey, error)
+	// RevokeAPIKey sets revoked_at; revoking an already revoked key is a no-op.
+	RevokeAPIKey(ctx context.Context, exec Querier, userID, id uuid.UUID) error
+	// RotateAPIKey replaces the secret of an unrevoked key, so the previous secret stops working at once.
+	RotateAPIKey(ctx context.Context, exec Querier, userID, id uuid.UUID, keyHash, keyHint string) (*models.APIKey, error)
+	// TouchAPIKey records a use. It only writes when last_used_at is older than minInterval.
+	TouchAPIKey(ctx context.Context, exec Querier, id uuid.UUID, clientIP string, minInterval time.Duration) error
+}
+
+// AuthorizationStore persists user roles, campaign shares and the authorization decision log.
+type AuthorizationStore interface {
+	// GetUserRole returns ErrNotFound for unknown users.
+	GetUserRole(ctx context.Context, exec Querier, userID uuid.UUID) (string, error)
+	SetUserRole(ctx context.Context, exec Querier, userID uuid.UUID, role string) error
+	// GetCampaignAccess returns the campaign's owner and userID's live grants; ErrNotFound when the campaign does not exist.
+	GetCampaignAccess(ctx context.Context, exec Querier, campaignID, userID uuid.UUID) (*models.CampaignAccess, error)
+	// ListCampaignAccessGrants returns the campaign's live grants, at most one per user.
+	ListCampaignAccessGrants(ctx context.Context, exec Querier, campaignID uuid.UUID) ([]*models.CampaignAccessGrant, error)
+	// GrantCampaignAccess replaces whatever access the user held on the campaign with one grant of level.
+	GrantCampaignAccess(ctx context.Context, exec Querier, campaignID, userID, grantedBy uuid.UUID, level string, expiresAt *time.Time) (*models.CampaignAccessGrant, error)
+	// RevokeCampaignAccess revokes all of the user's live grants on the campaign; ErrNotFound when there were none.
+	RevokeCampaignAccess(ctx context.Context, exec Querier, campaignID, userID, revokedBy uuid.UUID) error
+	RecordAuthorizationDecision(ctx context.Context, exec Querier, decision *models.AuthorizationDecision) error
+}
+
+// ConfigVersionStore persists versioned runtime configuration sections.
+type ConfigVersionStore interface {
+	// GetCurrentConfigVersions returns the current version of every config key.
+	GetCurrentConfigVersions(ctx context.Context, exec Querier) ([]*models.ConfigVersion, error)
+	// GetConfigVersion returns ErrNotFound for unknown ids.
+	GetConfigVersion(ctx context.Context, exec Querier, id uuid.UUID) (*models.ConfigVersion, error)
+	// ListConfigVersions returns versions newest first; an empty key lists every key.
+	ListConfigVersions(ctx context.Context, exec Querier, key string, limit, offset int) ([]*models.ConfigVersion, error)
+	// CreateConfigVersion stores v as the next, current version of v.ConfigKey and fills in its generated
+	// fields. It returns ErrOptimisticLock unless expectedVersion is the key's current version (0 when the
+	// key has none yet).
+	CreateConfigVersion(ctx context.Context, exec Querier, v *models.ConfigVersion, expectedVersion int) error
 }
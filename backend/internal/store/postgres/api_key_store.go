@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// apiKeyStorePostgres implements store.APIKeyStore
+type apiKeyStorePostgres struct{ db *sqlx.DB }
+
+// NewAPIKeyStorePostgres creates a new APIKeyStore backed by PostgreSQL
+func NewAPIKeyStorePostgres(db *sqlx.DB) store.APIKeyStore {
+	return &apiKeyStorePostgres{db: db}
+}
+
+func (s *apiKeyStorePostgres) querier(exec store.Querier) store.Querier {
+	if exec != nil {
+		return exec
+	}
+	return s.db
+}
+
+const apiKeyColumns = `id, user_id, name, key_hash, key_hint, scopes, expires_at, last_used_at, last_used_ip, revoked_at, rotated_at, created_at, updated_at`
+
+func (s *apiKeyStorePostgres) CreateAPIKey(ctx context.Context, exec store.Querier, key *models.APIKey) error {
+	_, err := s.querier(exec).ExecContext(ctx, `INSERT INTO api_keys (id, user_id, name, key_hash, key_hint, scopes, expires_at, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		key.ID, key.UserID, key.Name, key.KeyHash, key.KeyHint, key.Scopes, key.ExpiresAt, key.CreatedAt, key.UpdatedAt)
+	return err
+}
+
+func (s *apiKeyStorePostgres) ListAPIKeys(ctx context.Context, exec store.Querier, userID uuid.UUID) ([]*models.APIKey, error) {
+	keys := []*models.APIKey{}
+	err := s.querier(exec).SelectContext(ctx, &keys, `SELECT `+apiKeyColumns+` FROM api_keys WHERE user_id = $1 ORDER BY created_at DESC, id`, userID)
+	return keys, err
+}
+
+func (s *apiKeyStorePostgres) GetAPIKey(ctx context.Context, exec store.Querier, userID, id uuid.UUID) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	err := s.querier(exec).GetContext(ctx, key, `SELECT `+apiKeyColumns+` FROM api_keys WHERE id = $1 AND user_id = $2`, id, userID)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	return key, err
+}
+
+func (s *apiKeyStorePostgres) GetAPIKeyByHash(ctx context.Context, exec store.Querier, keyHash string) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	err := s.querier(exec).GetContext(ctx, key, `SELECT `+apiKeyColumns+` FROM api_keys WHERE key_hash = $1`, keyHash)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	return key, err
+}
+
+func (s *apiKeyStorePostgres) RevokeAPIKey(ctx context.Context, exec store.Querier, userID, id uuid.UUID) error {
+	res, err := s.querier(exec).ExecContext(ctx, `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW()), updated_at = NOW()
+              WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *apiKeyStorePostgres) RotateAPIKey(ctx context.Context, exec store.Querier, userID, id uuid.UUID, keyHash, keyHint string) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	err := s.querier(exec).GetContext(ctx, key, `UPDATE api_keys SET key_hash = $3, key_hint = $4, rotated_at = NOW(), updated_at = NOW()
+              WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+              RETURNING `+apiKeyColumns, id, userID, keyHash, keyHint)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	return key, err
+}
+
+func (s *apiKeyStorePostgres) TouchAPIKey(ctx context.Context, exec store.Querier, id uuid.UUID, clientIP string, minInterval time.Duration) error {
+	_, err := s.querier(exec).ExecContext(ctx, `UPDATE api_keys SET last_used_at = NOW(), last_used_ip = NULLIF($2, '')
+              WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - make_interval(secs => $3))`,
+		id, clientIP, minInterval.Seconds())
+	return err
+}
+
+var _ store.APIKeyStore = (*apiKeyStorePostgres)(nil)
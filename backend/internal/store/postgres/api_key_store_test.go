@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/fntelecomllc/studio/backend/internal/store"
+)
+
+var apiKeyTestColumns = []string{"id", "user_id", "name", "key_hash", "key_hint", "scopes", "expires_at", "last_used_at", "last_used_ip", "revoked_at", "rotated_at", "created_at", "updated_at"}
+
+func TestRotateAPIKey_SkipsRevokedKeys(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &apiKeyStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	userID, keyID := uuid.New(), uuid.New()
+	now := time.Now()
+	mock.ExpectQuery(`UPDATE api_keys SET key_hash = \$3, key_hint = \$4, rotated_at = NOW\(\).*WHERE id = \$1 AND user_id = \$2 AND revoked_at IS NULL`).
+		WithArgs(keyID, userID, "newhash", "abcd").
+		WillReturnRows(sqlmock.NewRows(apiKeyTestColumns).
+			AddRow(keyID, userID, "ci", "newhash", "abcd", "{read,write}", nil, nil, nil, nil, now, now, now))
+	mock.ExpectQuery(`UPDATE api_keys SET key_hash`).
+		WithArgs(keyID, userID, "again", "efgh").
+		WillReturnRows(sqlmock.NewRows(apiKeyTestColumns))
+
+	key, err := s.RotateAPIKey(context.Background(), nil, userID, keyID, "newhash", "abcd")
+	if err != nil {
+		t.Fatalf("RotateAPIKey: %v", err)
+	}
+	if key.KeyHint != "abcd" || !key.RotatedAt.Valid || len(key.Scopes) != 2 {
+		t.Fatalf("unexpected key %+v", key)
+	}
+	if _, err := s.RotateAPIKey(context.Background(), nil, userID, keyID, "again", "efgh"); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a revoked key, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRevokeAPIKey_NotFoundForOtherUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &apiKeyStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	userID, keyID := uuid.New(), uuid.New()
+	mock.ExpectExec(`UPDATE api_keys SET revoked_at = COALESCE\(revoked_at, NOW\(\)\)`).
+		WithArgs(keyID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := s.RevokeAPIKey(context.Background(), nil, userID, keyID); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
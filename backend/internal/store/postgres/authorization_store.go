@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// authorizationStorePostgres implements store.AuthorizationStore
+type authorizationStorePostgres struct{ db *sqlx.DB }
+
+// NewAuthorizationStorePostgres creates a new AuthorizationStore backed by PostgreSQL
+func NewAuthorizationStorePostgres(db *sqlx.DB) store.AuthorizationStore {
+	return &authorizationStorePostgres{db: db}
+}
+
+func (s *authorizationStorePostgres) querier(exec store.Querier) store.Querier {
+	if exec != nil {
+		return exec
+	}
+	return s.db
+}
+
+// liveGrant restricts campaign_access_grants (aliased g) to grants that are currently in force.
+const liveGrant = `g.active AND NOT g.revoked AND (g.expires_at IS NULL OR g.expires_at > NOW())`
+
+const campaignAccessGrantColumns = `g.id, g.campaign_id, g.user_id, COALESCE(u.email, '') AS user_email, g.access_type::text AS access_type,
+       g.granted_by, g.granted_at, g.expires_at`
+
+func (s *authorizationStorePostgres) GetUserRole(ctx context.Context, exec store.Querier, userID uuid.UUID) (string, error) {
+	var role string
+	err := s.querier(exec).GetContext(ctx, &role, `SELECT role FROM users WHERE id = $1`, userID)
+	if err == sql.ErrNoRows {
+		return "", store.ErrNotFound
+	}
+	return role, err
+}
+
+func (s *authorizationStorePostgres) SetUserRole(ctx context.Context, exec store.Querier, userID uuid.UUID, role string) error {
+	res, err := s.querier(exec).ExecContext(ctx, `UPDATE users SET role = $2, updated_at = NOW() WHERE id = $1`, userID, role)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *authorizationStorePostgres) GetCampaignAccess(ctx context.Context, exec store.Querier, campaignID, userID uuid.UUID) (*models.CampaignAccess, error) {
+	access := &models.CampaignAccess{}
+	err := s.querier(exec).GetContext(ctx, access, `SELECT c.user_id AS owner_id,
+              ARRAY(SELECT g.access_type::text FROM campaign_access_grants g
+                     WHERE g.campaign_id = c.id AND g.user_id = $2 AND `+liveGrant+`) AS grant_types
+              FROM lead_generation_campaigns c WHERE c.id = $1`, campaignID, userID)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	return access, err
+}
+
+func (s *authorizationStorePostgres) ListCampaignAccessGrants(ctx context.Context, exec store.Querier, campaignID uuid.UUID) ([]*models.CampaignAccessGrant, error) {
+	grants := []*models.CampaignAccessGrant{}
+	err := s.querier(exec).SelectContext(ctx, &grants, `SELECT `+campaignAccessGrantColumns+`
+              FROM campaign_access_grants g LEFT JOIN users u ON u.id = g.user_id
+              WHERE g.campaign_id = $1 AND `+liveGrant+`
+              ORDER BY g.granted_at, g.id`, campaignID)
+	return grants, err
+}
+
+func (s *authorizationStorePostgres) GrantCampaignAccess(ctx context.Context, exec store.Querier, campaignID, userID, grantedBy uuid.UUID, level string, expiresAt *time.Time) (*models.CampaignAccessGrant, error) {
+	// One statement so the previous level is never revoked without the new one taking its place
+	grant := &models.CampaignAccessGrant{}
+	err := s.querier(exec).GetContext(ctx, grant, `WITH replaced AS (
+                  UPDATE campaign_access_grants
+                     SET active = FALSE, revoked = TRUE, revoked_at = NOW(), revoked_by = $3, revocation_reason = 'replaced'
+                   WHERE campaign_id = $1 AND user_id = $2 AND access_type <> $4::access_grant_type_enum AND active AND NOT revoked
+              ), g AS (
+                  INSERT INTO campaign_access_grants (campaign_id, user_id, access_type, granted_by, granted_at, expires_at)
+                  VALUES ($1, $2, $4::access_grant_type_enum, $3, NOW(), $5)
+                  ON CONFLICT (campaign_id, user_id, access_type) DO UPDATE
+                     SET granted_by = EXCLUDED.granted_by, granted_at = EXCLUDED.granted_at, expires_at = EXCLUDED.expires_at,
+                         active = TRUE, revoked = FALSE, revoked_at = NULL, revoked_by = NULL, revocation_reason = NULL
+                  RETURNING *
+              )
+              SELECT `+campaignAccessGrantColumns+` FROM g LEFT JOIN users u ON u.id = g.user_id`,
+		campaignID, userID, grantedBy, models.GrantTypeForCampaignAccess(level), expiresAt)
+	return grant, err
+}
+
+func (s *authorizationStorePostgres) RevokeCampaignAccess(ctx context.Context, exec store.Querier, campaignID, userID, revokedBy uuid.UUID) error {
+	res, err := s.querier(exec).ExecContext(ctx, `UPDATE campaign_access_grants g
+                 SET active = FALSE, revoked = TRUE, revoked_at = NOW(), revoked_by = $3
+               WHERE g.campaign_id = $1 AND g.user_id = $2 AND `+liveGrant, campaignID, userID, revokedBy)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *authorizationStorePostgres) RecordAuthorizationDecision(ctx context.Context, exec store.Querier, d *models.AuthorizationDecision) error {
+	// campaign_id is a foreign key, so it is only filled in when the campaign still exists
+	var campaignID uuid.NullUUID
+	if d.ResourceType == "campaign" {
+		campaignID = d.ResourceID
+	}
+	_, err := s.querier(exec).ExecContext(ctx, `INSERT INTO authorization_decisions
+              (user_id, resource_type, resource_id, action, decision, ip_address, user_agent, request_method, request_path,
+               policy_evaluated, permissions_checked, reason, evaluation_time_ms, campaign_id)
+              VALUES ($1, $2::authorization_resource_type_enum, $3, $4, $5::authorization_decision_enum, $6::inet, $7, $8, $9,
+                      $10, $11, $12, $13, (SELECT id FROM lead_generation_campaigns WHERE id = $14))`,
+		d.UserID, d.ResourceType, d.ResourceID, d.Action, d.Decision, d.IPAddress, d.UserAgent, d.RequestMethod, d.RequestPath,
+		d.PolicyEvaluated, d.PermissionsChecked, d.Reason, d.EvaluationTimeMs, campaignID)
+	return err
+}
+
+var _ store.AuthorizationStore = (*authorizationStorePostgres)(nil)
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+)
+
+func TestGrantCampaignAccess_StoresControlAsWrite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &authorizationStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	campaignID, userID, grantedBy, grantID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+	mock.ExpectQuery(`WITH replaced AS \(\s*UPDATE campaign_access_grants.*access_type <> \$4::access_grant_type_enum.*ON CONFLICT \(campaign_id, user_id, access_type\) DO UPDATE`).
+		WithArgs(campaignID, userID, grantedBy, "write", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "user_id", "user_email", "access_type", "granted_by", "granted_at", "expires_at"}).
+			AddRow(grantID, campaignID, userID, "ops@example.com", "write", grantedBy, now, nil))
+
+	grant, err := s.GrantCampaignAccess(context.Background(), nil, campaignID, userID, grantedBy, models.CampaignAccessControl, nil)
+	if err != nil {
+		t.Fatalf("GrantCampaignAccess: %v", err)
+	}
+	if grant.Level() != models.CampaignAccessControl || grant.UserEmail != "ops@example.com" {
+		t.Fatalf("unexpected grant %+v", grant)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestGetCampaignAccess_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &authorizationStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	campaignID, userID, ownerID := uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectQuery(`SELECT c.user_id AS owner_id`).
+		WithArgs(campaignID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"owner_id", "grant_types"}).AddRow(ownerID, "{read,write}"))
+	mock.ExpectQuery(`SELECT c.user_id AS owner_id`).
+		WithArgs(campaignID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"owner_id", "grant_types"}))
+
+	access, err := s.GetCampaignAccess(context.Background(), nil, campaignID, userID)
+	if err != nil {
+		t.Fatalf("GetCampaignAccess: %v", err)
+	}
+	if got := access.Level(userID, models.RoleOperator); got != models.CampaignAccessControl {
+		t.Fatalf("operator level = %q, want control", got)
+	}
+	if got := access.Level(userID, models.RoleViewer); got != models.CampaignAccessRead {
+		t.Fatalf("viewer level = %q, want read", got)
+	}
+	if _, err := s.GetCampaignAccess(context.Background(), nil, campaignID, userID); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing campaign, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// bulkOperationStorePostgres implements store.BulkOperationStore
+type bulkOperationStorePostgres struct{ db *sqlx.DB }
+
+// NewBulkOperationStorePostgres creates a new BulkOperationStore backed by PostgreSQL
+func NewBulkOperationStorePostgres(db *sqlx.DB) store.BulkOperationStore {
+	return &bulkOperationStorePostgres{db: db}
+}
+
+func (s *bulkOperationStorePostgres) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return s.db.BeginTxx(ctx, opts)
+}
+
+func (s *bulkOperationStorePostgres) querier(exec store.Querier) store.Querier {
+	if exec != nil {
+		return exec
+	}
+	return s.db
+}
+
+const bulkOperationColumns = `o.id, o.operation_type, o.status, o.cancel_requested, o.progress, o.created_at, o.updated_at, o.completed_at,
+              (SELECT COUNT(*) FROM bulk_operation_tasks t WHERE t.operation_id = o.id) AS campaign_count`
+
+func (s *bulkOperationStorePostgres) CreateBulkOperation(ctx context.Context, exec store.Querier, op *models.BulkOperation, tasks []*models.BulkOperationTask) error {
+	q := s.querier(exec)
+	_, err := q.ExecContext(ctx, `INSERT INTO bulk_operations (id, operation_type, status, cancel_requested, progress, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		op.ID, string(op.Type), string(op.Status), op.CancelRequested, op.Progress, op.CreatedAt, op.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		// config is passed as text so lib/pq does not encode the raw bytes as bytea
+		var cfg sql.NullString
+		if t.Config != nil {
+			cfg = sql.NullString{String: string(*t.Config), Valid: true}
+		}
+		_, err := q.ExecContext(ctx, `INSERT INTO bulk_operation_tasks (operation_id, campaign_id, position, status, config, last_error, started_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5::jsonb, $6, $7, $8)`,
+			t.OperationID, t.CampaignID, t.Position, string(t.Status), cfg, t.LastError, t.StartedAt, t.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *bulkOperationStorePostgres) GetBulkOperation(ctx context.Context, exec store.Querier, id uuid.UUID) (*models.BulkOperation, error) {
+	op := &models.BulkOperation{}
+	err := s.querier(exec).GetContext(ctx, op, `SELECT `+bulkOperationColumns+` FROM bulk_operations o WHERE o.id = $1`, id)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	return op, err
+}
+
+func (s *bulkOperationStorePostgres) ListBulkOperations(ctx context.Context, exec store.Querier, filter store.ListBulkOperationsFilter) ([]*models.BulkOperation, int64, error) {
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.Type != nil {
+		conditions = append(conditions, "o.operation_type = ?")
+		args = append(args, string(*filter.Type))
+	}
+	if filter.Status != nil {
+		conditions = append(conditions, "o.status = ?")
+		args = append(args, string(*filter.Status))
+	}
+	if filter.CreatedAfter != nil {
+		conditions = append(conditions, "o.created_at >= ?")
+		args = append(args, *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		conditions = append(conditions, "o.created_at < ?")
+		args = append(args, *filter.CreatedBefore)
+	}
+	if filter.AccessibleTo != nil {
+		conditions = append(conditions, `NOT EXISTS (SELECT 1 FROM bulk_operation_tasks t JOIN lead_generation_campaigns c ON c.id = t.campaign_id
+              WHERE t.operation_id = o.id AND c.user_id IS DISTINCT FROM ?
+                AND NOT EXISTS (SELECT 1 FROM campaign_access_grants g WHERE g.campaign_id = c.id AND g.user_id = ? AND `+liveGrant+`))`)
+		args = append(args, *filter.AccessibleTo, *filter.AccessibleTo)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	q := s.querier(exec)
+	var total int64
+	if err := q.GetContext(ctx, &total, sqlx.Rebind(sqlx.DOLLAR, `SELECT COUNT(*) FROM bulk_operations o`+where), args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + bulkOperationColumns + ` FROM bulk_operations o` + where + ` ORDER BY o.created_at DESC, o.id`
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query += " OFFSET ?"
+		args = append(args, filter.Offset)
+	}
+	ops := []*models.BulkOperation{}
+	err := q.SelectContext(ctx, &ops, sqlx.Rebind(sqlx.DOLLAR, query), args...)
+	return ops, total, err
+}
+
+func (s *bulkOperationStorePostgres) ListBulkOperationTasks(ctx context.Context, exec store.Querier, operationID uuid.UUID) ([]*models.BulkOperationTask, error) {
+	tasks := []*models.BulkOperationTask{}
+	err := s.querier(exec).SelectContext(ctx, &tasks, `SELECT * FROM bulk_operation_tasks WHERE operation_id = $1 ORDER BY position`, operationID)
+	return tasks, err
+}
+
+func (s *bulkOperationStorePostgres) UpdateBulkOperationProgress(ctx context.Context, exec store.Querier, id uuid.UUID, status models.BulkOperationStatusEnum, progress float64) error {
+	_, err := s.querier(exec).ExecContext(ctx, `UPDATE bulk_operations
+              SET status = $2, progress = $3, updated_at = NOW(),
+                  completed_at = CASE WHEN $4 THEN COALESCE(completed_at, NOW()) ELSE NULL END
+              WHERE id = $1`, id, string(status), progress, status.IsTerminal())
+	return err
+}
+
+func (s *bulkOperationStorePostgres) UpdateBulkOperationTask(ctx context.Context, exec store.Querier, task *models.BulkOperationTask) error {
+	task.UpdatedAt = time.Now().UTC()
+	task.ClaimedUntil = sql.NullTime{}
+	_, err := s.querier(exec).ExecContext(ctx, `UPDATE bulk_operation_tasks
+              SET status = $3, items_processed = $4, items_total = $5, last_error = $6, started_at = $7, claimed_until = NULL, updated_at = $8
+              WHERE operation_id = $1 AND campaign_id = $2`,
+		task.OperationID, task.CampaignID, string(task.Status), task.ItemsProcessed, task.ItemsTotal, task.LastError, task.StartedAt, task.UpdatedAt)
+	return err
+}
+
+func (s *bulkOperationStorePostgres) RequestBulkOperationCancel(ctx context.Context, exec store.Querier, id uuid.UUID) error {
+	q := s.querier(exec)
+	res, err := q.ExecContext(ctx, `UPDATE bulk_operations
+              SET cancel_requested = TRUE, status = 'cancelled', updated_at = NOW(), completed_at = COALESCE(completed_at, NOW())
+              WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return store.ErrNotFound
+	}
+	_, err = q.ExecContext(ctx, `UPDATE bulk_operation_tasks SET status = 'cancelled', claimed_until = NULL, updated_at = NOW()
+              WHERE operation_id = $1 AND status = 'pending'`, id)
+	return err
+}
+
+func (s *bulkOperationStorePostgres) ClaimPendingBulkOperationTasks(ctx context.Context, exec store.Querier, operationID *uuid.UUID, limit int, lease time.Duration) ([]*models.BulkOperationTask, error) {
+	var opID uuid.NullUUID
+	if operationID != nil {
+		opID = uuid.NullUUID{UUID: *operationID, Valid: true}
+	}
+	tasks := []*models.BulkOperationTask{}
+	query := `UPDATE bulk_operation_tasks SET claimed_until = NOW() + make_interval(secs => $3)
+              WHERE (operation_id, campaign_id) IN (
+                  SELECT t.operation_id, t.campaign_id FROM bulk_operation_tasks t
+                  JOIN bulk_operations o ON o.id = t.operation_id
+                  WHERE t.status = 'pending' AND NOT o.cancel_requested
+                    AND ($1::uuid IS NULL OR t.operation_id = $1)
+                    AND (t.claimed_until IS NULL OR t.claimed_until < NOW())
+                  ORDER BY o.created_at, t.position
+                  LIMIT $2
+                  FOR UPDATE OF t SKIP LOCKED
+              )
+              RETURNING *`
+	err := s.querier(exec).SelectContext(ctx, &tasks, query, opID, limit, lease.Seconds())
+	return tasks, err
+}
+
+var _ store.BulkOperationStore = (*bulkOperationStorePostgres)(nil)
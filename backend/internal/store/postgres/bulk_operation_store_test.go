@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+)
+
+func TestListBulkOperations_FiltersAndPages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &bulkOperationStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	typ := models.BulkOperationTypeDNSValidation
+	status := models.BulkOperationStatusRunning
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	opID := uuid.New()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM bulk_operations o WHERE o.operation_type = \$1 AND o.status = \$2 AND o.created_at >= \$3`).
+		WithArgs("dns_validation", "running", after).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+	mock.ExpectQuery(`FROM bulk_operations o WHERE o.operation_type = \$1 AND o.status = \$2 AND o.created_at >= \$3 ORDER BY o.created_at DESC, o.id LIMIT \$4 OFFSET \$5`).
+		WithArgs("dns_validation", "running", after, 5, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "operation_type", "status", "cancel_requested", "progress", "created_at", "updated_at", "completed_at", "campaign_count"}).
+			AddRow(opID, "dns_validation", "running", false, 42.5, after, after, nil, 3))
+
+	ops, total, err := s.ListBulkOperations(context.Background(), nil, store.ListBulkOperationsFilter{
+		Type: &typ, Status: &status, CreatedAfter: &after, Limit: 5, Offset: 5,
+	})
+	if err != nil {
+		t.Fatalf("ListBulkOperations: %v", err)
+	}
+	if total != 7 || len(ops) != 1 || ops[0].ID != opID || ops[0].CampaignCount != 3 || ops[0].Progress != 42.5 {
+		t.Fatalf("unexpected page total=%d ops=%+v", total, ops)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestListBulkOperations_AccessibleTo(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &bulkOperationStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	userID := uuid.New()
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM bulk_operations o WHERE NOT EXISTS \(SELECT 1 FROM bulk_operation_tasks t .+c.user_id IS DISTINCT FROM \$1.+g.user_id = \$2`).
+		WithArgs(userID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`FROM bulk_operations o WHERE NOT EXISTS .+ ORDER BY o.created_at DESC, o.id LIMIT \$3`).
+		WithArgs(userID, userID, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	if _, _, err := s.ListBulkOperations(context.Background(), nil, store.ListBulkOperationsFilter{AccessibleTo: &userID, Limit: 10}); err != nil {
+		t.Fatalf("ListBulkOperations: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestRequestBulkOperationCancel(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &bulkOperationStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	opID := uuid.New()
+	mock.ExpectExec(`UPDATE bulk_operations\s+SET cancel_requested = TRUE`).WithArgs(opID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE bulk_operation_tasks SET status = 'cancelled'.*status = 'pending'`).WithArgs(opID).WillReturnResult(sqlmock.NewResult(0, 2))
+	if err := s.RequestBulkOperationCancel(context.Background(), nil, opID); err != nil {
+		t.Fatalf("RequestBulkOperationCancel: %v", err)
+	}
+
+	missing := uuid.New()
+	mock.ExpectExec(`UPDATE bulk_operations\s+SET cancel_requested = TRUE`).WithArgs(missing).WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := s.RequestBulkOperationCancel(context.Background(), nil, missing); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestClaimPendingBulkOperationTasks_SkipsCancelledAndLeased(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &bulkOperationStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	mock.ExpectQuery(`UPDATE bulk_operation_tasks SET claimed_until = NOW\(\) \+ make_interval\(secs => \$3\)(?s:.*)NOT o.cancel_requested(?s:.*)t.claimed_until < NOW\(\)(?s:.*)FOR UPDATE OF t SKIP LOCKED`).
+		WithArgs(uuid.NullUUID{}, 20, float64(60)).
+		WillReturnRows(sqlmock.NewRows([]string{"operation_id", "campaign_id", "position", "status"}).AddRow(uuid.New(), uuid.New(), 0, "pending"))
+
+	tasks, err := s.ClaimPendingBulkOperationTasks(context.Background(), nil, nil, 20, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimPendingBulkOperationTasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Status != models.BulkOperationStatusPending {
+		t.Fatalf("unexpected claimed tasks %+v", tasks)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
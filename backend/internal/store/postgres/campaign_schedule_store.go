@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// campaignScheduleStorePostgres implements store.CampaignScheduleStore
+type campaignScheduleStorePostgres struct{ db *sqlx.DB }
+
+// NewCampaignScheduleStorePostgres creates a new CampaignScheduleStore backed by PostgreSQL
+func NewCampaignScheduleStorePostgres(db *sqlx.DB) store.CampaignScheduleStore {
+	return &campaignScheduleStorePostgres{db: db}
+}
+
+func (s *campaignScheduleStorePostgres) querier(exec store.Querier) store.Querier {
+	if exec != nil {
+		return exec
+	}
+	return s.db
+}
+
+func (s *campaignScheduleStorePostgres) CreateCampaignSchedule(ctx context.Context, exec store.Querier, schedule *models.CampaignSchedule) error {
+	query := `INSERT INTO campaign_schedules (id, campaign_id, user_id, cron_expression, timezone, action, phase, is_enabled, next_run_at, created_at, updated_at)
+              VALUES (:id, :campaign_id, :user_id, :cron_expression, :timezone, :action, :phase, :is_enabled, :next_run_at, :created_at, :updated_at)`
+	_, err := s.querier(exec).NamedExecContext(ctx, query, schedule)
+	return err
+}
+
+func (s *campaignScheduleStorePostgres) GetCampaignSchedule(ctx context.Context, exec store.Querier, campaignID, id uuid.UUID) (*models.CampaignSchedule, error) {
+	schedule := &models.CampaignSchedule{}
+	err := s.querier(exec).GetContext(ctx, schedule, `SELECT * FROM campaign_schedules WHERE id=$1 AND campaign_id=$2`, id, campaignID)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	return schedule, err
+}
+
+func (s *campaignScheduleStorePostgres) ListCampaignSchedules(ctx context.Context, exec store.Querier, campaignID uuid.UUID) ([]*models.CampaignSchedule, error) {
+	schedules := []*models.CampaignSchedule{}
+	err := s.querier(exec).SelectContext(ctx, &schedules, `SELECT * FROM campaign_schedules WHERE campaign_id=$1 ORDER BY created_at`, campaignID)
+	return schedules, err
+}
+
+func (s *campaignScheduleStorePostgres) UpdateCampaignSchedule(ctx context.Context, exec store.Querier, schedule *models.CampaignSchedule) error {
+	schedule.UpdatedAt = time.Now().UTC()
+	query := `UPDATE campaign_schedules SET cron_expression=:cron_expression, timezone=:timezone, action=:action, phase=:phase,
+              is_enabled=:is_enabled, next_run_at=:next_run_at, updated_at=:updated_at WHERE id=:id AND campaign_id=:campaign_id`
+	result, err := s.querier(exec).NamedExecContext(ctx, query, schedule)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *campaignScheduleStorePostgres) DeleteCampaignSchedule(ctx context.Context, exec store.Querier, campaignID, id uuid.UUID) error {
+	res, err := s.querier(exec).ExecContext(ctx, `DELETE FROM campaign_schedules WHERE id=$1 AND campaign_id=$2`, id, campaignID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *campaignScheduleStorePostgres) ClaimDueCampaignSchedules(ctx context.Context, exec store.Querier, now time.Time, limit int, lease time.Duration) ([]*models.CampaignSchedule, error) {
+	schedules := []*models.CampaignSchedule{}
+	query := `UPDATE campaign_schedules SET locked_until = $1::timestamptz + make_interval(secs => $3)
+              WHERE id IN (
+                  SELECT id FROM campaign_schedules
+                  WHERE is_enabled AND next_run_at <= $1 AND (locked_until IS NULL OR locked_until < $1)
+                  ORDER BY next_run_at
+                  LIMIT $2
+                  FOR UPDATE SKIP LOCKED
+              )
+              RETURNING *`
+	err := s.querier(exec).SelectContext(ctx, &schedules, query, now, limit, lease.Seconds())
+	return schedules, err
+}
+
+func (s *campaignScheduleStorePostgres) RecordCampaignScheduleRun(ctx context.Context, exec store.Querier, claimed *models.CampaignSchedule, ranAt time.Time, status models.CampaignScheduleRunStatusEnum, lastError string, nextRunAt *time.Time) error {
+	var next sql.NullTime
+	if nextRunAt != nil {
+		next = sql.NullTime{Time: *nextRunAt, Valid: true}
+	}
+	var errText sql.NullString
+	if lastError != "" {
+		errText = sql.NullString{String: lastError, Valid: true}
+	}
+	_, err := s.querier(exec).ExecContext(ctx, `UPDATE campaign_schedules
+              SET last_run_at=$2, last_run_status=$3, last_error=$4, locked_until=NULL,
+                  next_run_at = CASE WHEN updated_at = $6 THEN $5 ELSE next_run_at END
+              WHERE id=$1`, claimed.ID, ranAt, string(status), errText, next, claimed.UpdatedAt)
+	return err
+}
+
+var _ store.CampaignScheduleStore = (*campaignScheduleStorePostgres)(nil)
@@ -331,6 +331,10 @@ func (s *campaignStorePostgres) ListCampaigns(ctx context.Context, exec store.Qu
 		conditions = append(conditions, "user_id = ?")
 		args = append(args, filter.UserID)
 	}
+	if filter.AccessibleTo != "" {
+		conditions = append(conditions, "(user_id = ? OR id IN (SELECT g.campaign_id FROM campaign_access_grants g WHERE g.user_id = ? AND "+liveGrant+"))")
+		args = append(args, filter.AccessibleTo, filter.AccessibleTo)
+	}
 
 	finalQuery := baseQuery
 	if len(conditions) > 0 {
@@ -412,6 +416,10 @@ func (s *campaignStorePostgres) CountCampaigns(ctx context.Context, exec store.Q
 		conditions = append(conditions, "user_id = ?")
 		args = append(args, filter.UserID)
 	}
+	if filter.AccessibleTo != "" {
+		conditions = append(conditions, "(user_id = ? OR id IN (SELECT g.campaign_id FROM campaign_access_grants g WHERE g.user_id = ? AND "+liveGrant+"))")
+		args = append(args, filter.AccessibleTo, filter.AccessibleTo)
+	}
 
 	finalQuery := baseQuery
 	if len(conditions) > 0 {
@@ -611,7 +619,7 @@ func (s *campaignStorePostgres) GetGeneratedDomainsByCampaign(ctx context.Contex
 	if exec == nil {
 		exec = s.db
 	}
-	base := `SELECT id, campaign_id, domain_name, source_keyword, source_pattern, tld, offset_index, generated_at, created_at, dns_status, dns_ip, http_status, http_status_code, http_title, http_keywords, lead_score, lead_status, last_validated_at, dns_reason, http_reason, rejection_reason FROM generated_domains`
+	base := `SELECT id, campaign_id, domain_name, source_keyword, source_pattern, tld, offset_index, generated_at, created_at, dns_status, dns_ip, http_status, http_status_code, http_title, http_keywords, lead_score, lead_status, last_validated_at, dns_reason, http_reason, rejection_reason, rdap_status, rdap_registrar, rdap_registered_at, rdap_expires_at, rdap_checked_at, tls_certificate, contacts FROM generated_domains`
 	conditions := []string{"campaign_id = $1", "offset_index >= $2"}
 	args := []interface{}{campaignID, lastOffsetIndex}
 	argPos := 3
@@ -656,6 +664,31 @@ func (s *campaignStorePostgres) GetGeneratedDomainsByCampaign(ctx context.Contex
 			args = append(args, *filter.RejectionReason)
 			argPos++
 		}
+		if filter.ChangedSinceRun != nil {
+			conditions = append(conditions, fmt.Sprintf(changedSinceRunCondition, argPos))
+			args = append(args, *filter.ChangedSinceRun)
+			argPos++
+		}
+		if filter.RDAPStatus != nil {
+			conditions = append(conditions, fmt.Sprintf("rdap_status = $%d", argPos))
+			args = append(args, *filter.RDAPStatus)
+			argPos++
+		}
+		if filter.RegisteredBefore != nil {
+			conditions = append(conditions, fmt.Sprintf("rdap_registered_at < $%d", argPos))
+			args = append(args, *filter.RegisteredBefore)
+			argPos++
+		}
+		if filter.RegisteredAfter != nil {
+			conditions = append(conditions, fmt.Sprintf("rdap_registered_at > $%d", argPos))
+			args = append(args, *filter.RegisteredAfter)
+			argPos++
+		}
+		if filter.ExpiresBefore != nil {
+			conditions = append(conditions, fmt.Sprintf("rdap_expires_at < $%d", argPos))
+			args = append(args, *filter.ExpiresBefore)
+			argPos++
+		}
 	}
 	query := base + " WHERE " + strings.Join(conditions, " AND ") + " ORDER BY offset_index ASC LIMIT $" + fmt.Sprint(argPos)
 	args = append(args, limit)
@@ -948,6 +981,8 @@ func (s *campaignStorePostgres) CreateDNSValidationResults(ctx context.Context,
 			dnsStatus = models.DomainDNSStatusTimeout
 		case "error":
 			dnsStatus = models.DomainDNSStatusError
+		case "wildcard":
+			dnsStatus = models.DomainDNSStatusWildcard
 		default:
 			// Only truly unknown statuses should remain pending
 			log.Printf("WARNING [CreateDNSValidationResults]: Unknown validation status '%s' for domain %s, setting to pending", result.ValidationStatus, result.DomainName)
@@ -982,6 +1017,9 @@ func (s *campaignStorePostgres) GetDNSValidationResultsByCampaign(ctx context.Co
 		case "unresolved", "timeout", "error":
 			query += " AND dns_status = $2"
 			args = append(args, models.DomainDNSStatusError)
+		case "wildcard":
+			query += " AND dns_status = $2"
+			args = append(args, models.DomainDNSStatusWildcard)
 		case "pending":
 			query += " AND dns_status = $2"
 			args = append(args, models.DomainDNSStatusPending)
@@ -1010,6 +1048,8 @@ func (s *campaignStorePostgres) GetDNSValidationResultsByCampaign(ctx context.Co
 			validationStatus = "resolved"
 		case domain.DNSStatus != nil && *domain.DNSStatus == models.DomainDNSStatusError:
 			validationStatus = "unresolved"
+		case domain.DNSStatus != nil && *domain.DNSStatus == models.DomainDNSStatusWildcard:
+			validationStatus = "wildcard"
 		default:
 			validationStatus = "pending"
 		}
@@ -1730,18 +1770,45 @@ func (s *campaignStorePostgres) UpdateDomainsBulkDNSStatus(ctx context.Context,
 	if exec == nil {
 		exec = s.db
 	}
+	// Per-type DNS answers are only written when the batch carries them, so callers that never
+	// query records leave previously stored answers untouched.
+	withRecords := false
+	for _, r := range results {
+		if r.DNSRecords != nil {
+			withRecords = true
+			break
+		}
+	}
+	cols := 4
+	if withRecords {
+		cols = 5
+	}
 	// Build dynamic VALUES list
 	valueStrings := make([]string, 0, len(results))
-	valueArgs := make([]interface{}, 0, len(results)*4)
+	valueArgs := make([]interface{}, 0, len(results)*cols)
 	for i, r := range results {
-		idx := i * 4
+		idx := i * cols
 		ts := r.LastCheckedAt
+		if withRecords {
+			var records interface{}
+			if r.DNSRecords != nil {
+				records = string(*r.DNSRecords)
+			}
+			valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d)", idx+1, idx+2, idx+3, idx+4, idx+5))
+			valueArgs = append(valueArgs, r.DomainName, r.ValidationStatus, ts, r.Reason, records)
+			continue
+		}
 		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d)", idx+1, idx+2, idx+3, idx+4))
 		valueArgs = append(valueArgs, r.DomainName, r.ValidationStatus, ts, r.Reason)
 	}
 	tmp := strings.Join(valueStrings, ",")
+	recordsSet, valueCols := "", "domain_name,validation_status,last_checked_at,reason"
+	if withRecords {
+		recordsSet = ",\n\t\t    dns_records = v.dns_records::jsonb"
+		valueCols += ",dns_records"
+	}
 	// NOTE: Cast validation_status (text) to domain_dns_status_enum to satisfy PostgreSQL enum type
-	// P0-3: Set rejection_reason ONLY for terminal DNS errors (dns_error, dns_timeout)
+	// P0-3: Set rejection_reason ONLY for terminal DNS errors (dns_error, dns_timeout, dns_wildcard, dns_missing_records)
 	// Do NOT overwrite existing non-pending rejection_reason on other paths
 	q := fmt.Sprintf(`UPDATE generated_domains gd
 		SET dns_status = v.validation_status::domain_dns_status_enum,
@@ -1752,12 +1819,14 @@ func (s *campaignStorePostgres) UpdateDomainsBulkDNSStatus(ctx context.Context,
 		    END,
 		    dns_reason = CASE WHEN v.validation_status = 'ok' THEN NULL ELSE COALESCE(v.reason, gd.dns_reason) END,
 		    rejection_reason = CASE
+		        WHEN v.validation_status = 'wildcard' THEN 'dns_wildcard'::domain_rejection_reason_enum
+		        WHEN v.validation_status = 'error' AND v.reason LIKE 'MISSING_RECORDS:%%' THEN 'dns_missing_records'::domain_rejection_reason_enum
 		        WHEN v.validation_status = 'timeout' THEN 'dns_timeout'::domain_rejection_reason_enum
 		        WHEN v.validation_status = 'error' THEN 'dns_error'::domain_rejection_reason_enum
 		        ELSE gd.rejection_reason
-		    END
-		FROM (VALUES %s) AS v(domain_name,validation_status,last_checked_at,reason)
-		WHERE gd.domain_name = v.domain_name`, tmp)
+		    END%s
+		FROM (VALUES %s) AS v(%s)
+		WHERE gd.domain_name = v.domain_name`, recordsSet, tmp, valueCols)
 	if _, err := exec.ExecContext(ctx, q, valueArgs...); err != nil {
 		return fmt.Errorf("bulk DNS status update failed: %w", err)
 	}
@@ -1773,14 +1842,39 @@ func (s *campaignStorePostgres) UpdateDomainsBulkHTTPStatus(ctx context.Context,
 	if exec == nil {
 		exec = s.db
 	}
+	withTLS := false
+	for _, r := range results {
+		if r.TLSCertificate != nil {
+			withTLS = true
+			break
+		}
+	}
+	cols := 5
+	if withTLS {
+		cols = 6
+	}
 	valueStrings := make([]string, 0, len(results))
-	valueArgs := make([]interface{}, 0, len(results)*5)
+	valueArgs := make([]interface{}, 0, len(results)*cols)
 	for i, r := range results {
-		idx := i * 5
+		idx := i * cols
+		if withTLS {
+			var cert interface{}
+			if r.TLSCertificate != nil {
+				cert = string(*r.TLSCertificate)
+			}
+			valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d)", idx+1, idx+2, idx+3, idx+4, idx+5, idx+6))
+			valueArgs = append(valueArgs, r.DomainName, r.ValidationStatus, r.HTTPStatusCode, r.LastCheckedAt, r.Reason, cert)
+			continue
+		}
 		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d)", idx+1, idx+2, idx+3, idx+4, idx+5))
 		valueArgs = append(valueArgs, r.DomainName, r.ValidationStatus, r.HTTPStatusCode, r.LastCheckedAt, r.Reason)
 	}
 	tmp := strings.Join(valueStrings, ",")
+	tlsSet, valueCols := "", "domain_name,validation_status,http_status_code,last_checked_at,reason"
+	if withTLS {
+		tlsSet = ",\n\t\t\ttls_certificate = v.tls_certificate::jsonb"
+		valueCols += ",tls_certificate"
+	}
 	// Cast validation_status to enum and timestamp; only set http_reason when status not 'ok'
 	// P0-3: Set rejection_reason deterministically based on HTTP validation outcome
 	q := fmt.Sprintf(`UPDATE generated_domains gd
@@ -1800,9 +1894,9 @@ func (s *campaignStorePostgres) UpdateDomainsBulkHTTPStatus(ctx context.Context,
 				WHEN v.validation_status = 'timeout' THEN 'http_timeout'::domain_rejection_reason_enum
 				WHEN v.validation_status = 'error' THEN 'http_error'::domain_rejection_reason_enum
 				ELSE gd.rejection_reason
-			END
-		FROM (VALUES %s) AS v(domain_name,validation_status,http_status_code,last_checked_at,reason)
-		WHERE gd.domain_name = v.domain_name`, tmp)
+			END%s
+		FROM (VALUES %s) AS v(%s)
+		WHERE gd.domain_name = v.domain_name`, tlsSet, tmp, valueCols)
 	if _, err := exec.ExecContext(ctx, q, valueArgs...); err != nil {
 		return fmt.Errorf("bulk HTTP status update failed: %w", err)
 	}
@@ -2122,6 +2216,8 @@ func phaseTypeDefaultOrder(phaseType models.PhaseTypeEnum) int {
 		return 5
 	case models.PhaseTypeEnrichment:
 		return 6
+	case models.PhaseTypeRDAPLookup:
+		return 7
 	default:
 		return 6
 	}
@@ -3052,6 +3148,8 @@ func (s *campaignStorePostgres) GetRejectionSummary(ctx context.Context, exec st
 			COALESCE(COUNT(*) FILTER (WHERE rejection_reason = 'parked'), 0) as parked,
 			COALESCE(COUNT(*) FILTER (WHERE rejection_reason = 'dns_error'), 0) as dns_error,
 			COALESCE(COUNT(*) FILTER (WHERE rejection_reason = 'dns_timeout'), 0) as dns_timeout,
+			COALESCE(COUNT(*) FILTER (WHERE rejection_reason = 'dns_wildcard'), 0) as dns_wildcard,
+			COALESCE(COUNT(*) FILTER (WHERE rejection_reason = 'dns_missing_records'), 0) as dns_missing_records,
 			COALESCE(COUNT(*) FILTER (WHERE rejection_reason = 'http_error'), 0) as http_error,
 			COALESCE(COUNT(*) FILTER (WHERE rejection_reason = 'http_timeout'), 0) as http_timeout,
 			COALESCE(COUNT(*) FILTER (WHERE rejection_reason = 'pending'), 0) as pending,
@@ -3060,16 +3158,18 @@ func (s *campaignStorePostgres) GetRejectionSummary(ctx context.Context, exec st
 		WHERE campaign_id = $1`
 
 	type countsRow struct {
-		Qualified   int64 `db:"qualified"`
-		LowScore    int64 `db:"low_score"`
-		NoKeywords  int64 `db:"no_keywords"`
-		Parked      int64 `db:"parked"`
-		DNSError    int64 `db:"dns_error"`
-		DNSTimeout  int64 `db:"dns_timeout"`
-		HTTPError   int64 `db:"http_error"`
-		HTTPTimeout int64 `db:"http_timeout"`
-		Pending     int64 `db:"pending"`
-		NullCount   int64 `db:"null_count"`
+		Qualified         int64 `db:"qualified"`
+		LowScore          int64 `db:"low_score"`
+		NoKeywords        int64 `db:"no_keywords"`
+		Parked            int64 `db:"parked"`
+		DNSError          int64 `db:"dns_error"`
+		DNSTimeout        int64 `db:"dns_timeout"`
+		DNSWildcard       int64 `db:"dns_wildcard"`
+		DNSMissingRecords int64 `db:"dns_missing_records"`
+		HTTPError         int64 `db:"http_error"`
+		HTTPTimeout       int64 `db:"http_timeout"`
+		Pending           int64 `db:"pending"`
+		NullCount         int64 `db:"null_count"`
 	}
 
 	var row countsRow
@@ -3087,6 +3187,8 @@ func (s *campaignStorePostgres) GetRejectionSummary(ctx context.Context, exec st
 	summary.Counts.Parked = row.Parked
 	summary.Counts.DNSError = row.DNSError
 	summary.Counts.DNSTimeout = row.DNSTimeout
+	summary.Counts.DNSWildcard = row.DNSWildcard
+	summary.Counts.DNSMissingRecords = row.DNSMissingRecords
 	summary.Counts.HTTPError = row.HTTPError
 	summary.Counts.HTTPTimeout = row.HTTPTimeout
 	summary.Counts.Pending = row.Pending
@@ -3094,8 +3196,8 @@ func (s *campaignStorePostgres) GetRejectionSummary(ctx context.Context, exec st
 	// Calculate totals
 	// errors = dns_error + dns_timeout + http_error + http_timeout
 	errors := row.DNSError + row.DNSTimeout + row.HTTPError + row.HTTPTimeout
-	// rejected = low_score + no_keywords + parked + errors
-	rejected := row.LowScore + row.NoKeywords + row.Parked + errors
+	// rejected = low_score + no_keywords + parked + dns_wildcard + dns_missing_records + errors
+	rejected := row.LowScore + row.NoKeywords + row.Parked + row.DNSWildcard + row.DNSMissingRecords + errors
 	// analyzed = all non-pending domains (those that have completed processing)
 	analyzed := row.Qualified + rejected
 
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+)
+
+func TestUpdateDomainsBulkDNSStatusPersistsRecords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	store := &campaignStorePostgres{db: sqlxDB}
+
+	now := time.Now()
+	records := json.RawMessage(`{"A":["192.0.2.1"],"MX":["10 mx.example.com."]}`)
+	reason := "NXDOMAIN"
+	results := []models.DNSValidationResult{
+		{DomainName: "mail.example.com", ValidationStatus: "ok", LastCheckedAt: &now, DNSRecords: &records},
+		{DomainName: "gone.example.com", ValidationStatus: "error", LastCheckedAt: &now, Reason: &reason},
+	}
+
+	mock.ExpectExec(`dns_records = v\.dns_records::jsonb\s+FROM \(VALUES \(\$1,\$2,\$3,\$4,\$5\),\(\$6,\$7,\$8,\$9,\$10\)\) AS v\(domain_name,validation_status,last_checked_at,reason,dns_records\)`).
+		WithArgs("mail.example.com", "ok", &now, nil, string(records), "gone.example.com", "error", &now, &reason, nil).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	if err := store.UpdateDomainsBulkDNSStatus(context.Background(), sqlxDB, results); err != nil {
+		t.Fatalf("UpdateDomainsBulkDNSStatus: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
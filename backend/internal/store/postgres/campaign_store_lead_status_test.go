@@ -47,6 +47,68 @@ func TestUpdateDomainsBulkDNSStatusSetsLeadStatus(t *testing.T) {
 	}
 }
 
+func TestUpdateDomainsBulkDNSStatusMapsMissingRecords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	store := &campaignStorePostgres{db: sqlxDB}
+
+	now := time.Now()
+	reason := "MISSING_RECORDS:MX"
+	results := []models.DNSValidationResult{{
+		DomainName:       "example.com",
+		ValidationStatus: string(models.DomainDNSStatusError),
+		Reason:           &reason,
+		LastCheckedAt:    &now,
+	}}
+
+	mock.ExpectExec(`WHEN v\.validation_status = 'error' AND v\.reason LIKE 'MISSING_RECORDS:%' THEN 'dns_missing_records'::domain_rejection_reason_enum\s+WHEN v\.validation_status = 'timeout'`).
+		WithArgs(results[0].DomainName, results[0].ValidationStatus, results[0].LastCheckedAt, results[0].Reason).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := store.UpdateDomainsBulkDNSStatus(context.Background(), sqlxDB, results); err != nil {
+		t.Fatalf("UpdateDomainsBulkDNSStatus: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestUpdateDomainsBulkDNSStatusMapsWildcard(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	store := &campaignStorePostgres{db: sqlxDB}
+
+	now := time.Now()
+	reason := "SINKHOLE"
+	results := []models.DNSValidationResult{{
+		DomainName:       "parked.example.com",
+		ValidationStatus: string(models.DomainDNSStatusWildcard),
+		Reason:           &reason,
+		LastCheckedAt:    &now,
+	}}
+
+	mock.ExpectExec(`(?s)SET dns_status = v\.validation_status::domain_dns_status_enum.*WHEN v\.validation_status = 'wildcard' THEN 'dns_wildcard'::domain_rejection_reason_enum`).
+		WithArgs(results[0].DomainName, results[0].ValidationStatus, results[0].LastCheckedAt, results[0].Reason).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := store.UpdateDomainsBulkDNSStatus(context.Background(), sqlxDB, results); err != nil {
+		t.Fatalf("UpdateDomainsBulkDNSStatus: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
 func TestUpdateDomainsBulkHTTPStatusSetsLeadStatus(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
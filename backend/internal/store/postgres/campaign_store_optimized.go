@@ -31,7 +31,7 @@ func (s *campaignStorePostgres) GetGeneratedDomainsWithCursor(ctx context.Contex
 	// Extended projection includes scoring + HTTP enrichment fields used for Phase 2 filtering & sorting.
 	// COALESCE(feature_vector,'null') left as raw to distinguish absent vs empty; using NULL will map to nil pointer for *json.RawMessage
 	baseQuery := `SELECT id, campaign_id, domain_name, source_keyword, source_pattern, tld, offset_index, generated_at, created_at,
-		relevance_score, domain_score, is_parked, last_http_fetched_at, feature_vector
+		relevance_score, domain_score, is_parked, last_http_fetched_at, feature_vector, contacts
 		FROM generated_domains
 		WHERE campaign_id = $1`
 
@@ -137,7 +137,7 @@ func (s *campaignStorePostgres) GetGeneratedDomainsWithCursor(ctx context.Contex
 		conditions = append(conditions, "(feature_vector->>'kw_unique')::int > 0")
 	}
 
-	// HasContact filter (placeholder heuristic): treat presence of contact-related unique keyword count kw_contact > 0.
+	// HasContact filter: kw_contact counts the emails, phones and addresses found during enrichment.
 	if filter.WantsHasContact() {
 		conditions = append(conditions, "(feature_vector->>'kw_contact')::int > 0")
 	}
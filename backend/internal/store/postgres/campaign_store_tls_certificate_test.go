@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+)
+
+func TestUpdateDomainsBulkHTTPStatusPersistsTLSCertificate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	store := &campaignStorePostgres{db: sqlxDB}
+
+	now := time.Now()
+	code := int32(200)
+	cert := json.RawMessage(`{"version":"TLS 1.3","subject":"secure.example.com","selfSigned":false,"hostnameMismatch":false}`)
+	results := []models.HTTPKeywordResult{
+		{DomainName: "secure.example.com", ValidationStatus: "ok", HTTPStatusCode: &code, LastCheckedAt: &now, TLSCertificate: &cert},
+		{DomainName: "plain.example.com", ValidationStatus: "ok", HTTPStatusCode: &code, LastCheckedAt: &now},
+	}
+
+	mock.ExpectExec(`tls_certificate = v\.tls_certificate::jsonb\s+FROM \(VALUES \(\$1,\$2,\$3,\$4,\$5,\$6\),\(\$7,\$8,\$9,\$10,\$11,\$12\)\) AS v\(domain_name,validation_status,http_status_code,last_checked_at,reason,tls_certificate\)`).
+		WithArgs("secure.example.com", "ok", &code, &now, nil, string(cert), "plain.example.com", "ok", &code, &now, nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	if err := store.UpdateDomainsBulkHTTPStatus(context.Background(), sqlxDB, results); err != nil {
+		t.Fatalf("UpdateDomainsBulkHTTPStatus: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
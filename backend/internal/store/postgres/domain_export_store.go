@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// domainExportStorePostgres implements store.DomainExportStore
+type domainExportStorePostgres struct{ db *sqlx.DB }
+
+// NewDomainExportStorePostgres creates a new DomainExportStore backed by PostgreSQL
+func NewDomainExportStorePostgres(db *sqlx.DB) store.DomainExportStore {
+	return &domainExportStorePostgres{db: db}
+}
+
+const domainExportColumns = `id, campaign_id, domain_name, source_keyword, source_pattern, tld, offset_index, generated_at, created_at,
+	dns_status, dns_ip, http_status, http_status_code, http_title, http_keywords, lead_status, lead_score, last_validated_at,
+	dns_reason, http_reason, rejection_reason, relevance_score, domain_score, feature_vector, is_parked, parked_confidence,
+	content_lang, last_http_fetched_at, rdap_status, rdap_registrar, rdap_registered_at, rdap_expires_at, rdap_checked_at, contacts`
+
+func (s *domainExportStorePostgres) StreamCampaignDomains(ctx context.Context, campaignID uuid.UUID, filter store.DomainExportFilter, batchSize int, fn func([]*models.GeneratedDomain) error) error {
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	query, args := buildDomainExportQuery(campaignID, filter)
+
+	// Cursors only live inside a transaction; read-only keeps the snapshot consistent for the whole export.
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("begin export tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "DECLARE domain_export_cursor NO SCROLL CURSOR FOR "+query, args...); err != nil {
+		return fmt.Errorf("declare export cursor: %w", err)
+	}
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM domain_export_cursor", batchSize)
+	for {
+		batch := make([]*models.GeneratedDomain, 0, batchSize)
+		if err := tx.SelectContext(ctx, &batch, fetch); err != nil {
+			return fmt.Errorf("fetch export batch: %w", err)
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+	}
+}
+
+// buildDomainExportQuery translates the filter into the cursor query; ordering always ends with id for stability.
+func buildDomainExportQuery(campaignID uuid.UUID, filter store.DomainExportFilter) (string, []interface{}) {
+	conditions := []string{"campaign_id = $1"}
+	args := []interface{}{campaignID}
+	add := func(cond string, v interface{}) {
+		args = append(args, v)
+		conditions = append(conditions, fmt.Sprintf(cond, len(args)))
+	}
+	if filter.DNSStatus != nil {
+		add("dns_status = $%d", *filter.DNSStatus)
+	}
+	if filter.HTTPStatus != nil {
+		add("http_status = $%d", *filter.HTTPStatus)
+	}
+	if filter.DNSReason != nil {
+		add("dns_reason = $%d", *filter.DNSReason)
+	}
+	if filter.HTTPReason != nil {
+		add("http_reason = $%d", *filter.HTTPReason)
+	}
+	if filter.LeadStatus != nil {
+		add("lead_status = $%d", *filter.LeadStatus)
+	}
+	if filter.RejectionReason != nil {
+		add("rejection_reason = $%d", *filter.RejectionReason)
+	}
+	if len(filter.RejectionReasons) > 0 {
+		reasons := make([]string, len(filter.RejectionReasons))
+		for i, r := range filter.RejectionReasons {
+			reasons[i] = string(r)
+		}
+		add("rejection_reason::text = ANY($%d)", pq.Array(reasons))
+	}
+	if filter.ChangedSinceRun != nil {
+		add(changedSinceRunCondition, *filter.ChangedSinceRun)
+	}
+	if filter.RDAPStatus != nil {
+		add("rdap_status = $%d", *filter.RDAPStatus)
+	}
+	if filter.RegisteredBefore != nil {
+		add("rdap_registered_at < $%d", *filter.RegisteredBefore)
+	}
+	if filter.RegisteredAfter != nil {
+		add("rdap_registered_at > $%d", *filter.RegisteredAfter)
+	}
+	if filter.ExpiresBefore != nil {
+		add("rdap_expires_at < $%d", *filter.ExpiresBefore)
+	}
+	if filter.MinScore != nil {
+		add("domain_score IS NOT NULL AND domain_score >= $%d", *filter.MinScore)
+	}
+	if filter.MaxScore != nil {
+		add("domain_score IS NOT NULL AND domain_score <= $%d", *filter.MaxScore)
+	}
+	if filter.NotParked {
+		conditions = append(conditions, "(is_parked IS DISTINCT FROM TRUE)")
+	}
+	if filter.Keyword {
+		conditions = append(conditions, "(feature_vector->>'kw_unique')::int > 0")
+	}
+	if filter.HasContact {
+		conditions = append(conditions, "(feature_vector->>'kw_contact')::int > 0")
+	}
+
+	order := "ASC"
+	if strings.EqualFold(filter.SortOrder, "DESC") {
+		order = "DESC"
+	}
+	var orderBy string
+	switch filter.SortBy {
+	case "domain_score":
+		orderBy = fmt.Sprintf("domain_score %s NULLS LAST, id %s", order, order)
+	case "last_http_fetched_at":
+		orderBy = fmt.Sprintf("last_http_fetched_at %s NULLS LAST, id %s", order, order)
+	case "domain_name":
+		orderBy = fmt.Sprintf("domain_name %s, id %s", order, order)
+	default:
+		orderBy = fmt.Sprintf("offset_index %s, id %s", order, order)
+	}
+	query := "SELECT " + domainExportColumns + " FROM generated_domains WHERE " + strings.Join(conditions, " AND ") + " ORDER BY " + orderBy
+	return query, args
+}
+
+var _ store.DomainExportStore = (*domainExportStorePostgres)(nil)
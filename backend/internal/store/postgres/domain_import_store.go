@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// domainImportStorePostgres implements store.DomainImportStore
+type domainImportStorePostgres struct{ db *sqlx.DB }
+
+// NewDomainImportStorePostgres creates a new DomainImportStore backed by PostgreSQL
+func NewDomainImportStorePostgres(db *sqlx.DB) store.DomainImportStore {
+	return &domainImportStorePostgres{db: db}
+}
+
+func (s *domainImportStorePostgres) querier(exec store.Querier) store.Querier {
+	if exec != nil {
+		return exec
+	}
+	return s.db
+}
+
+func (s *domainImportStorePostgres) StageDomainImport(ctx context.Context, exec store.Querier, campaignID uuid.UUID, domains []string) (int64, error) {
+	if len(domains) == 0 {
+		return 0, nil
+	}
+	// Positions continue after the highest staged or generated offset so repeated uploads keep their order
+	// and never collide with generated_domains.offset_index.
+	query := `WITH base AS (
+			SELECT GREATEST(
+				COALESCE((SELECT MAX(position) FROM campaign_domain_import_entries WHERE campaign_id = $1), -1),
+				COALESCE((SELECT MAX(offset_index) FROM generated_domains WHERE campaign_id = $1), -1)
+			) AS max_pos
+		), incoming AS (
+			SELECT d.domain_name, MIN(d.ord) AS ord
+			FROM unnest($2::text[]) WITH ORDINALITY AS d(domain_name, ord)
+			WHERE NOT EXISTS (
+				SELECT 1 FROM generated_domains g WHERE g.campaign_id = $1 AND g.domain_name = d.domain_name
+			) AND NOT EXISTS (
+				SELECT 1 FROM campaign_domain_import_entries e WHERE e.campaign_id = $1 AND e.domain_name = d.domain_name
+			)
+			GROUP BY d.domain_name
+		)
+		INSERT INTO campaign_domain_import_entries (campaign_id, domain_name, position)
+		SELECT $1, i.domain_name, base.max_pos + ROW_NUMBER() OVER (ORDER BY i.ord)
+		FROM incoming i CROSS JOIN base
+		ON CONFLICT (campaign_id, domain_name) DO NOTHING`
+	res, err := s.querier(exec).ExecContext(ctx, query, campaignID, pq.Array(domains))
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // 23505 is unique_violation
+			return 0, store.ErrDuplicateEntry
+		}
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (s *domainImportStorePostgres) ListStagedImportDomains(ctx context.Context, exec store.Querier, campaignID uuid.UUID, afterPosition int64, limit int) ([]*models.StagedImportDomain, error) {
+	entries := []*models.StagedImportDomain{}
+	if limit <= 0 {
+		limit = 1000
+	}
+	err := s.querier(exec).SelectContext(ctx, &entries,
+		`SELECT campaign_id, domain_name, position, created_at FROM campaign_domain_import_entries e
+		 WHERE campaign_id = $1 AND position > $2
+		   AND NOT EXISTS (SELECT 1 FROM generated_domains g WHERE g.campaign_id = $1 AND g.domain_name = e.domain_name)
+		 ORDER BY position ASC LIMIT $3`,
+		campaignID, afterPosition, limit)
+	return entries, err
+}
+
+func (s *domainImportStorePostgres) CountStagedImportDomains(ctx context.Context, exec store.Querier, campaignID uuid.UUID) (int64, error) {
+	var count int64
+	err := s.querier(exec).GetContext(ctx, &count, `SELECT COUNT(*) FROM campaign_domain_import_entries WHERE campaign_id = $1`, campaignID)
+	return count, err
+}
+
+func (s *domainImportStorePostgres) ClearStagedImportDomains(ctx context.Context, exec store.Querier, campaignID uuid.UUID) error {
+	_, err := s.querier(exec).ExecContext(ctx, `DELETE FROM campaign_domain_import_entries WHERE campaign_id = $1`, campaignID)
+	return err
+}
+
+var _ store.DomainImportStore = (*domainImportStorePostgres)(nil)
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// domainRunSnapshotStorePostgres implements store.DomainRunSnapshotStore
+type domainRunSnapshotStorePostgres struct{ db *sqlx.DB }
+
+// NewDomainRunSnapshotStorePostgres creates a new DomainRunSnapshotStore backed by PostgreSQL
+func NewDomainRunSnapshotStorePostgres(db *sqlx.DB) store.DomainRunSnapshotStore {
+	return &domainRunSnapshotStorePostgres{db: db}
+}
+
+func (s *domainRunSnapshotStorePostgres) querier(exec store.Querier) store.Querier {
+	if exec != nil {
+		return exec
+	}
+	return s.db
+}
+
+// domainContentHashExpr resolves a generated_domains row's latest fetched content hash.
+const domainContentHashExpr = `(SELECT f.content_hash FROM domain_extraction_features f
+     WHERE f.campaign_id = generated_domains.campaign_id AND f.domain_id = generated_domains.id)`
+
+// changedSinceRunCondition matches generated_domains rows whose tracked state differs from their snapshot in
+// the run bound to placeholder $%d (domains missing from the snapshot count as changed). Callers format the
+// placeholder position in.
+const changedSinceRunCondition = `NOT EXISTS (SELECT 1 FROM domain_state_snapshots s
+     WHERE s.run_id = $%d AND s.domain_id = generated_domains.id
+       AND s.dns_status IS NOT DISTINCT FROM generated_domains.dns_status::text
+       AND s.http_status IS NOT DISTINCT FROM generated_domains.http_status::text
+       AND s.lead_status IS NOT DISTINCT FROM generated_domains.lead_status::text
+       AND s.is_parked IS NOT DISTINCT FROM generated_domains.is_parked
+       AND s.domain_score IS NOT DISTINCT FROM generated_domains.domain_score
+       AND s.content_hash IS NOT DISTINCT FROM ` + domainContentHashExpr + `)`
+
+// SnapshotCampaignPhaseRun records the run, its snapshot rows and the domain count atomically. It joins exec
+// when that is already a transaction and otherwise runs in its own.
+func (s *domainRunSnapshotStorePostgres) SnapshotCampaignPhaseRun(ctx context.Context, exec store.Querier, run *models.CampaignPhaseRun) error {
+	if tx, ok := exec.(*sqlx.Tx); ok {
+		return snapshotCampaignPhaseRun(ctx, tx, run)
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin snapshot tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := snapshotCampaignPhaseRun(ctx, tx, run); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func snapshotCampaignPhaseRun(ctx context.Context, q store.Querier, run *models.CampaignPhaseRun) error {
+	res, err := q.ExecContext(ctx, `INSERT INTO campaign_phase_runs (run_id, campaign_id, phase, completed_at)
+              VALUES ($1, $2, $3, $4) ON CONFLICT (run_id) DO NOTHING`,
+		run.RunID, run.CampaignID, string(run.Phase), run.CompletedAt)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return nil
+	}
+	res, err = q.ExecContext(ctx, `INSERT INTO domain_state_snapshots
+              (run_id, domain_id, domain_name, dns_status, http_status, lead_status, is_parked, domain_score, content_hash)
+              SELECT $1, id, domain_name, dns_status::text, http_status::text, lead_status::text, is_parked, domain_score,
+                     `+domainContentHashExpr+`
+              FROM generated_domains WHERE campaign_id = $2`, run.RunID, run.CampaignID)
+	if err != nil {
+		return err
+	}
+	run.DomainCount, _ = res.RowsAffected()
+	_, err = q.ExecContext(ctx, `UPDATE campaign_phase_runs SET domain_count = $2 WHERE run_id = $1`, run.RunID, run.DomainCount)
+	return err
+}
+
+func (s *domainRunSnapshotStorePostgres) GetCampaignPhaseRun(ctx context.Context, exec store.Querier, campaignID, runID uuid.UUID) (*models.CampaignPhaseRun, error) {
+	run := &models.CampaignPhaseRun{}
+	err := s.querier(exec).GetContext(ctx, run, `SELECT * FROM campaign_phase_runs WHERE run_id=$1 AND campaign_id=$2`, runID, campaignID)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	return run, err
+}
+
+func (s *domainRunSnapshotStorePostgres) ListCampaignPhaseRuns(ctx context.Context, exec store.Querier, campaignID uuid.UUID, limit int) ([]*models.CampaignPhaseRun, error) {
+	runs := []*models.CampaignPhaseRun{}
+	err := s.querier(exec).SelectContext(ctx, &runs, `SELECT * FROM campaign_phase_runs WHERE campaign_id=$1
+              ORDER BY completed_at DESC, run_id LIMIT $2`, campaignID, limit)
+	return runs, err
+}
+
+func (s *domainRunSnapshotStorePostgres) GetPreviousCampaignPhaseRun(ctx context.Context, exec store.Querier, run *models.CampaignPhaseRun) (*models.CampaignPhaseRun, error) {
+	prev := &models.CampaignPhaseRun{}
+	err := s.querier(exec).GetContext(ctx, prev, `SELECT * FROM campaign_phase_runs
+              WHERE campaign_id=$1 AND phase=$2 AND run_id<>$3 AND completed_at <= $4
+              ORDER BY completed_at DESC LIMIT 1`, run.CampaignID, string(run.Phase), run.RunID, run.CompletedAt)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	return prev, err
+}
+
+// snapshotPairRow is one FULL OUTER JOIN row of two runs' snapshots; b_ columns belong to the later run.
+type snapshotPairRow struct {
+	ADomainID    uuid.NullUUID   `db:"a_domain_id"`
+	ADomainName  sql.NullString  `db:"a_domain_name"`
+	ADNSStatus   sql.NullString  `db:"a_dns_status"`
+	AHTTPStatus  sql.NullString  `db:"a_http_status"`
+	ALeadStatus  sql.NullString  `db:"a_lead_status"`
+	AIsParked    sql.NullBool    `db:"a_is_parked"`
+	ADomainScore sql.NullFloat64 `db:"a_domain_score"`
+	AContentHash sql.NullString  `db:"a_content_hash"`
+	BDomainID    uuid.NullUUID   `db:"b_domain_id"`
+	BDomainName  sql.NullString  `db:"b_domain_name"`
+	BDNSStatus   sql.NullString  `db:"b_dns_status"`
+	BHTTPStatus  sql.NullString  `db:"b_http_status"`
+	BLeadStatus  sql.NullString  `db:"b_lead_status"`
+	BIsParked    sql.NullBool    `db:"b_is_parked"`
+	BDomainScore sql.NullFloat64 `db:"b_domain_score"`
+	BContentHash sql.NullString  `db:"b_content_hash"`
+}
+
+func (s *domainRunSnapshotStorePostgres) ListChangedDomainSnapshots(ctx context.Context, exec store.Querier, fromRunID, toRunID, afterDomainID uuid.UUID, limit int) ([]*models.DomainStateChange, error) {
+	rows := []snapshotPairRow{}
+	query := `SELECT a.domain_id AS a_domain_id, a.domain_name AS a_domain_name, a.dns_status AS a_dns_status,
+                     a.http_status AS a_http_status, a.lead_status AS a_lead_status, a.is_parked AS a_is_parked,
+                     a.domain_score AS a_domain_score, a.content_hash AS a_content_hash,
+                     b.domain_id AS b_domain_id, b.domain_name AS b_domain_name, b.dns_status AS b_dns_status,
+                     b.http_status AS b_http_status, b.lead_status AS b_lead_status, b.is_parked AS b_is_parked,
+                     b.domain_score AS b_domain_score, b.content_hash AS b_content_hash
+              FROM (SELECT * FROM domain_state_snapshots WHERE run_id = $1) a
+              FULL OUTER JOIN (SELECT * FROM domain_state_snapshots WHERE run_id = $2) b ON a.domain_id = b.domain_id
+              WHERE COALESCE(b.domain_id, a.domain_id) > $3
+                AND (a.domain_id IS NULL OR b.domain_id IS NULL
+                     OR a.dns_status IS DISTINCT FROM b.dns_status
+                     OR a.http_status IS DISTINCT FROM b.http_status
+                     OR a.lead_status IS DISTINCT FROM b.lead_status
+                     OR a.is_parked IS DISTINCT FROM b.is_parked
+                     OR a.domain_score IS DISTINCT FROM b.domain_score
+                     OR a.content_hash IS DISTINCT FROM b.content_hash)
+              ORDER BY COALESCE(b.domain_id, a.domain_id)
+              LIMIT $4`
+	if err := s.querier(exec).SelectContext(ctx, &rows, query, fromRunID, toRunID, afterDomainID, limit); err != nil {
+		return nil, fmt.Errorf("diff run snapshots: %w", err)
+	}
+	changes := make([]*models.DomainStateChange, 0, len(rows))
+	for _, r := range rows {
+		change := &models.DomainStateChange{}
+		if r.ADomainID.Valid {
+			change.Before = &models.DomainStateSnapshot{RunID: fromRunID, DomainID: r.ADomainID.UUID, DomainName: r.ADomainName.String,
+				DNSStatus: r.ADNSStatus, HTTPStatus: r.AHTTPStatus, LeadStatus: r.ALeadStatus, IsParked: r.AIsParked,
+				DomainScore: r.ADomainScore, ContentHash: r.AContentHash}
+			change.DomainID, change.DomainName = r.ADomainID.UUID, r.ADomainName.String
+		}
+		if r.BDomainID.Valid {
+			change.After = &models.DomainStateSnapshot{RunID: toRunID, DomainID: r.BDomainID.UUID, DomainName: r.BDomainName.String,
+				DNSStatus: r.BDNSStatus, HTTPStatus: r.BHTTPStatus, LeadStatus: r.BLeadStatus, IsParked: r.BIsParked,
+				DomainScore: r.BDomainScore, ContentHash: r.BContentHash}
+			change.DomainID, change.DomainName = r.BDomainID.UUID, r.BDomainName.String
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func (s *domainRunSnapshotStorePostgres) PruneCampaignPhaseRuns(ctx context.Context, exec store.Querier, campaignID uuid.UUID, keep int) (int64, error) {
+	res, err := s.querier(exec).ExecContext(ctx, `DELETE FROM campaign_phase_runs WHERE run_id IN (
+                  SELECT run_id FROM (
+                      SELECT run_id, ROW_NUMBER() OVER (PARTITION BY phase ORDER BY completed_at DESC) AS rn
+                      FROM campaign_phase_runs WHERE campaign_id = $1
+                  ) ranked WHERE rn > $2
+              )`, campaignID, keep)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+var _ store.DomainRunSnapshotStore = (*domainRunSnapshotStorePostgres)(nil)
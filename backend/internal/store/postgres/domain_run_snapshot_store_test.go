@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+)
+
+func TestGetGeneratedDomainsByCampaign_ChangedSinceRunFilter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &campaignStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	campaignID, runID := uuid.New(), uuid.New()
+	mock.ExpectQuery(`WHERE campaign_id = \$1 AND offset_index >= \$2 AND NOT EXISTS \(SELECT 1 FROM domain_state_snapshots s\s+WHERE s.run_id = \$3 AND s.domain_id = generated_domains.id`).
+		WithArgs(campaignID, int64(0), runID, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	if _, err := s.GetGeneratedDomainsByCampaign(context.Background(), nil, campaignID, 50, 0, &store.ListCampaignDomainsFilter{ChangedSinceRun: &runID}); err != nil {
+		t.Fatalf("GetGeneratedDomainsByCampaign: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestSnapshotCampaignPhaseRun_SkipsRecordedRun(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &domainRunSnapshotStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	run := &models.CampaignPhaseRun{RunID: uuid.New(), CampaignID: uuid.New(), Phase: models.PhaseTypeDNSValidation, CompletedAt: time.Now()}
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO campaign_phase_runs`).
+		WithArgs(run.RunID, run.CampaignID, "dns_validation", run.CompletedAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	if err := s.SnapshotCampaignPhaseRun(context.Background(), nil, run); err != nil {
+		t.Fatalf("SnapshotCampaignPhaseRun: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expected no snapshot copy for an already recorded run: %v", err)
+	}
+}
+
+func TestSnapshotCampaignPhaseRun_RollsBackPartialSnapshot(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &domainRunSnapshotStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	run := &models.CampaignPhaseRun{RunID: uuid.New(), CampaignID: uuid.New(), Phase: models.PhaseTypeDNSValidation, CompletedAt: time.Now()}
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO campaign_phase_runs`).
+		WithArgs(run.RunID, run.CampaignID, "dns_validation", run.CompletedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO domain_state_snapshots`).
+		WithArgs(run.RunID, run.CampaignID).
+		WillReturnError(errors.New("disk full"))
+	mock.ExpectRollback()
+
+	if err := s.SnapshotCampaignPhaseRun(context.Background(), nil, run); err == nil {
+		t.Fatal("expected snapshot copy failure")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expected the recorded run rolled back: %v", err)
+	}
+}
+
+func TestListChangedDomainSnapshots_PairsRuns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &domainRunSnapshotStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	from, to := uuid.New(), uuid.New()
+	changed, added := uuid.New(), uuid.New()
+	cols := []string{"a_domain_id", "a_domain_name", "a_dns_status", "a_http_status", "a_lead_status", "a_is_parked", "a_domain_score", "a_content_hash",
+		"b_domain_id", "b_domain_name", "b_dns_status", "b_http_status", "b_lead_status", "b_is_parked", "b_domain_score", "b_content_hash"}
+	mock.ExpectQuery(`FULL OUTER JOIN`).
+		WithArgs(from, to, uuid.Nil, 10).
+		WillReturnRows(sqlmock.NewRows(cols).
+			AddRow(changed, "a.com", "ok", "error", "pending", false, 0.2, "h1", changed, "a.com", "ok", "ok", "match", false, 0.7, "h2").
+			AddRow(nil, nil, nil, nil, nil, nil, nil, nil, added, "b.com", "ok", "pending", "pending", nil, nil, nil))
+
+	pairs, err := s.ListChangedDomainSnapshots(context.Background(), nil, from, to, uuid.Nil, 10)
+	if err != nil {
+		t.Fatalf("ListChangedDomainSnapshots: %v", err)
+	}
+	if len(pairs) != 2 {
+		t.Fatalf("expected 2 pairs, got %d", len(pairs))
+	}
+	if pairs[0].Before == nil || pairs[0].After == nil || pairs[0].After.RunID != to || pairs[0].Before.ContentHash.String != "h1" {
+		t.Fatalf("unexpected changed pair %+v", pairs[0])
+	}
+	if pairs[1].Before != nil || pairs[1].DomainID != added || pairs[1].DomainName != "b.com" {
+		t.Fatalf("expected added domain without before snapshot, got %+v", pairs[1])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
@@ -25,8 +25,8 @@ func (s *proxyPoolStorePostgres) BeginTxx(ctx context.Context, opts *sql.TxOptio
 }
 
 func (s *proxyPoolStorePostgres) CreateProxyPool(ctx context.Context, exec store.Querier, pool *models.ProxyPool) error {
-	query := `INSERT INTO proxy_pools (id, name, description, is_enabled, pool_strategy, health_check_enabled, health_check_interval_seconds, max_retries, timeout_seconds, created_at, updated_at)
-              VALUES (:id, :name, :description, :is_enabled, :pool_strategy, :health_check_enabled, :health_check_interval_seconds, :max_retries, :timeout_seconds, :created_at, :updated_at)`
+	query := `INSERT INTO proxy_pools (id, name, description, is_enabled, pool_strategy, health_check_enabled, health_check_interval_seconds, max_retries, timeout_seconds, max_consecutive_failures, min_success_rate, created_at, updated_at)
+              VALUES (:id, :name, :description, :is_enabled, :pool_strategy, :health_check_enabled, :health_check_interval_seconds, :max_retries, :timeout_seconds, :max_consecutive_failures, :min_success_rate, :created_at, :updated_at)`
 	_, err := exec.NamedExecContext(ctx, query, pool)
 	return err
 }
@@ -42,7 +42,7 @@ func (s *proxyPoolStorePostgres) GetProxyPoolByID(ctx context.Context, exec stor
 
 func (s *proxyPoolStorePostgres) UpdateProxyPool(ctx context.Context, exec store.Querier, pool *models.ProxyPool) error {
 	pool.UpdatedAt = time.Now().UTC()
-	query := `UPDATE proxy_pools SET name=:name, description=:description, is_enabled=:is_enabled, pool_strategy=:pool_strategy, health_check_enabled=:health_check_enabled, health_check_interval_seconds=:health_check_interval_seconds, max_retries=:max_retries, timeout_seconds=:timeout_seconds, updated_at=:updated_at WHERE id=:id`
+	query := `UPDATE proxy_pools SET name=:name, description=:description, is_enabled=:is_enabled, pool_strategy=:pool_strategy, health_check_enabled=:health_check_enabled, health_check_interval_seconds=:health_check_interval_seconds, max_retries=:max_retries, timeout_seconds=:timeout_seconds, max_consecutive_failures=:max_consecutive_failures, min_success_rate=:min_success_rate, updated_at=:updated_at WHERE id=:id`
 	result, err := exec.NamedExecContext(ctx, query, pool)
 	if err != nil {
 		return err
@@ -96,4 +96,10 @@ func (s *proxyPoolStorePostgres) ListProxiesForPool(ctx context.Context, exec st
 	return proxies, err
 }
 
+func (s *proxyPoolStorePostgres) ListPoolMemberships(ctx context.Context, exec store.Querier, poolID uuid.UUID) ([]*models.ProxyPoolMembership, error) {
+	memberships := []*models.ProxyPoolMembership{}
+	err := exec.SelectContext(ctx, &memberships, `SELECT pool_id, proxy_id, weight, is_active, added_at FROM proxy_pool_memberships WHERE pool_id=$1 ORDER BY added_at, proxy_id`, poolID)
+	return memberships, err
+}
+
 var _ store.ProxyPoolStore = (*proxyPoolStorePostgres)(nil)
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+)
+
+func TestGetGeneratedDomainsByCampaign_RDAPFilters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+	s := &campaignStorePostgres{db: sqlx.NewDb(db, "postgres")}
+
+	campaignID := uuid.New()
+	status := models.RDAPStatusRegistered
+	before := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT .*rdap_registered_at.* FROM generated_domains WHERE campaign_id = \$1 AND offset_index >= \$2 AND rdap_status = \$3 AND rdap_registered_at < \$4 AND rdap_expires_at < \$5 ORDER BY`).
+		WithArgs(campaignID, int64(0), status, before, expires, 25).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	filter := &store.ListCampaignDomainsFilter{RDAPStatus: &status, RegisteredBefore: &before, ExpiresBefore: &expires}
+	if _, err := s.GetGeneratedDomainsByCampaign(context.Background(), nil, campaignID, 25, 0, filter); err != nil {
+		t.Fatalf("GetGeneratedDomainsByCampaign: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("sql expectations: %v", err)
+	}
+}
+
+func TestBuildDomainExportQuery_RDAPFilters(t *testing.T) {
+	after := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := models.RDAPStatusUnregistered
+	filter := store.DomainExportFilter{}
+	filter.RDAPStatus = &status
+	filter.RegisteredAfter = &after
+
+	query, args := buildDomainExportQuery(uuid.New(), filter)
+	if !strings.Contains(query, "rdap_status = $2 AND rdap_registered_at > $3") {
+		t.Fatalf("unexpected query: %s", query)
+	}
+	if len(args) != 3 || args[1] != status || args[2] != after {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
@@ -148,6 +148,8 @@ func TestGetRejectionSummary_EachReasonCounted(t *testing.T) {
 		{"parked_only", "parked", 10},
 		{"dns_error_only", "dns_error", 5},
 		{"dns_timeout_only", "dns_timeout", 3},
+		{"dns_wildcard_only", "dns_wildcard", 4},
+		{"dns_missing_records_only", "dns_missing_records", 6},
 		{"http_error_only", "http_error", 8},
 		{"http_timeout_only", "http_timeout", 2},
 		{"pending_only", "pending", 15},
@@ -167,16 +169,18 @@ func TestGetRejectionSummary_EachReasonCounted(t *testing.T) {
 
 			// Build row with only the specific column having the value
 			values := map[string]int64{
-				"qualified":    0,
-				"low_score":    0,
-				"no_keywords":  0,
-				"parked":       0,
-				"dns_error":    0,
-				"dns_timeout":  0,
-				"http_error":   0,
-				"http_timeout": 0,
-				"pending":      0,
-				"null_count":   0,
+				"qualified":           0,
+				"low_score":           0,
+				"no_keywords":         0,
+				"parked":              0,
+				"dns_error":           0,
+				"dns_timeout":         0,
+				"dns_wildcard":        0,
+				"dns_missing_records": 0,
+				"http_error":          0,
+				"http_timeout":        0,
+				"pending":             0,
+				"null_count":          0,
 			}
 			values[tc.columnName] = tc.expected
 
@@ -184,10 +188,10 @@ func TestGetRejectionSummary_EachReasonCounted(t *testing.T) {
 				WithArgs(campaignID).
 				WillReturnRows(sqlmock.NewRows([]string{
 					"qualified", "low_score", "no_keywords", "parked",
-					"dns_error", "dns_timeout", "http_error", "http_timeout", "pending", "null_count",
+					"dns_error", "dns_timeout", "dns_wildcard", "dns_missing_records", "http_error", "http_timeout", "pending", "null_count",
 				}).AddRow(
 					values["qualified"], values["low_score"], values["no_keywords"], values["parked"],
-					values["dns_error"], values["dns_timeout"], values["http_error"], values["http_timeout"],
+					values["dns_error"], values["dns_timeout"], values["dns_wildcard"], values["dns_missing_records"], values["http_error"], values["http_timeout"],
 					values["pending"], values["null_count"],
 				))
 
@@ -211,6 +215,10 @@ func TestGetRejectionSummary_EachReasonCounted(t *testing.T) {
 				actual = summary.Counts.DNSError
 			case "dns_timeout":
 				actual = summary.Counts.DNSTimeout
+			case "dns_wildcard":
+				actual = summary.Counts.DNSWildcard
+			case "dns_missing_records":
+				actual = summary.Counts.DNSMissingRecords
 			case "http_error":
 				actual = summary.Counts.HTTPError
 			case "http_timeout":
@@ -222,6 +230,9 @@ func TestGetRejectionSummary_EachReasonCounted(t *testing.T) {
 			if actual != tc.expected {
 				t.Errorf("expected %s=%d, got %d", tc.columnName, tc.expected, actual)
 			}
+			if (tc.columnName == "dns_wildcard" || tc.columnName == "dns_missing_records") && (summary.Totals.Rejected != tc.expected || summary.Totals.Errors != 0) {
+				t.Errorf("%s should count as rejected but not as an error, got rejected=%d errors=%d", tc.columnName, summary.Totals.Rejected, summary.Totals.Errors)
+			}
 
 			if err := mock.ExpectationsWereMet(); err != nil {
 				t.Fatalf("sql expectations: %v", err)
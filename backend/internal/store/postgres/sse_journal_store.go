@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// sseJournalStorePostgres implements store.SSEEventJournalStore
+type sseJournalStorePostgres struct{ db *sqlx.DB }
+
+// NewSSEEventJournalStorePostgres creates a new SSEEventJournalStore backed by PostgreSQL
+func NewSSEEventJournalStorePostgres(db *sqlx.DB) store.SSEEventJournalStore {
+	return &sseJournalStorePostgres{db: db}
+}
+
+func (s *sseJournalStorePostgres) querier(exec store.Querier) store.Querier {
+	if exec != nil {
+		return exec
+	}
+	return s.db
+}
+
+func (s *sseJournalStorePostgres) AppendSSEEvents(ctx context.Context, exec store.Querier, entries []*models.SSEJournalEntry) error {
+	q := s.querier(exec)
+	for _, e := range entries {
+		payload := string(e.Payload)
+		if payload == "" {
+			payload = "{}"
+		}
+		// payload is passed as text so lib/pq does not encode the raw bytes as bytea
+		_, err := q.ExecContext(ctx, `INSERT INTO sse_event_journal (campaign_id, seq, event_type, user_id, payload, created_at)
+              VALUES ($1, $2, $3, $4, $5::jsonb, $6)
+              ON CONFLICT (campaign_id, seq) DO NOTHING`,
+			e.CampaignID, e.Seq, e.EventType, e.UserID, payload, e.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sseJournalStorePostgres) ListSSEEventsAfter(ctx context.Context, exec store.Querier, campaignID uuid.UUID, afterSeq, throughSeq int64, limit int) ([]*models.SSEJournalEntry, error) {
+	entries := []*models.SSEJournalEntry{}
+	err := s.querier(exec).SelectContext(ctx, &entries, `SELECT * FROM sse_event_journal
+              WHERE campaign_id=$1 AND seq > $2 AND seq <= $3
+              ORDER BY seq LIMIT $4`, campaignID, afterSeq, throughSeq, limit)
+	return entries, err
+}
+
+func (s *sseJournalStorePostgres) ReserveSSEEventSeqs(ctx context.Context, exec store.Querier, campaignID uuid.UUID, floor, n int64) (int64, error) {
+	var through int64
+	err := s.querier(exec).GetContext(ctx, &through, `INSERT INTO sse_event_sequences (campaign_id, reserved_through)
+              VALUES ($1, GREATEST($2::bigint, (SELECT COALESCE(MAX(seq), 0) FROM sse_event_journal WHERE campaign_id=$1)) + $3)
+              ON CONFLICT (campaign_id) DO UPDATE
+              SET reserved_through = GREATEST(sse_event_sequences.reserved_through, $2::bigint) + $3, updated_at = NOW()
+              RETURNING reserved_through`, campaignID, floor, n)
+	return through, err
+}
+
+func (s *sseJournalStorePostgres) PruneSSEEvents(ctx context.Context, exec store.Querier, olderThan time.Time) (int64, error) {
+	res, err := s.querier(exec).ExecContext(ctx, `DELETE FROM sse_event_journal WHERE created_at < $1`, olderThan)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+var _ store.SSEEventJournalStore = (*sseJournalStorePostgres)(nil)
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// webhookStorePostgres implements store.WebhookStore
+type webhookStorePostgres struct{ db *sqlx.DB }
+
+// NewWebhookStorePostgres creates a new WebhookStore backed by PostgreSQL
+func NewWebhookStorePostgres(db *sqlx.DB) store.WebhookStore {
+	return &webhookStorePostgres{db: db}
+}
+
+func (s *webhookStorePostgres) querier(exec store.Querier) store.Querier {
+	if exec != nil {
+		return exec
+	}
+	return s.db
+}
+
+func (s *webhookStorePostgres) CreateWebhookSubscription(ctx context.Context, exec store.Querier, sub *models.WebhookSubscription) error {
+	if sub.EventTypes == nil {
+		sub.EventTypes = pq.StringArray{}
+	}
+	query := `INSERT INTO webhook_subscriptions (id, user_id, name, url, secret, event_types, campaign_id, is_active, created_at, updated_at)
+              VALUES (:id, :user_id, :name, :url, :secret, :event_types, :campaign_id, :is_active, :created_at, :updated_at)`
+	_, err := s.querier(exec).NamedExecContext(ctx, query, sub)
+	return err
+}
+
+func (s *webhookStorePostgres) GetWebhookSubscription(ctx context.Context, exec store.Querier, userID, id uuid.UUID) (*models.WebhookSubscription, error) {
+	sub := &models.WebhookSubscription{}
+	err := s.querier(exec).GetContext(ctx, sub, `SELECT * FROM webhook_subscriptions WHERE id=$1 AND user_id=$2`, id, userID)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	return sub, err
+}
+
+func (s *webhookStorePostgres) GetWebhookSubscriptionsByIDs(ctx context.Context, exec store.Querier, ids []uuid.UUID) ([]*models.WebhookSubscription, error) {
+	subs := []*models.WebhookSubscription{}
+	if len(ids) == 0 {
+		return subs, nil
+	}
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = id.String()
+	}
+	err := s.querier(exec).SelectContext(ctx, &subs, `SELECT * FROM webhook_subscriptions WHERE id = ANY($1::uuid[])`, pq.Array(idStrings))
+	return subs, err
+}
+
+func (s *webhookStorePostgres) UpdateWebhookSubscription(ctx context.Context, exec store.Querier, sub *models.WebhookSubscription) error {
+	if sub.EventTypes == nil {
+		sub.EventTypes = pq.StringArray{}
+	}
+	sub.UpdatedAt = time.Now().UTC()
+	query := `UPDATE webhook_subscriptions SET name=:name, url=:url, secret=:secret, event_types=:event_types, campaign_id=:campaign_id,
+              is_active=:is_active, updated_at=:updated_at WHERE id=:id AND user_id=:user_id`
+	result, err := s.querier(exec).NamedExecContext(ctx, query, sub)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *webhookStorePostgres) DeleteWebhookSubscription(ctx context.Context, exec store.Querier, userID, id uuid.UUID) error {
+	res, err := s.querier(exec).ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id=$1 AND user_id=$2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *webhookStorePostgres) ListWebhookSubscriptions(ctx context.Context, exec store.Querier, userID uuid.UUID) ([]*models.WebhookSubscription, error) {
+	subs := []*models.WebhookSubscription{}
+	err := s.querier(exec).SelectContext(ctx, &subs, `SELECT * FROM webhook_subscriptions WHERE user_id=$1 ORDER BY created_at`, userID)
+	return subs, err
+}
+
+func (s *webhookStorePostgres) ListMatchingWebhookSubscriptions(ctx context.Context, exec store.Querier, userID uuid.UUID, eventType string, campaignID *uuid.UUID) ([]*models.WebhookSubscription, error) {
+	subs := []*models.WebhookSubscription{}
+	var campaign uuid.NullUUID
+	if campaignID != nil {
+		campaign = uuid.NullUUID{UUID: *campaignID, Valid: true}
+	}
+	var owner uuid.NullUUID
+	if userID != uuid.Nil {
+		owner = uuid.NullUUID{UUID: userID, Valid: true}
+	}
+	query := `SELECT * FROM webhook_subscriptions
+              WHERE user_id = COALESCE($1::uuid, (SELECT user_id FROM lead_generation_campaigns WHERE id = $3)) AND is_active
+                AND (cardinality(event_types) = 0 OR $2 = ANY(event_types))
+                AND (campaign_id IS NULL OR campaign_id = $3)`
+	err := s.querier(exec).SelectContext(ctx, &subs, query, owner, eventType, campaign)
+	return subs, err
+}
+
+func (s *webhookStorePostgres) EnqueueWebhookDeliveries(ctx context.Context, exec store.Querier, deliveries []*models.WebhookDelivery) error {
+	q := s.querier(exec)
+	for _, d := range deliveries {
+		// payload is passed as text so lib/pq does not encode the raw bytes as bytea
+		_, err := q.ExecContext(ctx, `INSERT INTO webhook_deliveries (id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at)
+              VALUES ($1, $2, $3, $4, $5::jsonb, $6, 0, $7, $8)
+              ON CONFLICT (subscription_id, event_id) DO NOTHING`,
+			d.ID, d.SubscriptionID, d.EventID, d.EventType, string(d.Payload), d.Status, d.NextAttemptAt, d.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *webhookStorePostgres) ClaimDueWebhookDeliveries(ctx context.Context, exec store.Querier, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	deliveries := []*models.WebhookDelivery{}
+	query := `UPDATE webhook_deliveries SET next_attempt_at = NOW() + make_interval(secs => $2)
+              WHERE id IN (
+                  SELECT id FROM webhook_deliveries
+                  WHERE status = 'pending' AND next_attempt_at <= NOW()
+                  ORDER BY next_attempt_at
+                  LIMIT $1
+                  FOR UPDATE SKIP LOCKED
+              )
+              RETURNING *`
+	err := s.querier(exec).SelectContext(ctx, &deliveries, query, limit, lease.Seconds())
+	return deliveries, err
+}
+
+func (s *webhookStorePostgres) MarkWebhookDeliveryDelivered(ctx context.Context, exec store.Querier, id uuid.UUID, attempts int, statusCode int) error {
+	_, err := s.querier(exec).ExecContext(ctx, `UPDATE webhook_deliveries
+              SET status='delivered', attempts=$2, last_status_code=$3, last_error=NULL, delivered_at=NOW()
+              WHERE id=$1`, id, attempts, statusCode)
+	return err
+}
+
+func (s *webhookStorePostgres) ScheduleWebhookDeliveryRetry(ctx context.Context, exec store.Querier, id uuid.UUID, attempts int, next time.Time, statusCode *int, lastError string) error {
+	var code sql.NullInt32
+	if statusCode != nil {
+		code = sql.NullInt32{Int32: int32(*statusCode), Valid: true}
+	}
+	_, err := s.querier(exec).ExecContext(ctx, `UPDATE webhook_deliveries
+              SET attempts=$2, next_attempt_at=$3, last_status_code=$4, last_error=$5
+              WHERE id=$1 AND status='pending'`, id, attempts, next, code, lastError)
+	return err
+}
+
+func (s *webhookStorePostgres) DeadLetterWebhookDelivery(ctx context.Context, exec store.Querier, delivery *models.WebhookDelivery) error {
+	query := `WITH dead AS (
+                  UPDATE webhook_deliveries SET status='dead', attempts=$2, last_status_code=$3, last_error=$4
+                  WHERE id=$1 AND status='pending'
+                  RETURNING id, subscription_id, event_type, payload, attempts, last_status_code, last_error
+              )
+              INSERT INTO webhook_dead_letters (id, delivery_id, subscription_id, event_type, payload, attempts, last_status_code, last_error, created_at)
+              SELECT $5, id, subscription_id, event_type, payload, attempts, last_status_code, last_error, NOW() FROM dead
+              ON CONFLICT (delivery_id) DO NOTHING`
+	_, err := s.querier(exec).ExecContext(ctx, query, delivery.ID, delivery.Attempts, delivery.LastStatusCode, delivery.LastError, uuid.New())
+	return err
+}
+
+func (s *webhookStorePostgres) ListWebhookDeliveries(ctx context.Context, exec store.Querier, subscriptionID uuid.UUID, limit, offset int) ([]*models.WebhookDelivery, error) {
+	deliveries := []*models.WebhookDelivery{}
+	err := s.querier(exec).SelectContext(ctx, &deliveries, `SELECT * FROM webhook_deliveries WHERE subscription_id=$1
+              ORDER BY created_at DESC, id LIMIT $2 OFFSET $3`, subscriptionID, limit, offset)
+	return deliveries, err
+}
+
+func (s *webhookStorePostgres) ListWebhookDeadLetters(ctx context.Context, exec store.Querier, subscriptionID uuid.UUID, limit, offset int) ([]*models.WebhookDeadLetter, error) {
+	letters := []*models.WebhookDeadLetter{}
+	err := s.querier(exec).SelectContext(ctx, &letters, `SELECT * FROM webhook_dead_letters WHERE subscription_id=$1
+              ORDER BY created_at DESC, id LIMIT $2 OFFSET $3`, subscriptionID, limit, offset)
+	return letters, err
+}
+
+func (s *webhookStorePostgres) RequeueWebhookDeadLetter(ctx context.Context, exec store.Querier, subscriptionID, deadLetterID uuid.UUID) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{}
+	query := `WITH dl AS (
+                  DELETE FROM webhook_dead_letters WHERE id=$1 AND subscription_id=$2 RETURNING delivery_id
+              )
+              UPDATE webhook_deliveries d
+              SET status='pending', attempts=0, next_attempt_at=NOW(), delivered_at=NULL
+              FROM dl WHERE d.id = dl.delivery_id
+              RETURNING d.*`
+	err := s.querier(exec).GetContext(ctx, delivery, query, deadLetterID, subscriptionID)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	return delivery, err
+}
+
+var _ store.WebhookStore = (*webhookStorePostgres)(nil)
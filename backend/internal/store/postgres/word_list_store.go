@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// wordListStorePostgres implements store.WordListStore
+type wordListStorePostgres struct{ db *sqlx.DB }
+
+// NewWordListStorePostgres creates a new WordListStore backed by PostgreSQL
+func NewWordListStorePostgres(db *sqlx.DB) store.WordListStore {
+	return &wordListStorePostgres{db: db}
+}
+
+func (s *wordListStorePostgres) querier(exec store.Querier) store.Querier {
+	if exec != nil {
+		return exec
+	}
+	return s.db
+}
+
+func (s *wordListStorePostgres) CreateWordList(ctx context.Context, exec store.Querier, list *models.DomainWordList) error {
+	query := `INSERT INTO domain_word_lists (id, name, description, words, word_count, checksum, created_by, created_at, updated_at)
+              VALUES (:id, :name, :description, :words, :word_count, :checksum, :created_by, :created_at, :updated_at)`
+	_, err := s.querier(exec).NamedExecContext(ctx, query, list)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // 23505 is unique_violation
+		return store.ErrDuplicateEntry
+	}
+	return err
+}
+
+func (s *wordListStorePostgres) GetWordListByID(ctx context.Context, exec store.Querier, id uuid.UUID) (*models.DomainWordList, error) {
+	list := &models.DomainWordList{}
+	err := s.querier(exec).GetContext(ctx, list, `SELECT * FROM domain_word_lists WHERE id=$1`, id)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	return list, err
+}
+
+func (s *wordListStorePostgres) GetWordListsByIDs(ctx context.Context, exec store.Querier, ids []uuid.UUID) ([]*models.DomainWordList, error) {
+	lists := []*models.DomainWordList{}
+	if len(ids) == 0 {
+		return lists, nil
+	}
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = id.String()
+	}
+	err := s.querier(exec).SelectContext(ctx, &lists, `SELECT * FROM domain_word_lists WHERE id = ANY($1::uuid[])`, pq.Array(idStrings))
+	return lists, err
+}
+
+func (s *wordListStorePostgres) UpdateWordList(ctx context.Context, exec store.Querier, list *models.DomainWordList) error {
+	list.UpdatedAt = time.Now().UTC()
+	query := `UPDATE domain_word_lists SET name=:name, description=:description, words=:words, word_count=:word_count, checksum=:checksum, updated_at=:updated_at WHERE id=:id`
+	result, err := s.querier(exec).NamedExecContext(ctx, query, list)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return store.ErrDuplicateEntry
+		}
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *wordListStorePostgres) DeleteWordList(ctx context.Context, exec store.Querier, id uuid.UUID) error {
+	res, err := s.querier(exec).ExecContext(ctx, `DELETE FROM domain_word_lists WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (s *wordListStorePostgres) WordListInUse(ctx context.Context, exec store.Querier, id uuid.UUID) (bool, error) {
+	// Configs are persisted as submitted, so accept both key spellings the phase config parser reads.
+	query := `SELECT EXISTS (
+                SELECT 1 FROM phase_configurations pc
+                CROSS JOIN LATERAL jsonb_array_elements(CASE
+                    WHEN jsonb_typeof(pc.config->'wordLists') = 'array' THEN pc.config->'wordLists'
+                    WHEN jsonb_typeof(pc.config->'word_lists') = 'array' THEN pc.config->'word_lists'
+                    ELSE '[]'::jsonb END) AS slot
+                WHERE pc.phase = 'domain_generation'
+                  AND jsonb_typeof(slot) = 'object'
+                  AND lower(COALESCE(slot->>'wordListId', slot->>'word_list_id')) = $1)`
+	var inUse bool
+	err := s.querier(exec).GetContext(ctx, &inUse, query, id.String())
+	return inUse, err
+}
+
+func (s *wordListStorePostgres) ListWordLists(ctx context.Context, exec store.Querier, limit, offset int) ([]*models.DomainWordList, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	lists := []*models.DomainWordList{}
+	query := `SELECT id, name, description, '{}'::text[] AS words, word_count, checksum, created_by, created_at, updated_at
+              FROM domain_word_lists ORDER BY name LIMIT $1 OFFSET $2`
+	err := s.querier(exec).SelectContext(ctx, &lists, query, limit, offset)
+	return lists, err
+}
+
+var _ store.WordListStore = (*wordListStorePostgres)(nil)
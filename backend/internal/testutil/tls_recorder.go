@@ -0,0 +1,80 @@
+package testutil
+
+import (
+	"bufio"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// RecordingTLSServer is a TLS test server that records each ClientHello and the request header names in
+// wire order, answering every request with 200 OK. Recordings beyond the channel buffers are dropped.
+type RecordingTLSServer struct {
+	URL         string
+	Hellos      chan *tls.ClientHelloInfo
+	HeaderNames chan []string
+}
+
+// NewRecordingTLSServer starts a RecordingTLSServer on a loopback port; it is closed when the test ends.
+func NewRecordingTLSServer(t *testing.T) *RecordingTLSServer {
+	t.Helper()
+	certSrv := httptest.NewTLSServer(http.NotFoundHandler())
+	certs := certSrv.TLS.Certificates
+	certSrv.Close()
+
+	s := &RecordingTLSServer{Hellos: make(chan *tls.ClientHelloInfo, 4), HeaderNames: make(chan []string, 4)}
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: certs,
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			select {
+			case s.Hellos <- hello:
+			default:
+			}
+			return nil, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	s.URL = "https://" + ln.Addr().String()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *RecordingTLSServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	if _, err := r.ReadString('\n'); err != nil {
+		return
+	}
+	var names []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, _, _ := strings.Cut(line, ":")
+		names = append(names, name)
+	}
+	select {
+	case s.HeaderNames <- names:
+	default:
+	}
+	_, _ = conn.Write([]byte("HTTP/1.1 200 OK\r\nContent-Type: text/plain; charset=utf-8\r\nContent-Length: 2\r\nConnection: close\r\n\r\nok"))
+}
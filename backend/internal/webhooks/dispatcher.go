@@ -0,0 +1,338 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/netguard"
+	"github.com/fntelecomllc/studio/backend/internal/services"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+)
+
+// DispatcherConfig tunes delivery polling and the retry policy.
+type DispatcherConfig struct {
+	PollInterval   time.Duration // how often due deliveries are claimed
+	BatchSize      int           // deliveries claimed per poll
+	Concurrency    int           // parallel HTTP requests per poll
+	RequestTimeout time.Duration
+	MaxAttempts    int // attempts before a delivery is dead-lettered
+	BaseBackoff    time.Duration
+	MaxBackoff     time.Duration
+	// DedupeWindow suppresses repeats of the same (event, campaign, phase): the orchestrator emits both a
+	// sequenced transition event and a legacy event for some lifecycle changes.
+	DedupeWindow time.Duration
+	QueueSize    int // buffered events awaiting fan-out; events are dropped (and logged) when full
+}
+
+// DefaultDispatcherConfig retries for roughly four hours before dead-lettering.
+func DefaultDispatcherConfig() DispatcherConfig {
+	return DispatcherConfig{
+		PollInterval:   2 * time.Second,
+		BatchSize:      50,
+		Concurrency:    8,
+		RequestTimeout: 10 * time.Second,
+		MaxAttempts:    10,
+		BaseBackoff:    30 * time.Second,
+		MaxBackoff:     2 * time.Hour,
+		DedupeWindow:   5 * time.Second,
+		QueueSize:      1024,
+	}
+}
+
+// maxErrorBodyBytes bounds how much of a failed response body is kept as last_error.
+const maxErrorBodyBytes = 512
+
+// Dispatcher fans lifecycle events out to matching subscriptions and delivers queued webhooks.
+type Dispatcher struct {
+	store  store.WebhookStore
+	cfg    DispatcherConfig
+	client *http.Client
+	events chan services.SSEEvent
+	now    func() time.Time
+	jitter func() float64
+
+	mu     sync.Mutex
+	recent map[string]time.Time
+
+	startOnce sync.Once
+}
+
+// NewDispatcher creates a dispatcher; zero config fields fall back to DefaultDispatcherConfig.
+func NewDispatcher(st store.WebhookStore, cfg DispatcherConfig) *Dispatcher {
+	def := DefaultDispatcherConfig()
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = def.PollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = def.BatchSize
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = def.Concurrency
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = def.RequestTimeout
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = def.MaxAttempts
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = def.BaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = def.MaxBackoff
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = def.QueueSize
+	}
+	return &Dispatcher{
+		store: st,
+		cfg:   cfg,
+		client: &http.Client{
+			Timeout: cfg.RequestTimeout,
+			// Subscriber URLs are user input: never connect to loopback, private or link-local addresses,
+			// even when a public name resolves to one
+			Transport: &http.Transport{
+				DialContext:         netguard.DialContext(&net.Dialer{Timeout: cfg.RequestTimeout, KeepAlive: 30 * time.Second}),
+				TLSHandshakeTimeout: cfg.RequestTimeout,
+				MaxIdleConnsPerHost: 2,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			// Redirects are reported as failures; subscribers must register the final URL.
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		events: make(chan services.SSEEvent, cfg.QueueSize),
+		now:    time.Now,
+		jitter: func() float64 { return 0.8 + rand.Float64()*0.4 },
+		recent: make(map[string]time.Time),
+	}
+}
+
+// Observe is an services.SSEEventObserver: it queues lifecycle events for fan-out without blocking the broadcaster.
+// Events need an owner or a campaign; campaign-level events without a user go to the campaign owner.
+func (d *Dispatcher) Observe(evt services.SSEEvent) {
+	if !IsLifecycleEvent(string(evt.Event)) || (eventOwner(evt) == uuid.Nil && evt.CampaignID == nil) {
+		return
+	}
+	if d.duplicate(evt) {
+		return
+	}
+	select {
+	case d.events <- evt:
+	default:
+		log.Printf("[webhooks] event queue full; dropping event=%s campaign=%v", evt.Event, evt.CampaignID)
+	}
+}
+
+// Start launches the fan-out and delivery loops; they stop when ctx is cancelled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	d.startOnce.Do(func() {
+		go d.fanOutLoop(ctx)
+		go d.deliveryLoop(ctx)
+	})
+}
+
+func (d *Dispatcher) duplicate(evt services.SSEEvent) bool {
+	if d.cfg.DedupeWindow <= 0 {
+		return false
+	}
+	phase, _ := evt.Data["phase"].(string)
+	campaign := ""
+	if evt.CampaignID != nil {
+		campaign = evt.CampaignID.String()
+	}
+	key := string(evt.Event) + "|" + campaign + "|" + phase
+	now := d.now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for k, seen := range d.recent {
+		if now.Sub(seen) > d.cfg.DedupeWindow {
+			delete(d.recent, k)
+		}
+	}
+	if seen, ok := d.recent[key]; ok && now.Sub(seen) <= d.cfg.DedupeWindow {
+		return true
+	}
+	d.recent[key] = now
+	return false
+}
+
+func (d *Dispatcher) fanOutLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case evt := <-d.events:
+			if err := d.fanOut(ctx, evt); err != nil {
+				log.Printf("[webhooks] fan-out failed event=%s campaign=%v err=%v", evt.Event, evt.CampaignID, err)
+			}
+		}
+	}
+}
+
+func eventOwner(evt services.SSEEvent) uuid.UUID {
+	if evt.UserID == nil {
+		return uuid.Nil
+	}
+	return *evt.UserID
+}
+
+// fanOut queues one delivery per subscription of the event owner that accepts the event.
+func (d *Dispatcher) fanOut(ctx context.Context, evt services.SSEEvent) error {
+	subs, err := d.store.ListMatchingWebhookSubscriptions(ctx, nil, eventOwner(evt), string(evt.Event), evt.CampaignID)
+	if err != nil || len(subs) == 0 {
+		return err
+	}
+	payload := NewPayload(evt)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	now := d.now().UTC()
+	deliveries := make([]*models.WebhookDelivery, 0, len(subs))
+	for _, sub := range subs {
+		deliveries = append(deliveries, &models.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: sub.ID,
+			EventID:        payload.ID,
+			EventType:      payload.Type,
+			Payload:        body,
+			Status:         models.WebhookDeliveryStatusPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+	}
+	return d.store.EnqueueWebhookDeliveries(ctx, nil, deliveries)
+}
+
+func (d *Dispatcher) deliveryLoop(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.deliverDue(ctx); err != nil {
+				log.Printf("[webhooks] delivery poll failed: %v", err)
+			}
+		}
+	}
+}
+
+// deliverDue claims one batch of due deliveries and attempts each of them.
+func (d *Dispatcher) deliverDue(ctx context.Context) (int, error) {
+	lease := d.cfg.RequestTimeout + 30*time.Second
+	due, err := d.store.ClaimDueWebhookDeliveries(ctx, nil, d.cfg.BatchSize, lease)
+	if err != nil || len(due) == 0 {
+		return 0, err
+	}
+	ids := make([]uuid.UUID, 0, len(due))
+	for _, del := range due {
+		ids = append(ids, del.SubscriptionID)
+	}
+	subs, err := d.store.GetWebhookSubscriptionsByIDs(ctx, nil, ids)
+	if err != nil {
+		return 0, err
+	}
+	byID := make(map[uuid.UUID]*models.WebhookSubscription, len(subs))
+	for _, s := range subs {
+		byID[s.ID] = s
+	}
+
+	sem := make(chan struct{}, d.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for _, del := range due {
+		sub := byID[del.SubscriptionID]
+		if sub == nil {
+			continue // subscription deleted; its deliveries cascade away
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(sub *models.WebhookSubscription, del *models.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			d.attempt(ctx, sub, del)
+		}(sub, del)
+	}
+	wg.Wait()
+	return len(due), nil
+}
+
+// attempt sends one delivery and records the outcome: delivered, retry scheduled, or dead-lettered.
+func (d *Dispatcher) attempt(ctx context.Context, sub *models.WebhookSubscription, del *models.WebhookDelivery) {
+	attempts := del.Attempts + 1
+	if !sub.IsActive {
+		// Paused subscriptions keep their queue; look again after the base backoff.
+		_ = d.store.ScheduleWebhookDeliveryRetry(ctx, nil, del.ID, del.Attempts, d.now().Add(d.cfg.BaseBackoff), nil, "subscription inactive")
+		return
+	}
+	code, err := d.send(ctx, sub, del)
+	if err == nil {
+		if mErr := d.store.MarkWebhookDeliveryDelivered(ctx, nil, del.ID, attempts, code); mErr != nil {
+			log.Printf("[webhooks] mark delivered failed delivery=%s err=%v", del.ID, mErr)
+		}
+		return
+	}
+
+	var codePtr *int
+	if code != 0 {
+		codePtr = &code
+	}
+	// 410 Gone means the endpoint was removed on purpose; retrying cannot succeed.
+	if attempts >= d.cfg.MaxAttempts || code == http.StatusGone {
+		del.Attempts = attempts
+		del.LastError.String, del.LastError.Valid = err.Error(), true
+		if codePtr != nil {
+			del.LastStatusCode.Int32, del.LastStatusCode.Valid = int32(code), true
+		}
+		if dErr := d.store.DeadLetterWebhookDelivery(ctx, nil, del); dErr != nil {
+			log.Printf("[webhooks] dead-letter failed delivery=%s err=%v", del.ID, dErr)
+		}
+		log.Printf("[webhooks] delivery dead-lettered delivery=%s subscription=%s attempts=%d err=%v", del.ID, sub.ID, attempts, err)
+		return
+	}
+	next := d.now().Add(backoffDelay(attempts, d.cfg.BaseBackoff, d.cfg.MaxBackoff, d.jitter()))
+	if rErr := d.store.ScheduleWebhookDeliveryRetry(ctx, nil, del.ID, attempts, next, codePtr, err.Error()); rErr != nil {
+		log.Printf("[webhooks] schedule retry failed delivery=%s err=%v", del.ID, rErr)
+	}
+}
+
+// send POSTs the signed payload; any non-2xx response is an error. The status code is 0 when no response arrived.
+func (d *Dispatcher) send(ctx context.Context, sub *models.WebhookSubscription, del *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(del.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, del.EventType)
+	req.Header.Set(HeaderDelivery, del.ID.String())
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, d.now(), del.Payload))
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := fmt.Sprintf("unexpected status %d", resp.StatusCode)
+		if s := strings.TrimSpace(string(snippet)); s != "" {
+			msg += ": " + s
+		}
+		return resp.StatusCode, fmt.Errorf("%s", msg)
+	}
+	return resp.StatusCode, nil
+}
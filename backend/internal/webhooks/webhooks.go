@@ -0,0 +1,196 @@
+// Package webhooks delivers campaign and phase lifecycle events to user-registered HTTP endpoints.
+//
+// Events are taken from the SSE broadcast path (the same events the orchestrator and the domain EventBus
+// publish), matched against each owner's subscriptions and queued in webhook_deliveries. A poller sends
+// due deliveries with an HMAC-SHA256 signature, retries failures with exponential backoff and moves
+// deliveries that exhaust their attempts to the dead-letter table.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/netguard"
+	"github.com/fntelecomllc/studio/backend/internal/services"
+	"github.com/google/uuid"
+)
+
+// Delivery request headers.
+const (
+	HeaderEvent     = "X-Studio-Event"
+	HeaderDelivery  = "X-Studio-Delivery"
+	HeaderSignature = "X-Studio-Signature"
+	userAgent       = "studio-webhooks/1"
+)
+
+// EventTypes lists the lifecycle events a subscription can filter on. They use the SSE event names.
+var EventTypes = []string{
+	string(services.SSEEventCampaignCompleted),
+	string(services.SSEEventPhaseStarted),
+	string(services.SSEEventPhaseAutoStarted),
+	string(services.SSEEventPhasePaused),
+	string(services.SSEEventPhaseResumed),
+	string(services.SSEEventPhaseCompleted),
+	string(services.SSEEventPhaseFailed),
+	string(services.SSEEventModeChanged),
+}
+
+// IsLifecycleEvent reports whether eventType is deliverable via webhooks.
+func IsLifecycleEvent(eventType string) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeEventTypes validates and de-duplicates a subscription event filter. An empty result means all events.
+func NormalizeEventTypes(types []string) ([]string, error) {
+	seen := make(map[string]bool, len(types))
+	out := make([]string, 0, len(types))
+	for _, raw := range types {
+		t := strings.TrimSpace(raw)
+		if t == "" || seen[t] {
+			continue
+		}
+		if !IsLifecycleEvent(t) {
+			return nil, fmt.Errorf("unsupported webhook event type %q", t)
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// ValidateURL requires an absolute http(s) URL without embedded credentials that does not point at
+// localhost or a private, loopback or link-local address.
+func ValidateURL(raw string) error {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return fmt.Errorf("invalid webhook url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("webhook url must use http or https")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("webhook url must include a host")
+	}
+	if u.User != nil {
+		return fmt.Errorf("webhook url must not embed credentials")
+	}
+	if err := netguard.CheckHost(u.Hostname()); err != nil {
+		return fmt.Errorf("webhook url must be publicly reachable: %w", err)
+	}
+	return nil
+}
+
+// GenerateSecret returns a random signing secret.
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// Sign returns the X-Studio-Signature value for body: "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">".
+// The timestamp is signed so receivers can reject replays outside their tolerance window.
+func Sign(secret string, ts time.Time, body []byte) string {
+	t := strconv.FormatInt(ts.Unix(), 10)
+	return "t=" + t + ",v1=" + hex.EncodeToString(signature(secret, t, body))
+}
+
+// Verify checks a signature header produced by Sign and rejects timestamps older than tolerance (0 disables the check).
+func Verify(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var t, v1 string
+	for _, part := range strings.Split(header, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "t":
+			t = v
+		case "v1":
+			v1 = v
+		}
+	}
+	if t == "" || v1 == "" {
+		return fmt.Errorf("malformed signature header")
+	}
+	sec, err := strconv.ParseInt(t, 10, 64)
+	if err != nil {
+		return fmt.Errorf("malformed signature timestamp")
+	}
+	if tolerance > 0 {
+		if age := now.Sub(time.Unix(sec, 0)); age > tolerance || age < -tolerance {
+			return fmt.Errorf("signature timestamp outside tolerance")
+		}
+	}
+	got, err := hex.DecodeString(v1)
+	if err != nil || !hmac.Equal(got, signature(secret, t, body)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+func signature(secret, t string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// Payload is the JSON body POSTed to subscribers.
+type Payload struct {
+	ID         uuid.UUID              `json:"id"`
+	Type       string                 `json:"type"`
+	CreatedAt  time.Time              `json:"createdAt"`
+	CampaignID *uuid.UUID             `json:"campaignId,omitempty"`
+	Phase      string                 `json:"phase,omitempty"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// NewPayload converts a broadcast SSE event into a webhook payload.
+func NewPayload(evt services.SSEEvent) Payload {
+	id, err := uuid.Parse(evt.ID)
+	if err != nil {
+		id = uuid.New()
+	}
+	created := evt.Timestamp
+	if created.IsZero() {
+		created = time.Now()
+	}
+	p := Payload{ID: id, Type: string(evt.Event), CreatedAt: created.UTC(), CampaignID: evt.CampaignID, Data: evt.Data}
+	if phase, ok := evt.Data["phase"].(string); ok {
+		p.Phase = phase
+	}
+	if p.Data == nil {
+		p.Data = map[string]interface{}{}
+	}
+	return p
+}
+
+// backoffDelay returns the wait before attempt attempts+1: base doubled per failed attempt, capped at max,
+// then scaled by jitter (a factor around 1.0 so synchronized failures spread out).
+func backoffDelay(attempts int, base, max time.Duration, jitter float64) time.Duration {
+	d := base
+	for i := 1; i < attempts && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return time.Duration(float64(d) * jitter)
+}
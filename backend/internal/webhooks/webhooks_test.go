@@ -0,0 +1,237 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fntelecomllc/studio/backend/internal/models"
+	"github.com/fntelecomllc/studio/backend/internal/services"
+	"github.com/fntelecomllc/studio/backend/internal/store"
+	"github.com/google/uuid"
+)
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"type":"phase_completed"}`)
+	now := time.Unix(1700000000, 0)
+	header := Sign("secret", now, body)
+	if err := Verify("secret", header, body, 5*time.Minute, now.Add(time.Minute)); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if err := Verify("other", header, body, 0, now); err == nil {
+		t.Fatalf("expected mismatch for wrong secret")
+	}
+	if err := Verify("secret", header, []byte(`{}`), 0, now); err == nil {
+		t.Fatalf("expected mismatch for altered body")
+	}
+	if err := Verify("secret", header, body, time.Minute, now.Add(10*time.Minute)); err == nil {
+		t.Fatalf("expected stale timestamp rejection")
+	}
+}
+
+func TestNormalizeEventTypesAndURL(t *testing.T) {
+	got, err := NormalizeEventTypes([]string{"phase_failed", " campaign_completed", "phase_failed", ""})
+	if err != nil || len(got) != 2 || got[0] != "campaign_completed" || got[1] != "phase_failed" {
+		t.Fatalf("unexpected %v err=%v", got, err)
+	}
+	if _, err := NormalizeEventTypes([]string{"domain_generated"}); err == nil {
+		t.Fatalf("expected non-lifecycle event to be rejected")
+	}
+	for _, bad := range []string{"ftp://x.test/hook", "/relative", "https://user:pw@x.test/hook",
+		"http://localhost:8080/hook", "http://127.0.0.1/hook", "http://169.254.169.254/latest/meta-data", "http://[::1]/hook", "https://10.0.0.5/hook"} {
+		if ValidateURL(bad) == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+	if err := ValidateURL("https://hooks.example.com/studio"); err != nil {
+		t.Fatalf("valid url rejected: %v", err)
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	base, max := 30*time.Second, 10*time.Minute
+	cases := map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 3: 2 * time.Minute, 6: max, 20: max}
+	for attempts, want := range cases {
+		if got := backoffDelay(attempts, base, max, 1); got != want {
+			t.Errorf("attempts=%d: got %s want %s", attempts, got, want)
+		}
+	}
+	if got := backoffDelay(1, base, max, 1.2); got != 36*time.Second {
+		t.Errorf("jitter not applied: %s", got)
+	}
+}
+
+func TestObserveFiltersAndDedupes(t *testing.T) {
+	d := NewDispatcher(&fakeStore{}, DispatcherConfig{DedupeWindow: 5 * time.Second, QueueSize: 8})
+	now := time.Now()
+	d.now = func() time.Time { return now }
+	user, campaign := uuid.New(), uuid.New()
+	evt := services.CreatePhaseCompletedEvent(campaign, user, models.PhaseTypeDNSValidation, nil)
+
+	d.Observe(evt)
+	d.Observe(evt) // legacy duplicate of the transition event
+	d.Observe(services.SSEEvent{Event: services.SSEEventCampaignProgress, UserID: &user, CampaignID: &campaign})
+	d.Observe(services.SSEEvent{Event: services.SSEEventPhaseStarted})                             // neither owner nor campaign
+	d.Observe(services.SSEEvent{Event: services.SSEEventCampaignCompleted, CampaignID: &campaign}) // goes to the campaign owner
+	if len(d.events) != 2 {
+		t.Fatalf("expected 2 queued events, got %d", len(d.events))
+	}
+	now = now.Add(6 * time.Second)
+	d.Observe(evt)
+	if len(d.events) != 3 {
+		t.Fatalf("expected repeat after dedupe window, got %d", len(d.events))
+	}
+}
+
+func TestDeliverySuccessRetryAndDeadLetter(t *testing.T) {
+	status := http.StatusOK
+	var gotSig, gotEvent string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSig, gotEvent = r.Header.Get(HeaderSignature), r.Header.Get(HeaderEvent)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("nope"))
+	}))
+	defer srv.Close()
+
+	user, campaign := uuid.New(), uuid.New()
+	sub := &models.WebhookSubscription{ID: uuid.New(), UserID: user, URL: srv.URL, Secret: "s3cret", IsActive: true}
+	fs := &fakeStore{subs: []*models.WebhookSubscription{sub}}
+	d := NewDispatcher(fs, DispatcherConfig{MaxAttempts: 2, BaseBackoff: time.Minute, MaxBackoff: time.Hour})
+	d.jitter = func() float64 { return 1 }
+	d.client.Transport = srv.Client().Transport // the test server listens on loopback
+	ctx := context.Background()
+
+	evt := services.CreatePhaseFailedEvent(campaign, user, models.PhaseTypeHTTPKeywordValidation, "boom")
+	if err := d.fanOut(ctx, evt); err != nil || len(fs.queued) != 1 {
+		t.Fatalf("fan-out: err=%v queued=%d", err, len(fs.queued))
+	}
+
+	// Success path: signed JSON body, delivered with attempt count 1.
+	if n, err := d.deliverDue(ctx); err != nil || n != 1 {
+		t.Fatalf("deliverDue: n=%d err=%v", n, err)
+	}
+	if gotEvent != "phase_failed" || Verify("s3cret", gotSig, gotBody, time.Minute, time.Now()) != nil {
+		t.Fatalf("bad request headers event=%q sig=%q", gotEvent, gotSig)
+	}
+	var p Payload
+	if err := json.Unmarshal(gotBody, &p); err != nil || p.Phase != string(models.PhaseTypeHTTPKeywordValidation) || p.CampaignID == nil || *p.CampaignID != campaign {
+		t.Fatalf("unexpected payload %s err=%v", gotBody, err)
+	}
+	if fs.delivered[fs.queued[0].ID] != 1 {
+		t.Fatalf("expected delivered after 1 attempt, got %v", fs.delivered)
+	}
+
+	// Failure path: first failure schedules a backoff retry, second exhausts MaxAttempts.
+	status = http.StatusInternalServerError
+	del := fs.queued[0]
+	del.Attempts = 0
+	d.attempt(ctx, sub, del)
+	if fs.retries[del.ID] != 1 || fs.lastErr != "unexpected status 500: nope" {
+		t.Fatalf("expected retry scheduled, retries=%v err=%q", fs.retries, fs.lastErr)
+	}
+	if wait := fs.nextAt.Sub(time.Now()); wait < 50*time.Second || wait > 70*time.Second {
+		t.Fatalf("unexpected backoff %s", wait)
+	}
+	del.Attempts = 1
+	d.attempt(ctx, sub, del)
+	if len(fs.dead) != 1 || fs.dead[0].Attempts != 2 || fs.dead[0].LastStatusCode.Int32 != 500 {
+		t.Fatalf("expected dead letter, got %+v", fs.dead)
+	}
+}
+
+// fakeStore implements the WebhookStore methods the dispatcher uses.
+type fakeStore struct {
+	store.WebhookStore
+	subs      []*models.WebhookSubscription
+	owners    map[uuid.UUID]uuid.UUID // campaign -> owner
+	queued    []*models.WebhookDelivery
+	claimed   bool
+	delivered map[uuid.UUID]int
+	retries   map[uuid.UUID]int
+	nextAt    time.Time
+	lastErr   string
+	dead      []*models.WebhookDelivery
+}
+
+func (f *fakeStore) ListMatchingWebhookSubscriptions(_ context.Context, _ store.Querier, userID uuid.UUID, _ string, campaignID *uuid.UUID) ([]*models.WebhookSubscription, error) {
+	if userID == uuid.Nil && campaignID != nil {
+		userID = f.owners[*campaignID]
+	}
+	var out []*models.WebhookSubscription
+	for _, s := range f.subs {
+		if s.UserID == userID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) EnqueueWebhookDeliveries(_ context.Context, _ store.Querier, d []*models.WebhookDelivery) error {
+	f.queued = append(f.queued, d...)
+	return nil
+}
+
+func (f *fakeStore) ClaimDueWebhookDeliveries(context.Context, store.Querier, int, time.Duration) ([]*models.WebhookDelivery, error) {
+	if f.claimed {
+		return nil, nil
+	}
+	f.claimed = true
+	return f.queued, nil
+}
+
+func (f *fakeStore) GetWebhookSubscriptionsByIDs(context.Context, store.Querier, []uuid.UUID) ([]*models.WebhookSubscription, error) {
+	return f.subs, nil
+}
+
+func (f *fakeStore) MarkWebhookDeliveryDelivered(_ context.Context, _ store.Querier, id uuid.UUID, attempts int, _ int) error {
+	if f.delivered == nil {
+		f.delivered = map[uuid.UUID]int{}
+	}
+	f.delivered[id] = attempts
+	return nil
+}
+
+func (f *fakeStore) ScheduleWebhookDeliveryRetry(_ context.Context, _ store.Querier, id uuid.UUID, attempts int, next time.Time, _ *int, lastError string) error {
+	if f.retries == nil {
+		f.retries = map[uuid.UUID]int{}
+	}
+	f.retries[id] = attempts
+	f.nextAt, f.lastErr = next, lastError
+	return nil
+}
+
+func (f *fakeStore) DeadLetterWebhookDelivery(_ context.Context, _ store.Querier, d *models.WebhookDelivery) error {
+	f.dead = append(f.dead, d)
+	return nil
+}
+
+func TestFanOutCampaignEventReachesCampaignOwner(t *testing.T) {
+	owner, campaign := uuid.New(), uuid.New()
+	sub := &models.WebhookSubscription{ID: uuid.New(), UserID: owner, URL: "https://hooks.example.com/studio", IsActive: true}
+	fs := &fakeStore{subs: []*models.WebhookSubscription{sub}, owners: map[uuid.UUID]uuid.UUID{campaign: owner}}
+	d := NewDispatcher(fs, DispatcherConfig{})
+
+	evt := services.SSEEvent{Event: services.SSEEventCampaignCompleted, CampaignID: &campaign, Timestamp: time.Now()}
+	if err := d.fanOut(context.Background(), evt); err != nil || len(fs.queued) != 1 || fs.queued[0].SubscriptionID != sub.ID {
+		t.Fatalf("expected one delivery for the campaign owner, err=%v queued=%d", err, len(fs.queued))
+	}
+}
+
+func TestSendRefusesLoopbackTargets(t *testing.T) {
+	hit := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hit = true }))
+	defer srv.Close()
+
+	d := NewDispatcher(&fakeStore{}, DispatcherConfig{})
+	sub := &models.WebhookSubscription{ID: uuid.New(), URL: srv.URL, Secret: "s", IsActive: true}
+	del := &models.WebhookDelivery{ID: uuid.New(), EventType: "phase_failed", Payload: []byte(`{}`)}
+	if _, err := d.send(context.Background(), sub, del); err == nil || hit {
+		t.Fatalf("expected the loopback delivery to be blocked, err=%v hit=%v", err, hit)
+	}
+}
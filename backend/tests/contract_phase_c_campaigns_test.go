@@ -97,6 +97,26 @@ func TestPhaseC_BulkOperationsList_DirectArray(t *testing.T) {
 	}
 }
 
+// TestPhaseC_BulkOperationsHistory_PagedObject
+func TestPhaseC_BulkOperationsHistory_PagedObject(t *testing.T) {
+	sample := gen.CampaignsBulkOperationsHistory200JSONResponse{Items: []gen.BulkOperationSummary{{}}}
+	rr := httptest.NewRecorder()
+	if err := sample.VisitCampaignsBulkOperationsHistoryResponse(rr); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	assertNoEnvelopeObject(t, rr.Body.Bytes(), "bulk operations history")
+	var page map[string]any
+	if err := json.Unmarshal(rr.Body.Bytes(), &page); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if _, ok := page["items"].([]any); !ok {
+		t.Fatalf("expected items array, got %q", rr.Body.Bytes())
+	}
+	if _, ok := page["meta"].(map[string]any); !ok {
+		t.Fatalf("expected pagination meta, got %q", rr.Body.Bytes())
+	}
+}
+
 // Negative envelope still for errors: simulate not found for Status (404 response type still envelope)
 func TestPhaseC_Status_NotFound_StillErrorEnvelope(t *testing.T) {
 	fals := false
@@ -678,7 +678,7 @@ export const CampaignsApiAxiosParamCreator = function (configuration?: Configura
          * @param {string} campaignId 
          * @param {number} [limit] 
          * @param {number} [offset] 
-         * @param {CampaignsDomainsListDnsStatusEnum} [dnsStatus] Filter domains whose authoritative DNS status matches (pending|ok|error|timeout)
+         * @param {CampaignsDomainsListDnsStatusEnum} [dnsStatus] Filter domains whose authoritative DNS status matches (pending|ok|error|timeout|wildcard)
          * @param {CampaignsDomainsListHttpStatusEnum} [httpStatus] Filter domains whose authoritative HTTP status matches (pending|ok|error|timeout)
          * @param {string} [dnsReason] Filter domains by DNS reason (exact match). Example values: NXDOMAIN, SERVFAIL, REFUSED, NOANSWER, TIMEOUT, ERROR
          * @param {string} [httpReason] Filter domains by HTTP reason (exact match). Example values: TIMEOUT, NOT_FOUND, UPSTREAM_5XX, PROXY_ERROR, TLS_ERROR, SSL_EXPIRED, CONNECTION_RESET, ERROR
@@ -2393,7 +2393,7 @@ export const CampaignsApiFp = function(configuration?: Configuration) {
          * @param {string} campaignId 
          * @param {number} [limit] 
          * @param {number} [offset] 
-         * @param {CampaignsDomainsListDnsStatusEnum} [dnsStatus] Filter domains whose authoritative DNS status matches (pending|ok|error|timeout)
+         * @param {CampaignsDomainsListDnsStatusEnum} [dnsStatus] Filter domains whose authoritative DNS status matches (pending|ok|error|timeout|wildcard)
          * @param {CampaignsDomainsListHttpStatusEnum} [httpStatus] Filter domains whose authoritative HTTP status matches (pending|ok|error|timeout)
          * @param {string} [dnsReason] Filter domains by DNS reason (exact match). Example values: NXDOMAIN, SERVFAIL, REFUSED, NOANSWER, TIMEOUT, ERROR
          * @param {string} [httpReason] Filter domains by HTTP reason (exact match). Example values: TIMEOUT, NOT_FOUND, UPSTREAM_5XX, PROXY_ERROR, TLS_ERROR, SSL_EXPIRED, CONNECTION_RESET, ERROR
@@ -3013,7 +3013,7 @@ export const CampaignsApiFactory = function (configuration?: Configuration, base
          * @param {string} campaignId 
          * @param {number} [limit] 
          * @param {number} [offset] 
-         * @param {CampaignsDomainsListDnsStatusEnum} [dnsStatus] Filter domains whose authoritative DNS status matches (pending|ok|error|timeout)
+         * @param {CampaignsDomainsListDnsStatusEnum} [dnsStatus] Filter domains whose authoritative DNS status matches (pending|ok|error|timeout|wildcard)
          * @param {CampaignsDomainsListHttpStatusEnum} [httpStatus] Filter domains whose authoritative HTTP status matches (pending|ok|error|timeout)
          * @param {string} [dnsReason] Filter domains by DNS reason (exact match). Example values: NXDOMAIN, SERVFAIL, REFUSED, NOANSWER, TIMEOUT, ERROR
          * @param {string} [httpReason] Filter domains by HTTP reason (exact match). Example values: TIMEOUT, NOT_FOUND, UPSTREAM_5XX, PROXY_ERROR, TLS_ERROR, SSL_EXPIRED, CONNECTION_RESET, ERROR
@@ -3530,7 +3530,7 @@ export interface CampaignsApiInterface {
      * @param {string} campaignId 
      * @param {number} [limit] 
      * @param {number} [offset] 
-     * @param {CampaignsDomainsListDnsStatusEnum} [dnsStatus] Filter domains whose authoritative DNS status matches (pending|ok|error|timeout)
+     * @param {CampaignsDomainsListDnsStatusEnum} [dnsStatus] Filter domains whose authoritative DNS status matches (pending|ok|error|timeout|wildcard)
      * @param {CampaignsDomainsListHttpStatusEnum} [httpStatus] Filter domains whose authoritative HTTP status matches (pending|ok|error|timeout)
      * @param {string} [dnsReason] Filter domains by DNS reason (exact match). Example values: NXDOMAIN, SERVFAIL, REFUSED, NOANSWER, TIMEOUT, ERROR
      * @param {string} [httpReason] Filter domains by HTTP reason (exact match). Example values: TIMEOUT, NOT_FOUND, UPSTREAM_5XX, PROXY_ERROR, TLS_ERROR, SSL_EXPIRED, CONNECTION_RESET, ERROR
@@ -4073,7 +4073,7 @@ export class CampaignsApi extends BaseAPI implements CampaignsApiInterface {
      * @param {string} campaignId 
      * @param {number} [limit] 
      * @param {number} [offset] 
-     * @param {CampaignsDomainsListDnsStatusEnum} [dnsStatus] Filter domains whose authoritative DNS status matches (pending|ok|error|timeout)
+     * @param {CampaignsDomainsListDnsStatusEnum} [dnsStatus] Filter domains whose authoritative DNS status matches (pending|ok|error|timeout|wildcard)
      * @param {CampaignsDomainsListHttpStatusEnum} [httpStatus] Filter domains whose authoritative HTTP status matches (pending|ok|error|timeout)
      * @param {string} [dnsReason] Filter domains by DNS reason (exact match). Example values: NXDOMAIN, SERVFAIL, REFUSED, NOANSWER, TIMEOUT, ERROR
      * @param {string} [httpReason] Filter domains by HTTP reason (exact match). Example values: TIMEOUT, NOT_FOUND, UPSTREAM_5XX, PROXY_ERROR, TLS_ERROR, SSL_EXPIRED, CONNECTION_RESET, ERROR
@@ -4520,7 +4520,8 @@ export enum CampaignsDomainsListDnsStatusEnum {
     pending = 'pending',
     ok = 'ok',
     error = 'error',
-    timeout = 'timeout'
+    timeout = 'timeout',
+    wildcard = 'wildcard'
 }
 /**
   * @export